@@ -0,0 +1,136 @@
+// Command scrabbled-dawgcheck cross-validates a DAWG against the word list it
+// was built from and fuzzes its lookups, catching corruption in the binary
+// format
+//
+// Usage:
+//
+//	scrabbled-dawgcheck -words words.txt [-dawg words.dawg] [-lexicon NAME] [-fuzz N] [-seed N]
+//	scrabbled-dawgcheck -words words.txt -build words.dawg [-lexicon NAME]
+//
+// Every listed word must be found in the DAWG, every word the DAWG holds must
+// be listed, the header's word count must match, and random strings, half of
+// them listed words with one letter changed, added or dropped, must get the
+// same answer from both. Without -dawg the DAWG is built from the list and
+// round-tripped through the binary format in memory, which checks the builder
+// and encoder. With -build it is built, verified and then saved. The command
+// exits with status 1 if any fault is found.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"time"
+
+	"scrabbled/internal/dictionary"
+)
+
+// errFaults is returned when verification finds faults, which are printed
+var errFaults = errors.New("verification failed")
+
+// options holds the command-line settings
+type options struct {
+	wordsPath string
+	dawgPath  string
+	buildPath string
+	lexicon   string
+	fuzz      int
+	seed      int64
+}
+
+func main() {
+	var opts options
+	flag.StringVar(&opts.wordsPath, "words", "", "word list file, one word per line (required)")
+	flag.StringVar(&opts.dawgPath, "dawg", "", "DAWG file to check (default builds one from the list)")
+	flag.StringVar(&opts.buildPath, "build", "", "build a DAWG from the list and save it here once verified")
+	flag.StringVar(&opts.lexicon, "lexicon", "", "lexicon name for the list, such as CSW21 or NWL2020")
+	flag.IntVar(&opts.fuzz, "fuzz", 100000, "number of random lookups")
+	flag.Int64Var(&opts.seed, "seed", 0, "random seed (default from the clock)")
+	flag.Parse()
+
+	if err := run(opts, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "scrabbled-dawgcheck:", err)
+		os.Exit(1)
+	}
+}
+
+// run loads or builds the DAWG, verifies it and prints the report
+func run(opts options, w io.Writer) error {
+	if opts.wordsPath == "" {
+		return errors.New("a word list is required (-words)")
+	}
+	if opts.dawgPath != "" && opts.buildPath != "" {
+		return errors.New("give at most one of -dawg and -build")
+	}
+	if opts.fuzz < 0 {
+		return fmt.Errorf("-fuzz must not be negative, got %d", opts.fuzz)
+	}
+	if opts.seed == 0 {
+		opts.seed = time.Now().UnixNano()
+	}
+
+	wl, err := dictionary.LoadFile(dictionary.ParseLexicon(opts.lexicon), opts.wordsPath)
+	if err != nil {
+		return err
+	}
+	d, name, err := loadDAWG(opts, wl)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	fmt.Fprintf(w, "%s: %s, %d words\n", name, d.Lexicon(), d.Size())
+	if d.Lexicon() != wl.Lexicon() {
+		fmt.Fprintf(w, "warning: the word list is %s\n", wl.Lexicon())
+	}
+	v := dictionary.VerifyDAWG(d, wl, opts.fuzz, rand.New(rand.NewSource(opts.seed)))
+	fmt.Fprintf(w, "listed %d, walked %d, fuzzed %d (seed %d)\n", v.Listed, v.Walked, v.Fuzzed, opts.seed)
+	printExamples(w, "missing", v.Missing)
+	printExamples(w, "phantom", v.Phantoms)
+	printExamples(w, "disagreed", v.Disagreed)
+	printExamples(w, "problem", v.Problems)
+	if !v.OK() {
+		fmt.Fprintf(w, "FAILED with %d faults\n", v.Faults)
+		return errFaults
+	}
+	fmt.Fprintln(w, "OK")
+
+	if opts.buildPath != "" {
+		if err := dictionary.SaveDAWG(opts.buildPath, d); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "saved %s\n", opts.buildPath)
+	}
+	return nil
+}
+
+// loadDAWG opens the DAWG file, or builds one from the word list and
+// round-trips it through the binary format
+func loadDAWG(opts options, wl *dictionary.WordList) (*dictionary.DAWG, string, error) {
+	if opts.dawgPath != "" {
+		d, err := dictionary.LoadDAWG(opts.dawgPath)
+		return d, opts.dawgPath, err
+	}
+
+	built, err := dictionary.BuildDAWGFromWordList(wl)
+	if err != nil {
+		return nil, "", err
+	}
+	var buf bytes.Buffer
+	if _, err := built.WriteTo(&buf); err != nil {
+		return nil, "", err
+	}
+	d, err := dictionary.ParseDAWG(buf.Bytes())
+	return d, "built from " + opts.wordsPath, err
+}
+
+// printExamples prints one line per example of a kind of fault
+func printExamples(w io.Writer, kind string, examples []string) {
+	for _, e := range examples {
+		fmt.Fprintf(w, "%s %s\n", kind, e)
+	}
+}
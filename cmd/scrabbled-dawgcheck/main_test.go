@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"scrabbled/internal/dictionary"
+)
+
+// writeFile creates a file in a temporary directory and returns its path
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+// check runs the command and returns its output
+func check(t *testing.T, opts options) (string, error) {
+	t.Helper()
+	if opts.wordsPath == "" {
+		opts.wordsPath = writeFile(t, "words.txt", "CAT\nCATS\nCAST\nDOG\nDOGS\nA\nAB\n")
+	}
+	opts.seed = 1
+	var out bytes.Buffer
+	err := run(opts, &out)
+	return out.String(), err
+}
+
+// TestBuildAndCheck tests building, saving and then checking a DAWG file
+func TestBuildAndCheck(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "words.dawg")
+	out, err := check(t, options{buildPath: path, lexicon: "csw21", fuzz: 500})
+	if err != nil {
+		t.Fatalf("run failed: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "listed 7, walked 7, fuzzed 500") || !strings.Contains(out, "OK\nsaved ") {
+		t.Errorf("Should verify then save, got:\n%s", out)
+	}
+
+	out, err = check(t, options{dawgPath: path, lexicon: "csw21", fuzz: 500})
+	if err != nil || !strings.HasPrefix(out, path+": CSW, 7 words\n") {
+		t.Errorf("The saved DAWG should verify, got %v:\n%s", err, out)
+	}
+	out, _ = check(t, options{dawgPath: path})
+	if !strings.Contains(out, "warning: the word list is CUSTOM") {
+		t.Errorf("Should warn of a lexicon mismatch, got:\n%s", out)
+	}
+}
+
+// TestCheckCorrupt tests reporting a DAWG that drops a word
+func TestCheckCorrupt(t *testing.T) {
+	d, _ := dictionary.BuildDAWG(dictionary.Custom, []string{"CAT", "CATS", "DOG", "DOGS"})
+	var buf bytes.Buffer
+	d.WriteTo(&buf)
+	data := buf.Bytes()
+	// Header, lexicon name, then the root's first edge: clear its child so
+	// every word starting with C is lost
+	off := 20 + len("CUSTOM") + 4
+	binary.LittleEndian.PutUint32(data[off:], binary.LittleEndian.Uint32(data[off:])&3)
+	path := writeFile(t, "bad.dawg", string(data))
+
+	out, err := check(t, options{
+		dawgPath:  path,
+		wordsPath: writeFile(t, "words.txt", "CAT\nCATS\nDOG\nDOGS\n"),
+		fuzz:      100,
+	})
+	if !errors.Is(err, errFaults) {
+		t.Fatalf("Expected errFaults, got %v", err)
+	}
+	for _, want := range []string{"missing CAT\n", "missing CATS\n", "problem header counts 4 words, graph holds 2\n", "FAILED"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Output should contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+// TestRunErrors tests rejection of bad settings
+func TestRunErrors(t *testing.T) {
+	words := writeFile(t, "words.txt", "CAT\n")
+	for name, opts := range map[string]options{
+		"no words":     {},
+		"dawg & build": {wordsPath: words, dawgPath: "a", buildPath: "b"},
+		"negative":     {wordsPath: words, fuzz: -1},
+		"missing dawg": {wordsPath: words, dawgPath: filepath.Join(t.TempDir(), "none.dawg")},
+	} {
+		if err := run(opts, &bytes.Buffer{}); err == nil {
+			t.Errorf("%s: should fail", name)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Command scrabbled-drill trains challenge judgement by asking whether words
+// are valid, mixing words from a word list with phonies made from them
+//
+// Usage:
+//
+//	scrabbled-drill -words words.txt [-lexicon NAME] [-n N] [-phonies RATE] [-min N] [-max N] [-seed N]
+//
+// Answer each word with y if it is valid or n if it is a phony; q ends the
+// drill early. Wrong answers are corrected as they are given, and the drill
+// ends with the player's accuracy, phony detection rate and average response
+// time.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"scrabbled/internal/dictionary"
+	"scrabbled/internal/drill"
+)
+
+// options holds the command-line settings
+type options struct {
+	wordsPath string
+	lexicon   string
+	drill     drill.Options
+	seed      int64
+}
+
+func main() {
+	opts := options{drill: drill.DefaultOptions()}
+	flag.StringVar(&opts.wordsPath, "words", "", "word list file, one word per line (required)")
+	flag.StringVar(&opts.lexicon, "lexicon", "", "lexicon name for the list, such as CSW21 or NWL2020")
+	flag.IntVar(&opts.drill.Questions, "n", drill.DefaultQuestions, "number of words to ask")
+	flag.Float64Var(&opts.drill.PhonyRate, "phonies", drill.DefaultPhonyRate, "fraction of the words that are phonies")
+	flag.IntVar(&opts.drill.MinLength, "min", drill.DefaultMinLength, "shortest word to ask")
+	flag.IntVar(&opts.drill.MaxLength, "max", drill.DefaultMaxLength, "longest word to ask")
+	flag.Int64Var(&opts.seed, "seed", 0, "random seed (default from the clock)")
+	flag.Parse()
+
+	if err := run(opts, os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "scrabbled-drill:", err)
+		os.Exit(1)
+	}
+}
+
+// run deals the drill, puts each word to the player and prints the summary
+func run(opts options, in io.Reader, w io.Writer) error {
+	if opts.wordsPath == "" {
+		return errors.New("a word list is required (-words)")
+	}
+	if opts.seed == 0 {
+		opts.seed = time.Now().UnixNano()
+	}
+
+	wl, err := dictionary.LoadFile(dictionary.ParseLexicon(opts.lexicon), opts.wordsPath)
+	if err != nil {
+		return err
+	}
+	d, err := drill.New("", wl, opts.drill, opts.seed)
+	if err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(in)
+	for {
+		q, ok := d.Next()
+		if !ok {
+			break
+		}
+		fmt.Fprintf(w, "%d. %s? ", q.Number, q.Word)
+		valid, ok := readAnswer(scanner, w)
+		if !ok {
+			fmt.Fprintln(w)
+			break
+		}
+		a, err := d.Answer(valid)
+		if err != nil {
+			return err
+		}
+		printAnswer(w, a)
+	}
+	printStats(w, d.Stats())
+	return nil
+}
+
+// readAnswer reads y or n, asking again on anything else; returns false at
+// the end of input or on q
+func readAnswer(scanner *bufio.Scanner, w io.Writer) (bool, bool) {
+	for scanner.Scan() {
+		switch strings.ToLower(strings.TrimSpace(scanner.Text())) {
+		case "y", "yes":
+			return true, true
+		case "n", "no":
+			return false, true
+		case "q", "quit":
+			return false, false
+		default:
+			fmt.Fprint(w, "y, n or q? ")
+		}
+	}
+	return false, false
+}
+
+// printAnswer reports whether the player ruled correctly
+func printAnswer(w io.Writer, a drill.Answer) {
+	switch {
+	case a.Correct && a.Valid && a.Definition != "":
+		fmt.Fprintf(w, "right: %s\n", a.Definition)
+	case a.Correct:
+		fmt.Fprintln(w, "right")
+	case a.Valid:
+		fmt.Fprintf(w, "wrong, %s is valid\n", a.Word)
+	default:
+		fmt.Fprintf(w, "wrong, %s is a phony\n", a.Word)
+	}
+}
+
+// printStats prints the summary of the drill
+func printStats(w io.Writer, s drill.Stats) {
+	fmt.Fprintf(w, "%d/%d right (%.0f%%), caught %d of %d phonies, doubted %d valid words, average %s\n",
+		s.Correct, s.Answered, 100*s.Accuracy(),
+		s.PhoniesCaught, s.PhoniesCaught+s.PhoniesMissed, s.WordsDoubted,
+		s.AverageTime().Round(time.Millisecond))
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"scrabbled/internal/drill"
+)
+
+// drillWith runs a drill over a small word list, answering from input
+func drillWith(t *testing.T, opts drill.Options, input string) (string, error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "words.txt")
+	if err := os.WriteFile(path, []byte("CAT a small feline\nDOG\nTEA\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write words: %v", err)
+	}
+	var out bytes.Buffer
+	err := run(options{wordsPath: path, drill: opts, seed: 1}, strings.NewReader(input), &out)
+	return out.String(), err
+}
+
+// TestRunValidWords tests answering a drill of valid words
+func TestRunValidWords(t *testing.T) {
+	opts := drill.Options{Questions: 2, PhonyRate: 0, MinLength: 3, MaxLength: 3}
+	out, err := drillWith(t, opts, "maybe\ny\nn\n")
+	if err != nil {
+		t.Fatalf("run failed: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "1. ") || !strings.Contains(out, "y, n or q? ") || !strings.Contains(out, "wrong, ") {
+		t.Errorf("Should ask again and correct the wrong answer, got:\n%s", out)
+	}
+	if !strings.Contains(out, "1/2 right (50%), caught 0 of 0 phonies, doubted 1 valid words") {
+		t.Errorf("Unexpected summary:\n%s", out)
+	}
+}
+
+// TestRunPhonies tests catching phonies and quitting early
+func TestRunPhonies(t *testing.T) {
+	opts := drill.Options{Questions: 3, PhonyRate: 1, MinLength: 3, MaxLength: 6}
+	out, err := drillWith(t, opts, "n\nq\n")
+	if err != nil {
+		t.Fatalf("run failed: %v\n%s", err, out)
+	}
+	if strings.Contains(out, "3. ") || !strings.Contains(out, "1/1 right (100%), caught 1 of 1 phonies") {
+		t.Errorf("Should catch one phony then stop, got:\n%s", out)
+	}
+}
+
+// TestRunErrors tests rejecting a missing word list and bad drill options
+func TestRunErrors(t *testing.T) {
+	if err := run(options{drill: drill.DefaultOptions()}, strings.NewReader(""), &bytes.Buffer{}); err == nil {
+		t.Errorf("Should require a word list")
+	}
+	if _, err := drillWith(t, drill.Options{Questions: 1, PhonyRate: 0, MinLength: 9, MaxLength: 9}, ""); err == nil {
+		t.Errorf("Should fail when no words fit")
+	}
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"scrabbled/internal/game"
+)
+
+// emptyBoard is the board field of position notation for an empty board
+const emptyBoard = "15/15/15/15/15/15/15/15/15/15/15/15/15/15/15"
+
+// simBatch is the number of continuations simulated per candidate between
+// checks of the time limit
+const simBatch = 10
+
+// engine holds the position being analysed and answers protocol commands
+type engine struct {
+	generator *game.MoveGenerator
+	seed      int64
+	pos       *game.GamePosition
+	out       io.Writer
+}
+
+// newEngine creates an engine set up on an empty classic board
+func newEngine(generator *game.MoveGenerator, seed int64, out io.Writer) (*engine, error) {
+	e := &engine{generator: generator, seed: seed, out: out}
+	if err := e.setPosition("startpos"); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// handle runs one command line, returning false once the engine should stop
+func (e *engine) handle(line string) (bool, error) {
+	cmd, args, _ := strings.Cut(strings.TrimSpace(line), " ")
+	args = strings.TrimSpace(args)
+
+	switch cmd {
+	case "":
+	case "engine":
+		fmt.Fprintln(e.out, "id name scrabbled-engine")
+		fmt.Fprintln(e.out, "engineok")
+	case "isready":
+		fmt.Fprintln(e.out, "readyok")
+	case "newgame":
+		return true, e.setPosition("startpos")
+	case "position":
+		return true, e.setPosition(args)
+	case "rack":
+		rack, err := game.ParseRack(args, e.pos.Board.Variant)
+		if err != nil {
+			return true, err
+		}
+		e.pos.Racks[e.pos.Turn] = rack
+	case "go":
+		s, err := parseSearch(args)
+		if err != nil {
+			return true, err
+		}
+		return true, e.search(s)
+	case "quit":
+		return false, nil
+	default:
+		return true, fmt.Errorf("unknown command %q", cmd)
+	}
+	return true, nil
+}
+
+// setPosition replaces the position with "startpos [VARIANT]" or a position
+// in single-line notation
+func (e *engine) setPosition(args string) error {
+	if args == "" {
+		return errors.New("position needs startpos or a position in notation")
+	}
+	if rest, ok := strings.CutPrefix(args, "startpos"); ok {
+		args = emptyBoard + " -/- 0/0 0 " + strings.TrimSpace(rest)
+	}
+	pos, err := game.ParsePosition(strings.TrimSpace(args))
+	if err != nil {
+		return err
+	}
+	e.pos = pos
+	return nil
+}
+
+// searchLimits are the settings of a "go" command
+type searchLimits struct {
+	depth    int           // Plies simulated after each candidate (0 ranks by equity)
+	movetime time.Duration // Time to spend simulating (0 runs a fixed number of continuations)
+	multipv  int           // Plays to report
+}
+
+// parseSearch reads the name-value pairs of a "go" command
+func parseSearch(args string) (searchLimits, error) {
+	s := searchLimits{multipv: 1}
+	fields := strings.Fields(args)
+	if len(fields)%2 != 0 {
+		return s, fmt.Errorf("go settings come in pairs, got %q", args)
+	}
+	for i := 0; i < len(fields); i += 2 {
+		n, err := strconv.Atoi(fields[i+1])
+		if err != nil || n < 0 {
+			return s, fmt.Errorf("bad value %q for %s", fields[i+1], fields[i])
+		}
+		switch fields[i] {
+		case "depth":
+			s.depth = n
+		case "movetime":
+			s.movetime = time.Duration(n) * time.Millisecond
+		case "multipv":
+			s.multipv = max(n, 1)
+		default:
+			return s, fmt.Errorf("unknown go setting %q", fields[i])
+		}
+	}
+	return s, nil
+}
+
+// search finds the best plays for the player to move and reports them
+func (e *engine) search(s searchLimits) error {
+	rack := e.pos.Racks[e.pos.Turn]
+	if len(rack) == 0 {
+		return errors.New("the rack is unknown; set it with rack")
+	}
+
+	var best []game.Move
+	if s.depth == 0 {
+		ranked := game.NewLeaveEvaluator().Rank(rack, e.generator.GenerateMoves(e.pos.Board, rack))
+		for i, rm := range ranked {
+			if i == s.multipv {
+				break
+			}
+			fmt.Fprintf(e.out, "info multipv %d move %s score %d equity %.1f\n", i+1, rm.Move.Notation(), rm.Move.Score, rm.Equity)
+			best = append(best, rm.Move)
+		}
+	} else {
+		results, err := e.simulate(s)
+		if err != nil {
+			return err
+		}
+		for i, r := range results {
+			if i == s.multipv {
+				break
+			}
+			fmt.Fprintf(e.out, "info multipv %d move %s score %d win %.3f spread %.1f iterations %d\n",
+				i+1, r.Move.Notation(), r.Move.Score, r.WinProbability, r.AvgSpread, r.Iterations)
+			best = append(best, r.Move)
+		}
+	}
+
+	if len(best) == 0 {
+		fmt.Fprintln(e.out, "bestmove pass")
+		return nil
+	}
+	fmt.Fprintf(e.out, "bestmove %s\n", best[0].Notation())
+	return nil
+}
+
+// simulate ranks the best candidates by simulating depth plies after each,
+// in batches until movetime has passed if one is set
+func (e *engine) simulate(s searchLimits) ([]game.SimResult, error) {
+	g, err := game.NewGameFromPosition("engine", e.pos)
+	if err != nil {
+		return nil, err
+	}
+	playerID := g.Players[e.pos.Turn].ID
+	sim := game.NewSimulator(e.generator, e.seed)
+	sim.Plies = s.depth
+	candidates := max(s.multipv, game.DefaultHintCount)
+	if s.movetime == 0 {
+		return g.Simulate(playerID, sim, candidates)
+	}
+
+	sim.Iterations = simBatch
+	totals := make(map[string]*game.SimResult)
+	deadline := time.Now().Add(s.movetime)
+	for first := true; first || time.Now().Before(deadline); first = false {
+		batch, err := g.Simulate(playerID, sim, candidates)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range batch {
+			key := r.Move.Notation()
+			t, ok := totals[key]
+			if !ok {
+				t = &game.SimResult{Move: r.Move}
+				totals[key] = t
+			}
+			// Sums for now, averaged once the time is up
+			t.Iterations += r.Iterations
+			t.AvgSpread += r.AvgSpread * float64(r.Iterations)
+			t.WinProbability += r.WinProbability * float64(r.Iterations)
+		}
+	}
+
+	results := make([]game.SimResult, 0, len(totals))
+	for _, t := range totals {
+		t.AvgSpread /= float64(t.Iterations)
+		t.WinProbability /= float64(t.Iterations)
+		results = append(results, *t)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].WinProbability != results[j].WinProbability {
+			return results[i].WinProbability > results[j].WinProbability
+		}
+		if results[i].AvgSpread != results[j].AvgSpread {
+			return results[i].AvgSpread > results[j].AvgSpread
+		}
+		return results[i].Move.Notation() < results[j].Move.Notation()
+	})
+	return results, nil
+}
@@ -0,0 +1,89 @@
+// Command scrabbled-engine lets other programs drive the move engine as a
+// subprocess, over a line protocol on standard input and output modelled on
+// the chess UCI protocol
+//
+// Usage:
+//
+//	scrabbled-engine -dict words.txt [-seed 1]
+//
+// Commands, one per line:
+//
+//	engine                      identify; answered with "id name ..." and "engineok"
+//	isready                     answered with "readyok" once earlier commands are done
+//	newgame                     start again from an empty classic board
+//	position startpos [VARIANT] an empty board, optionally of another variant
+//	position NOTATION           a position in single-line position notation
+//	rack RACK                   the rack of the player to move, with ? for a blank
+//	go [depth N] [movetime MS] [multipv K]
+//	quit
+//
+// "go" searches the position and answers with one "info" line for each of the
+// K best plays, then "bestmove" with the best play in GCG notation (8D across,
+// D8 down, blanks in lower case) or "bestmove pass". Depth 0, the default,
+// ranks plays by equity; a positive depth simulates that many plies after
+// each candidate, for movetime milliseconds if given. A command that fails is
+// answered with "error" and a message, and the engine carries on.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"scrabbled/internal/dictionary"
+	"scrabbled/internal/game"
+)
+
+// options holds the command-line settings
+type options struct {
+	dictPath string
+	seed     int64
+}
+
+func main() {
+	var opts options
+	flag.StringVar(&opts.dictPath, "dict", "", "word list file, one word per line (required)")
+	flag.Int64Var(&opts.seed, "seed", 1, "seed for simulated continuations")
+	flag.Parse()
+
+	if err := run(opts, os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "scrabbled-engine:", err)
+		os.Exit(1)
+	}
+}
+
+// run loads the word list and answers commands from r until "quit" or the
+// end of the input
+func run(opts options, r io.Reader, w io.Writer) error {
+	if opts.dictPath == "" {
+		return errors.New("a word list is required (-dict)")
+	}
+	dict, err := dictionary.LoadFile(dictionary.Custom, opts.dictPath)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	e, err := newEngine(game.NewMoveGenerator(dict.Words()), opts.seed, bw)
+	if err != nil {
+		return err
+	}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		more, err := e.handle(scanner.Text())
+		if err != nil {
+			fmt.Fprintln(bw, "error", err)
+		}
+		// Answers go out as soon as each command is done
+		if err := bw.Flush(); err != nil {
+			return err
+		}
+		if !more {
+			return nil
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"scrabbled/internal/game"
+)
+
+// testWords is the small word list used by engine tests
+var testWords = []string{"AT", "TA", "AN", "NA", "IN", "IT", "TI", "TO", "ON", "NO", "DO", "GO", "OR", "RE", "ER", "ES",
+	"CAT", "CATS", "ACT", "SCAT", "DOG", "GOD", "DOGS", "EAT", "TEA", "ATE", "NET", "TEN", "RAT", "ART", "TAR", "RATE", "TEAR", "STAR"}
+
+// runScript runs the engine on the given command lines and returns its output
+func runScript(t *testing.T, lines ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "words.txt")
+	if err := os.WriteFile(path, []byte(strings.Join(testWords, "\n")), 0o644); err != nil {
+		t.Fatalf("Failed to write word list: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := run(options{dictPath: path, seed: 1}, strings.NewReader(strings.Join(lines, "\n")), &out); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	return out.String()
+}
+
+// TestProtocol tests the handshake and a search by equity
+func TestProtocol(t *testing.T) {
+	out := runScript(t, "engine", "isready", "position startpos", "rack CATXYZV", "go multipv 2", "quit", "isready")
+
+	want := "id name scrabbled-engine\nengineok\nreadyok\n" +
+		"info multipv 1 move 8F ACT score 10 equity "
+	if !strings.HasPrefix(out, want) {
+		t.Errorf("Output should start with\n%s\ngot\n%s", want, out)
+	}
+	if !strings.Contains(out, "info multipv 2 ") || !strings.HasSuffix(out, "bestmove 8F ACT\n") {
+		t.Errorf("Should report two plays and then the best, got\n%s", out)
+	}
+	if strings.Count(out, "readyok") != 1 {
+		t.Errorf("Commands after quit should be ignored, got\n%s", out)
+	}
+}
+
+// TestProtocolPositions tests positions in notation, passes and errors
+func TestProtocolPositions(t *testing.T) {
+	out := runScript(t,
+		"position 15/15/15/15/15/15/15/7CaT5/15/15/15/15/15/15/15 JKQXZ/DGO? 8/0 0",
+		"go",
+		"position 15/15/15/15/15/15/15/7CaT5/15/15/15/15/15/15/15 JKQXZ/DGO? 8/0 1",
+		"go",
+		"rack ZZZZ",
+		"go depth",
+		"go speed 3",
+		"position nowhere",
+		"position startpos CHESS",
+		"fly",
+	)
+
+	for _, want := range []string{
+		"bestmove pass\n",
+		"bestmove J8 TO\n",
+		"error unknown go setting \"speed\"",
+		"error go settings come in pairs",
+		"error unknown command \"fly\"",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Output should contain %q, got\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "error ") != 5 {
+		t.Errorf("Should report five errors, got\n%s", out)
+	}
+}
+
+// TestSimulatedSearch tests searching by simulation with and without a time limit
+func TestSimulatedSearch(t *testing.T) {
+	out := runScript(t, "position startpos", "rack CATSDOG", "go depth 1 multipv 3")
+	if strings.Count(out, "info multipv") != 3 || !strings.Contains(out, " win ") || !strings.Contains(out, "iterations 100") {
+		t.Errorf("Should report three simulated plays, got\n%s", out)
+	}
+
+	mg := game.NewMoveGenerator(testWords)
+	var buf bytes.Buffer
+	e, err := newEngine(mg, 1, &buf)
+	if err != nil {
+		t.Fatalf("newEngine failed: %v", err)
+	}
+	e.handle("rack CATSDOG")
+	start := time.Now()
+	if _, err := e.handle("go depth 1 movetime 50"); err != nil {
+		t.Fatalf("go failed: %v", err)
+	}
+	if time.Since(start) < 50*time.Millisecond || !strings.Contains(buf.String(), "bestmove ") {
+		t.Errorf("Should simulate for the time given, got\n%s", buf.String())
+	}
+	// A batch can end just as the default iteration count is reached, so
+	// check for whole batches rather than for a count other than the default
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if fields := strings.Fields(line); len(fields) > 0 && fields[0] == "info" {
+			n, err := strconv.Atoi(fields[len(fields)-1])
+			if err != nil || n == 0 || n%simBatch != 0 {
+				t.Errorf("Timed searches should run in batches of %d, got %q", simBatch, line)
+			}
+		}
+	}
+}
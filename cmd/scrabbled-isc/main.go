@@ -0,0 +1,158 @@
+// Command scrabbled-isc serves games over a line-based text protocol in the
+// style of the Internet Scrabble Club's telnet interface, so that terminal
+// and scripted clients can find opponents and play
+//
+// Usage:
+//
+//	scrabbled-isc [-addr localhost:1330] [-dict words.txt] [-challenge void|double]
+//	              [-abandon 10m] [-secret-file key]
+//
+// The protocol is a documented subset in the ISC style rather than a
+// reimplementation of ISC's own. Commands, one per line, with keywords in
+// any case:
+//
+//	LOGIN NAME [PASSWORD]  sign in; with -secret-file the password must be a
+//	                       token issued to NAME
+//	WHO                    list signed-in players
+//	MATCH NAME             offer NAME a game
+//	ACCEPT NAME            accept NAME's offer; NAME moves first
+//	DECLINE NAME           decline NAME's offer
+//	PLAY COORDS WORD       play in GCG notation: 8D CAT across, D8 CAT down,
+//	                       blanks in lower case, letters on the board included
+//	SAY PLAY               play as spoken, such as "CAT at H8 across" or
+//	                       "8G QUA, blank as U", for voice clients
+//	CHANGE LETTERS         exchange tiles, with ? for a blank
+//	PASS
+//	CHALLENGE              challenge the last play
+//	RESIGN
+//	BOARD                  show the board
+//	DESCRIBE [SQUARE]      describe the board, or one square such as H8, in
+//	                       words for screen readers
+//	RACK                   show your rack
+//	SCORE                  show the scores
+//	TELL NAME MESSAGE      send NAME a message
+//	QUIT
+//
+// Each command is answered with "OK COMMAND" or "ERROR message", after any
+// lines it produces. Lines sent as they happen:
+//
+//	WHO NAME IDLE|PLAYING              answer to WHO, one per player
+//	BOARD ROW                          answer to BOARD, one per row
+//	DESCRIBE SENTENCE                  answer to DESCRIBE, one per line
+//	MATCH NAME                         NAME offers you a game
+//	DECLINE NAME                       NAME declined your offer
+//	GAME ID FIRST SECOND               a game started or was resumed
+//	RACK LETTERS                       your rack after each turn
+//	TURN NAME                          NAME is to move
+//	MOVE NAME COORDS WORD SCORE TOTAL  NAME played
+//	CHANGE NAME COUNT                  NAME exchanged COUNT tiles
+//	PASS NAME                          NAME passed
+//	CHALLENGE NAME VALID|PHONY         NAME challenged the last play
+//	RESIGN NAME                        NAME resigned
+//	ABANDON NAME                       NAME forfeited by staying away
+//	SCORE NAME SCORE ...               the scores in seating order
+//	GAMEOVER NAME SCORE ...            the final scores
+//	TELL NAME MESSAGE                  NAME sent you a message
+//
+// Lines end with CRLF, as telnet clients expect. A player who disconnects
+// may sign in again to resume their game; with -abandon, one who stays away
+// that long on their turn forfeits.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"scrabbled/internal/auth"
+	"scrabbled/internal/dictionary"
+	"scrabbled/internal/game"
+)
+
+// sweepInterval is how often games are checked for absent players
+const sweepInterval = 10 * time.Second
+
+// options holds the command-line settings
+type options struct {
+	addr       string
+	dictPath   string
+	challenge  string
+	abandon    time.Duration
+	secretPath string
+}
+
+func main() {
+	var opts options
+	flag.StringVar(&opts.addr, "addr", "localhost:1330", "address to listen on")
+	flag.StringVar(&opts.dictPath, "dict", "", "word list file, one word per line (default accepts any word)")
+	flag.StringVar(&opts.challenge, "challenge", "void", "challenge rule: void or double")
+	flag.DurationVar(&opts.abandon, "abandon", 0, "time on one turn after which the player to move forfeits (0 never)")
+	flag.StringVar(&opts.secretPath, "secret-file", "", "token signing key; when set, passwords must be tokens")
+	flag.Parse()
+
+	s, err := newServer(opts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "scrabbled-isc:", err)
+		os.Exit(1)
+	}
+	ln, err := net.Listen("tcp", opts.addr)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "scrabbled-isc:", err)
+		os.Exit(1)
+	}
+	log.Printf("listening on %s", ln.Addr())
+
+	if opts.abandon > 0 {
+		go func() {
+			for range time.Tick(sweepInterval) {
+				s.sweep()
+			}
+		}()
+	}
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			log.Fatal(err)
+		}
+		go s.serve(conn)
+	}
+}
+
+// newServer builds a server from the options
+func newServer(opts options) (*server, error) {
+	rules := game.DefaultRules()
+	rules.AbandonAfter = opts.abandon
+	switch strings.ToLower(opts.challenge) {
+	case "void":
+	case "double":
+		rules.ChallengeRule = game.DoubleChallenge
+	default:
+		return nil, fmt.Errorf("unknown challenge rule %q", opts.challenge)
+	}
+	if opts.dictPath != "" {
+		dict, err := dictionary.LoadFile(dictionary.Custom, opts.dictPath)
+		if err != nil {
+			return nil, err
+		}
+		rules.Dictionary = dict
+	}
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+
+	var issuer *auth.Issuer
+	if opts.secretPath != "" {
+		secret, err := os.ReadFile(opts.secretPath)
+		if err != nil {
+			return nil, err
+		}
+		if issuer, err = auth.NewIssuer(secret); err != nil {
+			return nil, err
+		}
+	}
+	return newServerWithRules(rules, issuer), nil
+}
@@ -0,0 +1,338 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"scrabbled/internal/auth"
+	"scrabbled/internal/game"
+)
+
+// client is a test connection to a server
+type client struct {
+	t     *testing.T
+	conn  net.Conn
+	lines chan string
+}
+
+// connect opens a connection to the server and reads its greeting
+func connect(t *testing.T, s *server) *client {
+	t.Helper()
+
+	server, conn := net.Pipe()
+	go s.serve(server)
+	c := &client{t: t, conn: conn, lines: make(chan string, 100)}
+	// Read continuously, so lines sent to one client never hold up another
+	go func() {
+		defer close(c.lines)
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			c.lines <- scanner.Text()
+		}
+	}()
+	t.Cleanup(func() { conn.Close() })
+	c.expect("WELCOME")
+	return c
+}
+
+// login connects and signs in as name
+func login(t *testing.T, s *server, name string) *client {
+	t.Helper()
+
+	c := connect(t, s)
+	c.send("LOGIN " + name)
+	c.expect("OK LOGIN")
+	return c
+}
+
+// send writes a command line
+func (c *client) send(line string) {
+	c.t.Helper()
+	if _, err := fmt.Fprintf(c.conn, "%s\r\n", line); err != nil {
+		c.t.Fatalf("Failed to send %q: %v", line, err)
+	}
+}
+
+// expect skips lines until one starts with prefix and returns it
+func (c *client) expect(prefix string) string {
+	c.t.Helper()
+	for {
+		select {
+		case line, ok := <-c.lines:
+			if !ok {
+				c.t.Fatalf("Connection closed waiting for %q", prefix)
+			}
+			if strings.HasPrefix(line, prefix) {
+				return line
+			}
+			if strings.HasPrefix(line, "ERROR") {
+				c.t.Fatalf("Got %q waiting for %q", line, prefix)
+			}
+		case <-time.After(2 * time.Second):
+			c.t.Fatalf("Timed out waiting for %q", prefix)
+		}
+	}
+}
+
+// startGame signs in alice and bob and has bob accept alice's offer
+func startGame(t *testing.T, s *server) (alice, bob *client) {
+	t.Helper()
+
+	alice, bob = login(t, s, "alice"), login(t, s, "bob")
+	alice.send("MATCH Bob")
+	alice.expect("OK MATCH")
+	bob.expect("MATCH alice")
+	bob.send("ACCEPT alice")
+	for _, c := range []*client{alice, bob} {
+		if got := c.expect("GAME"); got != "GAME isc-1 alice bob" {
+			t.Fatalf("Expected the game to start with alice first, got %q", got)
+		}
+		c.expect("TURN alice")
+	}
+	bob.expect("OK ACCEPT")
+	return alice, bob
+}
+
+// setRacks deals fixed racks in seating order
+func setRacks(t *testing.T, s *server, racks ...string) {
+	t.Helper()
+
+	err := s.manager.Do("isc-1", func(g *game.Game) error {
+		for i, letters := range racks {
+			rack, err := game.ParseRack(letters, game.Classic)
+			if err != nil {
+				return err
+			}
+			g.Players[i].Rack = rack
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to set racks: %v", err)
+	}
+}
+
+// TestLogin tests signing in and listing players
+func TestLogin(t *testing.T) {
+	s := newServerWithRules(game.DefaultRules(), nil)
+
+	c := connect(t, s)
+	c.send("WHO")
+	if got := c.expect("ERROR"); !strings.Contains(got, "LOGIN") {
+		t.Errorf("Commands before signing in should be refused, got %q", got)
+	}
+	c.send("login Alice")
+	c.expect("OK LOGIN")
+
+	other := connect(t, s)
+	other.send("LOGIN alice")
+	other.expect("ERROR")
+	other.send("LOGIN bob")
+	other.expect("OK LOGIN")
+
+	c.send("WHO")
+	if got := c.expect("WHO"); got != "WHO Alice IDLE" {
+		t.Errorf("Expected Alice first, got %q", got)
+	}
+	if got := c.expect("WHO"); got != "WHO bob IDLE" {
+		t.Errorf("Expected bob second, got %q", got)
+	}
+	c.expect("OK WHO")
+
+	c.send("TELL bob good luck")
+	c.expect("OK TELL")
+	if got := other.expect("TELL"); got != "TELL Alice good luck" {
+		t.Errorf("Unexpected message %q", got)
+	}
+	c.send("FROB")
+	c.expect("ERROR unknown command FROB")
+	c.send("QUIT")
+	c.expect("OK QUIT")
+}
+
+// TestPlayGame tests a game from the offer to a resignation
+func TestPlayGame(t *testing.T) {
+	s := newServerWithRules(game.DefaultRules(), nil)
+	alice, bob := startGame(t, s)
+	setRacks(t, s, "CATSXYZ", "QVWEEEE")
+
+	bob.send("PASS")
+	bob.expect("ERROR")
+
+	alice.send("PLAY 8G CAT")
+	for _, c := range []*client{alice, bob} {
+		if got := c.expect("MOVE"); got != "MOVE alice 8G CAT 10 10" {
+			t.Errorf("Unexpected move line %q", got)
+		}
+		c.expect("TURN bob")
+	}
+	alice.expect("OK PLAY")
+
+	bob.send("CHANGE QV")
+	alice.expect("CHANGE bob 2")
+	bob.expect("OK CHANGE")
+
+	alice.send("BOARD")
+	if got := alice.expect("BOARD  8"); !strings.Contains(got, "C  A  T") {
+		t.Errorf("The board should show CAT, got %q", got)
+	}
+	alice.send("DESCRIBE")
+	if got := alice.expect("DESCRIBE"); got != "DESCRIBE Row 8: G8 C, H8 A, I8 T" {
+		t.Errorf("Unexpected board description %q", got)
+	}
+	alice.expect("DESCRIBE Hotspots open at")
+	alice.expect("OK DESCRIBE")
+	alice.send("DESCRIBE d-8")
+	alice.expect("DESCRIBE D8: empty, double letter")
+	alice.send("DESCRIBE Z99")
+	alice.expect("ERROR invalid position")
+	alice.send("SCORE")
+	if got := alice.expect("SCORE"); got != "SCORE alice 10 bob 0" {
+		t.Errorf("Unexpected scores %q", got)
+	}
+
+	bob.send("RESIGN")
+	for _, c := range []*client{alice, bob} {
+		c.expect("RESIGN bob")
+		if got := c.expect("GAMEOVER"); got != "GAMEOVER alice 10 bob 0" {
+			t.Errorf("Unexpected final scores %q", got)
+		}
+	}
+	bob.expect("OK RESIGN")
+
+	alice.send("PASS")
+	alice.expect("ERROR you are not in a game")
+	alice.send("WHO")
+	alice.expect("WHO alice IDLE")
+	if s.manager.Len() != 0 {
+		t.Errorf("Finished games should be removed from the manager")
+	}
+}
+
+// TestSay tests playing a move as spoken
+func TestSay(t *testing.T) {
+	s := newServerWithRules(game.DefaultRules(), nil)
+	alice, bob := startGame(t, s)
+	setRacks(t, s, "CATSXYZ", "QVWEEEE")
+
+	alice.send("SAY cat at hotel eight across")
+	if got := alice.expect("MOVE"); got != "MOVE alice 8H CAT 10 10" {
+		t.Errorf("Unexpected move line %q", got)
+	}
+	alice.expect("OK SAY")
+
+	bob.send("SAY something about cats")
+	bob.expect("ERROR unrecognized play")
+}
+
+// TestDecline tests turning down an offer
+func TestDecline(t *testing.T) {
+	s := newServerWithRules(game.DefaultRules(), nil)
+	alice, bob := login(t, s, "alice"), login(t, s, "bob")
+
+	bob.send("ACCEPT alice")
+	bob.expect("ERROR alice has not offered you a game")
+	alice.send("MATCH alice")
+	alice.expect("ERROR")
+	alice.send("MATCH bob")
+	bob.expect("MATCH alice")
+	bob.send("DECLINE alice")
+	alice.expect("DECLINE bob")
+	bob.expect("OK DECLINE")
+}
+
+// TestResume tests signing in again to a game left behind
+func TestResume(t *testing.T) {
+	s := newServerWithRules(game.DefaultRules(), nil)
+	alice, bob := startGame(t, s)
+
+	alice.conn.Close()
+	// Wait for the server to sign alice out
+	for s.session("alice") != nil {
+		time.Sleep(time.Millisecond)
+	}
+	bob.send("WHO")
+	if got := bob.expect("WHO"); got != "WHO bob PLAYING" {
+		t.Errorf("Only bob should be signed in, got %q", got)
+	}
+
+	again := connect(t, s)
+	again.send("LOGIN alice")
+	if got := again.expect("GAME"); got != "GAME isc-1 alice bob" {
+		t.Errorf("Signing in should resume the game, got %q", got)
+	}
+	again.expect("RACK")
+	again.expect("TURN alice")
+	again.expect("OK LOGIN")
+}
+
+// TestSweep tests forfeiting a player who stays away on their turn
+func TestSweep(t *testing.T) {
+	rules := game.DefaultRules()
+	rules.AbandonAfter = time.Minute
+	s := newServerWithRules(rules, nil)
+	alice, bob := startGame(t, s)
+
+	s.sweep()
+	// Shorten the wait so alice's turn has already run over
+	s.manager.Do("isc-1", func(g *game.Game) error {
+		g.AbandonAfter = time.Nanosecond
+		return nil
+	})
+	s.sweep()
+	for _, c := range []*client{alice, bob} {
+		c.expect("ABANDON alice")
+		c.expect("GAMEOVER")
+	}
+}
+
+// TestTokenLogin tests requiring tokens as passwords
+func TestTokenLogin(t *testing.T) {
+	issuer, err := auth.NewIssuer([]byte(strings.Repeat("k", auth.MinSecretLength)))
+	if err != nil {
+		t.Fatalf("NewIssuer failed: %v", err)
+	}
+	token, _ := issuer.Issue("player-1", "alice", "")
+	s := newServerWithRules(game.DefaultRules(), issuer)
+
+	c := connect(t, s)
+	c.send("LOGIN alice")
+	c.expect("ERROR a password is required")
+	c.send("LOGIN bob " + token)
+	c.expect("ERROR")
+	c.send("LOGIN alice " + token)
+	c.expect("OK LOGIN")
+	if s.session("player-1") == nil {
+		t.Errorf("The token's player ID should identify the session")
+	}
+}
+
+// TestNewServer tests building a server from the options
+func TestNewServer(t *testing.T) {
+	if _, err := newServer(options{challenge: "sometimes"}); err == nil {
+		t.Errorf("An unknown challenge rule should fail")
+	}
+	s, err := newServer(options{challenge: "DOUBLE", abandon: time.Minute})
+	if err != nil {
+		t.Fatalf("newServer failed: %v", err)
+	}
+	if s.rules.ChallengeRule != game.DoubleChallenge || s.rules.AbandonAfter != time.Minute {
+		t.Errorf("Unexpected rules %+v", s.rules)
+	}
+}
+
+// TestRackIndices tests finding exchanged letters on the rack
+func TestRackIndices(t *testing.T) {
+	rack, _ := game.ParseRack("AB?AC", game.Classic)
+	indices, err := rackIndices(rack, "a?a", game.Classic)
+	if err != nil || fmt.Sprint(indices) != "[0 2 3]" {
+		t.Errorf("Expected [0 2 3], got %v, %v", indices, err)
+	}
+	if _, err := rackIndices(rack, "BB", game.Classic); err == nil {
+		t.Errorf("Letters missing from the rack should fail")
+	}
+}
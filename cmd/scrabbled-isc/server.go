@@ -0,0 +1,568 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"scrabbled/internal/auth"
+	"scrabbled/internal/game"
+	"scrabbled/internal/i18n"
+)
+
+// Errors reported to clients
+var (
+	errNotSignedIn = errors.New("sign in first with LOGIN")
+	errNotPlaying  = errors.New("you are not in a game")
+	errNoSuchUser  = errors.New("no such player is signed in")
+)
+
+// server holds the signed-in players, their match offers and their games
+type server struct {
+	manager  *game.GameManager
+	rules    game.Rules
+	issuer   *auth.Issuer    // Verifies passwords as tokens (nil admits anyone)
+	loc      *i18n.Localizer // Writes board descriptions
+	mu       sync.Mutex
+	sessions map[string]*session   // Signed-in players by ID
+	games    map[string]*game.Game // Unfinished games by player ID
+	offers   map[[2]string]bool    // Match offers, from and to, by player ID
+	nextID   int                   // Number of the last game started
+}
+
+// session is one connected client
+type session struct {
+	id   string // Player ID, empty until signed in
+	name string
+	out  io.Writer
+	mu   sync.Mutex // Serializes lines sent from different connections
+}
+
+// newServerWithRules creates a server whose games are played under rules
+func newServerWithRules(rules game.Rules, issuer *auth.Issuer) *server {
+	return &server{
+		manager:  game.NewGameManager(1),
+		rules:    rules,
+		issuer:   issuer,
+		loc:      i18n.NewLocalizer(),
+		sessions: make(map[string]*session),
+		games:    make(map[string]*game.Game),
+		offers:   make(map[[2]string]bool),
+	}
+}
+
+// send writes one line to the client, ignoring a closed connection
+func (c *session) send(format string, args ...any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	fmt.Fprintf(c.out, format+"\r\n", args...)
+}
+
+// serve answers a client's commands until it quits or disconnects
+func (s *server) serve(conn io.ReadWriteCloser) {
+	defer conn.Close()
+
+	c := &session{out: conn}
+	defer s.logout(c)
+	c.send("WELCOME scrabbled")
+
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		if !s.handle(c, scanner.Text()) {
+			return
+		}
+	}
+}
+
+// handle runs one command line, returning false once the client quits
+func (s *server) handle(c *session, line string) bool {
+	keyword, args, _ := strings.Cut(strings.TrimSpace(line), " ")
+	keyword, args = strings.ToUpper(keyword), strings.TrimSpace(args)
+	if keyword == "" {
+		return true
+	}
+
+	var err error
+	switch {
+	case keyword == "QUIT":
+		c.send("OK QUIT")
+		return false
+	case keyword == "LOGIN":
+		err = s.login(c, args)
+	case c.id == "":
+		err = errNotSignedIn
+	default:
+		err = s.command(c, keyword, args)
+	}
+	if err != nil {
+		c.send("ERROR %s", flatten(err.Error()))
+	} else {
+		c.send("OK %s", keyword)
+	}
+	return true
+}
+
+// command runs a command from a signed-in client
+func (s *server) command(c *session, keyword, args string) error {
+	switch keyword {
+	case "WHO":
+		s.who(c)
+	case "MATCH":
+		return s.match(c, args)
+	case "ACCEPT":
+		return s.accept(c, args)
+	case "DECLINE":
+		return s.decline(c, args)
+	case "TELL":
+		name, message, _ := strings.Cut(args, " ")
+		to := s.find(name)
+		if to == nil {
+			return errNoSuchUser
+		}
+		to.send("TELL %s %s", c.name, flatten(message))
+	case "PLAY":
+		coords, word, _ := strings.Cut(args, " ")
+		return s.play(c, func(g *game.Game) (game.Move, error) {
+			return g.ParsePlay(c.id, coords, strings.TrimSpace(word))
+		})
+	case "SAY":
+		return s.play(c, func(g *game.Game) (game.Move, error) {
+			return g.ParseSpokenPlay(c.id, args)
+		})
+	case "CHANGE":
+		return s.act(c, func(g *game.Game) error {
+			indices, err := rackIndices(g.GetPlayer(c.id).Rack, args, g.Board.Variant)
+			if err != nil {
+				return err
+			}
+			if err := g.ExchangeTiles(c.id, indices); err != nil {
+				return err
+			}
+			s.broadcast(g, "CHANGE %s %d", c.name, len(indices))
+			return nil
+		})
+	case "PASS":
+		return s.act(c, func(g *game.Game) error {
+			if err := g.PassTurn(c.id); err != nil {
+				return err
+			}
+			s.broadcast(g, "PASS %s", c.name)
+			return nil
+		})
+	case "CHALLENGE":
+		return s.act(c, func(g *game.Game) error {
+			result, err := g.Challenge(c.id)
+			if err != nil {
+				return err
+			}
+			verdict := "VALID"
+			if result.Successful {
+				verdict = "PHONY"
+			}
+			s.broadcast(g, "CHALLENGE %s %s", c.name, verdict)
+			return nil
+		})
+	case "RESIGN":
+		return s.act(c, func(g *game.Game) error {
+			if err := g.Resign(c.id); err != nil {
+				return err
+			}
+			s.broadcast(g, "RESIGN %s", c.name)
+			return nil
+		})
+	case "BOARD":
+		return s.show(c, func(g *game.Game) {
+			for _, row := range strings.Split(strings.TrimRight(g.Board.String(), "\n"), "\n") {
+				c.send("BOARD %s", row)
+			}
+		})
+	case "DESCRIBE":
+		return s.describe(c, args)
+	case "RACK":
+		return s.show(c, func(g *game.Game) {
+			c.send("RACK %s", rackLetters(g.GetPlayer(c.id).Rack))
+		})
+	case "SCORE":
+		return s.show(c, func(g *game.Game) {
+			c.send("SCORE %s", scores(g))
+		})
+	default:
+		return fmt.Errorf("unknown command %s", keyword)
+	}
+	return nil
+}
+
+// play makes the move parse reads on the client's game and announces it
+func (s *server) play(c *session, parse func(g *game.Game) (game.Move, error)) error {
+	return s.act(c, func(g *game.Game) error {
+		move, err := parse(g)
+		if err != nil {
+			return err
+		}
+		score, err := g.PlayMove(move)
+		if err != nil {
+			return err
+		}
+		s.broadcast(g, "MOVE %s %s %d %d", c.name, move.Notation(), score, g.GetPlayer(c.id).Score)
+		return nil
+	})
+}
+
+// describe sends the board, or one square of it, in words
+func (s *server) describe(c *session, args string) error {
+	if args == "" {
+		return s.show(c, func(g *game.Game) {
+			for _, line := range s.loc.Board(g.Board) {
+				c.send("DESCRIBE %s", line)
+			}
+		})
+	}
+	pos, _, err := game.ParseCoordinates(args)
+	if err != nil {
+		return err
+	}
+	return s.show(c, func(g *game.Game) {
+		c.send("DESCRIBE %s", s.loc.Square(g.Board, pos))
+	})
+}
+
+// login signs a client in as NAME, checking the password as a token when the
+// server has an issuer, and resumes any game they left
+func (s *server) login(c *session, args string) error {
+	if c.id != "" {
+		return fmt.Errorf("already signed in as %s", c.name)
+	}
+	fields := strings.Fields(args)
+	if len(fields) == 0 || len(fields) > 2 {
+		return errors.New("usage: LOGIN NAME [PASSWORD]")
+	}
+	name, id := fields[0], strings.ToLower(fields[0])
+	if s.issuer != nil {
+		if len(fields) < 2 {
+			return errors.New("a password is required")
+		}
+		claims, err := s.issuer.Verify(fields[1])
+		if err != nil {
+			return err
+		}
+		if !strings.EqualFold(claims.Name, name) {
+			return auth.ErrInvalidToken
+		}
+		id = claims.PlayerID
+	}
+
+	s.mu.Lock()
+	if _, ok := s.sessions[id]; ok {
+		s.mu.Unlock()
+		return fmt.Errorf("%s is already signed in", name)
+	}
+	c.id, c.name = id, name
+	s.sessions[id] = c
+	g := s.games[id]
+	s.mu.Unlock()
+
+	if g != nil {
+		s.manager.Do(g.ID, func(g *game.Game) error {
+			c.send("GAME %s %s", g.ID, playerNames(g))
+			c.send("RACK %s", rackLetters(g.GetPlayer(id).Rack))
+			if p := g.CurrentPlayer(); p != nil {
+				c.send("TURN %s", p.Name)
+			}
+			return nil
+		})
+	}
+	return nil
+}
+
+// logout signs a client out, withdrawing their match offers; their game
+// waits for them to sign in again
+func (s *server) logout(c *session) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c.id == "" {
+		return
+	}
+	delete(s.sessions, c.id)
+	for offer := range s.offers {
+		if offer[0] == c.id || offer[1] == c.id {
+			delete(s.offers, offer)
+		}
+	}
+}
+
+// who lists the signed-in players by name
+func (s *server) who(c *session) {
+	s.mu.Lock()
+	var lines []string
+	for id, other := range s.sessions {
+		status := "IDLE"
+		if s.games[id] != nil {
+			status = "PLAYING"
+		}
+		lines = append(lines, other.name+" "+status)
+	}
+	s.mu.Unlock()
+
+	sort.Strings(lines)
+	for _, line := range lines {
+		c.send("WHO %s", line)
+	}
+}
+
+// find returns the signed-in player with the given name, in any case
+func (s *server) find(name string) *session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, other := range s.sessions {
+		if strings.EqualFold(other.name, name) {
+			return other
+		}
+	}
+	return nil
+}
+
+// match offers another player a game
+func (s *server) match(c *session, name string) error {
+	to := s.find(name)
+	switch {
+	case to == nil:
+		return errNoSuchUser
+	case to == c:
+		return errors.New("you cannot play yourself")
+	}
+
+	s.mu.Lock()
+	if s.games[c.id] != nil || s.games[to.id] != nil {
+		s.mu.Unlock()
+		return errors.New("both players must be free to play")
+	}
+	s.offers[[2]string{c.id, to.id}] = true
+	s.mu.Unlock()
+
+	to.send("MATCH %s", c.name)
+	return nil
+}
+
+// accept starts a game from another player's offer, with them to move first
+func (s *server) accept(c *session, name string) error {
+	from := s.find(name)
+	if from == nil {
+		return errNoSuchUser
+	}
+
+	s.mu.Lock()
+	offer := [2]string{from.id, c.id}
+	if !s.offers[offer] {
+		s.mu.Unlock()
+		return fmt.Errorf("%s has not offered you a game", from.name)
+	}
+	if s.games[c.id] != nil || s.games[from.id] != nil {
+		s.mu.Unlock()
+		return errors.New("both players must be free to play")
+	}
+	delete(s.offers, offer)
+	s.nextID++
+	id := fmt.Sprintf("isc-%d", s.nextID)
+	s.mu.Unlock()
+
+	g, err := game.NewGameWithRules(id, []*game.Player{game.NewPlayer(from.id, from.name), game.NewPlayer(c.id, c.name)}, s.rules)
+	if err != nil {
+		return err
+	}
+	if err := g.StartGame(); err != nil {
+		return err
+	}
+	if err := s.manager.Add(g); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.games[from.id], s.games[c.id] = g, g
+	s.mu.Unlock()
+
+	return s.manager.Do(id, func(g *game.Game) error {
+		s.broadcast(g, "GAME %s %s", g.ID, playerNames(g))
+		s.afterTurn(g)
+		return nil
+	})
+}
+
+// decline turns down another player's offer
+func (s *server) decline(c *session, name string) error {
+	from := s.find(name)
+	if from == nil {
+		return errNoSuchUser
+	}
+
+	s.mu.Lock()
+	offer := [2]string{from.id, c.id}
+	offered := s.offers[offer]
+	delete(s.offers, offer)
+	s.mu.Unlock()
+
+	if !offered {
+		return fmt.Errorf("%s has not offered you a game", from.name)
+	}
+	from.send("DECLINE %s", c.name)
+	return nil
+}
+
+// gameOf returns the client's unfinished game
+func (s *server) gameOf(c *session) (*game.Game, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if g := s.games[c.id]; g != nil {
+		return g, nil
+	}
+	return nil, errNotPlaying
+}
+
+// act runs a turn action in the client's game with exclusive use of it, then
+// tells both players what follows
+func (s *server) act(c *session, action func(g *game.Game) error) error {
+	g, err := s.gameOf(c)
+	if err != nil {
+		return err
+	}
+	return s.manager.Do(g.ID, func(g *game.Game) error {
+		if err := action(g); err != nil {
+			return err
+		}
+		s.afterTurn(g)
+		return nil
+	})
+}
+
+// show runs a read-only command in the client's game
+func (s *server) show(c *session, fn func(g *game.Game)) error {
+	g, err := s.gameOf(c)
+	if err != nil {
+		return err
+	}
+	return s.manager.Do(g.ID, func(g *game.Game) error {
+		fn(g)
+		return nil
+	})
+}
+
+// sweep forfeits games whose player to move has stayed away too long
+func (s *server) sweep() {
+	for _, id := range s.manager.CheckAbandonment() {
+		s.manager.Do(id, func(g *game.Game) error {
+			if f := g.Forfeit; f != nil {
+				s.broadcast(g, "ABANDON %s", g.GetPlayer(f.PlayerID).Name)
+			}
+			s.afterTurn(g)
+			return nil
+		})
+	}
+}
+
+// afterTurn sends each player their rack and the player to move, or the
+// final scores once the game is over; callers must have exclusive use of g
+func (s *server) afterTurn(g *game.Game) {
+	if g.State == game.Finished {
+		s.broadcast(g, "GAMEOVER %s", scores(g))
+		s.mu.Lock()
+		for _, p := range g.Players {
+			delete(s.games, p.ID)
+		}
+		s.mu.Unlock()
+		s.manager.Remove(g.ID)
+		return
+	}
+
+	current := g.CurrentPlayer()
+	for _, p := range g.Players {
+		if c := s.session(p.ID); c != nil {
+			c.send("RACK %s", rackLetters(p.Rack))
+			c.send("TURN %s", current.Name)
+		}
+	}
+}
+
+// broadcast sends a line to every signed-in player in the game
+func (s *server) broadcast(g *game.Game, format string, args ...any) {
+	for _, p := range g.Players {
+		if c := s.session(p.ID); c != nil {
+			c.send(format, args...)
+		}
+	}
+}
+
+// session returns the signed-in client of a player, or nil
+func (s *server) session(id string) *session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.sessions[id]
+}
+
+// playerNames lists the players' names in seating order
+func playerNames(g *game.Game) string {
+	names := make([]string, len(g.Players))
+	for i, p := range g.Players {
+		names[i] = p.Name
+	}
+	return strings.Join(names, " ")
+}
+
+// scores lists each player's name and score in seating order
+func scores(g *game.Game) string {
+	fields := make([]string, len(g.Players))
+	for i, p := range g.Players {
+		fields[i] = fmt.Sprintf("%s %d", p.Name, p.Score)
+	}
+	return strings.Join(fields, " ")
+}
+
+// rackLetters writes a rack with ? for each blank
+func rackLetters(rack []game.Tile) string {
+	var sb strings.Builder
+	for _, t := range rack {
+		if t.IsBlank {
+			sb.WriteRune('?')
+		} else {
+			sb.WriteRune(t.Letter)
+		}
+	}
+	return sb.String()
+}
+
+// rackIndices finds the rack positions of the given letters, with ? for a blank
+func rackIndices(rack []game.Tile, letters string, variant game.Variant) ([]int, error) {
+	tiles, err := game.ParseRack(letters, variant)
+	if err != nil {
+		return nil, err
+	}
+	used := make([]bool, len(rack))
+	indices := make([]int, 0, len(tiles))
+	for _, t := range tiles {
+		found := -1
+		for i, r := range rack {
+			if !used[i] && r.IsBlank == t.IsBlank && (t.IsBlank || r.Letter == t.Letter) {
+				found = i
+				break
+			}
+		}
+		if found < 0 {
+			return nil, fmt.Errorf("%s is not on your rack", letters)
+		}
+		used[found] = true
+		indices = append(indices, found)
+	}
+	return indices, nil
+}
+
+// flatten keeps client-supplied text and error messages to a single line
+func flatten(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,174 @@
+// Command scrabbled-selfplay plays computer opponents against each other and
+// reports how each strategy fared
+//
+// Usage:
+//
+//	scrabbled-selfplay -dict words.txt [-games 100] [-a equity] [-b greedy] [-seed 1] [-log-level off]
+//	                   [-leaves leaves.txt] [-learn-leaves out.txt] [-learn-min 10]
+//
+// The two bots take turns to move first. Each game is dealt from a bag seeded
+// from -seed, so a run is reproducible and engine changes can be compared on
+// the same deals. The report gives each strategy's wins, average final score,
+// average points per turn and bingos per game, and how often the player who
+// moved first won. With -log-level set to debug, info, warn or error, each
+// game's events are logged to standard error, tagged with the game's ID.
+//
+// With -leaves, equity bots value the leaves in the given leave table from
+// its file rather than by heuristic. With -learn-leaves, the leave values
+// learned from the match's games are written to a file in the same format,
+// keeping leaves seen at least -learn-min times, so a table can be refined by
+// playing again with it.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"scrabbled/internal/dictionary"
+	"scrabbled/internal/game"
+)
+
+// options holds the command-line settings
+type options struct {
+	dictPath string
+	games    int
+	a, b     string
+	seed     int64
+	logLevel string
+
+	leavesPath string // Leave table for equity bots
+	learnPath  string // File to write learned leave values to
+	learnMin   int    // Observations needed for a learned leave to be written
+}
+
+func main() {
+	var opts options
+	flag.StringVar(&opts.dictPath, "dict", "", "word list file, one word per line (required)")
+	flag.IntVar(&opts.games, "games", 100, "number of games to play")
+	flag.StringVar(&opts.a, "a", "equity", "strategy of the first bot: greedy, topn, equity or adaptive")
+	flag.StringVar(&opts.b, "b", "greedy", "strategy of the second bot: greedy, topn, equity or adaptive")
+	flag.Int64Var(&opts.seed, "seed", 1, "seed for the first game's deal")
+	flag.StringVar(&opts.logLevel, "log-level", "off", "game log level: debug, info, warn, error or off")
+	flag.StringVar(&opts.leavesPath, "leaves", "", "leave table file for equity bots")
+	flag.StringVar(&opts.learnPath, "learn-leaves", "", "file to write leave values learned from the games to")
+	flag.IntVar(&opts.learnMin, "learn-min", 10, "times a leave must be seen to be written by -learn-leaves")
+	flag.Parse()
+
+	if err := run(opts, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "scrabbled-selfplay:", err)
+		os.Exit(1)
+	}
+}
+
+// run plays the match and prints its statistics
+func run(opts options, w io.Writer) error {
+	if opts.dictPath == "" {
+		return errors.New("a word list is required (-dict)")
+	}
+	if opts.games < 1 {
+		return fmt.Errorf("-games must be positive, got %d", opts.games)
+	}
+	var strategies [2]game.BotStrategy
+	for i, name := range []string{opts.a, opts.b} {
+		s, err := parseStrategy(name)
+		if err != nil {
+			return err
+		}
+		strategies[i] = s
+	}
+	logger, err := newLogger(opts.logLevel, os.Stderr)
+	if err != nil {
+		return err
+	}
+
+	var leaves game.LeaveTable
+	if opts.leavesPath != "" {
+		if leaves, err = game.LoadLeaveTable(opts.leavesPath); err != nil {
+			return err
+		}
+	}
+
+	dict, err := dictionary.LoadFile(dictionary.Custom, opts.dictPath)
+	if err != nil {
+		return err
+	}
+	ms, err := playMatch(game.NewMoveGenerator(dict.Words()), strategies, leaves, opts.games, opts.seed, logger)
+	if err != nil {
+		return err
+	}
+	if opts.learnPath != "" {
+		if err := writeLeaves(opts.learnPath, ms.leaves.Table(opts.learnMin)); err != nil {
+			return err
+		}
+	}
+	return printMatch(w, ms, opts.seed)
+}
+
+// writeLeaves writes a leave table to a file
+func writeLeaves(path string, table game.LeaveTable) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := table.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// printMatch writes the match statistics as a table
+func printMatch(w io.Writer, ms *matchStats, seed int64) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "%d games from seed %d\n", ms.games, seed)
+	fmt.Fprintln(tw, "BOT\tSTRATEGY\tWINS\tAVG SCORE\tPER TURN\tBINGOS/GAME")
+	for i, side := range ms.sides {
+		games := float64(ms.games)
+		fmt.Fprintf(tw, "%s\t%s\t%d (%.1f%%)\t%.1f\t%.1f\t%.2f\n",
+			sideIDs[i], side.strategy, side.wins, percent(side.wins, ms.games),
+			float64(side.points)/games, side.stats.AverageScore(), float64(side.stats.Bingos)/games)
+	}
+	fmt.Fprintf(tw, "Ties: %d\n", ms.ties)
+	fmt.Fprintf(tw, "First player won %d (%.1f%%)\n", ms.firstWins, percent(ms.firstWins, ms.games))
+	return tw.Flush()
+}
+
+// percent returns n as a percentage of total
+func percent(n, total int) float64 {
+	return 100 * float64(n) / float64(total)
+}
+
+// newLogger returns a logger writing records at or above the named level to w,
+// or nil for "off" or an empty level
+func newLogger(level string, w io.Writer) (*slog.Logger, error) {
+	if level == "" || strings.EqualFold(level, "off") {
+		return nil, nil
+	}
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("unknown log level %q", level)
+	}
+	return slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: l})), nil
+}
+
+// parseStrategy converts a strategy flag value to a strategy
+func parseStrategy(s string) (game.BotStrategy, error) {
+	switch strings.ToLower(s) {
+	case "greedy":
+		return game.Greedy, nil
+	case "topn":
+		return game.TopNRandom, nil
+	case "equity":
+		return game.Equity, nil
+	case "adaptive":
+		return game.Adaptive, nil
+	default:
+		return 0, fmt.Errorf("unknown bot strategy %q", s)
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"scrabbled/internal/game"
+)
+
+// testWords is the small word list used by self-play tests
+var testWords = []string{"AT", "TA", "AN", "NA", "IN", "IT", "TI", "TO", "ON", "NO", "DO", "GO", "OR", "RE", "ER", "ES",
+	"CAT", "CATS", "ACT", "SCAT", "DOG", "GOD", "DOGS", "EAT", "TEA", "ATE", "NET", "TEN", "RAT", "ART", "TAR", "RATE", "TEAR", "STAR"}
+
+// TestPlayMatch tests that a match is played to completion and is reproducible
+func TestPlayMatch(t *testing.T) {
+	mg := game.NewMoveGenerator(testWords)
+	strategies := [2]game.BotStrategy{game.Equity, game.TopNRandom}
+
+	ms, err := playMatch(mg, strategies, nil, 6, 42, nil)
+	if err != nil {
+		t.Fatalf("playMatch failed: %v", err)
+	}
+	if ms.sides[0].wins+ms.sides[1].wins+ms.ties != 6 {
+		t.Errorf("Every game should be won or tied, got %+v", ms)
+	}
+	if ms.sides[0].stats.Games != 6 || ms.sides[1].stats.Games != 6 {
+		t.Errorf("Both bots should play every game, got %d and %d", ms.sides[0].stats.Games, ms.sides[1].stats.Games)
+	}
+	if ms.firstWins > ms.games-ms.ties {
+		t.Errorf("First-player wins should not exceed decided games, got %d", ms.firstWins)
+	}
+
+	again, err := playMatch(mg, strategies, nil, 6, 42, nil)
+	if err != nil {
+		t.Fatalf("playMatch failed: %v", err)
+	}
+	if again.sides != ms.sides || again.firstWins != ms.firstWins {
+		t.Errorf("Matches with the same seed should repeat, got %+v and %+v", ms.sides, again.sides)
+	}
+}
+
+// TestRun tests the report and option checks
+func TestRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "words.txt")
+	if err := os.WriteFile(path, []byte(strings.Join(testWords, "\n")), 0o644); err != nil {
+		t.Fatalf("Failed to write word list: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := run(options{dictPath: path, games: 2, a: "greedy", b: "equity", seed: 1}, &out); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	for _, want := range []string{"2 games from seed 1", "GREEDY", "EQUITY", "First player won"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Report should contain %q, got:\n%s", want, out.String())
+		}
+	}
+
+	for name, opts := range map[string]options{
+		"no word list":  {games: 1, a: "greedy", b: "greedy"},
+		"no games":      {dictPath: path, a: "greedy", b: "greedy"},
+		"bad strategy":  {dictPath: path, games: 1, a: "random", b: "greedy"},
+		"bad log level": {dictPath: path, games: 1, a: "greedy", b: "greedy", logLevel: "loud"},
+		"no leaves":     {dictPath: path, games: 1, a: "greedy", b: "greedy", leavesPath: filepath.Join(t.TempDir(), "none.txt")},
+	} {
+		if err := run(opts, &bytes.Buffer{}); err == nil {
+			t.Errorf("%s: run should fail", name)
+		}
+	}
+}
+
+// TestLearnLeaves tests writing learned leave values and playing with them
+func TestLearnLeaves(t *testing.T) {
+	dir := t.TempDir()
+	words := filepath.Join(dir, "words.txt")
+	if err := os.WriteFile(words, []byte(strings.Join(testWords, "\n")), 0o644); err != nil {
+		t.Fatalf("Failed to write word list: %v", err)
+	}
+	learned := filepath.Join(dir, "leaves.txt")
+
+	opts := options{dictPath: words, games: 4, a: "equity", b: "greedy", seed: 1, learnPath: learned, learnMin: 1}
+	if err := run(opts, &bytes.Buffer{}); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	table, err := game.LoadLeaveTable(learned)
+	if err != nil {
+		t.Fatalf("Learned leaves should load, got %v", err)
+	}
+	if len(table) == 0 {
+		t.Errorf("Should learn some leave values")
+	}
+
+	opts = options{dictPath: words, games: 2, a: "equity", b: "equity", seed: 1, leavesPath: learned}
+	var out bytes.Buffer
+	if err := run(opts, &out); err != nil {
+		t.Fatalf("run with learned leaves failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "2 games from seed 1") {
+		t.Errorf("Should report the match, got:\n%s", out.String())
+	}
+}
+
+// TestNewLogger tests the log level setting
+func TestNewLogger(t *testing.T) {
+	for _, level := range []string{"", "off", "OFF"} {
+		if l, err := newLogger(level, &bytes.Buffer{}); l != nil || err != nil {
+			t.Errorf("Level %q should turn logging off, got %v, %v", level, l, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	l, err := newLogger("warn", &buf)
+	if err != nil {
+		t.Fatalf("newLogger failed: %v", err)
+	}
+	l.Info("hidden")
+	l.Warn("shown")
+	if strings.Contains(buf.String(), "hidden") || !strings.Contains(buf.String(), "shown") {
+		t.Errorf("Should log only at warn and above, got %q", buf.String())
+	}
+
+	var games bytes.Buffer
+	l, _ = newLogger("info", &games)
+	if _, err := playGame(game.NewMoveGenerator(testWords), [2]game.BotStrategy{game.Greedy, game.Greedy}, nil, 0, 7, l); err != nil {
+		t.Fatalf("playGame failed: %v", err)
+	}
+	if !strings.Contains(games.String(), "game_id=selfplay-7") || !strings.Contains(games.String(), "game_over=true") {
+		t.Errorf("Game records should carry the game ID through to the end, got:\n%s", games.String())
+	}
+}
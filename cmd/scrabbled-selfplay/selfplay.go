@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+
+	"scrabbled/internal/game"
+)
+
+// sideIDs are the player IDs of the two bots in every game
+var sideIDs = [2]string{"a", "b"}
+
+// sideStats aggregates one bot's results over a match
+type sideStats struct {
+	strategy game.BotStrategy
+	wins     int
+	points   int              // Final scores, summed over the games
+	stats    game.PlayerStats // Turn-by-turn statistics, summed over the games
+}
+
+// matchStats holds the results of a self-play match
+type matchStats struct {
+	games     int
+	ties      int
+	firstWins int // Games won by the bot that moved first
+	sides     [2]sideStats
+	leaves    *game.LeaveLearner // Leave values learned from the games
+}
+
+// playMatch plays games between bots using the two strategies
+// The bots take turns to move first, and game i is dealt from a bag seeded
+// with seed+i, so a match is reproducible from its seed. Equity bots value
+// leaves from the table where it has them. Games log to logger unless it is
+// nil.
+func playMatch(generator *game.MoveGenerator, strategies [2]game.BotStrategy, leaves game.LeaveTable, games int, seed int64, logger *slog.Logger) (*matchStats, error) {
+	ms := &matchStats{games: games, leaves: game.NewLeaveLearner()}
+	for i := range ms.sides {
+		ms.sides[i].strategy = strategies[i]
+		ms.sides[i].stats.PlayerID = sideIDs[i]
+	}
+
+	for i := 0; i < games; i++ {
+		first := i % 2
+		g, err := playGame(generator, strategies, leaves, first, seed+int64(i), logger)
+		if err != nil {
+			return nil, fmt.Errorf("game %d: %w", i+1, err)
+		}
+		ms.leaves.Observe(g.History)
+
+		var scores [2]int
+		for seat, p := range g.Players {
+			side := order(first)[seat]
+			stats, err := g.PlayerStats(p.ID)
+			if err != nil {
+				return nil, err
+			}
+			ms.sides[side].stats.Add(stats)
+			scores[side] = p.Score
+			ms.sides[side].points += p.Score
+		}
+
+		switch winner := winnerOf(scores); winner {
+		case -1:
+			ms.ties++
+		default:
+			ms.sides[winner].wins++
+			if winner == first {
+				ms.firstWins++
+			}
+		}
+	}
+	return ms, nil
+}
+
+// playGame plays one game to the end between two seeded bots, with the bot
+// for side first moving first
+func playGame(generator *game.MoveGenerator, strategies [2]game.BotStrategy, leaves game.LeaveTable, first int, seed int64, logger *slog.Logger) (*game.Game, error) {
+	players := make([]*game.Player, 2)
+	for seat, side := range order(first) {
+		players[seat] = game.NewPlayer(sideIDs[side], fmt.Sprintf("Bot %s", sideIDs[side]))
+	}
+
+	g, err := game.NewGame(fmt.Sprintf("selfplay-%d", seed), players)
+	if err != nil {
+		return nil, err
+	}
+	g.TileBag = game.NewTileBagWithSeed(seed)
+	g.SetLogger(logger)
+	for side, id := range sideIDs {
+		bot := game.NewBotWithSeed(id, strategies[side], generator, seed)
+		bot.Leave.Table = leaves
+		if err := g.AttachBot(bot); err != nil {
+			return nil, err
+		}
+	}
+	if err := g.StartGame(); err != nil {
+		return nil, err
+	}
+	if _, err := g.PlayBotTurns(); err != nil {
+		return nil, err
+	}
+	if g.State != game.Finished {
+		return nil, fmt.Errorf("game stopped in state %v", g.State)
+	}
+	return g, nil
+}
+
+// order returns the side in each seat when side first moves first
+func order(first int) [2]int {
+	return [2]int{first, 1 - first}
+}
+
+// winnerOf returns the side with the higher score, or -1 for a tie
+func winnerOf(scores [2]int) int {
+	switch {
+	case scores[0] > scores[1]:
+		return 0
+	case scores[1] > scores[0]:
+		return 1
+	default:
+		return -1
+	}
+}
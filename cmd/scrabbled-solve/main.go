@@ -0,0 +1,200 @@
+// Command scrabbled-solve prints the best plays for a rack in a given position
+//
+// Usage:
+//
+//	scrabbled-solve -dict words.txt -position "15/15/.../15 AEINRST/- 0/0 0" [-rack RACK] [-top N]
+//	scrabbled-solve -dict words.txt -gcg game.gcg -turn N [-rack RACK] [-top N]
+//
+// The position is given either in single-line position notation or as a GCG
+// record replayed up to its first N events. The rack defaults to that of the
+// player to move in the notation, or for a GCG record to the rack written on
+// event N+1. Plays are ranked by equity, the score plus the value of the tiles
+// kept, and printed with their coordinates in GCG notation: row first for
+// plays across, column first for plays down, with blanks in lower case.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"scrabbled/internal/dictionary"
+	"scrabbled/internal/game"
+)
+
+// options holds the command-line settings
+type options struct {
+	dictPath string
+	position string
+	gcgPath  string
+	turn     int
+	rack     string
+	top      int
+}
+
+func main() {
+	var opts options
+	flag.StringVar(&opts.dictPath, "dict", "", "word list file, one word per line (required)")
+	flag.StringVar(&opts.position, "position", "", "position in single-line notation")
+	flag.StringVar(&opts.gcgPath, "gcg", "", "GCG record to take the position from")
+	flag.IntVar(&opts.turn, "turn", -1, "number of GCG events to replay (default all)")
+	flag.StringVar(&opts.rack, "rack", "", "rack to solve for, with ? for a blank")
+	flag.IntVar(&opts.top, "top", 10, "number of plays to print")
+	flag.Parse()
+
+	if err := run(opts, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "scrabbled-solve:", err)
+		os.Exit(1)
+	}
+}
+
+// run finds and prints the best plays for the configured position and rack
+func run(opts options, w io.Writer) error {
+	if opts.dictPath == "" {
+		return errors.New("a word list is required (-dict)")
+	}
+	if (opts.position == "") == (opts.gcgPath == "") {
+		return errors.New("give exactly one of -position and -gcg")
+	}
+	if opts.top < 1 {
+		return fmt.Errorf("-top must be positive, got %d", opts.top)
+	}
+
+	pos, rackText, err := loadPosition(opts)
+	if err != nil {
+		return err
+	}
+	if opts.rack != "" {
+		rackText = opts.rack
+	}
+	// Racks replayed from a GCG record hold whatever the bag dealt, so only a
+	// position's own rack is a sensible default
+	var rack []game.Tile
+	switch {
+	case rackText != "":
+		if rack, err = game.ParseRack(rackText, pos.Board.Variant); err != nil {
+			return err
+		}
+	case opts.position != "":
+		rack = pos.Racks[pos.Turn]
+	}
+	if len(rack) == 0 {
+		return errors.New("the rack is unknown; give one with -rack")
+	}
+
+	dict, err := dictionary.LoadFile(dictionary.Custom, opts.dictPath)
+	if err != nil {
+		return err
+	}
+	generator := game.NewMoveGenerator(dict.Words())
+	ranked := game.NewLeaveEvaluator().Rank(rack, generator.GenerateMoves(pos.Board, rack))
+	return printPlays(w, rack, ranked, opts.top)
+}
+
+// loadPosition reads the position to solve and, for a GCG record, the rack
+// recorded for the next event if there is one
+func loadPosition(opts options) (*game.GamePosition, string, error) {
+	if opts.position != "" {
+		pos, err := game.ParsePosition(opts.position)
+		return pos, "", err
+	}
+
+	f, err := os.Open(opts.gcgPath)
+	if err != nil {
+		return nil, "", err
+	}
+	defer f.Close()
+
+	record, nextRack, err := truncateGCG(f, opts.turn)
+	if err != nil {
+		return nil, "", err
+	}
+	g, err := game.ImportGCG(strings.NewReader(record))
+	if err != nil {
+		return nil, "", err
+	}
+	return g.Position(), nextRack, nil
+}
+
+// truncateGCG keeps a GCG record's header and its first n event lines, or all
+// of them if n is negative, and returns the rack of the event that follows
+func truncateGCG(r io.Reader, n int) (string, string, error) {
+	var sb strings.Builder
+	events := 0
+	nextRack := ""
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(strings.TrimSpace(line), ">") {
+			if n >= 0 && events >= n {
+				if events == n {
+					nextRack = gcgEventRack(line)
+				}
+				events++
+				continue
+			}
+			events++
+		}
+		sb.WriteString(line)
+		sb.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		return "", "", err
+	}
+	if n > events {
+		return "", "", fmt.Errorf("the record has only %d events", events)
+	}
+	return sb.String(), nextRack, nil
+}
+
+// gcgEventRack returns the rack written on a GCG event line, or "" if the
+// line does not give one
+func gcgEventRack(line string) string {
+	colon := strings.Index(line, ":")
+	if colon < 0 {
+		return ""
+	}
+	fields := strings.Fields(line[colon+1:])
+	if len(fields) < 2 || strings.ContainsAny(fields[0], "0123456789()") {
+		return ""
+	}
+	return fields[0]
+}
+
+// printPlays writes the top plays as a table
+func printPlays(w io.Writer, rack []game.Tile, ranked []game.RankedMove, top int) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Rack %s: %d plays\n", rackString(rack), len(ranked))
+	if len(ranked) > 0 {
+		fmt.Fprintln(tw, "#\tPLAY\tSCORE\tLEAVE\tVALUE\tEQUITY")
+	}
+	for i, rm := range ranked {
+		if i == top {
+			break
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%d\t%s\t%+.1f\t%.1f\n",
+			i+1, rm.Move.Notation(), rm.Move.Score, rackString(rm.Leave), rm.LeaveValue, rm.Equity)
+	}
+	return tw.Flush()
+}
+
+// rackString writes tiles with '?' for blanks, or '-' for none
+func rackString(tiles []game.Tile) string {
+	if len(tiles) == 0 {
+		return "-"
+	}
+	var sb strings.Builder
+	for _, t := range tiles {
+		if t.IsBlank {
+			sb.WriteRune('?')
+		} else {
+			sb.WriteRune(t.Letter)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testRecord is a short GCG record in which bob's second rack is known
+const testRecord = `#player1 alice Alice
+#player2 bob Bob
+>alice: ACTXYZQ 8G CAT +10 10
+>bob: DEGOSVW 8G ...S +6 6
+>alice: QXYZAEI -XYZ +0 10
+`
+
+// writeFile creates a file in a temporary directory and returns its path
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+// solve runs the command and returns its output
+func solve(t *testing.T, opts options) (string, error) {
+	t.Helper()
+	if opts.dictPath == "" {
+		opts.dictPath = writeFile(t, "words.txt", "AT\nTA\nCAT\nCATS\nACT\nSCAT\nDOG\nDOGS\nGOD\n")
+	}
+	if opts.top == 0 {
+		opts.top = 10
+	}
+	var out bytes.Buffer
+	err := run(opts, &out)
+	return out.String(), err
+}
+
+// TestSolvePosition tests ranking plays for the rack in a position
+func TestSolvePosition(t *testing.T) {
+	empty := "15/15/15/15/15/15/15/15/15/15/15/15/15/15/15"
+	out, err := solve(t, options{position: empty + " DOGSXYZ/- 0/0 0", turn: -1, top: 1})
+	if err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "Rack DOGSXYZ:") {
+		t.Fatalf("Should print the rack, a header and one play, got:\n%s", out)
+	}
+	// Keeping the S is worth more than the points it adds to DOGS
+	if fields := strings.Fields(lines[2]); len(fields) != 7 || fields[2] != "DOG" || fields[4] != "SXYZ" {
+		t.Errorf("Should rank DOG first, keeping SXYZ, got %q", lines[2])
+	}
+
+	out, err = solve(t, options{position: empty + " -/- 0/0 0", rack: "ca?", turn: -1})
+	if err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if !strings.Contains(out, "Rack CA?:") || !strings.Contains(out, "CAt") {
+		t.Errorf("Should solve the given rack with the blank in lower case, got:\n%s", out)
+	}
+}
+
+// TestSolveGCG tests taking the position and rack from a GCG record
+func TestSolveGCG(t *testing.T) {
+	path := writeFile(t, "game.gcg", testRecord)
+
+	out, err := solve(t, options{gcgPath: path, turn: 1})
+	if err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if !strings.Contains(out, "Rack DEGOSVW:") || !strings.Contains(out, "8G CATS") {
+		t.Errorf("Should solve bob's recorded rack after CAT, got:\n%s", out)
+	}
+
+	if _, err := solve(t, options{gcgPath: path, turn: -1}); err == nil {
+		t.Errorf("Should need a rack after the last event")
+	}
+	if _, err := solve(t, options{gcgPath: path, turn: 5}); err == nil {
+		t.Errorf("Should reject a turn beyond the record")
+	}
+}
+
+// TestSolveOptions tests rejection of incomplete settings
+func TestSolveOptions(t *testing.T) {
+	for name, opts := range map[string]options{
+		"no position":   {turn: -1},
+		"two positions": {position: "x", gcgPath: "y", turn: -1},
+		"bad rack":      {position: "15/15/15/15/15/15/15/15/15/15/15/15/15/15/15 -/- 0/0 0", rack: "A1", turn: -1},
+	} {
+		if _, err := solve(t, opts); err == nil {
+			t.Errorf("%s: run should fail", name)
+		}
+	}
+	if err := run(options{position: "x"}, &bytes.Buffer{}); err == nil {
+		t.Errorf("Should require a word list")
+	}
+}
@@ -0,0 +1,168 @@
+// Command scrabbled-study generates alphagram study lists from a word list
+// for flashcard apps, or reviews them with a Leitner cardbox
+//
+// Usage:
+//
+//	scrabbled-study -words words.txt -length N [-top N] [-containing LETTERS] [-format csv|json] [-o FILE] [-lexicon NAME]
+//	scrabbled-study -words words.txt -length N [-top N] [-containing LETTERS] -db games.db -player ID [-review N]
+//
+// For example, -length 7 -top 2000 lists the bingos of the 2000 most
+// probable seven-letter alphagrams, and -length 4 -containing JQXZ lists
+// every four-letter word with a J, Q, X or Z. The list is written to
+// standard output unless -o is given.
+//
+// With -db the list is added to the player's cardbox in the SQLite database
+// instead, and up to -review due alphagrams are put to the player, who
+// answers each with every word it spells, space-separated, or q to stop.
+// Progress is saved after every answer.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"scrabbled/internal/dictionary"
+	"scrabbled/internal/storage"
+	"scrabbled/internal/study"
+)
+
+// options holds the command-line settings
+type options struct {
+	wordsPath string
+	lexicon   string
+	outPath   string
+	format    string
+	criteria  study.Criteria
+	dbPath    string
+	playerID  string
+	review    int
+}
+
+func main() {
+	var opts options
+	flag.StringVar(&opts.wordsPath, "words", "", "word list file, one word per line (required)")
+	flag.StringVar(&opts.lexicon, "lexicon", "", "lexicon name for the list, such as CSW21 or NWL2020")
+	flag.StringVar(&opts.outPath, "o", "", "file to write the list to (default standard output)")
+	flag.StringVar(&opts.format, "format", "csv", "export format, csv or json")
+	flag.IntVar(&opts.criteria.Length, "length", 7, "letters in each word")
+	flag.IntVar(&opts.criteria.MaxRank, "top", 0, "keep only the most probable alphagrams (default all)")
+	flag.StringVar(&opts.criteria.Containing, "containing", "", "keep only alphagrams with at least one of these letters")
+	flag.StringVar(&opts.dbPath, "db", "", "SQLite database holding cardboxes; review the list instead of exporting it")
+	flag.StringVar(&opts.playerID, "player", "", "player whose cardbox to review (required with -db)")
+	flag.IntVar(&opts.review, "review", 20, "most alphagrams to review")
+	flag.Parse()
+
+	if err := run(opts, os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "scrabbled-study:", err)
+		os.Exit(1)
+	}
+}
+
+// run generates the list and writes it to the output file or w, or reviews
+// it from in
+func run(opts options, in io.Reader, w io.Writer) error {
+	if opts.wordsPath == "" {
+		return errors.New("a word list is required (-words)")
+	}
+	format, err := study.ParseFormat(opts.format)
+	if err != nil {
+		return err
+	}
+
+	wl, err := dictionary.LoadFile(dictionary.ParseLexicon(opts.lexicon), opts.wordsPath)
+	if err != nil {
+		return err
+	}
+	list, err := study.Generate(listName(wl.Lexicon(), opts.criteria), wl, opts.criteria)
+	if err != nil {
+		return err
+	}
+
+	if opts.dbPath != "" {
+		return review(opts, list, in, w)
+	}
+	if opts.outPath == "" {
+		return list.Write(w, format)
+	}
+	f, err := os.Create(opts.outPath)
+	if err != nil {
+		return err
+	}
+	if err := list.Write(f, format); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "wrote %d alphagrams, %d words to %s\n", len(list.Items), list.Words(), opts.outPath)
+	return nil
+}
+
+// listName describes the list, such as "CSW 7s top 2000 with JQXZ"
+func listName(lexicon dictionary.Lexicon, c study.Criteria) string {
+	name := fmt.Sprintf("%s %ds", lexicon, c.Length)
+	if c.MaxRank > 0 {
+		name += fmt.Sprintf(" top %d", c.MaxRank)
+	}
+	if c.Containing != "" {
+		name += " with " + dictionary.Normalize(c.Containing)
+	}
+	return name
+}
+
+// review adds the list to the player's cardbox and quizzes them on the due
+// alphagrams, saving each answer
+func review(opts options, list *study.List, in io.Reader, w io.Writer) error {
+	if opts.playerID == "" {
+		return errors.New("a player is required to review (-player)")
+	}
+	store, err := storage.NewSQLiteGameStore(opts.dbPath)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	cards, err := store.LoadCards(opts.playerID)
+	if err != nil {
+		return err
+	}
+	cb := study.NewCardbox(opts.playerID, cards...)
+	now := time.Now()
+	if err := store.SaveCards(opts.playerID, cb.Add(list, now)); err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(in)
+	right, answered := 0, 0
+	for _, c := range cb.Due(now, opts.review) {
+		fmt.Fprintf(w, "%s (box %d)? ", c.Alphagram, c.Box)
+		if !scanner.Scan() || strings.EqualFold(strings.TrimSpace(scanner.Text()), "q") {
+			fmt.Fprintln(w)
+			break
+		}
+		correct := c.Check(strings.Fields(scanner.Text()))
+		reviewed, err := cb.Review(c.Alphagram, correct, time.Now())
+		if err != nil {
+			return err
+		}
+		if err := store.SaveCards(opts.playerID, []study.Card{reviewed}); err != nil {
+			return err
+		}
+		answered++
+		if correct {
+			right++
+			fmt.Fprintln(w, "right")
+		} else {
+			fmt.Fprintf(w, "wrong: %s\n", strings.Join(c.Words, " "))
+		}
+	}
+	fmt.Fprintf(w, "%d/%d right; boxes %v\n", right, answered, cb.Boxes())
+	return nil
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"scrabbled/internal/study"
+)
+
+// generate runs the command over a small word list, reading input, and
+// returns its output
+func generate(t *testing.T, opts options, input string) (string, error) {
+	t.Helper()
+	if opts.wordsPath == "" {
+		opts.wordsPath = filepath.Join(t.TempDir(), "words.txt")
+		words := "RETAINS\nRETINAS\nSTAINER\nQUIXOTE\nJAZZ\nQUIZ\nRATE\nTEAR\n"
+		if err := os.WriteFile(opts.wordsPath, []byte(words), 0o644); err != nil {
+			t.Fatalf("Failed to write words: %v", err)
+		}
+	}
+	if opts.format == "" {
+		opts.format = "csv"
+	}
+	var out bytes.Buffer
+	err := run(opts, strings.NewReader(input), &out)
+	return out.String(), err
+}
+
+// TestRunCSV tests writing the most probable sevens as CSV
+func TestRunCSV(t *testing.T) {
+	out, err := generate(t, options{criteria: study.Criteria{Length: 7, MaxRank: 1}}, "")
+	if err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	expected := "alphagram,words,combinations,rank\nAEINRST,RETAINS RETINAS STAINER,839808,1\n"
+	if out != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, out)
+	}
+}
+
+// TestRunJSONFile tests writing a JQXZ list to a JSON file
+func TestRunJSONFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jqxz.json")
+	opts := options{lexicon: "csw21", format: "json", outPath: path, criteria: study.Criteria{Length: 4, Containing: "jqxz"}}
+	out, err := generate(t, opts, "")
+	if err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if out != "wrote 2 alphagrams, 2 words to "+path+"\n" {
+		t.Errorf("Unexpected output: %s", out)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read the list: %v", err)
+	}
+	var list study.List
+	if err := json.Unmarshal(data, &list); err != nil {
+		t.Fatalf("Failed to decode the list: %v", err)
+	}
+	if list.Name != "CSW 4s with JQXZ" || len(list.Items) != 2 || list.Items[0].Alphagram != "IQUZ" {
+		t.Errorf("Unexpected list: %+v", list)
+	}
+}
+
+// TestRunErrors tests rejecting a missing word list, an unknown format and an empty list
+func TestRunErrors(t *testing.T) {
+	if err := run(options{format: "csv", criteria: study.Criteria{Length: 7}}, strings.NewReader(""), &bytes.Buffer{}); err == nil {
+		t.Errorf("Should require a word list")
+	}
+	if _, err := generate(t, options{format: "xml", criteria: study.Criteria{Length: 7}}, ""); err == nil {
+		t.Errorf("Should reject an unknown format")
+	}
+	if _, err := generate(t, options{criteria: study.Criteria{Length: 5}}, ""); !errors.Is(err, study.ErrNoWords) {
+		t.Errorf("Should report that no words match, got %v", err)
+	}
+}
+
+// TestRunReview tests reviewing a list with a cardbox kept between runs
+func TestRunReview(t *testing.T) {
+	opts := options{dbPath: filepath.Join(t.TempDir(), "games.db"), playerID: "p1", review: 5, criteria: study.Criteria{Length: 4}}
+	out, err := generate(t, opts, "tear rate\nquiz\n")
+	if err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	expected := "AERT (box 0)? right\nAJZZ (box 0)? wrong: JAZZ\nIQUZ (box 0)? \n1/2 right; boxes [2 1 0 0 0 0 0]\n"
+	if out != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, out)
+	}
+
+	// The missed card is due again at once; the right one waits a day
+	out, err = generate(t, opts, "\n\n\n")
+	if err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if !strings.Contains(out, "AJZZ (box 0)?") || strings.Contains(out, "AERT") {
+		t.Errorf("Expected the missed card again but not the right one, got:\n%s", out)
+	}
+
+	opts.playerID = ""
+	if _, err := generate(t, opts, ""); err == nil {
+		t.Errorf("Should require a player to review")
+	}
+}
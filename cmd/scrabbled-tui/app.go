@@ -0,0 +1,320 @@
+package main
+
+import (
+	"unicode"
+
+	"github.com/gdamore/tcell/v2"
+
+	"scrabbled/internal/game"
+	"scrabbled/internal/i18n"
+)
+
+// mode is what typed letters currently do
+type mode int
+
+const (
+	placing    mode = iota // Letters place rack tiles at the cursor
+	blank                  // The next letter is assigned to a blank
+	exchanging             // Letters mark rack tiles to exchange
+)
+
+// app is the interactive client state
+type app struct {
+	screen   tcell.Screen
+	game     *game.Game
+	playerID string
+	loc      *i18n.Localizer
+
+	cursor    game.Position
+	direction game.Direction
+	mode      mode
+	pending   []game.PlacedTile // Tiles placed this turn but not yet played
+	used      map[int]bool      // Rack indices of pending tiles
+	marked    map[int]bool      // Rack indices selected for exchange
+	message   string
+	quit      bool
+}
+
+// newApp creates a client for the given player with the cursor on the center
+// square, writing its messages with loc
+func newApp(screen tcell.Screen, g *game.Game, playerID string, loc *i18n.Localizer) *app {
+	return &app{
+		screen:   screen,
+		game:     g,
+		playerID: playerID,
+		loc:      loc,
+		cursor:   g.Board.Center,
+		used:     make(map[int]bool),
+		marked:   make(map[int]bool),
+		message:  loc.Text(keyYourMove),
+	}
+}
+
+// Run handles input until the user quits
+func (a *app) Run() error {
+	a.draw()
+	for !a.quit {
+		switch ev := a.screen.PollEvent().(type) {
+		case *tcell.EventKey:
+			a.handleKey(ev)
+		case *tcell.EventResize:
+			a.screen.Sync()
+		case nil:
+			return nil
+		}
+		a.draw()
+	}
+	return nil
+}
+
+// rack returns the player's current tiles
+func (a *app) rack() []game.Tile {
+	for _, p := range a.game.View(game.ViewOptions{PlayerID: a.playerID}).Players {
+		if p.ID == a.playerID {
+			return p.Rack
+		}
+	}
+	return nil
+}
+
+// handleKey applies one key press
+func (a *app) handleKey(ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyCtrlC:
+		a.quit = true
+		return
+	case tcell.KeyUp:
+		a.moveCursor(-1, 0)
+	case tcell.KeyDown:
+		a.moveCursor(1, 0)
+	case tcell.KeyLeft:
+		a.moveCursor(0, -1)
+	case tcell.KeyRight:
+		a.moveCursor(0, 1)
+	case tcell.KeyEscape:
+		a.clear(a.loc.Text(keyCleared))
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		a.takeBack()
+	case tcell.KeyEnter:
+		if a.mode == exchanging {
+			a.exchange()
+		} else {
+			a.play()
+		}
+	case tcell.KeyCtrlX:
+		a.clear(a.loc.Text(keyExchangePrompt))
+		a.mode = exchanging
+	case tcell.KeyCtrlP:
+		a.pass()
+	case tcell.KeyRune:
+		a.handleRune(ev.Rune())
+	}
+}
+
+// handleRune applies a typed character
+func (a *app) handleRune(r rune) {
+	switch {
+	case r == ' ' && a.mode != exchanging:
+		if a.direction == game.Horizontal {
+			a.direction = game.Vertical
+		} else {
+			a.direction = game.Horizontal
+		}
+	case r == '?' && a.mode == placing:
+		a.mode = blank
+		a.message = a.loc.Text(keyBlankPrompt)
+	case a.mode == exchanging:
+		a.markForExchange(unicode.ToUpper(r))
+	case unicode.IsLetter(r):
+		a.place(unicode.ToUpper(r))
+	}
+}
+
+// moveCursor moves the cursor, staying on the board
+func (a *app) moveCursor(dRow, dCol int) {
+	next := game.Position{Row: a.cursor.Row + dRow, Col: a.cursor.Col + dCol}
+	if a.game.Board.IsValidPosition(next) {
+		a.cursor = next
+	}
+}
+
+// place puts a rack tile on the cursor square and advances the cursor
+func (a *app) place(letter rune) {
+	if a.game.Board.HasTileAt(a.cursor) || a.pendingAt(a.cursor) >= 0 {
+		a.message = a.loc.Text(keySquareTaken)
+		return
+	}
+
+	wantBlank := a.mode == blank
+	a.mode = placing
+	idx := -1
+	for i, t := range a.rack() {
+		if a.used[i] || t.IsBlank != wantBlank {
+			continue
+		}
+		if wantBlank || t.Letter == letter {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		a.message = a.loc.Text(keyNotOnRack, letter)
+		return
+	}
+
+	tile := a.rack()[idx]
+	if tile.IsBlank {
+		tile.Letter = letter
+	}
+	a.used[idx] = true
+	a.pending = append(a.pending, game.PlacedTile{Tile: tile, Position: a.cursor})
+	a.message = ""
+	a.advance()
+}
+
+// advance moves the cursor to the next empty square in the current direction
+func (a *app) advance() {
+	for {
+		if a.direction == game.Horizontal {
+			a.moveCursor(0, 1)
+		} else {
+			a.moveCursor(1, 0)
+		}
+		if !a.game.Board.HasTileAt(a.cursor) || a.cursor.Row == 14 || a.cursor.Col == 14 {
+			return
+		}
+	}
+}
+
+// pendingAt returns the index of the pending tile at pos, or -1
+func (a *app) pendingAt(pos game.Position) int {
+	for i, pt := range a.pending {
+		if pt.Position == pos {
+			return i
+		}
+	}
+	return -1
+}
+
+// takeBack returns the last placed tile to the rack
+func (a *app) takeBack() {
+	if len(a.pending) == 0 {
+		return
+	}
+	last := a.pending[len(a.pending)-1]
+	a.pending = a.pending[:len(a.pending)-1]
+	a.cursor = last.Position
+	a.used = make(map[int]bool)
+	for _, pt := range a.pending {
+		a.markUsed(pt.Tile)
+	}
+}
+
+// markUsed marks the first unused rack tile matching a placed tile
+func (a *app) markUsed(placed game.Tile) {
+	for i, t := range a.rack() {
+		if !a.used[i] && t.IsBlank == placed.IsBlank && (t.IsBlank || t.Letter == placed.Letter) {
+			a.used[i] = true
+			return
+		}
+	}
+}
+
+// markForExchange toggles the first rack tile with the letter ('?' for a blank)
+func (a *app) markForExchange(letter rune) {
+	for i, t := range a.rack() {
+		matches := (letter == '?' && t.IsBlank) || (!t.IsBlank && t.Letter == letter)
+		if !matches {
+			continue
+		}
+		if !a.marked[i] {
+			a.marked[i] = true
+			return
+		}
+	}
+	// Every copy is already marked; unmark them
+	for i, t := range a.rack() {
+		if (letter == '?' && t.IsBlank) || (!t.IsBlank && t.Letter == letter) {
+			delete(a.marked, i)
+		}
+	}
+}
+
+// clear discards pending tiles and exchange marks
+func (a *app) clear(message string) {
+	a.pending = nil
+	a.used = make(map[int]bool)
+	a.marked = make(map[int]bool)
+	a.mode = placing
+	a.message = message
+}
+
+// play submits the pending tiles as a move
+func (a *app) play() {
+	if len(a.pending) == 0 {
+		a.message = a.loc.Text(keyNoTiles)
+		return
+	}
+	score, err := a.game.PlayMove(game.Move{
+		PlayerID:  a.playerID,
+		Direction: a.direction,
+		Tiles:     a.pending,
+	})
+	if err != nil {
+		a.message = a.loc.Text(keyCannotPlay, a.loc.Error(err))
+		return
+	}
+	a.clear(a.loc.Text(keyYouScored, score))
+	a.opponentsMove()
+}
+
+// exchange swaps the marked tiles
+func (a *app) exchange() {
+	indices := make([]int, 0, len(a.marked))
+	for i := range a.rack() {
+		if a.marked[i] {
+			indices = append(indices, i)
+		}
+	}
+	if err := a.game.ExchangeTiles(a.playerID, indices); err != nil {
+		a.clear(a.loc.Text(keyCannotExchange, a.loc.Error(err)))
+		return
+	}
+	a.clear(a.loc.Text(keyYouExchanged, len(indices)))
+	a.opponentsMove()
+}
+
+// pass ends the turn without playing
+func (a *app) pass() {
+	if err := a.game.PassTurn(a.playerID); err != nil {
+		a.clear(a.loc.Text(keyCannotPass, a.loc.Error(err)))
+		return
+	}
+	a.clear(a.loc.Text(keyYouPassed))
+	a.opponentsMove()
+}
+
+// opponentsMove lets the bot play and reports what it did
+func (a *app) opponentsMove() {
+	moves, err := a.game.PlayBotTurns()
+	if err != nil {
+		a.message += " " + a.loc.Text(keyComputerError, a.loc.Error(err))
+		return
+	}
+	for _, m := range moves {
+		a.message += " " + a.loc.Move(m, a.playerName(m.PlayerID))
+	}
+	if a.game.State == game.Finished {
+		a.message += " " + a.loc.Result(a.game.Players)
+	}
+}
+
+// playerName returns the display name of a seat
+func (a *app) playerName(id string) string {
+	for _, p := range a.game.Players {
+		if p.ID == id {
+			return p.Name
+		}
+	}
+	return id
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+
+	"scrabbled/internal/dictionary"
+	"scrabbled/internal/game"
+	"scrabbled/internal/i18n"
+)
+
+// testWords is the small word list used by client tests
+var testWords = []string{"AT", "TA", "CAT", "CATS", "ACT", "SCAT", "DOG", "GOD", "DOGS", "TO", "DO", "GO"}
+
+// newTestApp creates a client on a simulated screen with a known rack
+func newTestApp(t *testing.T, rack string) *app {
+	t.Helper()
+	dict := dictionary.NewWordList(dictionary.Custom)
+	for _, w := range testWords {
+		dict.AddWord(w)
+	}
+	g, err := newLocalGame(dict, "Tester", game.Greedy)
+	if err != nil {
+		t.Fatalf("newLocalGame failed: %v", err)
+	}
+
+	tiles := make([]game.Tile, 0, len(rack))
+	for _, r := range rack {
+		if r == '?' {
+			tiles = append(tiles, game.Tile{IsBlank: true})
+		} else {
+			tiles = append(tiles, game.Tile{Letter: r, Points: game.GetTileValue(r)})
+		}
+	}
+	g.Players[0].Rack = tiles
+
+	screen := tcell.NewSimulationScreen("")
+	if err := screen.Init(); err != nil {
+		t.Fatalf("screen init failed: %v", err)
+	}
+	screen.SetSize(80, 24)
+	t.Cleanup(screen.Fini)
+	return newApp(screen, g, humanID, i18n.NewLocalizer(messages))
+}
+
+// typeKeys sends runes and special keys to the client
+func typeKeys(a *app, keys ...interface{}) {
+	for _, k := range keys {
+		switch k := k.(type) {
+		case string:
+			for _, r := range k {
+				a.handleKey(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone))
+			}
+		case tcell.Key:
+			a.handleKey(tcell.NewEventKey(k, 0, tcell.ModNone))
+		}
+	}
+}
+
+// screenText returns the characters of one screen row
+func screenText(a *app, y int) string {
+	var sb strings.Builder
+	w, _ := a.screen.Size()
+	for x := 0; x < w; x++ {
+		r, _, _, _ := a.screen.GetContent(x, y)
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// TestPlayWord tests placing and playing a word from the keyboard
+func TestPlayWord(t *testing.T) {
+	a := newTestApp(t, "CATXYZQ")
+
+	typeKeys(a, "cat")
+	if len(a.pending) != 3 || a.cursor != (game.Position{Row: 7, Col: 10}) {
+		t.Fatalf("Should place three tiles and advance the cursor, got %d at %v", len(a.pending), a.cursor)
+	}
+
+	a.draw()
+	if row := screenText(a, boardTop+7); !strings.Contains(row, " C  A  T ") {
+		t.Errorf("Pending tiles should be drawn on the board, got %q", row)
+	}
+
+	typeKeys(a, tcell.KeyEnter)
+	if !strings.HasPrefix(a.message, "You scored 10.") {
+		t.Errorf("Should report the score, got %q", a.message)
+	}
+	if a.game.Board.GetTile(game.Position{Row: 7, Col: 7}) == nil || len(a.pending) != 0 {
+		t.Errorf("Tiles should be on the board after playing")
+	}
+	if a.game.CurrentPlayer().ID != humanID {
+		t.Errorf("The computer should have moved, got turn %s", a.game.CurrentPlayer().ID)
+	}
+}
+
+// TestPlacementEditing tests direction, blanks, take-back and invalid input
+func TestPlacementEditing(t *testing.T) {
+	a := newTestApp(t, "DO?XYZQ")
+
+	typeKeys(a, " ", "do", "?g")
+	if a.direction != game.Vertical || len(a.pending) != 3 {
+		t.Fatalf("Should place DOG downwards, got %d tiles", len(a.pending))
+	}
+	if g := a.pending[2].Tile; !g.IsBlank || g.Letter != 'G' {
+		t.Errorf("Blank should be assigned G, got %+v", g)
+	}
+
+	typeKeys(a, tcell.KeyBackspace2)
+	if len(a.pending) != 2 || a.used[2] {
+		t.Errorf("Backspace should return the blank to the rack")
+	}
+
+	typeKeys(a, "e")
+	if !strings.Contains(a.message, "No E") {
+		t.Errorf("Should report missing letters, got %q", a.message)
+	}
+
+	typeKeys(a, tcell.KeyEscape)
+	if len(a.pending) != 0 || len(a.used) != 0 {
+		t.Errorf("Esc should clear pending tiles")
+	}
+}
+
+// TestInvalidWordRejected tests that the dictionary rejects phonies
+func TestInvalidWordRejected(t *testing.T) {
+	a := newTestApp(t, "CATXYZQ")
+
+	typeKeys(a, "tac", tcell.KeyEnter)
+	if !strings.HasPrefix(a.message, "Cannot play") || len(a.pending) != 3 {
+		t.Errorf("Should keep the tiles and report the error, got %q", a.message)
+	}
+}
+
+// TestExchangeAndPass tests exchanging tiles and passing
+func TestExchangeAndPass(t *testing.T) {
+	a := newTestApp(t, "QQXYZCA")
+
+	typeKeys(a, tcell.KeyCtrlX, "qz", tcell.KeyEnter)
+	if !strings.HasPrefix(a.message, "Exchanged 2 tiles.") {
+		t.Errorf("Should exchange the marked tiles, got %q", a.message)
+	}
+	if h := a.game.GetPlayerHistory(humanID); len(h) != 1 || h[0].Type != game.Exchange {
+		t.Errorf("Exchange should be recorded")
+	}
+
+	typeKeys(a, tcell.KeyCtrlP)
+	if !strings.HasPrefix(a.message, "You passed.") {
+		t.Errorf("Should pass, got %q", a.message)
+	}
+
+	typeKeys(a, tcell.KeyCtrlC)
+	if !a.quit {
+		t.Errorf("Ctrl-C should quit")
+	}
+}
+
+// TestParseStrategy tests the -bot flag values
+func TestParseStrategy(t *testing.T) {
+	for name, want := range map[string]game.BotStrategy{"greedy": game.Greedy, "TopN": game.TopNRandom, "equity": game.Equity, "adaptive": game.Adaptive} {
+		if got, err := parseStrategy(name); err != nil || got != want {
+			t.Errorf("parseStrategy(%q) = %v, %v", name, got, err)
+		}
+	}
+	if _, err := parseStrategy("clever"); err == nil {
+		t.Errorf("Should reject unknown strategies")
+	}
+}
+
+// TestLocalizedMessages tests showing messages from a translated catalog,
+// with English for anything it leaves out
+func TestLocalizedMessages(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fr.json")
+	catalog := `{"language": "fr", "messages": {
+		"tui.cannot_play": "Coup impossible : %s",
+		"tui.you_passed": "Vous passez.",
+		"error.invalid_word": "mot non valable"
+	}}`
+	if err := os.WriteFile(path, []byte(catalog), 0o644); err != nil {
+		t.Fatalf("Failed to write catalog: %v", err)
+	}
+	loc, err := loadLocalizer(path)
+	if err != nil {
+		t.Fatalf("loadLocalizer failed: %v", err)
+	}
+
+	a := newTestApp(t, "CATXYZQ")
+	a.loc = loc
+	typeKeys(a, "tac", tcell.KeyEnter)
+	if !strings.HasPrefix(a.message, "Coup impossible : mot non valable") {
+		t.Errorf("Should report the error in French, got %q", a.message)
+	}
+
+	typeKeys(a, tcell.KeyEscape)
+	if a.message != "Cleared." {
+		t.Errorf("Should fall back to English, got %q", a.message)
+	}
+	typeKeys(a, tcell.KeyCtrlP)
+	if !strings.HasPrefix(a.message, "Vous passez. Computer ") {
+		t.Errorf("Should pass in French and describe the reply, got %q", a.message)
+	}
+}
+
+// TestLoadLocalizerErrors tests rejecting catalogs that cannot be used
+func TestLoadLocalizerErrors(t *testing.T) {
+	if _, err := loadLocalizer(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Errorf("Should fail on a missing file")
+	}
+	path := filepath.Join(t.TempDir(), "bad.json")
+	if err := os.WriteFile(path, []byte(`{"language": "fr", "messages": {"tui.you_scored": "Vous marquez %s."}}`), 0o644); err != nil {
+		t.Fatalf("Failed to write catalog: %v", err)
+	}
+	if _, err := loadLocalizer(path); !errors.Is(err, i18n.ErrInvalidCatalog) {
+		t.Errorf("Should reject a message taking the wrong arguments, got %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+// Command scrabbled-tui is a terminal client for playing Scrabble against the
+// computer
+//
+// Usage:
+//
+//	scrabbled-tui -dict words.txt [-name Player] [-bot greedy|topn|equity|adaptive] [-messages catalog.json]
+//
+// Move the cursor with the arrow keys and type letters to place tiles from
+// your rack; press ? then a letter to play a blank. Space switches between
+// across and down, Backspace takes back the last tile, Enter plays the word
+// and Esc clears it. Ctrl-X starts an exchange (type the letters to swap,
+// then Enter), Ctrl-P passes and Ctrl-C quits.
+//
+// Messages are in English unless -messages names a JSON message catalog
+// translating them; see package i18n for the format. Anything the catalog
+// leaves out is shown in English.
+//
+// Only local games against the computer are supported; there is no game
+// server to connect to yet.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+
+	"scrabbled/internal/dictionary"
+	"scrabbled/internal/game"
+)
+
+func main() {
+	dictPath := flag.String("dict", "", "word list file, one word per line (required)")
+	name := flag.String("name", "Player", "your display name")
+	strategy := flag.String("bot", "equity", "computer strategy: greedy, topn, equity or adaptive")
+	catalog := flag.String("messages", "", "message catalog file for a language other than English")
+	flag.Parse()
+
+	if err := run(*dictPath, *name, *strategy, *catalog); err != nil {
+		fmt.Fprintln(os.Stderr, "scrabbled-tui:", err)
+		os.Exit(1)
+	}
+}
+
+// run loads the dictionary, sets up a game against the bot and runs the interface
+func run(dictPath, name, strategy, catalog string) error {
+	if dictPath == "" {
+		return fmt.Errorf("a word list is required (-dict)")
+	}
+	botStrategy, err := parseStrategy(strategy)
+	if err != nil {
+		return err
+	}
+	loc, err := loadLocalizer(catalog)
+	if err != nil {
+		return err
+	}
+	dict, err := dictionary.LoadFile(dictionary.Custom, dictPath)
+	if err != nil {
+		return err
+	}
+
+	g, err := newLocalGame(dict, name, botStrategy)
+	if err != nil {
+		return err
+	}
+
+	screen, err := tcell.NewScreen()
+	if err != nil {
+		return err
+	}
+	if err := screen.Init(); err != nil {
+		return err
+	}
+	defer screen.Fini()
+
+	return newApp(screen, g, humanID, loc).Run()
+}
+
+// Seat IDs in a local game
+const (
+	humanID = "human"
+	botID   = "computer"
+)
+
+// newLocalGame creates a started game between the user and a bot
+func newLocalGame(dict *dictionary.WordList, name string, strategy game.BotStrategy) (*game.Game, error) {
+	g, err := game.NewGame("local", []*game.Player{
+		game.NewPlayer(humanID, name),
+		game.NewPlayer(botID, "Computer"),
+	})
+	if err != nil {
+		return nil, err
+	}
+	g.SetDictionary(dict)
+
+	generator := game.NewMoveGenerator(dict.Words())
+	if err := g.AttachBot(game.NewBot(botID, strategy, generator)); err != nil {
+		return nil, err
+	}
+	if err := g.StartGame(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// parseStrategy converts a -bot flag value to a strategy
+func parseStrategy(s string) (game.BotStrategy, error) {
+	switch strings.ToLower(s) {
+	case "greedy":
+		return game.Greedy, nil
+	case "topn":
+		return game.TopNRandom, nil
+	case "equity":
+		return game.Equity, nil
+	case "adaptive":
+		return game.Adaptive, nil
+	default:
+		return 0, fmt.Errorf("unknown bot strategy %q", s)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"scrabbled/internal/i18n"
+)
+
+// Keys of the client's own messages
+const (
+	keyYourMove       i18n.Key = "tui.your_move"
+	keyCleared        i18n.Key = "tui.cleared"
+	keyExchangePrompt i18n.Key = "tui.exchange_prompt"
+	keyBlankPrompt    i18n.Key = "tui.blank_prompt"
+	keySquareTaken    i18n.Key = "tui.square_taken"
+	keyNotOnRack      i18n.Key = "tui.not_on_rack"     // The missing letter
+	keyNoTiles        i18n.Key = "tui.no_tiles"        // Enter pressed with nothing placed
+	keyCannotPlay     i18n.Key = "tui.cannot_play"     // The engine's reason
+	keyCannotExchange i18n.Key = "tui.cannot_exchange" // The engine's reason
+	keyCannotPass     i18n.Key = "tui.cannot_pass"     // The engine's reason
+	keyYouScored      i18n.Key = "tui.you_scored"      // Score of the play
+	keyYouExchanged   i18n.Key = "tui.you_exchanged"   // Number of tiles exchanged
+	keyYouPassed      i18n.Key = "tui.you_passed"
+	keyComputerError  i18n.Key = "tui.computer_error" // The engine's reason
+	keyBagCount       i18n.Key = "tui.bag_count"      // Tiles left in the bag
+	keyYourRack       i18n.Key = "tui.your_rack"
+	keyGameOver       i18n.Key = "tui.game_over"
+	keyHelp           i18n.Key = "tui.help" // Key bindings, one per line
+)
+
+// messages holds the client's English text; translations of these keys go
+// in the catalog given with -messages
+var messages = &i18n.Catalog{Language: "en", Messages: map[i18n.Key]string{
+	keyYourMove:       "Your move. Type letters to place tiles, Enter to play.",
+	keyCleared:        "Cleared.",
+	keyExchangePrompt: "Exchange: type the letters to swap, then Enter.",
+	keyBlankPrompt:    "Blank: type the letter it stands for.",
+	keySquareTaken:    "That square is taken.",
+	keyNotOnRack:      "No %c on your rack.",
+	keyNoTiles:        "Place some tiles first.",
+	keyCannotPlay:     "Cannot play: %s",
+	keyCannotExchange: "Cannot exchange: %s",
+	keyCannotPass:     "Cannot pass: %s",
+	keyYouScored:      "You scored %d.",
+	keyYouExchanged:   "Exchanged %d tiles.",
+	keyYouPassed:      "You passed.",
+	keyComputerError:  "Computer error: %s",
+	keyBagCount:       "Tiles in bag: %d",
+	keyYourRack:       "Your rack:",
+	keyGameOver:       "Game over - Ctrl-C to quit",
+	keyHelp:           "Arrows move   Space turns\nEnter plays   Esc clears\n? blank       Bksp undoes\n^X exchange   ^P pass\n^C quit",
+}}
+
+// loadLocalizer returns a localizer for the catalog at path, or English when
+// path is empty
+func loadLocalizer(path string) (*i18n.Localizer, error) {
+	if path == "" {
+		return i18n.NewLocalizer(messages), nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	c, err := i18n.ParseCatalog(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := c.Validate(messages); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return i18n.NewLocalizer(c, messages), nil
+}
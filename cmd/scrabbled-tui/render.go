@@ -0,0 +1,175 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+
+	"scrabbled/internal/game"
+)
+
+// Screen layout
+const (
+	boardLeft  = 4 // Columns taken by row numbers
+	boardTop   = 1 // Rows taken by column letters
+	cellWidth  = 3
+	panelLeft  = boardLeft + 15*cellWidth + 3
+	statusLine = boardTop + 15 + 2
+)
+
+// Styles
+var (
+	styleDefault = tcell.StyleDefault
+	styleLabel   = tcell.StyleDefault.Foreground(tcell.ColorGray)
+	styleTile    = tcell.StyleDefault.Background(tcell.ColorOlive).Foreground(tcell.ColorBlack)
+	styleLast    = tcell.StyleDefault.Background(tcell.ColorYellow).Foreground(tcell.ColorBlack)
+	stylePending = tcell.StyleDefault.Background(tcell.ColorGreen).Foreground(tcell.ColorBlack)
+	styleCursor  = tcell.StyleDefault.Reverse(true)
+	styleEmpty   = tcell.StyleDefault.Background(tcell.ColorDarkGreen).Foreground(tcell.ColorWhite)
+)
+
+// premiumStyles colors empty premium squares
+var premiumStyles = map[game.PremiumType]tcell.Style{
+	game.TripleWordScore:   tcell.StyleDefault.Background(tcell.ColorRed).Foreground(tcell.ColorWhite),
+	game.DoubleWordScore:   tcell.StyleDefault.Background(tcell.ColorPink).Foreground(tcell.ColorBlack),
+	game.TripleLetterScore: tcell.StyleDefault.Background(tcell.ColorBlue).Foreground(tcell.ColorWhite),
+	game.DoubleLetterScore: tcell.StyleDefault.Background(tcell.ColorLightBlue).Foreground(tcell.ColorBlack),
+}
+
+// premiumLabels marks empty premium squares
+var premiumLabels = map[game.PremiumType]string{
+	game.TripleWordScore:   "TW",
+	game.DoubleWordScore:   "DW",
+	game.TripleLetterScore: "TL",
+	game.DoubleLetterScore: "DL",
+}
+
+// draw redraws the whole screen
+func (a *app) draw() {
+	a.screen.Clear()
+	view := a.game.View(game.ViewOptions{PlayerID: a.playerID})
+	a.drawBoard(view)
+	a.drawPanel(view)
+	a.drawText(0, statusLine, styleDefault, a.message)
+	a.screen.Show()
+}
+
+// drawBoard draws the grid with labels, the last move and pending tiles
+func (a *app) drawBoard(view game.GameView) {
+	for col := 0; col < 15; col++ {
+		a.drawText(boardLeft+col*cellWidth+1, 0, styleLabel, string(rune('A'+col)))
+	}
+
+	last := map[game.Position]bool{}
+	for i := len(view.History) - 1; i >= 0; i-- {
+		if m := view.History[i]; m.Type == game.PlaceTiles && !m.Withdrawn {
+			for _, pt := range m.Tiles {
+				last[pt.Position] = true
+			}
+			break
+		}
+	}
+
+	for row := 0; row < 15; row++ {
+		a.drawText(0, boardTop+row, styleLabel, fmt.Sprintf("%2d", row+1))
+		for col := 0; col < 15; col++ {
+			pos := game.Position{Row: row, Col: col}
+			text, style := a.cell(view.Board, pos, last[pos])
+			if pos == a.cursor && view.State == game.InProgress {
+				style = style.Reverse(true)
+				if text == "   " {
+					style = styleCursor
+					text = " " + a.directionArrow() + " "
+				}
+			}
+			a.drawText(boardLeft+col*cellWidth, boardTop+row, style, text)
+		}
+	}
+}
+
+// cell returns the text and style of one board square
+func (a *app) cell(b *game.Board, pos game.Position, inLast bool) (string, tcell.Style) {
+	if i := a.pendingAt(pos); i >= 0 {
+		return tileText(a.pending[i].Tile), stylePending
+	}
+	if t := b.GetTile(pos); t != nil {
+		if inLast {
+			return tileText(*t), styleLast
+		}
+		return tileText(*t), styleTile
+	}
+	premium := b.GetPremiumType(pos)
+	if label, ok := premiumLabels[premium]; ok {
+		return label + " ", premiumStyles[premium]
+	}
+	return "   ", styleEmpty
+}
+
+// tileText shows a tile's letter, lower case for blanks
+func tileText(t game.Tile) string {
+	letter := string(t.Letter)
+	if t.IsBlank {
+		letter = strings.ToLower(letter)
+	}
+	return " " + letter + " "
+}
+
+// directionArrow shows the typing direction at the cursor
+func (a *app) directionArrow() string {
+	if a.direction == game.Vertical {
+		return "v"
+	}
+	return ">"
+}
+
+// drawPanel draws scores, the bag count and the player's rack
+func (a *app) drawPanel(view game.GameView) {
+	y := boardTop
+	for _, p := range view.Players {
+		marker := "  "
+		if p.ID == view.CurrentPlayerID {
+			marker = "> "
+		}
+		a.drawText(panelLeft, y, styleDefault, fmt.Sprintf("%s%-12s %4d", marker, p.Name, p.Score))
+		y++
+	}
+	y++
+	a.drawText(panelLeft, y, styleLabel, a.loc.Text(keyBagCount, view.BagCount))
+	y += 2
+
+	a.drawText(panelLeft, y, styleLabel, a.loc.Text(keyYourRack))
+	y++
+	for i, t := range a.rack() {
+		style := styleTile
+		switch {
+		case a.used[i]:
+			style = styleLabel
+		case a.marked[i]:
+			style = stylePending
+		}
+		text := " ? "
+		if !t.IsBlank {
+			text = tileText(t)
+		}
+		a.drawText(panelLeft+i*cellWidth, y, style, text)
+	}
+	y += 2
+
+	if view.State == game.Finished {
+		a.drawText(panelLeft, y, styleDefault, a.loc.Text(keyGameOver))
+		return
+	}
+	for _, help := range strings.Split(a.loc.Text(keyHelp), "\n") {
+		a.drawText(panelLeft, y, styleLabel, help)
+		y++
+	}
+}
+
+// drawText writes a string starting at (x, y)
+func (a *app) drawText(x, y int, style tcell.Style, text string) {
+	for _, r := range text {
+		a.screen.SetContent(x, y, r, nil, style)
+		x++
+	}
+}
@@ -0,0 +1,179 @@
+// Command scrabbled-vision trains board vision: it shows mid-game positions
+// from self-play games and asks for any play reaching a target score within a
+// time limit
+//
+// Usage:
+//
+//	scrabbled-vision -dict words.txt [-n N] [-target POINTS] [-limit DURATION] [-seed N]
+//
+// Answer with a play in GCG notation, such as "8D CAT" across or "D8 cAT"
+// down with blanks in lower case; an empty line skips the position and q ends
+// the session. Each answer is scored by the move generator and compared with
+// the best play, and the session ends with the number of positions solved.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"strings"
+	"time"
+
+	"scrabbled/internal/dictionary"
+	"scrabbled/internal/game"
+	"scrabbled/internal/puzzle"
+)
+
+// now reads the clock that times answers; tests replace it
+var now = time.Now
+
+// options holds the command-line settings
+type options struct {
+	dictPath string
+	n        int
+	target   int
+	limit    time.Duration
+	seed     int64
+}
+
+func main() {
+	var opts options
+	flag.StringVar(&opts.dictPath, "dict", "", "word list file, one word per line (required)")
+	flag.IntVar(&opts.n, "n", 5, "number of positions")
+	flag.IntVar(&opts.target, "target", puzzle.DefaultVisionTarget, "score a play must reach")
+	flag.DurationVar(&opts.limit, "limit", puzzle.DefaultVisionTimeLimit, "time allowed for each position")
+	flag.Int64Var(&opts.seed, "seed", 0, "random seed (default from the clock)")
+	flag.Parse()
+
+	if err := run(opts, os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "scrabbled-vision:", err)
+		os.Exit(1)
+	}
+}
+
+// run sets the exercises, puts each to the player and prints the results
+func run(opts options, in io.Reader, w io.Writer) error {
+	if opts.dictPath == "" {
+		return errors.New("a word list is required (-dict)")
+	}
+	if opts.n <= 0 || opts.target <= 0 || opts.limit <= 0 {
+		return errors.New("-n, -target and -limit must be positive")
+	}
+	if opts.seed == 0 {
+		opts.seed = time.Now().UnixNano()
+	}
+
+	wl, err := dictionary.LoadFile(dictionary.Custom, opts.dictPath)
+	if err != nil {
+		return err
+	}
+	gen, err := game.NewMoveGeneratorForDictionary(wl)
+	if err != nil {
+		return err
+	}
+	trainer := puzzle.NewVisionTrainer(gen, opts.seed)
+	trainer.Target = opts.target
+	trainer.TimeLimit = opts.limit
+	exercises, err := pickExercises(trainer, opts.n, opts.seed)
+	if err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(in)
+	solved := 0
+	for i, v := range exercises {
+		fmt.Fprintf(w, "\nPosition %d of %d\n%s\n", i+1, len(exercises), v.Board)
+		fmt.Fprintf(w, "Rack %s: find a play worth %d or more in %s\n", rackString(v.Rack), v.Target, v.TimeLimit)
+		result, ok := answer(trainer, v, scanner, w)
+		if !ok {
+			break
+		}
+		if result.Solved {
+			solved++
+		}
+		best := v.Best
+		fmt.Fprintf(w, "Best: %s for %d (%d plays reach %d)\n", best.Notation(), best.Score, v.Plays, v.Target)
+	}
+	fmt.Fprintf(w, "\nSolved %d of %d\n", solved, len(exercises))
+	return nil
+}
+
+// pickExercises plays a self-play game per exercise and picks one of its
+// positions at random
+func pickExercises(trainer *puzzle.VisionTrainer, n int, seed int64) ([]puzzle.Vision, error) {
+	rng := rand.New(rand.NewSource(seed))
+	var picked []puzzle.Vision
+	for tries := 0; len(picked) < n && tries < 4*n; tries++ {
+		found, err := trainer.FromRandomGames(1)
+		if err != nil {
+			return nil, err
+		}
+		if len(found) > 0 {
+			picked = append(picked, found[rng.Intn(len(found))])
+		}
+	}
+	if len(picked) == 0 {
+		return nil, errors.New("no positions offer a play reaching the target")
+	}
+	return picked, nil
+}
+
+// answer reads plays until one parses, then marks it; returns false on q or
+// at the end of input, and a zero result when the position is skipped
+func answer(trainer *puzzle.VisionTrainer, v puzzle.Vision, scanner *bufio.Scanner, w io.Writer) (puzzle.VisionResult, bool) {
+	asked := now()
+	for {
+		fmt.Fprint(w, "> ")
+		if !scanner.Scan() {
+			fmt.Fprintln(w)
+			return puzzle.VisionResult{}, false
+		}
+		fields := strings.Fields(scanner.Text())
+		switch {
+		case len(fields) == 0:
+			fmt.Fprintln(w, "Skipped")
+			return puzzle.VisionResult{}, true
+		case len(fields) == 1 && strings.EqualFold(fields[0], "q"):
+			return puzzle.VisionResult{}, false
+		case len(fields) != 2:
+			fmt.Fprintln(w, "Give coordinates and a play, such as 8D CAT")
+			continue
+		}
+
+		move, err := v.Board.ParsePlay("", fields[0], fields[1])
+		if err != nil {
+			fmt.Fprintln(w, err)
+			continue
+		}
+		elapsed := now().Sub(asked)
+		r := trainer.Check(v, move, elapsed)
+		switch {
+		case !r.Legal:
+			fmt.Fprintf(w, "%s is not a legal play from your rack\n", fields[1])
+		case r.Solved:
+			fmt.Fprintf(w, "Solved: %d points, rank %d, in %s\n", r.Score, r.Rank, elapsed.Round(time.Second))
+		case !r.InTime:
+			fmt.Fprintf(w, "Too late: %d points, rank %d, in %s\n", r.Score, r.Rank, elapsed.Round(time.Second))
+		default:
+			fmt.Fprintf(w, "Short: %d points, rank %d\n", r.Score, r.Rank)
+		}
+		return r, true
+	}
+}
+
+// rackString writes tiles with '?' for blanks
+func rackString(tiles []game.Tile) string {
+	var sb strings.Builder
+	for _, t := range tiles {
+		if t.IsBlank {
+			sb.WriteRune('?')
+		} else {
+			sb.WriteRune(t.Letter)
+		}
+	}
+	return sb.String()
+}
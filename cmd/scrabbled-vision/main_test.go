@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"scrabbled/internal/game"
+	"scrabbled/internal/puzzle"
+)
+
+// testWords is a small lexicon that self-play games can be built from
+var testWords = []string{
+	"AT", "TA", "AS", "IS", "IT", "TI", "NA", "AN", "IN", "RE", "ER", "ES", "OE", "EN", "NE",
+	"EAT", "TEA", "ATE", "SAT", "SIT", "TIN", "TAN", "RAT", "TAR", "ART", "STAR", "RATS",
+	"NOTE", "TONE", "ONE", "EON", "TOE", "RIOT", "RATIO", "SENOR", "SNORE", "STONE",
+	"ZA", "QI", "ZAS", "QIS", "ZIT", "ZITS", "OX", "XI", "AX", "EX",
+}
+
+// vision runs the command with a clock that advances a second per reading
+func vision(t *testing.T, opts options, input string) (string, error) {
+	t.Helper()
+	clock := time.Unix(0, 0)
+	now = func() time.Time {
+		clock = clock.Add(time.Second)
+		return clock
+	}
+	t.Cleanup(func() { now = time.Now })
+
+	var out bytes.Buffer
+	err := run(opts, strings.NewReader(input), &out)
+	return out.String(), err
+}
+
+// testOptions writes the test lexicon and returns options using it
+func testOptions(t *testing.T) options {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "words.txt")
+	if err := os.WriteFile(path, []byte(strings.Join(testWords, "\n")), 0o644); err != nil {
+		t.Fatalf("Failed to write words: %v", err)
+	}
+	return options{dictPath: path, n: 1, target: 10, limit: time.Minute, seed: 7}
+}
+
+// exercises returns the positions run sets for opts
+func exercises(t *testing.T, opts options) []puzzle.Vision {
+	t.Helper()
+	trainer := puzzle.NewVisionTrainer(game.NewMoveGenerator(testWords), opts.seed)
+	trainer.Target, trainer.TimeLimit = opts.target, opts.limit
+	found, err := pickExercises(trainer, opts.n, opts.seed)
+	if err != nil {
+		t.Fatalf("Failed to set exercises: %v", err)
+	}
+	return found
+}
+
+// TestRunSolve tests solving a position with its best play
+func TestRunSolve(t *testing.T) {
+	opts := testOptions(t)
+	best := exercises(t, opts)[0].Best
+	input := "8H\n" + best.Notation() + "\n"
+
+	out, err := vision(t, opts, input)
+	if err != nil {
+		t.Fatalf("run failed: %v\n%s", err, out)
+	}
+	for _, want := range []string{"Position 1 of 1", "Give coordinates and a play", "Solved: ", "rank 1", "Solved 1 of 1"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+// TestRunIllegalSkipAndQuit tests rejecting a play from tiles not held,
+// skipping and quitting
+func TestRunIllegalSkipAndQuit(t *testing.T) {
+	opts := testOptions(t)
+	out, err := vision(t, opts, "A1 QQQ\n")
+	if err != nil {
+		t.Fatalf("run failed: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "QQQ is not a legal play") || !strings.Contains(out, "Solved 0 of") {
+		t.Errorf("Expected the play to be rejected, got:\n%s", out)
+	}
+
+	out, _ = vision(t, opts, "\n")
+	if !strings.Contains(out, "Skipped") || !strings.Contains(out, "Best:") {
+		t.Errorf("Expected the position to be skipped, got:\n%s", out)
+	}
+
+	out, _ = vision(t, opts, "q\nA1 QQQ\n")
+	if strings.Contains(out, "Best:") || strings.Contains(out, "QQQ") {
+		t.Errorf("Expected q to end the session, got:\n%s", out)
+	}
+}
+
+// TestRunErrors tests rejecting missing word lists and bad settings
+func TestRunErrors(t *testing.T) {
+	if _, err := vision(t, options{n: 1, target: 10, limit: time.Minute}, ""); err == nil {
+		t.Errorf("Should require a word list")
+	}
+	opts := testOptions(t)
+	opts.limit = 0
+	if _, err := vision(t, opts, ""); err == nil {
+		t.Errorf("Should reject a zero time limit")
+	}
+	opts = testOptions(t)
+	opts.target = 1000
+	if _, err := vision(t, opts, ""); err == nil {
+		t.Errorf("Should fail when no position reaches the target")
+	}
+}
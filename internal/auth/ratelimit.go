@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned when a player or address acts too often
+var ErrRateLimited = errors.New("too many requests")
+
+// Action is a kind of request rate limited on its own
+type Action int
+
+const (
+	SubmitMove Action = iota // Plays, exchanges, passes and challenges
+	SendChat                 // Chat messages
+	CreateGame               // New games
+)
+
+// String returns a string representation of the action
+func (a Action) String() string {
+	switch a {
+	case SubmitMove:
+		return "SUBMIT_MOVE"
+	case SendChat:
+		return "SEND_CHAT"
+	case CreateGame:
+		return "CREATE_GAME"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// Limit bounds how often one player, or one address, may take an action
+// Up to Burst requests are allowed at once, after which one more is allowed
+// each Cooldown.
+type Limit struct {
+	Burst    int
+	Cooldown time.Duration
+}
+
+// DefaultLimits are limits suited to a public deployment
+var DefaultLimits = map[Action]Limit{
+	SubmitMove: {Burst: 10, Cooldown: time.Second},
+	SendChat:   {Burst: 5, Cooldown: 2 * time.Second},
+	CreateGame: {Burst: 3, Cooldown: time.Minute},
+}
+
+// bucketKey identifies the requests counted together
+type bucketKey struct {
+	action Action
+	key    string // "player:" or "addr:" followed by the player ID or address
+}
+
+// bucket holds the requests a key may still make, refilling over time
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Limiter applies per-player and per-address rate limits to actions
+// Each action is limited separately, and a request must be within both the
+// player's and the address's limit. Actions without a limit are not limited.
+type Limiter struct {
+	limits  map[Action]Limit
+	buckets map[bucketKey]*bucket
+	now     func() time.Time
+	mu      sync.Mutex
+}
+
+// NewLimiter creates a limiter with the given limits by action
+func NewLimiter(limits map[Action]Limit) (*Limiter, error) {
+	copied := make(map[Action]Limit, len(limits))
+	for action, limit := range limits {
+		if limit.Burst < 1 || limit.Cooldown <= 0 {
+			return nil, fmt.Errorf("%s limit needs a positive burst and cooldown, got %d and %s", action, limit.Burst, limit.Cooldown)
+		}
+		copied[action] = limit
+	}
+	return &Limiter{limits: copied, buckets: make(map[bucketKey]*bucket), now: time.Now}, nil
+}
+
+// Allow counts a request for an action by a player from an address, returning
+// an error wrapping ErrRateLimited if either is over its limit
+// Rejected requests are not counted. An empty player ID or address is not
+// limited on its own.
+func (l *Limiter) Allow(action Action, playerID, addr string) error {
+	limit, ok := l.limits[action]
+	if !ok {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	var counted []*bucket
+	for _, key := range []string{keyFor("player:", playerID), keyFor("addr:", addr)} {
+		if key == "" {
+			continue
+		}
+		b := l.refill(bucketKey{action: action, key: key}, limit, now)
+		if b.tokens < 1 {
+			wait := time.Duration((1 - b.tokens) * float64(limit.Cooldown))
+			return fmt.Errorf("%w: %s for %s, retry in %s", ErrRateLimited, action, key, wait.Round(time.Millisecond))
+		}
+		counted = append(counted, b)
+	}
+	for _, b := range counted {
+		b.tokens--
+	}
+	return nil
+}
+
+// keyFor returns the bucket key for an identifier, or "" if it is empty
+func keyFor(prefix, id string) string {
+	if id == "" {
+		return ""
+	}
+	return prefix + id
+}
+
+// refill returns a key's bucket topped up for the time since it was last
+// used; callers must hold the lock
+func (l *Limiter) refill(key bucketKey, limit Limit, now time.Time) *bucket {
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), last: now}
+		l.buckets[key] = b
+		return b
+	}
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = min(float64(limit.Burst), b.tokens+float64(elapsed)/float64(limit.Cooldown))
+		b.last = now
+	}
+	return b
+}
+
+// Prune forgets players and addresses whose limits have fully refilled,
+// returning how many were removed
+// Call it periodically on long-running servers to bound memory use.
+func (l *Limiter) Prune() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	removed := 0
+	for key := range l.buckets {
+		limit := l.limits[key.action]
+		if l.refill(key, limit, now).tokens >= float64(limit.Burst) {
+			delete(l.buckets, key)
+			removed++
+		}
+	}
+	return removed
+}
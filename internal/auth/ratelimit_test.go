@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// newTestLimiter creates a limiter whose clock reads *now
+func newTestLimiter(t *testing.T, limits map[Action]Limit, now *time.Time) *Limiter {
+	t.Helper()
+	l, err := NewLimiter(limits)
+	if err != nil {
+		t.Fatalf("NewLimiter failed: %v", err)
+	}
+	l.now = func() time.Time { return *now }
+	return l
+}
+
+// TestLimiterBurstAndCooldown tests that requests refill one per cooldown up to the burst
+func TestLimiterBurstAndCooldown(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	l := newTestLimiter(t, map[Action]Limit{SubmitMove: {Burst: 2, Cooldown: time.Second}}, &now)
+
+	for i := 0; i < 2; i++ {
+		if err := l.Allow(SubmitMove, "alice", ""); err != nil {
+			t.Fatalf("Request %d should be within the burst: %v", i+1, err)
+		}
+	}
+	if err := l.Allow(SubmitMove, "alice", ""); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Should limit requests past the burst, got %v", err)
+	}
+
+	now = now.Add(time.Second)
+	if err := l.Allow(SubmitMove, "alice", ""); err != nil {
+		t.Errorf("Should allow a request after the cooldown: %v", err)
+	}
+	if err := l.Allow(SubmitMove, "alice", ""); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Should allow only one request per cooldown, got %v", err)
+	}
+
+	now = now.Add(time.Hour)
+	for i := 0; i < 2; i++ {
+		if err := l.Allow(SubmitMove, "alice", ""); err != nil {
+			t.Errorf("Should refill to the burst after a long wait: %v", err)
+		}
+	}
+	if err := l.Allow(SubmitMove, "alice", ""); err == nil {
+		t.Errorf("Should not refill beyond the burst")
+	}
+}
+
+// TestLimiterKeys tests that players, addresses and actions are limited separately
+func TestLimiterKeys(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	l := newTestLimiter(t, map[Action]Limit{
+		SubmitMove: {Burst: 1, Cooldown: time.Minute},
+		CreateGame: {Burst: 1, Cooldown: time.Minute},
+	}, &now)
+
+	if err := l.Allow(SubmitMove, "alice", "10.0.0.1"); err != nil {
+		t.Fatalf("First request should be allowed: %v", err)
+	}
+	if err := l.Allow(CreateGame, "alice", "10.0.0.1"); err != nil {
+		t.Errorf("Actions should have separate limits: %v", err)
+	}
+	if err := l.Allow(SubmitMove, "alice", "10.0.0.2"); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Changing address should not escape the player's limit, got %v", err)
+	}
+	if err := l.Allow(SubmitMove, "bob", "10.0.0.1"); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Changing player should not escape the address's limit, got %v", err)
+	}
+	if err := l.Allow(SubmitMove, "bob", "10.0.0.2"); err != nil {
+		t.Errorf("A rejected request should not count against the other key: %v", err)
+	}
+	if err := l.Allow(SendChat, "alice", "10.0.0.1"); err != nil {
+		t.Errorf("Actions without a limit should be allowed: %v", err)
+	}
+}
+
+// TestLimiterPrune tests forgetting keys whose limits have refilled
+func TestLimiterPrune(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	l := newTestLimiter(t, map[Action]Limit{SubmitMove: {Burst: 2, Cooldown: time.Second}}, &now)
+
+	l.Allow(SubmitMove, "alice", "10.0.0.1")
+	if n := l.Prune(); n != 0 {
+		t.Errorf("Should keep keys still cooling down, removed %d", n)
+	}
+	now = now.Add(time.Second)
+	if n := l.Prune(); n != 2 || len(l.buckets) != 0 {
+		t.Errorf("Should remove both refilled keys, removed %d", n)
+	}
+}
+
+// TestNewLimiterErrors tests that unusable limits are rejected
+func TestNewLimiterErrors(t *testing.T) {
+	for _, limit := range []Limit{{Burst: 0, Cooldown: time.Second}, {Burst: 1}} {
+		if _, err := NewLimiter(map[Action]Limit{SendChat: limit}); err == nil {
+			t.Errorf("Should reject limit %+v", limit)
+		}
+	}
+	if _, err := NewLimiter(DefaultLimits); err != nil {
+		t.Errorf("Default limits should be valid: %v", err)
+	}
+}
+
+// TestSeatRateLimit tests that seats apply the move limit
+func TestSeatRateLimit(t *testing.T) {
+	g := newSeatedGame(t)
+	now := time.Unix(1700000000, 0)
+	l := newTestLimiter(t, map[Action]Limit{SubmitMove: {Burst: 1, Cooldown: time.Minute}}, &now)
+	alice, _ := Bind(g, Claims{PlayerID: "alice"})
+	alice.LimitRate(l, "10.0.0.1")
+
+	if err := alice.PassTurn(); err != nil {
+		t.Fatalf("First move should be allowed: %v", err)
+	}
+	if err := alice.ExchangeTiles([]int{0}); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Second move within the cooldown should be limited, got %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+package auth
+
+import (
+	"errors"
+
+	"scrabbled/internal/game"
+)
+
+// Errors returned when binding identities to games
+var (
+	ErrNotInGame = errors.New("player is not seated in this game")
+	ErrWrongGame = errors.New("token is not valid for this game")
+	ErrForbidden = errors.New("action is not permitted for this player")
+)
+
+// Seat is an authenticated player's handle on a game
+// Every action is taken as the authenticated player, so a client cannot move
+// for someone else, and views reveal only the player's own rack.
+type Seat struct {
+	claims  Claims
+	game    *game.Game
+	limiter *Limiter // Rate limits on moves (nil when unlimited)
+	addr    string   // Client address the limits are also applied to
+}
+
+// Bind returns the seat of the authenticated player in a game
+func Bind(g *game.Game, claims Claims) (*Seat, error) {
+	if claims.GameID != "" && claims.GameID != g.ID {
+		return nil, ErrWrongGame
+	}
+	if g.GetPlayer(claims.PlayerID) == nil {
+		return nil, ErrNotInGame
+	}
+	return &Seat{claims: claims, game: g}, nil
+}
+
+// PlayerID returns the authenticated player's ID
+func (s *Seat) PlayerID() string {
+	return s.claims.PlayerID
+}
+
+// LimitRate applies the limiter's SubmitMove limit to the seat's moves, for
+// the player and for the client address they connected from
+// Moves over the limit fail with an error wrapping ErrRateLimited.
+func (s *Seat) LimitRate(l *Limiter, addr string) {
+	s.limiter = l
+	s.addr = addr
+}
+
+// allowMove counts a move submission against the seat's rate limits
+func (s *Seat) allowMove() error {
+	if s.limiter == nil {
+		return nil
+	}
+	return s.limiter.Allow(SubmitMove, s.claims.PlayerID, s.addr)
+}
+
+// PlayMove plays a move as the seated player
+// A move naming a different player is rejected with ErrForbidden.
+func (s *Seat) PlayMove(move game.Move) (int, error) {
+	if move.PlayerID != "" && move.PlayerID != s.claims.PlayerID {
+		return 0, ErrForbidden
+	}
+	if err := s.allowMove(); err != nil {
+		return 0, err
+	}
+	move.PlayerID = s.claims.PlayerID
+	return s.game.PlayMove(move)
+}
+
+// ExchangeTiles exchanges the seated player's tiles at the given rack indices
+func (s *Seat) ExchangeTiles(indices []int) error {
+	if err := s.allowMove(); err != nil {
+		return err
+	}
+	return s.game.ExchangeTiles(s.claims.PlayerID, indices)
+}
+
+// PassTurn passes the seated player's turn
+func (s *Seat) PassTurn() error {
+	if err := s.allowMove(); err != nil {
+		return err
+	}
+	return s.game.PassTurn(s.claims.PlayerID)
+}
+
+// Challenge challenges the most recent move as the seated player
+func (s *Seat) Challenge() (game.ChallengeResult, error) {
+	if err := s.allowMove(); err != nil {
+		return game.ChallengeResult{}, err
+	}
+	return s.game.Challenge(s.claims.PlayerID)
+}
+
+// Rack returns the seated player's tiles
+func (s *Seat) Rack() []game.Tile {
+	for _, p := range s.View().Players {
+		if p.ID == s.claims.PlayerID {
+			return p.Rack
+		}
+	}
+	return nil
+}
+
+// View returns the game as the seated player may see it, with other racks hidden
+func (s *Seat) View() game.GameView {
+	return s.game.View(game.ViewOptions{PlayerID: s.claims.PlayerID})
+}
+
+// Subscribe delivers live views as the seated player may see them
+func (s *Seat) Subscribe() *game.Subscription {
+	return s.game.Subscribe(game.ViewOptions{PlayerID: s.claims.PlayerID})
+}
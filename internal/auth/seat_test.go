@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"testing"
+
+	"scrabbled/internal/game"
+)
+
+// newSeatedGame creates a started game between alice and bob
+func newSeatedGame(t *testing.T) *game.Game {
+	t.Helper()
+	g, err := game.NewGame("g1", []*game.Player{
+		game.NewPlayer("alice", "Alice"),
+		game.NewPlayer("bob", "Bob"),
+	})
+	if err != nil {
+		t.Fatalf("NewGame failed: %v", err)
+	}
+	g.StartGame()
+	return g
+}
+
+// TestBind tests binding identities to games
+func TestBind(t *testing.T) {
+	g := newSeatedGame(t)
+
+	if _, err := Bind(g, Claims{PlayerID: "alice"}); err != nil {
+		t.Errorf("Should bind a seated player: %v", err)
+	}
+	if _, err := Bind(g, Claims{PlayerID: "alice", GameID: "g1"}); err != nil {
+		t.Errorf("Should bind a token scoped to this game: %v", err)
+	}
+	if _, err := Bind(g, Claims{PlayerID: "alice", GameID: "g2"}); err != ErrWrongGame {
+		t.Errorf("Should reject a token scoped to another game, got %v", err)
+	}
+	if _, err := Bind(g, Claims{PlayerID: "mallory"}); err != ErrNotInGame {
+		t.Errorf("Should reject players not in the game, got %v", err)
+	}
+}
+
+// TestSeatActsAsPlayer tests that seats cannot act for other players
+func TestSeatActsAsPlayer(t *testing.T) {
+	g := newSeatedGame(t)
+	alice, _ := Bind(g, Claims{PlayerID: "alice"})
+	bob, _ := Bind(g, Claims{PlayerID: "bob"})
+
+	if _, err := bob.PlayMove(game.Move{PlayerID: "alice"}); err != ErrForbidden {
+		t.Errorf("Should reject a move submitted for another player, got %v", err)
+	}
+	if err := bob.PassTurn(); err != game.ErrNotPlayersTurn {
+		t.Errorf("Bob should not be able to pass on Alice's turn, got %v", err)
+	}
+	if err := alice.PassTurn(); err != nil {
+		t.Errorf("Alice should be able to pass: %v", err)
+	}
+	if err := bob.ExchangeTiles([]int{0}); err != nil {
+		t.Errorf("Bob should be able to exchange on their turn: %v", err)
+	}
+}
+
+// TestSeatHidesOtherRacks tests that a seat only reveals the player's own rack
+func TestSeatHidesOtherRacks(t *testing.T) {
+	g := newSeatedGame(t)
+	alice, _ := Bind(g, Claims{PlayerID: "alice"})
+
+	if len(alice.Rack()) != game.MaxRackSize {
+		t.Errorf("Alice should see their own rack")
+	}
+	v := alice.View()
+	if v.Players[1].Rack != nil || v.Players[1].RackSize != game.MaxRackSize {
+		t.Errorf("Alice should see only the size of Bob's rack")
+	}
+
+	sub := alice.Subscribe()
+	defer sub.Close()
+	if update := <-sub.Updates; update.Players[1].Rack != nil || update.Players[0].Rack == nil {
+		t.Errorf("Live updates should redact other racks")
+	}
+}
@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultTokenLifetime is how long issued tokens remain valid
+const DefaultTokenLifetime = 24 * time.Hour
+
+// MinSecretLength is the minimum signing key size in bytes
+const MinSecretLength = 32
+
+// Errors returned by token verification
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrTokenExpired = errors.New("token has expired")
+)
+
+// Claims identify the player a token was issued to
+type Claims struct {
+	PlayerID  string `json:"sub"`           // Player the token authenticates
+	Name      string `json:"name"`          // Display name
+	GameID    string `json:"gid,omitempty"` // Game the token is limited to (empty for any game)
+	IssuedAt  int64  `json:"iat"`           // Unix seconds
+	ExpiresAt int64  `json:"exp"`           // Unix seconds
+}
+
+// Expires returns the time the claims stop being valid
+func (c Claims) Expires() time.Time {
+	return time.Unix(c.ExpiresAt, 0)
+}
+
+// tokenHeader is the fixed JWT header for HMAC-SHA256 tokens
+var tokenHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// Issuer signs and verifies player tokens
+// Tokens are JSON Web Tokens signed with HMAC-SHA256, so any server holding
+// the secret can verify them without shared session state.
+type Issuer struct {
+	secret   []byte
+	Lifetime time.Duration
+	now      func() time.Time
+}
+
+// NewIssuer creates an issuer signing with secret, which must be at least MinSecretLength bytes
+func NewIssuer(secret []byte) (*Issuer, error) {
+	if len(secret) < MinSecretLength {
+		return nil, fmt.Errorf("secret must be at least %d bytes, got %d", MinSecretLength, len(secret))
+	}
+	key := make([]byte, len(secret))
+	copy(key, secret)
+	return &Issuer{secret: key, Lifetime: DefaultTokenLifetime, now: time.Now}, nil
+}
+
+// Issue creates a token for a player, optionally limited to one game
+func (iss *Issuer) Issue(playerID, name, gameID string) (string, error) {
+	if playerID == "" {
+		return "", errors.New("player ID must not be empty")
+	}
+	now := iss.now()
+	payload, err := json.Marshal(Claims{
+		PlayerID:  playerID,
+		Name:      name,
+		GameID:    gameID,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(iss.Lifetime).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signed := tokenHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return signed + "." + iss.sign(signed), nil
+}
+
+// Verify checks a token's signature and expiry and returns its claims
+func (iss *Issuer) Verify(token string) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != tokenHeader {
+		return Claims{}, ErrInvalidToken
+	}
+	if !hmac.Equal([]byte(parts[2]), []byte(iss.sign(parts[0]+"."+parts[1]))) {
+		return Claims{}, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return Claims{}, ErrInvalidToken
+	}
+	var claims Claims
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.PlayerID == "" {
+		return Claims{}, ErrInvalidToken
+	}
+	if !iss.now().Before(claims.Expires()) {
+		return Claims{}, ErrTokenExpired
+	}
+	return claims, nil
+}
+
+// sign returns the encoded HMAC-SHA256 signature of the signing input
+func (iss *Issuer) sign(input string) string {
+	mac := hmac.New(sha256.New, iss.secret)
+	mac.Write([]byte(input))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// testSecret is a signing key long enough for NewIssuer
+var testSecret = []byte("0123456789abcdef0123456789abcdef")
+
+// newTestIssuer creates an issuer with a controllable clock
+func newTestIssuer(t *testing.T, now *time.Time) *Issuer {
+	t.Helper()
+	iss, err := NewIssuer(testSecret)
+	if err != nil {
+		t.Fatalf("NewIssuer failed: %v", err)
+	}
+	iss.now = func() time.Time { return *now }
+	return iss
+}
+
+// TestIssueAndVerify tests that issued tokens verify to their claims
+func TestIssueAndVerify(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	iss := newTestIssuer(t, &now)
+
+	token, err := iss.Issue("alice", "Alice", "g1")
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+	if strings.Count(token, ".") != 2 {
+		t.Errorf("Token should have three JWT segments, got %q", token)
+	}
+
+	claims, err := iss.Verify(token)
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if claims.PlayerID != "alice" || claims.Name != "Alice" || claims.GameID != "g1" {
+		t.Errorf("Should return the issued claims, got %+v", claims)
+	}
+	if !claims.Expires().Equal(now.Add(DefaultTokenLifetime)) {
+		t.Errorf("Should expire after the default lifetime, got %v", claims.Expires())
+	}
+
+	if _, err := iss.Issue("", "Nobody", ""); err == nil {
+		t.Errorf("Should refuse to issue a token without a player ID")
+	}
+}
+
+// TestVerifyRejectsTampering tests that modified or foreign tokens are rejected
+func TestVerifyRejectsTampering(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	iss := newTestIssuer(t, &now)
+	token, _ := iss.Issue("alice", "Alice", "")
+	bobToken, _ := iss.Issue("bob", "Bob", "")
+
+	parts := strings.Split(token, ".")
+	bobParts := strings.Split(bobToken, ".")
+	forged := parts[0] + "." + bobParts[1] + "." + parts[2]
+
+	other, _ := NewIssuer([]byte("another secret that is long enough!"))
+	other.now = iss.now
+	foreign, _ := other.Issue("alice", "Alice", "")
+
+	for name, tok := range map[string]string{
+		"empty":     "",
+		"garbage":   "not.a.token",
+		"forged":    forged,
+		"foreign":   foreign,
+		"truncated": parts[0] + "." + parts[1],
+	} {
+		if _, err := iss.Verify(tok); err != ErrInvalidToken {
+			t.Errorf("%s token should be invalid, got %v", name, err)
+		}
+	}
+}
+
+// TestVerifyExpired tests that tokens stop verifying after they expire
+func TestVerifyExpired(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	iss := newTestIssuer(t, &now)
+	iss.Lifetime = time.Hour
+	token, _ := iss.Issue("alice", "Alice", "")
+
+	now = now.Add(59 * time.Minute)
+	if _, err := iss.Verify(token); err != nil {
+		t.Errorf("Token should be valid before expiry: %v", err)
+	}
+	now = now.Add(time.Minute)
+	if _, err := iss.Verify(token); err != ErrTokenExpired {
+		t.Errorf("Token should expire after its lifetime, got %v", err)
+	}
+}
+
+// TestNewIssuerShortSecret tests that weak signing keys are refused
+func TestNewIssuerShortSecret(t *testing.T) {
+	if _, err := NewIssuer([]byte("short")); err == nil {
+		t.Errorf("Should reject secrets shorter than %d bytes", MinSecretLength)
+	}
+}
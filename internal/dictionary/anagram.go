@@ -0,0 +1,120 @@
+package dictionary
+
+import (
+	"sort"
+	"strings"
+)
+
+// Blank is the rack character standing for a blank tile in anagram queries
+const Blank = '?'
+
+// Alphagram returns the letters of a word in alphabetical order
+func Alphagram(word string) string {
+	letters := []rune(Normalize(word))
+	sort.Slice(letters, func(i, j int) bool { return letters[i] < letters[j] })
+	return string(letters)
+}
+
+// Anagrams returns every word that uses all of the given letters, in
+// alphabetical order
+// A '?' in letters is a blank that may stand for any letter.
+func (wl *WordList) Anagrams(letters string) []string {
+	letters = Normalize(letters)
+	if !strings.ContainsRune(letters, Blank) {
+		wl.mu.RLock()
+		found := append([]string{}, wl.alphagram[Alphagram(letters)]...)
+		wl.mu.RUnlock()
+
+		sort.Strings(found)
+		return found
+	}
+
+	length := len([]rune(letters))
+	return wl.filter(func(word string) bool {
+		return len([]rune(word)) == length && canSpell(word, letters)
+	}, byAlphabet)
+}
+
+// SubAnagrams returns every word that can be made from some or all of the
+// given letters, longest first
+// A '?' in letters is a blank that may stand for any letter.
+func (wl *WordList) SubAnagrams(letters string) []string {
+	letters = Normalize(letters)
+	return wl.filter(func(word string) bool {
+		return canSpell(word, letters)
+	}, byLength)
+}
+
+// PlayThrough returns every word containing the letters already on the
+// board (through, which must be contiguous) plus at least one tile from the
+// rack, longest first
+// For example, PlayThrough("AEINRST", "E") finds ARSENITE and STEARINE
+// through an E on the board. A '?' in rack is a blank.
+func (wl *WordList) PlayThrough(rack, through string) []string {
+	rack, through = Normalize(rack), Normalize(through)
+	if through == "" {
+		return wl.SubAnagrams(rack)
+	}
+
+	return wl.filter(func(word string) bool {
+		if len(word) <= len(through) {
+			return false
+		}
+		for i := strings.Index(word, through); i >= 0; {
+			if canSpell(word[:i]+word[i+len(through):], rack) {
+				return true
+			}
+			next := strings.Index(word[i+1:], through)
+			if next < 0 {
+				break
+			}
+			i += next + 1
+		}
+		return false
+	}, byLength)
+}
+
+// Orderings for query results
+var (
+	byAlphabet = func(a, b string) bool { return a < b }
+	byLength   = func(a, b string) bool {
+		if len(a) != len(b) {
+			return len(a) > len(b)
+		}
+		return a < b
+	}
+)
+
+// filter returns the words matching keep, sorted by less
+func (wl *WordList) filter(keep func(string) bool, less func(a, b string) bool) []string {
+	wl.mu.RLock()
+	found := []string{}
+	for word := range wl.words {
+		if keep(word) {
+			found = append(found, word)
+		}
+	}
+	wl.mu.RUnlock()
+
+	sort.Slice(found, func(i, j int) bool { return less(found[i], found[j]) })
+	return found
+}
+
+// canSpell returns true if word can be made from the letters, using blanks
+// ('?') for any letters that are missing
+func canSpell(word, letters string) bool {
+	counts := make(map[rune]int, len(letters))
+	for _, r := range letters {
+		counts[r]++
+	}
+	for _, r := range word {
+		if counts[r] > 0 {
+			counts[r]--
+		} else if counts[Blank] > 0 {
+			counts[Blank]--
+		} else {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,110 @@
+package dictionary
+
+import (
+	"reflect"
+	"testing"
+)
+
+// newAnagramList creates a word list for anagram tests
+func newAnagramList(t *testing.T) *WordList {
+	wl := NewWordList(Custom)
+	for _, w := range []string{"RETAINS", "NASTIER", "STAINER", "RETINAS", "ARSENITE", "STEARINE",
+		"STAIR", "TEA", "EAT", "ETA", "AT", "TA", "ET", "RE", "EATS", "QI"} {
+		if err := wl.AddWord(w); err != nil {
+			t.Fatalf("AddWord failed: %v", err)
+		}
+	}
+	return wl
+}
+
+// TestAlphagram tests sorting a word's letters
+func TestAlphagram(t *testing.T) {
+	if a := Alphagram("retains"); a != "AEINRST" {
+		t.Errorf("Expected AEINRST, got %s", a)
+	}
+}
+
+// TestAnagrams tests finding words using all the given letters
+func TestAnagrams(t *testing.T) {
+	wl := newAnagramList(t)
+
+	testCases := []struct {
+		letters  string
+		expected []string
+	}{
+		{"aeinrst", []string{"NASTIER", "RETAINS", "RETINAS", "STAINER"}},
+		{"TAE", []string{"EAT", "ETA", "TEA"}},
+		{"TE?", []string{"EAT", "ETA", "TEA"}},
+		{"??", []string{"AT", "ET", "QI", "RE", "TA"}},
+		{"XYZ", []string{}},
+	}
+
+	for _, tc := range testCases {
+		if got := wl.Anagrams(tc.letters); !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("Anagrams(%q): expected %v, got %v", tc.letters, tc.expected, got)
+		}
+	}
+
+	// Adding a word twice must not duplicate it in the index
+	wl.AddWord("tea")
+	if got := wl.Anagrams("AET"); len(got) != 3 {
+		t.Errorf("Duplicate words should not be indexed twice, got %v", got)
+	}
+}
+
+// TestSubAnagrams tests finding words from some of the given letters
+func TestSubAnagrams(t *testing.T) {
+	wl := newAnagramList(t)
+
+	got := wl.SubAnagrams("EATS")
+	expected := []string{"EATS", "EAT", "ETA", "TEA", "AT", "ET", "TA"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	if got := wl.SubAnagrams("Q?"); !reflect.DeepEqual(got, []string{"QI"}) {
+		t.Errorf("Blank should complete QI, got %v", got)
+	}
+}
+
+// TestPlayThrough tests finding words through letters on the board
+func TestPlayThrough(t *testing.T) {
+	wl := newAnagramList(t)
+
+	got := wl.PlayThrough("AEINRST", "E")
+	for _, want := range []string{"ARSENITE", "STEARINE"} {
+		if !contains(got, want) {
+			t.Errorf("PlayThrough should find %s, got %v", want, got)
+		}
+	}
+	if got[0] != "ARSENITE" {
+		t.Errorf("Longest words should come first, got %v", got)
+	}
+	if contains(got, "STAIR") || contains(got, "QI") {
+		t.Errorf("Words must use the board letter, got %v", got)
+	}
+
+	// The board letters alone do not count as a play
+	if contains(wl.PlayThrough("Z", "EAT"), "EAT") {
+		t.Errorf("At least one rack tile must be used")
+	}
+
+	if got := wl.PlayThrough("S", "EAT"); !reflect.DeepEqual(got, []string{"EATS"}) {
+		t.Errorf("Expected [EATS], got %v", got)
+	}
+
+	// Multi-letter play-through with a blank
+	if got := wl.PlayThrough("?", "TA"); !reflect.DeepEqual(got, []string{"ETA"}) {
+		t.Errorf("Expected [ETA], got %v", got)
+	}
+}
+
+// contains returns true if words includes word
+func contains(words []string, word string) bool {
+	for _, w := range words {
+		if w == word {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,476 @@
+package dictionary
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DAWG file format
+//
+// A file starts with a fixed header followed by the lexicon name and a flat
+// array of edges, all little-endian:
+//
+//	magic    [4]byte  "DAWG"
+//	version  uint32   DAWGVersion
+//	words    uint32   number of words
+//	count    uint32   number of edges
+//	lexlen   uint32   length of the lexicon name
+//	lexicon  [lexlen]byte
+//	edges    [count]struct{ letter, next uint32 }
+//
+// Each node is a run of edges sorted by letter, the last marked with
+// dawgLastEdge. The root's edges come first. next holds the index of the
+// child node's first edge shifted left by two, or zero if the edge ends
+// every word through it, and the dawgEndOfWord and dawgLastEdge flags.
+// Lookups read edges straight from the bytes, so a memory-mapped file is
+// usable without decoding.
+const (
+	DAWGVersion = 1
+
+	dawgMagic      = "DAWG"
+	dawgHeaderSize = 20
+	dawgEdgeSize   = 8
+	dawgEndOfWord  = 1 << 0
+	dawgLastEdge   = 1 << 1
+)
+
+// Errors returned when loading a DAWG
+var (
+	ErrNotDAWG            = errors.New("not a DAWG file")
+	ErrUnsupportedVersion = errors.New("unsupported DAWG version")
+)
+
+// DAWG is a read-only Dictionary stored as a directed acyclic word graph, in
+// which words sharing prefixes or suffixes share nodes
+type DAWG struct {
+	lexicon Lexicon
+	words   int
+	edges   []byte       // Encoded edge array, possibly within a mapped file
+	release func() error // Unmaps the file image, if mapped
+}
+
+// BuildDAWG builds a DAWG from a list of words in any order
+// Words are normalized to upper case and duplicates are ignored.
+func BuildDAWG(lexicon Lexicon, words []string) (*DAWG, error) {
+	sorted := make([]string, 0, len(words))
+	for _, w := range words {
+		w = Normalize(w)
+		if !isWord(w) {
+			return nil, fmt.Errorf("invalid word %q", w)
+		}
+		sorted = append(sorted, w)
+	}
+	sort.Strings(sorted)
+
+	b := newDAWGBuilder()
+	count := 0
+	for i, w := range sorted {
+		if i > 0 && w == sorted[i-1] {
+			continue
+		}
+		b.insert([]rune(w))
+		count++
+	}
+	b.minimize(0)
+
+	return &DAWG{lexicon: lexicon, words: count, edges: b.encode()}, nil
+}
+
+// BuildDAWGFromWordList builds a DAWG holding the words of a word list
+func BuildDAWGFromWordList(wl *WordList) (*DAWG, error) {
+	return BuildDAWG(wl.Lexicon(), wl.Words())
+}
+
+// SaveDAWG writes a DAWG to a file
+func SaveDAWG(filename string, d *DAWG) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create DAWG file: %w", err)
+	}
+	if _, err := d.WriteTo(f); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", filename, err)
+	}
+	return f.Close()
+}
+
+// LoadDAWG opens a DAWG file, memory-mapping it where the platform allows
+// Call Close to release the mapping when the DAWG is no longer needed.
+func LoadDAWG(filename string) (*DAWG, error) {
+	data, release, err := mapFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open DAWG: %w", err)
+	}
+
+	d, err := ParseDAWG(data)
+	if err != nil {
+		release()
+		return nil, fmt.Errorf("failed to load %s: %w", filename, err)
+	}
+	d.release = release
+	return d, nil
+}
+
+// ReadDAWG reads a DAWG from r into memory
+func ReadDAWG(r io.Reader) (*DAWG, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDAWG(data)
+}
+
+// ParseDAWG uses an encoded DAWG in place; data must not be modified afterwards
+func ParseDAWG(data []byte) (*DAWG, error) {
+	if len(data) < dawgHeaderSize || string(data[:4]) != dawgMagic {
+		return nil, ErrNotDAWG
+	}
+	if v := binary.LittleEndian.Uint32(data[4:]); v != DAWGVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, v)
+	}
+
+	words := binary.LittleEndian.Uint32(data[8:])
+	edges := uint64(binary.LittleEndian.Uint32(data[12:]))
+	lexLen := uint64(binary.LittleEndian.Uint32(data[16:]))
+	if uint64(len(data)) != dawgHeaderSize+lexLen+edges*dawgEdgeSize {
+		return nil, fmt.Errorf("%w: size does not match header", ErrNotDAWG)
+	}
+
+	start := dawgHeaderSize + lexLen
+	d := &DAWG{
+		lexicon: Lexicon(data[dawgHeaderSize:start]),
+		words:   int(words),
+		edges:   data[start:],
+	}
+	if err := d.check(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// WriteTo writes the DAWG in its binary file format
+func (d *DAWG) WriteTo(w io.Writer) (int64, error) {
+	bw := bufio.NewWriter(w)
+	header := make([]byte, dawgHeaderSize)
+	copy(header, dawgMagic)
+	binary.LittleEndian.PutUint32(header[4:], DAWGVersion)
+	binary.LittleEndian.PutUint32(header[8:], uint32(d.words))
+	binary.LittleEndian.PutUint32(header[12:], uint32(d.edgeCount()))
+	binary.LittleEndian.PutUint32(header[16:], uint32(len(d.lexicon)))
+
+	bw.Write(header)
+	bw.WriteString(string(d.lexicon))
+	bw.Write(d.edges)
+	if err := bw.Flush(); err != nil {
+		return 0, err
+	}
+	return int64(dawgHeaderSize + len(d.lexicon) + len(d.edges)), nil
+}
+
+// Close releases the memory mapping of a DAWG opened with LoadDAWG
+// The DAWG must not be used afterwards.
+func (d *DAWG) Close() error {
+	if d.release == nil {
+		return nil
+	}
+	release := d.release
+	d.release = nil
+	d.edges = nil
+	return release()
+}
+
+// IsValid returns true if the word is in the DAWG
+func (d *DAWG) IsValid(word string) bool {
+	runes := []rune(Normalize(word))
+	if len(runes) == 0 {
+		return false
+	}
+
+	node := uint32(0)
+	for i, r := range runes {
+		next, ok := d.find(node, r)
+		if !ok {
+			return false
+		}
+		if i == len(runes)-1 {
+			return next&dawgEndOfWord != 0
+		}
+		node = next >> 2
+		if node == 0 {
+			return false
+		}
+	}
+	return false
+}
+
+// Lookup returns the entry for a word if it is in the DAWG
+func (d *DAWG) Lookup(word string) (Entry, bool) {
+	word = Normalize(word)
+	if !d.IsValid(word) {
+		return Entry{}, false
+	}
+	return Entry{Word: word, Lexicon: d.lexicon}, true
+}
+
+// Lexicon returns the lexicon the DAWG was built from
+func (d *DAWG) Lexicon() Lexicon {
+	return d.lexicon
+}
+
+// Size returns the number of words in the DAWG
+func (d *DAWG) Size() int {
+	return d.words
+}
+
+// Words returns every word in the DAWG in alphabetical order
+func (d *DAWG) Words() []string {
+	// The count comes from the file header, so it is not trusted to size the result
+	var words []string
+	if d.edgeCount() > 0 {
+		d.walk(0, nil, &words)
+	}
+	return words
+}
+
+// walk appends the words below a node to words, in edge order
+func (d *DAWG) walk(node uint32, prefix []rune, words *[]string) {
+	for i := node; ; i++ {
+		letter, next := d.edge(i)
+		word := append(prefix, rune(letter))
+		if next&dawgEndOfWord != 0 {
+			*words = append(*words, string(word))
+		}
+		if child := next >> 2; child != 0 {
+			d.walk(child, word, words)
+		}
+		if next&dawgLastEdge != 0 {
+			return
+		}
+	}
+}
+
+// find returns the next field of the edge labeled r leaving node
+func (d *DAWG) find(node uint32, r rune) (uint32, bool) {
+	if d.edgeCount() == 0 {
+		return 0, false
+	}
+	for i := node; ; i++ {
+		letter, next := d.edge(i)
+		if rune(letter) == r {
+			return next, true
+		}
+		if next&dawgLastEdge != 0 || rune(letter) > r {
+			return 0, false
+		}
+	}
+}
+
+// edge decodes the edge at index i
+func (d *DAWG) edge(i uint32) (letter, next uint32) {
+	off := int(i) * dawgEdgeSize
+	return binary.LittleEndian.Uint32(d.edges[off:]), binary.LittleEndian.Uint32(d.edges[off+4:])
+}
+
+// edgeCount returns the number of edges in the DAWG
+func (d *DAWG) edgeCount() int {
+	return len(d.edges) / dawgEdgeSize
+}
+
+// check verifies that every node ends within the edge array, every child
+// index is in range and no path returns to a node it has passed, so lookups
+// cannot run off the end of corrupt data and walks cannot loop forever
+func (d *DAWG) check() error {
+	n := uint32(d.edgeCount())
+	if n > 0 {
+		if _, next := d.edge(n - 1); next&dawgLastEdge == 0 {
+			return fmt.Errorf("%w: final node is unterminated", ErrNotDAWG)
+		}
+	}
+	for i := uint32(0); i < n; i++ {
+		if _, next := d.edge(i); next>>2 >= n {
+			return fmt.Errorf("%w: edge %d points outside the graph", ErrNotDAWG, i)
+		}
+	}
+	if n == 0 {
+		return nil
+	}
+
+	// Shared suffixes may be laid out before a later parent, so child indices
+	// need not increase and cycles are found by a depth-first search instead
+	const (
+		unvisited = iota
+		onPath
+		finished
+	)
+	state := make([]uint8, n)
+	type frame struct{ node, edge uint32 }
+	stack := []frame{{0, 0}}
+	state[0] = onPath
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if top.edge == n {
+			state[top.node] = finished
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		_, next := d.edge(top.edge)
+		if next&dawgLastEdge != 0 {
+			top.edge = n
+		} else {
+			top.edge++
+		}
+		child := next >> 2
+		if child == 0 {
+			continue
+		}
+		switch state[child] {
+		case onPath:
+			return fmt.Errorf("%w: edge to %d forms a cycle", ErrNotDAWG, child)
+		case unvisited:
+			state[child] = onPath
+			stack = append(stack, frame{child, child})
+		}
+	}
+	return nil
+}
+
+// dawgNode is a node of a DAWG under construction
+type dawgNode struct {
+	id    int
+	final bool
+	edges []dawgBuildEdge
+}
+
+// dawgBuildEdge is an edge of a DAWG under construction
+type dawgBuildEdge struct {
+	letter rune
+	child  *dawgNode
+}
+
+// dawgBuilder constructs a minimal DAWG from words inserted in sorted order,
+// following Daciuk et al.'s incremental algorithm
+type dawgBuilder struct {
+	root      *dawgNode
+	nextID    int
+	previous  []rune
+	unchecked []dawgBuildEdge // Path of the previous word not yet minimized, with parents
+	parents   []*dawgNode
+	minimized map[string]*dawgNode
+}
+
+// newDAWGBuilder creates a builder with an empty root
+func newDAWGBuilder() *dawgBuilder {
+	b := &dawgBuilder{minimized: make(map[string]*dawgNode)}
+	b.root = b.newNode()
+	return b
+}
+
+// newNode allocates a node with a unique ID
+func (b *dawgBuilder) newNode() *dawgNode {
+	b.nextID++
+	return &dawgNode{id: b.nextID}
+}
+
+// insert adds a word, which must sort after every word inserted before it
+func (b *dawgBuilder) insert(word []rune) {
+	common := 0
+	for common < len(word) && common < len(b.previous) && word[common] == b.previous[common] {
+		common++
+	}
+	b.minimize(common)
+
+	node := b.root
+	if len(b.unchecked) > 0 {
+		node = b.unchecked[len(b.unchecked)-1].child
+	}
+	for _, r := range word[common:] {
+		child := b.newNode()
+		node.edges = append(node.edges, dawgBuildEdge{letter: r, child: child})
+		b.unchecked = append(b.unchecked, dawgBuildEdge{letter: r, child: child})
+		b.parents = append(b.parents, node)
+		node = child
+	}
+	node.final = true
+	b.previous = word
+}
+
+// minimize merges the unchecked nodes below depth downTo with equivalent
+// nodes already in the graph
+func (b *dawgBuilder) minimize(downTo int) {
+	for i := len(b.unchecked) - 1; i >= downTo; i-- {
+		parent, child := b.parents[i], b.unchecked[i].child
+		key := child.signature()
+		if existing, ok := b.minimized[key]; ok {
+			parent.edges[len(parent.edges)-1].child = existing
+		} else {
+			b.minimized[key] = child
+		}
+	}
+	b.unchecked = b.unchecked[:downTo]
+	b.parents = b.parents[:downTo]
+}
+
+// signature identifies a node by its finality and outgoing edges; nodes with
+// equal signatures accept the same suffixes
+func (n *dawgNode) signature() string {
+	var sb strings.Builder
+	if n.final {
+		sb.WriteByte('!')
+	}
+	for _, e := range n.edges {
+		sb.WriteRune(e.letter)
+		sb.WriteString(strconv.Itoa(e.child.id))
+		sb.WriteByte(',')
+	}
+	return sb.String()
+}
+
+// encode lays out the graph as an edge array, the root's edges first
+func (b *dawgBuilder) encode() []byte {
+	offsets := make(map[*dawgNode]uint32)
+	order := []*dawgNode{b.root}
+	offsets[b.root] = 0
+	total := uint32(len(b.root.edges))
+
+	// Assign each node with edges a contiguous run, breadth first
+	for i := 0; i < len(order); i++ {
+		for _, e := range order[i].edges {
+			if _, seen := offsets[e.child]; seen || len(e.child.edges) == 0 {
+				continue
+			}
+			offsets[e.child] = total
+			total += uint32(len(e.child.edges))
+			order = append(order, e.child)
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(int(total) * dawgEdgeSize)
+	var edge [dawgEdgeSize]byte
+	for _, n := range order {
+		for i, e := range n.edges {
+			next := uint32(0)
+			if len(e.child.edges) > 0 {
+				next = offsets[e.child] << 2
+			}
+			if e.child.final {
+				next |= dawgEndOfWord
+			}
+			if i == len(n.edges)-1 {
+				next |= dawgLastEdge
+			}
+			binary.LittleEndian.PutUint32(edge[:4], uint32(e.letter))
+			binary.LittleEndian.PutUint32(edge[4:], next)
+			buf.Write(edge[:])
+		}
+	}
+	return buf.Bytes()
+}
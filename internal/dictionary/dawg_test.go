@@ -0,0 +1,199 @@
+package dictionary
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// dawgWords share prefixes and suffixes so the graph has nodes to merge
+var dawgWords = []string{
+	"cat", "CATS", "cart", "carts", "car", "cars", "bat", "bats", "bar", "bars",
+	"tap", "taps", "top", "tops", "a", "aa", "añejo", "cat",
+}
+
+// TestBuildDAWG tests lookups in a freshly built DAWG
+func TestBuildDAWG(t *testing.T) {
+	d, err := BuildDAWG(Collins, dawgWords)
+	if err != nil {
+		t.Fatalf("BuildDAWG failed: %v", err)
+	}
+
+	var _ Dictionary = d
+	if d.Size() != 17 || d.Lexicon() != Collins {
+		t.Errorf("Expected 17 Collins words, got %d %s", d.Size(), d.Lexicon())
+	}
+	for _, w := range dawgWords {
+		if !d.IsValid(w) {
+			t.Errorf("%s should be valid", w)
+		}
+	}
+	for _, w := range []string{"", "ca", "ta", "catss", "b", "aaa", "zzz", "AÑEJ"} {
+		if d.IsValid(w) {
+			t.Errorf("%q should not be valid", w)
+		}
+	}
+	if e, ok := d.Lookup(" carts "); !ok || e.Word != "CARTS" || e.Lexicon != Collins {
+		t.Errorf("Lookup should normalize and report the lexicon, got %+v", e)
+	}
+
+	want := make([]string, 0)
+	seen := map[string]bool{}
+	for _, w := range dawgWords {
+		if w = Normalize(w); !seen[w] {
+			seen[w] = true
+			want = append(want, w)
+		}
+	}
+	sort.Strings(want)
+	if got := d.Words(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Words should list every word in order:\n got %v\nwant %v", got, want)
+	}
+
+	if _, err := BuildDAWG(Custom, []string{"ok", "not ok"}); err == nil {
+		t.Errorf("Should reject invalid words")
+	}
+}
+
+// TestDAWGSharesSuffixes tests that the graph is smaller than a trie of the same words
+func TestDAWGSharesSuffixes(t *testing.T) {
+	words := []string{}
+	letters := 0
+	for _, stem := range []string{"BAT", "CAT", "HAT", "MAT", "RAT", "SAT"} {
+		for _, suffix := range []string{"", "S", "TED", "TING"} {
+			words = append(words, stem+suffix)
+			letters += len(stem + suffix)
+		}
+	}
+	d, err := BuildDAWG(Custom, words)
+	if err != nil {
+		t.Fatalf("BuildDAWG failed: %v", err)
+	}
+	// Every stem shares the same suffix graph, leaving one edge per stem's
+	// first letter plus a single copy of "AT" and its endings
+	if d.edgeCount() > 15 {
+		t.Errorf("Suffixes should be shared, got %d edges for %d letters", d.edgeCount(), letters)
+	}
+}
+
+// TestSaveLoadDAWG tests the binary format round trip through a memory-mapped file
+func TestSaveLoadDAWG(t *testing.T) {
+	built, err := BuildDAWG(TWL06, dawgWords)
+	if err != nil {
+		t.Fatalf("BuildDAWG failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "twl.dawg")
+	if err := SaveDAWG(path, built); err != nil {
+		t.Fatalf("SaveDAWG failed: %v", err)
+	}
+	loaded, err := LoadDAWG(path)
+	if err != nil {
+		t.Fatalf("LoadDAWG failed: %v", err)
+	}
+	defer loaded.Close()
+
+	if loaded.Lexicon() != TWL06 || loaded.Size() != built.Size() {
+		t.Errorf("Header should round-trip, got %s with %d words", loaded.Lexicon(), loaded.Size())
+	}
+	if !reflect.DeepEqual(loaded.Words(), built.Words()) {
+		t.Errorf("Loaded DAWG should hold the same words")
+	}
+	if !loaded.IsValid("CARTS") || loaded.IsValid("CART S") {
+		t.Errorf("Loaded DAWG should answer lookups")
+	}
+
+	if err := loaded.Close(); err != nil {
+		t.Errorf("Close failed: %v", err)
+	}
+	if err := loaded.Close(); err != nil {
+		t.Errorf("A second Close should be harmless: %v", err)
+	}
+
+	if _, err := LoadDAWG(filepath.Join(t.TempDir(), "missing.dawg")); err == nil {
+		t.Errorf("Should fail for a missing file")
+	}
+}
+
+// TestReadDAWG tests reading from a stream, including an empty DAWG
+func TestReadDAWG(t *testing.T) {
+	for _, words := range [][]string{dawgWords, {}} {
+		built, _ := BuildDAWG(Custom, words)
+		var buf bytes.Buffer
+		n, err := built.WriteTo(&buf)
+		if err != nil || n != int64(buf.Len()) {
+			t.Fatalf("WriteTo wrote %d of %d bytes: %v", n, buf.Len(), err)
+		}
+
+		read, err := ReadDAWG(&buf)
+		if err != nil {
+			t.Fatalf("ReadDAWG failed: %v", err)
+		}
+		if !reflect.DeepEqual(read.Words(), built.Words()) || read.Size() != len(built.Words()) {
+			t.Errorf("Read DAWG should match the original")
+		}
+		if read.IsValid("CAT") != (len(words) > 0) {
+			t.Errorf("Lookups should work on the read DAWG")
+		}
+	}
+}
+
+// TestParseDAWGErrors tests rejection of corrupt data
+func TestParseDAWGErrors(t *testing.T) {
+	built, _ := BuildDAWG(Custom, dawgWords)
+	var buf bytes.Buffer
+	built.WriteTo(&buf)
+	good := buf.Bytes()
+
+	corrupt := func(f func(b []byte) []byte) []byte {
+		b := append([]byte(nil), good...)
+		return f(b)
+	}
+	lastNext := len(good) - 4
+	firstEdge := len(good) - int(binary.LittleEndian.Uint32(good[12:]))*dawgEdgeSize
+
+	tests := map[string]struct {
+		data []byte
+		want error
+	}{
+		"empty":       {nil, ErrNotDAWG},
+		"bad magic":   {corrupt(func(b []byte) []byte { b[0] = 'X'; return b }), ErrNotDAWG},
+		"truncated":   {good[:len(good)-3], ErrNotDAWG},
+		"trailing":    {append(append([]byte(nil), good...), 0), ErrNotDAWG},
+		"new version": {corrupt(func(b []byte) []byte { binary.LittleEndian.PutUint32(b[4:], DAWGVersion+1); return b }), ErrUnsupportedVersion},
+		"unterminated": {corrupt(func(b []byte) []byte {
+			binary.LittleEndian.PutUint32(b[lastNext:], binary.LittleEndian.Uint32(b[lastNext:])&^dawgLastEdge)
+			return b
+		}), ErrNotDAWG},
+		"bad child": {corrupt(func(b []byte) []byte {
+			binary.LittleEndian.PutUint32(b[lastNext:], 1<<30|dawgLastEdge)
+			return b
+		}), ErrNotDAWG},
+		"cycle": {corrupt(func(b []byte) []byte {
+			// Point the first edge of the root's first child back at that child
+			child := int(binary.LittleEndian.Uint32(b[firstEdge+4:]) >> 2)
+			next := b[firstEdge+child*dawgEdgeSize+4:]
+			binary.LittleEndian.PutUint32(next, uint32(child)<<2|binary.LittleEndian.Uint32(next)&(dawgEndOfWord|dawgLastEdge))
+			return b
+		}), ErrNotDAWG},
+	}
+	for name, tt := range tests {
+		if _, err := ParseDAWG(tt.data); !errors.Is(err, tt.want) {
+			t.Errorf("%s: expected %v, got %v", name, tt.want, err)
+		}
+	}
+
+	// The word count in the header is not trusted when listing words
+	inflated := corrupt(func(b []byte) []byte { binary.LittleEndian.PutUint32(b[8:], 1<<31); return b })
+	d, err := ParseDAWG(inflated)
+	if err != nil {
+		t.Fatalf("ParseDAWG failed: %v", err)
+	}
+	if words := d.Words(); len(words) != built.Size() || cap(words) > 2*built.Size() {
+		t.Errorf("Expected %d words without trusting the header, got %d with capacity %d", built.Size(), len(words), cap(words))
+	}
+}
@@ -0,0 +1,286 @@
+package dictionary
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Lexicon identifies a published word list
+type Lexicon string
+
+const (
+	TWL06   Lexicon = "TWL06"   // Tournament Word List (North America)
+	NWL     Lexicon = "NWL"     // NASPA Word List, successor to TWL (North America)
+	SOWPODS Lexicon = "SOWPODS" // Combined international word list
+	Collins Lexicon = "CSW"     // Collins Scrabble Words
+	ENABLE  Lexicon = "ENABLE"  // Enhanced North American Benchmark Lexicon
+	Custom  Lexicon = "CUSTOM"  // Any other word list
+)
+
+// ParseLexicon returns the lexicon for a word list name as written by other
+// tools, including dated editions such as CSW21 or NWL2020 used by Woogles and
+// Macondo; unrecognized names are Custom
+func ParseLexicon(name string) Lexicon {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	switch {
+	case strings.HasPrefix(name, "CSW"), strings.HasPrefix(name, "COLLINS"):
+		return Collins
+	case strings.HasPrefix(name, "NWL"), strings.HasPrefix(name, "NSWL"):
+		return NWL
+	case strings.HasPrefix(name, "TWL"), strings.HasPrefix(name, "OWL"):
+		return TWL06
+	case strings.HasPrefix(name, "SOWPODS"):
+		return SOWPODS
+	case strings.HasPrefix(name, "ENABLE"):
+		return ENABLE
+	default:
+		return Custom
+	}
+}
+
+// Entry describes a word found in a dictionary
+type Entry struct {
+	Word       string  `json:"word"`                 // Normalized (upper-case) word
+	Lexicon    Lexicon `json:"lexicon"`              // Word list the word was found in
+	Definition string  `json:"definition,omitempty"` // Definition, if the word list carries one
+}
+
+// Dictionary validates words against a word list
+type Dictionary interface {
+	// IsValid returns true if the word is in the dictionary
+	IsValid(word string) bool
+	// Lookup returns the dictionary entry for a word
+	Lookup(word string) (Entry, bool)
+}
+
+// Definer is implemented by dictionaries that carry word definitions
+type Definer interface {
+	// Definition returns the definition of a word, or false if it has none
+	Definition(word string) (string, bool)
+}
+
+// WordList is an in-memory Dictionary backed by a hash set of words
+type WordList struct {
+	lexicon     Lexicon
+	words       map[string]bool
+	alphagram   map[string][]string // Words keyed by their sorted letters
+	definitions map[string]string   // Definitions from annotated word lists
+	letters     map[rune]bool       // Every letter used by a word, for hook queries
+	mu          sync.RWMutex
+}
+
+// NewWordList creates an empty word list for the given lexicon
+func NewWordList(lexicon Lexicon) *WordList {
+	return &WordList{
+		lexicon:     lexicon,
+		words:       make(map[string]bool),
+		alphagram:   make(map[string][]string),
+		definitions: make(map[string]string),
+		letters:     make(map[rune]bool),
+	}
+}
+
+// NewDictionary creates a custom word list loaded from a plain-text file
+func NewDictionary(filename string) (*WordList, error) {
+	return LoadFile(Custom, filename)
+}
+
+// LoadFile creates a word list for the given lexicon from a plain-text file
+func LoadFile(lexicon Lexicon, filename string) (*WordList, error) {
+	wl := NewWordList(lexicon)
+	if err := wl.LoadFromFile(filename); err != nil {
+		return nil, err
+	}
+	return wl, nil
+}
+
+// LoadTWL06 loads a TWL06 word list from a plain-text file
+func LoadTWL06(filename string) (*WordList, error) {
+	return LoadFile(TWL06, filename)
+}
+
+// LoadSOWPODS loads a SOWPODS word list from a plain-text file
+func LoadSOWPODS(filename string) (*WordList, error) {
+	return LoadFile(SOWPODS, filename)
+}
+
+// LoadCollins loads a Collins Scrabble Words list from a plain-text file
+func LoadCollins(filename string) (*WordList, error) {
+	return LoadFile(Collins, filename)
+}
+
+// LoadENABLE loads an ENABLE word list from a plain-text file
+func LoadENABLE(filename string) (*WordList, error) {
+	return LoadFile(ENABLE, filename)
+}
+
+// LoadFromFile adds the words from a plain-text file to the word list
+func (wl *WordList) LoadFromFile(filename string) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return fmt.Errorf("failed to open word list: %w", err)
+	}
+	defer f.Close()
+
+	if err := wl.LoadFromReader(f); err != nil {
+		return fmt.Errorf("failed to load %s: %w", filename, err)
+	}
+	return nil
+}
+
+// LoadFromReader adds words from a plain-text word list
+// Each line holds one word, optionally followed by whitespace and a definition
+// (as in annotated Collins lists), which is kept for Definition. Blank lines
+// and lines starting with '#' are ignored. Words are case-insensitive.
+func (wl *WordList) LoadFromReader(r io.Reader) error {
+	words := make(map[string]bool)
+	definitions := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		field := strings.Fields(line)[0]
+		word := Normalize(field)
+		if !isWord(word) {
+			return fmt.Errorf("line %d: invalid word %q", lineNum, word)
+		}
+		words[word] = true
+		if def := strings.TrimSpace(line[len(field):]); def != "" {
+			definitions[word] = def
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	wl.mu.Lock()
+	defer wl.mu.Unlock()
+
+	for word := range words {
+		wl.add(word)
+	}
+	for word, def := range definitions {
+		wl.definitions[word] = def
+	}
+	return nil
+}
+
+// AddWord adds a single word to the word list
+func (wl *WordList) AddWord(word string) error {
+	word = Normalize(word)
+	if !isWord(word) {
+		return fmt.Errorf("invalid word %q", word)
+	}
+
+	wl.mu.Lock()
+	defer wl.mu.Unlock()
+
+	wl.add(word)
+	return nil
+}
+
+// add inserts a normalized word and indexes it by alphagram; callers must hold the lock
+func (wl *WordList) add(word string) {
+	if wl.words[word] {
+		return
+	}
+	wl.words[word] = true
+	key := Alphagram(word)
+	wl.alphagram[key] = append(wl.alphagram[key], word)
+	for _, r := range word {
+		wl.letters[r] = true
+	}
+}
+
+// IsValid returns true if the word is in the word list
+func (wl *WordList) IsValid(word string) bool {
+	wl.mu.RLock()
+	defer wl.mu.RUnlock()
+
+	return wl.words[Normalize(word)]
+}
+
+// IsValidWord is an alias for IsValid
+func (wl *WordList) IsValidWord(word string) bool {
+	return wl.IsValid(word)
+}
+
+// Lookup returns the entry for a word if it is in the word list
+func (wl *WordList) Lookup(word string) (Entry, bool) {
+	word = Normalize(word)
+
+	wl.mu.RLock()
+	defer wl.mu.RUnlock()
+
+	if !wl.words[word] {
+		return Entry{}, false
+	}
+	return Entry{Word: word, Lexicon: wl.lexicon, Definition: wl.definitions[word]}, true
+}
+
+// Definition returns the definition loaded with a word, or false if the word
+// list has none for it
+func (wl *WordList) Definition(word string) (string, bool) {
+	wl.mu.RLock()
+	defer wl.mu.RUnlock()
+
+	def, ok := wl.definitions[Normalize(word)]
+	return def, ok
+}
+
+// Lexicon returns the lexicon this word list represents
+func (wl *WordList) Lexicon() Lexicon {
+	return wl.lexicon
+}
+
+// Size returns the number of words in the word list
+func (wl *WordList) Size() int {
+	wl.mu.RLock()
+	defer wl.mu.RUnlock()
+
+	return len(wl.words)
+}
+
+// Words returns every word in the word list in alphabetical order
+func (wl *WordList) Words() []string {
+	wl.mu.RLock()
+	words := make([]string, 0, len(wl.words))
+	for word := range wl.words {
+		words = append(words, word)
+	}
+	wl.mu.RUnlock()
+
+	sort.Strings(words)
+	return words
+}
+
+// Normalize trims and upper-cases a word for lookup
+func Normalize(word string) string {
+	return strings.ToUpper(strings.TrimSpace(word))
+}
+
+// isWord returns true if the string is non-empty and made only of letters
+func isWord(word string) bool {
+	if word == "" {
+		return false
+	}
+	for _, r := range word {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+	}
+	return true
+}
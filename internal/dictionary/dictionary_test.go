@@ -0,0 +1,209 @@
+package dictionary
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TestDictionaryInterface tests that WordList satisfies Dictionary
+func TestDictionaryInterface(t *testing.T) {
+	var _ Dictionary = NewWordList(Custom)
+}
+
+// TestLoadFormats tests loading each supported word list format
+func TestLoadFormats(t *testing.T) {
+	testCases := []struct {
+		name    string
+		load    func(string) (*WordList, error)
+		file    string
+		lexicon Lexicon
+		size    int
+		valid   []string
+	}{
+		{"TWL06", LoadTWL06, "testdata/twl_sample.txt", TWL06, 7, []string{"AA", "QI", "CATS"}},
+		{"SOWPODS", LoadSOWPODS, "testdata/twl_sample.txt", SOWPODS, 7, []string{"ZA"}},
+		{"ENABLE", LoadENABLE, "testdata/enable_sample.txt", ENABLE, 5, []string{"ZYZZYVA", "dog"}},
+		{"Collins", LoadCollins, "testdata/collins_sample.txt", Collins, 4, []string{"AA", "ZO", "QI"}},
+		{"Custom", NewDictionary, "testdata/twl_sample.txt", Custom, 7, []string{"DOG"}},
+	}
+
+	for _, tc := range testCases {
+		wl, err := tc.load(tc.file)
+		if err != nil {
+			t.Fatalf("%s: load failed: %v", tc.name, err)
+		}
+
+		if wl.Lexicon() != tc.lexicon {
+			t.Errorf("%s: expected lexicon %s, got %s", tc.name, tc.lexicon, wl.Lexicon())
+		}
+
+		if wl.Size() != tc.size {
+			t.Errorf("%s: expected %d words, got %d", tc.name, tc.size, wl.Size())
+		}
+
+		for _, word := range tc.valid {
+			if !wl.IsValid(word) {
+				t.Errorf("%s: %s should be valid", tc.name, word)
+			}
+		}
+	}
+}
+
+// TestLoadErrors tests missing and malformed files
+func TestLoadErrors(t *testing.T) {
+	if _, err := NewDictionary("testdata/does_not_exist.txt"); err == nil {
+		t.Errorf("Loading a missing file should fail")
+	}
+
+	if _, err := NewDictionary("testdata/malformed.txt"); err == nil {
+		t.Errorf("Loading a file with non-letter words should fail")
+	}
+
+	// A failed load should not partially populate the list
+	wl := NewWordList(Custom)
+	if err := wl.LoadFromReader(strings.NewReader("CAT\nD0G\n")); err == nil {
+		t.Errorf("Loading invalid words should fail")
+	}
+	if wl.Size() != 0 {
+		t.Errorf("Failed load should add no words, got %d", wl.Size())
+	}
+}
+
+// TestIsValidCaseHandling tests case-insensitive lookup and preprocessing
+func TestIsValidCaseHandling(t *testing.T) {
+	wl, _ := LoadTWL06("testdata/twl_sample.txt")
+
+	for _, word := range []string{"CAT", "cat", "Cat", "  cat  ", "cAt\n"} {
+		if !wl.IsValid(word) || !wl.IsValidWord(word) {
+			t.Errorf("%q should be valid", word)
+		}
+	}
+
+	for _, word := range []string{"", "CA", "CATZ", "DOGS"} {
+		if wl.IsValid(word) {
+			t.Errorf("%q should not be valid", word)
+		}
+	}
+}
+
+// TestLookup tests dictionary entries
+func TestLookup(t *testing.T) {
+	wl, _ := LoadENABLE("testdata/enable_sample.txt")
+
+	entry, ok := wl.Lookup("zyzzyva")
+	if !ok {
+		t.Fatalf("ZYZZYVA should be found")
+	}
+	if entry.Word != "ZYZZYVA" || entry.Lexicon != ENABLE {
+		t.Errorf("Unexpected entry: %+v", entry)
+	}
+
+	if _, ok := wl.Lookup("QI"); ok {
+		t.Errorf("QI should not be in the ENABLE sample")
+	}
+}
+
+// TestDefinition tests definitions loaded from an annotated word list
+func TestDefinition(t *testing.T) {
+	wl, err := LoadCollins("testdata/collins_sample.txt")
+	if err != nil {
+		t.Fatalf("LoadCollins failed: %v", err)
+	}
+
+	if def, ok := wl.Definition("qi"); !ok || def != "the vital force in the body [n QIS]" {
+		t.Errorf("QI should have its definition, got %q", def)
+	}
+	if entry, _ := wl.Lookup("CAT"); entry.Definition != "a small domesticated feline [n CATS]" {
+		t.Errorf("Lookup should include the definition, got %q", entry.Definition)
+	}
+
+	var _ Definer = wl
+	plain, _ := LoadTWL06("testdata/twl_sample.txt")
+	if def, ok := plain.Definition("CAT"); ok {
+		t.Errorf("Plain word lists should have no definitions, got %q", def)
+	}
+}
+
+// TestAddWord tests adding individual words
+func TestAddWord(t *testing.T) {
+	wl := NewWordList(Custom)
+
+	if err := wl.AddWord("hello"); err != nil {
+		t.Errorf("Should be able to add a word: %v", err)
+	}
+	if !wl.IsValid("HELLO") {
+		t.Errorf("Added word should be valid")
+	}
+
+	for _, word := range []string{"", "  ", "R2D2", "ICE-CREAM"} {
+		if err := wl.AddWord(word); err == nil {
+			t.Errorf("Adding %q should fail", word)
+		}
+	}
+}
+
+// TestConcurrentLookup tests thread-safety of concurrent reads and writes
+func TestConcurrentLookup(t *testing.T) {
+	wl, _ := LoadTWL06("testdata/twl_sample.txt")
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(n int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				wl.IsValid("CAT")
+				wl.Lookup("DOG")
+			}
+		}(i)
+		go func(n int) {
+			defer wg.Done()
+			wl.AddWord(fmt.Sprintf("WORD%c", 'A'+n))
+		}(i)
+	}
+
+	wg.Wait()
+
+	if wl.Size() != 17 {
+		t.Errorf("Expected 17 words after concurrent adds, got %d", wl.Size())
+	}
+}
+
+// TestWords tests listing all words in order
+func TestWords(t *testing.T) {
+	wl, _ := LoadTWL06("testdata/twl_sample.txt")
+	words := wl.Words()
+
+	expected := []string{"AA", "AT", "CAT", "CATS", "DOG", "QI", "ZA"}
+	if len(words) != len(expected) {
+		t.Fatalf("Expected %d words, got %d", len(expected), len(words))
+	}
+	for i := range expected {
+		if words[i] != expected[i] {
+			t.Errorf("Word %d: expected %s, got %s", i, expected[i], words[i])
+		}
+	}
+}
+
+// TestParseLexicon tests mapping word list names and editions to lexicons
+func TestParseLexicon(t *testing.T) {
+	tests := map[string]Lexicon{
+		"CSW21":   Collins,
+		"csw19":   Collins,
+		"NWL2020": NWL,
+		"NWL23":   NWL,
+		"TWL06":   TWL06,
+		"TWL2014": TWL06,
+		"SOWPODS": SOWPODS,
+		"ENABLE2": ENABLE,
+		"ECWL":    Custom,
+		"":        Custom,
+	}
+	for name, want := range tests {
+		if got := ParseLexicon(name); got != want {
+			t.Errorf("ParseLexicon(%q) = %s, want %s", name, got, want)
+		}
+	}
+}
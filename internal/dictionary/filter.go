@@ -0,0 +1,97 @@
+package dictionary
+
+// Filter reports whether a word may be played
+// Hosted deployments use filters to keep offensive words out of games; see
+// Registry.SetFilter.
+type Filter func(word string) bool
+
+// Lister is implemented by dictionaries that can list their words
+type Lister interface {
+	// Words returns every word in the dictionary in alphabetical order
+	Words() []string
+}
+
+// AsLister returns the dictionary as a Lister if it can list its words
+// Every Filtered view has a Words method, so filtered views are asked whether
+// the dictionary beneath them can list its words.
+func AsLister(dict Dictionary) (Lister, bool) {
+	if f, ok := dict.(*Filtered); ok && !f.CanList() {
+		return nil, false
+	}
+	lister, ok := dict.(Lister)
+	return lister, ok
+}
+
+// Exclude returns a filter allowing every word the list does not hold
+func Exclude(list Dictionary) Filter {
+	return func(word string) bool { return !list.IsValid(word) }
+}
+
+// Within returns a filter allowing only the words the list holds
+func Within(list Dictionary) Filter {
+	return func(word string) bool { return list.IsValid(word) }
+}
+
+// Both returns a filter allowing the words that both filters allow
+func Both(a, b Filter) Filter {
+	return func(word string) bool { return a(word) && b(word) }
+}
+
+// Filtered is a Dictionary holding the words of another dictionary that a
+// filter allows
+type Filtered struct {
+	base  Dictionary
+	allow Filter
+}
+
+// NewFiltered creates a view of base holding only the words allow accepts
+// The words are checked as they are looked up, so changes to base show through.
+func NewFiltered(base Dictionary, allow Filter) *Filtered {
+	return &Filtered{base: base, allow: allow}
+}
+
+// IsValid returns true if the word is in the base dictionary and allowed
+func (f *Filtered) IsValid(word string) bool {
+	return f.base.IsValid(word) && f.allow(Normalize(word))
+}
+
+// Lookup returns the base dictionary's entry for an allowed word
+func (f *Filtered) Lookup(word string) (Entry, bool) {
+	if !f.allow(Normalize(word)) {
+		return Entry{}, false
+	}
+	return f.base.Lookup(word)
+}
+
+// Definition returns the base dictionary's definition of an allowed word, or
+// false if the word is filtered out or the base has no definitions
+func (f *Filtered) Definition(word string) (string, bool) {
+	definer, ok := f.base.(Definer)
+	if !ok || !f.allow(Normalize(word)) {
+		return "", false
+	}
+	return definer.Definition(word)
+}
+
+// CanList returns true if the base dictionary can list its words, so that
+// Words lists the allowed ones
+func (f *Filtered) CanList() bool {
+	_, ok := AsLister(f.base)
+	return ok
+}
+
+// Words returns the allowed words of the base dictionary in alphabetical
+// order, or nil if the base cannot list its words
+func (f *Filtered) Words() []string {
+	lister, ok := f.base.(Lister)
+	if !ok {
+		return nil
+	}
+	var words []string
+	for _, word := range lister.Words() {
+		if f.allow(word) {
+			words = append(words, word)
+		}
+	}
+	return words
+}
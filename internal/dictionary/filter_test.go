@@ -0,0 +1,52 @@
+package dictionary
+
+import (
+	"reflect"
+	"testing"
+)
+
+// lookupOnly is a dictionary that cannot list its words
+type lookupOnly struct{ wl *WordList }
+
+func (d lookupOnly) IsValid(word string) bool         { return d.wl.IsValid(word) }
+func (d lookupOnly) Lookup(word string) (Entry, bool) { return d.wl.Lookup(word) }
+
+// TestFiltered tests restricting a dictionary to the words a filter allows
+func TestFiltered(t *testing.T) {
+	base := wordListOf(Custom, "CAT", "DOG", "DARN")
+	f := NewFiltered(base, Exclude(wordListOf(Custom, "DARN")))
+
+	if !f.IsValid("cat") || f.IsValid(" darn ") || f.IsValid("COW") {
+		t.Errorf("Should accept only allowed words in the base list")
+	}
+	if _, ok := f.Lookup("DARN"); ok {
+		t.Errorf("Lookup should not find filtered words")
+	}
+	if e, ok := f.Lookup("dog"); !ok || e.Word != "DOG" || e.Lexicon != Custom {
+		t.Errorf("Lookup should return the base entry, got %+v", e)
+	}
+	if words := f.Words(); !reflect.DeepEqual(words, []string{"CAT", "DOG"}) {
+		t.Errorf("Words should leave out filtered words, got %v", words)
+	}
+	unlisted := NewFiltered(lookupOnly{base}, Within(base))
+	if words := unlisted.Words(); words != nil {
+		t.Errorf("A base that cannot list its words should list none, got %v", words)
+	}
+	if _, ok := AsLister(unlisted); ok || unlisted.CanList() || !f.CanList() {
+		t.Errorf("Filtered views should list words only when their base can")
+	}
+	if _, ok := AsLister(NewFiltered(unlisted, Within(base))); ok {
+		t.Errorf("Nested views should look through to the base")
+	}
+
+	// Changes to the base show through
+	base.AddWord("COW")
+	if !f.IsValid("COW") {
+		t.Errorf("Words added to the base should be allowed")
+	}
+
+	only := NewFiltered(base, Both(Within(wordListOf(Custom, "CAT", "DARN")), Exclude(wordListOf(Custom, "DARN"))))
+	if words := only.Words(); !reflect.DeepEqual(words, []string{"CAT"}) {
+		t.Errorf("Both should allow words that pass both filters, got %v", words)
+	}
+}
@@ -0,0 +1,48 @@
+package dictionary
+
+import (
+	"sort"
+	"strings"
+)
+
+// Hooks lists the single letters that can be added to a word to form another
+type Hooks struct {
+	Front string `json:"front"` // Letters that can go before the word, alphabetically
+	Back  string `json:"back"`  // Letters that can go after the word, alphabetically
+}
+
+// Hooks returns the front and back hooks of a word
+// The word itself need not be valid; hooks onto a fragment on the board tell
+// how open it is.
+func (wl *WordList) Hooks(word string) Hooks {
+	word = Normalize(word)
+
+	var front, back []rune
+	wl.mu.RLock()
+	for r := range wl.letters {
+		if wl.words[string(r)+word] {
+			front = append(front, r)
+		}
+		if wl.words[word+string(r)] {
+			back = append(back, r)
+		}
+	}
+	wl.mu.RUnlock()
+
+	return Hooks{Front: sortedLetters(front), Back: sortedLetters(back)}
+}
+
+// Extensions returns every longer word made by adding letters to the front,
+// back or both ends of a word, longest first
+func (wl *WordList) Extensions(word string) []string {
+	word = Normalize(word)
+	return wl.filter(func(w string) bool {
+		return len(w) > len(word) && strings.Contains(w, word)
+	}, byLength)
+}
+
+// sortedLetters returns letters as a string in alphabetical order
+func sortedLetters(letters []rune) string {
+	sort.Slice(letters, func(i, j int) bool { return letters[i] < letters[j] })
+	return string(letters)
+}
@@ -0,0 +1,46 @@
+package dictionary
+
+import (
+	"reflect"
+	"testing"
+)
+
+// newHookList creates a word list around CARE for hook tests
+func newHookList() *WordList {
+	wl := NewWordList(Custom)
+	for _, word := range []string{"CARE", "SCARE", "CARED", "CARER", "CARES", "SCARED", "CAREER", "CAR", "RACE"} {
+		wl.AddWord(word)
+	}
+	return wl
+}
+
+// TestHooks tests front and back hooks
+func TestHooks(t *testing.T) {
+	wl := newHookList()
+
+	hooks := wl.Hooks("care")
+	if hooks.Front != "S" || hooks.Back != "DRS" {
+		t.Errorf("CARE should have hooks S/DRS, got %+v", hooks)
+	}
+
+	// Fragments that are not words can still be hooked
+	if hooks := wl.Hooks("CAR"); hooks.Back != "E" {
+		t.Errorf("CAR should take E at the back, got %+v", hooks)
+	}
+	if hooks := wl.Hooks("RACE"); hooks != (Hooks{}) {
+		t.Errorf("RACE should have no hooks, got %+v", hooks)
+	}
+}
+
+// TestExtensions tests words built around a word
+func TestExtensions(t *testing.T) {
+	wl := newHookList()
+
+	want := []string{"CAREER", "SCARED", "CARED", "CARER", "CARES", "SCARE"}
+	if got := wl.Extensions("CARE"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if got := wl.Extensions("SCARED"); len(got) != 0 {
+		t.Errorf("SCARED should have no extensions, got %v", got)
+	}
+}
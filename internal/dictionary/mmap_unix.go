@@ -0,0 +1,37 @@
+//go:build unix
+
+package dictionary
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// mapFile maps a file read-only into memory, returning its contents and a
+// function that unmaps it
+func mapFile(filename string) ([]byte, func() error, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, nil, err
+	}
+	size := info.Size()
+	if size == 0 {
+		return nil, nil, errors.New("file is empty")
+	}
+	if int64(int(size)) != size {
+		return nil, nil, errors.New("file is too large to map")
+	}
+
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(size), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, func() error { return syscall.Munmap(data) }, nil
+}
@@ -0,0 +1,147 @@
+package dictionary
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Errors returned by the registry
+var (
+	ErrDictionaryNotFound = errors.New("dictionary not registered")
+	ErrNoDefault          = errors.New("no default dictionary set")
+)
+
+// Registry holds dictionaries by name, such as "TWL06", "CSW21" or a club's
+// own list, with one selected as the default for new games
+// Dictionaries may be registered, replaced and made default while games are
+// running; games keep the dictionary they were given. A content filter set on
+// the registry applies to every dictionary it hands out.
+type Registry struct {
+	dicts       map[string]Dictionary
+	defaultName string
+	filter      Filter // Words every dictionary handed out must pass (nil allows all)
+	mu          sync.RWMutex
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{dicts: make(map[string]Dictionary)}
+}
+
+// Register adds a dictionary under a name, replacing any registered before
+// Names are case-insensitive. The first dictionary registered becomes the default.
+func (r *Registry) Register(name string, dict Dictionary) error {
+	key := registryKey(name)
+	if key == "" {
+		return errors.New("dictionary name must not be empty")
+	}
+	if dict == nil {
+		return errors.New("dictionary must not be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.dicts[key] = dict
+	if r.defaultName == "" {
+		r.defaultName = key
+	}
+	return nil
+}
+
+// Unregister removes a dictionary; removing the default leaves none set
+func (r *Registry) Unregister(name string) error {
+	key := registryKey(name)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.dicts[key]; !ok {
+		return fmt.Errorf("%w: %s", ErrDictionaryNotFound, name)
+	}
+	delete(r.dicts, key)
+	if r.defaultName == key {
+		r.defaultName = ""
+	}
+	return nil
+}
+
+// SetDefault selects the dictionary given to games that do not name one
+func (r *Registry) SetDefault(name string) error {
+	key := registryKey(name)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.dicts[key]; !ok {
+		return fmt.Errorf("%w: %s", ErrDictionaryNotFound, name)
+	}
+	r.defaultName = key
+	return nil
+}
+
+// DefaultName returns the name of the default dictionary, or "" if none is set
+func (r *Registry) DefaultName() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.defaultName
+}
+
+// SetFilter restricts every dictionary handed out from now on to the words
+// allow accepts, so that hosted deployments can keep offensive words out of
+// validation and, through the dictionary's Words, out of bot play; nil
+// removes the filter
+func (r *Registry) SetFilter(allow Filter) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.filter = allow
+}
+
+// Get returns the dictionary registered under name, and the name it is
+// registered under; an empty name selects the default
+// Tiers are registered under their TierName. With a filter set, the
+// dictionary is wrapped to apply it.
+func (r *Registry) Get(name string) (Dictionary, string, error) {
+	key := registryKey(name)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if key == "" {
+		if r.defaultName == "" {
+			return nil, "", ErrNoDefault
+		}
+		key = r.defaultName
+	}
+	dict, ok := r.dicts[key]
+	if !ok {
+		return nil, "", fmt.Errorf("%w: %s", ErrDictionaryNotFound, name)
+	}
+	if r.filter != nil {
+		dict = NewFiltered(dict, r.filter)
+	}
+	return dict, key, nil
+}
+
+// Names returns the registered names in alphabetical order
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	names := make([]string, 0, len(r.dicts))
+	for name := range r.dicts {
+		names = append(names, name)
+	}
+	r.mu.RUnlock()
+
+	sort.Strings(names)
+	return names
+}
+
+// registryKey normalizes a dictionary name
+func registryKey(name string) string {
+	return strings.ToUpper(strings.TrimSpace(name))
+}
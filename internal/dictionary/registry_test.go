@@ -0,0 +1,127 @@
+package dictionary
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// wordListOf builds a custom word list holding the given words
+func wordListOf(lexicon Lexicon, words ...string) *WordList {
+	wl := NewWordList(lexicon)
+	for _, w := range words {
+		wl.AddWord(w)
+	}
+	return wl
+}
+
+// TestRegistry tests registering, selecting and removing dictionaries
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+	if _, _, err := r.Get(""); err != ErrNoDefault {
+		t.Errorf("An empty registry should have no default, got %v", err)
+	}
+
+	twl := wordListOf(TWL06, "CAT")
+	csw := wordListOf(Collins, "CAT", "QI")
+	if err := r.Register("twl06", twl); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	r.Register("CSW21", csw)
+
+	if r.DefaultName() != "TWL06" {
+		t.Errorf("The first dictionary should become the default, got %q", r.DefaultName())
+	}
+	if d, name, err := r.Get(""); err != nil || d != Dictionary(twl) || name != "TWL06" {
+		t.Errorf("Empty name should select the default, got %q %v", name, err)
+	}
+	if d, name, _ := r.Get(" csw21 "); d != Dictionary(csw) || name != "CSW21" {
+		t.Errorf("Names should be case-insensitive, got %q", name)
+	}
+	if _, _, err := r.Get("club"); !errors.Is(err, ErrDictionaryNotFound) {
+		t.Errorf("Expected ErrDictionaryNotFound, got %v", err)
+	}
+	if !reflect.DeepEqual(r.Names(), []string{"CSW21", "TWL06"}) {
+		t.Errorf("Unexpected names %v", r.Names())
+	}
+
+	if err := r.Register(" ", twl); err == nil {
+		t.Errorf("Should reject an empty name")
+	}
+	if err := r.Register("x", nil); err == nil {
+		t.Errorf("Should reject a nil dictionary")
+	}
+
+	if err := r.Unregister("TWL06"); err != nil {
+		t.Fatalf("Unregister failed: %v", err)
+	}
+	if r.DefaultName() != "" {
+		t.Errorf("Removing the default should leave none")
+	}
+	if err := r.Unregister("TWL06"); !errors.Is(err, ErrDictionaryNotFound) {
+		t.Errorf("Expected ErrDictionaryNotFound, got %v", err)
+	}
+}
+
+// TestRegistryHotSwap tests replacing dictionaries and the default while in use
+func TestRegistryHotSwap(t *testing.T) {
+	r := NewRegistry()
+	r.Register("club", wordListOf(Custom, "CAT"))
+	r.Register("CSW21", wordListOf(Collins, "QI"))
+
+	if err := r.SetDefault("csw21"); err != nil {
+		t.Fatalf("SetDefault failed: %v", err)
+	}
+	if d, _, _ := r.Get(""); !d.IsValid("QI") {
+		t.Errorf("The new default should be used")
+	}
+	if err := r.SetDefault("missing"); !errors.Is(err, ErrDictionaryNotFound) {
+		t.Errorf("Expected ErrDictionaryNotFound, got %v", err)
+	}
+
+	old, _, _ := r.Get("club")
+	r.Register("club", wordListOf(Custom, "CAT", "DOG"))
+	if d, _, _ := r.Get("club"); !d.IsValid("DOG") {
+		t.Errorf("Re-registering should replace the dictionary")
+	}
+	if old.IsValid("DOG") {
+		t.Errorf("Holders of the old dictionary should keep it unchanged")
+	}
+
+	// Lookups and swaps may run concurrently
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i%2 == 0 {
+				r.SetDefault("club")
+			} else {
+				r.Get("")
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+// TestRegistryFilter tests applying a content filter to every dictionary handed out
+func TestRegistryFilter(t *testing.T) {
+	r := NewRegistry()
+	twl := wordListOf(TWL06, "CAT", "DARN")
+	r.Register("TWL06", twl)
+
+	r.SetFilter(Exclude(wordListOf(Custom, "DARN")))
+	dict, _, _ := r.Get("")
+	if !dict.IsValid("CAT") || dict.IsValid("DARN") {
+		t.Errorf("The filter should apply to dictionaries handed out")
+	}
+	if words := dict.(Lister).Words(); len(words) != 1 {
+		t.Errorf("The filter should apply to listed words, got %v", words)
+	}
+
+	r.SetFilter(nil)
+	if dict, _, _ := r.Get(""); dict != Dictionary(twl) {
+		t.Errorf("Removing the filter should hand out the dictionary itself")
+	}
+}
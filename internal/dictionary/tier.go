@@ -0,0 +1,69 @@
+package dictionary
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Tier selects how much of a word list is playable
+type Tier int
+
+const (
+	FullTier   Tier = iota // Every word in the list
+	Expurgated             // The list without offensive words
+	School                 // Expurgated words that are on a school word list
+)
+
+// String returns a string representation of the tier
+func (t Tier) String() string {
+	switch t {
+	case FullTier:
+		return "FULL"
+	case Expurgated:
+		return "EXPURGATED"
+	case School:
+		return "SCHOOL"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// ParseTier returns the tier with the given name, ignoring case
+func ParseTier(name string) (Tier, error) {
+	for t := FullTier; t <= School; t++ {
+		if strings.EqualFold(strings.TrimSpace(name), t.String()) {
+			return t, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown dictionary tier %q", name)
+}
+
+// TierName returns the registry name of a word list's tier, such as
+// "TWL06/SCHOOL"; the full tier is registered under the list's own name
+func TierName(name string, tier Tier) string {
+	if tier == FullTier {
+		return name
+	}
+	return name + "/" + tier.String()
+}
+
+// RegisterTiers registers a word list with its expurgated and school tiers
+// The expurgated tier leaves out the words on the offensive list, and the
+// school tier keeps only the expurgated words on the school list; a nil list
+// leaves its tier unregistered. Games select a tier by its TierName.
+func (r *Registry) RegisterTiers(name string, full, offensive, school Dictionary) error {
+	if err := r.Register(name, full); err != nil {
+		return err
+	}
+	if offensive == nil {
+		return nil
+	}
+	expurgated := Exclude(offensive)
+	if err := r.Register(TierName(name, Expurgated), NewFiltered(full, expurgated)); err != nil {
+		return err
+	}
+	if school == nil {
+		return nil
+	}
+	return r.Register(TierName(name, School), NewFiltered(full, Both(expurgated, Within(school))))
+}
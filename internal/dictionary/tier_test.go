@@ -0,0 +1,62 @@
+package dictionary
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestParseTier tests reading tier names
+func TestParseTier(t *testing.T) {
+	for tier := FullTier; tier <= School; tier++ {
+		if got, err := ParseTier(tier.String()); err != nil || got != tier {
+			t.Errorf("ParseTier(%q) = %v, %v", tier.String(), got, err)
+		}
+	}
+	if got, err := ParseTier(" school "); err != nil || got != School {
+		t.Errorf("Tier names should be case-insensitive, got %v %v", got, err)
+	}
+	if _, err := ParseTier("adult"); err == nil {
+		t.Errorf("Should reject unknown tiers")
+	}
+	if name := TierName("TWL06", FullTier); name != "TWL06" {
+		t.Errorf("The full tier should use the list's name, got %q", name)
+	}
+}
+
+// TestRegisterTiers tests registering and selecting the tiers of a word list
+func TestRegisterTiers(t *testing.T) {
+	r := NewRegistry()
+	full := wordListOf(TWL06, "CAT", "DOG", "DARN", "ZAX")
+	if err := r.RegisterTiers("twl06", full, wordListOf(Custom, "DARN"), wordListOf(Custom, "CAT", "DOG", "DARN")); err != nil {
+		t.Fatalf("RegisterTiers failed: %v", err)
+	}
+
+	tests := []struct {
+		tier  Tier
+		valid map[string]bool
+	}{
+		{FullTier, map[string]bool{"CAT": true, "DARN": true, "ZAX": true}},
+		{Expurgated, map[string]bool{"CAT": true, "DARN": false, "ZAX": true}},
+		{School, map[string]bool{"CAT": true, "DARN": false, "ZAX": false}},
+	}
+	for _, tt := range tests {
+		dict, name, err := r.Get(TierName("twl06", tt.tier))
+		if err != nil {
+			t.Fatalf("Get %v failed: %v", tt.tier, err)
+		}
+		if name != TierName("TWL06", tt.tier) {
+			t.Errorf("Expected the tier's registry name, got %q", name)
+		}
+		for word, valid := range tt.valid {
+			if dict.IsValid(word) != valid {
+				t.Errorf("%v tier: IsValid(%s) should be %v", tt.tier, word, valid)
+			}
+		}
+	}
+
+	// Without lists the tiers are left out
+	r.RegisterTiers("CSW21", full, nil, nil)
+	if _, _, err := r.Get(TierName("CSW21", Expurgated)); !errors.Is(err, ErrDictionaryNotFound) {
+		t.Errorf("Expected no expurgated tier, got %v", err)
+	}
+}
@@ -0,0 +1,159 @@
+package dictionary
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// maxExamples caps the words kept for each kind of fault in a Verification
+const maxExamples = 20
+
+// Verification reports how a DAWG compares with the word list it was built from
+// A corrupt binary can pass ParseDAWG's structural checks yet drop words or
+// accept words never listed, and a graph changed in memory can loop back on
+// itself; each kind of fault is counted with up to maxExamples examples.
+type Verification struct {
+	Listed    int // Words in the word list, each looked up in the DAWG
+	Walked    int // Words enumerated from the graph
+	Fuzzed    int // Random strings looked up in both
+	Faults    int // Total faults of every kind
+	Missing   []string
+	Phantoms  []string
+	Disagreed []string // Random strings the DAWG and word list disagree on
+	Problems  []string // Structural faults, such as cycles or a wrong word count
+}
+
+// OK returns true if no faults were found
+func (v *Verification) OK() bool {
+	return v.Faults == 0
+}
+
+// VerifyDAWG checks that a DAWG holds exactly the words of a word list and
+// then looks up fuzz random strings in both, comparing the answers
+// Half the strings are listed words with a letter changed, added or dropped,
+// which probe the paths real lookups take; the rest are random letters.
+func VerifyDAWG(d *DAWG, wl *WordList, fuzz int, rng *rand.Rand) *Verification {
+	v := &Verification{}
+	words := wl.Words()
+
+	v.Listed = len(words)
+	for _, w := range words {
+		if !d.IsValid(w) {
+			v.fault(&v.Missing, w)
+		}
+	}
+
+	if d.edgeCount() > 0 {
+		onPath := make(map[uint32]bool)
+		d.verifyWalk(0, nil, onPath, func(word string) {
+			v.Walked++
+			if !wl.IsValid(word) {
+				v.fault(&v.Phantoms, word)
+			}
+		}, func(problem string) {
+			v.fault(&v.Problems, problem)
+		})
+	}
+	if d.Size() != v.Walked {
+		v.fault(&v.Problems, fmt.Sprintf("header counts %d words, graph holds %d", d.Size(), v.Walked))
+	}
+
+	alphabet := fuzzAlphabet(wl)
+	for i := 0; i < fuzz; i++ {
+		var s string
+		if i%2 == 0 && len(words) > 0 {
+			s = mutate(words[rng.Intn(len(words))], alphabet, rng)
+		} else {
+			s = randomString(alphabet, rng)
+		}
+		v.Fuzzed++
+		if d.IsValid(s) != wl.IsValid(s) {
+			v.fault(&v.Disagreed, s)
+		}
+	}
+	return v
+}
+
+// fault counts a fault and keeps it as an example if there is room
+func (v *Verification) fault(examples *[]string, example string) {
+	v.Faults++
+	if len(*examples) < maxExamples {
+		*examples = append(*examples, example)
+	}
+}
+
+// verifyWalk enumerates the words below a node like walk, reporting edges
+// that lead back to a node on the current path instead of following them
+func (d *DAWG) verifyWalk(node uint32, prefix []rune, onPath map[uint32]bool, word func(string), problem func(string)) {
+	onPath[node] = true
+	defer delete(onPath, node)
+
+	for i := node; ; i++ {
+		letter, next := d.edge(i)
+		w := append(prefix, rune(letter))
+		if next&dawgEndOfWord != 0 {
+			word(string(w))
+		}
+		if child := next >> 2; child != 0 {
+			if onPath[child] {
+				problem(fmt.Sprintf("edge %d after %q loops back to edge %d", i, string(w), child))
+			} else {
+				d.verifyWalk(child, w, onPath, word, problem)
+			}
+		}
+		if next&dawgLastEdge != 0 {
+			return
+		}
+	}
+}
+
+// fuzzAlphabet returns the letters of a word list plus A to Z, so random
+// strings use letters the DAWG holds as well as ones it may not
+func fuzzAlphabet(wl *WordList) []rune {
+	seen := make(map[rune]bool)
+	var alphabet []rune
+	for r := 'A'; r <= 'Z'; r++ {
+		seen[r] = true
+		alphabet = append(alphabet, r)
+	}
+	wl.mu.RLock()
+	for r := range wl.letters {
+		if !seen[r] {
+			alphabet = append(alphabet, r)
+		}
+	}
+	wl.mu.RUnlock()
+	return []rune(sortedLetters(alphabet))
+}
+
+// mutate changes, adds or drops one letter of a word
+func mutate(word string, alphabet []rune, rng *rand.Rand) string {
+	runes := []rune(word)
+	i := rng.Intn(len(runes) + 1)
+	letter := alphabet[rng.Intn(len(alphabet))]
+	switch rng.Intn(3) {
+	case 0:
+		if i < len(runes) {
+			runes[i] = letter
+			break
+		}
+		fallthrough
+	case 1:
+		runes = append(runes[:i], append([]rune{letter}, runes[i:]...)...)
+	default:
+		if i == len(runes) {
+			i--
+		}
+		runes = append(runes[:i], runes[i+1:]...)
+	}
+	return string(runes)
+}
+
+// randomString returns one to 15 random letters
+func randomString(alphabet []rune, rng *rand.Rand) string {
+	runes := make([]rune, 1+rng.Intn(15))
+	for i := range runes {
+		runes[i] = alphabet[rng.Intn(len(alphabet))]
+	}
+	return string(runes)
+}
@@ -0,0 +1,98 @@
+package dictionary
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// verifyList builds a word list from words
+func verifyList(t *testing.T, words ...string) *WordList {
+	t.Helper()
+	wl := NewWordList(Custom)
+	if err := wl.LoadFromReader(strings.NewReader(strings.Join(words, "\n"))); err != nil {
+		t.Fatalf("LoadFromReader failed: %v", err)
+	}
+	return wl
+}
+
+// TestVerifyDAWG tests that a DAWG built from a word list verifies against it
+func TestVerifyDAWG(t *testing.T) {
+	wl := verifyList(t, dawgWords...)
+	d, err := BuildDAWGFromWordList(wl)
+	if err != nil {
+		t.Fatalf("BuildDAWGFromWordList failed: %v", err)
+	}
+
+	v := VerifyDAWG(d, wl, 2000, rand.New(rand.NewSource(1)))
+	if !v.OK() {
+		t.Errorf("Should find no faults, got %+v", v)
+	}
+	if v.Listed != 17 || v.Walked != 17 || v.Fuzzed != 2000 {
+		t.Errorf("Expected 17 words listed and walked and 2000 fuzzed, got %+v", v)
+	}
+}
+
+// TestVerifyDAWGFaults tests detecting dropped words, phantom words and cycles
+func TestVerifyDAWGFaults(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	// Building from an extra word leaves a phantom, and the fuzzer's
+	// mutations of CAT soon try it
+	wl := verifyList(t, "CAT", "CATS")
+	d, _ := BuildDAWG(Custom, []string{"CAT", "CATS", "CAST"})
+	v := VerifyDAWG(d, wl, 1000, rng)
+	if v.OK() || len(v.Phantoms) != 1 || v.Phantoms[0] != "CAST" {
+		t.Errorf("Should find the phantom CAST, got %+v", v)
+	}
+	if len(v.Disagreed) == 0 || v.Disagreed[0] != "CAST" {
+		t.Errorf("Lookups should disagree on CAST, got %+v", v)
+	}
+
+	// Clearing the end-of-word flag on the root's A edge drops the word A
+	wl = verifyList(t, "A", "AB", "ABC")
+	d, _ = BuildDAWGFromWordList(wl)
+	binary.LittleEndian.PutUint32(d.edges[4:], binary.LittleEndian.Uint32(d.edges[4:])&^dawgEndOfWord)
+	v = VerifyDAWG(d, wl, 0, rng)
+	if len(v.Missing) != 1 || v.Missing[0] != "A" || v.Walked != 2 {
+		t.Errorf("Should find A missing, got %+v", v)
+	}
+	if len(v.Problems) != 1 || !strings.Contains(v.Problems[0], "header counts 3 words") {
+		t.Errorf("Should flag the word count, got %+v", v)
+	}
+
+	// Pointing C back at the node after A makes a cycle
+	d, _ = BuildDAWGFromWordList(wl)
+	binary.LittleEndian.PutUint32(d.edges[20:], 1<<2|dawgEndOfWord|dawgLastEdge)
+	if _, err := ParseDAWG(encoded(t, d)); !errors.Is(err, ErrNotDAWG) {
+		t.Errorf("Loading should reject a cycle, got %v", err)
+	}
+	v = VerifyDAWG(d, wl, 0, rng)
+	if len(v.Problems) != 1 || !strings.Contains(v.Problems[0], "loops back") {
+		t.Errorf("Should report the cycle, got %+v", v)
+	}
+}
+
+// encoded returns the binary form of a DAWG
+func encoded(t *testing.T, d *DAWG) []byte {
+	t.Helper()
+	var sb strings.Builder
+	if _, err := d.WriteTo(&sb); err != nil {
+		t.Fatalf("WriteTo failed: %v", err)
+	}
+	return []byte(sb.String())
+}
+
+// TestMutate tests that mutations stay within one edit of the word
+func TestMutate(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	alphabet := []rune("XYZ")
+	for i := 0; i < 200; i++ {
+		got := mutate("CAT", alphabet, rng)
+		if n := len(got); n < 2 || n > 4 || got == "CAT" {
+			t.Fatalf("Expected CAT with one letter changed, added or dropped, got %q", got)
+		}
+	}
+}
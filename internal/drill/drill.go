@@ -0,0 +1,172 @@
+// Package drill quizzes players on whether words are valid, mixing dictionary
+// words with plausible phonies, for challenge training
+package drill
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"scrabbled/internal/dictionary"
+	"scrabbled/internal/game"
+)
+
+// Default drill settings
+const (
+	DefaultQuestions = 20
+	DefaultPhonyRate = 0.5
+	DefaultMinLength = 2
+	DefaultMaxLength = 8
+)
+
+// Errors returned by drills
+var (
+	ErrNoQuestion = errors.New("no question is waiting for an answer")
+	ErrNoWords    = errors.New("the dictionary has no words to drill")
+)
+
+// Options configures the words a drill asks about
+type Options struct {
+	Questions int     // Words asked in the drill
+	PhonyRate float64 // Fraction of the words that are phonies
+	MinLength int     // Shortest word asked
+	MaxLength int     // Longest word asked
+}
+
+// DefaultOptions returns a drill of 20 words of two to eight letters, half
+// of them phonies
+func DefaultOptions() Options {
+	return Options{
+		Questions: DefaultQuestions,
+		PhonyRate: DefaultPhonyRate,
+		MinLength: DefaultMinLength,
+		MaxLength: DefaultMaxLength,
+	}
+}
+
+// Question is a word put to the player
+type Question struct {
+	Number int    `json:"number"` // Position in the drill, from 1
+	Word   string `json:"word"`
+}
+
+// Answer records the player's ruling on a word
+type Answer struct {
+	Question
+	Valid      bool          `json:"valid"`                // True if the dictionary holds the word
+	Said       bool          `json:"said"`                 // True if the player ruled the word valid
+	Correct    bool          `json:"correct"`              // True if the ruling was right
+	Elapsed    time.Duration `json:"elapsed"`              // Time taken to answer
+	Definition string        `json:"definition,omitempty"` // Definition of a valid word, if the dictionary carries one
+}
+
+// Drill is one player's run through a list of words
+// A drill is not safe for concurrent use.
+type Drill struct {
+	PlayerID string
+	dict     dictionary.Dictionary
+	words    []string
+	answers  []Answer
+	asked    time.Time // When the waiting question was asked (zero when none waits)
+	now      func() time.Time
+}
+
+// New deals a drill from the dictionary's words and phonies invented from
+// them; the words chosen are determined by seed
+// Returns an error if the dictionary cannot list its words or has none of
+// the lengths asked for.
+func New(playerID string, dict dictionary.Dictionary, opts Options, seed int64) (*Drill, error) {
+	if opts.Questions <= 0 || opts.PhonyRate < 0 || opts.PhonyRate > 1 || opts.MinLength < 1 || opts.MaxLength < opts.MinLength {
+		return nil, fmt.Errorf("invalid drill options %+v", opts)
+	}
+	lister, ok := dictionary.AsLister(dict)
+	if !ok {
+		return nil, fmt.Errorf("dictionary %T cannot list its words", dict)
+	}
+
+	fits := func(w string) bool { return len(w) >= opts.MinLength && len(w) <= opts.MaxLength }
+	var valid, phonies []string
+	all := lister.Words()
+	for _, w := range all {
+		if fits(w) {
+			valid = append(valid, w)
+		}
+	}
+	for _, w := range game.InventPhonies(all) {
+		if fits(w) && !dict.IsValid(w) {
+			phonies = append(phonies, w)
+		}
+	}
+	if len(valid) == 0 {
+		return nil, ErrNoWords
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	words := make([]string, opts.Questions)
+	for i := range words {
+		if len(phonies) > 0 && rng.Float64() < opts.PhonyRate {
+			words[i] = phonies[rng.Intn(len(phonies))]
+		} else {
+			words[i] = valid[rng.Intn(len(valid))]
+		}
+	}
+	return &Drill{PlayerID: playerID, dict: dict, words: words, now: time.Now}, nil
+}
+
+// Next asks the next word, starting its timer, or returns false when the
+// drill is over
+// Asking again before answering repeats the waiting question without
+// restarting its timer.
+func (d *Drill) Next() (Question, bool) {
+	n := len(d.answers)
+	if n >= len(d.words) {
+		return Question{}, false
+	}
+	if d.asked.IsZero() {
+		d.asked = d.now()
+	}
+	return Question{Number: n + 1, Word: d.words[n]}, true
+}
+
+// Answer rules on the waiting question, valid being the player's call, and
+// returns how they did
+func (d *Drill) Answer(valid bool) (Answer, error) {
+	if d.asked.IsZero() {
+		return Answer{}, ErrNoQuestion
+	}
+	n := len(d.answers)
+	word := d.words[n]
+	a := Answer{
+		Question: Question{Number: n + 1, Word: word},
+		Valid:    d.dict.IsValid(word),
+		Said:     valid,
+		Elapsed:  d.now().Sub(d.asked),
+	}
+	a.Correct = a.Said == a.Valid
+	if definer, ok := d.dict.(dictionary.Definer); ok && a.Valid {
+		a.Definition, _ = definer.Definition(word)
+	}
+	d.answers = append(d.answers, a)
+	d.asked = time.Time{}
+	return a, nil
+}
+
+// Remaining returns the number of words not yet answered
+func (d *Drill) Remaining() int {
+	return len(d.words) - len(d.answers)
+}
+
+// Answers returns the answers given so far, in order
+func (d *Drill) Answers() []Answer {
+	return append([]Answer(nil), d.answers...)
+}
+
+// Stats returns the player's record over the answers given so far
+func (d *Drill) Stats() Stats {
+	s := Stats{PlayerID: d.PlayerID}
+	for _, a := range d.answers {
+		s.record(a)
+	}
+	return s
+}
@@ -0,0 +1,143 @@
+package drill
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"scrabbled/internal/dictionary"
+)
+
+// testDictionary builds a word list with a definition for CAT
+func testDictionary(t *testing.T) *dictionary.WordList {
+	t.Helper()
+	wl := dictionary.NewWordList(dictionary.TWL06)
+	words := "CAT a small feline\nCATS\nDOG\nDOGS\nTEA\nEAT\nRATE\nRATED\nQI\n"
+	if err := wl.LoadFromReader(strings.NewReader(words)); err != nil {
+		t.Fatalf("Failed to load words: %v", err)
+	}
+	return wl
+}
+
+// newTestDrill creates a drill whose clock advances a second per reading
+func newTestDrill(t *testing.T, opts Options) *Drill {
+	t.Helper()
+	d, err := New("p1", testDictionary(t), opts, 1)
+	if err != nil {
+		t.Fatalf("Failed to create drill: %v", err)
+	}
+	clock := time.Unix(0, 0)
+	d.now = func() time.Time {
+		clock = clock.Add(time.Second)
+		return clock
+	}
+	return d
+}
+
+// TestNewDrill tests that drills mix valid words and phonies of the lengths asked for
+func TestNewDrill(t *testing.T) {
+	dict := testDictionary(t)
+	opts := Options{Questions: 200, PhonyRate: 0.5, MinLength: 3, MaxLength: 4}
+	d, err := New("p1", dict, opts, 1)
+	if err != nil {
+		t.Fatalf("Failed to create drill: %v", err)
+	}
+	if d.Remaining() != opts.Questions {
+		t.Errorf("Expected %d questions, got %d", opts.Questions, d.Remaining())
+	}
+
+	var valid, phonies int
+	for _, w := range d.words {
+		if len(w) < opts.MinLength || len(w) > opts.MaxLength {
+			t.Errorf("Word %s is outside the lengths asked for", w)
+		}
+		if dict.IsValid(w) {
+			valid++
+		} else {
+			phonies++
+		}
+	}
+	if valid == 0 || phonies == 0 {
+		t.Errorf("Expected a mix of words and phonies, got %d valid and %d phonies", valid, phonies)
+	}
+
+	again, _ := New("p1", dict, opts, 1)
+	if strings.Join(again.words, " ") != strings.Join(d.words, " ") {
+		t.Errorf("Expected the same seed to deal the same words")
+	}
+}
+
+// TestNewDrillErrors tests that drills need valid options and a dictionary with words to list
+func TestNewDrillErrors(t *testing.T) {
+	dict := testDictionary(t)
+	bad := []Options{
+		{Questions: 0, PhonyRate: 0.5, MinLength: 2, MaxLength: 8},
+		{Questions: 10, PhonyRate: 1.5, MinLength: 2, MaxLength: 8},
+		{Questions: 10, PhonyRate: 0.5, MinLength: 5, MaxLength: 4},
+	}
+	for _, opts := range bad {
+		if _, err := New("p1", dict, opts, 1); err == nil {
+			t.Errorf("Expected options %+v to be rejected", opts)
+		}
+	}
+
+	opts := DefaultOptions()
+	opts.MinLength, opts.MaxLength = 7, 8
+	if _, err := New("p1", dict, opts, 1); !errors.Is(err, ErrNoWords) {
+		t.Errorf("Expected ErrNoWords, got %v", err)
+	}
+
+	unlisted := struct{ dictionary.Dictionary }{dict}
+	if _, err := New("p1", unlisted, DefaultOptions(), 1); err == nil {
+		t.Errorf("Expected a dictionary that cannot list its words to be rejected")
+	}
+	filtered := dictionary.NewFiltered(unlisted, func(string) bool { return true })
+	if _, err := New("p1", filtered, DefaultOptions(), 1); err == nil {
+		t.Errorf("Expected a filtered view of it to be rejected too")
+	}
+}
+
+// TestDrillAnswer tests asking, answering and timing questions
+func TestDrillAnswer(t *testing.T) {
+	d := newTestDrill(t, Options{Questions: 2, PhonyRate: 0, MinLength: 3, MaxLength: 3})
+	d.words = []string{"CAT", "CATED"}
+
+	if _, err := d.Answer(true); !errors.Is(err, ErrNoQuestion) {
+		t.Errorf("Expected ErrNoQuestion before a question is asked, got %v", err)
+	}
+
+	q, ok := d.Next()
+	if !ok || q.Number != 1 || q.Word != "CAT" {
+		t.Fatalf("Expected question 1 to be CAT, got %+v, %v", q, ok)
+	}
+	if again, _ := d.Next(); again != q {
+		t.Errorf("Expected asking again to repeat the question, got %+v", again)
+	}
+	a, err := d.Answer(true)
+	if err != nil {
+		t.Fatalf("Failed to answer: %v", err)
+	}
+	if !a.Valid || !a.Said || !a.Correct || a.Elapsed != time.Second || a.Definition != "a small feline" {
+		t.Errorf("Unexpected answer for CAT: %+v", a)
+	}
+
+	d.Next()
+	a, err = d.Answer(true)
+	if err != nil {
+		t.Fatalf("Failed to answer: %v", err)
+	}
+	if a.Valid || a.Correct || a.Definition != "" {
+		t.Errorf("Expected calling the phony CATED valid to be wrong, got %+v", a)
+	}
+
+	if _, ok := d.Next(); ok {
+		t.Errorf("Expected the drill to be over")
+	}
+	if d.Remaining() != 0 || len(d.Answers()) != 2 {
+		t.Errorf("Expected two answers and none remaining, got %d and %d", len(d.Answers()), d.Remaining())
+	}
+	if s := d.Stats(); s.Answered != 2 || s.Correct != 1 || s.PhoniesMissed != 1 || s.TotalTime != 2*time.Second {
+		t.Errorf("Unexpected stats: %+v", s)
+	}
+}
@@ -0,0 +1,75 @@
+package drill
+
+import "time"
+
+// Stats accumulates a player's drill record over one or more drills
+// Phony detection is counted as game.PlayerStats counts challenges: calling a
+// phony invalid catches it, calling it valid misses it, and calling a real
+// word invalid doubts it.
+type Stats struct {
+	PlayerID      string        `json:"player_id"`
+	Answered      int           `json:"answered"`
+	Correct       int           `json:"correct"`
+	PhoniesCaught int           `json:"phonies_caught"` // Phonies ruled invalid
+	PhoniesMissed int           `json:"phonies_missed"` // Phonies ruled valid
+	WordsDoubted  int           `json:"words_doubted"`  // Valid words ruled invalid
+	TotalTime     time.Duration `json:"total_time"`     // Time taken over every answer
+	Slowest       time.Duration `json:"slowest"`        // Longest time taken to answer
+}
+
+// record adds an answer to the stats
+func (s *Stats) record(a Answer) {
+	s.Answered++
+	if a.Correct {
+		s.Correct++
+	}
+	switch {
+	case !a.Valid && !a.Said:
+		s.PhoniesCaught++
+	case !a.Valid:
+		s.PhoniesMissed++
+	case !a.Said:
+		s.WordsDoubted++
+	}
+	s.TotalTime += a.Elapsed
+	if a.Elapsed > s.Slowest {
+		s.Slowest = a.Elapsed
+	}
+}
+
+// Accuracy returns the fraction of answers that were right
+func (s Stats) Accuracy() float64 {
+	if s.Answered == 0 {
+		return 0
+	}
+	return float64(s.Correct) / float64(s.Answered)
+}
+
+// AverageTime returns the mean time taken to answer
+func (s Stats) AverageTime() time.Duration {
+	if s.Answered == 0 {
+		return 0
+	}
+	return s.TotalTime / time.Duration(s.Answered)
+}
+
+// DetectionRate returns the fraction of phonies the player caught
+func (s Stats) DetectionRate() float64 {
+	if s.PhoniesCaught+s.PhoniesMissed == 0 {
+		return 0
+	}
+	return float64(s.PhoniesCaught) / float64(s.PhoniesCaught+s.PhoniesMissed)
+}
+
+// Add merges another set of stats for the same player into s
+func (s *Stats) Add(other Stats) {
+	s.Answered += other.Answered
+	s.Correct += other.Correct
+	s.PhoniesCaught += other.PhoniesCaught
+	s.PhoniesMissed += other.PhoniesMissed
+	s.WordsDoubted += other.WordsDoubted
+	s.TotalTime += other.TotalTime
+	if other.Slowest > s.Slowest {
+		s.Slowest = other.Slowest
+	}
+}
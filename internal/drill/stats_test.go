@@ -0,0 +1,37 @@
+package drill
+
+import (
+	"testing"
+	"time"
+)
+
+// TestStats tests tallying answers into accuracy, detection and timing
+func TestStats(t *testing.T) {
+	var s Stats
+	if s.Accuracy() != 0 || s.AverageTime() != 0 || s.DetectionRate() != 0 {
+		t.Errorf("Expected empty stats to report zero")
+	}
+
+	answers := []Answer{
+		{Valid: true, Said: true, Correct: true, Elapsed: time.Second},
+		{Valid: true, Said: false, Elapsed: 3 * time.Second},
+		{Valid: false, Said: false, Correct: true, Elapsed: 2 * time.Second},
+		{Valid: false, Said: true, Elapsed: 2 * time.Second},
+	}
+	for _, a := range answers {
+		s.record(a)
+	}
+
+	expected := Stats{Answered: 4, Correct: 2, PhoniesCaught: 1, PhoniesMissed: 1, WordsDoubted: 1, TotalTime: 8 * time.Second, Slowest: 3 * time.Second}
+	if s != expected {
+		t.Errorf("Expected %+v, got %+v", expected, s)
+	}
+	if s.Accuracy() != 0.5 || s.AverageTime() != 2*time.Second || s.DetectionRate() != 0.5 {
+		t.Errorf("Unexpected rates: %v, %v, %v", s.Accuracy(), s.AverageTime(), s.DetectionRate())
+	}
+
+	s.Add(Stats{Answered: 1, Correct: 1, PhoniesCaught: 1, TotalTime: 4 * time.Second, Slowest: 4 * time.Second})
+	if s.Answered != 5 || s.PhoniesCaught != 2 || s.Slowest != 4*time.Second || s.AverageTime() != 2400*time.Millisecond {
+		t.Errorf("Unexpected merged stats: %+v", s)
+	}
+}
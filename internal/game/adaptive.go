@@ -0,0 +1,82 @@
+package game
+
+// DefaultAdaptiveBand is how far from its target spread an Adaptive bot's
+// candidate moves may leave the game
+const DefaultAdaptiveBand = 15
+
+// adaptiveWindow is the number of recent opponent turns an Adaptive bot
+// averages to judge their strength
+const adaptiveWindow = 5
+
+// adaptivePrior is the score per turn assumed of opponents who have not yet
+// taken a turn
+const adaptivePrior = 20
+
+// pace is what an Adaptive bot knows of the game when choosing a move
+type pace struct {
+	lead  int // Bot's score minus the leading opponent's
+	reply int // Expected score of the opponents' next turn
+}
+
+// paceOf measures the game for the player to move from a view: their lead
+// and the opponents' average score over their recent turns
+func paceOf(view GameView, playerID string) *pace {
+	p := &pace{reply: adaptivePrior}
+	own, best, found := 0, 0, false
+	for _, pv := range view.Players {
+		switch {
+		case pv.ID == playerID:
+			own = pv.Score
+		case pv.IsActive && (!found || pv.Score > best):
+			best, found = pv.Score, true
+		}
+	}
+	p.lead = own - best
+
+	total, turns := 0, 0
+	for i := len(view.History) - 1; i >= 0 && turns < adaptiveWindow; i-- {
+		m := view.History[i]
+		if m.PlayerID == playerID || m.Type == ChallengeTurn {
+			continue
+		}
+		if !m.Withdrawn {
+			total += m.Score
+		}
+		turns++
+	}
+	if turns > 0 {
+		p.reply = total / turns
+	}
+	return p
+}
+
+// adapt picks a move leaving the spread, once the opponents have replied at
+// their recent pace, within Band of Target, choosing at random when several
+// do and the closest when none does; moves are in descending score order
+func (b *Bot) adapt(moves []Move, p *pace) Move {
+	if p == nil {
+		return moves[0]
+	}
+	off := func(m Move) int {
+		d := p.lead + m.Score - p.reply - b.Target
+		if d < 0 {
+			return -d
+		}
+		return d
+	}
+
+	closest := 0
+	var pool []int
+	for i, m := range moves {
+		if off(m) < off(moves[closest]) {
+			closest = i
+		}
+		if off(m) <= b.Band {
+			pool = append(pool, i)
+		}
+	}
+	if len(pool) == 0 {
+		return moves[closest]
+	}
+	return moves[pool[b.intn(len(pool))]]
+}
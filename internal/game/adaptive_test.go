@@ -0,0 +1,89 @@
+package game
+
+import (
+	"context"
+	"testing"
+)
+
+// TestPaceOf tests measuring the lead and the opponents' recent scoring
+func TestPaceOf(t *testing.T) {
+	view := GameView{Players: []PlayerView{
+		{ID: "bot", Score: 100, IsActive: true},
+		{ID: "p1", Score: 130, IsActive: true},
+		{ID: "p2", Score: 400, IsActive: false},
+	}}
+	if p := paceOf(view, "bot"); p.lead != -30 || p.reply != adaptivePrior {
+		t.Errorf("Expected a lead of -30 and the prior, got %+v", *p)
+	}
+
+	view.History = History{
+		{Type: PlaceTiles, PlayerID: "p1", Score: 90},
+		{Type: PlaceTiles, PlayerID: "p1", Score: 10},
+		{Type: PlaceTiles, PlayerID: "bot", Score: 50},
+		{Type: PlaceTiles, PlayerID: "p1", Score: 30, Withdrawn: true},
+		{Type: ChallengeTurn, PlayerID: "bot"},
+		{Type: Pass, PlayerID: "p1"},
+		{Type: PlaceTiles, PlayerID: "p1", Score: 20},
+		{Type: PlaceTiles, PlayerID: "p1", Score: 30},
+	}
+	// The last five opponent turns: 30, 20, pass, withdrawn and 10
+	if p := paceOf(view, "bot"); p.reply != 12 {
+		t.Errorf("Expected a recent average of 12, got %d", p.reply)
+	}
+}
+
+// TestAdaptiveChoice tests steering toward the target spread
+func TestAdaptiveChoice(t *testing.T) {
+	moves := []Move{{Score: 40}, {Score: 30}, {Score: 20}, {Score: 10}, {Score: 5}}
+	bot := NewBotWithSeed("bot", Adaptive, nil, 1)
+	bot.Band = 0
+
+	tests := []struct {
+		lead, target, want int
+	}{
+		{0, 0, 20},   // Level: match the opponent's pace
+		{-30, 0, 40}, // Behind: play the strongest move
+		{60, 0, 5},   // Far ahead: play the weakest
+		{0, 10, 30},  // Aim to lead by 10
+	}
+	for _, tt := range tests {
+		bot.Target = tt.target
+		if got := bot.adapt(moves, &pace{lead: tt.lead, reply: 20}); got.Score != tt.want {
+			t.Errorf("Lead %d, target %d: expected %d, got %d", tt.lead, tt.target, tt.want, got.Score)
+		}
+	}
+
+	bot.Target, bot.Band = 0, 10
+	seen := map[int]bool{}
+	for i := 0; i < 50; i++ {
+		seen[bot.adapt(moves, &pace{reply: 20}).Score] = true
+	}
+	if len(seen) != 3 || !seen[30] || !seen[20] || !seen[10] {
+		t.Errorf("Should sample the moves within the band, got %v", seen)
+	}
+	if got := bot.adapt(moves, nil); got.Score != 40 {
+		t.Errorf("Without a pace should play greedily, got %d", got.Score)
+	}
+}
+
+// TestAdaptiveStrategy tests an adaptive strategy easing off when far ahead
+func TestAdaptiveStrategy(t *testing.T) {
+	mg := NewMoveGenerator(testWords)
+	g := newTestGame(t, 2)
+	g.StartGame()
+	g.Players[0].Rack = rackOf("CATSDOG")
+	g.Players[0].Score = 200
+
+	s := NewAdaptiveStrategy(mg, 0)
+	move, err := s.ChooseMove(context.Background(), g.View(ViewOptions{PlayerID: "p1"}))
+	if err != nil {
+		t.Fatalf("ChooseMove failed: %v", err)
+	}
+	moves := mg.GenerateMoves(g.Board, g.Players[0].Rack)
+	if move.Score != moves[len(moves)-1].Score || move.PlayerID != "p1" {
+		t.Errorf("Should play a weakest move (%d) for p1, got %d for %s", moves[len(moves)-1].Score, move.Score, move.PlayerID)
+	}
+	if name := strategyName(s); name != "ADAPTIVE" {
+		t.Errorf("Expected the strategy named ADAPTIVE, got %s", name)
+	}
+}
@@ -0,0 +1,164 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Errors returned when annotating a game
+var (
+	ErrNoSuchTurn        = errors.New("no move at that turn")
+	ErrInvalidAnnotation = errors.New("invalid annotation")
+)
+
+// AnnotationKind identifies what an annotation adds to a move
+type AnnotationKind int
+
+const (
+	CommentAnnotation     AnnotationKind = iota // Free-text remark
+	AlternativeAnnotation                       // A placement that could have been made instead
+	EvaluationAnnotation                        // An engine's assessment of the move
+)
+
+// String returns a string representation of the annotation kind
+func (k AnnotationKind) String() string {
+	switch k {
+	case CommentAnnotation:
+		return "COMMENT"
+	case AlternativeAnnotation:
+		return "ALTERNATIVE"
+	case EvaluationAnnotation:
+		return "EVALUATION"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// Annotation is a note attached to a move in the game history, for review
+// and teaching
+// Annotations do not change the game and are not part of its event stream.
+type Annotation struct {
+	Turn        int            `json:"turn"` // Index of the move in the game history
+	Kind        AnnotationKind `json:"kind"`
+	Author      string         `json:"author,omitempty"`
+	Text        string         `json:"text,omitempty"`
+	Alternative *Move          `json:"alternative,omitempty"` // Suggested placement, required for alternatives
+	Equity      float64        `json:"equity,omitempty"`      // Evaluated equity of the move, for evaluations
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+// Annotation converts coaching feedback into an evaluation of the move,
+// naming the best move when a better one was available
+func (fb MoveFeedback) Annotation() Annotation {
+	a := Annotation{Turn: fb.Turn, Kind: EvaluationAnnotation, Equity: fb.Played.Equity}
+	if fb.EquityLoss > 0 {
+		best := fb.Best.Move
+		a.Alternative = &best
+	}
+	return a
+}
+
+// Annotate attaches an annotation to a move in the history
+// Comments need text and alternatives a tile placement; the placement is not
+// checked against the position. Annotations on the same move keep the order
+// in which they were added.
+func (g *Game) Annotate(a Annotation) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if a.Turn < 0 || a.Turn >= len(g.History) {
+		return fmt.Errorf("%w: %d", ErrNoSuchTurn, a.Turn)
+	}
+	switch a.Kind {
+	case CommentAnnotation:
+		if strings.TrimSpace(a.Text) == "" {
+			return fmt.Errorf("%w: comment has no text", ErrInvalidAnnotation)
+		}
+	case AlternativeAnnotation:
+		if a.Alternative == nil || a.Alternative.Type != PlaceTiles || len(a.Alternative.Tiles) == 0 {
+			return fmt.Errorf("%w: alternative has no placement", ErrInvalidAnnotation)
+		}
+	case EvaluationAnnotation:
+	default:
+		return fmt.Errorf("%w: unknown kind %d", ErrInvalidAnnotation, a.Kind)
+	}
+
+	if a.Alternative != nil {
+		alt := *a.Alternative
+		alt.Tiles = append([]PlacedTile(nil), alt.Tiles...)
+		a.Alternative = &alt
+	}
+	if a.CreatedAt.IsZero() {
+		a.CreatedAt = time.Now()
+	}
+
+	// Keep annotations ordered by turn, after any already on the same move
+	i := sort.Search(len(g.annotations), func(i int) bool { return g.annotations[i].Turn > a.Turn })
+	g.annotations = append(g.annotations, Annotation{})
+	copy(g.annotations[i+1:], g.annotations[i:])
+	g.annotations[i] = a
+	g.touch()
+	return nil
+}
+
+// Annotations returns the annotations on a move in the order they were
+// added, or those on every move in history order if turn is negative
+func (g *Game) Annotations(turn int) []Annotation {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	var result []Annotation
+	for _, a := range g.annotations {
+		if turn < 0 || a.Turn == turn {
+			result = append(result, a)
+		}
+	}
+	return result
+}
+
+// RemoveAnnotation deletes the index-th annotation on a move
+func (g *Game) RemoveAnnotation(turn, index int) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	n := 0
+	for i, a := range g.annotations {
+		if a.Turn != turn {
+			continue
+		}
+		if n == index {
+			g.annotations = append(g.annotations[:i], g.annotations[i+1:]...)
+			g.touch()
+			return nil
+		}
+		n++
+	}
+	return fmt.Errorf("%w: turn %d has no annotation %d", ErrInvalidAnnotation, turn, index)
+}
+
+// gcgNote renders an annotation as the text of a GCG #note line
+func gcgNote(a Annotation) string {
+	var parts []string
+	if a.Author != "" {
+		parts = append(parts, gcgNick(a.Author)+":")
+	}
+	switch a.Kind {
+	case AlternativeAnnotation:
+		parts = append(parts, "Alternative")
+	case EvaluationAnnotation:
+		parts = append(parts, fmt.Sprintf("Evaluation %+.1f", a.Equity))
+	}
+	if alt := a.Alternative; alt != nil {
+		if a.Kind == EvaluationAnnotation {
+			parts = append(parts, "best")
+		}
+		parts = append(parts, fmt.Sprintf("%s %s +%d", alt.Coordinates(), gcgPlay(*alt), alt.Score))
+	}
+	if text := strings.Join(strings.Fields(a.Text), " "); text != "" {
+		parts = append(parts, text)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,157 @@
+package game
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// newAnnotatedGame creates a started game in which p1 has played CAT and p2
+// has passed
+func newAnnotatedGame(t *testing.T) *Game {
+	t.Helper()
+
+	g := newTestGame(t, 2)
+	if err := g.TileBag.stack(rackOf("CATSDOG")); err != nil {
+		t.Fatalf("Failed to stack bag: %v", err)
+	}
+	if err := g.StartGame(); err != nil {
+		t.Fatalf("Failed to start game: %v", err)
+	}
+	move, _ := g.Board.BuildMove("p1", "CAT", mustPos(t, "H8"), Horizontal, nil)
+	if _, err := g.PlayMove(move); err != nil {
+		t.Fatalf("PlayMove failed: %v", err)
+	}
+	if err := g.PassTurn("p2"); err != nil {
+		t.Fatalf("PassTurn failed: %v", err)
+	}
+	return g
+}
+
+// TestAnnotate tests attaching, listing and removing annotations
+func TestAnnotate(t *testing.T) {
+	g := newAnnotatedGame(t)
+	alt, _ := g.Board.BuildMove("p1", "DOGS", mustPos(t, "G7"), Vertical, nil)
+	alt.Score = 7
+
+	for _, a := range []Annotation{
+		{Turn: 1, Kind: CommentAnnotation, Text: "Should have exchanged"},
+		{Turn: 0, Kind: CommentAnnotation, Author: "coach", Text: "Solid opening"},
+		{Turn: 0, Kind: AlternativeAnnotation, Alternative: &alt},
+		{Turn: 0, Kind: EvaluationAnnotation, Equity: 14.5},
+	} {
+		if err := g.Annotate(a); err != nil {
+			t.Fatalf("Annotate failed: %v", err)
+		}
+	}
+
+	all := g.Annotations(-1)
+	if len(all) != 4 || all[0].Text != "Solid opening" || all[3].Turn != 1 {
+		t.Errorf("Annotations should be ordered by turn then insertion, got %+v", all)
+	}
+	if first := g.Annotations(0); len(first) != 3 || first[2].Kind != EvaluationAnnotation {
+		t.Errorf("Should list the three annotations on the opening, got %+v", first)
+	}
+	if all[0].CreatedAt.IsZero() {
+		t.Errorf("Should stamp the annotation time")
+	}
+
+	if err := g.Annotate(Annotation{Turn: 2, Kind: CommentAnnotation, Text: "x"}); !errors.Is(err, ErrNoSuchTurn) {
+		t.Errorf("Should reject a turn beyond the history, got %v", err)
+	}
+	if err := g.Annotate(Annotation{Turn: 0, Kind: CommentAnnotation, Text: "  "}); !errors.Is(err, ErrInvalidAnnotation) {
+		t.Errorf("Should reject an empty comment, got %v", err)
+	}
+	if err := g.Annotate(Annotation{Turn: 0, Kind: AlternativeAnnotation}); !errors.Is(err, ErrInvalidAnnotation) {
+		t.Errorf("Should reject an alternative without a placement, got %v", err)
+	}
+
+	if err := g.RemoveAnnotation(0, 1); err != nil {
+		t.Fatalf("RemoveAnnotation failed: %v", err)
+	}
+	if first := g.Annotations(0); len(first) != 2 || first[1].Kind != EvaluationAnnotation {
+		t.Errorf("Should remove the alternative, got %+v", first)
+	}
+	if err := g.RemoveAnnotation(1, 1); !errors.Is(err, ErrInvalidAnnotation) {
+		t.Errorf("Should reject a missing annotation, got %v", err)
+	}
+}
+
+// TestAnnotationsSurviveUndo tests that undo drops only annotations on undone moves
+func TestAnnotationsSurviveUndo(t *testing.T) {
+	g := newAnnotatedGame(t)
+	g.Annotate(Annotation{Turn: 0, Kind: CommentAnnotation, Text: "opening"})
+	g.Annotate(Annotation{Turn: 1, Kind: CommentAnnotation, Text: "pass"})
+
+	if err := g.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if all := g.Annotations(-1); len(all) != 1 || all[0].Text != "opening" {
+		t.Errorf("Undo should keep annotations on remaining moves, got %+v", all)
+	}
+	if err := g.Redo(); err != nil {
+		t.Fatalf("Redo failed: %v", err)
+	}
+	if all := g.Annotations(-1); len(all) != 2 || all[1].Text != "pass" {
+		t.Errorf("Redo should restore annotations on the redone move, got %+v", all)
+	}
+}
+
+// TestAnnotationsGCG tests that annotations are exported as notes and
+// re-imported as comments
+func TestAnnotationsGCG(t *testing.T) {
+	g := newAnnotatedGame(t)
+	alt, _ := g.Board.BuildMove("p1", "DOGS", mustPos(t, "G7"), Vertical, nil)
+	alt.Score = 7
+	g.Annotate(Annotation{Turn: 0, Kind: CommentAnnotation, Author: "coach", Text: "Solid\nopening"})
+	g.Annotate(Annotation{Turn: 0, Kind: EvaluationAnnotation, Equity: 12.25, Alternative: &alt})
+	g.Annotate(Annotation{Turn: 1, Kind: AlternativeAnnotation, Alternative: &alt, Text: "keeps the S"})
+
+	var buf bytes.Buffer
+	if err := g.ExportGCG(&buf); err != nil {
+		t.Fatalf("ExportGCG failed: %v", err)
+	}
+	out := buf.String()
+	for _, line := range []string{
+		"+10 10\n#note coach: Solid opening\n#note Evaluation +12.2 best G7 DOGS +7\n>p2:",
+		"+0 0\n#note Alternative G7 DOGS +7 keeps the S\n",
+	} {
+		if !strings.Contains(out, line) {
+			t.Errorf("Export should contain %q, got:\n%s", line, out)
+		}
+	}
+
+	imported, err := ImportGCG(strings.NewReader(out))
+	if err != nil {
+		t.Fatalf("ImportGCG failed: %v", err)
+	}
+	notes := imported.Annotations(0)
+	if len(notes) != 2 || notes[0].Kind != CommentAnnotation || notes[1].Text != "Evaluation +12.2 best G7 DOGS +7" {
+		t.Errorf("Notes should import as comments on their move, got %+v", notes)
+	}
+	if len(imported.Annotations(1)) != 1 {
+		t.Errorf("Should import the note on the pass, got %+v", imported.Annotations(-1))
+	}
+}
+
+// TestAnnotationsPersist tests that annotations survive saving and loading
+func TestAnnotationsPersist(t *testing.T) {
+	g := newAnnotatedGame(t)
+	g.Annotate(Annotation{Turn: 1, Kind: EvaluationAnnotation, Equity: -3})
+
+	data, err := g.Serialize()
+	if err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	loaded, err := LoadGame(data)
+	if err != nil {
+		t.Fatalf("LoadGame failed: %v", err)
+	}
+	if notes := loaded.Annotations(1); len(notes) != 1 || notes[0].Equity != -3 {
+		t.Errorf("Loaded game should keep annotations, got %+v", notes)
+	}
+	if notes := g.Clone().Annotations(-1); len(notes) != 1 {
+		t.Errorf("Clone should copy annotations, got %+v", notes)
+	}
+}
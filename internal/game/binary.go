@@ -0,0 +1,249 @@
+package game
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Binary format versions, written as the first byte of each encoding
+const (
+	boardBinaryVersion    = 1
+	positionBinaryVersion = 1
+)
+
+// boardSquares is the number of squares on the board
+const boardSquares = 15 * 15
+
+// ErrInvalidBinary is returned when binary data cannot be decoded
+var ErrInvalidBinary = errors.New("invalid binary encoding")
+
+// MarshalBinary encodes the tiles on the board compactly for storage and
+// networking
+// The encoding holds the variant, a table of the letters on the board, one
+// byte per square indexing that table and a bitmap of the blanks: about 260
+// bytes, against several kilobytes of JSON. The tile set, layout, bingo bonus
+// and rack size are settings of the game and are not included.
+func (b *Board) MarshalBinary() ([]byte, error) {
+	return b.appendBinary(make([]byte, 0, 3+boardSquares+(boardSquares+7)/8+16))
+}
+
+// appendBinary appends the board's binary encoding to buf
+func (b *Board) appendBinary(buf []byte) ([]byte, error) {
+	var letters []rune
+	index := make(map[rune]byte)
+	squares := make([]byte, boardSquares)
+	blanks := make([]byte, (boardSquares+7)/8)
+
+	for row := 0; row < 15; row++ {
+		for col := 0; col < 15; col++ {
+			tile := b.GetTile(Position{Row: row, Col: col})
+			if tile == nil {
+				continue
+			}
+			i, ok := index[tile.Letter]
+			if !ok {
+				if len(letters) == 255 {
+					return nil, fmt.Errorf("%w: more than 255 different letters", ErrInvalidBinary)
+				}
+				letters = append(letters, tile.Letter)
+				i = byte(len(letters))
+				index[tile.Letter] = i
+			}
+			sq := row*15 + col
+			squares[sq] = i
+			if tile.IsBlank {
+				blanks[sq/8] |= 1 << (sq % 8)
+			}
+		}
+	}
+
+	buf = append(buf, boardBinaryVersion, byte(b.Variant), byte(len(letters)))
+	for _, r := range letters {
+		buf = binary.AppendUvarint(buf, uint64(r))
+	}
+	buf = append(buf, squares...)
+	return append(buf, blanks...), nil
+}
+
+// UnmarshalBinary replaces the tiles on the board with those encoded by
+// MarshalBinary
+// A zero Board becomes a new board for the encoded variant. A board already
+// set up must have the encoded variant, and should have the tile set the
+// encoding was made with: letters outside the set are rejected, and tile
+// values come from the set.
+func (b *Board) UnmarshalBinary(data []byte) error {
+	rest, err := b.readBinary(data)
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return fmt.Errorf("%w: %d trailing bytes", ErrInvalidBinary, len(rest))
+	}
+	return nil
+}
+
+// readBinary decodes a board from the start of data, returning the bytes
+// after it
+func (b *Board) readBinary(data []byte) ([]byte, error) {
+	if len(data) < 3 {
+		return nil, fmt.Errorf("%w: board is too short", ErrInvalidBinary)
+	}
+	if data[0] != boardBinaryVersion {
+		return nil, fmt.Errorf("%w: unsupported board version %d", ErrInvalidBinary, data[0])
+	}
+	variant := Variant(data[1])
+	if variant.String() == "UNKNOWN" {
+		return nil, fmt.Errorf("%w: unknown variant %d", ErrInvalidBinary, data[1])
+	}
+	if b.RackSize == 0 {
+		*b = *NewBoardForVariant(variant)
+	} else if b.Variant != variant {
+		return nil, fmt.Errorf("%w: board is %s, encoding is %s", ErrInvalidBinary, b.Variant, variant)
+	}
+
+	letters := make([]rune, int(data[2]))
+	data = data[3:]
+	known := b.tileTracker().distribution
+	for i := range letters {
+		r, n := binary.Uvarint(data)
+		if n <= 0 {
+			return nil, fmt.Errorf("%w: bad letter table", ErrInvalidBinary)
+		}
+		if _, ok := known[rune(r)]; !ok || r == 0 {
+			return nil, fmt.Errorf("%w: %q is not in the tile set", ErrInvalidBinary, rune(r))
+		}
+		letters[i] = rune(r)
+		data = data[n:]
+	}
+
+	blanksLen := (boardSquares + 7) / 8
+	if len(data) < boardSquares+blanksLen {
+		return nil, fmt.Errorf("%w: board is too short", ErrInvalidBinary)
+	}
+	squares, blanks := data[:boardSquares], data[boardSquares:boardSquares+blanksLen]
+
+	b.clearTiles()
+	for sq, i := range squares {
+		if i == 0 {
+			continue
+		}
+		if int(i) > len(letters) {
+			return nil, fmt.Errorf("%w: square %d names letter %d of %d", ErrInvalidBinary, sq, i, len(letters))
+		}
+		tile := Tile{Letter: letters[i-1]}
+		if blanks[sq/8]&(1<<(sq%8)) != 0 {
+			tile.IsBlank = true
+		} else {
+			tile.Points = b.tileValue(tile.Letter)
+		}
+		b.PlaceTile(tile, Position{Row: sq / 15, Col: sq % 15})
+	}
+	return data[boardSquares+blanksLen:], nil
+}
+
+// clearTiles removes every tile from the board
+func (b *Board) clearTiles() {
+	for row := range b.Grid {
+		for col := range b.Grid[row] {
+			b.Grid[row][col].Tile = nil
+			b.Grid[row][col].Occupied = false
+			b.Grid[row][col].Turn, b.Grid[row][col].PlayerID = 0, ""
+		}
+	}
+	b.occupied = [15]uint16{}
+	b.hash = 0
+	b.tiles = nil
+}
+
+// MarshalBinary encodes the position compactly: the board as Board.MarshalBinary
+// does, then the player to move and each player's score and rack
+// Rack letters are written as variable-length integers, with 0 for a blank.
+func (p *GamePosition) MarshalBinary() ([]byte, error) {
+	buf, err := p.Board.appendBinary([]byte{positionBinaryVersion})
+	if err != nil {
+		return nil, err
+	}
+	buf = append(buf, byte(len(p.Racks)))
+	buf = binary.AppendUvarint(buf, uint64(p.Turn))
+	for i, rack := range p.Racks {
+		buf = binary.AppendVarint(buf, int64(p.Scores[i]))
+		buf = append(buf, byte(len(rack)))
+		for _, t := range rack {
+			letter := t.Letter
+			if t.IsBlank {
+				letter = 0
+			}
+			buf = binary.AppendUvarint(buf, uint64(letter))
+		}
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary decodes a position encoded by MarshalBinary
+// The board is decoded as Board.UnmarshalBinary does, so set p.Board first to
+// decode a position played with a tile set.
+func (p *GamePosition) UnmarshalBinary(data []byte) error {
+	if len(data) < 1 || data[0] != positionBinaryVersion {
+		return fmt.Errorf("%w: unsupported position version", ErrInvalidBinary)
+	}
+	if p.Board == nil {
+		p.Board = &Board{}
+	}
+	data, err := p.Board.readBinary(data[1:])
+	if err != nil {
+		return err
+	}
+
+	if len(data) < 1 {
+		return fmt.Errorf("%w: position is too short", ErrInvalidBinary)
+	}
+	players := int(data[0])
+	if players < 1 || players > MaxPlayers {
+		return fmt.Errorf("%w: %d players", ErrInvalidBinary, players)
+	}
+	data = data[1:]
+	turn, n := binary.Uvarint(data)
+	if n <= 0 || turn >= uint64(players) {
+		return fmt.Errorf("%w: bad turn", ErrInvalidBinary)
+	}
+	data = data[n:]
+
+	p.Turn = int(turn)
+	p.Racks = make([][]Tile, players)
+	p.Scores = make([]int, players)
+	known := p.Board.tileTracker().distribution
+	for i := range p.Racks {
+		score, n := binary.Varint(data)
+		if n <= 0 || len(data) < n+1 {
+			return fmt.Errorf("%w: bad score for player %d", ErrInvalidBinary, i+1)
+		}
+		p.Scores[i] = int(score)
+		size := int(data[n])
+		data = data[n+1:]
+		if size > MaxRackSize {
+			return fmt.Errorf("%w: rack of %d tiles", ErrInvalidBinary, size)
+		}
+
+		p.Racks[i] = make([]Tile, 0, MaxRackSize)
+		for j := 0; j < size; j++ {
+			r, n := binary.Uvarint(data)
+			if n <= 0 {
+				return fmt.Errorf("%w: bad rack for player %d", ErrInvalidBinary, i+1)
+			}
+			data = data[n:]
+			if r == 0 {
+				p.Racks[i] = append(p.Racks[i], Tile{IsBlank: true})
+				continue
+			}
+			if _, ok := known[rune(r)]; !ok {
+				return fmt.Errorf("%w: %q is not in the tile set", ErrInvalidBinary, rune(r))
+			}
+			p.Racks[i] = append(p.Racks[i], Tile{Letter: rune(r), Points: p.Board.tileValue(rune(r))})
+		}
+	}
+	if len(data) > 0 {
+		return fmt.Errorf("%w: %d trailing bytes", ErrInvalidBinary, len(data))
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestBoardBinaryRoundTrip tests that tiles and blanks survive encoding
+func TestBoardBinaryRoundTrip(t *testing.T) {
+	board := NewBoard()
+	placeWord(t, board, "CAT", "H8", Horizontal)
+	placeWord(t, board, "OX", "I9", Vertical)
+	board.RemoveTile(mustPos(t, "I9"))
+	board.PlaceTile(Tile{Letter: 'O', IsBlank: true}, mustPos(t, "I9"))
+
+	data, err := board.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+	jsonData, _ := json.Marshal(board)
+	if len(data) > 300 || len(data)*10 > len(jsonData) {
+		t.Errorf("Binary board should be far smaller than JSON, got %d bytes against %d", len(data), len(jsonData))
+	}
+
+	var decoded Board
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	if !reflect.DeepEqual(decoded.GetOccupiedPositions(), board.GetOccupiedPositions()) {
+		t.Errorf("Decoded board should have the same occupied squares")
+	}
+	for _, pos := range board.GetOccupiedPositions() {
+		if got, want := *decoded.GetTile(pos), *board.GetTile(pos); got != want {
+			t.Errorf("Tile at %s should be %+v, got %+v", pos.String(), want, got)
+		}
+	}
+	if decoded.GetPremiumType(mustPos(t, "A1")) != TripleWordScore || decoded.RackSize != MaxRackSize {
+		t.Errorf("A zero board should be set up for the encoded variant")
+	}
+
+	// Decoding into a board that has tiles replaces them
+	other := NewBoard()
+	placeWord(t, other, "ZA", "A1", Horizontal)
+	if err := other.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	if other.HasTileAt(mustPos(t, "A1")) || len(other.GetOccupiedPositions()) != 5 {
+		t.Errorf("Decoding should replace the board's tiles")
+	}
+}
+
+// TestBoardBinaryTileSet tests tile set letters and the variant check
+func TestBoardBinaryTileSet(t *testing.T) {
+	ts, err := LoadTileSet("spanish")
+	if err != nil {
+		t.Fatalf("LoadTileSet failed: %v", err)
+	}
+	ch, _ := ts.Rune("CH")
+	board := NewBoard()
+	board.TileSet = ts
+	board.PlaceTile(Tile{Letter: ch, Points: ts.Value(ch)}, mustPos(t, "H8"))
+	board.PlaceTile(Tile{Letter: 'Ñ', Points: ts.Value('Ñ')}, mustPos(t, "I8"))
+	data, _ := board.MarshalBinary()
+
+	if err := new(Board).UnmarshalBinary(data); !errors.Is(err, ErrInvalidBinary) {
+		t.Errorf("Letters outside the standard set should be rejected, got %v", err)
+	}
+	spanish := NewBoard()
+	spanish.TileSet = ts
+	if err := spanish.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	if tile := spanish.GetTile(mustPos(t, "H8")); tile == nil || tile.Letter != ch || tile.Points != 5 {
+		t.Errorf("H8 should hold CH worth 5, got %+v", tile)
+	}
+
+	if err := NewBoardForVariant(WordsWithFriends).UnmarshalBinary(data); !errors.Is(err, ErrInvalidBinary) {
+		t.Errorf("Should reject an encoding of another variant, got %v", err)
+	}
+}
+
+// TestBoardBinaryErrors tests that damaged encodings are rejected
+func TestBoardBinaryErrors(t *testing.T) {
+	board := NewBoard()
+	placeWord(t, board, "CAT", "H8", Horizontal)
+	data, _ := board.MarshalBinary()
+
+	damaged := map[string][]byte{
+		"empty":         nil,
+		"version":       append([]byte{9}, data[1:]...),
+		"variant":       append([]byte{data[0], 7}, data[2:]...),
+		"truncated":     data[:len(data)-1],
+		"trailing":      append(append([]byte(nil), data...), 0),
+		"letter index":  func() []byte { d := append([]byte(nil), data...); d[len(d)-30] = 9; return d }(),
+		"unknown rune":  append([]byte{data[0], data[1], 1, '*'}, data[4:]...),
+		"letter table":  {data[0], data[1], 3, 'C'},
+		"no letter set": append([]byte{data[0], data[1], 1, 0}, data[4:]...),
+	}
+	for name, d := range damaged {
+		var b Board
+		if err := b.UnmarshalBinary(d); !errors.Is(err, ErrInvalidBinary) {
+			t.Errorf("%s: should fail with ErrInvalidBinary, got %v", name, err)
+		}
+	}
+}
+
+// TestPositionBinaryRoundTrip tests encoding a position with racks and scores
+func TestPositionBinaryRoundTrip(t *testing.T) {
+	pos, err := ParsePosition("15/15/15/15/15/15/15/7CaT5/15/15/15/15/15/15/15 AEINRST/DGO?/-/Q 8/-3/0/12 1")
+	if err != nil {
+		t.Fatalf("ParsePosition failed: %v", err)
+	}
+	data, err := pos.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+
+	var decoded GamePosition
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	if got, want := decoded.Encode(), pos.Encode(); got != want {
+		t.Errorf("Decoded position should be\n%s\ngot\n%s", want, got)
+	}
+	if !reflect.DeepEqual(decoded.Racks, pos.Racks) {
+		t.Errorf("Racks should round-trip, got %v", decoded.Racks)
+	}
+
+	for name, d := range map[string][]byte{
+		"version":   append([]byte{9}, data[1:]...),
+		"truncated": data[:len(data)-1],
+		"trailing":  append(append([]byte(nil), data...), 0),
+	} {
+		var p GamePosition
+		if err := p.UnmarshalBinary(d); !errors.Is(err, ErrInvalidBinary) {
+			t.Errorf("%s: should fail with ErrInvalidBinary, got %v", name, err)
+		}
+	}
+}
@@ -1,8 +1,12 @@
 package game
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math/bits"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -35,6 +39,13 @@ func (pt PremiumType) String() string {
 	}
 }
 
+// Errors returned by board operations
+var (
+	ErrInvalidPosition  = errors.New("invalid position")
+	ErrPositionOccupied = errors.New("position is already occupied")
+	ErrNoTileAtPosition = errors.New("no tile at position")
+)
+
 // Position represents a coordinate on the board
 type Position struct {
 	Row int `json:"row"` // 0-based row (0-14)
@@ -47,9 +58,7 @@ func (p Position) String() string {
 		return "INVALID"
 	}
 	// Convert to 1-based and use letter notation (A-O for columns, 1-15 for rows)
-	col := string(rune('A' + p.Col))
-	row := p.Row + 1
-	return fmt.Sprintf("%s%d", col, row)
+	return string(rune('A'+p.Col)) + strconv.Itoa(p.Row+1)
 }
 
 // IsValid checks if the position is within the board boundaries
@@ -61,13 +70,13 @@ func (p Position) IsValid() bool {
 func NewPositionFromString(s string) (Position, error) {
 	s = strings.ToUpper(strings.TrimSpace(s))
 	if len(s) < 2 || len(s) > 3 {
-		return Position{}, fmt.Errorf("invalid position format: %s", s)
+		return Position{}, fmt.Errorf("%w: %q", ErrInvalidPosition, s)
 	}
 
 	// Parse column (A-O)
 	col := int(s[0] - 'A')
 	if col < 0 || col > 14 {
-		return Position{}, fmt.Errorf("invalid column: %c", s[0])
+		return Position{}, fmt.Errorf("%w: column %c", ErrInvalidPosition, s[0])
 	}
 
 	// Parse row (1-15)
@@ -77,13 +86,13 @@ func NewPositionFromString(s string) (Position, error) {
 	} else {
 		// Handle two-digit rows (10-15)
 		if s[1] != '1' {
-			return Position{}, fmt.Errorf("invalid row: %s", s[1:])
+			return Position{}, fmt.Errorf("%w: row %s", ErrInvalidPosition, s[1:])
 		}
 		row = 10 + int(s[2]-'0')
 	}
 
 	if row < 1 || row > 15 {
-		return Position{}, fmt.Errorf("invalid row: %d", row)
+		return Position{}, fmt.Errorf("%w: row %d", ErrInvalidPosition, row)
 	}
 
 	return Position{Row: row - 1, Col: col}, nil
@@ -94,6 +103,9 @@ type Square struct {
 	Tile     *Tile       `json:"tile"`     // Tile placed on this square (nil if empty)
 	Premium  PremiumType `json:"premium"`  // Premium type for this square
 	Occupied bool        `json:"occupied"` // True if a tile is placed here
+
+	Turn     int    `json:"turn,omitempty"`      // Index in the game history of the move that placed the tile
+	PlayerID string `json:"player_id,omitempty"` // Player who placed the tile (empty if unknown, as on boards set up from a position)
 }
 
 // IsEmpty returns true if the square has no tile
@@ -103,14 +115,58 @@ func (s *Square) IsEmpty() bool {
 
 // Board represents the 15x15 Scrabble game board
 type Board struct {
-	Grid   [15][15]Square `json:"grid"`   // 15x15 grid of squares
-	Center Position       `json:"center"` // Center position (H8)
+	Grid    [15][15]Square `json:"grid"`               // 15x15 grid of squares
+	Center  Position       `json:"center"`             // Center position (H8)
+	Variant Variant        `json:"variant"`            // Rule set determining the layout, tile values and bingo bonus
+	TileSet *TileSet       `json:"tile_set,omitempty"` // Language tile set overriding the variant's tiles
+
+	CustomLayout *Layout `json:"custom_layout,omitempty"` // Premium layout overriding the variant's
+
+	BingoBonus  int          `json:"bingo_bonus"`           // Points for playing a full rack
+	RackSize    int          `json:"rack_size"`             // Tiles in a full rack
+	Multipliers *Multipliers `json:"multipliers,omitempty"` // Premium square multipliers (nil for the standard ones)
+
+	tiles    []Tile     // Backing array for the tiles of a cloned board
+	occupied [15]uint16 // Bit c of row r is set when the square at row r, column c holds a tile
+	hash     uint64     // Zobrist hash of the tiles, updated as they are placed and removed
+}
+
+// rowMask has a bit set for each of the 15 columns
+const rowMask = 1<<15 - 1
+
+// UnmarshalJSON decodes a board and rebuilds its occupancy masks and hash
+// Boards saved before the bingo bonus and rack size were recorded get the
+// variant's standard values.
+func (b *Board) UnmarshalJSON(data []byte) error {
+	type plain Board
+	b.BingoBonus, b.RackSize = -1, 0
+	if err := json.Unmarshal(data, (*plain)(b)); err != nil {
+		return err
+	}
+	if b.BingoBonus < 0 {
+		b.BingoBonus = b.Variant.BingoBonus()
+	}
+	if b.RackSize == 0 {
+		b.RackSize = MaxRackSize
+	}
+	b.occupied = [15]uint16{}
+	for row := 0; row < 15; row++ {
+		for col := 0; col < 15; col++ {
+			if b.Grid[row][col].Occupied {
+				b.occupied[row] |= 1 << col
+			}
+		}
+	}
+	b.rehash()
+	return nil
 }
 
 // NewBoard creates a new Scrabble board with premium squares initialized
 func NewBoard() *Board {
 	board := &Board{
-		Center: Position{Row: 7, Col: 7}, // H8 (0-based: row 7, col 7)
+		Center:     Position{Row: 7, Col: 7}, // H8 (0-based: row 7, col 7)
+		BingoBonus: BingoBonus,
+		RackSize:   MaxRackSize,
 	}
 
 	// Initialize all squares as normal
@@ -171,17 +227,19 @@ func (b *Board) IsValidPosition(pos Position) bool {
 // PlaceTile places a tile at the specified position
 func (b *Board) PlaceTile(tile Tile, pos Position) error {
 	if !b.IsValidPosition(pos) {
-		return fmt.Errorf("invalid position: %s", pos.String())
+		return fmt.Errorf("%w: %s", ErrInvalidPosition, pos.String())
 	}
 
 	square := &b.Grid[pos.Row][pos.Col]
 	if square.Occupied {
-		return fmt.Errorf("position %s is already occupied", pos.String())
+		return fmt.Errorf("%w: %s", ErrPositionOccupied, pos.String())
 	}
 
 	// Place the tile
 	square.Tile = &tile
 	square.Occupied = true
+	b.occupied[pos.Row] |= 1 << pos.Col
+	b.hash ^= squareKey(pos, tile)
 
 	return nil
 }
@@ -212,17 +270,20 @@ func (b *Board) GetSquare(pos Position) *Square {
 // RemoveTile removes a tile from the specified position
 func (b *Board) RemoveTile(pos Position) (*Tile, error) {
 	if !b.IsValidPosition(pos) {
-		return nil, fmt.Errorf("invalid position: %s", pos.String())
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPosition, pos.String())
 	}
 
 	square := &b.Grid[pos.Row][pos.Col]
 	if !square.Occupied {
-		return nil, fmt.Errorf("no tile at position %s", pos.String())
+		return nil, fmt.Errorf("%w: %s", ErrNoTileAtPosition, pos.String())
 	}
 
 	tile := square.Tile
 	square.Tile = nil
 	square.Occupied = false
+	square.Turn, square.PlayerID = 0, ""
+	b.occupied[pos.Row] &^= 1 << pos.Col
+	b.hash ^= squareKey(pos, *tile)
 
 	return tile, nil
 }
@@ -282,48 +343,23 @@ func (b *Board) ValidateBoard() error {
 		return errors.New("center position must be H8 (row 7, col 7)")
 	}
 
-	// Verify premium square counts
-	premiumCounts := make(map[PremiumType]int)
+	// Verify every premium square against the configured layout
+	expected := b.expectedLayout()
 	for row := 0; row < 15; row++ {
 		for col := 0; col < 15; col++ {
-			premiumCounts[b.Grid[row][col].Premium]++
-		}
-	}
-
-	// Expected premium square counts according to official Scrabble rules
-	expectedCounts := map[PremiumType]int{
-		Normal:            164, // 225 total - 61 premium squares
-		DoubleLetterScore: 24,
-		TripleLetterScore: 12,
-		DoubleWordScore:   17, // Including center star
-		TripleWordScore:   8,
-	}
-
-	for premiumType, expected := range expectedCounts {
-		if actual := premiumCounts[premiumType]; actual != expected {
-			return fmt.Errorf("premium square count mismatch for %s: expected %d, got %d",
-				premiumType.String(), expected, actual)
+			if want, got := expected[row][col], b.Grid[row][col].Premium; got != want {
+				return fmt.Errorf("premium square mismatch at %s: expected %s, got %s",
+					Position{Row: row, Col: col}, want, got)
+			}
 		}
 	}
 
-	// Verify center square is Double Word Score
-	if b.Grid[b.Center.Row][b.Center.Col].Premium != DoubleWordScore {
-		return errors.New("center square must be a Double Word Score")
-	}
-
 	return nil
 }
 
 // IsFirstMove checks if this is the first move of the game (board is empty)
 func (b *Board) IsFirstMove() bool {
-	for row := 0; row < 15; row++ {
-		for col := 0; col < 15; col++ {
-			if b.Grid[row][col].Occupied {
-				return false
-			}
-		}
-	}
-	return true
+	return b.occupied == [15]uint16{}
 }
 
 // HasTileAt returns true if there is a tile at the specified position
@@ -335,19 +371,69 @@ func (b *Board) HasTileAt(pos Position) bool {
 	return b.Grid[pos.Row][pos.Col].Occupied
 }
 
-// GetOccupiedPositions returns all positions that have tiles
+// GetOccupiedPositions returns all positions that have tiles, row by row
 func (b *Board) GetOccupiedPositions() []Position {
-	positions := []Position{}
+	n := 0
+	for _, mask := range b.occupied {
+		n += bits.OnesCount16(mask)
+	}
+
+	positions := make([]Position, 0, n)
+	for row, mask := range b.occupied {
+		for ; mask != 0; mask &= mask - 1 {
+			positions = append(positions, Position{Row: row, Col: bits.TrailingZeros16(mask)})
+		}
+	}
+	return positions
+}
 
+// hasNeighbor returns true if a tile is directly above, below, left or right of pos
+func (b *Board) hasNeighbor(pos Position) bool {
+	return pos.IsValid() && b.touching(pos.Row)&(1<<pos.Col) != 0
+}
+
+// touching returns the squares of a row next to at least one tile, whether
+// or not they hold a tile themselves
+func (b *Board) touching(row int) uint16 {
+	occ := b.occupied[row]
+	mask := occ<<1 | occ>>1
+	if row > 0 {
+		mask |= b.occupied[row-1]
+	}
+	if row < 14 {
+		mask |= b.occupied[row+1]
+	}
+	return mask & rowMask
+}
+
+// anchorMask returns the empty squares of a row next to at least one tile,
+// where any move after the first must be built
+func (b *Board) anchorMask(row int) uint16 {
+	return b.touching(row) &^ b.occupied[row]
+}
+
+// Hotspots returns the empty premium squares a play can reach now: those
+// next to a tile, or the center on an empty board
+// The most valuable come first, word premiums before letter premiums, and
+// squares of equal value are in row order.
+func (b *Board) Hotspots() []Position {
+	if b.IsFirstMove() {
+		return []Position{b.Center}
+	}
+
+	var spots []Position
 	for row := 0; row < 15; row++ {
-		for col := 0; col < 15; col++ {
-			if b.Grid[row][col].Occupied {
-				positions = append(positions, Position{Row: row, Col: col})
+		for mask := b.anchorMask(row); mask != 0; mask &= mask - 1 {
+			pos := Position{Row: row, Col: bits.TrailingZeros16(mask)}
+			if b.GetPremiumType(pos) != Normal {
+				spots = append(spots, pos)
 			}
 		}
 	}
-
-	return positions
+	sort.SliceStable(spots, func(i, j int) bool {
+		return b.GetPremiumType(spots[i]) > b.GetPremiumType(spots[j])
+	})
+	return spots
 }
 
 // CountPremiumSquares returns the count of each premium square type
@@ -433,3 +519,64 @@ func (b *Board) String() string {
 
 	return sb.String()
 }
+
+// ANSI escape sequences used by RenderANSI
+const (
+	ansiReset     = "\x1b[0m"
+	ansiTile      = "\x1b[30;48;5;230m"   // Black on cream
+	ansiHighlight = "\x1b[1;30;48;5;220m" // Bold black on yellow
+	ansiEmpty     = "\x1b[37;48;5;22m"    // Grey on green
+)
+
+// ansiPremium colors empty premium squares
+var ansiPremium = map[PremiumType]string{
+	TripleWordScore:   "\x1b[97;41m",       // White on red
+	DoubleWordScore:   "\x1b[30;48;5;218m", // Black on pink
+	TripleLetterScore: "\x1b[97;44m",       // White on blue
+	DoubleLetterScore: "\x1b[30;48;5;117m", // Black on light blue
+}
+
+// RenderANSI returns the board drawn with ANSI colors for terminals
+// Premium squares are colored (red triple word, pink double word, blue
+// triple letter, light blue double letter), blanks are shown as lower-case
+// letters and tiles at the highlight positions, such as the last move's, stand out.
+func (b *Board) RenderANSI(highlight ...Position) string {
+	lit := make(map[Position]bool, len(highlight))
+	for _, pos := range highlight {
+		lit[pos] = true
+	}
+
+	var sb strings.Builder
+	header := "   "
+	for col := 0; col < 15; col++ {
+		header += fmt.Sprintf(" %c ", 'A'+col)
+	}
+	sb.WriteString(header + "\n")
+
+	for row := 0; row < 15; row++ {
+		sb.WriteString(fmt.Sprintf("%2d ", row+1))
+		for col := 0; col < 15; col++ {
+			square := &b.Grid[row][col]
+			switch {
+			case square.Occupied && square.Tile != nil:
+				letter := string(square.Tile.Letter)
+				if square.Tile.IsBlank {
+					letter = strings.ToLower(letter)
+				}
+				color := ansiTile
+				if lit[Position{Row: row, Col: col}] {
+					color = ansiHighlight
+				}
+				sb.WriteString(color + " " + letter + " " + ansiReset)
+			case ansiPremium[square.Premium] != "":
+				sb.WriteString(ansiPremium[square.Premium] + "   " + ansiReset)
+			default:
+				sb.WriteString(ansiEmpty + " . " + ansiReset)
+			}
+		}
+		sb.WriteString(fmt.Sprintf(" %d\n", row+1))
+	}
+
+	sb.WriteString(header + "\n")
+	return sb.String()
+}
@@ -1,6 +1,8 @@
 package game
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 )
 
@@ -637,6 +639,63 @@ func TestOccupiedPositions(t *testing.T) {
 	}
 }
 
+// TestOccupancyMasks tests anchor and adjacency queries and masks surviving JSON
+func TestOccupancyMasks(t *testing.T) {
+	board := NewBoard()
+	placeWord(t, board, "CAT", "G8", Horizontal)
+
+	anchors := []string{}
+	for row := 0; row < 15; row++ {
+		for col := 0; col < 15; col++ {
+			if board.anchorMask(row)&(1<<col) != 0 {
+				anchors = append(anchors, Position{Row: row, Col: col}.String())
+			}
+		}
+	}
+	if strings.Join(anchors, " ") != "G7 H7 I7 F8 J8 G9 H9 I9" {
+		t.Errorf("Unexpected anchors: %v", anchors)
+	}
+	if !board.hasNeighbor(mustPos(t, "J8")) || !board.hasNeighbor(mustPos(t, "H8")) || board.hasNeighbor(mustPos(t, "K8")) {
+		t.Errorf("Adjacency should follow the placed tiles")
+	}
+
+	data, err := json.Marshal(board)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var decoded Board
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if decoded.IsFirstMove() || len(decoded.GetOccupiedPositions()) != 3 || decoded.anchorMask(6) != board.anchorMask(6) {
+		t.Errorf("Decoded board should rebuild its occupancy")
+	}
+
+	for _, pos := range []string{"G8", "H8", "I8"} {
+		board.RemoveTile(mustPos(t, pos))
+	}
+	if !board.IsFirstMove() || board.hasNeighbor(mustPos(t, "J8")) {
+		t.Errorf("Board should be empty after removing every tile")
+	}
+}
+
+// TestHotspots tests finding the open premium squares, most valuable first
+func TestHotspots(t *testing.T) {
+	board := NewBoard()
+	if spots := board.Hotspots(); len(spots) != 1 || spots[0] != board.Center {
+		t.Errorf("An empty board should offer only the center, got %v", spots)
+	}
+
+	placeWord(t, board, "CAT", "E6", Horizontal)
+	var got []string
+	for _, pos := range board.Hotspots() {
+		got = append(got, pos.String())
+	}
+	if strings.Join(got, " ") != "E5 G7" {
+		t.Errorf("Expected the double word at E5 before the double letter at G7, got %v", got)
+	}
+}
+
 // TestPremiumTypeString tests premium type string representations
 func TestPremiumTypeString(t *testing.T) {
 	testCases := []struct {
@@ -686,3 +745,42 @@ func TestBoardString(t *testing.T) {
 		t.Errorf("Board string with tile should not be empty")
 	}
 }
+
+// TestRenderANSI tests colored board rendering
+func TestRenderANSI(t *testing.T) {
+	b := NewBoard()
+	b.PlaceTile(Tile{Letter: 'C', Points: 3}, mustPos(t, "H8"))
+	b.PlaceTile(Tile{Letter: 'A', Points: 0, IsBlank: true}, mustPos(t, "I8"))
+	b.PlaceTile(Tile{Letter: 'T', Points: 1}, mustPos(t, "J8"))
+
+	out := b.RenderANSI()
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if len(lines) != 17 {
+		t.Fatalf("Should render a header, 15 rows and a footer, got %d lines", len(lines))
+	}
+
+	row1 := lines[1]
+	if !strings.HasPrefix(row1, " 1 "+ansiPremium[TripleWordScore]+"   "+ansiReset) {
+		t.Errorf("A1 should be drawn as a red triple word square, got %q", row1)
+	}
+	if !strings.Contains(row1, ansiPremium[DoubleLetterScore]) {
+		t.Errorf("Row 1 should contain a light blue double letter square")
+	}
+	if !strings.Contains(lines[2], ansiPremium[DoubleWordScore]) || !strings.Contains(lines[2], ansiPremium[TripleLetterScore]) {
+		t.Errorf("Row 2 should contain pink and blue premium squares")
+	}
+
+	row8 := lines[8]
+	if !strings.Contains(row8, ansiTile+" C "+ansiReset+ansiTile+" a "+ansiReset) {
+		t.Errorf("Blanks should render in lower case, got %q", row8)
+	}
+	if strings.Contains(row8, ansiHighlight) {
+		t.Errorf("Nothing should be highlighted by default")
+	}
+
+	move := Move{Tiles: []PlacedTile{{Position: mustPos(t, "J8")}}}
+	lit := b.RenderANSI(move.Positions()...)
+	if !strings.Contains(lit, ansiHighlight+" T "+ansiReset) || strings.Contains(lit, ansiHighlight+" C ") {
+		t.Errorf("Only the highlighted tile should stand out")
+	}
+}
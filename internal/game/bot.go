@@ -0,0 +1,145 @@
+package game
+
+import (
+	"math/rand"
+)
+
+// BotStrategy selects how a computer opponent chooses among generated moves
+type BotStrategy int
+
+const (
+	Greedy     BotStrategy = iota // Always plays the highest scoring move
+	TopNRandom                    // Plays a random move from the N highest scoring
+	Equity                        // Plays the move with the best score plus rack leave value
+	Adaptive                      // Plays weaker or stronger moves to keep the spread near a target
+)
+
+// String returns a string representation of the bot strategy
+func (s BotStrategy) String() string {
+	switch s {
+	case Greedy:
+		return "GREEDY"
+	case TopNRandom:
+		return "TOP_N_RANDOM"
+	case Equity:
+		return "EQUITY"
+	case Adaptive:
+		return "ADAPTIVE"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// DefaultBotTopN is the number of candidate moves a TopNRandom bot picks from
+const DefaultBotTopN = 5
+
+// Bot is a computer opponent that plays for a seated player
+type Bot struct {
+	PlayerID  string            `json:"player_id"`
+	Strategy  BotStrategy       `json:"strategy"`
+	TopN      int               `json:"top_n"`         // Candidate pool size for TopNRandom
+	Target    int               `json:"target_spread"` // Margin an Adaptive bot steers toward; negative lets the opponents lead
+	Band      int               `json:"band"`          // Distance from Target within which an Adaptive bot picks at random
+	Leave     *LeaveEvaluator   `json:"-"`             // Leave scoring for the Equity strategy
+	Book      *OpeningBook      `json:"-"`             // First plays an Equity bot takes from the book (nil works them out)
+	Defense   *DefenseEvaluator `json:"-"`             // Board openness penalty for the Equity strategy (nil ignores the board)
+	Bluff     float64           `json:"bluff_rate"`    // Chance each turn of playing a phony from Phonies, for challenge training
+	Phonies   *MoveGenerator    `json:"-"`             // Plays a bluffing bot may choose from; see NewPhonyGenerator
+	generator *MoveGenerator
+	rng       *rand.Rand // Source of TopNRandom, Adaptive and bluffing choices (nil uses the global source)
+}
+
+// NewBot creates a bot that plays for playerID using the given move generator
+func NewBot(playerID string, strategy BotStrategy, generator *MoveGenerator) *Bot {
+	return &Bot{
+		PlayerID:  playerID,
+		Strategy:  strategy,
+		TopN:      DefaultBotTopN,
+		Band:      DefaultAdaptiveBand,
+		Leave:     NewLeaveEvaluator(),
+		Defense:   NewDefenseEvaluator(),
+		generator: generator,
+	}
+}
+
+// NewBotWithSeed creates a bot whose random choices are determined by seed
+// Bots created with the same seed choose the same moves in the same positions.
+func NewBotWithSeed(playerID string, strategy BotStrategy, generator *MoveGenerator, seed int64) *Bot {
+	b := NewBot(playerID, strategy, generator)
+	b.rng = rand.New(rand.NewSource(seed))
+	return b
+}
+
+// ChooseMove picks a move for the rack according to the bot's strategy
+// Returns false if no legal move exists. An Adaptive bot cannot see the scores
+// here and plays like Greedy; attached to a game, it follows the play.
+func (b *Bot) ChooseMove(board *Board, rack []Tile) (Move, bool) {
+	return b.choose(board, rack, nil)
+}
+
+// choose picks a move for the rack, steering an Adaptive bot by the pace of
+// the game (nil when unknown)
+// A bluffing bot first decides whether to play a phony this turn; only games
+// under the double challenge rule accept one.
+func (b *Bot) choose(board *Board, rack []Tile, p *pace) (Move, bool) {
+	if b.Phonies != nil && b.Bluff > 0 && b.float() < b.Bluff {
+		if move, ok := b.bluff(board, rack); ok {
+			move.PlayerID = b.PlayerID
+			return move, true
+		}
+	}
+
+	if b.Book != nil && b.Strategy == Equity {
+		if move, ok := b.Book.Lookup(board, rack); ok {
+			move.PlayerID = b.PlayerID
+			return move, true
+		}
+	}
+
+	moves := b.generator.GenerateMoves(board, rack)
+	if len(moves) == 0 {
+		return Move{}, false
+	}
+
+	var move Move
+	switch b.Strategy {
+	case TopNRandom:
+		n := b.TopN
+		if n <= 0 || n > len(moves) {
+			n = len(moves)
+		}
+		move = moves[b.intn(n)]
+	case Equity:
+		ranked := b.Leave.Rank(rack, moves)
+		if b.Defense != nil {
+			ranked = b.Defense.Rerank(board, ranked)
+		}
+		if len(ranked) == 0 {
+			return Move{}, false
+		}
+		move = ranked[0].Move
+	case Adaptive:
+		move = b.adapt(moves, p)
+	default:
+		move = moves[0]
+	}
+
+	move.PlayerID = b.PlayerID
+	return move, true
+}
+
+// intn returns a random choice in [0, n) from the bot's source
+func (b *Bot) intn(n int) int {
+	if b.rng != nil {
+		return b.rng.Intn(n)
+	}
+	return rand.Intn(n)
+}
+
+// float returns a random number in [0, 1) from the bot's source
+func (b *Bot) float() float64 {
+	if b.rng != nil {
+		return b.rng.Float64()
+	}
+	return rand.Float64()
+}
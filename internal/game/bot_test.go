@@ -0,0 +1,105 @@
+package game
+
+import (
+	"testing"
+)
+
+// TestBotStrategies tests move selection for each strategy
+func TestBotStrategies(t *testing.T) {
+	mg := NewMoveGenerator(testWords)
+	board := NewBoard()
+	rack := rackOf("CATSDOG")
+	best := mg.GenerateMoves(board, rack)[0]
+
+	greedy := NewBot("p2", Greedy, mg)
+	move, ok := greedy.ChooseMove(board, rack)
+	if !ok || move.Score != best.Score {
+		t.Errorf("Greedy bot should play a top scoring move (%d), got %d", best.Score, move.Score)
+	}
+	if move.PlayerID != "p2" {
+		t.Errorf("Bot move should carry the bot's player ID, got %s", move.PlayerID)
+	}
+
+	topN := NewBot("p2", TopNRandom, mg)
+	topN.TopN = 3
+	moves := mg.GenerateMoves(board, rack)
+	for i := 0; i < 20; i++ {
+		move, _ := topN.ChooseMove(board, rack)
+		if move.Score < moves[2].Score {
+			t.Errorf("TopNRandom bot played a move outside the top 3: %d", move.Score)
+		}
+	}
+
+	equity := NewBot("p2", Equity, mg)
+	if _, ok := equity.ChooseMove(board, rack); !ok {
+		t.Errorf("Equity bot should find a move")
+	}
+
+	if _, ok := greedy.ChooseMove(board, rackOf("XYZ")); ok {
+		t.Errorf("Bot should report no move for an unplayable rack")
+	}
+}
+
+// TestBotWithSeed tests that seeded bots repeat their random choices
+func TestBotWithSeed(t *testing.T) {
+	mg := NewMoveGenerator(testWords)
+	board := NewBoard()
+	rack := rackOf("CATSDOG")
+
+	a := NewBotWithSeed("p1", TopNRandom, mg, 7)
+	b := NewBotWithSeed("p1", TopNRandom, mg, 7)
+	for i := 0; i < 10; i++ {
+		ma, _ := a.ChooseMove(board, rack)
+		mb, _ := b.ChooseMove(board, rack)
+		if moveKey(ma.Tiles) != moveKey(mb.Tiles) {
+			t.Fatalf("Bots with the same seed should choose the same moves, got %s and %s", ma.Word, mb.Word)
+		}
+	}
+}
+
+// TestBotGameLoop tests a human playing against a bot through the game
+func TestBotGameLoop(t *testing.T) {
+	mg := NewMoveGenerator(testWords)
+	g := newTestGame(t, 2)
+	g.StartGame()
+
+	if err := g.AttachBot(NewBot("p2", Greedy, mg)); err != nil {
+		t.Fatalf("Should be able to attach a bot: %v", err)
+	}
+	if err := g.AttachBot(NewBot("nobody", Greedy, mg)); err == nil {
+		t.Errorf("Attaching a bot to an unknown player should fail")
+	}
+	if !g.IsBot("p2") || g.IsBot("p1") {
+		t.Errorf("Only p2 should be a bot")
+	}
+
+	// Human's turn: bots do nothing
+	if played, err := g.PlayBotTurns(); err != nil || len(played) != 0 {
+		t.Errorf("Bots should not play on a human's turn: %v, %v", played, err)
+	}
+
+	g.Players[0].Rack = rackOf("CATXYZV")
+	human, _ := g.Board.BuildMove("p1", "CAT", mustPos(t, "G8"), Horizontal, nil)
+	if _, err := g.PlayMove(human); err != nil {
+		t.Fatalf("Human move failed: %v", err)
+	}
+
+	g.Players[1].Rack = rackOf("SDOGVVW")
+	played, err := g.PlayBotTurns()
+	if err != nil {
+		t.Fatalf("Bot turn failed: %v", err)
+	}
+	if len(played) != 1 || played[0].PlayerID != "p2" {
+		t.Errorf("Bot should play exactly one move, got %v", played)
+	}
+	if g.CurrentPlayer().ID != "p1" {
+		t.Errorf("Turn should return to the human, got %s", g.CurrentPlayer().ID)
+	}
+}
+
+// TestBotStrategyString tests BotStrategy string conversion
+func TestBotStrategyString(t *testing.T) {
+	if Greedy.String() != "GREEDY" || TopNRandom.String() != "TOP_N_RANDOM" || Equity.String() != "EQUITY" || Adaptive.String() != "ADAPTIVE" || BotStrategy(9).String() != "UNKNOWN" {
+		t.Errorf("Unexpected bot strategy strings")
+	}
+}
@@ -0,0 +1,109 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ScoreBreakdown explains how a move's score was reached, so it can be shown
+// long after the board has changed
+type ScoreBreakdown struct {
+	Words      []WordScore `json:"words"`                 // Main word first, then cross words
+	BingoBonus int         `json:"bingo_bonus,omitempty"` // Bonus for playing a full rack
+	Total      int         `json:"total"`
+}
+
+// WordScore is the score of one word formed by a move
+type WordScore struct {
+	Word           string      `json:"word"`
+	Tiles          []TileScore `json:"tiles"`           // One per letter of Word
+	WordMultiplier int         `json:"word_multiplier"` // Product of the word premiums the move covered
+	Score          int         `json:"score"`
+}
+
+// TileScore is one letter's contribution to a word
+// Premiums only count for tiles the move placed; tiles already on the board
+// score their face value.
+type TileScore struct {
+	Position         Position    `json:"position"`
+	Letter           rune        `json:"letter"`
+	Points           int         `json:"points"`  // Face value, 0 for a blank
+	Placed           bool        `json:"placed"`  // True if the move placed the tile
+	Premium          PremiumType `json:"premium"` // Premium square applied, Normal if none
+	LetterMultiplier int         `json:"letter_multiplier"`
+	Score            int         `json:"score"` // Points times LetterMultiplier
+}
+
+// ExplainScore breaks down the score of a move on the current board
+// The move is assumed to have passed ValidatePlacement; the total matches
+// ScoreMove.
+func (b *Board) ExplainScore(move Move) ScoreBreakdown {
+	placed := make(map[Position]Tile, len(move.Tiles))
+	for _, pt := range move.Tiles {
+		placed[pt.Position] = pt.Tile
+	}
+	mul := b.multipliers()
+
+	var sb ScoreBreakdown
+	for _, w := range b.FormedWords(move) {
+		ws := WordScore{Word: w.Word, WordMultiplier: 1}
+		sum := 0
+		for _, pos := range w.Positions {
+			ts := TileScore{Position: pos, LetterMultiplier: 1}
+			if tile, isNew := placed[pos]; isNew {
+				ts.Letter, ts.Points, ts.Placed = tile.Letter, tile.Points, true
+				ts.Premium = b.GetPremiumType(pos)
+				ts.LetterMultiplier = mul.Letter(ts.Premium)
+				ws.WordMultiplier *= mul.Word(ts.Premium)
+			} else if tile := b.GetTile(pos); tile != nil {
+				ts.Letter, ts.Points = tile.Letter, tile.Points
+			}
+			ts.Score = ts.Points * ts.LetterMultiplier
+			sum += ts.Score
+			ws.Tiles = append(ws.Tiles, ts)
+		}
+		ws.Score = sum * ws.WordMultiplier
+		sb.Words = append(sb.Words, ws)
+		sb.Total += ws.Score
+	}
+
+	if len(move.Tiles) > 0 && len(move.Tiles) == b.RackSize {
+		sb.BingoBonus = b.BingoBonus
+		sb.Total += b.BingoBonus
+	}
+	return sb
+}
+
+// String writes the breakdown a line per word, for example
+//
+//	QI: Q 10x3 + I 1 = 31
+//	QAT: Q 10x3 + A 1 + T 1 = 32 x2 = 64
+//	Bingo bonus: 50
+//	Total: 145
+func (sb ScoreBreakdown) String() string {
+	var b strings.Builder
+	for _, w := range sb.Words {
+		fmt.Fprintf(&b, "%s: ", w.Word)
+		sum := 0
+		for i, t := range w.Tiles {
+			if i > 0 {
+				b.WriteString(" + ")
+			}
+			fmt.Fprintf(&b, "%c %d", t.Letter, t.Points)
+			if t.LetterMultiplier != 1 {
+				fmt.Fprintf(&b, "x%d", t.LetterMultiplier)
+			}
+			sum += t.Score
+		}
+		fmt.Fprintf(&b, " = %d", sum)
+		if w.WordMultiplier != 1 {
+			fmt.Fprintf(&b, " x%d = %d", w.WordMultiplier, w.Score)
+		}
+		b.WriteByte('\n')
+	}
+	if sb.BingoBonus != 0 {
+		fmt.Fprintf(&b, "Bingo bonus: %d\n", sb.BingoBonus)
+	}
+	fmt.Fprintf(&b, "Total: %d", sb.Total)
+	return b.String()
+}
@@ -0,0 +1,96 @@
+package game
+
+import (
+	"testing"
+)
+
+// TestExplainScore tests the breakdown of a play forming cross words
+func TestExplainScore(t *testing.T) {
+	board, move := parallelPlay(t)
+
+	sb := board.ExplainScore(move)
+	if sb.Total != board.ScoreMove(move) || len(sb.Words) != 3 {
+		t.Fatalf("Breakdown should explain the 3 words and total of ScoreMove, got %+v", sb)
+	}
+	tt := sb.Words[2]
+	if tt.Word != "TT" || tt.Score != 3 || tt.WordMultiplier != 1 {
+		t.Errorf("Expected TT worth 3, got %+v", tt)
+	}
+	old, placed := tt.Tiles[0], tt.Tiles[1]
+	if old.Placed || old.Premium != Normal || old.Score != 1 {
+		t.Errorf("A tile already on the board should score face value, got %+v", old)
+	}
+	if !placed.Placed || placed.Premium != DoubleLetterScore || placed.LetterMultiplier != 2 || placed.Score != 2 {
+		t.Errorf("The placed T should be doubled on I9, got %+v", placed)
+	}
+
+	want := "AT: A 1 + T 1x2 = 3\nAA: A 1 + A 1 = 2\nTT: T 1 + T 1x2 = 3\nTotal: 8"
+	if got := sb.String(); got != want {
+		t.Errorf("Expected\n%s\ngot\n%s", want, got)
+	}
+}
+
+// TestExplainScoreMatchesScoreMove tests breakdowns of generated moves, with
+// word premiums, blanks and bingos, under standard and custom multipliers
+func TestExplainScoreMatchesScoreMove(t *testing.T) {
+	mg := NewMoveGenerator(append([]string{"CATDOGS"}, testWords...))
+	for _, mul := range []*Multipliers{nil, {DoubleLetter: 4, TripleLetter: 5, DoubleWord: 1, TripleWord: 7}} {
+		board := NewBoard()
+		board.Multipliers = mul
+		placeWord(t, board, "CAT", "G8", Horizontal)
+		for _, m := range mg.GenerateMoves(board, rackOf("SDOGAT?")) {
+			if sb := board.ExplainScore(m); sb.Total != m.Score {
+				t.Errorf("%s at %s: breakdown total %d should match score %d:\n%s", m.Word, m.Start, sb.Total, m.Score, sb)
+			}
+		}
+	}
+
+	board := NewBoard()
+	move, err := board.BuildMove("p1", "CATDOGS", mustPos(t, "H4"), Vertical, []int{6})
+	if err != nil {
+		t.Fatalf("BuildMove failed: %v", err)
+	}
+	sb := board.ExplainScore(move)
+	if sb.BingoBonus != BingoBonus || sb.Total != board.ScoreMove(move) {
+		t.Errorf("A bingo should include the bonus, got %+v", sb)
+	}
+	if last := sb.Words[0].Tiles[6]; last.Points != 0 || last.Letter != 'S' {
+		t.Errorf("A blank should score nothing, got %+v", last)
+	}
+}
+
+// TestHistoryBreakdown tests that played moves keep their breakdown through saving
+func TestHistoryBreakdown(t *testing.T) {
+	g := newTestGame(t, 2)
+	g.StartGame()
+	g.Players[0].Rack = rackOf("CATXYZV")
+	if _, err := g.PlayMove(Move{PlayerID: "p1", Word: "CAT", Start: mustPos(t, "H8"), Direction: Horizontal,
+		Tiles: []PlacedTile{
+			{Tile: Tile{Letter: 'C', Points: 3}, Position: mustPos(t, "H8")},
+			{Tile: Tile{Letter: 'A', Points: 1}, Position: mustPos(t, "I8")},
+			{Tile: Tile{Letter: 'T', Points: 1}, Position: mustPos(t, "J8")},
+		}}); err != nil {
+		t.Fatalf("PlayMove failed: %v", err)
+	}
+	g.PassTurn("p2")
+
+	played := g.History[0].Breakdown
+	if played == nil || played.Total != 10 || played.Words[0].WordMultiplier != 2 {
+		t.Fatalf("The move should record its breakdown, got %+v", played)
+	}
+	if g.History[1].Breakdown != nil {
+		t.Errorf("A pass should have no breakdown")
+	}
+
+	data, err := g.Serialize()
+	if err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	loaded, err := LoadGame(data)
+	if err != nil {
+		t.Fatalf("LoadGame failed: %v", err)
+	}
+	if got := loaded.History[0].Breakdown; got == nil || got.String() != played.String() {
+		t.Errorf("A loaded game should keep the breakdown, got %+v", got)
+	}
+}
@@ -0,0 +1,227 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ChallengeRule determines how words not in the dictionary are handled
+type ChallengeRule int
+
+const (
+	VoidChallenge   ChallengeRule = iota // Plays forming invalid words are rejected when made
+	DoubleChallenge                      // Any play is accepted; a failed challenger loses their turn
+)
+
+// String returns a string representation of the challenge rule
+func (cr ChallengeRule) String() string {
+	switch cr {
+	case VoidChallenge:
+		return "VOID"
+	case DoubleChallenge:
+		return "DOUBLE"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// Errors returned by challenges
+var (
+	ErrChallengeNotAllowed = errors.New("challenges are not allowed under the void rule")
+	ErrNothingToChallenge  = errors.New("there is no move that can be challenged")
+	ErrNoDictionary        = errors.New("a dictionary is required to resolve challenges")
+	ErrOwnMove             = errors.New("players cannot challenge their own move")
+)
+
+// ChallengeResult describes the outcome of a challenge
+type ChallengeResult struct {
+	ChallengerID string   `json:"challenger_id"`
+	ChallengedID string   `json:"challenged_id"`
+	Successful   bool     `json:"successful"`    // True if the move formed an invalid word and was withdrawn
+	InvalidWords []string `json:"invalid_words"` // Words from the move not found in the dictionary
+}
+
+// SetChallengeRule selects how invalid words are handled for the rest of the game
+func (g *Game) SetChallengeRule(rule ChallengeRule) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.ChallengeRule = rule
+	g.record(Event{Type: ChallengeRuleChanged, Rule: rule})
+}
+
+// CanChallenge returns true if the most recent move may be challenged
+func (g *Game) CanChallenge() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.ChallengeRule == DoubleChallenge && g.challengeable
+}
+
+// Challenge disputes the words formed by the most recent move
+// If any word is invalid the move is withdrawn: its tiles return to the
+// player's rack, drawn tiles return to the bag and its score is removed.
+// Otherwise the challenger forfeits their next turn.
+func (g *Game) Challenge(challengerID string) (ChallengeResult, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.ChallengeRule != DoubleChallenge {
+		return ChallengeResult{}, ErrChallengeNotAllowed
+	}
+	if g.Dictionary == nil {
+		return ChallengeResult{}, ErrNoDictionary
+	}
+	if !g.challengeable || len(g.History) == 0 {
+		return ChallengeResult{}, ErrNothingToChallenge
+	}
+	if g.Paused != nil {
+		return ChallengeResult{}, ErrGamePaused
+	}
+
+	last := &g.History[len(g.History)-1]
+	if g.findPlayer(challengerID) == nil {
+		return ChallengeResult{}, ErrPlayerNotFound
+	}
+	if challengerID == last.PlayerID {
+		return ChallengeResult{}, ErrOwnMove
+	}
+
+	g.recordAction()
+	result := ChallengeResult{
+		ChallengerID: challengerID,
+		ChallengedID: last.PlayerID,
+		InvalidWords: []string{},
+	}
+	for _, word := range last.Words {
+		if !g.Dictionary.IsValid(word) {
+			result.InvalidWords = append(result.InvalidWords, word)
+		}
+	}
+	g.challengeable = false
+
+	challenger := g.findPlayer(challengerID)
+	entry := Move{
+		Type:      ChallengeTurn,
+		PlayerID:  challengerID,
+		Timestamp: time.Now(),
+		Rack:      rackCopy(challenger.Rack),
+		Total:     challenger.Score,
+	}
+
+	if len(result.InvalidWords) == 0 {
+		g.forfeitTurn(challengerID)
+	} else {
+		if err := g.withdrawLastMove(); err != nil {
+			return ChallengeResult{}, err
+		}
+		result.Successful = true
+	}
+
+	entry.Challenge = &result
+	g.History = append(g.History, entry)
+	g.record(Event{Type: ChallengeResolved, Timestamp: entry.Timestamp, PlayerID: challengerID, Challenge: &result})
+	g.touch()
+	return result, nil
+}
+
+// forfeitTurn makes a player lose their turn, immediately if it is theirs now;
+// callers must hold the lock
+func (g *Game) forfeitTurn(playerID string) {
+	if g.State == InProgress && g.Players[g.CurrentTurn].ID == playerID {
+		g.advanceTurn()
+		return
+	}
+	g.losesTurn[playerID] = true
+}
+
+// withdrawLastMove reverses the most recent tile placement, leaving it in the
+// history marked as withdrawn; callers must hold the lock
+func (g *Game) withdrawLastMove() error {
+	last := &g.History[len(g.History)-1]
+	if last.Type != PlaceTiles || last.Withdrawn {
+		return ErrNothingToChallenge
+	}
+
+	player := g.findPlayer(last.PlayerID)
+	if player == nil {
+		return ErrPlayerNotFound
+	}
+
+	// A move that went out ended the game; undo the final rack adjustments first
+	wentOut := g.State == Finished
+	if wentOut {
+		g.unfinish(player)
+		g.State = InProgress
+	}
+
+	// Return drawn tiles to the bag
+	drawnIdx, err := rackIndices(player.Rack, tilesAsPlaced(last.Drawn))
+	if err != nil {
+		return fmt.Errorf("cannot withdraw move: %w", err)
+	}
+	drawn, err := player.RemoveTilesFromRack(drawnIdx)
+	if err != nil {
+		return err
+	}
+	g.TileBag.ReturnTiles(drawn)
+
+	// Lift the placed tiles off the board and back onto the rack
+	for _, pt := range last.Tiles {
+		if _, err := g.Board.RemoveTile(pt.Position); err != nil {
+			return err
+		}
+		tile := pt.Tile
+		if tile.IsBlank {
+			tile.Letter = 0
+		}
+		player.Rack = append(player.Rack, tile)
+	}
+
+	// The entry keeps its original score and total so records show what was withdrawn
+	player.Score -= last.Score
+	last.Withdrawn = true
+
+	// Play had not yet passed on from a move that ended the game
+	if wentOut {
+		g.advanceTurn()
+	}
+	return nil
+}
+
+// unfinish reverses the end-of-game rack adjustments applied by finish;
+// callers must hold the lock
+func (g *Game) unfinish(wentOut *Player) {
+	g.unsettleClock()
+
+	var partner *Player
+	if g.Teams != nil && wentOut != nil {
+		partner = g.partner(wentOut.ID)
+	}
+
+	remaining := 0
+	for _, p := range g.Players {
+		rackValue := 0
+		for _, t := range p.Rack {
+			rackValue += t.Points
+		}
+		p.Score += rackValue
+		if p != partner {
+			remaining += rackValue
+		}
+	}
+
+	if wentOut != nil {
+		wentOut.Score -= remaining
+	}
+}
+
+// tilesAsPlaced wraps tiles as placed tiles so rack lookups can match them
+func tilesAsPlaced(tiles []Tile) []PlacedTile {
+	placed := make([]PlacedTile, len(tiles))
+	for i, t := range tiles {
+		placed[i] = PlacedTile{Tile: t}
+	}
+	return placed
+}
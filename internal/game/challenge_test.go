@@ -0,0 +1,217 @@
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"scrabbled/internal/dictionary"
+)
+
+// newChallengeGame creates a started two player double challenge game with a small dictionary
+func newChallengeGame(t *testing.T) *Game {
+	t.Helper()
+
+	dict := dictionary.NewWordList(dictionary.Custom)
+	for _, word := range testWords {
+		dict.AddWord(word)
+	}
+
+	g := newTestGame(t, 2)
+	g.SetDictionary(dict)
+	g.SetChallengeRule(DoubleChallenge)
+	if err := g.StartGame(); err != nil {
+		t.Fatalf("Failed to start game: %v", err)
+	}
+	return g
+}
+
+// TestChallengeVoidRule tests that void games reject phonies outright and disallow challenges
+func TestChallengeVoidRule(t *testing.T) {
+	g := newChallengeGame(t)
+	g.SetChallengeRule(VoidChallenge)
+	g.Players[0].Rack = rackOf("CATXYZQ")
+
+	phony, _ := g.Board.BuildMove("p1", "TAC", mustPos(t, "G8"), Horizontal, nil)
+	if _, err := g.PlayMove(phony); !errors.Is(err, ErrInvalidWord) {
+		t.Errorf("Void rule should reject TAC, got %v", err)
+	}
+
+	if _, err := g.Challenge("p2"); !errors.Is(err, ErrChallengeNotAllowed) {
+		t.Errorf("Challenge under void rule should return ErrChallengeNotAllowed, got %v", err)
+	}
+}
+
+// TestChallengeSuccessful tests withdrawing a phony under double challenge
+func TestChallengeSuccessful(t *testing.T) {
+	g := newChallengeGame(t)
+	g.Players[0].Rack = rackOf("CATXYZQ")
+	bagBefore := g.TileBag.RemainingCount()
+
+	phony, _ := g.Board.BuildMove("p1", "TAC", mustPos(t, "G8"), Horizontal, nil)
+	if _, err := g.PlayMove(phony); err != nil {
+		t.Fatalf("Double challenge should accept TAC until challenged: %v", err)
+	}
+	if !g.CanChallenge() {
+		t.Errorf("The last move should be challengeable")
+	}
+
+	if _, err := g.Challenge("p1"); err == nil {
+		t.Errorf("Players should not be able to challenge their own move")
+	}
+
+	result, err := g.Challenge("p2")
+	if err != nil {
+		t.Fatalf("Challenge failed: %v", err)
+	}
+	if !result.Successful || len(result.InvalidWords) != 1 || result.InvalidWords[0] != "TAC" {
+		t.Errorf("Challenge should succeed on TAC, got %+v", result)
+	}
+
+	if !g.Board.IsFirstMove() {
+		t.Errorf("Withdrawn tiles should be removed from the board")
+	}
+	if g.Players[0].Score != 0 {
+		t.Errorf("Withdrawn move score should be removed, got %d", g.Players[0].Score)
+	}
+	if g.TileBag.RemainingCount() != bagBefore {
+		t.Errorf("Drawn tiles should return to the bag: expected %d, got %d", bagBefore, g.TileBag.RemainingCount())
+	}
+
+	rack := g.Players[0].Rack
+	if len(rack) != MaxRackSize || !rackHolds(rack, phony.Tiles) {
+		t.Errorf("Withdrawn tiles should return to the rack, got %v", rack)
+	}
+
+	if withdrawn := g.History[len(g.History)-2]; !withdrawn.Withdrawn || withdrawn.Score != 10 || withdrawn.Total != 10 {
+		t.Errorf("History should mark the move withdrawn, got %+v", withdrawn)
+	}
+	if last := g.History[len(g.History)-1]; last.Type != ChallengeTurn || last.Challenge == nil || !last.Challenge.Successful {
+		t.Errorf("History should record the challenge, got %+v", last)
+	}
+
+	if g.CurrentPlayer().ID != "p2" {
+		t.Errorf("Challenged player should lose their turn, got %s to move", g.CurrentPlayer().ID)
+	}
+
+	if _, err := g.Challenge("p2"); !errors.Is(err, ErrNothingToChallenge) {
+		t.Errorf("A move can only be challenged once, got %v", err)
+	}
+}
+
+// TestChallengeUnsuccessful tests that a failed challenger loses their turn
+func TestChallengeUnsuccessful(t *testing.T) {
+	g := newChallengeGame(t)
+	g.Players[0].Rack = rackOf("CATXYZQ")
+
+	move, _ := g.Board.BuildMove("p1", "CAT", mustPos(t, "G8"), Horizontal, nil)
+	g.PlayMove(move)
+
+	result, err := g.Challenge("p2")
+	if err != nil {
+		t.Fatalf("Challenge failed: %v", err)
+	}
+	if result.Successful {
+		t.Errorf("Challenge on CAT should fail")
+	}
+
+	if g.Players[0].Score != 10 {
+		t.Errorf("Valid move should keep its score, got %d", g.Players[0].Score)
+	}
+	if g.CurrentPlayer().ID != "p1" {
+		t.Errorf("Failed challenger should lose their turn, got %s to move", g.CurrentPlayer().ID)
+	}
+}
+
+// TestChallengeWindow tests that only the latest move can be challenged
+func TestChallengeWindow(t *testing.T) {
+	g := newChallengeGame(t)
+
+	if _, err := g.Challenge("p2"); !errors.Is(err, ErrNothingToChallenge) {
+		t.Errorf("Challenge with no moves should return ErrNothingToChallenge, got %v", err)
+	}
+
+	g.Players[0].Rack = rackOf("CATXYZQ")
+	move, _ := g.Board.BuildMove("p1", "TAC", mustPos(t, "G8"), Horizontal, nil)
+	g.PlayMove(move)
+	g.PassTurn("p2")
+
+	if g.CanChallenge() {
+		t.Errorf("Move should not be challengeable after the next turn")
+	}
+	if _, err := g.Challenge("p2"); !errors.Is(err, ErrNothingToChallenge) {
+		t.Errorf("Late challenge should return ErrNothingToChallenge, got %v", err)
+	}
+
+	// A dictionary is needed to resolve challenges
+	g.SetDictionary(nil)
+	if _, err := g.Challenge("p2"); !errors.Is(err, ErrNoDictionary) {
+		t.Errorf("Challenge without a dictionary should return ErrNoDictionary, got %v", err)
+	}
+}
+
+// TestChallengeGoingOut tests withdrawing a move that ended the game
+func TestChallengeGoingOut(t *testing.T) {
+	g := newChallengeGame(t)
+	g.TileBag.DrawTiles(100)
+	g.Players[0].Rack = rackOf("XA")
+	g.Players[1].Rack = rackOf("QZ")
+	bad, _ := g.Board.BuildMove("p1", "XA", mustPos(t, "H8"), Horizontal, nil)
+	g.PlayMove(bad)
+	if g.State != Finished {
+		t.Fatalf("Going out should end the game")
+	}
+
+	result, err := g.Challenge("p2")
+	if err != nil || !result.Successful {
+		t.Fatalf("Challenge on XA should succeed: %+v, %v", result, err)
+	}
+
+	if g.State != InProgress {
+		t.Errorf("Withdrawing the final move should resume the game, got %s", g.State)
+	}
+	if g.Players[0].Score != 0 || g.Players[1].Score != 0 {
+		t.Errorf("Final adjustments should be reversed, got %d and %d", g.Players[0].Score, g.Players[1].Score)
+	}
+	if g.CurrentPlayer().ID != "p2" {
+		t.Errorf("Play should pass to p2, got %s", g.CurrentPlayer().ID)
+	}
+}
+
+// TestChallengeTeamGoingOut tests that withdrawing a team's going-out move
+// reverses the adjustments without charging the partner's rack to the player
+func TestChallengeTeamGoingOut(t *testing.T) {
+	g := newTeamGame(t, 0)
+	dict := dictionary.NewWordList(dictionary.Custom)
+	for _, word := range testWords {
+		dict.AddWord(word)
+	}
+	g.SetDictionary(dict)
+	g.SetChallengeRule(DoubleChallenge)
+	g.TileBag.DrawTiles(100)
+	g.Players[0].Rack = rackOf("ZQ")
+	g.Players[1].Rack = rackOf("AE")
+	g.Players[2].Rack = rackOf("XX")
+	g.Players[3].Rack = rackOf("AE")
+
+	bad, _ := g.Board.BuildMove("p1", "ZQ", mustPos(t, "H8"), Horizontal, nil)
+	if _, err := g.PlayMove(bad); err != nil || g.State != Finished {
+		t.Fatalf("Going out should end the game: %v", err)
+	}
+	result, err := g.Challenge("p2")
+	if err != nil || !result.Successful {
+		t.Fatalf("Challenge on ZQ should succeed: %+v, %v", result, err)
+	}
+
+	for _, p := range g.Players {
+		if p.Score != 0 {
+			t.Errorf("Player %s should be back to 0, got %d", p.ID, p.Score)
+		}
+	}
+}
+
+// TestChallengeRuleString tests ChallengeRule string conversion
+func TestChallengeRuleString(t *testing.T) {
+	if VoidChallenge.String() != "VOID" || DoubleChallenge.String() != "DOUBLE" || ChallengeRule(9).String() != "UNKNOWN" {
+		t.Errorf("Unexpected challenge rule strings")
+	}
+}
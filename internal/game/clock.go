@@ -0,0 +1,343 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Errors returned by the game clock
+var (
+	ErrOutOfTime = errors.New("player has run out of time")
+	ErrNoClock   = errors.New("game has no clock")
+)
+
+// DefaultOvertimePenalty is the tournament penalty in points per minute of overtime
+const DefaultOvertimePenalty = 10
+
+// TimeControl configures the game clocks
+// Each player starts with Base time and gains Increment after every turn.
+// A player whose clock runs out keeps playing in overtime, losing
+// OvertimePenalty points per minute or part of a minute, until MaxOvertime is
+// reached and the player is flagged, which ends the game. With a TurnLimit,
+// a turn that runs longer is passed for the player when the limit is reached;
+// the time is charged and no increment is gained.
+type TimeControl struct {
+	Base            time.Duration `json:"base"`
+	Increment       time.Duration `json:"increment"`
+	OvertimePenalty int           `json:"overtime_penalty"`     // Points per started minute of overtime
+	MaxOvertime     time.Duration `json:"max_overtime"`         // Overtime allowed before flagging (0 flags at zero)
+	TurnLimit       time.Duration `json:"turn_limit,omitempty"` // Time allowed for each turn before it is passed (0 for no limit)
+}
+
+// TournamentTimeControl returns the standard tournament control: 25 minutes
+// each, 10 points per overtime minute and a flag after 10 minutes of overtime
+func TournamentTimeControl() TimeControl {
+	return TimeControl{
+		Base:            25 * time.Minute,
+		OvertimePenalty: DefaultOvertimePenalty,
+		MaxOvertime:     10 * time.Minute,
+	}
+}
+
+// BlitzTimeControl returns the speed control: 3 minutes each plus 2 seconds a
+// turn, 15 seconds for each turn and a flag as soon as a clock runs out
+func BlitzTimeControl() TimeControl {
+	return TimeControl{
+		Base:      3 * time.Minute,
+		Increment: 2 * time.Second,
+		TurnLimit: 15 * time.Second,
+	}
+}
+
+// Clock tracks the time left for each player; only the player to move's clock runs
+type Clock struct {
+	Control   TimeControl              `json:"control"`
+	Remaining map[string]time.Duration `json:"remaining"`         // Time left when each clock last stopped; negative in overtime
+	Running   string                   `json:"running,omitempty"` // Player whose clock is running
+	StartedAt time.Time                `json:"started_at"`        // When the running clock was started
+	Flagged   string                   `json:"flagged,omitempty"` // Player who exceeded the overtime limit
+	Penalties map[string]int           `json:"penalties,omitempty"`
+	now       func() time.Time
+}
+
+// newClock creates stopped clocks with the base time for each player
+func newClock(tc TimeControl, players []*Player) *Clock {
+	c := &Clock{
+		Control:   tc,
+		Remaining: make(map[string]time.Duration, len(players)),
+		Penalties: make(map[string]int),
+		now:       time.Now,
+	}
+	for _, p := range players {
+		c.Remaining[p.ID] = tc.Base
+	}
+	return c
+}
+
+// copyClock returns a deep copy of a clock
+func copyClock(c *Clock) *Clock {
+	if c == nil {
+		return nil
+	}
+	cp := *c
+	cp.Remaining = make(map[string]time.Duration, len(c.Remaining))
+	for id, d := range c.Remaining {
+		cp.Remaining[id] = d
+	}
+	cp.Penalties = make(map[string]int, len(c.Penalties))
+	for id, n := range c.Penalties {
+		cp.Penalties[id] = n
+	}
+	if cp.now == nil {
+		cp.now = time.Now
+	}
+	return &cp
+}
+
+// timeLeft returns a player's remaining time, including the running period
+func (c *Clock) timeLeft(playerID string) time.Duration {
+	left := c.Remaining[playerID]
+	if c.Running == playerID && playerID != "" {
+		left -= c.now().Sub(c.StartedAt)
+	}
+	return left
+}
+
+// start runs a player's clock
+func (c *Clock) start(playerID string) {
+	c.Running = playerID
+	c.StartedAt = c.now()
+}
+
+// stop stops the running clock, crediting the increment if the turn was completed
+func (c *Clock) stop(completed bool) {
+	if c.Running == "" {
+		return
+	}
+	c.Remaining[c.Running] = c.timeLeft(c.Running)
+	if completed {
+		c.Remaining[c.Running] += c.Control.Increment
+	}
+	c.Running = ""
+}
+
+// flagged returns true if the player has used more than the allowed overtime
+func (c *Clock) flagged(playerID string) bool {
+	return c.timeLeft(playerID) < -c.Control.MaxOvertime
+}
+
+// penalty returns the overtime penalty a player has incurred so far
+func (c *Clock) penalty(playerID string) int {
+	overtime := -c.timeLeft(playerID)
+	if overtime <= 0 {
+		return 0
+	}
+	minutes := int((overtime + time.Minute - 1) / time.Minute)
+	return minutes * c.Control.OvertimePenalty
+}
+
+// SetTimeControl turns on game clocks; it may only be called before the game starts
+func (g *Game) SetTimeControl(tc TimeControl) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.State != WaitingForPlayers {
+		return ErrGameNotWaiting
+	}
+	if g.Solo != nil {
+		return fmt.Errorf("%w: solo games are timed by their duration", ErrInvalidRules)
+	}
+	g.Clock = newClock(tc, g.Players)
+	g.record(Event{Type: TimeControlSet, TimeControl: &tc})
+	return nil
+}
+
+// TimeRemaining returns the time left on a player's clock, negative in overtime
+func (g *Game) TimeRemaining(playerID string) (time.Duration, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if g.findPlayer(playerID) == nil {
+		return 0, ErrPlayerNotFound
+	}
+	if g.Clock == nil {
+		return 0, ErrNoClock
+	}
+	return g.Clock.timeLeft(playerID), nil
+}
+
+// CheckTime flags the player to move if they have exceeded the overtime
+// limit, ending the game; it returns the flagged player's ID or "" if none
+// Servers should call it periodically, since a flagged player may never act.
+func (g *Game) CheckTime() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.enforceClock() != nil {
+		return g.Clock.Flagged
+	}
+	return ""
+}
+
+// TurnDeadline returns when the turn of the player to move will be passed for
+// running over the turn limit
+// Returns false if the game has no turn limit or no clock is running.
+func (g *Game) TurnDeadline() (time.Time, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if g.Clock == nil || g.Clock.Control.TurnLimit <= 0 || g.State != InProgress || g.Paused != nil || g.Clock.Running == "" {
+		return time.Time{}, false
+	}
+	return g.Clock.StartedAt.Add(g.Clock.Control.TurnLimit), true
+}
+
+// watchChanges returns a channel signalled whenever the game changes, for a
+// manager to watch the turn limit; nil if the game has no turn limit
+func (g *Game) watchChanges() <-chan struct{} {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.Clock == nil || g.Clock.Control.TurnLimit <= 0 {
+		return nil
+	}
+	if g.changed == nil {
+		g.changed = make(chan struct{}, 1)
+	}
+	return g.changed
+}
+
+// enforceClock passes the turns that have run over the turn limit, then ends
+// the game with ErrOutOfTime if the player to move has been flagged; callers
+// must hold the lock
+func (g *Game) enforceClock() error {
+	if g.Clock == nil || g.State != InProgress || g.Paused != nil {
+		return nil
+	}
+	g.expireTurns()
+	if g.State != InProgress {
+		return nil
+	}
+	current := g.Players[g.CurrentTurn].ID
+	if !g.Clock.flagged(current) {
+		return nil
+	}
+	g.flag(current)
+	return ErrOutOfTime
+}
+
+// expireTurns passes each turn that has run over the turn limit, starting the
+// next turn when the limit was reached; a player whose clock would flag first
+// is left to be flagged. Callers must hold the lock.
+func (g *Game) expireTurns() {
+	c := g.Clock
+	limit := c.Control.TurnLimit
+	if limit <= 0 {
+		return
+	}
+	for g.State == InProgress {
+		current := g.Players[g.CurrentTurn].ID
+		if c.Running != current || c.now().Sub(c.StartedAt) < limit || c.Remaining[current]+c.Control.MaxOvertime < limit {
+			return
+		}
+		deadline := c.StartedAt.Add(limit)
+		c.Remaining[current] -= limit
+		c.Running = ""
+
+		g.recordAction()
+		player := g.Players[g.CurrentTurn]
+		g.History = append(g.History, Move{
+			Type:      Pass,
+			PlayerID:  current,
+			Timestamp: deadline,
+			Rack:      rackCopy(player.Rack),
+			Total:     player.Score,
+		})
+		g.challengeable = false
+		g.endScorelessTurn()
+		g.turnStarted = deadline
+		if c.Running != "" {
+			c.StartedAt = deadline
+		}
+		g.record(Event{Type: TurnPassed, Timestamp: deadline, PlayerID: current})
+		g.touch()
+	}
+}
+
+// expire flags a player regardless of their clock, as when replaying a
+// recorded flag
+func (g *Game) expire(playerID string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.Clock == nil || g.State != InProgress {
+		return ErrGameNotInProgress
+	}
+	g.flag(playerID)
+	return nil
+}
+
+// flag ends the game because a player ran out of time; callers must hold the lock
+func (g *Game) flag(playerID string) {
+	g.recordAction()
+	g.Clock.Flagged = playerID
+	g.challengeable = false
+	g.finish(nil)
+	g.record(Event{Type: TimeExpired, PlayerID: playerID})
+	g.touch()
+}
+
+// switchClock starts the clock of the player to move, stopping the previous
+// one; completed credits the increment to the player who just moved.
+// Callers must hold the lock.
+func (g *Game) switchClock(completed bool) {
+	if g.Clock == nil {
+		return
+	}
+	g.Clock.stop(completed)
+	if g.State == InProgress {
+		g.Clock.start(g.Players[g.CurrentTurn].ID)
+	}
+}
+
+// resumeClock runs the clock of the player to move after undo or redo, clearing
+// the result of a flag or settlement that was undone; callers must hold the lock
+func (g *Game) resumeClock() {
+	if g.Clock == nil {
+		return
+	}
+	if g.State != Finished {
+		g.Clock.Penalties = make(map[string]int)
+		g.Clock.Flagged = ""
+	}
+	g.switchClock(false)
+}
+
+// settleClock stops the clocks at the end of the game and deducts overtime
+// penalties; callers must hold the lock
+func (g *Game) settleClock() {
+	if g.Clock == nil {
+		return
+	}
+	g.Clock.stop(false)
+	for _, p := range g.Players {
+		if n := g.Clock.penalty(p.ID); n > 0 {
+			p.Score -= n
+			g.Clock.Penalties[p.ID] = n
+		}
+	}
+}
+
+// unsettleClock refunds overtime penalties when a finished game is resumed;
+// callers must hold the lock
+func (g *Game) unsettleClock() {
+	if g.Clock == nil {
+		return
+	}
+	for _, p := range g.Players {
+		p.Score += g.Clock.Penalties[p.ID]
+	}
+	g.Clock.Penalties = make(map[string]int)
+	g.Clock.Flagged = ""
+}
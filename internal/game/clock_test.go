@@ -0,0 +1,213 @@
+package game
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeNow is a controllable time source for clock tests
+type fakeNow struct {
+	t time.Time
+}
+
+func (f *fakeNow) now() time.Time { return f.t }
+
+func (f *fakeNow) advance(d time.Duration) { f.t = f.t.Add(d) }
+
+// newTimedGame creates a started two-player game with the given time control
+func newTimedGame(t *testing.T, tc TimeControl) (*Game, *fakeNow) {
+	t.Helper()
+	g := newTestGame(t, 2)
+	if err := g.SetTimeControl(tc); err != nil {
+		t.Fatalf("SetTimeControl failed: %v", err)
+	}
+	clock := &fakeNow{t: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	g.Clock.now = clock.now
+	if err := g.StartGame(); err != nil {
+		t.Fatalf("StartGame failed: %v", err)
+	}
+	return g, clock
+}
+
+// TestClockSwitchesOnTurnChange tests that only the player to move loses time
+func TestClockSwitchesOnTurnChange(t *testing.T) {
+	g, clock := newTimedGame(t, TimeControl{Base: 10 * time.Minute, Increment: 5 * time.Second})
+
+	clock.advance(time.Minute)
+	if left, _ := g.TimeRemaining("p1"); left != 9*time.Minute {
+		t.Errorf("Should deduct running time from p1, got %v", left)
+	}
+	if left, _ := g.TimeRemaining("p2"); left != 10*time.Minute {
+		t.Errorf("Should not run p2's clock, got %v", left)
+	}
+
+	if err := g.PassTurn("p1"); err != nil {
+		t.Fatalf("PassTurn failed: %v", err)
+	}
+	clock.advance(2 * time.Minute)
+
+	if left, _ := g.TimeRemaining("p1"); left != 9*time.Minute+5*time.Second {
+		t.Errorf("Should stop p1's clock and add the increment, got %v", left)
+	}
+	if left, _ := g.TimeRemaining("p2"); left != 8*time.Minute {
+		t.Errorf("Should run p2's clock, got %v", left)
+	}
+
+	if _, err := g.TimeRemaining("nobody"); err != ErrPlayerNotFound {
+		t.Errorf("Should reject unknown players, got %v", err)
+	}
+}
+
+// TestSetTimeControlAfterStart tests that clocks cannot be added mid-game
+func TestSetTimeControlAfterStart(t *testing.T) {
+	g := newTestGame(t, 2)
+	g.StartGame()
+
+	if err := g.SetTimeControl(TournamentTimeControl()); err != ErrGameNotWaiting {
+		t.Errorf("Should reject time control after start, got %v", err)
+	}
+}
+
+// TestOvertimePenalty tests that overtime costs points per started minute
+func TestOvertimePenalty(t *testing.T) {
+	g, clock := newTimedGame(t, TournamentTimeControl())
+
+	clock.advance(26*time.Minute + 30*time.Second)
+	if err := g.PassTurn("p1"); err != nil {
+		t.Fatalf("PassTurn should be allowed in overtime: %v", err)
+	}
+	if err := g.EndGame(); err != nil {
+		t.Fatalf("EndGame failed: %v", err)
+	}
+
+	if g.Players[0].Score != -20 {
+		t.Errorf("Should deduct 20 points for 1m30s overtime, got %d", g.Players[0].Score)
+	}
+	if g.Players[1].Score != 0 {
+		t.Errorf("Should not penalize a player within time, got %d", g.Players[1].Score)
+	}
+	if g.Clock.Penalties["p1"] != 20 {
+		t.Errorf("Should record the penalty, got %v", g.Clock.Penalties)
+	}
+}
+
+// TestFlagEndsGame tests that exceeding the overtime limit ends the game
+func TestFlagEndsGame(t *testing.T) {
+	g, clock := newTimedGame(t, TimeControl{Base: time.Minute, OvertimePenalty: DefaultOvertimePenalty, MaxOvertime: 2 * time.Minute})
+
+	clock.advance(2 * time.Minute)
+	if flagged := g.CheckTime(); flagged != "" {
+		t.Errorf("Should not flag within the overtime limit, got %q", flagged)
+	}
+
+	clock.advance(2 * time.Minute)
+	if err := g.PassTurn("p1"); !errors.Is(err, ErrOutOfTime) {
+		t.Errorf("Should refuse moves from a flagged player, got %v", err)
+	}
+	if g.State != Finished || g.Clock.Flagged != "p1" {
+		t.Errorf("Should finish the game with p1 flagged, got %v %q", g.State, g.Clock.Flagged)
+	}
+	if g.Clock.Penalties["p1"] != 30 {
+		t.Errorf("Should deduct 30 points for 3 minutes overtime, got %v", g.Clock.Penalties)
+	}
+}
+
+// TestCheckTimeFlags tests that CheckTime ends the game for an idle player
+func TestCheckTimeFlags(t *testing.T) {
+	g, clock := newTimedGame(t, TimeControl{Base: time.Minute})
+
+	clock.advance(time.Minute + time.Second)
+	if flagged := g.CheckTime(); flagged != "p1" {
+		t.Errorf("Should flag p1, got %q", flagged)
+	}
+	if g.State != Finished {
+		t.Errorf("Should finish the game, got %v", g.State)
+	}
+
+	// Undoing the flag resumes the game and refunds the penalty
+	if err := g.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if g.State != InProgress || g.Clock.Flagged != "" || g.Players[0].Score != 0 {
+		t.Errorf("Should resume the game after undoing a flag")
+	}
+}
+
+// TestTurnLimit tests that turns running over the limit are passed at the limit
+func TestTurnLimit(t *testing.T) {
+	g, clock := newTimedGame(t, TimeControl{Base: time.Minute, Increment: 2 * time.Second, TurnLimit: 10 * time.Second})
+	start := clock.t
+
+	if deadline, ok := g.TurnDeadline(); !ok || !deadline.Equal(start.Add(10*time.Second)) {
+		t.Errorf("Expected p1's turn to end after 10s, got %v %v", deadline, ok)
+	}
+	clock.advance(9 * time.Second)
+	if g.CheckTime(); g.CurrentTurn != 0 {
+		t.Errorf("Should not pass a turn within the limit")
+	}
+
+	clock.advance(time.Second)
+	if flagged := g.CheckTime(); flagged != "" || g.CurrentTurn != 1 {
+		t.Fatalf("Should pass p1's turn at the limit, got turn %d flagged %q", g.CurrentTurn, flagged)
+	}
+	if last, _ := g.History.Last(); last.Type != Pass || last.PlayerID != "p1" || !last.Timestamp.Equal(start.Add(10*time.Second)) {
+		t.Errorf("Should record p1 passing at the limit, got %+v", last)
+	}
+	if left, _ := g.TimeRemaining("p1"); left != 50*time.Second {
+		t.Errorf("Should charge the limit without an increment, got %v", left)
+	}
+
+	// Turns missed while nobody checked are passed in turn, each starting at
+	// the previous limit
+	clock.advance(25 * time.Second)
+	if err := g.PassTurn("p1"); !errors.Is(err, ErrNotPlayersTurn) {
+		t.Errorf("p1's second turn should have been passed, got %v", err)
+	}
+	if len(g.History) != 3 || g.CurrentTurn != 1 {
+		t.Errorf("Expected three passes with p2 to move, got %d with %d to move", len(g.History), g.CurrentTurn)
+	}
+	if left, _ := g.TimeRemaining("p2"); left != 45*time.Second {
+		t.Errorf("p2's turn should have started at p1's limit, got %v", left)
+	}
+
+	// Scoreless turns still end the game
+	clock.advance(time.Hour)
+	if g.CheckTime(); g.State != Finished || len(g.History) != MaxScorelessTurns {
+		t.Errorf("Should end the game after %d passes, got %v with %d", MaxScorelessTurns, g.State, len(g.History))
+	}
+}
+
+// TestTurnLimitFlag tests that a clock running out before the turn limit flags
+func TestTurnLimitFlag(t *testing.T) {
+	g, clock := newTimedGame(t, TimeControl{Base: 5 * time.Second, TurnLimit: 10 * time.Second})
+
+	clock.advance(6 * time.Second)
+	if flagged := g.CheckTime(); flagged != "p1" {
+		t.Errorf("Should flag p1 rather than pass, got %q", flagged)
+	}
+}
+
+// TestClockSurvivesRestore tests that clocks are saved and restored
+func TestClockSurvivesRestore(t *testing.T) {
+	g, clock := newTimedGame(t, TournamentTimeControl())
+	clock.advance(3 * time.Minute)
+	g.PassTurn("p1")
+
+	data, err := g.Serialize()
+	if err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	restored, err := LoadGame(data)
+	if err != nil {
+		t.Fatalf("LoadGame failed: %v", err)
+	}
+
+	if restored.Clock == nil || restored.Clock.Control != g.Clock.Control {
+		t.Fatalf("Should restore the time control")
+	}
+	if restored.Clock.Remaining["p1"] != 22*time.Minute || restored.Clock.Running != "p2" {
+		t.Errorf("Should restore remaining time and the running clock, got %v %q",
+			restored.Clock.Remaining, restored.Clock.Running)
+	}
+}
@@ -0,0 +1,123 @@
+package game
+
+// Clone returns a deep copy of the board
+// The copy's tiles share one backing array, so a clone costs two allocations
+// however full the board is.
+func (b *Board) Clone() *Board {
+	if b == nil {
+		return nil
+	}
+	cp := &Board{}
+	b.CopyTo(cp)
+	return cp
+}
+
+// CopyTo makes dst a deep copy of the board, reusing dst's tile storage
+// Resetting the same scratch board for each simulated continuation avoids
+// allocating at all once dst has held a board as full. Tiles previously taken
+// from dst with GetTile are overwritten.
+func (b *Board) CopyTo(dst *Board) {
+	tiles := dst.tiles[:0]
+	*dst = *b
+
+	n := 0
+	for row := 0; row < 15; row++ {
+		for col := 0; col < 15; col++ {
+			if b.Grid[row][col].Tile != nil {
+				n++
+			}
+		}
+	}
+	if cap(tiles) < n {
+		tiles = make([]Tile, 0, n)
+	}
+
+	for row := 0; row < 15; row++ {
+		for col := 0; col < 15; col++ {
+			if t := b.Grid[row][col].Tile; t != nil {
+				tiles = append(tiles, *t)
+				dst.Grid[row][col].Tile = &tiles[len(tiles)-1]
+			}
+		}
+	}
+	dst.tiles = tiles
+}
+
+// Clone returns a deep copy of the player
+func (p *Player) Clone() *Player {
+	if p == nil {
+		return nil
+	}
+
+	cp := *p
+	cp.Rack = make([]Tile, len(p.Rack), MaxRackSize)
+	copy(cp.Rack, p.Rack)
+	return &cp
+}
+
+// clone returns a copy of the bag holding the same tiles in the same order
+// The copy shuffles with the global generator.
+func (tb *TileBag) clone() *TileBag {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	return &TileBag{tiles: append(make([]Tile, 0, len(tb.tiles)), tb.tiles...)}
+}
+
+// Clone returns a deep copy of the game's state for simulation or analysis
+// The copy shares the dictionary, move generator and bots, but has no
+// spectators, logger, tracer, recorded events or undo history, and is not
+// tied to storage.
+func (g *Game) Clone() *Game {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	c := &Game{
+		ID:              g.ID,
+		Board:           g.Board.Clone(),
+		Players:         make([]*Player, len(g.Players), MaxPlayers),
+		TileBag:         g.TileBag.clone(),
+		Dictionary:      g.Dictionary,
+		Generator:       g.Generator,
+		DictionaryName:  g.DictionaryName,
+		CurrentTurn:     g.CurrentTurn,
+		State:           g.State,
+		History:         copyHistory(g.History),
+		Scoreless:       g.Scoreless,
+		ChallengeRule:   g.ChallengeRule,
+		ExchangeMinimum: g.ExchangeMinimum,
+		Teams:           g.Teams,
+		Solo:            g.Solo,
+		AbandonAfter:    g.AbandonAfter,
+		Forfeit:         copyForfeit(g.Forfeit),
+		Pauses:          g.Pauses,
+		Paused:          copyPause(g.Paused),
+		PauseRequest:    copyPauseRequest(g.PauseRequest),
+		VacationUsed:    copyVacations(g.VacationUsed),
+		Clock:           copyClock(g.Clock),
+		Revision:        g.Revision,
+		CreatedAt:       g.CreatedAt,
+		LastActivity:    g.LastActivity,
+		ExpiresAt:       g.ExpiresAt,
+		bots:            make(map[string]Strategy, len(g.bots)),
+		coached:         make(map[string]bool, len(g.coached)),
+		feedback:        append([]MoveFeedback(nil), g.feedback...),
+		annotations:     append([]Annotation(nil), g.annotations...),
+		challengeable:   g.challengeable,
+		turnStarted:     g.turnStarted,
+		losesTurn:       make(map[string]bool, len(g.losesTurn)),
+	}
+	for i, p := range g.Players {
+		c.Players[i] = p.Clone()
+	}
+	for id, bot := range g.bots {
+		c.bots[id] = bot
+	}
+	for id := range g.coached {
+		c.coached[id] = true
+	}
+	for id := range g.losesTurn {
+		c.losesTurn[id] = true
+	}
+	return c
+}
@@ -0,0 +1,107 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestBoardClone tests that a cloned board is equal but independent
+func TestBoardClone(t *testing.T) {
+	board := NewBoard()
+	placeWord(t, board, "CAT", "G8", Horizontal)
+
+	cp := board.Clone()
+	if !reflect.DeepEqual(cp.Grid, board.Grid) {
+		t.Errorf("Clone should match the original board")
+	}
+
+	cp.PlaceTile(Tile{Letter: 'S', Points: 1}, mustPos(t, "J8"))
+	cp.GetTile(mustPos(t, "G8")).Letter = 'B'
+	if board.HasTileAt(mustPos(t, "J8")) || board.GetTile(mustPos(t, "G8")).Letter != 'C' {
+		t.Errorf("Changing the clone should not change the original")
+	}
+
+	var nilBoard *Board
+	if nilBoard.Clone() != nil {
+		t.Errorf("Cloning a nil board should return nil")
+	}
+}
+
+// TestBoardCopyTo tests reusing a scratch board without allocating
+func TestBoardCopyTo(t *testing.T) {
+	board := NewBoard()
+	placeWord(t, board, "RETAINS", "H8", Horizontal)
+
+	scratch := &Board{}
+	board.CopyTo(scratch)
+	scratch.PlaceTile(Tile{Letter: 'S', Points: 1}, mustPos(t, "H9"))
+
+	allocs := testing.AllocsPerRun(100, func() {
+		board.CopyTo(scratch)
+	})
+	if allocs != 0 {
+		t.Errorf("Copying into a used scratch board should not allocate, got %.0f allocations", allocs)
+	}
+	if !reflect.DeepEqual(scratch.Grid, board.Grid) {
+		t.Errorf("Scratch board should match the original after copying")
+	}
+}
+
+// TestPlayerClone tests that a cloned player's rack is independent
+func TestPlayerClone(t *testing.T) {
+	p := NewPlayer("p1", "Player 1")
+	p.AddTilesToRack(rackOf("CAT"))
+	p.Score = 12
+
+	cp := p.Clone()
+	if !reflect.DeepEqual(cp, p) {
+		t.Errorf("Clone should match the original player")
+	}
+	cp.RemoveTilesFromRack([]int{0})
+	cp.AddTilesToRack(rackOf("ZZ"))
+	if len(p.Rack) != 3 || p.Rack[0].Letter != 'C' {
+		t.Errorf("Changing the clone's rack should not change the original, got %v", p.Rack)
+	}
+}
+
+// TestGameClone tests playing on a cloned game without touching the original
+func TestGameClone(t *testing.T) {
+	g := newTestGame(t, 2)
+	g.StartGame()
+	g.Players[0].Rack = rackOf("CATXYZQ")
+
+	events := len(g.Events())
+	c := g.Clone()
+	if !reflect.DeepEqual(c.TileBag.Tiles(), g.TileBag.Tiles()) {
+		t.Errorf("Clone's bag should hold the same tiles in the same order")
+	}
+
+	move, _ := c.Board.BuildMove("p1", "CAT", mustPos(t, "G8"), Horizontal, nil)
+	if _, err := c.PlayMove(move); err != nil {
+		t.Fatalf("Playing on the clone failed: %v", err)
+	}
+	if len(c.History) != 1 || c.CurrentTurn != 1 {
+		t.Errorf("Clone should record its move")
+	}
+
+	if len(g.History) != 0 || g.CurrentTurn != 0 || !g.Board.IsFirstMove() || g.Players[0].Score != 0 {
+		t.Errorf("Original game should be unchanged")
+	}
+	if len(g.Players[0].Rack) != 7 || g.TileBag.RemainingCount() != c.TileBag.RemainingCount()+3 {
+		t.Errorf("Original rack and bag should be unchanged")
+	}
+	if len(g.Events()) != events || len(c.Events()) >= events {
+		t.Errorf("The clone's events should not reach the original's stream")
+	}
+}
+
+// BenchmarkBoardClone measures cloning a board in mid-game
+func BenchmarkBoardClone(b *testing.B) {
+	board, _ := parallelPlay(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		board.Clone()
+	}
+}
@@ -0,0 +1,133 @@
+package game
+
+// MoveFeedback compares a coached player's move with the best move available
+type MoveFeedback struct {
+	Turn       int        `json:"turn"` // Index of the move in the game history
+	PlayerID   string     `json:"player_id"`
+	Played     RankedMove `json:"played"`      // The move made, with its leave and equity
+	Best       RankedMove `json:"best"`        // The highest equity move that was available
+	Rank       int        `json:"rank"`        // Position of the played move among all candidates (1 is best, 0 if not generated)
+	Candidates int        `json:"candidates"`  // Number of legal moves that were available
+	ScoreLoss  int        `json:"score_loss"`  // Points short of the best move (negative if the played move scored more)
+	EquityLoss float64    `json:"equity_loss"` // Equity short of the best move, never negative
+	Accuracy   float64    `json:"accuracy"`    // Percentage of the best move's equity achieved
+}
+
+// EnableCoaching gives a player feedback on each tile placement they make
+// Feedback needs the attached move generator to find the best move.
+func (g *Game) EnableCoaching(playerID string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.findPlayer(playerID) == nil {
+		return ErrPlayerNotFound
+	}
+	if g.Generator == nil {
+		return ErrNoMoveGenerator
+	}
+	if g.coached == nil {
+		g.coached = make(map[string]bool)
+	}
+	g.coached[playerID] = true
+	return nil
+}
+
+// DisableCoaching stops feedback for a player; feedback already given is kept
+func (g *Game) DisableCoaching(playerID string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	delete(g.coached, playerID)
+}
+
+// Feedback returns the feedback given to a player so far, oldest first
+// Moves withdrawn after a challenge are left out.
+func (g *Game) Feedback(playerID string) []MoveFeedback {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.playerFeedback(playerID)
+}
+
+// Accuracy returns a player's mean move accuracy for the game as a percentage
+// Returns false if the player has not received any feedback.
+func (g *Game) Accuracy(playerID string) (float64, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	feedback := g.playerFeedback(playerID)
+	if len(feedback) == 0 {
+		return 0, false
+	}
+	total := 0.0
+	for _, fb := range feedback {
+		total += fb.Accuracy
+	}
+	return total / float64(len(feedback)), true
+}
+
+// playerFeedback returns the feedback for a player's standing moves; callers
+// must hold the lock
+func (g *Game) playerFeedback(playerID string) []MoveFeedback {
+	var result []MoveFeedback
+	for _, fb := range g.feedback {
+		if fb.PlayerID != playerID {
+			continue
+		}
+		if fb.Turn < len(g.History) && g.History[fb.Turn].Withdrawn {
+			continue
+		}
+		result = append(result, fb)
+	}
+	return result
+}
+
+// review compares a move about to be played with the best available move,
+// returning false if the player is not coached; callers must hold the lock
+// and call it before the move changes the board.
+func (g *Game) review(move Move, player *Player) (MoveFeedback, bool) {
+	if !g.coached[player.ID] || g.Generator == nil {
+		return MoveFeedback{}, false
+	}
+
+	le := NewLeaveEvaluator()
+	candidates := le.Rank(player.Rack, g.Generator.GenerateMoves(g.Board, player.Rack))
+
+	move.Score = g.Board.ScoreMove(move)
+	leave, err := Leave(player.Rack, move.Tiles)
+	if err != nil {
+		return MoveFeedback{}, false
+	}
+	value := le.Evaluate(leave)
+	played := RankedMove{Move: move, Leave: leave, LeaveValue: value, Equity: float64(move.Score) + value}
+
+	fb := MoveFeedback{PlayerID: player.ID, Played: played, Best: played, Candidates: len(candidates)}
+	key := moveKey(move.Tiles)
+	for i, c := range candidates {
+		if moveKey(c.Move.Tiles) == key {
+			fb.Rank = i + 1
+			break
+		}
+	}
+	if len(candidates) > 0 && candidates[0].Equity > played.Equity {
+		fb.Best = candidates[0]
+		fb.Best.Move.PlayerID = player.ID
+	}
+
+	fb.ScoreLoss = fb.Best.Move.Score - played.Move.Score
+	fb.EquityLoss = fb.Best.Equity - played.Equity
+	fb.Accuracy = moveAccuracy(played.Equity, fb.Best.Equity)
+	return fb, true
+}
+
+// moveAccuracy scores a move's equity against the best available, from 0 to 100
+func moveAccuracy(played, best float64) float64 {
+	switch {
+	case played >= best:
+		return 100
+	case best <= 0 || played <= 0:
+		return 0
+	default:
+		return 100 * played / best
+	}
+}
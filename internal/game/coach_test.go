@@ -0,0 +1,163 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+// newCoachedGame returns a started game in which p1 holds CATSDOG and is coached
+func newCoachedGame(t *testing.T) *Game {
+	t.Helper()
+	g := newTestGame(t, 2)
+	g.SetMoveGenerator(NewMoveGenerator(testWords))
+	if err := g.EnableCoaching("p1"); err != nil {
+		t.Fatalf("EnableCoaching failed: %v", err)
+	}
+	if err := g.StartGame(); err != nil {
+		t.Fatalf("Failed to start game: %v", err)
+	}
+	g.Players[0].Rack = rackOf("CATSDOG")
+	return g
+}
+
+// TestEnableCoaching tests the requirements for coaching a player
+func TestEnableCoaching(t *testing.T) {
+	g := newTestGame(t, 2)
+	if err := g.EnableCoaching("p1"); !errors.Is(err, ErrNoMoveGenerator) {
+		t.Errorf("Coaching should require a move generator, got %v", err)
+	}
+	g.SetMoveGenerator(NewMoveGenerator(testWords))
+	if err := g.EnableCoaching("nobody"); !errors.Is(err, ErrPlayerNotFound) {
+		t.Errorf("Should return ErrPlayerNotFound, got %v", err)
+	}
+	if err := g.EnableCoaching("p1"); err != nil {
+		t.Errorf("EnableCoaching failed: %v", err)
+	}
+}
+
+// TestCoachingWeakMove tests feedback on a move worse than the best available
+func TestCoachingWeakMove(t *testing.T) {
+	g := newCoachedGame(t)
+
+	move, err := g.Board.BuildMove("p1", "AT", mustPos(t, "H8"), Horizontal, nil)
+	if err != nil {
+		t.Fatalf("BuildMove failed: %v", err)
+	}
+	if _, err := g.PlayMove(move); err != nil {
+		t.Fatalf("PlayMove failed: %v", err)
+	}
+
+	feedback := g.Feedback("p1")
+	if len(feedback) != 1 {
+		t.Fatalf("Expected feedback on one move, got %d", len(feedback))
+	}
+	fb := feedback[0]
+	if fb.Turn != 0 || fb.Played.Move.Word != "AT" || fb.Played.Move.Score != 4 {
+		t.Errorf("Feedback should describe the played move, got %+v", fb.Played.Move)
+	}
+	if fb.Best.Move.Score <= fb.Played.Move.Score || fb.ScoreLoss != fb.Best.Move.Score-4 {
+		t.Errorf("Best move should outscore AT, got %d (loss %d)", fb.Best.Move.Score, fb.ScoreLoss)
+	}
+	if fb.Rank <= 1 || fb.Candidates < fb.Rank {
+		t.Errorf("AT should rank below the best of %d moves, got %d", fb.Candidates, fb.Rank)
+	}
+	if fb.EquityLoss <= 0 || fb.Accuracy <= 0 || fb.Accuracy >= 100 {
+		t.Errorf("Should report an equity loss and partial accuracy, got %.2f and %.1f%%", fb.EquityLoss, fb.Accuracy)
+	}
+
+	// The uncoached opponent gets no feedback
+	g.PassTurn("p2")
+	if len(g.Feedback("p2")) != 0 {
+		t.Errorf("Uncoached players should get no feedback")
+	}
+
+	acc, ok := g.Accuracy("p1")
+	if !ok || acc != fb.Accuracy {
+		t.Errorf("Accuracy should average move accuracy, got %.1f", acc)
+	}
+	if _, ok := g.Accuracy("p2"); ok {
+		t.Errorf("Accuracy should be unavailable without feedback")
+	}
+}
+
+// TestCoachingBestMove tests that playing the best move is fully accurate
+func TestCoachingBestMove(t *testing.T) {
+	g := newCoachedGame(t)
+
+	best, err := g.SuggestMoves("p1", 1, RankBy(RankByEquity))
+	if err != nil || len(best) == 0 {
+		t.Fatalf("SuggestMoves failed: %v", err)
+	}
+	if _, err := g.PlayMove(best[0].Move); err != nil {
+		t.Fatalf("PlayMove failed: %v", err)
+	}
+
+	fb := g.Feedback("p1")[0]
+	if fb.Rank != 1 || fb.Accuracy != 100 || fb.EquityLoss != 0 || fb.ScoreLoss != 0 {
+		t.Errorf("The best move should be rank 1 with full accuracy, got %+v", fb)
+	}
+}
+
+// TestCoachingUndoAndPersistence tests that feedback follows undo and survives saving
+func TestCoachingUndoAndPersistence(t *testing.T) {
+	g := newCoachedGame(t)
+
+	move, _ := g.Board.BuildMove("p1", "CAT", mustPos(t, "H8"), Horizontal, nil)
+	if _, err := g.PlayMove(move); err != nil {
+		t.Fatalf("PlayMove failed: %v", err)
+	}
+
+	data, err := g.Serialize()
+	if err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	loaded, err := LoadGame(data)
+	if err != nil {
+		t.Fatalf("LoadGame failed: %v", err)
+	}
+	if fb := loaded.Feedback("p1"); len(fb) != 1 || fb[0].Played.Move.Word != "CAT" {
+		t.Errorf("Feedback should be saved with the game, got %+v", fb)
+	}
+
+	if err := g.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if len(g.Feedback("p1")) != 0 {
+		t.Errorf("Undoing a move should remove its feedback")
+	}
+	if err := g.Redo(); err != nil {
+		t.Fatalf("Redo failed: %v", err)
+	}
+	if len(g.Feedback("p1")) != 1 {
+		t.Errorf("Redoing a move should restore its feedback")
+	}
+
+	g.DisableCoaching("p1")
+	g.PassTurn("p2")
+	g.Players[0].Rack = rackOf("DOGS")
+	move, _ = g.Board.BuildMove("p1", "DOGS", mustPos(t, "G7"), Vertical, nil)
+	if _, err := g.PlayMove(move); err != nil {
+		t.Fatalf("PlayMove failed: %v", err)
+	}
+	if len(g.Feedback("p1")) != 1 {
+		t.Errorf("Disabling coaching should stop new feedback but keep the old")
+	}
+}
+
+// TestMoveAccuracy tests the per-move accuracy scale
+func TestMoveAccuracy(t *testing.T) {
+	tests := []struct {
+		played, best, want float64
+	}{
+		{30, 30, 100},
+		{35, 30, 100},
+		{15, 30, 50},
+		{-5, 30, 0},
+		{-10, -5, 0},
+	}
+	for _, tt := range tests {
+		if got := moveAccuracy(tt.played, tt.best); got != tt.want {
+			t.Errorf("moveAccuracy(%v, %v) = %v, want %v", tt.played, tt.best, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package game
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// directionWords are the words that may follow coordinates to give a play's
+// direction outright
+var directionWords = map[string]Direction{
+	"ACROSS":     Horizontal,
+	"HORIZONTAL": Horizontal,
+	"DOWN":       Vertical,
+	"VERTICAL":   Vertical,
+}
+
+// ParseCoordinates reads the start square and direction of a play
+// In standard notation the order gives the direction: the row first ("8H")
+// for a play across and the column first ("H8") for a play down. Entries are
+// read leniently for typed and spoken input: case is ignored, the row and
+// column may be separated by a space, hyphen or comma, and a trailing
+// "across" or "down" overrides the order, so "h-8 across" is 8H.
+func ParseCoordinates(s string) (Position, Direction, error) {
+	fields := strings.FieldsFunc(strings.ToUpper(s), func(r rune) bool {
+		return unicode.IsSpace(r) || r == '-' || r == ','
+	})
+
+	var dir Direction
+	explicit := false
+	if n := len(fields); n > 1 {
+		if d, ok := directionWords[fields[n-1]]; ok {
+			dir, explicit = d, true
+			fields = fields[:n-1]
+		}
+	}
+
+	coords := strings.Join(fields, "")
+	if coords == "" {
+		return Position{}, Horizontal, fmt.Errorf("%w: missing coordinates", ErrInvalidPosition)
+	}
+	rowFirst := unicode.IsDigit(rune(coords[0]))
+	rowPart, colPart := coords[1:], coords[:1]
+	if rowFirst {
+		rowPart, colPart = coords[:len(coords)-1], coords[len(coords)-1:]
+	}
+	row, err := strconv.Atoi(rowPart)
+	pos := Position{Row: row - 1, Col: int(colPart[0]) - 'A'}
+	if err != nil || !pos.IsValid() {
+		return Position{}, Horizontal, fmt.Errorf("%w: %q", ErrInvalidPosition, s)
+	}
+
+	if !explicit {
+		dir = Vertical
+		if rowFirst {
+			dir = Horizontal
+		}
+	}
+	return pos, dir, nil
+}
+
+// FormatCoordinates writes a play's start square in standard notation: the
+// row first for a play across ("8D") and the column first for a play down
+// ("D8"), so ParseCoordinates reads back both square and direction
+func FormatCoordinates(pos Position, dir Direction) string {
+	if dir == Vertical {
+		return fmt.Sprintf("%c%d", 'A'+pos.Col, pos.Row+1)
+	}
+	return fmt.Sprintf("%d%c", pos.Row+1, 'A'+pos.Col)
+}
+
+// Coordinates returns where a placement starts in standard notation, or ""
+// for other turns
+func (m Move) Coordinates() string {
+	if m.Type != PlaceTiles {
+		return ""
+	}
+	return FormatCoordinates(m.Start, m.Direction)
+}
+
+// Notation writes a placement as its coordinates and the word it forms, with
+// blanks in lower case ("8D CaT"), or returns "" for other turns
+func (m Move) Notation() string {
+	if m.Type != PlaceTiles {
+		return ""
+	}
+
+	blanks := m.BlankAssignments()
+	step := m.Direction.step()
+	var sb strings.Builder
+	sb.WriteString(m.Coordinates() + " ")
+	pos := m.Start
+	for _, r := range m.Word {
+		if _, ok := blanks[pos]; ok {
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+		pos = Position{Row: pos.Row + step.Row, Col: pos.Col + step.Col}
+	}
+	return sb.String()
+}
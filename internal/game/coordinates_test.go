@@ -0,0 +1,92 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestParseCoordinates tests reading coordinates in standard and lenient forms
+func TestParseCoordinates(t *testing.T) {
+	tests := []struct {
+		in  string
+		pos string
+		dir Direction
+	}{
+		{"8H", "H8", Horizontal},
+		{"H8", "H8", Vertical},
+		{"15o", "O15", Horizontal},
+		{"a10", "A10", Vertical},
+		{" h-8 ", "H8", Vertical},
+		{"8, D", "D8", Horizontal},
+		{"H 8 across", "H8", Horizontal},
+		{"8h Down", "H8", Vertical},
+		{"D-8 horizontal", "D8", Horizontal},
+	}
+	for _, tt := range tests {
+		pos, dir, err := ParseCoordinates(tt.in)
+		if err != nil {
+			t.Errorf("ParseCoordinates(%q) failed: %v", tt.in, err)
+			continue
+		}
+		if pos.String() != tt.pos || dir != tt.dir {
+			t.Errorf("ParseCoordinates(%q) = %v %v, want %s %v", tt.in, pos, dir, tt.pos, tt.dir)
+		}
+	}
+
+	for _, in := range []string{"", "across", "H", "8", "P8", "H16", "H0", "8HH", "HH8", "H8 sideways"} {
+		if _, _, err := ParseCoordinates(in); !errors.Is(err, ErrInvalidPosition) {
+			t.Errorf("ParseCoordinates(%q) should fail with ErrInvalidPosition, got %v", in, err)
+		}
+	}
+}
+
+// TestFormatCoordinates tests writing coordinates in standard notation and
+// reading them back
+func TestFormatCoordinates(t *testing.T) {
+	for _, tt := range []struct {
+		pos  Position
+		dir  Direction
+		want string
+	}{
+		{Position{Row: 7, Col: 3}, Horizontal, "8D"},
+		{Position{Row: 7, Col: 3}, Vertical, "D8"},
+		{Position{Row: 14, Col: 14}, Horizontal, "15O"},
+		{Position{Row: 0, Col: 0}, Vertical, "A1"},
+	} {
+		got := FormatCoordinates(tt.pos, tt.dir)
+		if got != tt.want {
+			t.Errorf("FormatCoordinates(%v, %v) = %q, want %q", tt.pos, tt.dir, got, tt.want)
+		}
+		if pos, dir, err := ParseCoordinates(got); err != nil || pos != tt.pos || dir != tt.dir {
+			t.Errorf("ParseCoordinates(%q) = %v %v, %v; want %v %v", got, pos, dir, err, tt.pos, tt.dir)
+		}
+	}
+
+	play := Move{Type: PlaceTiles, Start: Position{Row: 6, Col: 9}, Direction: Vertical}
+	if got := play.Coordinates(); got != "J7" {
+		t.Errorf("Expected a play down from J7 to read J7, got %q", got)
+	}
+	if got := (Move{Type: Pass}).Coordinates(); got != "" {
+		t.Errorf("Expected no coordinates for a pass, got %q", got)
+	}
+}
+
+// TestMoveNotation tests writing a placement's coordinates and word
+func TestMoveNotation(t *testing.T) {
+	b := NewBoard()
+	across, err := b.BuildMove("p1", "CAT", mustPos(t, "D8"), Horizontal, []int{1})
+	if err != nil {
+		t.Fatalf("BuildMove failed: %v", err)
+	}
+	if got := across.Notation(); got != "8D CaT" {
+		t.Errorf("Expected 8D CaT with the blank in lower case, got %q", got)
+	}
+
+	down, _ := b.BuildMove("p1", "CAT", mustPos(t, "H7"), Vertical, nil)
+	if got := down.Notation(); got != "H7 CAT" {
+		t.Errorf("Expected H7 CAT, got %q", got)
+	}
+	if got := (Move{Type: Exchange}).Notation(); got != "" {
+		t.Errorf("Expected no notation for an exchange, got %q", got)
+	}
+}
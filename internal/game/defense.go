@@ -0,0 +1,138 @@
+package game
+
+import "sort"
+
+// Openness measures how much a board offers the player to move beyond the
+// squares next to the last play
+type Openness struct {
+	TripleLanes int `json:"triple_lanes"` // Lanes between two empty triple word squares that a play can reach
+	Hotspots    int `json:"hotspots"`     // Empty premium squares next to tiles, weighted by premiumWeight
+}
+
+// MeasureOpenness returns the board's openness
+// A lane runs along a row or column between two triple word squares; it can
+// be reached when a tile lies in it or alongside it, so that a play through
+// or hooking onto that tile could cover both triples.
+func MeasureOpenness(b *Board) Openness {
+	return measureOpenness(b, nil)
+}
+
+// measureOpenness returns the openness of the board with the extra positions
+// treated as occupied
+func measureOpenness(b *Board, extra map[Position]bool) Openness {
+	occupied := func(pos Position) bool {
+		return b.IsValidPosition(pos) && (extra[pos] || !b.IsEmpty(pos))
+	}
+
+	var o Openness
+	for row := 0; row < 15; row++ {
+		for col := 0; col < 15; col++ {
+			pos := Position{Row: row, Col: col}
+			weight := premiumWeight(b.GetPremiumType(pos))
+			if weight == 0 || occupied(pos) {
+				continue
+			}
+			for _, adj := range b.GetAdjacentPositions(pos) {
+				if occupied(adj) {
+					o.Hotspots += weight
+					break
+				}
+			}
+		}
+	}
+
+	// Lanes along rows, then along columns, with at and across mapping a
+	// distance along the lane and an offset across it to a position
+	for _, line := range []func(lane, at, across int) Position{
+		func(lane, at, across int) Position { return Position{Row: lane + across, Col: at} },
+		func(lane, at, across int) Position { return Position{Row: at, Col: lane + across} },
+	} {
+		for lane := 0; lane < 15; lane++ {
+			last := -1
+			for at := 0; at < 15; at++ {
+				if b.GetPremiumType(line(lane, at, 0)) != TripleWordScore {
+					continue
+				}
+				if last >= 0 && !occupied(line(lane, last, 0)) && !occupied(line(lane, at, 0)) && laneReachable(occupied, line, lane, last, at) {
+					o.TripleLanes++
+				}
+				last = at
+			}
+		}
+	}
+	return o
+}
+
+// laneReachable returns true if a tile lies in the lane from one distance to
+// another or alongside it
+func laneReachable(occupied func(Position) bool, line func(lane, at, across int) Position, lane, from, to int) bool {
+	for at := from; at <= to; at++ {
+		for across := -1; across <= 1; across++ {
+			if occupied(line(lane, at, across)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// premiumWeight rates how much a premium square offers an opponent: 1 for a
+// double letter, 2 for a triple letter or double word and 3 for a triple word
+func premiumWeight(pt PremiumType) int {
+	switch pt {
+	case DoubleLetterScore:
+		return 1
+	case TripleLetterScore, DoubleWordScore:
+		return 2
+	case TripleWordScore:
+		return 3
+	}
+	return 0
+}
+
+// DefenseEvaluator penalizes moves for the scoring chances they open to the
+// opponent, so that an equity bot avoids setting up triple-triples and
+// premium hotspots
+type DefenseEvaluator struct {
+	LaneWeight    float64 // Penalty for each triple word lane a move opens
+	HotspotWeight float64 // Penalty for each point of hotspot weight a move opens
+}
+
+// NewDefenseEvaluator creates an evaluator with the default weights
+func NewDefenseEvaluator() *DefenseEvaluator {
+	return &DefenseEvaluator{LaneWeight: 10, HotspotWeight: 1}
+}
+
+// Penalty returns the penalty for a board's openness
+func (de *DefenseEvaluator) Penalty(o Openness) float64 {
+	return de.LaneWeight*float64(o.TripleLanes) + de.HotspotWeight*float64(o.Hotspots)
+}
+
+// EvaluateMove returns how much a move would raise the board's penalty,
+// negative if it closes more than it opens
+func (de *DefenseEvaluator) EvaluateMove(b *Board, move Move) float64 {
+	return de.Penalty(measureOpenness(b, placedPositions(move))) - de.Penalty(measureOpenness(b, nil))
+}
+
+// Rerank charges each ranked move for the board it opens and orders the
+// moves by the adjusted equity, highest first
+func (de *DefenseEvaluator) Rerank(b *Board, ranked []RankedMove) []RankedMove {
+	before := de.Penalty(measureOpenness(b, nil))
+	for i := range ranked {
+		ranked[i].Defense = de.Penalty(measureOpenness(b, placedPositions(ranked[i].Move))) - before
+		ranked[i].Equity -= ranked[i].Defense
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Equity > ranked[j].Equity
+	})
+	return ranked
+}
+
+// placedPositions returns the set of squares a move places tiles on
+func placedPositions(move Move) map[Position]bool {
+	placed := make(map[Position]bool, len(move.Tiles))
+	for _, t := range move.Tiles {
+		placed[t.Position] = true
+	}
+	return placed
+}
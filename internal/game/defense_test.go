@@ -0,0 +1,67 @@
+package game
+
+import "testing"
+
+// movePlacing returns a move placing A tiles at the given positions
+func movePlacing(positions ...Position) Move {
+	var m Move
+	for _, pos := range positions {
+		m.Tiles = append(m.Tiles, PlacedTile{Tile: Tile{Letter: 'A', Points: 1}, Position: pos})
+	}
+	return m
+}
+
+// TestMeasureOpenness tests counting reachable triple lanes and hotspots
+func TestMeasureOpenness(t *testing.T) {
+	board := NewBoard()
+	if o := MeasureOpenness(board); o != (Openness{}) {
+		t.Errorf("An empty board should be closed, got %+v", o)
+	}
+
+	// The centre tile reaches row 8 and column H, which each join two triples
+	board.PlaceTile(Tile{Letter: 'A', Points: 1}, Position{Row: 7, Col: 7})
+	if o := MeasureOpenness(board); o != (Openness{TripleLanes: 2}) {
+		t.Errorf("Expected the two centre lanes open, got %+v", o)
+	}
+
+	// A tile alongside the top row reaches its lane from A1 to H1, and sits
+	// next to the double words at B2 and C3
+	board.PlaceTile(Tile{Letter: 'A', Points: 1}, Position{Row: 1, Col: 2})
+	if o := MeasureOpenness(board); o != (Openness{TripleLanes: 3, Hotspots: 4}) {
+		t.Errorf("Expected three lanes and two double word hotspots, got %+v", o)
+	}
+
+	// Covering a triple closes its lanes
+	board.PlaceTile(Tile{Letter: 'A', Points: 1}, Position{Row: 0, Col: 0})
+	if o := MeasureOpenness(board); o.TripleLanes != 2 {
+		t.Errorf("Expected the top lane closed, got %+v", o)
+	}
+}
+
+// TestDefenseEvaluator tests charging moves for the board they open
+func TestDefenseEvaluator(t *testing.T) {
+	board := NewBoard()
+	board.PlaceTile(Tile{Letter: 'A', Points: 1}, Position{Row: 7, Col: 7})
+	de := NewDefenseEvaluator()
+
+	opener := movePlacing(Position{Row: 1, Col: 7}, Position{Row: 2, Col: 7})
+	quiet := movePlacing(Position{Row: 7, Col: 8})
+	// The quiet move only opens the double letters at I7 and I9
+	if got := de.EvaluateMove(board, quiet); got != 2*de.HotspotWeight {
+		t.Errorf("A quiet move should open two double letters, got %v", got)
+	}
+	if got := de.EvaluateMove(board, opener); got < de.LaneWeight {
+		t.Errorf("Reaching the top row should cost at least a lane, got %v", got)
+	}
+
+	ranked := de.Rerank(board, []RankedMove{{Move: opener, Equity: 20}, {Move: quiet, Equity: 15}})
+	if len(ranked[0].Move.Tiles) != 1 || ranked[0].Equity != 15-ranked[0].Defense || ranked[1].Equity != 20-ranked[1].Defense {
+		t.Errorf("The quiet move should now rank first, got %+v", ranked)
+	}
+
+	// Closing lanes earns equity back
+	closer := movePlacing(Position{Row: 7, Col: 14})
+	if got := de.EvaluateMove(board, closer); got >= 0 {
+		t.Errorf("Covering a triple should lower the penalty, got %v", got)
+	}
+}
@@ -0,0 +1,41 @@
+package game
+
+import (
+	"fmt"
+
+	"scrabbled/internal/dictionary"
+)
+
+// WordDefinition pairs a word formed by a move with its definition
+type WordDefinition struct {
+	Word       string `json:"word"`
+	Definition string `json:"definition,omitempty"` // Empty if the dictionary has no definition for the word
+}
+
+// Definitions returns the words formed by the move at the given turn with
+// their definitions from the attached dictionary
+// Words are given in the order they were formed, main word first. Moves that
+// placed no tiles form no words.
+func (g *Game) Definitions(turn int) ([]WordDefinition, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if g.Dictionary == nil {
+		return nil, ErrNoDictionary
+	}
+	if turn < 0 || turn >= len(g.History) {
+		return nil, fmt.Errorf("turn %d is out of range", turn)
+	}
+
+	definer, _ := g.Dictionary.(dictionary.Definer)
+	move := g.History[turn]
+	result := make([]WordDefinition, 0, len(move.Words))
+	for _, word := range move.Words {
+		wd := WordDefinition{Word: word}
+		if definer != nil {
+			wd.Definition, _ = definer.Definition(word)
+		}
+		result = append(result, wd)
+	}
+	return result, nil
+}
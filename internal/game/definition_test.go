@@ -0,0 +1,44 @@
+package game
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"scrabbled/internal/dictionary"
+)
+
+// TestDefinitions tests looking up the words formed by a move
+func TestDefinitions(t *testing.T) {
+	dict := dictionary.NewWordList(dictionary.Collins)
+	dict.LoadFromReader(strings.NewReader("CAT a small domesticated feline\nCATS\nAT\n"))
+
+	g := newTestGame(t, 2)
+	g.StartGame()
+	g.Players[0].Rack = rackOf("CATXYZQ")
+	move, _ := g.Board.BuildMove("p1", "CAT", mustPos(t, "G8"), Horizontal, nil)
+	if _, err := g.PlayMove(move); err != nil {
+		t.Fatalf("Setup move failed: %v", err)
+	}
+
+	if _, err := g.Definitions(0); !errors.Is(err, ErrNoDictionary) {
+		t.Errorf("Definitions without a dictionary should return ErrNoDictionary, got %v", err)
+	}
+
+	g.SetDictionary(dict)
+	defs, err := g.Definitions(0)
+	if err != nil {
+		t.Fatalf("Definitions failed: %v", err)
+	}
+	if len(defs) != 1 || defs[0].Word != "CAT" || defs[0].Definition != "a small domesticated feline" {
+		t.Errorf("Unexpected definitions: %+v", defs)
+	}
+
+	g.PassTurn("p2")
+	if defs, err := g.Definitions(1); err != nil || len(defs) != 0 {
+		t.Errorf("A pass should form no words, got %+v, %v", defs, err)
+	}
+	if _, err := g.Definitions(2); err == nil {
+		t.Errorf("Turns beyond the history should fail")
+	}
+}
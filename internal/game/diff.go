@@ -0,0 +1,117 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrStaleDiff is returned when a diff is applied to a board it was not taken from
+var ErrStaleDiff = errors.New("diff does not apply to the board")
+
+// DeltaKind tells whether a placement delta adds or removes a tile
+type DeltaKind int
+
+const (
+	TileAdded   DeltaKind = iota // A tile appears on an empty square
+	TileRemoved                  // A tile is lifted off its square
+)
+
+// String returns a string representation of the delta kind
+func (k DeltaKind) String() string {
+	switch k {
+	case TileAdded:
+		return "TILE_ADDED"
+	case TileRemoved:
+		return "TILE_REMOVED"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// PlacementDelta is one tile added to or removed from a board
+type PlacementDelta struct {
+	Kind     DeltaKind `json:"kind"`
+	Position Position  `json:"position"`
+	Tile     Tile      `json:"tile"`
+	Turn     int       `json:"turn,omitempty"`      // Placement of an added tile, as on Square
+	PlayerID string    `json:"player_id,omitempty"` // Player who placed an added tile, if known
+}
+
+// Diff returns the deltas that turn the board into other: removals first,
+// then additions, each in board order
+// A square whose tile or placement differs is removed and added again, so
+// applying the deltas in order with ApplyDiff reproduces other exactly.
+func (b *Board) Diff(other *Board) []PlacementDelta {
+	var removed, added []PlacementDelta
+	for row := 0; row < 15; row++ {
+		if b.occupied[row] == 0 && other.occupied[row] == 0 {
+			continue
+		}
+		for col := 0; col < 15; col++ {
+			from, to := &b.Grid[row][col], &other.Grid[row][col]
+			if sameSquare(from, to) {
+				continue
+			}
+			pos := Position{Row: row, Col: col}
+			if !from.IsEmpty() {
+				removed = append(removed, PlacementDelta{Kind: TileRemoved, Position: pos, Tile: *from.Tile, Turn: from.Turn, PlayerID: from.PlayerID})
+			}
+			if !to.IsEmpty() {
+				added = append(added, PlacementDelta{Kind: TileAdded, Position: pos, Tile: *to.Tile, Turn: to.Turn, PlayerID: to.PlayerID})
+			}
+		}
+	}
+	return append(removed, added...)
+}
+
+// ApplyDiff applies deltas taken with Diff, in order
+// Removals must name the tile on the square and additions an empty square,
+// otherwise ErrStaleDiff is returned and the board is left unchanged. The
+// board takes fresh tile storage, so tiles taken earlier with GetTile keep
+// their values.
+func (b *Board) ApplyDiff(deltas []PlacementDelta) error {
+	cp := b.Clone()
+	for _, d := range deltas {
+		if err := cp.applyDelta(d); err != nil {
+			return err
+		}
+	}
+	*b = *cp
+	return nil
+}
+
+// applyDelta applies one delta
+func (b *Board) applyDelta(d PlacementDelta) error {
+	sq := b.GetSquare(d.Position)
+	if sq == nil {
+		return fmt.Errorf("%w: %s", ErrInvalidPosition, d.Position)
+	}
+
+	switch d.Kind {
+	case TileRemoved:
+		if sq.IsEmpty() || *sq.Tile != d.Tile {
+			return fmt.Errorf("%w: no %c to remove at %s", ErrStaleDiff, d.Tile.Letter, d.Position)
+		}
+		_, err := b.RemoveTile(d.Position)
+		return err
+	case TileAdded:
+		if !sq.IsEmpty() {
+			return fmt.Errorf("%w: %s is already occupied", ErrStaleDiff, d.Position)
+		}
+		if err := b.PlaceTile(d.Tile, d.Position); err != nil {
+			return err
+		}
+		sq.Turn, sq.PlayerID = d.Turn, d.PlayerID
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown delta kind %d", ErrStaleDiff, d.Kind)
+	}
+}
+
+// sameSquare returns true if two squares hold the same tile with the same placement
+func sameSquare(a, b *Square) bool {
+	if a.IsEmpty() || b.IsEmpty() {
+		return a.IsEmpty() == b.IsEmpty()
+	}
+	return *a.Tile == *b.Tile && a.Turn == b.Turn && a.PlayerID == b.PlayerID
+}
@@ -0,0 +1,113 @@
+package game
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestBoardDiff tests the deltas between two board states
+func TestBoardDiff(t *testing.T) {
+	g := newTestGame(t, 2)
+	g.StartGame()
+	g.Players[0].Rack = rackOf("CATXYZQ")
+	before := g.Board.Clone()
+	move, _ := g.Board.BuildMove("p1", "CAT", mustPos(t, "G8"), Horizontal, nil)
+	if _, err := g.PlayMove(move); err != nil {
+		t.Fatalf("CAT should be playable: %v", err)
+	}
+
+	deltas := before.Diff(g.Board)
+	if len(deltas) != 3 {
+		t.Fatalf("Expected 3 tiles added, got %+v", deltas)
+	}
+	for i, d := range deltas {
+		if d.Kind != TileAdded || d.Position != move.Tiles[i].Position || d.Tile != move.Tiles[i].Tile || d.PlayerID != "p1" {
+			t.Errorf("Unexpected delta %+v", d)
+		}
+	}
+	if len(g.Board.Diff(g.Board.Clone())) != 0 {
+		t.Errorf("A board should not differ from its clone")
+	}
+
+	// The reverse removes the tiles
+	back := g.Board.Diff(before)
+	if len(back) != 3 || back[0].Kind != TileRemoved {
+		t.Errorf("Expected 3 tiles removed, got %+v", back)
+	}
+
+	// Applying the deltas reproduces the board, placements included
+	if err := before.ApplyDiff(deltas); err != nil {
+		t.Fatalf("ApplyDiff failed: %v", err)
+	}
+	if !reflect.DeepEqual(before.Grid, g.Board.Grid) || before.Hash() != g.Board.Hash() {
+		t.Errorf("Applying the diff should reproduce the board")
+	}
+}
+
+// TestBoardDiffReplaced tests a square whose tile changed
+func TestBoardDiffReplaced(t *testing.T) {
+	a, b := NewBoard(), NewBoard()
+	a.PlaceTile(Tile{Letter: 'A', Points: 1}, mustPos(t, "H8"))
+	b.PlaceTile(Tile{Letter: 'A', IsBlank: true}, mustPos(t, "H8"))
+
+	deltas := a.Diff(b)
+	if len(deltas) != 2 || deltas[0].Kind != TileRemoved || deltas[1].Kind != TileAdded || !deltas[1].Tile.IsBlank {
+		t.Fatalf("Expected the tile removed then the blank added, got %+v", deltas)
+	}
+	if err := a.ApplyDiff(deltas); err != nil {
+		t.Fatalf("ApplyDiff failed: %v", err)
+	}
+	if !a.GetTile(mustPos(t, "H8")).IsBlank {
+		t.Errorf("H8 should hold the blank")
+	}
+}
+
+// TestApplyDiffKeepsTiles tests that tiles taken from the board before a diff
+// is applied are not rewritten
+func TestApplyDiffKeepsTiles(t *testing.T) {
+	b := NewBoard()
+	b.PlaceTile(Tile{Letter: 'C', Points: 3}, mustPos(t, "G8"))
+	b.PlaceTile(Tile{Letter: 'A', Points: 1}, mustPos(t, "H8"))
+	b = b.Clone() // Packs the tiles into one array, as restored boards are
+	c := b.GetTile(mustPos(t, "G8"))
+
+	removeC := []PlacementDelta{{Kind: TileRemoved, Position: mustPos(t, "G8"), Tile: Tile{Letter: 'C', Points: 3}}}
+	if err := b.ApplyDiff(removeC); err != nil {
+		t.Fatalf("ApplyDiff failed: %v", err)
+	}
+	if c.Letter != 'C' {
+		t.Errorf("A tile taken before the diff should still be C, got %c", c.Letter)
+	}
+	if b.HasTileAt(mustPos(t, "G8")) || b.GetTile(mustPos(t, "H8")).Letter != 'A' {
+		t.Errorf("Only C should be removed")
+	}
+}
+
+// TestApplyDiffStale tests rejecting deltas taken from another board
+func TestApplyDiffStale(t *testing.T) {
+	b := NewBoard()
+	b.PlaceTile(Tile{Letter: 'A', Points: 1}, mustPos(t, "H8"))
+
+	testCases := map[string][]PlacementDelta{
+		"wrong tile removed": {{Kind: TileRemoved, Position: mustPos(t, "H8"), Tile: Tile{Letter: 'B', Points: 3}}},
+		"empty removed":      {{Kind: TileRemoved, Position: mustPos(t, "A1"), Tile: Tile{Letter: 'A', Points: 1}}},
+		"occupied added": {
+			{Kind: TileAdded, Position: mustPos(t, "A1"), Tile: Tile{Letter: 'B', Points: 3}},
+			{Kind: TileAdded, Position: mustPos(t, "H8"), Tile: Tile{Letter: 'B', Points: 3}},
+		},
+	}
+	for name, deltas := range testCases {
+		err := b.ApplyDiff(deltas)
+		if !errors.Is(err, ErrStaleDiff) || Code(err) != CodeConflict {
+			t.Errorf("%s: Expected ErrStaleDiff, got %v", name, err)
+		}
+		if len(b.GetOccupiedPositions()) != 1 || b.GetTile(mustPos(t, "H8")).Letter != 'A' {
+			t.Errorf("%s: A failed diff should leave the board unchanged", name)
+		}
+	}
+
+	if TileAdded.String() != "TILE_ADDED" || TileRemoved.String() != "TILE_REMOVED" || DeltaKind(9).String() != "UNKNOWN" {
+		t.Errorf("Unexpected delta kind strings")
+	}
+}
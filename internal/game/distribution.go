@@ -0,0 +1,123 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MinTileBagSize is the smallest tile bag that can deal a full rack to two players
+const MinTileBagSize = 2 * MaxRackSize
+
+// LetterConfig gives the number of copies and point value of one letter
+type LetterConfig struct {
+	Quantity int `json:"quantity"`
+	Points   int `json:"points"`
+}
+
+// Distribution is a user-supplied tile distribution for house rules,
+// teaching sets and experimental variants
+type Distribution map[rune]LetterConfig
+
+// Validate checks that the distribution describes a usable set of tiles
+// Every key must be a letter with a positive quantity and non-negative points,
+// and together with the blanks there must be at least MinTileBagSize tiles.
+func (d Distribution) Validate(blanks int) error {
+	if len(d) == 0 {
+		return errors.New("distribution has no letters")
+	}
+	if blanks < 0 {
+		return fmt.Errorf("invalid blank count %d", blanks)
+	}
+
+	total := blanks
+	for letter, cfg := range d {
+		if !unicode.IsLetter(letter) {
+			return fmt.Errorf("%q is not a letter", letter)
+		}
+		if unicode.ToUpper(letter) != letter {
+			return fmt.Errorf("letter %q must be upper case", letter)
+		}
+		if cfg.Quantity <= 0 {
+			return fmt.Errorf("letter %c: quantity must be positive, got %d", letter, cfg.Quantity)
+		}
+		if cfg.Points < 0 {
+			return fmt.Errorf("letter %c: points must not be negative, got %d", letter, cfg.Points)
+		}
+		total += cfg.Quantity
+	}
+
+	if total < MinTileBagSize {
+		return fmt.Errorf("distribution has %d tiles, minimum is %d", total, MinTileBagSize)
+	}
+	return nil
+}
+
+// NewTileBagFromDistribution creates a shuffled tile bag from a custom distribution
+// Returns an error if the distribution is invalid.
+func NewTileBagFromDistribution(d Distribution, blanks int) (*TileBag, error) {
+	if err := d.Validate(blanks); err != nil {
+		return nil, err
+	}
+	return newShuffledTileBag(d.letters(), blanks, nil), nil
+}
+
+// TileSet converts the distribution to a tile set, so a game can be played
+// with its point values via Game.SetTileSet
+func (d Distribution) TileSet(name string, blanks int) (*TileSet, error) {
+	if err := d.Validate(blanks); err != nil {
+		return nil, err
+	}
+
+	letters := make([]rune, 0, len(d))
+	for letter := range d {
+		letters = append(letters, letter)
+	}
+	sort.Slice(letters, func(i, j int) bool { return letters[i] < letters[j] })
+
+	specs := make([]TileSpec, len(letters))
+	for i, letter := range letters {
+		specs[i] = TileSpec{Letter: string(letter), Quantity: d[letter].Quantity, Points: d[letter].Points}
+	}
+	return NewTileSet(name, blanks, specs)
+}
+
+// MarshalJSON encodes the distribution keyed by letter, e.g. {"A": {"quantity": 9, "points": 1}}
+func (d Distribution) MarshalJSON() ([]byte, error) {
+	keyed := make(map[string]LetterConfig, len(d))
+	for letter, cfg := range d {
+		keyed[string(letter)] = cfg
+	}
+	return json.Marshal(keyed)
+}
+
+// UnmarshalJSON decodes a distribution keyed by single letters
+func (d *Distribution) UnmarshalJSON(data []byte) error {
+	var keyed map[string]LetterConfig
+	if err := json.Unmarshal(data, &keyed); err != nil {
+		return err
+	}
+
+	dist := make(Distribution, len(keyed))
+	for key, cfg := range keyed {
+		letter, size := utf8.DecodeRuneInString(key)
+		if size == 0 || size != len(key) {
+			return fmt.Errorf("distribution key %q must be a single letter", key)
+		}
+		dist[letter] = cfg
+	}
+	*d = dist
+	return nil
+}
+
+// letters converts the distribution to the internal representation
+func (d Distribution) letters() map[rune]letterSpec {
+	letters := make(map[rune]letterSpec, len(d))
+	for letter, cfg := range d {
+		letters[letter] = letterSpec{quantity: cfg.Quantity, points: cfg.Points}
+	}
+	return letters
+}
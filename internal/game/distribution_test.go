@@ -0,0 +1,117 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// teachingDistribution returns a small valid distribution for tests
+func teachingDistribution() Distribution {
+	return Distribution{
+		'A': {Quantity: 6, Points: 1},
+		'E': {Quantity: 6, Points: 1},
+		'T': {Quantity: 4, Points: 2},
+		'Z': {Quantity: 1, Points: 20},
+	}
+}
+
+// TestDistributionValidate tests validation of custom distributions
+func TestDistributionValidate(t *testing.T) {
+	if err := teachingDistribution().Validate(1); err != nil {
+		t.Errorf("Teaching distribution should be valid: %v", err)
+	}
+
+	testCases := map[string]struct {
+		dist   Distribution
+		blanks int
+	}{
+		"empty":          {Distribution{}, 2},
+		"negative blank": {teachingDistribution(), -1},
+		"not a letter":   {Distribution{'1': {Quantity: 20, Points: 1}}, 0},
+		"lower case":     {Distribution{'a': {Quantity: 20, Points: 1}}, 0},
+		"zero quantity":  {Distribution{'A': {Quantity: 20, Points: 1}, 'B': {Quantity: 0, Points: 1}}, 0},
+		"negative value": {Distribution{'A': {Quantity: 20, Points: -1}}, 0},
+		"too few tiles":  {Distribution{'A': {Quantity: 10, Points: 1}}, 2},
+	}
+	for name, tc := range testCases {
+		if err := tc.dist.Validate(tc.blanks); err == nil {
+			t.Errorf("%s: Validate should fail", name)
+		}
+		if _, err := NewTileBagFromDistribution(tc.dist, tc.blanks); err == nil {
+			t.Errorf("%s: NewTileBagFromDistribution should fail", name)
+		}
+	}
+}
+
+// TestNewTileBagFromDistribution tests filling a bag from a custom distribution
+func TestNewTileBagFromDistribution(t *testing.T) {
+	bag, err := NewTileBagFromDistribution(teachingDistribution(), 1)
+	if err != nil {
+		t.Fatalf("NewTileBagFromDistribution failed: %v", err)
+	}
+	if bag.RemainingCount() != 18 {
+		t.Errorf("Expected 18 tiles, got %d", bag.RemainingCount())
+	}
+
+	counts := make(map[rune]int)
+	for _, tile := range bag.Tiles() {
+		if tile.IsBlank {
+			counts[0]++
+			continue
+		}
+		counts[tile.Letter]++
+		if tile.Letter == 'Z' && tile.Points != 20 {
+			t.Errorf("Z should be worth 20, got %d", tile.Points)
+		}
+	}
+	if counts['A'] != 6 || counts['Z'] != 1 || counts[0] != 1 {
+		t.Errorf("Unexpected tile counts: %v", counts)
+	}
+}
+
+// TestDistributionJSON tests reading a distribution from configuration
+func TestDistributionJSON(t *testing.T) {
+	var dist Distribution
+	config := `{"A": {"quantity": 8, "points": 1}, "Q": {"quantity": 8, "points": 3}}`
+	if err := json.Unmarshal([]byte(config), &dist); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if dist['Q'].Points != 3 || dist['A'].Quantity != 8 {
+		t.Errorf("Unexpected distribution: %v", dist)
+	}
+
+	data, err := json.Marshal(dist)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var again Distribution
+	json.Unmarshal(data, &again)
+	if len(again) != 2 || again['Q'] != dist['Q'] {
+		t.Errorf("Distribution should survive a JSON round trip, got %s", data)
+	}
+
+	if err := json.Unmarshal([]byte(`{"AB": {"quantity": 1}}`), &dist); err == nil {
+		t.Errorf("Multi-letter keys should be rejected")
+	}
+}
+
+// TestGameWithDistribution tests playing with custom point values
+func TestGameWithDistribution(t *testing.T) {
+	ts, err := teachingDistribution().TileSet("Teaching", 1)
+	if err != nil {
+		t.Fatalf("TileSet failed: %v", err)
+	}
+
+	g := newTestGame(t, 2)
+	if err := g.SetTileSet(ts); err != nil {
+		t.Fatalf("SetTileSet failed: %v", err)
+	}
+	g.StartGame()
+
+	// ZA on the center double word: (20 + 1) * 2
+	g.Players[0].Rack = []Tile{{Letter: 'Z', Points: 20}, {Letter: 'A', Points: 1}}
+	move, _ := g.Board.BuildMove("p1", "ZA", g.Board.Center, Horizontal, nil)
+	if score, err := g.PlayMove(move); err != nil || score != 42 {
+		t.Errorf("Expected score 42, got %d (%v)", score, err)
+	}
+}
@@ -0,0 +1,127 @@
+package game
+
+import (
+	"errors"
+
+	"scrabbled/internal/dictionary"
+)
+
+// ErrorCode classifies the errors returned by games so that callers can
+// branch on them, for example to choose an HTTP status code
+type ErrorCode int
+
+const (
+	CodeUnknown      ErrorCode = iota // Not a game error
+	CodeInvalidMove                   // The move or exchange breaks the rules
+	CodeNotYourTurn                   // Another player is to move
+	CodeConflict                      // The game is not in a state that allows the action
+	CodeNotFound                      // A game, player or dictionary does not exist
+	CodeInvalidInput                  // Notation or configuration could not be parsed
+	CodeUnavailable                   // The game lacks a dictionary, clock or move generator
+)
+
+// String returns a string representation of the error code
+func (c ErrorCode) String() string {
+	switch c {
+	case CodeInvalidMove:
+		return "INVALID_MOVE"
+	case CodeNotYourTurn:
+		return "NOT_YOUR_TURN"
+	case CodeConflict:
+		return "CONFLICT"
+	case CodeNotFound:
+		return "NOT_FOUND"
+	case CodeInvalidInput:
+		return "INVALID_INPUT"
+	case CodeUnavailable:
+		return "UNAVAILABLE"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// errorCodes maps each sentinel error to its code
+var errorCodes = []struct {
+	err  error
+	code ErrorCode
+}{
+	{ErrNoTilesPlaced, CodeInvalidMove},
+	{ErrTilesNotInLine, CodeInvalidMove},
+	{ErrTilesNotContiguous, CodeInvalidMove},
+	{ErrFirstMoveNotCenter, CodeInvalidMove},
+	{ErrFirstMoveTooShort, CodeInvalidMove},
+	{ErrNotConnected, CodeInvalidMove},
+	{ErrTilesNotInRack, CodeInvalidMove},
+	{ErrWordMismatch, CodeInvalidMove},
+	{ErrDuplicatePosition, CodeInvalidMove},
+	{ErrUnassignedBlank, CodeInvalidMove},
+	{ErrInvalidPosition, CodeInvalidMove},
+	{ErrPositionOccupied, CodeInvalidMove},
+	{ErrNoTileAtPosition, CodeInvalidMove},
+	{ErrInvalidWord, CodeInvalidMove},
+	{ErrExchangeNotAllowed, CodeInvalidMove},
+	{ErrNoTilesExchanged, CodeInvalidMove},
+	{ErrRackFull, CodeInvalidMove},
+	{ErrInvalidRackIndex, CodeInvalidMove},
+
+	{ErrNotPlayersTurn, CodeNotYourTurn},
+
+	{ErrGameNotWaiting, CodeConflict},
+	{ErrGameNotInProgress, CodeConflict},
+	{ErrGameNotFinished, CodeConflict},
+	{ErrGameFull, CodeConflict},
+	{ErrNotEnoughPlayers, CodeConflict},
+	{ErrDuplicatePlayer, CodeConflict},
+	{ErrDuplicateName, CodeConflict},
+	{ErrNotTeamGame, CodeConflict},
+	{ErrNotPartner, CodeConflict},
+	{ErrConsultationClosed, CodeConflict},
+	{ErrNotSoloGame, CodeConflict},
+	{ErrNoSuchTurn, CodeNotFound},
+	{ErrInvalidAnnotation, CodeInvalidInput},
+	{ErrOutOfTime, CodeConflict},
+	{ErrChallengeNotAllowed, CodeConflict},
+	{ErrNothingToChallenge, CodeConflict},
+	{ErrOwnMove, CodeConflict},
+	{ErrNothingToUndo, CodeConflict},
+	{ErrNothingToRedo, CodeConflict},
+	{ErrGameExists, CodeConflict},
+	{ErrManagerClosed, CodeConflict},
+	{ErrSeriesOver, CodeConflict},
+	{ErrStaleDiff, CodeConflict},
+	{ErrGamePaused, CodeConflict},
+	{ErrNotPaused, CodeConflict},
+	{ErrPauseNotAllowed, CodeConflict},
+	{ErrNoPauseRequested, CodeConflict},
+
+	{ErrPlayerNotFound, CodeNotFound},
+	{ErrGameNotFound, CodeNotFound},
+	{ErrUnknownTileSet, CodeNotFound},
+	{dictionary.ErrDictionaryNotFound, CodeNotFound},
+
+	{ErrInvalidNotation, CodeInvalidInput},
+	{ErrUnrecognizedPlay, CodeInvalidInput},
+	{ErrInvalidBinary, CodeInvalidInput},
+	{ErrInvalidLeaveTable, CodeInvalidInput},
+	{ErrInvalidRules, CodeInvalidInput},
+	{ErrUnsupportedSnapshot, CodeInvalidInput},
+
+	{ErrNoDictionary, CodeUnavailable},
+	{ErrNoMoveGenerator, CodeUnavailable},
+	{ErrNoClock, CodeUnavailable},
+	{dictionary.ErrNoDefault, CodeUnavailable},
+}
+
+// Code returns the code classifying err, looking through wrapped errors
+// Errors that are not game errors, including nil, are CodeUnknown.
+func Code(err error) ErrorCode {
+	if err == nil {
+		return CodeUnknown
+	}
+	for _, ec := range errorCodes {
+		if errors.Is(err, ec.err) {
+			return ec.code
+		}
+	}
+	return CodeUnknown
+}
@@ -0,0 +1,79 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// TestErrorSentinels tests that operations return errors matching their sentinels
+func TestErrorSentinels(t *testing.T) {
+	board := NewBoard()
+	center := Position{Row: 7, Col: 7}
+	if err := board.PlaceTile(Tile{Letter: 'A', Points: 1}, center); err != nil {
+		t.Fatalf("Failed to place tile: %v", err)
+	}
+
+	player := NewPlayer("p1", "Player 1")
+	player.Rack = rackOf("ABCDEFG")
+
+	g := newTestGame(t, 2)
+	if err := g.StartGame(); err != nil {
+		t.Fatalf("Failed to start game: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"occupied square", board.PlaceTile(Tile{Letter: 'B'}, center), ErrPositionOccupied},
+		{"off the board", board.PlaceTile(Tile{Letter: 'B'}, Position{Row: 15, Col: 0}), ErrInvalidPosition},
+		{"empty square", func() error { _, err := board.RemoveTile(Position{Row: 0, Col: 0}); return err }(), ErrNoTileAtPosition},
+		{"bad notation", func() error { _, err := NewPositionFromString("Z9"); return err }(), ErrInvalidPosition},
+		{"full rack", player.AddTilesToRack(rackOf("H")), ErrRackFull},
+		{"bad rack index", func() error { _, err := player.RemoveTilesFromRack([]int{7}); return err }(), ErrInvalidRackIndex},
+		{"repeated rack index", func() error { _, err := player.RemoveTilesFromRack([]int{1, 1}); return err }(), ErrInvalidRackIndex},
+		{"wrong player", g.PassTurn("p2"), ErrNotPlayersTurn},
+		{"empty exchange", g.ExchangeTiles("p1", nil), ErrNoTilesExchanged},
+		{"seated twice", g.AddPlayer(NewPlayer("p1", "Player 1")), ErrGameNotWaiting},
+	}
+
+	for _, tt := range tests {
+		if !errors.Is(tt.err, tt.want) {
+			t.Errorf("Should return %v for %s, got %v", tt.want, tt.name, tt.err)
+		}
+	}
+}
+
+// TestErrorCode tests classifying errors by code
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want ErrorCode
+	}{
+		{nil, CodeUnknown},
+		{errors.New("something else"), CodeUnknown},
+		{ErrTilesNotContiguous, CodeInvalidMove},
+		{fmt.Errorf("%w: %s", ErrPositionOccupied, "H8"), CodeInvalidMove},
+		{fmt.Errorf("%w: QXZ", ErrInvalidWord), CodeInvalidMove},
+		{ErrNotPlayersTurn, CodeNotYourTurn},
+		{ErrGameNotInProgress, CodeConflict},
+		{fmt.Errorf("lookup: %w", ErrGameNotFound), CodeNotFound},
+		{ErrInvalidNotation, CodeInvalidInput},
+		{ErrNoDictionary, CodeUnavailable},
+	}
+
+	for _, tt := range tests {
+		if got := Code(tt.err); got != tt.want {
+			t.Errorf("Should classify %v as %s, got %s", tt.err, tt.want, got)
+		}
+	}
+
+	if CodeNotYourTurn.String() != "NOT_YOUR_TURN" {
+		t.Errorf("Should name CodeNotYourTurn NOT_YOUR_TURN, got %s", CodeNotYourTurn)
+	}
+	if ErrorCode(99).String() != "UNKNOWN" {
+		t.Errorf("Should name an unknown code UNKNOWN, got %s", ErrorCode(99))
+	}
+}
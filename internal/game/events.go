@@ -0,0 +1,461 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"scrabbled/internal/dictionary"
+)
+
+// EventType identifies a change recorded in a game's event stream
+type EventType int
+
+const (
+	GameCreated          EventType = iota // Game created with its first players and variant
+	PlayerJoined                          // Player seated after creation
+	TileSetChanged                        // Custom tile set selected
+	LayoutChanged                         // Custom premium layout selected
+	TimeControlSet                        // Game clocks turned on
+	ChallengeRuleChanged                  // Challenge rule selected
+	GameStarted                           // Opening racks dealt
+	MovePlayed                            // Tiles placed on the board
+	TilesExchanged                        // Rack tiles swapped with the bag
+	TurnPassed                            // Player passed
+	TurnSkipped                           // Play advanced without an action (NextTurn)
+	ChallengeResolved                     // Last move challenged
+	TimeExpired                           // Player flagged for exceeding the overtime limit
+	GameEnded                             // Game ended early (EndGame)
+	ActionUndone                          // Most recent action undone
+	ActionRedone                          // Most recently undone action redone
+	RackReordered                         // Player rearranged their rack
+	TilesDrawn                            // Rack refilled outside a move (ReplenishRack)
+	PlayerResigned                        // Player conceded the game
+	GameAbandoned                         // Player to move forfeited by inactivity
+	VacationTaken                         // Game paused for one player's vacation
+	PauseRequested                        // Player proposed pausing the game
+	PauseAgreed                           // Player agreed to the proposed pause, starting it if they were the last
+	PauseDeclined                         // Player rejected or withdrew the proposed pause
+	GameResumed                           // Pause ended early or ran its course
+)
+
+// String returns a string representation of the event type
+func (et EventType) String() string {
+	switch et {
+	case GameCreated:
+		return "GAME_CREATED"
+	case PlayerJoined:
+		return "PLAYER_JOINED"
+	case TileSetChanged:
+		return "TILE_SET_CHANGED"
+	case LayoutChanged:
+		return "LAYOUT_CHANGED"
+	case TimeControlSet:
+		return "TIME_CONTROL_SET"
+	case ChallengeRuleChanged:
+		return "CHALLENGE_RULE_CHANGED"
+	case GameStarted:
+		return "GAME_STARTED"
+	case MovePlayed:
+		return "MOVE_PLAYED"
+	case TilesExchanged:
+		return "TILES_EXCHANGED"
+	case TurnPassed:
+		return "TURN_PASSED"
+	case TurnSkipped:
+		return "TURN_SKIPPED"
+	case ChallengeResolved:
+		return "CHALLENGE_RESOLVED"
+	case TimeExpired:
+		return "TIME_EXPIRED"
+	case GameEnded:
+		return "GAME_ENDED"
+	case ActionUndone:
+		return "ACTION_UNDONE"
+	case ActionRedone:
+		return "ACTION_REDONE"
+	case RackReordered:
+		return "RACK_REORDERED"
+	case TilesDrawn:
+		return "TILES_DRAWN"
+	case PlayerResigned:
+		return "PLAYER_RESIGNED"
+	case GameAbandoned:
+		return "GAME_ABANDONED"
+	case VacationTaken:
+		return "VACATION_TAKEN"
+	case PauseRequested:
+		return "PAUSE_REQUESTED"
+	case PauseAgreed:
+		return "PAUSE_AGREED"
+	case PauseDeclined:
+		return "PAUSE_DECLINED"
+	case GameResumed:
+		return "GAME_RESUMED"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// ErrReplayDiverged is returned when replaying an event does not reproduce the recorded outcome
+var ErrReplayDiverged = errors.New("replay diverged from the recorded game")
+
+// Event is one entry in a game's append-only event stream
+// Only the fields relevant to the event type are set. Every tile drawn from
+// the bag is recorded, so replay does not depend on how the bag was shuffled.
+type Event struct {
+	Seq         int              `json:"seq"` // Position in the stream, from 0
+	Type        EventType        `json:"type"`
+	Timestamp   time.Time        `json:"timestamp"`
+	GameID      string           `json:"game_id,omitempty"`      // GameCreated
+	PlayerID    string           `json:"player_id,omitempty"`    // Acting player (the challenger for challenges)
+	Players     []*Player        `json:"players,omitempty"`      // GameCreated, PlayerJoined (racks empty)
+	Variant     Variant          `json:"variant,omitempty"`      // GameCreated
+	Rules       *Rules           `json:"rules,omitempty"`        // GameCreated
+	TileSet     *TileSet         `json:"tile_set,omitempty"`     // TileSetChanged
+	Layout      *Layout          `json:"layout,omitempty"`       // LayoutChanged
+	TimeControl *TimeControl     `json:"time_control,omitempty"` // TimeControlSet
+	Rule        ChallengeRule    `json:"rule,omitempty"`         // ChallengeRuleChanged
+	Move        *Move            `json:"move,omitempty"`         // MovePlayed: the move as submitted
+	Indices     []int            `json:"indices,omitempty"`      // TilesExchanged: rack indices returned; RackReordered: new order
+	Racks       [][]Tile         `json:"racks,omitempty"`        // GameStarted: tiles dealt, by seat
+	Drawn       []Tile           `json:"drawn,omitempty"`        // MovePlayed, TilesExchanged, TilesDrawn: tiles drawn
+	Challenge   *ChallengeResult `json:"challenge,omitempty"`    // ChallengeResolved
+	Duration    time.Duration    `json:"duration,omitempty"`     // VacationTaken, PauseRequested
+}
+
+// isTurn returns true if the event ends a turn
+func (e Event) isTurn() bool {
+	switch e.Type {
+	case MovePlayed, TilesExchanged, TurnPassed, TurnSkipped, ChallengeResolved, TimeExpired, PlayerResigned, GameAbandoned:
+		return true
+	}
+	return false
+}
+
+// Events returns a copy of the events recorded since the game was created or loaded
+func (g *Game) Events() []Event {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	events := make([]Event, len(g.events))
+	copy(events, g.events)
+	return events
+}
+
+// EventsSince returns the recorded events with Seq >= seq
+func (g *Game) EventsSince(seq int) []Event {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	start := seq - g.eventBase
+	if start < 0 {
+		start = 0
+	}
+	if start > len(g.events) {
+		start = len(g.events)
+	}
+	events := make([]Event, len(g.events)-start)
+	copy(events, g.events[start:])
+	return events
+}
+
+// EventCount returns the number of events in the game's stream, including
+// any recorded before the game was loaded from a snapshot
+func (g *Game) EventCount() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.eventBase + len(g.events)
+}
+
+// record appends an event to the stream, stamping it with the current time
+// unless a timestamp is given; callers must hold the lock
+func (g *Game) record(ev Event) {
+	ev.Seq = g.eventBase + len(g.events)
+	if ev.Timestamp.IsZero() {
+		ev.Timestamp = time.Now()
+	}
+	g.events = append(g.events, ev)
+	g.logEvent(ev)
+}
+
+// seatCopies returns copies of players with empty racks and zero scores
+func seatCopies(players []*Player) []*Player {
+	seats := make([]*Player, len(players))
+	for i, p := range players {
+		seats[i] = NewPlayer(p.ID, p.Name)
+	}
+	return seats
+}
+
+// Replayer reconstructs a game at any point in its event stream
+type Replayer struct {
+	events []Event
+	game   *Game
+	pos    int // Number of events applied to game
+}
+
+// NewReplayer creates a replayer positioned after the GameCreated event
+func NewReplayer(events []Event) (*Replayer, error) {
+	if len(events) == 0 || events[0].Type != GameCreated {
+		return nil, errors.New("event stream must begin with GAME_CREATED")
+	}
+	for i, ev := range events {
+		if ev.Seq != i {
+			return nil, fmt.Errorf("event %d has sequence number %d", i, ev.Seq)
+		}
+	}
+
+	r := &Replayer{events: events}
+	if err := r.reset(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Replay reconstructs the game after the first n events; n < 0 replays them all
+func Replay(events []Event, n int) (*Game, error) {
+	r, err := NewReplayer(events)
+	if err != nil {
+		return nil, err
+	}
+	if n < 0 {
+		n = len(events)
+	}
+	return r.Seek(n)
+}
+
+// Len returns the number of events in the stream
+func (r *Replayer) Len() int {
+	return len(r.events)
+}
+
+// Position returns the number of events applied so far
+func (r *Replayer) Position() int {
+	return r.pos
+}
+
+// Game returns the game as of the current position
+// The game is owned by the replayer and changes as it moves.
+func (r *Replayer) Game() *Game {
+	return r.game
+}
+
+// Turns returns the stream position just after each completed turn, so
+// Seek(Turns()[i]) shows the game after turn i+1
+func (r *Replayer) Turns() []int {
+	turns := []int{}
+	for i, ev := range r.events {
+		if ev.isTurn() {
+			turns = append(turns, i+1)
+		}
+	}
+	return turns
+}
+
+// Step applies the next event
+func (r *Replayer) Step() error {
+	if r.pos >= len(r.events) {
+		return errors.New("no more events to replay")
+	}
+	if err := r.game.applyEvent(r.events[r.pos]); err != nil {
+		return fmt.Errorf("event %d (%s): %w", r.pos, r.events[r.pos].Type, err)
+	}
+	r.pos++
+	return nil
+}
+
+// Seek moves to the position after the first n events and returns the game
+// Seeking backwards replays from the start of the stream.
+func (r *Replayer) Seek(n int) (*Game, error) {
+	if n < 1 || n > len(r.events) {
+		return nil, fmt.Errorf("position %d out of range [1, %d]", n, len(r.events))
+	}
+	if n < r.pos {
+		if err := r.reset(); err != nil {
+			return nil, err
+		}
+	}
+	for r.pos < n {
+		if err := r.Step(); err != nil {
+			return nil, err
+		}
+	}
+	return r.game, nil
+}
+
+// reset rebuilds the game from the GameCreated event
+func (r *Replayer) reset() error {
+	created := r.events[0]
+	rules := RulesForVariant(created.Variant)
+	if created.Rules != nil {
+		rules = *created.Rules
+	}
+	g, err := NewGameWithRules(created.GameID, seatCopies(created.Players), rules)
+	if err != nil {
+		return err
+	}
+	g.CreatedAt = created.Timestamp
+	g.events[0].Timestamp = created.Timestamp
+	r.game = g
+	r.pos = 1
+	return nil
+}
+
+// applyEvent replays one recorded event through the public game API
+func (g *Game) applyEvent(ev Event) error {
+	historyLen := len(g.GetHistory())
+	g.mu.RLock()
+	started := g.turnStarted
+	g.mu.RUnlock()
+
+	var err error
+	switch ev.Type {
+	case PlayerJoined:
+		for _, p := range ev.Players {
+			if err = g.AddPlayer(NewPlayer(p.ID, p.Name)); err != nil {
+				break
+			}
+		}
+	case TileSetChanged:
+		err = g.SetTileSet(ev.TileSet)
+	case LayoutChanged:
+		err = g.SetLayout(*ev.Layout)
+	case TimeControlSet:
+		err = g.SetTimeControl(*ev.TimeControl)
+	case ChallengeRuleChanged:
+		g.SetChallengeRule(ev.Rule)
+	case GameStarted:
+		if err = g.stackBag(ev.Racks...); err == nil {
+			err = g.StartGame()
+		}
+	case MovePlayed:
+		if err = g.stackBag(ev.Drawn); err == nil {
+			_, err = g.PlayMove(*ev.Move)
+		}
+	case TilesExchanged:
+		if err = g.stackBag(ev.Drawn); err == nil {
+			err = g.ExchangeTiles(ev.PlayerID, ev.Indices)
+		}
+	case TurnPassed:
+		err = g.PassTurn(ev.PlayerID)
+	case TurnSkipped:
+		err = g.NextTurn()
+	case ChallengeResolved:
+		// Without the original dictionary, the recorded ruling decides the challenge
+		if ev.Challenge != nil && g.dictionary() == nil {
+			g.SetDictionary(newRecordedRuling(ev.Challenge.InvalidWords))
+			defer g.SetDictionary(nil)
+		}
+		var result ChallengeResult
+		result, err = g.Challenge(ev.PlayerID)
+		if err == nil && ev.Challenge != nil && result.Successful != ev.Challenge.Successful {
+			err = ErrReplayDiverged
+		}
+	case TimeExpired:
+		err = g.expire(ev.PlayerID)
+	case PlayerResigned:
+		err = g.Resign(ev.PlayerID)
+	case GameAbandoned:
+		err = g.abandon(ev.PlayerID)
+	case VacationTaken:
+		err = g.TakeVacation(ev.PlayerID, ev.Duration)
+	case PauseRequested:
+		err = g.RequestPause(ev.PlayerID, ev.Duration)
+	case PauseAgreed:
+		err = g.AgreePause(ev.PlayerID)
+	case PauseDeclined:
+		err = g.DeclinePause(ev.PlayerID)
+	case GameResumed:
+		err = g.unpause(ev.PlayerID, ev.Timestamp)
+	case GameEnded:
+		err = g.EndGame()
+	case ActionUndone:
+		err = g.Undo()
+	case ActionRedone:
+		err = g.Redo()
+	case RackReordered:
+		err = g.ReorderRack(ev.PlayerID, ev.Indices)
+	case TilesDrawn:
+		if err = g.stackBag(ev.Drawn); err == nil {
+			_, err = g.ReplenishRack(ev.PlayerID)
+		}
+	default:
+		err = fmt.Errorf("cannot replay event type %s", ev.Type)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Carry the recorded times over to the reconstructed game
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.events[len(g.events)-1].Timestamp = ev.Timestamp
+	for i := historyLen; i < len(g.History); i++ {
+		g.History[i].Timestamp = ev.Timestamp
+	}
+	g.LastActivity = ev.Timestamp
+	g.ExpiresAt = ev.Timestamp.Add(DefaultGameExpiration)
+	if g.turnStarted != started {
+		g.turnStarted = ev.Timestamp
+	}
+	if p := g.Paused; p != nil {
+		if ev.Type == VacationTaken || ev.Type == PauseAgreed {
+			p.Until = ev.Timestamp.Add(p.Until.Sub(p.Start))
+			p.Start = ev.Timestamp
+		}
+		g.ExpiresAt = p.Until.Add(DefaultGameExpiration)
+	}
+	return nil
+}
+
+// stackBag arranges the bag so the next draws yield the given tiles in order
+func (g *Game) stackBag(draws ...[]Tile) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	// The first draw comes off the end of the bag, so it is stacked last
+	var top []Tile
+	for i := len(draws) - 1; i >= 0; i-- {
+		top = append(top, draws[i]...)
+	}
+	if err := g.TileBag.stack(top); err != nil {
+		return fmt.Errorf("%w: %v", ErrReplayDiverged, err)
+	}
+	return nil
+}
+
+// dictionary returns the attached dictionary
+func (g *Game) dictionary() dictionary.Dictionary {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.Dictionary
+}
+
+// recordedRuling is a Dictionary that rejects exactly the words a recorded
+// challenge found invalid
+type recordedRuling map[string]bool
+
+// newRecordedRuling creates a ruling rejecting the given words
+func newRecordedRuling(invalid []string) recordedRuling {
+	r := make(recordedRuling, len(invalid))
+	for _, w := range invalid {
+		r[w] = true
+	}
+	return r
+}
+
+// IsValid returns true unless the word was ruled invalid
+func (r recordedRuling) IsValid(word string) bool {
+	return !r[word]
+}
+
+// Lookup returns an entry for any word not ruled invalid
+func (r recordedRuling) Lookup(word string) (dictionary.Entry, bool) {
+	if r[word] {
+		return dictionary.Entry{}, false
+	}
+	return dictionary.Entry{Word: word, Lexicon: dictionary.Custom}, true
+}
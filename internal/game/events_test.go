@@ -0,0 +1,242 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// playFromRack plays the current player's first non-blank tiles at the given positions
+func playFromRack(t *testing.T, g *Game, positions ...Position) {
+	t.Helper()
+	player := g.CurrentPlayer()
+	move := Move{PlayerID: player.ID, Direction: Horizontal}
+	if len(positions) > 1 && positions[0].Col == positions[1].Col {
+		move.Direction = Vertical
+	}
+	for _, tile := range player.Rack {
+		if len(move.Tiles) == len(positions) {
+			break
+		}
+		if !tile.IsBlank {
+			move.Tiles = append(move.Tiles, PlacedTile{Tile: tile, Position: positions[len(move.Tiles)]})
+		}
+	}
+	if _, err := g.PlayMove(move); err != nil {
+		t.Fatalf("PlayMove failed: %v", err)
+	}
+}
+
+// sortedBag returns the bag's tiles in a canonical order
+func sortedBag(g *Game) []Tile {
+	tiles := g.TileBag.Tiles()
+	sort.Slice(tiles, func(i, j int) bool { return tiles[i].Letter < tiles[j].Letter })
+	return tiles
+}
+
+// playRecordedGame plays a short game covering most event types
+func playRecordedGame(t *testing.T) *Game {
+	t.Helper()
+	g := newChallengeGame(t)
+
+	playFromRack(t, g, Position{Row: 7, Col: 7}, Position{Row: 7, Col: 8})
+	if _, err := g.Challenge("p2"); err != nil {
+		t.Fatalf("Challenge failed: %v", err)
+	}
+	current := g.CurrentPlayer().ID
+	if err := g.ExchangeTiles(current, []int{0, 2}); err != nil {
+		t.Fatalf("ExchangeTiles failed: %v", err)
+	}
+	g.PassTurn(g.CurrentPlayer().ID)
+	g.Undo()
+	if g.Board.IsFirstMove() {
+		playFromRack(t, g, Position{Row: 7, Col: 6}, Position{Row: 7, Col: 7}, Position{Row: 7, Col: 8})
+	} else {
+		playFromRack(t, g, Position{Row: 8, Col: 7}, Position{Row: 9, Col: 7})
+	}
+	return g
+}
+
+// TestReplayReconstructsGame tests that replaying all events reproduces the game
+func TestReplayReconstructsGame(t *testing.T) {
+	g := playRecordedGame(t)
+	events := g.Events()
+
+	replayed, err := Replay(events, -1)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(replayed.Board.Grid, g.Board.Grid) {
+		t.Errorf("Replayed board should match")
+	}
+	for i, p := range g.Players {
+		r := replayed.Players[i]
+		if r.Score != p.Score || !reflect.DeepEqual(r.Rack, p.Rack) {
+			t.Errorf("Replayed player %s should match: score %d/%d rack %v/%v", p.ID, r.Score, p.Score, r.Rack, p.Rack)
+		}
+	}
+	if !reflect.DeepEqual(sortedBag(replayed), sortedBag(g)) {
+		t.Errorf("Replayed bag should hold the same tiles")
+	}
+	if replayed.CurrentTurn != g.CurrentTurn || replayed.State != g.State || len(replayed.History) != len(g.History) {
+		t.Errorf("Replayed turn, state and history should match")
+	}
+	if !replayed.History[0].Timestamp.Equal(g.History[0].Timestamp) {
+		t.Errorf("Replay should keep recorded timestamps")
+	}
+
+	again := replayed.Events()
+	if len(again) != len(events) {
+		t.Fatalf("Replay should record the same events, got %d want %d", len(again), len(events))
+	}
+	for i := range events {
+		if again[i].Type != events[i].Type {
+			t.Errorf("Event %d: got %s, want %s", i, again[i].Type, events[i].Type)
+		}
+	}
+}
+
+// TestEventTypes tests the event stream recorded for a game
+func TestEventTypes(t *testing.T) {
+	g := playRecordedGame(t)
+
+	want := []EventType{
+		GameCreated, ChallengeRuleChanged, GameStarted, MovePlayed, ChallengeResolved,
+		TilesExchanged, TurnPassed, ActionUndone, MovePlayed,
+	}
+	events := g.Events()
+	if len(events) != len(want) {
+		t.Fatalf("Should record %d events, got %d", len(want), len(events))
+	}
+	for i, ev := range events {
+		if ev.Type != want[i] || ev.Seq != i {
+			t.Errorf("Event %d: got %s seq %d, want %s", i, ev.Type, ev.Seq, want[i])
+		}
+	}
+	if len(events[2].Racks) != 2 || len(events[2].Racks[0]) != MaxRackSize {
+		t.Errorf("GameStarted should record the opening racks")
+	}
+	if events[5].Type.String() != "TILES_EXCHANGED" || len(events[5].Drawn) != 2 {
+		t.Errorf("Exchange should record the tiles drawn")
+	}
+}
+
+// TestReplayerSeek tests moving backwards and forwards through a game
+func TestReplayerSeek(t *testing.T) {
+	g := playRecordedGame(t)
+	r, err := NewReplayer(g.Events())
+	if err != nil {
+		t.Fatalf("NewReplayer failed: %v", err)
+	}
+
+	turns := r.Turns()
+	if !reflect.DeepEqual(turns, []int{4, 5, 6, 7, 9}) {
+		t.Fatalf("Turns should mark the end of each turn, got %v", turns)
+	}
+
+	after, err := r.Seek(turns[0])
+	if err != nil {
+		t.Fatalf("Seek failed: %v", err)
+	}
+	if after.Board.IsFirstMove() || len(after.History) != 1 || r.Position() != 4 {
+		t.Errorf("Should show the board after the first move")
+	}
+
+	start, err := r.Seek(1)
+	if err != nil {
+		t.Fatalf("Seeking back failed: %v", err)
+	}
+	if start.State != WaitingForPlayers || !start.Board.IsFirstMove() {
+		t.Errorf("Seeking back should rebuild the game from the start")
+	}
+
+	if _, err := r.Seek(r.Len() + 1); err == nil {
+		t.Errorf("Should reject positions past the end")
+	}
+}
+
+// TestReplayFromJSON tests that events survive encoding, including custom tile sets
+func TestReplayFromJSON(t *testing.T) {
+	g := newTestGame(t, 2)
+	ts, err := LoadTileSet("spanish")
+	if err != nil {
+		t.Fatalf("LoadTileSet failed: %v", err)
+	}
+	g.SetTileSet(ts)
+	g.SetTimeControl(TournamentTimeControl())
+	g.StartGame()
+	g.PassTurn("p1")
+
+	data, err := json.Marshal(g.Events())
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var events []Event
+	if err := json.Unmarshal(data, &events); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	replayed, err := Replay(events, -1)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if replayed.Board.TileSet == nil || replayed.Clock == nil {
+		t.Errorf("Should replay configuration events")
+	}
+	for i, p := range g.Players {
+		if !reflect.DeepEqual(replayed.Players[i].Rack, p.Rack) {
+			t.Errorf("Should deal the same racks, got %v want %v", replayed.Players[i].Rack, p.Rack)
+		}
+	}
+}
+
+// TestReplayInvalidStream tests that malformed or inconsistent streams are rejected
+func TestReplayInvalidStream(t *testing.T) {
+	if _, err := Replay(nil, -1); err == nil {
+		t.Errorf("Should reject an empty stream")
+	}
+
+	g := newTestGame(t, 2)
+	g.StartGame()
+	events := g.Events()
+
+	if _, err := Replay(events[1:], -1); err == nil {
+		t.Errorf("Should reject a stream without GAME_CREATED")
+	}
+
+	events[1].Seq = 5
+	if _, err := Replay(events, -1); err == nil {
+		t.Errorf("Should reject gaps in sequence numbers")
+	}
+	events[1].Seq = 1
+
+	events[1].Racks[0] = rackOf("ZZZZZZZ")
+	if _, err := Replay(events, -1); !errors.Is(err, ErrReplayDiverged) {
+		t.Errorf("Should report draws the bag cannot supply, got %v", err)
+	}
+}
+
+// TestEventsSinceLoad tests that sequence numbers continue after a game is loaded
+func TestEventsSinceLoad(t *testing.T) {
+	g := newTestGame(t, 2)
+	g.StartGame()
+	count := g.EventCount()
+
+	data, _ := g.Serialize()
+	loaded, err := LoadGame(data)
+	if err != nil {
+		t.Fatalf("LoadGame failed: %v", err)
+	}
+	if loaded.EventCount() != count || len(loaded.Events()) != 0 {
+		t.Errorf("Loaded game should continue the stream at %d", count)
+	}
+
+	loaded.PassTurn("p1")
+	since := loaded.EventsSince(count)
+	if len(since) != 1 || since[0].Seq != count || since[0].Type != TurnPassed {
+		t.Errorf("Should return the new event with the next sequence number, got %+v", since)
+	}
+}
@@ -0,0 +1,148 @@
+package game
+
+import (
+	"errors"
+	"strings"
+)
+
+// MoveRule identifies the placement rule a rejected move broke
+type MoveRule int
+
+const (
+	RuleNoTiles           MoveRule = iota // No tiles were placed
+	RuleOffBoard                          // A tile lies outside the board
+	RuleOccupied                          // A tile covers a square that is already filled
+	RuleDuplicateSquare                   // Two tiles share a square
+	RuleUnassignedBlank                   // A blank has no letter
+	RuleNotInLine                         // Tiles are not in one row or column
+	RuleNotContiguous                     // Empty squares separate the tiles
+	RuleNotOnCenter                       // The first move misses the center square
+	RuleFirstMoveTooShort                 // The first move is a single tile
+	RuleNotConnected                      // The move does not touch the tiles on the board
+	RuleWordMismatch                      // The tiles do not spell the declared word
+	RuleNotInRack                         // The player does not hold the tiles
+	RuleInvalidWord                       // A formed word is not in the dictionary
+)
+
+// moveRuleErrors holds the sentinel error for each rule, indexed by MoveRule
+var moveRuleErrors = []error{
+	RuleNoTiles:           ErrNoTilesPlaced,
+	RuleOffBoard:          ErrInvalidPosition,
+	RuleOccupied:          ErrPositionOccupied,
+	RuleDuplicateSquare:   ErrDuplicatePosition,
+	RuleUnassignedBlank:   ErrUnassignedBlank,
+	RuleNotInLine:         ErrTilesNotInLine,
+	RuleNotContiguous:     ErrTilesNotContiguous,
+	RuleNotOnCenter:       ErrFirstMoveNotCenter,
+	RuleFirstMoveTooShort: ErrFirstMoveTooShort,
+	RuleNotConnected:      ErrNotConnected,
+	RuleWordMismatch:      ErrWordMismatch,
+	RuleNotInRack:         ErrTilesNotInRack,
+	RuleInvalidWord:       ErrInvalidWord,
+}
+
+// String returns a string representation of the rule
+func (r MoveRule) String() string {
+	switch r {
+	case RuleNoTiles:
+		return "NO_TILES"
+	case RuleOffBoard:
+		return "OFF_BOARD"
+	case RuleOccupied:
+		return "OCCUPIED"
+	case RuleDuplicateSquare:
+		return "DUPLICATE_SQUARE"
+	case RuleUnassignedBlank:
+		return "UNASSIGNED_BLANK"
+	case RuleNotInLine:
+		return "NOT_IN_LINE"
+	case RuleNotContiguous:
+		return "NOT_CONTIGUOUS"
+	case RuleNotOnCenter:
+		return "NOT_ON_CENTER"
+	case RuleFirstMoveTooShort:
+		return "FIRST_MOVE_TOO_SHORT"
+	case RuleNotConnected:
+		return "NOT_CONNECTED"
+	case RuleWordMismatch:
+		return "WORD_MISMATCH"
+	case RuleNotInRack:
+		return "NOT_IN_RACK"
+	case RuleInvalidWord:
+		return "INVALID_WORD"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// MoveError explains why a move was rejected, so that clients can highlight
+// the offending tiles
+// It wraps the sentinel error for the broken rule, so errors.Is and Code treat
+// it as that sentinel.
+type MoveError struct {
+	Rule    MoveRule     `json:"rule"`
+	Message string       `json:"message"`
+	Squares []Position   `json:"squares,omitempty"` // Offending tiles, gaps between them, or the square a tile must cover
+	Words   []FormedWord `json:"words,omitempty"`   // Formed words missing from the dictionary
+}
+
+// Error returns the explanation as text
+func (e *MoveError) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the sentinel error for the broken rule
+func (e *MoveError) Unwrap() error {
+	if e.Rule < 0 || int(e.Rule) >= len(moveRuleErrors) {
+		return nil
+	}
+	return moveRuleErrors[e.Rule]
+}
+
+// ExplainMove returns the explanation carried by an error from move
+// validation, or false if the error does not explain a rejected placement
+func ExplainMove(err error) (*MoveError, bool) {
+	var me *MoveError
+	if errors.As(err, &me) {
+		return me, true
+	}
+	return nil, false
+}
+
+// moveError explains a broken rule, naming the given detail after the
+// sentinel's message
+func moveError(rule MoveRule, detail string, squares ...Position) *MoveError {
+	msg := moveRuleErrors[rule].Error()
+	if detail != "" {
+		msg += ": " + detail
+	}
+	return &MoveError{Rule: rule, Message: msg, Squares: squares}
+}
+
+// invalidWordsError explains a move that forms words missing from the
+// dictionary, highlighting every square of those words
+func invalidWordsError(words []FormedWord) *MoveError {
+	names := make([]string, len(words))
+	var squares []Position
+	for i, w := range words {
+		names[i] = w.Word
+		for _, p := range w.Positions {
+			if !containsPosition(squares, p) {
+				squares = append(squares, p)
+			}
+		}
+	}
+	e := moveError(RuleInvalidWord, strings.Join(names, ", "), squares...)
+	e.Words = words
+	return e
+}
+
+// containsPosition returns true if p is among positions
+func containsPosition(positions []Position, p Position) bool {
+	for _, q := range positions {
+		if q == p {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"scrabbled/internal/dictionary"
+)
+
+// TestExplainPlacement tests the rule and squares reported for rejected placements
+func TestExplainPlacement(t *testing.T) {
+	board := NewBoard()
+	rack := rackOf("CATSDOG")
+	placed := func(tiles ...string) Move {
+		move := Move{Direction: Horizontal}
+		for i, pos := range tiles {
+			move.Tiles = append(move.Tiles, PlacedTile{Tile: rack[i], Position: mustPos(t, pos)})
+		}
+		return move
+	}
+
+	tests := []struct {
+		name    string
+		move    Move
+		rack    []Tile
+		rule    MoveRule
+		squares []string
+	}{
+		{"empty", Move{}, rack, RuleNoTiles, nil},
+		{"duplicate", placed("H8", "H8"), rack, RuleDuplicateSquare, []string{"H8"}},
+		{"diagonal", placed("H8", "I8", "J9"), rack, RuleNotInLine, []string{"J9"}},
+		{"gaps", placed("E8", "H8", "I8"), rack, RuleNotContiguous, []string{"F8", "G8"}},
+		{"off center", placed("A1", "B1"), rack, RuleNotOnCenter, []string{"H8"}},
+		{"not in rack", placed("H8", "I8", "J8"), rackOf("CXY"), RuleNotInRack, []string{"I8", "J8"}},
+	}
+	for _, tt := range tests {
+		err := board.ValidatePlacement(tt.move, tt.rack)
+		me, ok := ExplainMove(err)
+		if !ok {
+			t.Errorf("%s: Should explain the rejection, got %v", tt.name, err)
+			continue
+		}
+		if me.Rule != tt.rule || !errors.Is(err, moveRuleErrors[tt.rule]) || Code(err) != CodeInvalidMove {
+			t.Errorf("%s: Should break rule %v, got %v (%v)", tt.name, tt.rule, me.Rule, err)
+		}
+		if len(me.Squares) != len(tt.squares) {
+			t.Errorf("%s: Should highlight %v, got %v", tt.name, tt.squares, me.Squares)
+			continue
+		}
+		for i, sq := range tt.squares {
+			if me.Squares[i] != mustPos(t, sq) {
+				t.Errorf("%s: Should highlight %v, got %v", tt.name, tt.squares, me.Squares)
+				break
+			}
+		}
+	}
+
+	placeWord(t, board, "CAT", "G8", Horizontal)
+	err := board.ValidatePlacement(placed("H8"), rack)
+	if me, _ := ExplainMove(err); me == nil || me.Rule != RuleOccupied || me.Error() != "position is already occupied: H8" {
+		t.Errorf("Should name the occupied square, got %v", err)
+	}
+
+	if _, ok := ExplainMove(ErrGameFull); ok {
+		t.Errorf("Should not explain errors other than rejected placements")
+	}
+}
+
+// TestExplainInvalidWords tests that every invalid formed word is reported
+func TestExplainInvalidWords(t *testing.T) {
+	dict := dictionary.NewWordList(dictionary.Custom)
+	for _, word := range []string{"CAT", "AT"} {
+		dict.AddWord(word)
+	}
+
+	g := newTestGame(t, 2)
+	g.SetDictionary(dict)
+	g.StartGame()
+	g.Board.PlaceTile(Tile{Letter: 'C', Points: 3}, mustPos(t, "G8"))
+	g.Board.PlaceTile(Tile{Letter: 'A', Points: 1}, mustPos(t, "H8"))
+	g.Board.PlaceTile(Tile{Letter: 'T', Points: 1}, mustPos(t, "I8"))
+	g.Players[0].Rack = rackOf("XZQKOEE")
+
+	// QZ above CA forms QZ across and QC and ZA down
+	move := Move{PlayerID: "p1", Direction: Horizontal, Tiles: []PlacedTile{
+		{Tile: Tile{Letter: 'Q', Points: 10}, Position: mustPos(t, "G7")},
+		{Tile: Tile{Letter: 'Z', Points: 10}, Position: mustPos(t, "H7")},
+	}}
+	err := g.ValidateMove(move)
+	me, ok := ExplainMove(err)
+	if !ok || me.Rule != RuleInvalidWord || !errors.Is(err, ErrInvalidWord) {
+		t.Fatalf("Should explain the invalid words, got %v", err)
+	}
+	if len(me.Words) != 3 || me.Words[0].Word != "QZ" {
+		t.Errorf("Should report QZ, QC and ZA, got %+v", me.Words)
+	}
+	if len(me.Squares) != 4 {
+		t.Errorf("Should highlight each square of the words once, got %v", me.Squares)
+	}
+	if me.Error() != "word not in dictionary: QZ, QC, ZA" {
+		t.Errorf("Should list the words in the message, got %q", me.Error())
+	}
+
+	data, _ := json.Marshal(me)
+	var decoded map[string]any
+	if json.Unmarshal(data, &decoded); decoded["rule"] != float64(RuleInvalidWord) || decoded["message"] != me.Message {
+		t.Errorf("Explanation should encode its rule and message, got %s", data)
+	}
+}
@@ -0,0 +1,117 @@
+package game
+
+import "time"
+
+// ForfeitReason says why a player lost a game before it was played out
+type ForfeitReason int
+
+const (
+	Resigned  ForfeitReason = iota // Player conceded the game
+	Abandoned                      // Player to move stopped playing
+)
+
+// String returns a string representation of the forfeit reason
+func (r ForfeitReason) String() string {
+	switch r {
+	case Resigned:
+		return "RESIGNED"
+	case Abandoned:
+		return "ABANDONED"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// Forfeit records the player who lost a game by resigning or abandoning it
+type Forfeit struct {
+	PlayerID string        `json:"player_id"`
+	Reason   ForfeitReason `json:"reason"`
+}
+
+// copyForfeit returns a copy of a forfeit, or nil
+func copyForfeit(f *Forfeit) *Forfeit {
+	if f == nil {
+		return nil
+	}
+	cp := *f
+	return &cp
+}
+
+// Resign concedes the game for a player, who need not be the one to move
+// The game ends with the scores as they stand, without end-of-game rack
+// adjustments, and the player (with their partner in team play) loses
+// whatever the scores.
+func (g *Game) Resign(playerID string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.State != InProgress {
+		return ErrGameNotInProgress
+	}
+	if g.findPlayer(playerID) == nil {
+		return ErrPlayerNotFound
+	}
+	g.forfeit(playerID, Resigned)
+	return nil
+}
+
+// CheckAbandonment forfeits the game for the player to move once their turn
+// has lasted AbandonAfter; it returns their ID or "" if play goes on
+// The wait runs from the start of the turn, so the other players tidying
+// their racks or annotating moves do not extend it. Paused games are never
+// abandoned, and resuming play restarts the wait.
+// Like CheckTime, servers should call it periodically, since an absent player
+// never acts.
+func (g *Game) CheckAbandonment() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.AbandonAfter <= 0 || g.State != InProgress || g.Paused != nil || time.Since(g.turnStarted) < g.AbandonAfter {
+		return ""
+	}
+	absent := g.Players[g.CurrentTurn].ID
+	g.forfeit(absent, Abandoned)
+	return absent
+}
+
+// abandon forfeits a player's game regardless of activity, as when replaying
+// a recorded abandonment
+func (g *Game) abandon(playerID string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.State != InProgress {
+		return ErrGameNotInProgress
+	}
+	g.forfeit(playerID, Abandoned)
+	return nil
+}
+
+// forfeit ends the game as lost by a player; callers must hold the lock
+func (g *Game) forfeit(playerID string, reason ForfeitReason) {
+	g.recordAction()
+	g.Forfeit = &Forfeit{PlayerID: playerID, Reason: reason}
+	g.challengeable = false
+	g.State = Finished
+	g.settleClock()
+	g.endPause()
+
+	ev := Event{Type: PlayerResigned, PlayerID: playerID}
+	if reason == Abandoned {
+		ev.Type = GameAbandoned
+	}
+	g.record(ev)
+	g.touch()
+}
+
+// forfeited returns true if the player lost the game by forfeit, their own or
+// their partner's; callers must hold the lock
+func (g *Game) forfeited(playerID string) bool {
+	if g.Forfeit == nil {
+		return false
+	}
+	if g.Forfeit.PlayerID == playerID {
+		return true
+	}
+	return g.Teams != nil && g.seatOf(playerID)%2 == g.seatOf(g.Forfeit.PlayerID)%2
+}
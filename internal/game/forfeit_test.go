@@ -0,0 +1,146 @@
+package game
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestResign tests that resigning ends the game as a loss whatever the scores
+func TestResign(t *testing.T) {
+	g := newTestGame(t, 3)
+	g.StartGame()
+	g.Players[0].Score, g.Players[1].Score, g.Players[2].Score = 50, 30, 40
+	rackScore := g.Players[1].Score
+
+	if err := g.Resign("p9"); !errors.Is(err, ErrPlayerNotFound) {
+		t.Errorf("Expected ErrPlayerNotFound, got %v", err)
+	}
+	// p1 is to move, but anyone may resign
+	if err := g.Resign("p1"); err != nil {
+		t.Fatalf("Resign failed: %v", err)
+	}
+	if g.State != Finished || g.Forfeit == nil || *g.Forfeit != (Forfeit{PlayerID: "p1", Reason: Resigned}) {
+		t.Fatalf("Should finish the game forfeited by p1, got %v %+v", g.State, g.Forfeit)
+	}
+	if g.Players[1].Score != rackScore {
+		t.Errorf("Scores should stand without rack adjustments, got %d", g.Players[1].Score)
+	}
+	if err := g.Resign("p2"); !errors.Is(err, ErrGameNotInProgress) {
+		t.Errorf("Should not resign a finished game, got %v", err)
+	}
+
+	for id, wins := range map[string]int{"p1": 0, "p2": 0, "p3": 1} {
+		if stats, _ := g.PlayerStats(id); stats.Wins != wins {
+			t.Errorf("%s should have %d wins, got %d", id, wins, stats.Wins)
+		}
+	}
+	standings, _ := g.FinalStandings()
+	if !standings[0].Forfeited || standings[2].Forfeited || standings[0].Score != 50 {
+		t.Errorf("Only p1 should be marked as forfeiting, got %+v", standings)
+	}
+
+	events := g.Events()
+	if last := events[len(events)-1]; last.Type != PlayerResigned || last.PlayerID != "p1" {
+		t.Errorf("Should record the resignation, got %s", last.Type)
+	}
+
+	// Undoing the resignation resumes the game
+	if err := g.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if g.State != InProgress || g.Forfeit != nil {
+		t.Errorf("Should resume the game after undoing a resignation")
+	}
+}
+
+// TestResignTeams tests that a resignation loses the game for both partners
+func TestResignTeams(t *testing.T) {
+	g := newTeamGame(t, 0)
+	g.Players[0].Score = 100
+
+	if err := g.Resign("p3"); err != nil {
+		t.Fatalf("Resign failed: %v", err)
+	}
+	for id, wins := range map[string]int{"p1": 0, "p2": 1, "p3": 0, "p4": 1} {
+		if stats, _ := g.PlayerStats(id); stats.Wins != wins {
+			t.Errorf("%s should have %d wins, got %d", id, wins, stats.Wins)
+		}
+	}
+}
+
+// TestCheckAbandonment tests forfeiting the player to move after inactivity
+func TestCheckAbandonment(t *testing.T) {
+	rules := DefaultRules()
+	rules.AbandonAfter = time.Hour
+	g, err := NewGameWithRules("abandon", []*Player{NewPlayer("p1", "Ann"), NewPlayer("p2", "Bob")}, rules)
+	if err != nil {
+		t.Fatalf("NewGameWithRules failed: %v", err)
+	}
+	if g.CheckAbandonment() != "" {
+		t.Errorf("A game that has not started cannot be abandoned")
+	}
+	g.StartGame()
+	g.PassTurn("p1")
+
+	if absent := g.CheckAbandonment(); absent != "" {
+		t.Errorf("Should not forfeit an active game, got %q", absent)
+	}
+	// The waiting player tidying their rack does not keep the game alive
+	g.turnStarted = time.Now().Add(-2 * time.Hour)
+	if err := g.ShuffleRack("p1"); err != nil {
+		t.Fatalf("ShuffleRack failed: %v", err)
+	}
+	if absent := g.CheckAbandonment(); absent != "p2" {
+		t.Fatalf("Should forfeit the player to move, got %q", absent)
+	}
+	if g.State != Finished || g.Forfeit.Reason != Abandoned {
+		t.Errorf("Should finish the game as abandoned, got %v %+v", g.State, g.Forfeit)
+	}
+
+	data, err := g.Serialize()
+	if err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	loaded, err := LoadGame(data)
+	if err != nil {
+		t.Fatalf("LoadGame failed: %v", err)
+	}
+	if loaded.AbandonAfter != time.Hour || loaded.Forfeit == nil || loaded.Forfeit.PlayerID != "p2" {
+		t.Errorf("A loaded game should keep the policy and forfeit, got %v %+v", loaded.AbandonAfter, loaded.Forfeit)
+	}
+	if loaded.Rules().AbandonAfter != time.Hour {
+		t.Errorf("Rules should report the abandonment timeout")
+	}
+
+	replayed, err := Replay(g.Events(), -1)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if replayed.State != Finished || replayed.Forfeit == nil || *replayed.Forfeit != *g.Forfeit {
+		t.Errorf("Replay should reproduce the abandonment, got %+v", replayed.Forfeit)
+	}
+}
+
+// TestGameManagerCheckAbandonment tests sweeping managed games for abandonment
+func TestGameManagerCheckAbandonment(t *testing.T) {
+	m := NewGameManager(1)
+	defer m.Close()
+
+	for _, id := range []string{"idle", "busy", "untimed"} {
+		rules := DefaultRules()
+		if id != "untimed" {
+			rules.AbandonAfter = time.Minute
+		}
+		g, _ := NewGameWithRules(id, []*Player{NewPlayer("p1", "Ann"), NewPlayer("p2", "Bob")}, rules)
+		g.StartGame()
+		if id != "busy" {
+			g.turnStarted = time.Now().Add(-time.Hour)
+		}
+		m.Add(g)
+	}
+
+	if got := m.CheckAbandonment(); len(got) != 1 || got[0] != "idle" {
+		t.Errorf("Only the idle game should be forfeited, got %v", got)
+	}
+}
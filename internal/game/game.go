@@ -0,0 +1,812 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"scrabbled/internal/dictionary"
+)
+
+// Player count limits
+const (
+	MinPlayers = 2
+	MaxPlayers = 4
+)
+
+// DefaultGameExpiration is how long an inactive game is kept before it expires
+const DefaultGameExpiration = 7 * 24 * time.Hour
+
+// GameState represents the lifecycle state of a game
+type GameState int
+
+const (
+	WaitingForPlayers GameState = iota
+	InProgress
+	Finished
+)
+
+// String returns a string representation of the game state
+func (gs GameState) String() string {
+	switch gs {
+	case WaitingForPlayers:
+		return "WAITING_FOR_PLAYERS"
+	case InProgress:
+		return "IN_PROGRESS"
+	case Finished:
+		return "FINISHED"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// Errors returned by game state transitions
+var (
+	ErrGameNotWaiting     = errors.New("game is not waiting for players")
+	ErrGameNotInProgress  = errors.New("game is not in progress")
+	ErrGameNotFinished    = errors.New("game is not finished")
+	ErrGameFull           = errors.New("game is full")
+	ErrNotEnoughPlayers   = errors.New("not enough players to start the game")
+	ErrNotPlayersTurn     = errors.New("it is not this player's turn")
+	ErrPlayerNotFound     = errors.New("player not found")
+	ErrInvalidWord        = errors.New("word not in dictionary")
+	ErrExchangeNotAllowed = errors.New("too few tiles remain in the bag to exchange")
+	ErrNoTilesExchanged   = errors.New("must exchange at least one tile")
+	ErrDuplicatePlayer    = errors.New("player is already in the game")
+	ErrDuplicateName      = errors.New("player name is already taken")
+)
+
+// MinTilesForExchange is the number of tiles that must remain in the bag for an
+// exchange under the standard rules
+const MinTilesForExchange = 7
+
+// MaxScorelessTurns is the number of consecutive passes and exchanges that ends the game
+const MaxScorelessTurns = 6
+
+// Game ties together the board, tile bag and players and enforces turn order
+type Game struct {
+	ID              string                   `json:"id"`
+	Board           *Board                   `json:"board"`
+	Players         []*Player                `json:"players"`
+	TileBag         *TileBag                 `json:"-"`
+	Dictionary      dictionary.Dictionary    `json:"-"`                         // Word list used to validate moves (nil accepts any word)
+	Generator       *MoveGenerator           `json:"-"`                         // Move generator used for suggestions (nil disables them)
+	DictionaryName  string                   `json:"dictionary_name,omitempty"` // Registry name of the dictionary, if attached by name
+	CurrentTurn     int                      `json:"current_turn"`              // Index into Players of the player to move
+	State           GameState                `json:"state"`
+	History         History                  `json:"history"`         // Actions taken, in order
+	Scoreless       int                      `json:"scoreless_turns"` // Consecutive passes and exchanges
+	ChallengeRule   ChallengeRule            `json:"challenge_rule"`
+	ExchangeMinimum int                      `json:"exchange_minimum"`        // Tiles that must remain in the bag to exchange
+	Teams           *TeamRules               `json:"teams,omitempty"`         // Team play settings (nil when players play alone)
+	Solo            *SoloRules               `json:"solo,omitempty"`          // Solo practice settings (nil when there are opponents)
+	Clock           *Clock                   `json:"clock,omitempty"`         // Game clocks (nil when untimed)
+	AbandonAfter    time.Duration            `json:"abandon_after,omitempty"` // Time on one turn after which the player to move forfeits (0 never)
+	Forfeit         *Forfeit                 `json:"forfeit,omitempty"`       // Who lost the game by resigning or abandoning it
+	Pauses          *PauseRules              `json:"pauses,omitempty"`        // Pauses allowed (nil when the game cannot be paused)
+	Paused          *Pause                   `json:"paused,omitempty"`        // Pause in progress
+	PauseRequest    *PauseRequest            `json:"pause_request,omitempty"` // Pause awaiting agreement
+	VacationUsed    map[string]time.Duration `json:"vacation_used,omitempty"` // Vacation time taken by each player
+	Revision        int64                    `json:"revision"`                // Incremented on every change, for optimistic locking
+	CreatedAt       time.Time                `json:"created_at"`
+	LastActivity    time.Time                `json:"last_activity"`
+	ExpiresAt       time.Time                `json:"expires_at"`
+	bots            map[string]Strategy      // Computer opponents keyed by player ID
+	coached         map[string]bool          // Players receiving feedback on their moves
+	feedback        []MoveFeedback           // Feedback given to coached players, in move order
+	annotations     []Annotation             // Notes on moves in the history, in turn order
+	challengeable   bool                     // True while the last move may still be challenged
+	losesTurn       map[string]bool          // Players who forfeit their next turn after a failed challenge
+	savedRevision   int64                    // Revision last written to or read from storage
+	events          []Event                  // Events recorded since creation or loading
+	eventBase       int                      // Events in the stream before those in events
+	subscribers     map[*Subscription]bool   // Spectators receiving live views
+	changed         chan struct{}            // Signalled on every change for a manager's turn limit watcher (nil without one)
+	undoLog         []gameSnapshot           // States before each turn action, most recent last
+	redoLog         []gameSnapshot           // States undone, most recent last
+	turnStarted     time.Time                // When the player to move began their turn
+	suggestion      *Move                    // Move suggested by the partner of the player to move
+	logger          *slog.Logger             // Destination of log records (nil when logging is off)
+	tracer          Tracer                   // Tracer timing move processing (nil when tracing is off)
+	debug           bool                     // Checks invariants after every change
+	mu              sync.RWMutex
+}
+
+// NewGame creates a new classic Scrabble game waiting for players
+// Players are seated in the order given; up to MaxPlayers may be supplied
+func NewGame(id string, players []*Player) (*Game, error) {
+	return NewGameWithVariant(id, players, Classic)
+}
+
+// NewGameWithVariant creates a new game waiting for players using the
+// variant's board layout, tile distribution and bingo bonus
+func NewGameWithVariant(id string, players []*Player, variant Variant) (*Game, error) {
+	return NewGameWithRules(id, players, RulesForVariant(variant))
+}
+
+// AddPlayer seats a new player at the end of the turn order
+// A player without an ID is given a generated one.
+func (g *Game) AddPlayer(p *Player) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.State != WaitingForPlayers {
+		return ErrGameNotWaiting
+	}
+
+	if err := g.addPlayer(p); err != nil {
+		return err
+	}
+	g.record(Event{Type: PlayerJoined, Players: seatCopies([]*Player{p})})
+	g.touch()
+	return nil
+}
+
+// addPlayer validates and appends a player, generating an ID if it has none;
+// callers must hold the lock
+func (g *Game) addPlayer(p *Player) error {
+	if p == nil {
+		return errors.New("player must not be nil")
+	}
+	if p.ID == "" {
+		p.ID = NewPlayerID()
+	}
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	if len(g.Players) >= MaxPlayers || g.Solo != nil && len(g.Players) >= 1 {
+		return ErrGameFull
+	}
+	if g.findPlayer(p.ID) != nil {
+		return fmt.Errorf("%w: %s", ErrDuplicatePlayer, p.ID)
+	}
+	for _, seated := range g.Players {
+		if strings.EqualFold(strings.TrimSpace(seated.Name), strings.TrimSpace(p.Name)) {
+			return fmt.Errorf("%w: %s", ErrDuplicateName, p.Name)
+		}
+	}
+
+	g.Players = append(g.Players, p)
+	if g.Clock != nil {
+		g.Clock.Remaining[p.ID] = g.Clock.Control.Base
+	}
+	return nil
+}
+
+// StartGame deals a full rack to every player and moves the game to InProgress
+func (g *Game) StartGame() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.State != WaitingForPlayers {
+		return ErrGameNotWaiting
+	}
+	switch {
+	case g.Solo != nil:
+		if len(g.Players) != 1 {
+			return ErrNotEnoughPlayers
+		}
+	case len(g.Players) < MinPlayers, g.Teams != nil && len(g.Players) != 2*TeamSize:
+		return ErrNotEnoughPlayers
+	}
+
+	racks := make([][]Tile, len(g.Players))
+	for i, p := range g.Players {
+		drawn, err := g.replenish(p)
+		if err != nil {
+			return err
+		}
+		racks[i] = drawn
+	}
+
+	g.CurrentTurn = 0
+	g.State = InProgress
+	g.turnStarted = time.Now()
+	g.switchClock(false)
+	g.record(Event{Type: GameStarted, Racks: racks})
+	g.touch()
+	return nil
+}
+
+// CurrentPlayer returns the player whose turn it is (nil if the game is not in progress)
+func (g *Game) CurrentPlayer() *Player {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.currentPlayer()
+}
+
+// currentPlayer returns the player to move; callers must hold the lock
+func (g *Game) currentPlayer() *Player {
+	if g.State != InProgress || len(g.Players) == 0 {
+		return nil
+	}
+	return g.Players[g.CurrentTurn]
+}
+
+// IsPlayersTurn returns true if the given player is the one to move
+func (g *Game) IsPlayersTurn(playerID string) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.checkTurn(playerID) == nil
+}
+
+// checkTurn returns an error unless playerID may act now; callers must hold the lock
+func (g *Game) checkTurn(playerID string) error {
+	if g.State != InProgress {
+		return ErrGameNotInProgress
+	}
+	if g.findPlayer(playerID) == nil {
+		return ErrPlayerNotFound
+	}
+	if g.Players[g.CurrentTurn].ID != playerID {
+		return ErrNotPlayersTurn
+	}
+	if g.Paused != nil {
+		return ErrGamePaused
+	}
+	return nil
+}
+
+// NextTurn advances play to the next active player
+func (g *Game) NextTurn() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.State != InProgress {
+		return ErrGameNotInProgress
+	}
+	if g.Paused != nil {
+		return ErrGamePaused
+	}
+
+	g.advanceTurn()
+	g.record(Event{Type: TurnSkipped})
+	g.touch()
+	return nil
+}
+
+// advanceTurn moves CurrentTurn to the next active player, skipping anyone who
+// has forfeited their turn; callers must hold the lock
+func (g *Game) advanceTurn() {
+	for i := 1; i <= 2*len(g.Players); i++ {
+		next := (g.CurrentTurn + i) % len(g.Players)
+		p := g.Players[next]
+		if !p.IsActive {
+			continue
+		}
+		if g.losesTurn[p.ID] {
+			delete(g.losesTurn, p.ID)
+			continue
+		}
+		g.CurrentTurn = next
+		g.turnStarted = time.Now()
+		g.suggestion = nil
+		g.switchClock(true)
+		return
+	}
+}
+
+// EndGame moves the game to the Finished state
+func (g *Game) EndGame() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.State != InProgress {
+		return ErrGameNotInProgress
+	}
+
+	g.State = Finished
+	g.settleClock()
+	g.endPause()
+	g.record(Event{Type: GameEnded})
+	g.touch()
+	return nil
+}
+
+// GetPlayer returns the player with the given ID (nil if not found)
+func (g *Game) GetPlayer(playerID string) *Player {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.findPlayer(playerID)
+}
+
+// findPlayer looks up a player by ID; callers must hold the lock
+func (g *Game) findPlayer(playerID string) *Player {
+	for _, p := range g.Players {
+		if p.ID == playerID {
+			return p
+		}
+	}
+	return nil
+}
+
+// UpdateLastActivity records activity on the game and extends its expiry
+func (g *Game) UpdateLastActivity() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.touch()
+}
+
+// touch updates activity timestamps and the revision and notifies spectators;
+// callers must hold the lock
+func (g *Game) touch() {
+	g.assertTiles()
+	g.Revision++
+	g.LastActivity = time.Now()
+	g.ExpiresAt = g.LastActivity.Add(DefaultGameExpiration)
+	g.notify()
+	if g.changed != nil {
+		select {
+		case g.changed <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// IsExpired returns true if the game has been inactive past its expiry time
+func (g *Game) IsExpired() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return time.Now().After(g.ExpiresAt)
+}
+
+// SetDictionary attaches a dictionary used to reject moves forming invalid words
+func (g *Game) SetDictionary(dict dictionary.Dictionary) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.Dictionary = dict
+	g.DictionaryName = ""
+}
+
+// UseDictionary attaches a dictionary from a registry by name, or the
+// registry's default if name is empty
+// The resolved name is saved with the game so a loaded game can be given the
+// same dictionary again; later changes to the registry do not affect the game.
+func (g *Game) UseDictionary(reg *dictionary.Registry, name string) error {
+	dict, resolved, err := reg.Get(name)
+	if err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.Dictionary = dict
+	g.DictionaryName = resolved
+	return nil
+}
+
+// SetMoveGenerator attaches the move generator used to suggest moves
+func (g *Game) SetMoveGenerator(generator *MoveGenerator) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.Generator = generator
+}
+
+// ValidateMove checks that a move is legal for the current player without applying it
+func (g *Game) ValidateMove(move Move) error {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.validateMove(move)
+}
+
+// validateMove checks turn order, placement and, under the void challenge rule
+// with a dictionary attached, every formed word; callers must hold the lock
+func (g *Game) validateMove(move Move) error {
+	if err := g.checkTurn(move.PlayerID); err != nil {
+		return err
+	}
+
+	player := g.Players[g.CurrentTurn]
+	if err := g.Board.ValidatePlacement(move, player.Rack); err != nil {
+		return err
+	}
+
+	if g.Dictionary != nil && g.ChallengeRule == VoidChallenge {
+		var invalid []FormedWord
+		for _, word := range g.Board.FormedWords(move) {
+			if !g.Dictionary.IsValid(word.Word) {
+				invalid = append(invalid, word)
+			}
+		}
+		if len(invalid) > 0 {
+			return invalidWordsError(invalid)
+		}
+	}
+
+	return nil
+}
+
+// PlayMove validates and applies a move for the current player
+// On success the tiles are placed, the score is recorded, the player's rack is
+// refilled from the bag and play passes to the next player. The game ends when
+// the player uses their last tile and the bag is empty.
+func (g *Game) PlayMove(move Move) (score int, err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ctx, span := g.startSpan(context.Background(), SpanPlayMove, g.traceAttrs(move.PlayerID)...)
+	defer func() { span.End(err) }()
+
+	if err := g.checkMove(ctx, move); err != nil {
+		return 0, err
+	}
+	move = withRackPoints(move, g.Players[g.CurrentTurn].Rack)
+	g.recordAction()
+
+	_, stage := g.startSpan(ctx, SpanScore)
+	words := g.Board.FormedWords(move)
+	score = g.Board.ScoreMove(move)
+	stage.SetAttributes(slog.Int("score", score))
+	stage.End(nil)
+
+	_, stage = g.startSpan(ctx, SpanApply)
+	err = g.applyMove(move, words, score)
+	stage.End(err)
+	if err != nil {
+		return 0, err
+	}
+
+	_, stage = g.startSpan(ctx, SpanNotify)
+	g.touch()
+	stage.End(nil)
+
+	return score, nil
+}
+
+// checkMove is the validation stage of PlayMove; callers must hold the lock
+func (g *Game) checkMove(ctx context.Context, move Move) (err error) {
+	_, span := g.startSpan(ctx, SpanValidate)
+	defer func() { span.End(err) }()
+
+	if err := g.enforceClock(); err != nil {
+		return err
+	}
+	if err := g.validateMove(move); err != nil {
+		g.logRejected("play", move.PlayerID, err)
+		return err
+	}
+	return nil
+}
+
+// applyMove places a validated move, scored as given, and records it in the
+// history and event stream; callers must hold the lock
+func (g *Game) applyMove(move Move, words []FormedWord, score int) error {
+	submitted := Move{
+		PlayerID:  move.PlayerID,
+		Word:      move.Word,
+		Start:     move.Start,
+		Direction: move.Direction,
+		Tiles:     append([]PlacedTile(nil), move.Tiles...),
+	}
+	player := g.Players[g.CurrentTurn]
+
+	// Moves given only as tiles take their word from the main formed word
+	if move.Word == "" && len(words) > 0 {
+		move.Word = words[0].Word
+		move.Start = words[0].Start
+		move.Direction = words[0].Direction
+	}
+
+	feedback, coached := g.review(move, player)
+	breakdown := g.Board.ExplainScore(move)
+	move.Breakdown = &breakdown
+	move.Rack = rackCopy(player.Rack)
+	indices, err := rackIndices(player.Rack, move.Tiles)
+	if err != nil {
+		return err
+	}
+	if _, err := player.RemoveTilesFromRack(indices); err != nil {
+		return err
+	}
+
+	for _, pt := range move.Tiles {
+		if err := g.Board.PlaceTile(pt.Tile, pt.Position); err != nil {
+			return err
+		}
+	}
+	g.Board.markPlaced(move.Positions(), len(g.History), move.PlayerID)
+
+	player.Score += score
+	drawn, err := g.replenish(player)
+	if err != nil {
+		return err
+	}
+
+	move.Drawn = drawn
+	move.Score = score
+	move.Words = make([]string, len(words))
+	for i, w := range words {
+		move.Words[i] = w.Word
+	}
+	move.Type = PlaceTiles
+	move.Phonies = g.phonies(words)
+	move.Total = player.Score
+	move.Timestamp = time.Now()
+	g.History = append(g.History, move)
+	g.Scoreless = 0
+	g.challengeable = true
+	if coached {
+		feedback.Turn = len(g.History) - 1
+		g.feedback = append(g.feedback, feedback)
+	}
+
+	if player.GetRackSize() == 0 && g.TileBag.IsEmpty() {
+		g.finish(player)
+	} else {
+		g.advanceTurn()
+	}
+	g.record(Event{Type: MovePlayed, Timestamp: move.Timestamp, PlayerID: move.PlayerID, Move: &submitted, Drawn: drawn})
+	return nil
+}
+
+// ExchangeTiles swaps the rack tiles at the given indices for new tiles from
+// the bag and ends the player's turn
+// New tiles are drawn before the old ones are returned, as the rules require.
+func (g *Game) ExchangeTiles(playerID string, indices []int) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := g.enforceClock(); err != nil {
+		return err
+	}
+	if err := g.checkExchange(playerID, indices); err != nil {
+		g.logRejected("exchange", playerID, err)
+		return err
+	}
+
+	player := g.Players[g.CurrentTurn]
+	g.recordAction()
+	rack := rackCopy(player.Rack)
+
+	returned, err := player.RemoveTilesFromRack(indices)
+	if err != nil {
+		return err
+	}
+
+	drawn, err := g.TileBag.Exchange(returned, len(returned))
+	if err != nil {
+		return err
+	}
+	if err := player.AddTilesToRack(drawn); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	g.History = append(g.History, Move{
+		Type:      Exchange,
+		PlayerID:  playerID,
+		Timestamp: now,
+		Exchanged: returned,
+		Rack:      rack,
+		Total:     player.Score,
+	})
+	g.challengeable = false
+	g.endScorelessTurn()
+	g.record(Event{Type: TilesExchanged, Timestamp: now, PlayerID: playerID, Indices: append([]int(nil), indices...), Drawn: drawn})
+	g.touch()
+
+	return nil
+}
+
+// checkExchange verifies that the player may exchange the tiles at the given
+// rack indices; callers must hold the lock
+func (g *Game) checkExchange(playerID string, indices []int) error {
+	if err := g.checkTurn(playerID); err != nil {
+		return err
+	}
+	if len(indices) == 0 {
+		return ErrNoTilesExchanged
+	}
+	if remaining := g.TileBag.RemainingCount(); remaining < g.ExchangeMinimum || remaining < len(indices) {
+		return ErrExchangeNotAllowed
+	}
+	return g.Players[g.CurrentTurn].checkRackIndices(indices)
+}
+
+// PassTurn ends the player's turn without playing
+func (g *Game) PassTurn(playerID string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := g.enforceClock(); err != nil {
+		return err
+	}
+	if err := g.checkTurn(playerID); err != nil {
+		g.logRejected("pass", playerID, err)
+		return err
+	}
+	g.recordAction()
+
+	player := g.Players[g.CurrentTurn]
+	now := time.Now()
+	g.History = append(g.History, Move{
+		Type:      Pass,
+		PlayerID:  playerID,
+		Timestamp: now,
+		Rack:      rackCopy(player.Rack),
+		Total:     player.Score,
+	})
+	g.challengeable = false
+	g.endScorelessTurn()
+	g.record(Event{Type: TurnPassed, Timestamp: now, PlayerID: playerID})
+	g.touch()
+
+	return nil
+}
+
+// endScorelessTurn counts a pass or exchange and either ends the game after
+// MaxScorelessTurns in a row or passes play on; callers must hold the lock
+func (g *Game) endScorelessTurn() {
+	g.Scoreless++
+	if g.Scoreless >= MaxScorelessTurns {
+		g.finish(nil)
+		return
+	}
+	g.advanceTurn()
+}
+
+// rackIndices finds the rack index of each placed tile
+// Blank tiles match any blank in the rack regardless of their assigned letter.
+func rackIndices(rack []Tile, tiles []PlacedTile) ([]int, error) {
+	used := make([]bool, len(rack))
+	indices := make([]int, 0, len(tiles))
+
+	for _, pt := range tiles {
+		found := -1
+		for i, t := range rack {
+			if used[i] || t.IsBlank != pt.Tile.IsBlank {
+				continue
+			}
+			if t.IsBlank || t.Letter == pt.Tile.Letter {
+				found = i
+				break
+			}
+		}
+		if found < 0 {
+			return nil, ErrTilesNotInRack
+		}
+		used[found] = true
+		indices = append(indices, found)
+	}
+
+	return indices, nil
+}
+
+// finish ends the game and applies end-of-game rack adjustments
+// Each player loses the value of their remaining tiles; if a player went out,
+// they gain the total of everyone else's remaining tiles, or in team play the
+// total of their opponents'. Solo games are not adjusted. Callers must hold
+// the lock.
+func (g *Game) finish(wentOut *Player) {
+	if g.Solo != nil {
+		g.State = Finished
+		g.settleClock()
+		return
+	}
+
+	var partner *Player
+	if g.Teams != nil && wentOut != nil {
+		partner = g.partner(wentOut.ID)
+	}
+
+	remaining := 0
+	for _, p := range g.Players {
+		rackValue := 0
+		for _, t := range p.Rack {
+			rackValue += t.Points
+		}
+		p.Score -= rackValue
+		if p != partner {
+			remaining += rackValue
+		}
+	}
+
+	if wentOut != nil {
+		wentOut.Score += remaining
+	}
+
+	g.State = Finished
+	g.settleClock()
+}
+
+// AttachBot hands control of a seated player to a computer opponent
+func (g *Game) AttachBot(bot *Bot) error {
+	return g.AttachStrategy(bot.PlayerID, botStrategy{bot})
+}
+
+// AttachStrategy hands control of a seated player to a strategy, which
+// replaces any bot or strategy already playing for them
+func (g *Game) AttachStrategy(playerID string, s Strategy) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.findPlayer(playerID) == nil {
+		return ErrPlayerNotFound
+	}
+	g.bots[playerID] = s
+	return nil
+}
+
+// IsBot returns true if the given player is controlled by a bot or strategy
+func (g *Game) IsBot(playerID string) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	_, ok := g.bots[playerID]
+	return ok
+}
+
+// PlayBotTurns plays moves for bot-controlled players until it is a human
+// player's turn or the game ends, returning the moves played
+// A bot with no legal move passes.
+func (g *Game) PlayBotTurns() ([]Move, error) {
+	return g.PlayBotTurnsContext(context.Background())
+}
+
+// PlayBotTurnsContext plays bot turns as PlayBotTurns does, passing ctx to
+// each strategy so that a slow engine can be cancelled
+// Strategies choose without holding the game's lock, from a view revealing
+// only the rack of the player to move.
+func (g *Game) PlayBotTurnsContext(ctx context.Context) ([]Move, error) {
+	played := []Move{}
+
+	for {
+		g.mu.Lock()
+		player := g.currentPlayer()
+		if player == nil {
+			g.mu.Unlock()
+			return played, nil
+		}
+		strategy, ok := g.bots[player.ID]
+		if !ok || g.Paused != nil {
+			g.mu.Unlock()
+			return played, nil
+		}
+		view := g.view(ViewOptions{PlayerID: player.ID})
+		spanCtx, span := g.startSpan(ctx, SpanBotSearch, g.traceAttrs(player.ID)...)
+		g.mu.Unlock()
+
+		move, err := strategy.ChooseMove(spanCtx, view)
+		found := err == nil
+		if errors.Is(err, ErrNoMove) {
+			err = nil
+		}
+		span.SetAttributes(slog.String("strategy", strategyName(strategy)), slog.Bool("found", found))
+		span.End(err)
+		if err != nil {
+			return played, err
+		}
+
+		if found {
+			move.PlayerID = player.ID
+			if _, err := g.PlayMove(move); err != nil {
+				return played, err
+			}
+		} else if err := g.PassTurn(player.ID); err != nil {
+			return played, err
+		}
+		played = append(played, g.lastMove())
+	}
+}
+
+// lastMove returns the most recent entry in the history
+func (g *Game) lastMove() Move {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	last, _ := g.History.Last()
+	return last
+}
@@ -0,0 +1,573 @@
+package game
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"scrabbled/internal/dictionary"
+)
+
+// newTestGame creates a game with the given number of players named p1..pN
+func newTestGame(t *testing.T, numPlayers int) *Game {
+	t.Helper()
+
+	players := make([]*Player, numPlayers)
+	for i := range players {
+		id := string(rune('1' + i))
+		players[i] = NewPlayer("p"+id, "Player "+id)
+	}
+
+	g, err := NewGame("test-game", players)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	return g
+}
+
+// TestGameInitialization tests game creation and initial state
+func TestGameInitialization(t *testing.T) {
+	g := newTestGame(t, 2)
+
+	if g.State != WaitingForPlayers {
+		t.Errorf("New game should be waiting for players, got %s", g.State)
+	}
+
+	if len(g.Players) != 2 {
+		t.Errorf("Game should have 2 players, got %d", len(g.Players))
+	}
+
+	if g.Board == nil || g.TileBag == nil {
+		t.Fatalf("Game should own a board and tile bag")
+	}
+
+	if g.TileBag.RemainingCount() != 100 {
+		t.Errorf("Tile bag should have 100 tiles before start, got %d", g.TileBag.RemainingCount())
+	}
+
+	if g.CurrentPlayer() != nil {
+		t.Errorf("CurrentPlayer should be nil before the game starts")
+	}
+
+	if !g.ExpiresAt.After(g.CreatedAt) {
+		t.Errorf("ExpiresAt should be after CreatedAt")
+	}
+
+	// Too many players
+	players := make([]*Player, MaxPlayers+1)
+	for i := range players {
+		players[i] = NewPlayer(string(rune('a'+i)), "Player")
+	}
+	if _, err := NewGame("too-many", players); err == nil {
+		t.Errorf("Creating a game with %d players should fail", MaxPlayers+1)
+	}
+
+	// Duplicate player IDs
+	if _, err := NewGame("dup", []*Player{NewPlayer("p1", "A"), NewPlayer("p1", "B")}); err == nil {
+		t.Errorf("Creating a game with duplicate player IDs should fail")
+	}
+}
+
+// TestAddPlayer tests seating players before the game starts
+func TestAddPlayer(t *testing.T) {
+	g := newTestGame(t, 0)
+
+	for i := 0; i < MaxPlayers-1; i++ {
+		id := string(rune('a' + i))
+		if err := g.AddPlayer(NewPlayer(id, "Player "+id)); err != nil {
+			t.Errorf("Should be able to add player %d: %v", i+1, err)
+		}
+	}
+
+	if err := g.AddPlayer(NewPlayer("a", "Someone Else")); !errors.Is(err, ErrDuplicatePlayer) {
+		t.Errorf("Adding a duplicate ID should return ErrDuplicatePlayer, got %v", err)
+	}
+	if err := g.AddPlayer(NewPlayer("y", " player A ")); !errors.Is(err, ErrDuplicateName) {
+		t.Errorf("Adding a duplicate name should return ErrDuplicateName, got %v", err)
+	}
+	if err := g.AddPlayer(NewPlayer(NewPlayerID(), "Player d")); err != nil {
+		t.Errorf("Should be able to add a player with a generated ID: %v", err)
+	}
+
+	if err := g.AddPlayer(NewPlayer("z", "Player z")); !errors.Is(err, ErrGameFull) {
+		t.Errorf("Adding a fifth player should return ErrGameFull, got %v", err)
+	}
+
+	if err := g.AddPlayer(nil); err == nil {
+		t.Errorf("Adding a nil player should fail")
+	}
+}
+
+// TestAddPlayerWithoutID tests that players added without IDs get distinct UUIDs
+func TestAddPlayerWithoutID(t *testing.T) {
+	g := newTestGame(t, 0)
+	ann, bob := NewPlayer("", "Ann"), NewPlayer("", "Bob")
+	for _, p := range []*Player{ann, bob} {
+		if err := g.AddPlayer(p); err != nil {
+			t.Fatalf("Should add a player without an ID: %v", err)
+		}
+		if !uuidPattern.MatchString(p.ID) {
+			t.Errorf("Player %s should get a version 4 UUID, got %q", p.Name, p.ID)
+		}
+	}
+	if ann.ID == bob.ID {
+		t.Errorf("Generated IDs should differ, both got %q", ann.ID)
+	}
+}
+
+// TestStartGame tests dealing racks and the transition to InProgress
+func TestStartGame(t *testing.T) {
+	// Not enough players
+	g := newTestGame(t, 1)
+	if err := g.StartGame(); !errors.Is(err, ErrNotEnoughPlayers) {
+		t.Errorf("Starting with 1 player should return ErrNotEnoughPlayers, got %v", err)
+	}
+
+	for _, numPlayers := range []int{2, 3, 4} {
+		g := newTestGame(t, numPlayers)
+		if err := g.StartGame(); err != nil {
+			t.Fatalf("Starting a %d player game should succeed: %v", numPlayers, err)
+		}
+
+		if g.State != InProgress {
+			t.Errorf("Game should be in progress after start, got %s", g.State)
+		}
+
+		for _, p := range g.Players {
+			if p.GetRackSize() != MaxRackSize {
+				t.Errorf("Player %s should have %d tiles, got %d", p.ID, MaxRackSize, p.GetRackSize())
+			}
+		}
+
+		expected := 100 - numPlayers*MaxRackSize
+		if g.TileBag.RemainingCount() != expected {
+			t.Errorf("Tile bag should have %d tiles, got %d", expected, g.TileBag.RemainingCount())
+		}
+
+		// Starting twice should fail
+		if err := g.StartGame(); !errors.Is(err, ErrGameNotWaiting) {
+			t.Errorf("Starting twice should return ErrGameNotWaiting, got %v", err)
+		}
+
+		// No more players may join
+		if err := g.AddPlayer(NewPlayer("late", "Late")); !errors.Is(err, ErrGameNotWaiting) {
+			t.Errorf("Joining a started game should return ErrGameNotWaiting, got %v", err)
+		}
+	}
+}
+
+// TestTurnProgression tests turn order and NextTurn
+func TestTurnProgression(t *testing.T) {
+	g := newTestGame(t, 3)
+
+	if err := g.NextTurn(); !errors.Is(err, ErrGameNotInProgress) {
+		t.Errorf("NextTurn before start should return ErrGameNotInProgress, got %v", err)
+	}
+
+	g.StartGame()
+
+	expected := []string{"p1", "p2", "p3", "p1", "p2"}
+	for i, id := range expected {
+		current := g.CurrentPlayer()
+		if current == nil || current.ID != id {
+			t.Fatalf("Turn %d: expected %s to move, got %v", i, id, current)
+		}
+		if !g.IsPlayersTurn(id) {
+			t.Errorf("Turn %d: IsPlayersTurn(%s) should be true", i, id)
+		}
+		if err := g.NextTurn(); err != nil {
+			t.Errorf("NextTurn should succeed: %v", err)
+		}
+	}
+
+	// Inactive players are skipped
+	g.Players[0].IsActive = false
+	g.CurrentTurn = 2
+	g.NextTurn()
+	if g.CurrentPlayer().ID != "p2" {
+		t.Errorf("Inactive player should be skipped, got %s", g.CurrentPlayer().ID)
+	}
+}
+
+// TestTurnEnforcement tests that only the current player may act
+func TestTurnEnforcement(t *testing.T) {
+	g := newTestGame(t, 2)
+	g.StartGame()
+
+	if err := g.checkTurn("p1"); err != nil {
+		t.Errorf("p1 should be allowed to act: %v", err)
+	}
+
+	if err := g.checkTurn("p2"); !errors.Is(err, ErrNotPlayersTurn) {
+		t.Errorf("p2 should get ErrNotPlayersTurn, got %v", err)
+	}
+
+	if err := g.checkTurn("nobody"); !errors.Is(err, ErrPlayerNotFound) {
+		t.Errorf("Unknown player should get ErrPlayerNotFound, got %v", err)
+	}
+}
+
+// TestEndGame tests the transition to Finished
+func TestEndGame(t *testing.T) {
+	g := newTestGame(t, 2)
+
+	if err := g.EndGame(); !errors.Is(err, ErrGameNotInProgress) {
+		t.Errorf("Ending a game that has not started should fail, got %v", err)
+	}
+
+	g.StartGame()
+	if err := g.EndGame(); err != nil {
+		t.Errorf("Ending an in-progress game should succeed: %v", err)
+	}
+
+	if g.State != Finished {
+		t.Errorf("Game should be finished, got %s", g.State)
+	}
+
+	if g.CurrentPlayer() != nil {
+		t.Errorf("CurrentPlayer should be nil after the game ends")
+	}
+
+	if err := g.NextTurn(); !errors.Is(err, ErrGameNotInProgress) {
+		t.Errorf("NextTurn after end should return ErrGameNotInProgress, got %v", err)
+	}
+}
+
+// TestActivityTracking tests activity timestamps and expiration
+func TestActivityTracking(t *testing.T) {
+	g := newTestGame(t, 2)
+
+	if g.IsExpired() {
+		t.Errorf("New game should not be expired")
+	}
+
+	g.LastActivity = time.Now().Add(-2 * DefaultGameExpiration)
+	g.ExpiresAt = g.LastActivity.Add(DefaultGameExpiration)
+	if !g.IsExpired() {
+		t.Errorf("Game inactive past its expiry should be expired")
+	}
+
+	g.UpdateLastActivity()
+	if g.IsExpired() {
+		t.Errorf("Game should not be expired after activity")
+	}
+}
+
+// TestGameStateString tests GameState string conversion
+func TestGameStateString(t *testing.T) {
+	testCases := map[GameState]string{
+		WaitingForPlayers: "WAITING_FOR_PLAYERS",
+		InProgress:        "IN_PROGRESS",
+		Finished:          "FINISHED",
+		GameState(99):     "UNKNOWN",
+	}
+
+	for state, expected := range testCases {
+		if state.String() != expected {
+			t.Errorf("Expected %s, got %s", expected, state.String())
+		}
+	}
+}
+
+// TestPlayMove tests move application: board updates, scoring and tile management
+func TestPlayMove(t *testing.T) {
+	g := newTestGame(t, 2)
+	g.StartGame()
+	g.Players[0].Rack = rackOf("CATXYZQ")
+
+	move, _ := g.Board.BuildMove("p1", "CAT", mustPos(t, "G8"), Horizontal, nil)
+
+	// Out of turn
+	outOfTurn := move
+	outOfTurn.PlayerID = "p2"
+	if _, err := g.PlayMove(outOfTurn); !errors.Is(err, ErrNotPlayersTurn) {
+		t.Errorf("Out of turn move should return ErrNotPlayersTurn, got %v", err)
+	}
+
+	bagBefore := g.TileBag.RemainingCount()
+	score, err := g.PlayMove(move)
+	if err != nil {
+		t.Fatalf("CAT should be playable: %v", err)
+	}
+
+	if score != 10 || g.Players[0].Score != 10 {
+		t.Errorf("CAT through center should score 10, got %d (player score %d)", score, g.Players[0].Score)
+	}
+
+	if g.Board.GetTile(mustPos(t, "H8")) == nil || g.Board.GetTile(mustPos(t, "H8")).Letter != 'A' {
+		t.Errorf("A should be on H8 after the move")
+	}
+
+	if g.Players[0].GetRackSize() != MaxRackSize {
+		t.Errorf("Rack should be refilled to %d, got %d", MaxRackSize, g.Players[0].GetRackSize())
+	}
+
+	if g.TileBag.RemainingCount() != bagBefore-3 {
+		t.Errorf("Bag should have %d tiles, got %d", bagBefore-3, g.TileBag.RemainingCount())
+	}
+
+	if len(g.History) != 1 || g.History[0].Score != 10 || len(g.History[0].Words) != 1 {
+		t.Errorf("History should record the move, got %+v", g.History)
+	}
+
+	if g.CurrentPlayer().ID != "p2" {
+		t.Errorf("Turn should pass to p2, got %s", g.CurrentPlayer().ID)
+	}
+
+	// Invalid placement leaves state unchanged
+	g.Players[1].Rack = rackOf("DOGSABC")
+	bad, _ := g.Board.BuildMove("p2", "DOG", mustPos(t, "A1"), Horizontal, nil)
+	if _, err := g.PlayMove(bad); !errors.Is(err, ErrNotConnected) {
+		t.Errorf("Disconnected move should return ErrNotConnected, got %v", err)
+	}
+	if g.Players[1].GetRackSize() != MaxRackSize || g.CurrentPlayer().ID != "p2" {
+		t.Errorf("Failed move should not change rack or turn")
+	}
+}
+
+// TestPlayMoveForgedPoints tests that placed tiles are worth what the rack's
+// tiles are worth, whatever the submitted move claims
+func TestPlayMoveForgedPoints(t *testing.T) {
+	g := newTestGame(t, 2)
+	g.StartGame()
+	g.Players[0].Rack = rackOf("CATXYZQ")
+
+	move, _ := g.Board.BuildMove("p1", "CAT", mustPos(t, "G8"), Horizontal, nil)
+	for i := range move.Tiles {
+		move.Tiles[i].Tile.Points = 200
+	}
+	score, err := g.PlayMove(move)
+	if err != nil {
+		t.Fatalf("CAT should be playable: %v", err)
+	}
+	if score != 10 || g.Players[0].Score != 10 {
+		t.Errorf("Forged points should be ignored, got score %d", score)
+	}
+	if tile := g.Board.GetTile(mustPos(t, "G8")); tile == nil || tile.Points != 3 {
+		t.Errorf("C should be placed worth 3, got %v", tile)
+	}
+	if pts := g.History[0].Tiles[0].Tile.Points; pts != 3 {
+		t.Errorf("History should record C worth 3, got %d", pts)
+	}
+}
+
+// TestPlayMoveGoingOut tests the game end when a player uses their last tile
+func TestPlayMoveGoingOut(t *testing.T) {
+	g := newTestGame(t, 2)
+	g.StartGame()
+	g.TileBag.DrawTiles(100)
+	g.Players[0].Rack = rackOf("AT")
+	g.Players[1].Rack = rackOf("QZ")
+
+	move, _ := g.Board.BuildMove("p1", "AT", mustPos(t, "H8"), Horizontal, nil)
+	if _, err := g.PlayMove(move); err != nil {
+		t.Fatalf("AT should be playable: %v", err)
+	}
+
+	if g.State != Finished {
+		t.Errorf("Game should end when a player goes out with an empty bag, got %s", g.State)
+	}
+
+	// AT scores 4, plus Q(10)+Z(10) from the opponent
+	if g.Players[0].Score != 24 {
+		t.Errorf("Player going out should score 24, got %d", g.Players[0].Score)
+	}
+	if g.Players[1].Score != -20 {
+		t.Errorf("Opponent should lose 20, got %d", g.Players[1].Score)
+	}
+}
+
+// TestDictionaryValidation tests that moves forming invalid words are rejected
+func TestDictionaryValidation(t *testing.T) {
+	dict := dictionary.NewWordList(dictionary.Custom)
+	for _, word := range []string{"CAT", "CATS"} {
+		dict.AddWord(word)
+	}
+
+	g := newTestGame(t, 2)
+	g.SetDictionary(dict)
+	g.StartGame()
+	g.Players[0].Rack = rackOf("CATXZQK")
+
+	invalid, _ := g.Board.BuildMove("p1", "TAC", mustPos(t, "G8"), Horizontal, nil)
+	if err := g.ValidateMove(invalid); !errors.Is(err, ErrInvalidWord) {
+		t.Errorf("TAC should return ErrInvalidWord, got %v", err)
+	}
+	if _, err := g.PlayMove(invalid); !errors.Is(err, ErrInvalidWord) {
+		t.Errorf("Playing TAC should return ErrInvalidWord, got %v", err)
+	}
+
+	valid, _ := g.Board.BuildMove("p1", "CAT", mustPos(t, "G8"), Horizontal, nil)
+	if err := g.ValidateMove(valid); err != nil {
+		t.Errorf("CAT should be valid: %v", err)
+	}
+
+	// Without a dictionary any word is accepted
+	g.SetDictionary(nil)
+	if err := g.ValidateMove(invalid); err != nil {
+		t.Errorf("TAC should be accepted without a dictionary: %v", err)
+	}
+}
+
+// TestUseDictionary tests selecting a game's dictionary from a registry
+func TestUseDictionary(t *testing.T) {
+	twl := dictionary.NewWordList(dictionary.TWL06)
+	twl.AddWord("CAT")
+	club := dictionary.NewWordList(dictionary.Custom)
+	club.AddWord("TAC")
+
+	reg := dictionary.NewRegistry()
+	reg.Register("TWL06", twl)
+	reg.Register("club", club)
+
+	g := newTestGame(t, 2)
+	if err := g.UseDictionary(reg, ""); err != nil {
+		t.Fatalf("Default dictionary should be found: %v", err)
+	}
+	if g.Dictionary != twl || g.DictionaryName != "TWL06" {
+		t.Errorf("Game should use the default TWL06, got %q", g.DictionaryName)
+	}
+
+	if err := g.UseDictionary(reg, "Club"); err != nil {
+		t.Fatalf("Club dictionary should be found: %v", err)
+	}
+	if g.Dictionary != club || g.DictionaryName != "CLUB" {
+		t.Errorf("Game should use the club list, got %q", g.DictionaryName)
+	}
+
+	// Swapping the default leaves games that already chose alone
+	reg.SetDefault("club")
+	other := newTestGame(t, 2)
+	other.UseDictionary(reg, "")
+	if other.DictionaryName != "CLUB" || g.Dictionary != club {
+		t.Errorf("New game should use the new default, got %q", other.DictionaryName)
+	}
+
+	if err := g.UseDictionary(reg, "CSW21"); !errors.Is(err, dictionary.ErrDictionaryNotFound) {
+		t.Errorf("Unknown name should return ErrDictionaryNotFound, got %v", err)
+	}
+	if g.DictionaryName != "CLUB" {
+		t.Errorf("Failed selection should keep the current dictionary, got %q", g.DictionaryName)
+	}
+
+	// The name survives a save so the dictionary can be attached again
+	g.StartGame()
+	data, err := g.Serialize()
+	if err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	restored, err := LoadGame(data)
+	if err != nil {
+		t.Fatalf("LoadGame failed: %v", err)
+	}
+	if restored.DictionaryName != "CLUB" || restored.Dictionary != nil {
+		t.Errorf("Restored game should carry the name but no dictionary, got %q", restored.DictionaryName)
+	}
+	if err := restored.UseDictionary(reg, restored.DictionaryName); err != nil || restored.Dictionary != club {
+		t.Errorf("Restored game should reattach its dictionary: %v", err)
+	}
+
+	g.SetDictionary(nil)
+	if g.DictionaryName != "" {
+		t.Errorf("SetDictionary should clear the registry name, got %q", g.DictionaryName)
+	}
+}
+
+// TestExchangeTiles tests exchanging rack tiles with the bag
+func TestExchangeTiles(t *testing.T) {
+	g := newTestGame(t, 2)
+	g.StartGame()
+	g.Players[0].Rack = rackOf("QQVVWWX")
+
+	if err := g.ExchangeTiles("p2", []int{0}); !errors.Is(err, ErrNotPlayersTurn) {
+		t.Errorf("Out of turn exchange should return ErrNotPlayersTurn, got %v", err)
+	}
+
+	if err := g.ExchangeTiles("p1", []int{9}); err == nil {
+		t.Errorf("Exchange with an invalid index should fail")
+	}
+
+	if err := g.ExchangeTiles("p1", nil); err == nil {
+		t.Errorf("Exchange of no tiles should fail")
+	}
+
+	bagBefore := g.TileBag.RemainingCount()
+	if err := g.ExchangeTiles("p1", []int{0, 1, 2}); err != nil {
+		t.Fatalf("Exchange should succeed: %v", err)
+	}
+
+	if g.Players[0].GetRackSize() != MaxRackSize {
+		t.Errorf("Rack should still have %d tiles, got %d", MaxRackSize, g.Players[0].GetRackSize())
+	}
+	if g.TileBag.RemainingCount() != bagBefore {
+		t.Errorf("Bag size should be unchanged after exchange, got %d", g.TileBag.RemainingCount())
+	}
+	if g.CurrentPlayer().ID != "p2" {
+		t.Errorf("Exchange should consume the turn")
+	}
+
+	last := g.History[len(g.History)-1]
+	if last.Type != Exchange || len(last.Exchanged) != 3 || last.Exchanged[0].Letter != 'Q' {
+		t.Errorf("History should record the exchange, got %+v", last)
+	}
+
+	// Not allowed with fewer than 7 tiles in the bag
+	g.TileBag.DrawTiles(g.TileBag.RemainingCount() - (MinTilesForExchange - 1))
+	if err := g.ExchangeTiles("p2", []int{0}); !errors.Is(err, ErrExchangeNotAllowed) {
+		t.Errorf("Exchange with %d tiles in bag should return ErrExchangeNotAllowed, got %v", g.TileBag.RemainingCount(), err)
+	}
+}
+
+// TestPassTurn tests passing and the six scoreless turns game end
+func TestPassTurn(t *testing.T) {
+	g := newTestGame(t, 2)
+	g.StartGame()
+
+	if err := g.PassTurn("p2"); !errors.Is(err, ErrNotPlayersTurn) {
+		t.Errorf("Out of turn pass should return ErrNotPlayersTurn, got %v", err)
+	}
+
+	if err := g.PassTurn("p1"); err != nil {
+		t.Fatalf("Pass should succeed: %v", err)
+	}
+	if g.CurrentPlayer().ID != "p2" || g.Scoreless != 1 {
+		t.Errorf("Pass should consume the turn and count as scoreless")
+	}
+	if last := g.History[len(g.History)-1]; last.Type != Pass || last.PlayerID != "p1" {
+		t.Errorf("History should record the pass, got %+v", last)
+	}
+
+	// A scoring play resets the count
+	g.Players[1].Rack = rackOf("CATXYZQ")
+	move, _ := g.Board.BuildMove("p2", "CAT", mustPos(t, "G8"), Horizontal, nil)
+	if _, err := g.PlayMove(move); err != nil {
+		t.Fatalf("CAT should be playable: %v", err)
+	}
+	if g.Scoreless != 0 {
+		t.Errorf("Scoring play should reset scoreless turns, got %d", g.Scoreless)
+	}
+
+	// Passes and exchanges both count towards the limit
+	g.Players[0].Rack = rackOf("QZ")
+	g.Players[1].Rack = rackOf("AE")
+	g.ExchangeTiles("p1", []int{0})
+	g.Players[0].Rack = rackOf("QZ")
+	for i := 1; i < MaxScorelessTurns; i++ {
+		if g.State != InProgress {
+			t.Fatalf("Game ended early after %d scoreless turns", i)
+		}
+		g.PassTurn(g.CurrentPlayer().ID)
+	}
+
+	if g.State != Finished {
+		t.Fatalf("Game should end after %d scoreless turns, got %s", MaxScorelessTurns, g.State)
+	}
+
+	// Each player loses their remaining rack value and nobody gains
+	if g.Players[0].Score != -20 {
+		t.Errorf("p1 should lose Q+Z (20), got %d", g.Players[0].Score)
+	}
+	if g.Players[1].Score != 10-2 {
+		t.Errorf("p2 should have 10 minus A+E (2), got %d", g.Players[1].Score)
+	}
+}
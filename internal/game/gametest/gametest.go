@@ -0,0 +1,154 @@
+// Package gametest generates random racks, boards and games and checks the
+// invariants every game must keep, so that code integrating with package game
+// can be property-tested against many positions rather than a few hand-built
+// ones
+//
+// Generators take a *rand.Rand and are deterministic for a given seed; Run
+// reports the seed of a failing game so the failure can be reproduced.
+package gametest
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"scrabbled/internal/game"
+)
+
+// Words is the word list random games are played with unless Config.Words is set
+// It is small enough to build a move generator quickly yet playable enough
+// that games run to an empty bag rather than ending in passes.
+var Words = []string{
+	"AA", "AB", "AD", "AE", "AG", "AH", "AI", "AL", "AM", "AN", "AR", "AS", "AT", "AW", "AX", "AY",
+	"BA", "BE", "BI", "BO", "BY", "DA", "DE", "DO", "ED", "EF", "EH", "EL", "EM", "EN", "ER", "ES",
+	"EX", "FA", "FE", "GO", "HA", "HE", "HI", "HM", "HO", "ID", "IF", "IN", "IS", "IT", "JO", "KA",
+	"KI", "LA", "LI", "LO", "MA", "ME", "MI", "MO", "MU", "MY", "NA", "NE", "NO", "NU", "OD", "OE",
+	"OF", "OH", "OI", "OM", "ON", "OP", "OR", "OS", "OW", "OX", "OY", "PA", "PE", "PI", "QI", "RE",
+	"SH", "SI", "SO", "TA", "TI", "TO", "UH", "UM", "UN", "UP", "US", "UT", "WE", "WO", "XI", "XU",
+	"YA", "YE", "YO", "ZA",
+	"ACE", "ACT", "AGE", "AID", "AIR", "ANT", "APE", "ARE", "ART", "ATE", "BAD", "BAG", "BAT", "BED",
+	"BET", "BIG", "BIT", "BOX", "BOY", "BUG", "BUT", "CAB", "CAN", "CAR", "CAT", "COW", "CRY", "CUP",
+	"DEN", "DIG", "DOG", "DOT", "DRY", "DUE", "EAR", "EAT", "EEL", "EGG", "END", "ERA", "EVE", "FAN",
+	"FAR", "FAT", "FED", "FEW", "FIG", "FIN", "FIT", "FLY", "FOE", "FOG", "FOR", "FOX", "FUN", "FUR",
+	"GAS", "GEM", "GET", "GOD", "GUM", "GUN", "GUT", "HAT", "HEN", "HER", "HID", "HIM", "HIP", "HIS",
+	"HIT", "HOT", "HOW", "HUG", "ICE", "ILL", "INK", "ION", "IRE", "JAM", "JAR", "JAW", "JET", "JOB",
+	"JOG", "JOY", "KEY", "KID", "KIN", "KIT", "LAD", "LAP", "LAW", "LAY", "LED", "LEG", "LET", "LID",
+	"LIE", "LIP", "LOG", "LOT", "LOW", "MAD", "MAN", "MAP", "MAT", "MEN", "MET", "MIX", "MOB", "MUD",
+	"NAP", "NET", "NEW", "NOD", "NOR", "NOT", "NOW", "NUT", "OAK", "OAR", "ODD", "OIL", "OLD", "ONE",
+	"OPT", "ORE", "OUR", "OUT", "OWE", "OWL", "OWN", "PAD", "PAN", "PAT", "PAW", "PAY", "PEA", "PEN",
+	"PET", "PIE", "PIG", "PIN", "PIT", "POT", "PUT", "QUA", "RAG", "RAN", "RAT", "RAW", "RED", "RIB",
+	"RID", "RIM", "RIP", "ROD", "ROT", "ROW", "RUB", "RUG", "RUN", "SAD", "SAT", "SAW", "SAY", "SEA",
+	"SET", "SEW", "SHE", "SIN", "SIP", "SIT", "SIX", "SKI", "SKY", "SOD", "SON", "SOW", "SOY", "SPA",
+	"SUN", "TAB", "TAN", "TAP", "TAR", "TEA", "TEN", "TIE", "TIN", "TIP", "TOE", "TON", "TOO", "TOP",
+	"TOY", "TUB", "TUG", "TWO", "URN", "USE", "VAN", "VAT", "VET", "VIA", "VOW", "WAR", "WAS", "WAX",
+	"WAY", "WEB", "WED", "WET", "WHO", "WHY", "WIG", "WIN", "WIT", "WON", "YAK", "YAM", "YAP", "YES",
+	"YET", "YEW", "ZAP", "ZED", "ZEN", "ZIP", "ZOO",
+	"BATS", "CATS", "DOGS", "RATE", "TEAR", "STAR", "RAIN", "REIN", "TONE", "NOTE", "STONE", "NOTES",
+	"TONES", "RATES", "TEARS", "STARE", "EARN", "NEAR", "SNARE", "EARNS", "RAINS", "TRAIN", "TRAINS",
+	"RETAIN", "RETAINS", "STAINER", "RETINAS", "NASTIER",
+}
+
+// Config controls the games RandomGame plays
+type Config struct {
+	Players  int          // Players in the game, MinPlayers to MaxPlayers (0 means 2)
+	Variant  game.Variant // Board layout and tile distribution
+	Words    []string     // Word list the players choose from (nil means Words)
+	MaxTurns int          // Turns after which to stop, leaving the game in progress (0 plays to the end)
+}
+
+// RandomRack returns n tiles drawn from a full bag of the variant
+func RandomRack(rng *rand.Rand, variant game.Variant, n int) []game.Tile {
+	return randomBag(rng, variant).DrawTiles(n)
+}
+
+// RandomGame plays a game between players who pick at random among the best
+// moves, sometimes exchanging and sometimes passing instead
+// Every action goes through the game's public methods, so the result is a
+// game a real client could have reached.
+func RandomGame(rng *rand.Rand, cfg Config) (*game.Game, error) {
+	if cfg.Players == 0 {
+		cfg.Players = game.MinPlayers
+	}
+	if cfg.Words == nil {
+		cfg.Words = Words
+	}
+
+	players := make([]*game.Player, cfg.Players)
+	for i := range players {
+		players[i] = game.NewPlayer(fmt.Sprintf("p%d", i+1), fmt.Sprintf("Player %d", i+1))
+	}
+	g, err := game.NewGameWithVariant("gametest", players, cfg.Variant)
+	if err != nil {
+		return nil, err
+	}
+	g.TileBag = randomBag(rng, cfg.Variant)
+	if err := g.StartGame(); err != nil {
+		return nil, err
+	}
+
+	bot := game.NewBotWithSeed("", game.TopNRandom, game.NewMoveGenerator(cfg.Words), rng.Int63())
+	for turn := 0; g.State == game.InProgress && (cfg.MaxTurns == 0 || turn < cfg.MaxTurns); turn++ {
+		p := g.CurrentPlayer()
+		if err := randomTurn(rng, g, bot, p); err != nil {
+			return nil, fmt.Errorf("turn %d: %w", turn+1, err)
+		}
+	}
+	return g, nil
+}
+
+// RandomBoard returns the board of a random game stopped partway through
+func RandomBoard(rng *rand.Rand, cfg Config) (*game.Board, error) {
+	if cfg.MaxTurns == 0 {
+		cfg.MaxTurns = 1 + rng.Intn(20)
+	}
+	g, err := RandomGame(rng, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return g.Board, nil
+}
+
+// Run plays n random games, seeded from seed, seed+1 and so on, and fails
+// the test for each game on which check returns an error
+// Games that fail are reported with their seed, so that
+// RandomGame(rand.New(rand.NewSource(seed)), cfg) reproduces them.
+func Run(t testing.TB, n int, seed int64, cfg Config, check func(*game.Game) error) {
+	t.Helper()
+	for i := int64(0); i < int64(n); i++ {
+		g, err := RandomGame(rand.New(rand.NewSource(seed+i)), cfg)
+		if err != nil {
+			t.Fatalf("seed %d: %v", seed+i, err)
+		}
+		if err := check(g); err != nil {
+			t.Errorf("seed %d: %v", seed+i, err)
+		}
+	}
+}
+
+// randomTurn plays one turn for p: usually one of the bot's moves, otherwise
+// an exchange or a pass
+func randomTurn(rng *rand.Rand, g *game.Game, bot *game.Bot, p *game.Player) error {
+	switch roll := rng.Intn(20); {
+	case roll == 0:
+		return g.PassTurn(p.ID)
+	case roll == 1 && g.TileBag.RemainingCount() >= g.ExchangeMinimum && len(p.Rack) > 0:
+		indices := rng.Perm(len(p.Rack))[:1+rng.Intn(len(p.Rack))]
+		if g.TileBag.RemainingCount() >= len(indices) {
+			return g.ExchangeTiles(p.ID, indices)
+		}
+	}
+
+	move, ok := bot.ChooseMove(g.Board, p.Rack)
+	if !ok {
+		return g.PassTurn(p.ID)
+	}
+	move.PlayerID = p.ID
+	_, err := g.PlayMove(move)
+	return err
+}
+
+// randomBag returns a full bag of the variant whose shuffles, including
+// those after exchanges, are drawn from rng
+func randomBag(rng *rand.Rand, variant game.Variant) *game.TileBag {
+	return game.NewTileBagForVariantWithSeed(variant, rng.Int63())
+}
@@ -0,0 +1,114 @@
+package gametest
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"scrabbled/internal/game"
+)
+
+// TestRandomGame tests that random games run to the end and keep the invariants
+func TestRandomGame(t *testing.T) {
+	Run(t, 10, 1, Config{}, func(g *game.Game) error {
+		if g.State != game.Finished {
+			return fmt.Errorf("game should finish, got %v", g.State)
+		}
+		if len(g.Board.GetOccupiedPositions()) == 0 {
+			return errors.New("some tiles should be played")
+		}
+		return CheckInvariants(g)
+	})
+}
+
+// TestRandomGameConfig tests more players, another variant and stopping early
+func TestRandomGameConfig(t *testing.T) {
+	Run(t, 5, 1, Config{Players: 4, Variant: game.WordsWithFriends}, func(g *game.Game) error {
+		if len(g.Players) != 4 || g.Board.Variant != game.WordsWithFriends {
+			return fmt.Errorf("unexpected game %d players on %v", len(g.Players), g.Board.Variant)
+		}
+		return CheckInvariants(g)
+	})
+
+	g, err := RandomGame(rand.New(rand.NewSource(1)), Config{MaxTurns: 3})
+	if err != nil {
+		t.Fatalf("RandomGame failed: %v", err)
+	}
+	if g.State != game.InProgress || len(g.History) != 3 {
+		t.Errorf("Should stop in progress after 3 turns, got %v after %d", g.State, len(g.History))
+	}
+}
+
+// TestRandomGameReproducible tests that a seed determines the game
+func TestRandomGameReproducible(t *testing.T) {
+	play := func() []string {
+		g, err := RandomGame(rand.New(rand.NewSource(7)), Config{})
+		if err != nil {
+			t.Fatalf("RandomGame failed: %v", err)
+		}
+		var moves []string
+		for _, m := range g.History {
+			moves = append(moves, fmt.Sprintf("%s %v %s %d", m.PlayerID, m.Type, m.Word, m.Total))
+		}
+		return moves
+	}
+	if a, b := play(), play(); !reflect.DeepEqual(a, b) {
+		t.Errorf("The same seed should play the same game:\n%v\n%v", a, b)
+	}
+}
+
+// TestRandomRack tests drawing racks
+func TestRandomRack(t *testing.T) {
+	rack := RandomRack(rand.New(rand.NewSource(3)), game.Classic, 7)
+	again := RandomRack(rand.New(rand.NewSource(3)), game.Classic, 7)
+	if len(rack) != 7 || !reflect.DeepEqual(rack, again) {
+		t.Errorf("Expected the same 7 tiles from the same seed, got %v and %v", rack, again)
+	}
+	if all := RandomRack(rand.New(rand.NewSource(3)), game.Classic, 200); len(all) != game.Classic.TileCount() {
+		t.Errorf("Should draw no more than the bag holds, got %d", len(all))
+	}
+}
+
+// TestRandomBoard tests boards taken from games in progress
+func TestRandomBoard(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+	for i := 0; i < 5; i++ {
+		b, err := RandomBoard(rng, Config{})
+		if err != nil {
+			t.Fatalf("RandomBoard failed: %v", err)
+		}
+		if err := b.ValidateBoard(); err != nil {
+			t.Errorf("Board should be valid: %v", err)
+		}
+	}
+}
+
+// recorder is a testing.TB that records failures instead of reporting them
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+// TestRunReportsSeed tests that failures name the seed of the game
+func TestRunReportsSeed(t *testing.T) {
+	r := &recorder{TB: t}
+	n := 0
+	Run(r, 3, 10, Config{MaxTurns: 1}, func(*game.Game) error {
+		if n++; n == 2 {
+			return errors.New("broken")
+		}
+		return nil
+	})
+	if len(r.failures) != 1 || !strings.HasPrefix(r.failures[0], "seed 11: broken") {
+		t.Errorf("Expected one failure for seed 11, got %v", r.failures)
+	}
+}
@@ -0,0 +1,51 @@
+package gametest
+
+import (
+	"errors"
+	"fmt"
+
+	"scrabbled/internal/game"
+)
+
+// CheckInvariants checks every invariant a game must keep: tiles are
+// conserved, and scores are non-negative unless penalties have been applied
+func CheckInvariants(g *game.Game) error {
+	return errors.Join(CheckTileConservation(g), CheckScores(g, penalized(g)))
+}
+
+// CheckTileConservation checks that the tiles in the bag, on the racks and on
+// the board together make up exactly the game's tile distribution, 100 tiles
+// in classic Scrabble
+// The error is a *game.InvariantError, whose Dump shows where every tile is.
+func CheckTileConservation(g *game.Game) error {
+	return g.CheckTiles()
+}
+
+// CheckScores checks that no player's score is negative, unless
+// allowNegative is set for games in which penalties can take a score below zero
+func CheckScores(g *game.Game, allowNegative bool) error {
+	if allowNegative {
+		return nil
+	}
+	var errs []error
+	for _, p := range g.Players {
+		if p.Score < 0 {
+			errs = append(errs, fmt.Errorf("%s has a negative score %d", p.ID, p.Score))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// penalized returns true if the game may have taken points away: the rack
+// deductions at the end of a game, overtime penalties and challenge penalties
+func penalized(g *game.Game) bool {
+	if g.State == game.Finished || g.Clock != nil {
+		return true
+	}
+	for _, m := range g.History {
+		if m.Type == game.ChallengeTurn {
+			return true
+		}
+	}
+	return false
+}
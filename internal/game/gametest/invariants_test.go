@@ -0,0 +1,61 @@
+package gametest
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"scrabbled/internal/game"
+)
+
+// startedGame returns a random game a few turns in
+func startedGame(t *testing.T) *game.Game {
+	t.Helper()
+	g, err := RandomGame(rand.New(rand.NewSource(1)), Config{MaxTurns: 4})
+	if err != nil {
+		t.Fatalf("RandomGame failed: %v", err)
+	}
+	return g
+}
+
+// TestCheckTileConservation tests detecting lost and duplicated tiles
+func TestCheckTileConservation(t *testing.T) {
+	g := startedGame(t)
+	if err := CheckTileConservation(g); err != nil {
+		t.Fatalf("A fresh game should conserve tiles: %v", err)
+	}
+
+	p := g.Players[0]
+	lost := p.Rack[0]
+	p.Rack = p.Rack[1:]
+	err := CheckTileConservation(g)
+	var ie *game.InvariantError
+	if !errors.As(err, &ie) || len(ie.Counts) == 0 {
+		t.Errorf("Should report the lost %v, got %v", lost, err)
+	}
+
+	p.Rack = append(p.Rack, lost, game.Tile{IsBlank: true})
+	if err := CheckTileConservation(g); err == nil || !strings.Contains(err.Error(), "? 3 of 2") {
+		t.Errorf("Should report the extra blank, got %v", err)
+	}
+}
+
+// TestCheckScores tests rejecting negative scores unless they are allowed
+func TestCheckScores(t *testing.T) {
+	g := startedGame(t)
+	if err := CheckInvariants(g); err != nil {
+		t.Fatalf("A fresh game should keep the invariants: %v", err)
+	}
+
+	g.Players[1].Score = -5
+	if err := CheckScores(g, false); err == nil || !strings.Contains(err.Error(), "p2 has a negative score -5") {
+		t.Errorf("Should reject the negative score, got %v", err)
+	}
+	if err := CheckScores(g, true); err != nil {
+		t.Errorf("Negative scores should be allowed: %v", err)
+	}
+	if err := CheckInvariants(g); err == nil {
+		t.Errorf("A game in progress without penalties should not go negative")
+	}
+}
@@ -0,0 +1,605 @@
+package game
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"scrabbled/internal/dictionary"
+)
+
+// ExportGCG writes the game's history in the GCG format used by Quackle and cross-tables.com
+// Placements use '.' for letters already on the board and lower case for
+// blanks. Withdrawn phonies are followed by a "--" line, annotations follow
+// their move as #note lines, and end-of-game rack adjustments are written when
+// the game is finished.
+func (g *Game) ExportGCG(w io.Writer) error {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "#character-encoding UTF-8")
+	for i, p := range g.Players {
+		fmt.Fprintf(bw, "#player%d %s %s\n", i+1, gcgNick(p.ID), p.Name)
+	}
+	if g.ID != "" {
+		fmt.Fprintf(bw, "#id scrabbled %s\n", g.ID)
+	}
+
+	notes := g.annotations
+	for turn, m := range g.History {
+		nick := gcgNick(m.PlayerID)
+		rack := gcgRack(m.Rack)
+
+		switch m.Type {
+		case PlaceTiles:
+			fmt.Fprintf(bw, ">%s: %s %s %s +%d %d\n", nick, rack, m.Coordinates(), gcgPlay(m), m.Score, m.Total)
+			if m.Withdrawn {
+				fmt.Fprintf(bw, ">%s: %s -- -%d %d\n", nick, rack, m.Score, m.Total-m.Score)
+			}
+		case Exchange:
+			fmt.Fprintf(bw, ">%s: %s -%s +0 %d\n", nick, rack, gcgRack(m.Exchanged), m.Total)
+		case Pass:
+			fmt.Fprintf(bw, ">%s: %s - +0 %d\n", nick, rack, m.Total)
+		}
+		for len(notes) > 0 && notes[0].Turn == turn {
+			fmt.Fprintf(bw, "#note %s\n", gcgNote(notes[0]))
+			notes = notes[1:]
+		}
+	}
+
+	if g.State == Finished {
+		g.writeGCGEndRacks(bw)
+	}
+
+	return bw.Flush()
+}
+
+// writeGCGEndRacks writes the end-of-game rack adjustment lines; callers must hold the lock
+func (g *Game) writeGCGEndRacks(w io.Writer) {
+	for _, adj := range g.endRackAdjustments() {
+		if adj.wentOut {
+			fmt.Fprintf(w, ">%s: (%s) +%d %d\n", gcgNick(adj.player.ID), gcgRack(adj.tiles), adj.points, adj.player.Score)
+			continue
+		}
+		rack := gcgRack(adj.tiles)
+		fmt.Fprintf(w, ">%s: %s (%s) -%d %d\n", gcgNick(adj.player.ID), rack, rack, -adj.points, adj.player.Score)
+	}
+}
+
+// rackAdjustment is an end-of-game change to a player's score for the tiles
+// left on the racks
+type rackAdjustment struct {
+	player  *Player
+	tiles   []Tile
+	points  int  // Bonus for going out, or minus the value of the player's rack
+	wentOut bool // True for the bonus of the player who went out
+}
+
+// endRackAdjustments returns the going-out bonus, if a player went out,
+// followed by the deduction for each unplayed rack; callers must hold the lock
+func (g *Game) endRackAdjustments() []rackAdjustment {
+	var adjustments []rackAdjustment
+	if last, ok := g.History.Last(); ok && last.Type == PlaceTiles && !last.Withdrawn {
+		if p := g.findPlayer(last.PlayerID); p != nil && len(p.Rack) == 0 {
+			adjustments = append(adjustments, rackAdjustment{player: p, wentOut: true})
+		}
+	}
+
+	for _, p := range g.Players {
+		if len(p.Rack) == 0 {
+			continue
+		}
+		value := 0
+		for _, t := range p.Rack {
+			value += t.Points
+		}
+		if len(adjustments) > 0 && adjustments[0].wentOut {
+			adjustments[0].tiles = append(adjustments[0].tiles, p.Rack...)
+			adjustments[0].points += value
+		}
+		adjustments = append(adjustments, rackAdjustment{player: p, tiles: p.Rack, points: -value})
+	}
+	return adjustments
+}
+
+// gcgNick converts a player ID to a GCG nickname (no whitespace)
+func gcgNick(id string) string {
+	return strings.Join(strings.Fields(id), "_")
+}
+
+// gcgRack converts tiles to GCG rack notation with '?' for blanks
+func gcgRack(tiles []Tile) string {
+	var sb strings.Builder
+	for _, t := range tiles {
+		if t.IsBlank {
+			sb.WriteRune('?')
+		} else {
+			sb.WriteRune(t.Letter)
+		}
+	}
+	return sb.String()
+}
+
+// gcgPlay converts a placement to GCG play notation
+func gcgPlay(m Move) string {
+	placed := make(map[Position]Tile, len(m.Tiles))
+	for _, pt := range m.Tiles {
+		placed[pt.Position] = pt.Tile
+	}
+
+	var sb strings.Builder
+	step := m.Direction.step()
+	pos := m.Start
+	for range []rune(m.Word) {
+		if t, ok := placed[pos]; !ok {
+			sb.WriteRune('.')
+		} else if t.IsBlank {
+			sb.WriteRune(unicode.ToLower(t.Letter))
+		} else {
+			sb.WriteRune(t.Letter)
+		}
+		pos = Position{Row: pos.Row + step.Row, Col: pos.Col + step.Col}
+	}
+	return sb.String()
+}
+
+// GCGInfo holds the header pragmas of a GCG record
+type GCGInfo struct {
+	ID            string             `json:"id"`
+	Source        string             `json:"source,omitempty"` // Issuer named by #id, such as io.woogles
+	Title         string             `json:"title,omitempty"`
+	Description   string             `json:"description,omitempty"`
+	LexiconName   string             `json:"lexicon_name,omitempty"` // Word list as written, such as CSW21
+	Lexicon       dictionary.Lexicon `json:"lexicon"`                // Word list family, Custom if unrecognized
+	ChallengeRule ChallengeRule      `json:"challenge_rule"`
+	ChallengeName string             `json:"challenge_name,omitempty"` // Rule as written, such as FIVE_POINT
+}
+
+// ImportGCG reconstructs a game from a GCG record for replay and analysis
+// Each line's rack is dealt to the named player from the bag before the action
+// is applied, and computed scores must match the record. Phonies are accepted
+// and withdrawn as the record dictates.
+func ImportGCG(r io.Reader) (*Game, error) {
+	g, _, err := ImportGCGWithInfo(r)
+	return g, err
+}
+
+// ImportGCGWithInfo reconstructs a game from a GCG record and also returns its
+// header, including the lexicon and challenge rule pragmas written by Woogles
+// and Macondo
+// Challenge rules that award points for a failed challenge import as double
+// challenge, since the record carries the resulting score adjustments. Known
+// final racks given by #rack1 and #rack2 are dealt once the record is replayed,
+// and #note lines become comments on the move before them.
+func ImportGCGWithInfo(r io.Reader) (*Game, GCGInfo, error) {
+	return importGCG(r, nil)
+}
+
+// importGCG reconstructs a game from a GCG record, reporting discrepancies to
+// v instead of failing on them when v is not nil
+func importGCG(r io.Reader, v *gcgVerifier) (*Game, GCGInfo, error) {
+	type gcgPlayer struct{ nick, name string }
+
+	var players []gcgPlayer
+	var lines []string
+	finalRacks := make(map[int]string)
+	notes := make(map[int][]string)
+	info := GCGInfo{ID: "gcg", Lexicon: dictionary.Custom, ChallengeRule: DoubleChallenge}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, ">") {
+			lines = append(lines, line)
+			continue
+		}
+		if !strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		pragma := strings.ToLower(fields[0][1:])
+		rest := strings.TrimSpace(strings.TrimPrefix(line, fields[0]))
+		switch {
+		case strings.HasPrefix(pragma, "player"):
+			if len(fields) < 2 {
+				return nil, info, fmt.Errorf("malformed player line: %q", line)
+			}
+			name := strings.Join(fields[2:], " ")
+			if name == "" {
+				name = fields[1]
+			}
+			players = append(players, gcgPlayer{nick: fields[1], name: name})
+		case pragma == "id":
+			if len(fields) >= 3 {
+				info.Source = fields[1]
+				info.ID = fields[2]
+			}
+		case pragma == "title":
+			info.Title = rest
+		case pragma == "description":
+			info.Description = rest
+		case pragma == "lexicon":
+			info.LexiconName = rest
+			info.Lexicon = dictionary.ParseLexicon(rest)
+		case isGCGChallengePragma(pragma):
+			rule, err := parseGCGChallengeRule(rest)
+			if err != nil {
+				return nil, info, err
+			}
+			info.ChallengeName = rest
+			info.ChallengeRule = rule
+		case pragma == "variant":
+			if v := strings.ToLower(rest); v != "" && v != "classic" {
+				return nil, info, fmt.Errorf("unsupported variant %q", rest)
+			}
+		case pragma == "note":
+			if len(lines) > 0 && rest != "" {
+				notes[len(lines)-1] = append(notes[len(lines)-1], rest)
+			}
+		case pragma == "rack1" || pragma == "rack2":
+			if len(fields) >= 2 {
+				finalRacks[int(pragma[4]-'1')] = fields[1]
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, info, err
+	}
+	if len(players) < MinPlayers {
+		return nil, info, errors.New("GCG record must declare at least two players")
+	}
+
+	seated := make([]*Player, len(players))
+	for i, p := range players {
+		seated[i] = NewPlayer(p.nick, p.name)
+	}
+	g, err := NewGame(info.ID, seated)
+	if err != nil {
+		return nil, info, err
+	}
+	g.ChallengeRule = info.ChallengeRule
+	if err := g.StartGame(); err != nil {
+		return nil, info, err
+	}
+
+	// Racks are only known from the record, so return the opening deal
+	for _, p := range g.Players {
+		g.TileBag.ReturnTiles(p.Rack)
+		p.Rack = make([]Tile, 0, MaxRackSize)
+	}
+
+	for n, line := range lines {
+		v.before(g, n+1, line)
+		if err := g.applyGCGLine(line, v); err != nil {
+			if v == nil {
+				return nil, info, fmt.Errorf("GCG event %d: %w", n+1, err)
+			}
+			// The rest of the record cannot be replayed on a board without this move
+			v.flag(IllegalEvent, "%v", err)
+			break
+		}
+		v.after(g, line)
+		for _, note := range notes[n] {
+			if len(g.History) == 0 {
+				break
+			}
+			if err := g.Annotate(Annotation{Turn: len(g.History) - 1, Kind: CommentAnnotation, Text: note}); err != nil {
+				return nil, info, fmt.Errorf("GCG event %d: %w", n+1, err)
+			}
+		}
+	}
+
+	if g.State == InProgress && !v.stopped() {
+		for idx, rack := range finalRacks {
+			if idx >= len(g.Players) {
+				continue
+			}
+			if err := g.dealRack(g.Players[idx], rackFromGCG(rack)); err != nil {
+				return nil, info, fmt.Errorf("rack%d: %w", idx+1, err)
+			}
+		}
+	}
+
+	v.finish()
+
+	// The record, not the events of the reconstruction, is the source of an
+	// imported game
+	g.events = nil
+	return g, info, nil
+}
+
+// isGCGChallengePragma returns true for the spellings of the challenge rule pragma
+func isGCGChallengePragma(pragma string) bool {
+	switch strings.NewReplacer("-", "", "_", "").Replace(pragma) {
+	case "challengerule", "challenge":
+		return true
+	default:
+		return false
+	}
+}
+
+// parseGCGChallengeRule maps a Woogles or Macondo challenge rule name to the
+// rule used to replay the record
+func parseGCGChallengeRule(name string) (ChallengeRule, error) {
+	switch strings.ToUpper(strings.NewReplacer("-", "_", " ", "_").Replace(name)) {
+	case "VOID":
+		return VoidChallenge, nil
+	case "SINGLE", "DOUBLE", "TRIPLE", "FIVE_POINT", "TEN_POINT", "5_POINT", "10_POINT":
+		return DoubleChallenge, nil
+	default:
+		return DoubleChallenge, fmt.Errorf("unknown challenge rule %q", name)
+	}
+}
+
+// applyGCGLine applies one '>' event line to the game
+// A score that differs from the record fails unless v is not nil, which
+// records the discrepancy instead.
+func (g *Game) applyGCGLine(line string, v *gcgVerifier) error {
+	nick, fields, err := parseGCGEvent(line)
+	if err != nil {
+		return err
+	}
+
+	idx, handled, err := g.applyGCGAdjustment(nick, fields)
+	if handled || err != nil {
+		return err
+	}
+
+	// Some records omit the rack; deal just the tiles the play needs
+	_, rackKnown := gcgTurnRack(fields)
+	if !rackKnown {
+		fields = append([]string{""}, fields...)
+	}
+
+	g.mu.Lock()
+	player := g.Players[idx]
+	if rackKnown {
+		if err := g.dealRack(player, rackFromGCG(fields[0])); err != nil {
+			g.mu.Unlock()
+			return err
+		}
+	}
+	g.CurrentTurn = idx
+	g.mu.Unlock()
+
+	action := fields[1:]
+	switch {
+	case action[0] == "-":
+		return g.PassTurn(nick)
+	case strings.HasPrefix(action[0], "-"):
+		indices, err := exchangeIndices(player.Rack, action[0][1:])
+		if err != nil {
+			return err
+		}
+		return g.ExchangeTiles(nick, indices)
+	}
+
+	if len(action) < 3 {
+		return fmt.Errorf("malformed play: %q", line)
+	}
+	g.mu.Lock()
+	move, err := g.Board.ParsePlay(nick, action[0], action[1])
+	if err == nil && !rackKnown {
+		rack := make([]Tile, len(move.Tiles))
+		for i, pt := range move.Tiles {
+			rack[i] = pt.Tile
+			if pt.Tile.IsBlank {
+				rack[i].Letter = 0
+			}
+		}
+		err = g.dealRack(player, rack)
+	}
+	g.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	score, err := g.PlayMove(move)
+	if err != nil {
+		return err
+	}
+	if expected, err := strconv.Atoi(strings.TrimPrefix(action[2], "+")); err != nil {
+		return fmt.Errorf("invalid score %q", action[2])
+	} else if expected != score {
+		if v == nil {
+			return fmt.Errorf("score mismatch for %s: record says %d, computed %d", action[1], expected, score)
+		}
+		v.flag(ScoreMismatch, "%s %s: record says %d, computed %d", action[0], action[1], expected, score)
+	}
+	return nil
+}
+
+// applyGCGAdjustment resolves the player for an event and applies events that
+// do not take a turn: withdrawals, score adjustments and end-of-game racks
+// It returns the player's index and whether the event was fully handled.
+func (g *Game) applyGCGAdjustment(nick string, fields []string) (int, bool, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	idx := -1
+	for i, p := range g.Players {
+		if p.ID == nick {
+			idx = i
+		}
+	}
+	if idx < 0 {
+		return idx, false, fmt.Errorf("unknown player %q", nick)
+	}
+	player := g.Players[idx]
+
+	switch {
+	case fields[1] == "--":
+		if last, ok := g.History.Last(); !ok || last.PlayerID != nick || last.Type != PlaceTiles {
+			return idx, false, errors.New("withdrawal does not follow a play by the same player")
+		}
+		return idx, true, g.withdrawLastMove()
+	case fields[1] == "(challenge)" || fields[1] == "(time)":
+		if len(fields) < 3 {
+			return idx, false, fmt.Errorf("missing score for %s", fields[1])
+		}
+		delta, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return idx, false, fmt.Errorf("invalid score %q", fields[2])
+		}
+		player.Score += delta
+		return idx, true, nil
+	case strings.HasPrefix(fields[0], "(") || strings.HasPrefix(fields[1], "("):
+		// End-of-game rack adjustments carry the final total, which also
+		// covers records whose bag did not run dry in the reconstruction
+		total, err := strconv.Atoi(fields[len(fields)-1])
+		if err != nil {
+			return idx, false, fmt.Errorf("invalid total %q", fields[len(fields)-1])
+		}
+		player.Score = total
+		g.State = Finished
+		return idx, true, nil
+	}
+
+	return idx, false, nil
+}
+
+// dealRack replaces a player's rack with exactly the given tiles, drawing them
+// from the bag after returning the current rack; callers must hold the lock
+// Tiles the bag cannot supply are taken from other players' racks, since the
+// reconstruction cannot know which tiles they really drew, and those racks are
+// topped back up from the bag.
+func (g *Game) dealRack(p *Player, rack []Tile) error {
+	pool := append(g.TileBag.Tiles(), p.Rack...)
+	short := make(map[*Player]int)
+	for _, want := range rack {
+		if i := findTile(pool, want); i >= 0 {
+			pool = append(pool[:i], pool[i+1:]...)
+			continue
+		}
+
+		taken := false
+		for _, other := range g.Players {
+			if other == p {
+				continue
+			}
+			if i := findTile(other.Rack, want); i >= 0 {
+				other.Rack = append(other.Rack[:i:i], other.Rack[i+1:]...)
+				short[other]++
+				taken = true
+				break
+			}
+		}
+		if !taken {
+			return fmt.Errorf("tile %s is not available to deal", want.String())
+		}
+	}
+
+	g.TileBag = NewTileBagFromTiles(pool)
+	g.TileBag.shuffle()
+	p.Rack = rack
+	for _, other := range g.Players {
+		if n := short[other]; n > 0 {
+			other.Rack = append(other.Rack, g.TileBag.DrawTiles(n)...)
+		}
+	}
+	return nil
+}
+
+// findTile returns the index of a tile matching want, or -1
+// Blanks match any blank regardless of their assigned letter.
+func findTile(tiles []Tile, want Tile) int {
+	for i, t := range tiles {
+		if t.IsBlank == want.IsBlank && (t.IsBlank || t.Letter == want.Letter) {
+			return i
+		}
+	}
+	return -1
+}
+
+// rackFromGCG converts GCG rack notation to tiles
+func rackFromGCG(s string) []Tile {
+	rack := make([]Tile, 0, len(s))
+	for _, r := range strings.ToUpper(s) {
+		if r == '?' {
+			rack = append(rack, Tile{IsBlank: true})
+		} else {
+			rack = append(rack, Tile{Letter: r, Points: GetTileValue(r)})
+		}
+	}
+	return rack
+}
+
+// exchangeIndices finds the rack indices for a GCG exchange, given either the
+// exchanged letters or just their count
+func exchangeIndices(rack []Tile, spec string) ([]int, error) {
+	if n, err := strconv.Atoi(spec); err == nil {
+		if n <= 0 || n > len(rack) {
+			return nil, fmt.Errorf("invalid exchange count %d", n)
+		}
+		indices := make([]int, n)
+		for i := range indices {
+			indices[i] = i
+		}
+		return indices, nil
+	}
+
+	placed := make([]PlacedTile, 0, len(spec))
+	for _, t := range rackFromGCG(spec) {
+		placed = append(placed, PlacedTile{Tile: t})
+	}
+	return rackIndices(rack, placed)
+}
+
+// ParsePlay converts a play in GCG notation, such as "8D" "CAT" across or
+// "D8" "cAT" down, to a move by the player on the current board
+// Blanks are in lower case, and letters already on the board are written out
+// or given as '.'. The move is not checked against the player's rack.
+func (g *Game) ParsePlay(playerID, coords, play string) (Move, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.Board.ParsePlay(playerID, coords, play)
+}
+
+// ParsePlay converts a play in GCG notation to a move on the board, as
+// Game.ParsePlay does, for positions outside a game such as puzzles
+// Coordinates are read as ParseCoordinates reads them.
+func (b *Board) ParsePlay(playerID, coords, play string) (Move, error) {
+	start, dir, err := ParseCoordinates(coords)
+	if err != nil {
+		return Move{}, err
+	}
+
+	move := Move{PlayerID: playerID, Start: start, Direction: dir}
+	var word strings.Builder
+	step := dir.step()
+	pos := start
+	for _, r := range play {
+		if !pos.IsValid() {
+			return Move{}, fmt.Errorf("play %s runs off the board", play)
+		}
+
+		existing := b.GetTile(pos)
+		switch {
+		case r == '.' || (existing != nil && unicode.ToUpper(r) == existing.Letter):
+			if existing == nil {
+				return Move{}, fmt.Errorf("play %s: no tile at %s", play, pos.String())
+			}
+			word.WriteRune(existing.Letter)
+		case existing != nil:
+			return Move{}, fmt.Errorf("play %s: square %s is occupied", play, pos.String())
+		case unicode.IsLower(r):
+			letter := unicode.ToUpper(r)
+			move.Tiles = append(move.Tiles, PlacedTile{Tile: Tile{Letter: letter, IsBlank: true}, Position: pos})
+			word.WriteRune(letter)
+		default:
+			move.Tiles = append(move.Tiles, PlacedTile{Tile: Tile{Letter: r, Points: b.tileValue(r)}, Position: pos})
+			word.WriteRune(r)
+		}
+		pos = Position{Row: pos.Row + step.Row, Col: pos.Col + step.Col}
+	}
+
+	move.Word = word.String()
+	return move, nil
+}
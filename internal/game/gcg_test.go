@@ -0,0 +1,272 @@
+package game
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"scrabbled/internal/dictionary"
+)
+
+// TestImportGCG tests reconstructing a game from a GCG record
+func TestImportGCG(t *testing.T) {
+	f, err := os.Open("testdata/sample.gcg")
+	if err != nil {
+		t.Fatalf("Failed to open sample: %v", err)
+	}
+	defer f.Close()
+
+	g, err := ImportGCG(f)
+	if err != nil {
+		t.Fatalf("ImportGCG failed: %v", err)
+	}
+
+	if g.ID != "sample-1" || len(g.Players) != 2 || g.Players[0].Name != "Alice Smith" {
+		t.Errorf("Header should set game ID and players, got %s %+v", g.ID, g.Players[0])
+	}
+
+	if g.Players[0].Score != 10 || g.Players[1].Score != 20 {
+		t.Errorf("Expected scores 10 and 20, got %d and %d", g.Players[0].Score, g.Players[1].Score)
+	}
+
+	for pos, letter := range map[string]rune{"G8": 'C', "H8": 'A', "I8": 'T', "J8": 'S', "F9": 'D', "G9": 'O'} {
+		if tile := g.Board.GetTile(mustPos(t, pos)); tile == nil || tile.Letter != letter {
+			t.Errorf("Expected %c at %s, got %v", letter, pos, tile)
+		}
+	}
+	if g.Board.HasTileAt(mustPos(t, "H7")) {
+		t.Errorf("Withdrawn phony should not remain on the board")
+	}
+
+	expected := []MoveType{PlaceTiles, PlaceTiles, Exchange, Pass, PlaceTiles, PlaceTiles}
+	if len(g.History) != len(expected) {
+		t.Fatalf("Expected %d history entries, got %d", len(expected), len(g.History))
+	}
+	for i, mt := range expected {
+		if g.History[i].Type != mt {
+			t.Errorf("Entry %d: expected %s, got %s", i, mt, g.History[i].Type)
+		}
+	}
+	if !g.History[4].Withdrawn {
+		t.Errorf("Phony should be marked withdrawn")
+	}
+
+	// Tiles are conserved
+	total := g.TileBag.RemainingCount() + len(g.Board.GetOccupiedPositions())
+	for _, p := range g.Players {
+		total += p.GetRackSize()
+	}
+	if total != 100 {
+		t.Errorf("Expected 100 tiles in play, got %d", total)
+	}
+}
+
+// TestImportGCGDealFromRack tests dealing a recorded rack with tiles that only
+// another player holds
+func TestImportGCGDealFromRack(t *testing.T) {
+	// Alice keeps the only Z, Q, X, J and K, all of which Bob's rack needs
+	record := "#player1 alice Alice\n#player2 bob Bob\n" +
+		">alice: ZQXJKAE 8G AE +4 4\n" +
+		">bob: ZQXJKAB - +0 0\n"
+	g, err := ImportGCG(strings.NewReader(record))
+	if err != nil {
+		t.Fatalf("ImportGCG failed: %v", err)
+	}
+
+	if size := g.Players[0].GetRackSize(); size != MaxRackSize {
+		t.Errorf("Alice's rack should be topped up after giving up tiles, got %d", size)
+	}
+	total := g.TileBag.RemainingCount() + len(g.Board.GetOccupiedPositions())
+	for _, p := range g.Players {
+		total += p.GetRackSize()
+	}
+	if total != 100 {
+		t.Errorf("Expected 100 tiles in play, got %d", total)
+	}
+}
+
+// TestImportGCGErrors tests rejection of malformed records
+func TestImportGCGErrors(t *testing.T) {
+	header := "#player1 a A\n#player2 b B\n"
+	testCases := map[string]string{
+		"one player":     "#player1 a A\n>a: CAT 8G CAT +10 10\n",
+		"unknown player": header + ">c: CAT 8G CAT +10 10\n",
+		"score mismatch": header + ">a: CAT 8G CAT +12 12\n",
+		"bad coords":     header + ">a: CAT Z99 CAT +10 10\n",
+		"illegal play":   header + ">a: CAT 1A CAT +5 5\n",
+		"tile shortage":  header + ">a: ZZ 8G ZZ +40 40\n",
+		"bad withdrawal": header + ">a: CAT -- -10 0\n",
+		"malformed":      header + ">a: CAT\n",
+	}
+
+	for name, record := range testCases {
+		if _, err := ImportGCG(strings.NewReader(record)); err == nil {
+			t.Errorf("%s: ImportGCG should fail", name)
+		}
+	}
+}
+
+// TestImportGCGWoogles tests the pragmas written by Woogles and Macondo
+func TestImportGCGWoogles(t *testing.T) {
+	f, err := os.Open("testdata/woogles.gcg")
+	if err != nil {
+		t.Fatalf("Failed to open sample: %v", err)
+	}
+	defer f.Close()
+
+	g, info, err := ImportGCGWithInfo(f)
+	if err != nil {
+		t.Fatalf("ImportGCGWithInfo failed: %v", err)
+	}
+
+	if info.ID != "Xk3pQ9rT" || info.Source != "io.woogles" || g.ID != "Xk3pQ9rT" {
+		t.Errorf("Should read the Woogles game ID, got %q from %q", info.ID, info.Source)
+	}
+	if info.Title != "Club night, round 3" || info.Description != "Created with Macondo" {
+		t.Errorf("Should read the title and description, got %q and %q", info.Title, info.Description)
+	}
+	if info.LexiconName != "CSW21" || info.Lexicon != dictionary.Collins {
+		t.Errorf("CSW21 should map to Collins, got %s", info.Lexicon)
+	}
+	if info.ChallengeName != "FIVE_POINT" || info.ChallengeRule != DoubleChallenge || g.ChallengeRule != DoubleChallenge {
+		t.Errorf("Five point challenges should replay as double challenge, got %s", info.ChallengeRule)
+	}
+
+	if g.Players[0].Score != 10 || g.Players[1].Score != 20 {
+		t.Errorf("Expected scores 10 and 20, got %d and %d", g.Players[0].Score, g.Players[1].Score)
+	}
+	if rack := gcgRack(g.Players[0].Rack); rack != "EEILNRT" {
+		t.Errorf("#rack1 should deal the final rack, got %s", rack)
+	}
+}
+
+// TestImportGCGPragmas tests challenge rule spellings and unsupported headers
+func TestImportGCGPragmas(t *testing.T) {
+	players := "#player1 a A\n#player2 b B\n"
+	for _, pragma := range []string{"#challenge-rule VOID", "#challengerule void", "#challenge_rule Void"} {
+		_, info, err := ImportGCGWithInfo(strings.NewReader(pragma + "\n" + players))
+		if err != nil || info.ChallengeRule != VoidChallenge {
+			t.Errorf("%q should select the void rule, got %s (%v)", pragma, info.ChallengeRule, err)
+		}
+	}
+
+	_, info, err := ImportGCGWithInfo(strings.NewReader(players))
+	if err != nil || info.ChallengeRule != DoubleChallenge || info.Lexicon != dictionary.Custom {
+		t.Errorf("Records without pragmas should default to double challenge and a custom lexicon")
+	}
+
+	for _, header := range []string{"#challenge-rule SUDDEN_DEATH", "#variant wordsmog"} {
+		if _, _, err := ImportGCGWithInfo(strings.NewReader(header + "\n" + players)); err == nil {
+			t.Errorf("%q should be rejected", header)
+		}
+	}
+	if _, _, err := ImportGCGWithInfo(strings.NewReader("#variant classic\n" + players)); err != nil {
+		t.Errorf("The classic variant should be accepted: %v", err)
+	}
+}
+
+// TestExportGCG tests GCG output for each kind of action
+func TestExportGCG(t *testing.T) {
+	f, _ := os.Open("testdata/sample.gcg")
+	defer f.Close()
+	g, err := ImportGCG(f)
+	if err != nil {
+		t.Fatalf("ImportGCG failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := g.ExportGCG(&buf); err != nil {
+		t.Fatalf("ExportGCG failed: %v", err)
+	}
+	out := buf.String()
+
+	for _, line := range []string{
+		"#player1 alice Alice Smith",
+		"#id scrabbled sample-1",
+		">alice: ACTXYZQ 8G CAT +10 10",
+		">bob: DEGOSVW 8G ...S +6 6",
+		">alice: QXYZAEI -XYZ +0 10",
+		">bob: DEGOVW? - +0 6",
+		">alice: QIABCDE H7 Q.I +12 22",
+		">alice: QIABCDE -- -12 10",
+		">bob: DO 9F DO +9 ",
+	} {
+		if !strings.Contains(out, line) {
+			t.Errorf("Export should contain %q, got:\n%s", line, out)
+		}
+	}
+}
+
+// TestGCGRoundTrip tests that exporting and re-importing reproduces the game
+func TestGCGRoundTrip(t *testing.T) {
+	g := newTestGame(t, 2)
+	g.StartGame()
+	g.TileBag.DrawTiles(g.TileBag.RemainingCount() - 2)
+	g.Players[0].Rack = rackOf("CAT?")
+	g.Players[1].Rack = rackOf("QZ")
+
+	move, _ := g.Board.BuildMove("p1", "CATS", mustPos(t, "H7"), Vertical, []int{3})
+	if _, err := g.PlayMove(move); err != nil {
+		t.Fatalf("Setup move failed: %v", err)
+	}
+	g.PassTurn("p2")
+	g.Players[0].Rack = rackOf("AT")
+	finisher, _ := g.Board.BuildMove("p1", "AT", mustPos(t, "I8"), Vertical, nil)
+	if _, err := g.PlayMove(finisher); err != nil {
+		t.Fatalf("Final move failed: %v", err)
+	}
+	if g.State != Finished {
+		t.Fatalf("Game should be finished")
+	}
+
+	var buf bytes.Buffer
+	g.ExportGCG(&buf)
+	if !strings.Contains(buf.String(), "H7 CATs") {
+		t.Errorf("Blank should be exported in lower case:\n%s", buf.String())
+	}
+	if !strings.Contains(buf.String(), ">p1: (") || !strings.Contains(buf.String(), ">p2: ") {
+		t.Errorf("Finished game should include end-of-game rack lines:\n%s", buf.String())
+	}
+
+	restored, err := ImportGCG(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Re-import failed: %v\n%s", err, buf.String())
+	}
+
+	if !reflect.DeepEqual(restored.Board.Grid, g.Board.Grid) {
+		t.Errorf("Board should survive a GCG round trip")
+	}
+	if restored.State != Finished {
+		t.Errorf("Re-imported game should be finished, got %s", restored.State)
+	}
+	for i := range g.Players {
+		if restored.Players[i].Score != g.Players[i].Score {
+			t.Errorf("Player %d score should be %d, got %d", i, g.Players[i].Score, restored.Players[i].Score)
+		}
+	}
+}
+
+// TestParsePlay tests converting GCG play notation to moves on the live board
+func TestParsePlay(t *testing.T) {
+	g := newTestGame(t, 2)
+	placeWord(t, g.Board, "CAT", "H8", Horizontal)
+
+	move, err := g.ParsePlay("p1", "J7", "a.S")
+	if err != nil {
+		t.Fatalf("ParsePlay failed: %v", err)
+	}
+	if move.Word != "ATS" || move.Direction != Vertical || len(move.Tiles) != 2 || !move.Tiles[0].Tile.IsBlank {
+		t.Errorf("Expected ATS down with a blank A, got %+v", move)
+	}
+	if _, err := g.ParsePlay("p1", "8H", "DOG"); err == nil {
+		t.Errorf("Playing over different letters should fail")
+	}
+	if move, err := g.ParsePlay("p1", "j-7 down", "a.S"); err != nil || move.Word != "ATS" || move.Direction != Vertical {
+		t.Errorf("Should accept lenient coordinates, got %+v, %v", move, err)
+	}
+	if _, err := g.ParsePlay("p1", "Z9", "DOG"); err == nil {
+		t.Errorf("Coordinates off the board should fail")
+	}
+}
@@ -0,0 +1,282 @@
+package game
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"scrabbled/internal/dictionary"
+)
+
+// DiscrepancyKind classifies a point at which a GCG record disagrees with
+// the game replayed from it
+type DiscrepancyKind int
+
+const (
+	ScoreMismatch     DiscrepancyKind = iota // A play or withdrawal scores differently from the record
+	TotalMismatch                            // A running total does not add up
+	EndRackMismatch                          // An end-of-game rack adjustment is not worth the rack
+	RackMismatch                             // A rack lacks tiles kept from the player's previous turn
+	UnchallengedPhony                        // A play forming invalid words stayed on the board
+	ValidWithdrawn                           // A play forming only valid words was withdrawn
+	IllegalEvent                             // An event breaks the rules; the replay stops there
+)
+
+// String returns a string representation of the discrepancy kind
+func (k DiscrepancyKind) String() string {
+	switch k {
+	case ScoreMismatch:
+		return "SCORE_MISMATCH"
+	case TotalMismatch:
+		return "TOTAL_MISMATCH"
+	case EndRackMismatch:
+		return "END_RACK_MISMATCH"
+	case RackMismatch:
+		return "RACK_MISMATCH"
+	case UnchallengedPhony:
+		return "UNCHALLENGED_PHONY"
+	case ValidWithdrawn:
+		return "VALID_WITHDRAWN"
+	case IllegalEvent:
+		return "ILLEGAL_EVENT"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// GCGDiscrepancy is a point at which a GCG record disagrees with the game
+// replayed from it
+type GCGDiscrepancy struct {
+	Event   int             `json:"event"` // Number of the '>' line, from 1
+	Line    string          `json:"line"`
+	Kind    DiscrepancyKind `json:"kind"`
+	Message string          `json:"message"`
+}
+
+// String returns the discrepancy as "event N: KIND: message"
+func (d GCGDiscrepancy) String() string {
+	return fmt.Sprintf("event %d: %s: %s", d.Event, d.Kind, d.Message)
+}
+
+// VerifyGCG replays a GCG record as ImportGCGWithInfo does, re-deriving every
+// score and checking every move, and returns the discrepancies found rather
+// than failing on the first
+// Besides each play's score it checks that running totals add up, that
+// end-of-game rack adjustments are worth the rack, and that each rack holds
+// the tiles kept from the player's previous turn. Given a dictionary, plays
+// forming invalid words must be withdrawn and withdrawn plays must form one.
+// An event that breaks the rules is reported last, and the game returned is
+// replayed up to it. Errors are returned only for records that cannot be read.
+func VerifyGCG(r io.Reader, dict dictionary.Dictionary) (*Game, GCGInfo, []GCGDiscrepancy, error) {
+	v := &gcgVerifier{dict: dict, totals: make(map[string]int), leaves: make(map[string][]Tile)}
+	g, info, err := importGCG(r, v)
+	if err != nil {
+		return nil, info, nil, err
+	}
+	return g, info, v.found, nil
+}
+
+// gcgVerifier collects discrepancies while a GCG record is replayed
+// Its methods do nothing on a nil verifier, which is how plain imports run.
+type gcgVerifier struct {
+	dict   dictionary.Dictionary
+	event  int               // Number of the event being replayed
+	line   string            // Text of the event being replayed
+	turns  int               // Length of the history before the event
+	totals map[string]int    // Last total the record gave each player
+	leaves map[string][]Tile // Tiles each player kept after their last turn
+	phony  *GCGDiscrepancy   // Play forming invalid words, until the next event shows whether it was withdrawn
+	halted bool              // True once an illegal event stopped the replay
+	found  []GCGDiscrepancy
+}
+
+// flag records a discrepancy at the current event
+func (v *gcgVerifier) flag(kind DiscrepancyKind, format string, args ...any) {
+	v.found = append(v.found, GCGDiscrepancy{Event: v.event, Line: v.line, Kind: kind, Message: fmt.Sprintf(format, args...)})
+	if kind == IllegalEvent {
+		v.halted = true
+	}
+}
+
+// stopped returns true if an illegal event stopped the replay
+func (v *gcgVerifier) stopped() bool {
+	return v != nil && v.halted
+}
+
+// before checks an event against the state left by the events before it
+func (v *gcgVerifier) before(g *Game, event int, line string) {
+	if v == nil {
+		return
+	}
+	v.event, v.line, v.turns = event, line, len(g.History)
+	nick, fields, err := parseGCGEvent(line)
+	if err != nil {
+		return
+	}
+
+	withdrawal := fields[1] == "--"
+	switch {
+	case v.phony != nil && !withdrawal:
+		v.found = append(v.found, *v.phony)
+	case v.phony == nil && withdrawal && v.dict != nil:
+		v.flag(ValidWithdrawn, "the withdrawn play formed only valid words")
+	}
+	v.phony = nil
+
+	if rack, ok := gcgTurnRack(fields); ok {
+		if missing := missingTiles(rackFromGCG(rack), v.leaves[nick]); len(missing) > 0 {
+			v.flag(RackMismatch, "rack %s does not hold %s kept from the previous turn", rack, gcgRack(missing))
+		}
+	}
+}
+
+// after checks the scores an event recorded against the replay
+func (v *gcgVerifier) after(g *Game, line string) {
+	if v == nil {
+		return
+	}
+	nick, fields, err := parseGCGEvent(line)
+	if err != nil {
+		return
+	}
+	delta, total, ok := gcgScores(fields)
+	if !ok {
+		return
+	}
+
+	if prev := v.totals[nick]; prev+delta != total {
+		v.flag(TotalMismatch, "%+d on %d makes %d, record says %d", delta, prev, prev+delta, total)
+	}
+	v.totals[nick] = total
+
+	last, _ := g.History.Last()
+	switch {
+	case fields[1] == "--":
+		if delta != -last.Score {
+			v.flag(ScoreMismatch, "withdrawal takes %d, the play scored %d", -delta, last.Score)
+		}
+		v.leaves[nick] = last.Rack
+	case len(g.History) > v.turns:
+		v.leaves[nick] = leaveAfter(last)
+		if last.Type == PlaceTiles && v.dict != nil {
+			var invalid []string
+			for _, w := range last.Words {
+				if !v.dict.IsValid(w) {
+					invalid = append(invalid, w)
+				}
+			}
+			if len(invalid) > 0 {
+				v.phony = &GCGDiscrepancy{Event: v.event, Line: v.line, Kind: UnchallengedPhony,
+					Message: fmt.Sprintf("%s stayed on the board", strings.Join(invalid, ", "))}
+			}
+		}
+	default:
+		v.checkEndRack(fields, delta)
+	}
+}
+
+// checkEndRack checks that an end-of-game adjustment such as "(AEI) +6" is
+// twice the rack's value when gained and its value when lost
+func (v *gcgVerifier) checkEndRack(fields []string, delta int) {
+	for _, f := range fields {
+		if !strings.HasPrefix(f, "(") || f == "(challenge)" || f == "(time)" {
+			continue
+		}
+		value := 0
+		for _, t := range rackFromGCG(strings.Trim(f, "()")) {
+			value += t.Points
+		}
+		want := 2 * value
+		if delta < 0 {
+			want = -value
+		}
+		if delta != want {
+			v.flag(EndRackMismatch, "%s is worth %+d, record says %+d", f, want, delta)
+		}
+		return
+	}
+}
+
+// finish reports a phony left by the last event
+func (v *gcgVerifier) finish() {
+	if v != nil && v.phony != nil {
+		v.found = append(v.found, *v.phony)
+		v.phony = nil
+	}
+}
+
+// parseGCGEvent splits a '>' event line into the player's nickname and the
+// fields after the colon, of which there are at least two
+func parseGCGEvent(line string) (string, []string, error) {
+	colon := strings.Index(line, ":")
+	if colon < 0 {
+		return "", nil, fmt.Errorf("malformed event: %q", line)
+	}
+	fields := strings.Fields(line[colon+1:])
+	if len(fields) < 2 {
+		return "", nil, fmt.Errorf("malformed event: %q", line)
+	}
+	return strings.TrimSpace(line[1:colon]), fields, nil
+}
+
+// gcgTurnRack returns the rack written on a play, exchange or pass event, if
+// the record gives one
+func gcgTurnRack(fields []string) (string, bool) {
+	switch {
+	case fields[1] == "--", strings.HasPrefix(fields[0], "("), strings.HasPrefix(fields[1], "("):
+		return "", false
+	case len(fields) == 4 && strings.ContainsAny(fields[0], "0123456789"):
+		return "", false
+	}
+	return fields[0], true
+}
+
+// gcgScores returns the signed score and the running total that end an event
+func gcgScores(fields []string) (delta, total int, ok bool) {
+	d, t := fields[len(fields)-2], fields[len(fields)-1]
+	if !strings.HasPrefix(d, "+") && !strings.HasPrefix(d, "-") {
+		return 0, 0, false
+	}
+	delta, err := strconv.Atoi(d)
+	if err != nil {
+		return 0, 0, false
+	}
+	total, err = strconv.Atoi(t)
+	return delta, total, err == nil
+}
+
+// leaveAfter returns the tiles a player kept after a turn
+func leaveAfter(m Move) []Tile {
+	var used []Tile
+	switch m.Type {
+	case PlaceTiles:
+		for _, pt := range m.Tiles {
+			used = append(used, pt.Tile)
+		}
+	case Exchange:
+		used = m.Exchanged
+	}
+
+	leave := append([]Tile(nil), m.Rack...)
+	for _, t := range used {
+		if i := findTile(leave, t); i >= 0 {
+			leave = append(leave[:i], leave[i+1:]...)
+		}
+	}
+	return leave
+}
+
+// missingTiles returns the tiles of want that rack does not hold
+func missingTiles(rack, want []Tile) []Tile {
+	rack = append([]Tile(nil), rack...)
+	var missing []Tile
+	for _, t := range want {
+		if i := findTile(rack, t); i >= 0 {
+			rack = append(rack[:i], rack[i+1:]...)
+		} else {
+			missing = append(missing, t)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,120 @@
+package game
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"scrabbled/internal/dictionary"
+)
+
+// verifyRecord verifies a GCG record, failing the test if it cannot be read
+func verifyRecord(t *testing.T, record string, dict dictionary.Dictionary) (*Game, []GCGDiscrepancy) {
+	t.Helper()
+	g, _, found, err := VerifyGCG(strings.NewReader(record), dict)
+	if err != nil {
+		t.Fatalf("VerifyGCG failed: %v", err)
+	}
+	return g, found
+}
+
+// sampleRecord returns testdata/sample.gcg with old replaced by new
+func sampleRecord(t *testing.T, old, new string) string {
+	t.Helper()
+	data, err := os.ReadFile("testdata/sample.gcg")
+	if err != nil {
+		t.Fatalf("Failed to read sample: %v", err)
+	}
+	if !strings.Contains(string(data), old) {
+		t.Fatalf("Sample does not contain %q", old)
+	}
+	return strings.Replace(string(data), old, new, 1)
+}
+
+// TestVerifyGCG tests that a consistent record verifies cleanly
+func TestVerifyGCG(t *testing.T) {
+	g, found := verifyRecord(t, sampleRecord(t, "", ""), nil)
+	if len(found) != 0 {
+		t.Errorf("Should find no discrepancies, got %v", found)
+	}
+	if len(g.History) != 6 || g.Players[0].Score != 10 || g.Players[1].Score != 20 {
+		t.Errorf("Expected the whole record replayed, got %d moves and scores %d-%d",
+			len(g.History), g.Players[0].Score, g.Players[1].Score)
+	}
+}
+
+// TestVerifyGCGDiscrepancies tests that tampered records are flagged at the right event
+func TestVerifyGCGDiscrepancies(t *testing.T) {
+	testCases := map[string]struct {
+		old, new string
+		event    int
+		kind     DiscrepancyKind
+	}{
+		"wrong score":   {"9F DO +9 20", "9F DO +8 19", 8, ScoreMismatch},
+		"wrong total":   {"9F DO +9 20", "9F DO +9 21", 8, TotalMismatch},
+		"rack mismatch": {"DEGOVW? -", "DEGOVA? -", 4, RackMismatch},
+	}
+
+	for name, tc := range testCases {
+		_, found := verifyRecord(t, sampleRecord(t, tc.old, tc.new), nil)
+		if len(found) != 1 || found[0].Event != tc.event || found[0].Kind != tc.kind {
+			t.Errorf("%s: Expected %s at event %d, got %v", name, tc.kind, tc.event, found)
+		}
+	}
+}
+
+// TestVerifyGCGEndRack tests checking end-of-game rack adjustments
+func TestVerifyGCGEndRack(t *testing.T) {
+	header := "#player1 a A\n#player2 b B\n>a: CAT 8G CAT +10 10\n"
+
+	if _, found := verifyRecord(t, header+">a: (QI) +22 32\n", nil); len(found) != 0 {
+		t.Errorf("Twice the rack should verify, got %v", found)
+	}
+	if _, found := verifyRecord(t, header+">b: QI (QI) -11 -11\n", nil); len(found) != 0 {
+		t.Errorf("Losing the rack should verify, got %v", found)
+	}
+	_, found := verifyRecord(t, header+">a: (QI) +20 30\n", nil)
+	if len(found) != 1 || found[0].Kind != EndRackMismatch {
+		t.Errorf("Expected END_RACK_MISMATCH, got %v", found)
+	}
+}
+
+// TestVerifyGCGPhonies tests checking plays against a dictionary
+func TestVerifyGCGPhonies(t *testing.T) {
+	dict := dictionary.NewWordList(dictionary.Custom)
+	dict.AddWord("CAT")
+	header := "#player1 a A\n#player2 b B\n"
+
+	_, found := verifyRecord(t, header+">a: CTA 8G CTA +10 10\n>b: DOG - +0 0\n", dict)
+	if len(found) != 1 || found[0].Kind != UnchallengedPhony || found[0].Event != 1 {
+		t.Errorf("Expected an unchallenged phony at event 1, got %v", found)
+	}
+	if _, found := verifyRecord(t, header+">a: CTA 8G CTA +10 10\n", dict); len(found) != 1 || found[0].Kind != UnchallengedPhony {
+		t.Errorf("A phony on the last event should be flagged, got %v", found)
+	}
+	if _, found := verifyRecord(t, header+">a: CTA 8G CTA +10 10\n>a: CTA -- -10 0\n", dict); len(found) != 0 {
+		t.Errorf("A withdrawn phony should verify, got %v", found)
+	}
+	_, found = verifyRecord(t, header+">a: CAT 8G CAT +10 10\n>a: CAT -- -10 0\n", dict)
+	if len(found) != 1 || found[0].Kind != ValidWithdrawn || found[0].Event != 2 {
+		t.Errorf("Expected a valid play withdrawn at event 2, got %v", found)
+	}
+}
+
+// TestVerifyGCGIllegal tests that an illegal event stops the replay
+func TestVerifyGCGIllegal(t *testing.T) {
+	g, found := verifyRecord(t, sampleRecord(t, "9F DO +9 20", "1A DO +9 20"), nil)
+	if len(found) != 1 || found[0].Kind != IllegalEvent || found[0].Event != 8 {
+		t.Fatalf("Expected ILLEGAL_EVENT at event 8, got %v", found)
+	}
+	if len(g.History) != 5 {
+		t.Errorf("Expected the replay to stop before event 8, got %d moves", len(g.History))
+	}
+	if got := found[0].String(); !strings.HasPrefix(got, "event 8: ILLEGAL_EVENT: ") {
+		t.Errorf("Unexpected string %q", got)
+	}
+
+	if _, _, _, err := VerifyGCG(strings.NewReader("#player1 a A\n"), nil); err == nil {
+		t.Error("Should fail on a record that cannot be read")
+	}
+}
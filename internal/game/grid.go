@@ -0,0 +1,138 @@
+package game
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ParseGrid reads a position typed in from a physical board, for analysis
+//
+// The input starts with 15 lines of 15 characters giving rows 1 to 15, each
+// from column A, with a letter for each tile (lower case for a blank) and '.'
+// or '-' for an empty square. Each following line gives a player's rack,
+// starting with the player to move, optionally followed by their score:
+//
+//	AEINRST 120
+//	DGO? 95
+//
+// A rack may be '-' when empty. Blank lines and lines starting with '#' are
+// ignored. The tiles must come from the variant's tile set and, as on a real
+// board, connect to one another through the center square. Pass the result to
+// NewGameFromPosition to continue the game.
+func ParseGrid(r io.Reader, variant Variant) (*GamePosition, error) {
+	pos := &GamePosition{Board: NewBoardForVariant(variant)}
+	row := 0
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if row < 15 {
+			if err := pos.parseGridRow(row, line); err != nil {
+				return nil, err
+			}
+			row++
+			continue
+		}
+		if err := pos.parseGridRack(line, variant); err != nil {
+			return nil, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if row < 15 {
+		return nil, fmt.Errorf("%w: expected 15 rows, got %d", ErrInvalidNotation, row)
+	}
+	if len(pos.Racks) < MinPlayers || len(pos.Racks) > MaxPlayers {
+		return nil, fmt.Errorf("%w: %d racks, expected %d to %d", ErrInvalidNotation, len(pos.Racks), MinPlayers, MaxPlayers)
+	}
+	if err := pos.checkTileCounts(); err != nil {
+		return nil, err
+	}
+	if err := pos.checkConnected(); err != nil {
+		return nil, err
+	}
+	return pos, nil
+}
+
+// parseGridRow places the tiles of one grid row
+func (p *GamePosition) parseGridRow(row int, line string) error {
+	runes := []rune(line)
+	if len(runes) != 15 {
+		return fmt.Errorf("%w: row %d has %d squares", ErrInvalidNotation, row+1, len(runes))
+	}
+	for col, r := range runes {
+		pos := Position{Row: row, Col: col}
+		switch {
+		case r == '.' || r == '-':
+		case unicode.IsUpper(r):
+			p.Board.PlaceTile(Tile{Letter: r, Points: p.Board.Variant.TileValue(r)}, pos)
+		case unicode.IsLower(r):
+			p.Board.PlaceTile(Tile{Letter: unicode.ToUpper(r), IsBlank: true}, pos)
+		default:
+			return fmt.Errorf("%w: unexpected %q at %s", ErrInvalidNotation, r, pos.String())
+		}
+	}
+	return nil
+}
+
+// parseGridRack reads a rack line and its optional score
+func (p *GamePosition) parseGridRack(line string, variant Variant) error {
+	fields := strings.Fields(line)
+	if len(fields) > 2 {
+		return fmt.Errorf("%w: rack line %q has %d fields", ErrInvalidNotation, line, len(fields))
+	}
+	rack, err := ParseRack(fields[0], variant)
+	if err != nil {
+		return err
+	}
+	score := 0
+	if len(fields) == 2 {
+		if score, err = strconv.Atoi(fields[1]); err != nil {
+			return fmt.Errorf("%w: bad score %q", ErrInvalidNotation, fields[1])
+		}
+	}
+	p.Racks = append(p.Racks, rack)
+	p.Scores = append(p.Scores, score)
+	return nil
+}
+
+// checkConnected verifies that the tiles on the board form one group
+// covering the center square
+func (p *GamePosition) checkConnected() error {
+	occupied := p.Board.GetOccupiedPositions()
+	if len(occupied) == 0 {
+		return nil
+	}
+	if !p.Board.HasTileAt(p.Board.Center) {
+		return fmt.Errorf("%w: no tile on the center square %s", ErrInvalidNotation, p.Board.Center.String())
+	}
+
+	reached := map[Position]bool{p.Board.Center: true}
+	queue := []Position{p.Board.Center}
+	for len(queue) > 0 {
+		pos := queue[0]
+		queue = queue[1:]
+		for _, step := range []Position{{Row: -1}, {Row: 1}, {Col: -1}, {Col: 1}} {
+			next := Position{Row: pos.Row + step.Row, Col: pos.Col + step.Col}
+			if next.IsValid() && !reached[next] && p.Board.HasTileAt(next) {
+				reached[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	for _, pos := range occupied {
+		if !reached[pos] {
+			return fmt.Errorf("%w: tile at %s is not connected to the center", ErrInvalidNotation, pos.String())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package game
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// exampleGrid is CAT with a blank A at H8 and a down word ATE through the A
+const exampleGrid = `# Club night, round 3
+...............
+...............
+...............
+...............
+...............
+...............
+...............
+.......CaT.....
+........T......
+........E......
+...............
+...............
+...............
+...............
+...............
+
+AEINRST 12
+DGO? 0
+`
+
+// TestParseGrid tests reading a typed-in board with racks and scores
+func TestParseGrid(t *testing.T) {
+	pos, err := ParseGrid(strings.NewReader(exampleGrid), Classic)
+	if err != nil {
+		t.Fatalf("ParseGrid failed: %v", err)
+	}
+
+	if tile := pos.Board.GetTile(mustPos(t, "H8")); tile == nil || tile.Letter != 'C' || tile.Points != 3 {
+		t.Errorf("H8 should hold a C worth 3, got %+v", tile)
+	}
+	if tile := pos.Board.GetTile(mustPos(t, "I8")); tile == nil || !tile.IsBlank || tile.Letter != 'A' || tile.Points != 0 {
+		t.Errorf("I8 should hold a blank A, got %+v", tile)
+	}
+	if len(pos.Board.GetOccupiedPositions()) != 5 {
+		t.Errorf("Board should hold five tiles, got %d", len(pos.Board.GetOccupiedPositions()))
+	}
+	if len(pos.Racks) != 2 || len(pos.Racks[0]) != 7 || !pos.Racks[1][3].IsBlank {
+		t.Errorf("Racks were not parsed correctly: %v", pos.Racks)
+	}
+	if pos.Scores[0] != 12 || pos.Scores[1] != 0 || pos.Turn != 0 {
+		t.Errorf("Scores and turn were not parsed correctly: %v %d", pos.Scores, pos.Turn)
+	}
+
+	g, err := NewGameFromPosition("typed", pos)
+	if err != nil {
+		t.Fatalf("NewGameFromPosition failed: %v", err)
+	}
+	if g.CurrentPlayer().ID != "p1" || g.TileBag.RemainingCount() != 100-5-11 {
+		t.Errorf("Game should continue from the typed position, bag holds %d", g.TileBag.RemainingCount())
+	}
+}
+
+// TestParseGridErrors tests that mistyped grids are rejected with the reason
+func TestParseGridErrors(t *testing.T) {
+	rows := strings.Split(strings.TrimSpace(exampleGrid), "\n")[1:16]
+	grid := func(edit func(rows []string)) string {
+		r := append([]string(nil), rows...)
+		edit(r)
+		return strings.Join(r, "\n") + "\n"
+	}
+
+	tests := map[string]string{
+		"missing rows":   strings.Join(rows[:14], "\n"),
+		"no racks":       grid(func([]string) {}),
+		"one rack":       grid(func([]string) {}) + "AB\n",
+		"short row":      grid(func(r []string) { r[0] = "......" }) + "A\nB\n",
+		"bad square":     grid(func(r []string) { r[0] = "*.............." }) + "A\nB\n",
+		"bad score":      grid(func([]string) {}) + "A x\nB\n",
+		"extra field":    grid(func([]string) {}) + "A 1 2\nB\n",
+		"too many Zs":    grid(func(r []string) { r[7] = ".......ZZ......" }) + "A\nB\n",
+		"off center":     grid(func(r []string) { r[7] = "CAT............"; r[8] = "..............."; r[9] = "..............." }) + "A\nB\n",
+		"disconnected":   grid(func(r []string) { r[0] = "Q.............." }) + "A\nB\n",
+		"too many racks": grid(func([]string) {}) + "A\nB\nC\nD\nE\n",
+	}
+	for name, s := range tests {
+		if _, err := ParseGrid(strings.NewReader(s), Classic); !errors.Is(err, ErrInvalidNotation) {
+			t.Errorf("%s: ParseGrid should fail with ErrInvalidNotation, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package game
+
+import (
+	"errors"
+	"sort"
+
+	"scrabbled/internal/dictionary"
+)
+
+// DefaultHintCount is the number of suggestions returned when no count is given
+const DefaultHintCount = 3
+
+// ErrNoMoveGenerator is returned when moves are suggested without a move generator
+var ErrNoMoveGenerator = errors.New("a move generator is required to suggest moves")
+
+// SuggestRanking determines the order of suggested moves
+type SuggestRanking int
+
+const (
+	RankByScore  SuggestRanking = iota // Highest scoring moves first
+	RankByEquity                       // Best score plus rack leave value first
+)
+
+// String returns a string representation of the ranking
+func (r SuggestRanking) String() string {
+	switch r {
+	case RankByScore:
+		return "SCORE"
+	case RankByEquity:
+		return "EQUITY"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// suggestConfig collects the settings applied by suggest options
+type suggestConfig struct {
+	ranking    SuggestRanking
+	vocabulary dictionary.Dictionary
+}
+
+// SuggestOption adjusts how moves are suggested
+type SuggestOption func(*suggestConfig)
+
+// RankBy orders suggestions by score or by equity
+func RankBy(ranking SuggestRanking) SuggestOption {
+	return func(c *suggestConfig) {
+		c.ranking = ranking
+	}
+}
+
+// WithVocabulary limits suggestions to moves whose words are all in vocab
+// Teaching mode uses this to keep suggestions to words suited to the player's level.
+func WithVocabulary(vocab dictionary.Dictionary) SuggestOption {
+	return func(c *suggestConfig) {
+		c.vocabulary = vocab
+	}
+}
+
+// SuggestMoves returns the best moves for a player's current rack using the
+// attached move generator, ranked by score unless an option says otherwise
+// At most n suggestions are returned; n <= 0 uses DefaultHintCount.
+func (g *Game) SuggestMoves(playerID string, n int, opts ...SuggestOption) ([]RankedMove, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if g.Generator == nil {
+		return nil, ErrNoMoveGenerator
+	}
+
+	var cfg suggestConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return g.suggest(playerID, g.Generator, n, cfg)
+}
+
+// Hint suggests the best moves for a player's current rack, ranked by equity
+// At most n suggestions are returned; n <= 0 uses DefaultHintCount.
+func (g *Game) Hint(playerID string, generator *MoveGenerator, n int) ([]RankedMove, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.suggest(playerID, generator, n, suggestConfig{ranking: RankByEquity})
+}
+
+// suggest generates, filters and ranks moves for a player; callers must hold the lock
+func (g *Game) suggest(playerID string, generator *MoveGenerator, n int, cfg suggestConfig) ([]RankedMove, error) {
+	if g.State != InProgress {
+		return nil, ErrGameNotInProgress
+	}
+	player := g.findPlayer(playerID)
+	if player == nil {
+		return nil, ErrPlayerNotFound
+	}
+
+	moves := generator.GenerateMoves(g.Board, player.Rack)
+	if cfg.vocabulary != nil {
+		moves = g.withinVocabulary(moves, cfg.vocabulary)
+	}
+
+	ranked := NewLeaveEvaluator().Rank(player.Rack, moves)
+	if cfg.ranking == RankByScore {
+		// Equal scores stay in equity order
+		sort.SliceStable(ranked, func(i, j int) bool {
+			return ranked[i].Move.Score > ranked[j].Move.Score
+		})
+	}
+
+	if n <= 0 {
+		n = DefaultHintCount
+	}
+	if n < len(ranked) {
+		ranked = ranked[:n]
+	}
+	for i := range ranked {
+		ranked[i].Move.PlayerID = playerID
+	}
+	return ranked, nil
+}
+
+// withinVocabulary keeps the moves whose formed words are all in vocab;
+// callers must hold the lock
+func (g *Game) withinVocabulary(moves []Move, vocab dictionary.Dictionary) []Move {
+	kept := make([]Move, 0, len(moves))
+	for _, m := range moves {
+		known := true
+		for _, word := range g.Board.FormedWords(m) {
+			if !vocab.IsValid(word.Word) {
+				known = false
+				break
+			}
+		}
+		if known {
+			kept = append(kept, m)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,122 @@
+package game
+
+import (
+	"testing"
+
+	"scrabbled/internal/dictionary"
+)
+
+// TestHint tests suggesting moves for a player's rack
+func TestHint(t *testing.T) {
+	mg := NewMoveGenerator(testWords)
+	g := newTestGame(t, 2)
+
+	if _, err := g.Hint("p1", mg, 0); err != ErrGameNotInProgress {
+		t.Errorf("Hints should require a game in progress, got %v", err)
+	}
+
+	g.StartGame()
+	g.Players[0].Rack = rackOf("CATSDOG")
+
+	hints, err := g.Hint("p1", mg, 0)
+	if err != nil {
+		t.Fatalf("Hint failed: %v", err)
+	}
+	if len(hints) == 0 || len(hints) > DefaultHintCount {
+		t.Fatalf("Expected 1-%d hints, got %d", DefaultHintCount, len(hints))
+	}
+	for i, h := range hints {
+		if h.Move.PlayerID != "p1" {
+			t.Errorf("Hint should be attributed to the player")
+		}
+		if i > 0 && h.Equity > hints[i-1].Equity {
+			t.Errorf("Hints should be ordered by equity")
+		}
+		if err := g.ValidateMove(h.Move); err != nil {
+			t.Errorf("Hint %s should be a legal move: %v", h.Move.Word, err)
+		}
+	}
+
+	if all, _ := g.Hint("p1", mg, 1000); len(all) <= DefaultHintCount {
+		t.Errorf("A larger count should return more hints, got %d", len(all))
+	}
+
+	if _, err := g.Hint("nobody", mg, 1); err != ErrPlayerNotFound {
+		t.Errorf("Should return ErrPlayerNotFound, got %v", err)
+	}
+}
+
+// TestSuggestMoves tests ranked suggestions from the attached move generator
+func TestSuggestMoves(t *testing.T) {
+	g := newTestGame(t, 2)
+	g.StartGame()
+	g.Players[0].Rack = rackOf("CATSDOG")
+
+	if _, err := g.SuggestMoves("p1", 3); err != ErrNoMoveGenerator {
+		t.Errorf("Should require a move generator, got %v", err)
+	}
+	g.SetMoveGenerator(NewMoveGenerator(testWords))
+
+	byScore, err := g.SuggestMoves("p1", 5)
+	if err != nil {
+		t.Fatalf("SuggestMoves failed: %v", err)
+	}
+	if len(byScore) != 5 {
+		t.Fatalf("Expected 5 suggestions, got %d", len(byScore))
+	}
+	for i, s := range byScore {
+		if i > 0 && s.Move.Score > byScore[i-1].Move.Score {
+			t.Errorf("Suggestions should be ordered by score by default")
+		}
+		if s.Move.PlayerID != "p1" {
+			t.Errorf("Suggestions should be attributed to the player")
+		}
+	}
+
+	byEquity, err := g.SuggestMoves("p1", 5, RankBy(RankByEquity))
+	if err != nil {
+		t.Fatalf("SuggestMoves failed: %v", err)
+	}
+	for i := 1; i < len(byEquity); i++ {
+		if byEquity[i].Equity > byEquity[i-1].Equity {
+			t.Errorf("Suggestions should be ordered by equity when asked")
+		}
+	}
+
+	if _, err := g.SuggestMoves("nobody", 1); err != ErrPlayerNotFound {
+		t.Errorf("Should return ErrPlayerNotFound, got %v", err)
+	}
+}
+
+// TestSuggestMovesVocabulary tests restricting suggestions to a word list
+func TestSuggestMovesVocabulary(t *testing.T) {
+	g := newTestGame(t, 2)
+	g.SetMoveGenerator(NewMoveGenerator(testWords))
+	g.StartGame()
+	g.Players[0].Rack = rackOf("CATSDOG")
+
+	beginner := dictionary.NewWordList(dictionary.Custom)
+	for _, word := range []string{"CAT", "DOG"} {
+		beginner.AddWord(word)
+	}
+
+	suggestions, err := g.SuggestMoves("p1", 100, WithVocabulary(beginner))
+	if err != nil {
+		t.Fatalf("SuggestMoves failed: %v", err)
+	}
+	if len(suggestions) == 0 {
+		t.Fatalf("Should suggest moves using the vocabulary")
+	}
+	for _, s := range suggestions {
+		if s.Move.Word != "CAT" && s.Move.Word != "DOG" {
+			t.Errorf("Should only suggest words in the vocabulary, got %s", s.Move.Word)
+		}
+	}
+}
+
+// TestSuggestRankingString tests ranking names
+func TestSuggestRankingString(t *testing.T) {
+	if RankByScore.String() != "SCORE" || RankByEquity.String() != "EQUITY" || SuggestRanking(9).String() != "UNKNOWN" {
+		t.Errorf("Unexpected ranking names")
+	}
+}
@@ -0,0 +1,64 @@
+package game
+
+// History is the ordered record of every action taken in a game
+type History []Move
+
+// ForPlayer returns the entries for actions taken by the given player
+func (h History) ForPlayer(playerID string) History {
+	filtered := History{}
+	for _, m := range h {
+		if m.PlayerID == playerID {
+			filtered = append(filtered, m)
+		}
+	}
+	return filtered
+}
+
+// OfType returns the entries of the given action type
+func (h History) OfType(mt MoveType) History {
+	filtered := History{}
+	for _, m := range h {
+		if m.Type == mt {
+			filtered = append(filtered, m)
+		}
+	}
+	return filtered
+}
+
+// Last returns the most recent entry, or false if the history is empty
+func (h History) Last() (Move, bool) {
+	if len(h) == 0 {
+		return Move{}, false
+	}
+	return h[len(h)-1], true
+}
+
+// copyHistory returns a copy of the history that shares no entries with h
+func copyHistory(h History) History {
+	cp := make(History, len(h))
+	copy(cp, h)
+	return cp
+}
+
+// GetHistory returns a copy of the game's full action history
+func (g *Game) GetHistory() History {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return copyHistory(g.History)
+}
+
+// GetPlayerHistory returns the actions taken by one player, suitable for a scoresheet
+func (g *Game) GetPlayerHistory(playerID string) History {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.History.ForPlayer(playerID)
+}
+
+// rackCopy returns a copy of a rack for recording in the history
+func rackCopy(rack []Tile) []Tile {
+	cp := make([]Tile, len(rack))
+	copy(cp, rack)
+	return cp
+}
@@ -0,0 +1,87 @@
+package game
+
+import (
+	"testing"
+)
+
+// TestHistoryRecording tests that every action is recorded with rack and totals
+func TestHistoryRecording(t *testing.T) {
+	g := newChallengeGame(t)
+	g.Players[0].Rack = rackOf("CATXYZQ")
+
+	move, _ := g.Board.BuildMove("p1", "CAT", mustPos(t, "G8"), Horizontal, nil)
+	g.PlayMove(move)
+	g.Challenge("p2")
+	g.ExchangeTiles("p1", []int{0})
+	g.PassTurn("p2")
+
+	history := g.GetHistory()
+	expected := []MoveType{PlaceTiles, ChallengeTurn, Exchange, Pass}
+	if len(history) != len(expected) {
+		t.Fatalf("Expected %d history entries, got %d", len(expected), len(history))
+	}
+	for i, mt := range expected {
+		if history[i].Type != mt {
+			t.Errorf("Entry %d: expected %s, got %s", i, mt, history[i].Type)
+		}
+		if history[i].Timestamp.IsZero() {
+			t.Errorf("Entry %d should have a timestamp", i)
+		}
+	}
+
+	play := history[0]
+	if play.PlayerID != "p1" || play.Score != 10 || play.Total != 10 {
+		t.Errorf("Play entry should record score and total, got %+v", play)
+	}
+	if len(play.Rack) != MaxRackSize || play.Rack[0].Letter != 'C' {
+		t.Errorf("Play entry should record the rack before the move, got %v", play.Rack)
+	}
+
+	challenge := history[1]
+	if challenge.PlayerID != "p2" || challenge.Challenge == nil || challenge.Challenge.Successful {
+		t.Errorf("Challenge entry should record the failed challenge, got %+v", challenge)
+	}
+
+	// GetHistory returns a copy
+	history[0].Score = 999
+	if g.History[0].Score == 999 {
+		t.Errorf("GetHistory should return a copy")
+	}
+}
+
+// TestHistoryFilters tests per-player and per-type views
+func TestHistoryFilters(t *testing.T) {
+	g := newTestGame(t, 3)
+	g.StartGame()
+	g.PassTurn("p1")
+	g.PassTurn("p2")
+	g.ExchangeTiles("p3", []int{0})
+	g.PassTurn("p1")
+
+	p1 := g.GetPlayerHistory("p1")
+	if len(p1) != 2 {
+		t.Errorf("p1 should have 2 entries, got %d", len(p1))
+	}
+	for _, m := range p1 {
+		if m.PlayerID != "p1" {
+			t.Errorf("Filtered history should only contain p1, got %s", m.PlayerID)
+		}
+	}
+
+	if passes := g.GetHistory().OfType(Pass); len(passes) != 3 {
+		t.Errorf("Expected 3 passes, got %d", len(passes))
+	}
+
+	if len(g.GetPlayerHistory("nobody")) != 0 {
+		t.Errorf("Unknown player should have no history")
+	}
+
+	last, ok := g.GetHistory().Last()
+	if !ok || last.PlayerID != "p1" || last.Type != Pass {
+		t.Errorf("Last entry should be p1's pass, got %+v", last)
+	}
+
+	if _, ok := (History{}).Last(); ok {
+		t.Errorf("Empty history should have no last entry")
+	}
+}
@@ -0,0 +1,186 @@
+package game
+
+import "math/rand"
+
+// Default inference settings
+const (
+	DefaultInferenceSamples   = 200
+	DefaultInferenceTolerance = 5.0
+)
+
+// RackInference narrows an opponent's likely rack from their last turn
+// It deals candidate racks consistent with what the opponent did, keeps those
+// for which the turn was within Tolerance of the best equity play the rack
+// offered, and believes the opponent holds the tiles a kept rack would have
+// left. Only public information is used: the tiles played, or the number of
+// tiles exchanged.
+type RackInference struct {
+	Samples   int             // Candidate racks dealt per inference
+	Tolerance float64         // Equity a turn may fall short of the best play and still be believed
+	Leave     *LeaveEvaluator // Used to value the candidate plays and leaves
+	generator *MoveGenerator
+	rng       *rand.Rand
+}
+
+// NewRackInference creates an inference whose random choices are determined by seed
+func NewRackInference(generator *MoveGenerator, seed int64) *RackInference {
+	return &RackInference{
+		Samples:   DefaultInferenceSamples,
+		Tolerance: DefaultInferenceTolerance,
+		Leave:     NewLeaveEvaluator(),
+		generator: generator,
+		rng:       rand.New(rand.NewSource(seed)),
+	}
+}
+
+// RackModel is the set of tiles an opponent is believed to have kept
+type RackModel struct {
+	Leaves [][]Tile `json:"leaves"` // Kept tiles of each rack consistent with the opponent's turn, equally likely
+}
+
+// Probability returns the chance the opponent kept at least one of the letter,
+// or a blank for letter 0
+func (m *RackModel) Probability(letter rune) float64 {
+	if m == nil || len(m.Leaves) == 0 {
+		return 0
+	}
+	held := 0
+	for _, leave := range m.Leaves {
+		for _, t := range leave {
+			if trackerKey(t) == letter {
+				held++
+				break
+			}
+		}
+	}
+	return float64(held) / float64(len(m.Leaves))
+}
+
+// sample picks one of the believed leaves at random
+func (m *RackModel) sample(rng *rand.Rand) []Tile {
+	return m.Leaves[rng.Intn(len(m.Leaves))]
+}
+
+// Infer returns the tiles the opponent is believed to have kept after their
+// last turn, played on the board before
+// unseen holds the opponent's rack plus the bag from the inferring player's
+// point of view. Returns nil when the turn says nothing about the rack or no
+// dealt rack is consistent with it, leaving the rack to be dealt at random. A
+// move withdrawn after a challenge returned its tiles to the rack, so those
+// tiles are known to be held.
+func (ri *RackInference) Infer(before *Board, last Move, unseen []Tile) *RackModel {
+	var shown []Tile
+	hidden := 0
+	switch last.Type {
+	case PlaceTiles:
+		for _, pt := range last.Tiles {
+			shown = append(shown, rackTile(pt.Tile))
+		}
+		if last.Withdrawn {
+			return &RackModel{Leaves: [][]Tile{shown}}
+		}
+	case Exchange:
+		hidden = len(last.Exchanged)
+	default:
+		return nil
+	}
+
+	size := before.RackSize - len(shown) - hidden
+	if size > len(unseen)-hidden {
+		size = len(unseen) - hidden
+	}
+	if size <= 0 {
+		return nil
+	}
+
+	model := &RackModel{}
+	pool := make([]Tile, len(unseen))
+	for i := 0; i < ri.Samples; i++ {
+		copy(pool, unseen)
+		ri.rng.Shuffle(len(pool), func(i, j int) { pool[i], pool[j] = pool[j], pool[i] })
+		leave := pool[:size]
+		rack := append(append(append([]Tile{}, shown...), leave...), pool[size:size+hidden]...)
+
+		// The turn taken, valued as this rack would have seen it
+		taken := ri.Leave.Evaluate(leave)
+		if last.Type == PlaceTiles {
+			taken += float64(last.Score)
+		}
+		ranked := ri.Leave.Rank(rack, ri.generator.GenerateMoves(before, rack))
+		if len(ranked) > 0 && taken < ranked[0].Equity-ri.Tolerance {
+			continue
+		}
+		model.Leaves = append(model.Leaves, append([]Tile{}, leave...))
+	}
+	if len(model.Leaves) == 0 {
+		return nil
+	}
+	return model
+}
+
+// rackTile returns the tile as it was held on the rack, before any letter was
+// assigned to a blank
+func rackTile(t Tile) Tile {
+	if t.IsBlank {
+		return Tile{IsBlank: true}
+	}
+	return t
+}
+
+// takeTiles removes the given tiles from pool where it holds them, matching
+// blanks as blanks, and returns the rest of the pool and the tiles taken
+func takeTiles(pool, tiles []Tile) (rest, taken []Tile) {
+	rest = append([]Tile{}, pool...)
+	for _, t := range tiles {
+		for i, p := range rest {
+			if trackerKey(p) == trackerKey(t) {
+				taken = append(taken, p)
+				rest = append(rest[:i], rest[i+1:]...)
+				break
+			}
+		}
+	}
+	return rest, taken
+}
+
+// InferRack narrows the rack of the opponent who moved most recently, as seen
+// by the player
+// Returns nil if no opponent has played or exchanged, or nothing could be
+// inferred.
+func (g *Game) InferRack(playerID string, ri *RackInference) (*RackModel, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	player := g.findPlayer(playerID)
+	if player == nil {
+		return nil, ErrPlayerNotFound
+	}
+	return g.inferRack(player, ri), nil
+}
+
+// inferRack narrows the rack of the opponent who moved most recently
+// Passes and challenges leave the rack as it was, so the opponent's last play
+// or exchange is used. The board is taken as it stands less that play's tiles.
+// Callers must hold the lock.
+func (g *Game) inferRack(player *Player, ri *RackInference) *RackModel {
+	var last *Move
+	for i := len(g.History) - 1; i >= 0; i-- {
+		m := &g.History[i]
+		if m.PlayerID != player.ID && (m.Type == PlaceTiles || m.Type == Exchange) {
+			last = m
+			break
+		}
+	}
+	if last == nil {
+		return nil
+	}
+
+	before := &Board{}
+	g.Board.CopyTo(before)
+	if !last.Withdrawn {
+		for _, pt := range last.Tiles {
+			before.RemoveTile(pt.Position)
+		}
+	}
+	return ri.Infer(before, *last, g.Board.tileTracker().UnseenTiles(g.Board, player.Rack))
+}
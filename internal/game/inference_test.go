@@ -0,0 +1,106 @@
+package game
+
+import "testing"
+
+// TestRackInference tests narrowing the rack behind a play
+func TestRackInference(t *testing.T) {
+	ri := NewRackInference(NewMoveGenerator(testWords), 1)
+	ri.Samples = 100
+	b := NewBoard()
+
+	// Nobody holding QI would have opened with AT for 4
+	at := Move{Type: PlaceTiles, Score: 4, Tiles: []PlacedTile{
+		{Tile: Tile{Letter: 'A', Points: 1}, Position: Position{Row: 7, Col: 7}},
+		{Tile: Tile{Letter: 'T', Points: 1}, Position: Position{Row: 7, Col: 8}},
+	}}
+	model := ri.Infer(b, at, rackOf("QIEEEEEEEE"))
+	if model == nil {
+		t.Fatalf("Expected some racks consistent with the play")
+	}
+	for _, leave := range model.Leaves {
+		if len(leave) != 5 {
+			t.Fatalf("Expected five kept tiles, got %v", leave)
+		}
+		q, i := false, false
+		for _, tile := range leave {
+			q, i = q || tile.Letter == 'Q', i || tile.Letter == 'I'
+		}
+		if q && i {
+			t.Errorf("A rack holding QI should have been ruled out, got %v", leave)
+		}
+	}
+	if p := model.Probability('E'); p != 1 {
+		t.Errorf("Every leave should hold an E, got %v", p)
+	}
+
+	// Withdrawn tiles are back on the rack
+	withdrawn := at
+	withdrawn.Withdrawn = true
+	if p := ri.Infer(b, withdrawn, rackOf("QIEEEEEEEEAT")).Probability('T'); p != 1 {
+		t.Errorf("Withdrawn tiles should be known to be held, got %v", p)
+	}
+
+	// An exchange keeps the rest of the rack
+	if model := ri.Infer(b, Move{Type: Exchange, Exchanged: rackOf("EE")}, rackOf("QIEEEEEEEE")); model == nil || len(model.Leaves[0]) != 5 {
+		t.Errorf("Expected five kept tiles after exchanging two, got %+v", model)
+	}
+	if model := ri.Infer(b, Move{Type: Pass}, rackOf("QIEEEEEEEE")); model != nil {
+		t.Errorf("A pass should say nothing about the rack, got %+v", model)
+	}
+}
+
+// TestSimulateWithModel tests dealing the opponent the tiles a model believes they kept
+func TestSimulateWithModel(t *testing.T) {
+	mg := NewMoveGenerator(testWords)
+	b := NewBoard()
+	rack := rackOf("CATSDOG")
+	unseen := NewTileTracker().UnseenTiles(b, rack)
+	candidates := mg.GenerateMoves(b, rack)[:1]
+
+	run := func(model *RackModel) []SimResult {
+		sim := NewSimulator(mg, 5)
+		sim.Iterations = 10
+		return sim.SimulateWithModel(b, rack, unseen, model, candidates, 0)
+	}
+	plain := NewSimulator(mg, 5)
+	plain.Iterations = 10
+	random := run(nil)
+	if random[0].AvgSpread != plain.Simulate(b, rack, unseen, candidates, 0)[0].AvgSpread {
+		t.Errorf("A nil model should simulate as without inference")
+	}
+
+	// An opponent known to hold ZA outscores one dealt at random
+	za := run(&RackModel{Leaves: [][]Tile{rackOf("ZA")}})
+	if za[0].AvgSpread >= random[0].AvgSpread {
+		t.Errorf("Expected a worse spread against ZA, got %v", za[0].AvgSpread)
+	}
+}
+
+// TestGameInferRack tests inferring the rack of the last opponent to play
+func TestGameInferRack(t *testing.T) {
+	g := newAnnotatedGame(t)
+	ri := NewRackInference(NewMoveGenerator(testWords), 2)
+	ri.Samples = 20
+
+	model, err := g.InferRack("p2", ri)
+	if err != nil {
+		t.Fatalf("InferRack failed: %v", err)
+	}
+	if model == nil || len(model.Leaves[0]) != 4 {
+		t.Errorf("Expected four kept tiles behind CAT, got %+v", model)
+	}
+	if model, _ := g.InferRack("p1", ri); model != nil {
+		t.Errorf("A passing opponent should say nothing about their rack, got %+v", model)
+	}
+	if _, err := g.InferRack("nobody", ri); err != ErrPlayerNotFound {
+		t.Errorf("Should return ErrPlayerNotFound, got %v", err)
+	}
+
+	sim := NewSimulator(NewMoveGenerator(testWords), 3)
+	sim.Iterations = 5
+	sim.Inference = ri
+	g.Players[1].Rack = rackOf("SDOGAAT")
+	if results, err := g.Simulate("p2", sim, 2); err != nil || len(results) == 0 {
+		t.Errorf("Simulate with inference failed: %v", err)
+	}
+}
@@ -0,0 +1,186 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+)
+
+// ErrTilesNotConserved is wrapped by the InvariantError reported when the
+// tiles in the bag, on the racks and on the board do not make up the game's
+// tile distribution
+var ErrTilesNotConserved = errors.New("tiles not conserved")
+
+// TileCount tells where the copies of one tile are
+type TileCount struct {
+	Tile  string `json:"tile"` // Face of the tile, or "?" for blanks
+	Want  int    `json:"want"` // Copies in the distribution
+	Bag   int    `json:"bag"`
+	Racks int    `json:"racks"`
+	Board int    `json:"board"`
+}
+
+// Have returns the copies found
+func (c TileCount) Have() int {
+	return c.Bag + c.Racks + c.Board
+}
+
+// InvariantError reports a game whose tiles are not conserved, with a dump of
+// where every tile is
+type InvariantError struct {
+	GameID   string
+	Revision int64
+	After    string            // Last event recorded before the check, if any
+	Counts   []TileCount       // Every tile of the distribution or found, in order
+	Racks    map[string]string // Letters on each rack by player ID, ? for blanks
+	Bag      string            // Letters in the bag, ? for blanks
+}
+
+// Error summarizes the tiles whose counts are wrong
+func (e *InvariantError) Error() string {
+	var wrong []string
+	for _, c := range e.Counts {
+		if c.Have() != c.Want {
+			wrong = append(wrong, fmt.Sprintf("%s %d of %d", c.Tile, c.Have(), c.Want))
+		}
+	}
+	return fmt.Sprintf("game %s revision %d: %v: %s", e.GameID, e.Revision, ErrTilesNotConserved, strings.Join(wrong, ", "))
+}
+
+// Unwrap returns ErrTilesNotConserved
+func (e *InvariantError) Unwrap() error {
+	return ErrTilesNotConserved
+}
+
+// Dump returns a table of where every tile is, with wrong counts marked,
+// followed by the racks and the bag
+func (e *InvariantError) Dump() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "game %s revision %d", e.GameID, e.Revision)
+	if e.After != "" {
+		fmt.Fprintf(&b, " after %s", e.After)
+	}
+	b.WriteString("\ntile want have  bag racks board\n")
+	for _, c := range e.Counts {
+		mark := ""
+		if c.Have() != c.Want {
+			mark = " !"
+		}
+		fmt.Fprintf(&b, "%-4s %4d %4d %4d %5d %5d%s\n", c.Tile, c.Want, c.Have(), c.Bag, c.Racks, c.Board, mark)
+	}
+	ids := make([]string, 0, len(e.Racks))
+	for id := range e.Racks {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		fmt.Fprintf(&b, "rack %s: %s\n", id, e.Racks[id])
+	}
+	fmt.Fprintf(&b, "bag: %s\n", e.Bag)
+	return b.String()
+}
+
+// SetDebug turns invariant checking on or off
+// With it on, tile conservation is checked after every change to the game,
+// and a violation panics with an *InvariantError, so the action that broke
+// the invariant is on the stack; the error is logged first if a logger is
+// set. Each check counts every tile, so debug mode is meant for tests and
+// development.
+func (g *Game) SetDebug(on bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.debug = on
+}
+
+// CheckTiles checks that the tiles in the bag, on the racks and on the board
+// make up exactly the game's tile distribution, returning an *InvariantError
+// if not
+// Blanks are counted as blanks whatever letter they were assigned.
+func (g *Game) CheckTiles() error {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.checkTiles()
+}
+
+// checkTiles checks tile conservation; callers must hold the lock
+func (g *Game) checkTiles() error {
+	counts := make(map[rune]*TileCount)
+	count := func(key rune) *TileCount {
+		c, ok := counts[key]
+		if !ok {
+			c = &TileCount{Tile: g.Board.tileName(key)}
+			counts[key] = c
+		}
+		return c
+	}
+
+	for key, n := range g.Board.tileTracker().distribution {
+		count(key).Want = n
+	}
+	for _, pos := range g.Board.GetOccupiedPositions() {
+		count(trackerKey(*g.Board.GetTile(pos))).Board++
+	}
+	racks := make(map[string]string, len(g.Players))
+	for _, p := range g.Players {
+		for _, t := range p.Rack {
+			count(trackerKey(t)).Racks++
+		}
+		racks[p.ID] = gcgRack(p.Rack)
+	}
+	bag := g.TileBag.Tiles()
+	for _, t := range bag {
+		count(trackerKey(t)).Bag++
+	}
+
+	keys := make([]rune, 0, len(counts))
+	ok := true
+	for key, c := range counts {
+		keys = append(keys, key)
+		ok = ok && c.Have() == c.Want
+	}
+	if ok {
+		return nil
+	}
+
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	e := &InvariantError{GameID: g.ID, Revision: g.Revision, Racks: racks, Bag: gcgRack(bag)}
+	for _, key := range keys {
+		e.Counts = append(e.Counts, *counts[key])
+	}
+	if len(g.events) > 0 {
+		e.After = g.events[len(g.events)-1].Type.String()
+	}
+	return e
+}
+
+// assertTiles panics if debug mode is on and tiles are not conserved;
+// callers must hold the lock
+func (g *Game) assertTiles() {
+	if !g.debug {
+		return
+	}
+	err := g.checkTiles()
+	if err == nil {
+		return
+	}
+	if l := g.log(); l != nil {
+		l.Error("invariant violated", slog.String("error", err.Error()), slog.String("dump", err.(*InvariantError).Dump()))
+	}
+	panic(err)
+}
+
+// tileName returns the face of the tile a tracking key stands for, "?" for blanks
+func (b *Board) tileName(key rune) string {
+	switch {
+	case key == 0:
+		return "?"
+	case b.TileSet != nil:
+		return b.TileSet.Face(key)
+	default:
+		return string(key)
+	}
+}
@@ -0,0 +1,113 @@
+package game
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestCheckTiles tests counting tiles across the bag, racks and board
+func TestCheckTiles(t *testing.T) {
+	g := newTestGame(t, 2)
+	if err := g.CheckTiles(); err != nil {
+		t.Errorf("A new game should conserve tiles: %v", err)
+	}
+	g.StartGame()
+	if err := g.CheckTiles(); err != nil {
+		t.Errorf("Dealing should conserve tiles: %v", err)
+	}
+
+	// Replacing a rack creates tiles; the rest stay in the bag
+	for _, p := range g.Players {
+		g.TileBag.ReturnTiles(p.Rack)
+		p.Rack = nil
+	}
+	g.Players[0].Rack = rackOf("QQQ?")
+	err := g.CheckTiles()
+	var ie *InvariantError
+	if !errors.As(err, &ie) || !errors.Is(err, ErrTilesNotConserved) {
+		t.Fatalf("Expected an InvariantError, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "? 3 of 2") {
+		t.Errorf("Error should name the wrong counts, got %q", err)
+	}
+	for _, c := range ie.Counts {
+		if c.Tile == "Q" && (c.Racks != 3 || c.Bag != 1 || c.Want != 1 || c.Board != 0) {
+			t.Errorf("Unexpected count for Q %+v", c)
+		}
+	}
+	dump := ie.Dump()
+	for _, want := range []string{"game test-game revision", "after GAME_STARTED", "tile want have  bag racks board\n", " !\n", "rack p1: QQQ?\n", "bag: "} {
+		if !strings.Contains(dump, want) {
+			t.Errorf("Dump should contain %q, got:\n%s", want, dump)
+		}
+	}
+}
+
+// TestCheckTilesTileSets tests other distributions, including digraph tiles
+func TestCheckTilesTileSets(t *testing.T) {
+	wwf, _ := NewGameWithVariant("wwf", []*Player{NewPlayer("p1", "A"), NewPlayer("p2", "B")}, WordsWithFriends)
+	wwf.StartGame()
+	if err := wwf.CheckTiles(); err != nil {
+		t.Errorf("Words With Friends tiles should be conserved: %v", err)
+	}
+
+	ts, err := LoadTileSet("spanish")
+	if err != nil {
+		t.Fatalf("LoadTileSet failed: %v", err)
+	}
+	g := newTestGame(t, 2)
+	g.SetTileSet(ts)
+	g.StartGame()
+	if err := g.CheckTiles(); err != nil {
+		t.Errorf("Spanish tiles should be conserved: %v", err)
+	}
+	g.TileBag.DrawTiles(g.TileBag.RemainingCount())
+	for _, p := range g.Players {
+		p.Rack = nil
+	}
+	err = g.CheckTiles()
+	if err == nil || !strings.Contains(err.Error(), "LL 0 of 1") {
+		t.Errorf("Digraphs should be named by their faces, got %v", err)
+	}
+}
+
+// TestDebugMode tests checking tiles after every action in debug mode
+func TestDebugMode(t *testing.T) {
+	mg := NewMoveGenerator(testWords)
+	g := newTestGame(t, 2)
+	g.TileBag = NewTileBagWithSeed(3)
+	g.SetDebug(true)
+	g.StartGame()
+	g.AttachBot(NewBotWithSeed("p1", TopNRandom, mg, 1))
+	g.AttachBot(NewBotWithSeed("p2", Greedy, mg, 2))
+	if _, err := g.PlayBotTurns(); err != nil {
+		t.Fatalf("PlayBotTurns failed: %v", err)
+	}
+	for g.Undo() == nil {
+	}
+	if len(g.History) != 0 {
+		t.Fatalf("Undo should rewind the whole game, %d actions left", len(g.History))
+	}
+
+	// A lost tile panics on the next action, with the lock released
+	g.Players[0].Rack = g.Players[0].Rack[1:]
+	func() {
+		defer func() {
+			ie, ok := recover().(*InvariantError)
+			if !ok || ie.After != "TURN_PASSED" {
+				t.Errorf("Expected an InvariantError after the pass, got %v", ie)
+			}
+		}()
+		g.PassTurn("p1")
+		t.Errorf("Passing should panic")
+	}()
+	if err := g.CheckTiles(); err == nil {
+		t.Errorf("The lost tile should still be reported")
+	}
+
+	g.SetDebug(false)
+	if err := g.PassTurn("p2"); err != nil {
+		t.Errorf("Without debug mode the game should carry on: %v", err)
+	}
+}
@@ -0,0 +1,163 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Layout is an arrangement of premium squares, indexed by row then column
+type Layout [15][15]PremiumType
+
+// LayoutValidator checks a custom layout, returning an error if it is unacceptable
+type LayoutValidator func(Layout) error
+
+// NewBoardFromLayout creates an empty board with a custom premium layout
+// The layout must be 15x15 and pass every validator. ValidateBoard checks the
+// board against this layout from then on.
+func NewBoardFromLayout(premiums [][]PremiumType, validators ...LayoutValidator) (*Board, error) {
+	if len(premiums) != 15 {
+		return nil, fmt.Errorf("layout must have 15 rows, got %d", len(premiums))
+	}
+
+	var layout Layout
+	for row, squares := range premiums {
+		if len(squares) != 15 {
+			return nil, fmt.Errorf("layout row %d must have 15 squares, got %d", row+1, len(squares))
+		}
+		copy(layout[row][:], squares)
+	}
+
+	board := NewBoard()
+	if err := board.applyLayout(layout, validators); err != nil {
+		return nil, err
+	}
+	return board, nil
+}
+
+// applyLayout validates a layout and sets the board's premium squares to it
+func (b *Board) applyLayout(layout Layout, validators []LayoutValidator) error {
+	for row := 0; row < 15; row++ {
+		for col := 0; col < 15; col++ {
+			if pt := layout[row][col]; pt < Normal || pt > TripleWordScore {
+				return fmt.Errorf("invalid premium type %d at %s", pt, Position{Row: row, Col: col})
+			}
+		}
+	}
+	for _, validate := range validators {
+		if err := validate(layout); err != nil {
+			return err
+		}
+	}
+
+	for row := 0; row < 15; row++ {
+		for col := 0; col < 15; col++ {
+			b.Grid[row][col].Premium = layout[row][col]
+		}
+	}
+	b.CustomLayout = &layout
+	return nil
+}
+
+// PremiumLayout returns the premium squares of the board as a layout
+func (b *Board) PremiumLayout() Layout {
+	var layout Layout
+	for row := 0; row < 15; row++ {
+		for col := 0; col < 15; col++ {
+			layout[row][col] = b.Grid[row][col].Premium
+		}
+	}
+	return layout
+}
+
+// expectedLayout returns the layout the board should have: its custom layout
+// if one was configured, otherwise its variant's
+func (b *Board) expectedLayout() Layout {
+	if b.CustomLayout != nil {
+		return *b.CustomLayout
+	}
+	return NewBoardForVariant(b.Variant).PremiumLayout()
+}
+
+// SymmetricLayout requires the layout to look the same when mirrored
+// horizontally and vertically, as published boards do
+func SymmetricLayout(layout Layout) error {
+	for row := 0; row < 15; row++ {
+		for col := 0; col < 15; col++ {
+			pt := layout[row][col]
+			if layout[row][14-col] != pt || layout[14-row][col] != pt {
+				return fmt.Errorf("layout is not symmetric at %s", Position{Row: row, Col: col})
+			}
+		}
+	}
+	return nil
+}
+
+// CenterPremium requires the center square to have the given premium
+func CenterPremium(pt PremiumType) LayoutValidator {
+	return func(layout Layout) error {
+		if got := layout[7][7]; got != pt {
+			return fmt.Errorf("center square must be %s, got %s", pt, got)
+		}
+		return nil
+	}
+}
+
+// MaxPremiums limits the number of squares of each premium type
+func MaxPremiums(limits map[PremiumType]int) LayoutValidator {
+	return func(layout Layout) error {
+		counts := make(map[PremiumType]int)
+		for row := 0; row < 15; row++ {
+			for col := 0; col < 15; col++ {
+				counts[layout[row][col]]++
+			}
+		}
+		for pt, limit := range limits {
+			if counts[pt] > limit {
+				return fmt.Errorf("layout has %d %s squares, maximum is %d", counts[pt], pt, limit)
+			}
+		}
+		return nil
+	}
+}
+
+// RandomLayout scatters the given number of each premium type over the board
+// for party games; the center keeps its Double Word Score star
+func RandomLayout(rng *rand.Rand, counts map[PremiumType]int) Layout {
+	var layout Layout
+	layout[7][7] = DoubleWordScore
+
+	squares := make([]Position, 0, 224)
+	for row := 0; row < 15; row++ {
+		for col := 0; col < 15; col++ {
+			if row != 7 || col != 7 {
+				squares = append(squares, Position{Row: row, Col: col})
+			}
+		}
+	}
+	rng.Shuffle(len(squares), func(i, j int) { squares[i], squares[j] = squares[j], squares[i] })
+
+	next := 0
+	for _, pt := range []PremiumType{TripleWordScore, DoubleWordScore, TripleLetterScore, DoubleLetterScore} {
+		for i := 0; i < counts[pt] && next < len(squares); i++ {
+			layout[squares[next].Row][squares[next].Col] = pt
+			next++
+		}
+	}
+	return layout
+}
+
+// SetLayout replaces the game board's premium squares with a custom layout
+// The layout may only be changed before the game starts.
+func (g *Game) SetLayout(layout Layout, validators ...LayoutValidator) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.State != WaitingForPlayers {
+		return ErrGameNotWaiting
+	}
+	if err := g.Board.applyLayout(layout, validators); err != nil {
+		return err
+	}
+	g.record(Event{Type: LayoutChanged, Layout: &layout})
+	return nil
+}
@@ -0,0 +1,132 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// plainLayout returns a 15x15 layout with only a double word center
+func plainLayout() [][]PremiumType {
+	premiums := make([][]PremiumType, 15)
+	for row := range premiums {
+		premiums[row] = make([]PremiumType, 15)
+	}
+	premiums[7][7] = DoubleWordScore
+	return premiums
+}
+
+// TestNewBoardFromLayout tests building a board with custom premium squares
+func TestNewBoardFromLayout(t *testing.T) {
+	premiums := plainLayout()
+	premiums[0][0] = TripleWordScore
+
+	board, err := NewBoardFromLayout(premiums)
+	if err != nil {
+		t.Fatalf("NewBoardFromLayout failed: %v", err)
+	}
+	if board.GetPremiumType(mustPos(t, "A1")) != TripleWordScore || board.GetPremiumType(mustPos(t, "O15")) != Normal {
+		t.Errorf("Board should use the custom layout")
+	}
+	if err := board.ValidateBoard(); err != nil {
+		t.Errorf("Custom board should validate against its own layout: %v", err)
+	}
+
+	// Changing the layout after creation is detected
+	board.Grid[14][14].Premium = TripleWordScore
+	if err := board.ValidateBoard(); err == nil {
+		t.Errorf("ValidateBoard should detect a square differing from the layout")
+	}
+
+	if _, err := NewBoardFromLayout(premiums[:14]); err == nil {
+		t.Errorf("A layout with 14 rows should be rejected")
+	}
+	premiums[3] = premiums[3][:10]
+	if _, err := NewBoardFromLayout(premiums); err == nil {
+		t.Errorf("A short row should be rejected")
+	}
+
+	bad := plainLayout()
+	bad[2][2] = PremiumType(9)
+	if _, err := NewBoardFromLayout(bad); err == nil {
+		t.Errorf("Unknown premium types should be rejected")
+	}
+}
+
+// TestLayoutValidators tests the built-in layout validation hooks
+func TestLayoutValidators(t *testing.T) {
+	classic := NewBoard().PremiumLayout()
+	if err := SymmetricLayout(classic); err != nil {
+		t.Errorf("Classic layout should be symmetric: %v", err)
+	}
+	if err := CenterPremium(DoubleWordScore)(classic); err != nil {
+		t.Errorf("Classic center should be a double word: %v", err)
+	}
+	if err := MaxPremiums(map[PremiumType]int{TripleWordScore: 8})(classic); err != nil {
+		t.Errorf("Classic layout has 8 triple words: %v", err)
+	}
+
+	lopsided := plainLayout()
+	lopsided[0][0] = TripleWordScore
+	if _, err := NewBoardFromLayout(lopsided, SymmetricLayout); err == nil {
+		t.Errorf("Asymmetric layout should fail the symmetry check")
+	}
+	if _, err := NewBoardFromLayout(lopsided, CenterPremium(Normal)); err == nil {
+		t.Errorf("Center check should fail")
+	}
+	if _, err := NewBoardFromLayout(lopsided, MaxPremiums(map[PremiumType]int{TripleWordScore: 0})); err == nil {
+		t.Errorf("Premium limit should fail")
+	}
+}
+
+// TestRandomLayout tests generating party layouts
+func TestRandomLayout(t *testing.T) {
+	counts := map[PremiumType]int{TripleWordScore: 8, DoubleWordScore: 16, TripleLetterScore: 12, DoubleLetterScore: 24}
+	layout := RandomLayout(rand.New(rand.NewSource(5)), counts)
+	if again := RandomLayout(rand.New(rand.NewSource(5)), counts); again != layout {
+		t.Errorf("The same seed should give the same layout")
+	}
+
+	board := NewBoard()
+	board.applyLayout(layout, nil)
+	got := board.CountPremiumSquares()
+	counts[DoubleWordScore]++ // The center star
+	for pt, n := range counts {
+		if got[pt] != n {
+			t.Errorf("Expected %d %s squares, got %d", n, pt, got[pt])
+		}
+	}
+}
+
+// TestGameSetLayout tests playing a game on a custom layout
+func TestGameSetLayout(t *testing.T) {
+	g := newTestGame(t, 2)
+	layout := NewBoard().PremiumLayout()
+	layout[7][8] = TripleLetterScore // I8
+
+	if err := g.SetLayout(layout, SymmetricLayout); err == nil {
+		t.Errorf("Symmetry validator should reject the layout")
+	}
+	if err := g.SetLayout(layout); err != nil {
+		t.Fatalf("SetLayout failed: %v", err)
+	}
+	g.StartGame()
+	if err := g.SetLayout(layout); err != ErrGameNotWaiting {
+		t.Errorf("Layout should not change after the start, got %v", err)
+	}
+
+	// CAT from H8: C(3) + A(1*3) + T(1), doubled by the center
+	g.Players[0].Rack = rackOf("CAT")
+	move, _ := g.Board.BuildMove("p1", "CAT", g.Board.Center, Horizontal, nil)
+	if score, err := g.PlayMove(move); err != nil || score != 14 {
+		t.Errorf("Expected score 14, got %d (%v)", score, err)
+	}
+
+	data, _ := g.Serialize()
+	loaded, err := LoadGame(data)
+	if err != nil {
+		t.Fatalf("LoadGame should accept the custom layout: %v", err)
+	}
+	if loaded.Board.GetPremiumType(mustPos(t, "I8")) != TripleLetterScore {
+		t.Errorf("Loaded game should keep its custom layout")
+	}
+}
@@ -0,0 +1,155 @@
+package game
+
+import (
+	"sort"
+	"strings"
+)
+
+// LeaveEvaluator scores the tiles kept on the rack after a move
+// Blanks and S are worth keeping; Q (especially without U), duplicates and an
+// unbalanced vowel/consonant mix are penalized, and letter pairs that combine
+// well earn a small synergy bonus. Leaves found in Table take their learned
+// value instead.
+type LeaveEvaluator struct {
+	TileValues       map[rune]float64 // Value of keeping each tile; blanks are keyed by 0
+	DuplicatePenalty float64          // Penalty per extra copy of a letter
+	QWithoutUPenalty float64          // Additional penalty for keeping Q without U
+	BalancePenalty   float64          // Penalty per vowel/consonant difference beyond one
+	Synergies        map[string]float64
+	Table            LeaveTable // Learned values, used in place of the heuristic for the leaves it holds
+}
+
+// NewLeaveEvaluator creates an evaluator with the default heuristic weights
+func NewLeaveEvaluator() *LeaveEvaluator {
+	return &LeaveEvaluator{
+		TileValues: map[rune]float64{
+			0: 25, 'S': 8, 'Z': 2, 'X': 3, 'R': 1.5, 'E': 1.5, 'H': 1, 'N': 0.5,
+			'Q': -7, 'V': -4, 'W': -3, 'U': -3, 'J': -2, 'I': -1, 'G': -1.5, 'B': -1,
+		},
+		DuplicatePenalty: 3,
+		QWithoutUPenalty: 5,
+		BalancePenalty:   2,
+		Synergies: map[string]float64{
+			"QU": 6, "ER": 1.5, "ES": 1.5, "IN": 1, "ST": 1, "RS": 1, "CK": 1, "EN": 1,
+		},
+	}
+}
+
+// Evaluate returns the value of keeping the given tiles
+func (le *LeaveEvaluator) Evaluate(leave []Tile) float64 {
+	if le.Table != nil && len(leave) > 0 {
+		if value, ok := le.Table[LeaveKey(leave)]; ok {
+			return value
+		}
+	}
+
+	counts := make(map[rune]int)
+	vowels, consonants := 0, 0
+	value := 0.0
+
+	for _, t := range leave {
+		if t.IsBlank {
+			value += le.TileValues[0]
+			continue
+		}
+		counts[t.Letter]++
+		value += le.TileValues[t.Letter]
+		if strings.ContainsRune("AEIOU", t.Letter) {
+			vowels++
+		} else {
+			consonants++
+		}
+	}
+
+	for _, n := range counts {
+		if n > 1 {
+			value -= le.DuplicatePenalty * float64(n-1)
+		}
+	}
+	if counts['Q'] > 0 && counts['U'] == 0 {
+		value -= le.QWithoutUPenalty
+	}
+	for pair, bonus := range le.Synergies {
+		runes := []rune(pair)
+		if counts[runes[0]] > 0 && counts[runes[1]] > 0 {
+			value += bonus
+		}
+	}
+
+	diff := vowels - consonants
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff > 1 {
+		value -= le.BalancePenalty * float64(diff-1)
+	}
+
+	return value
+}
+
+// EvaluateMove returns the value of the tiles a move would leave on the rack
+// Returns 0 if the move uses tiles the rack does not hold.
+func (le *LeaveEvaluator) EvaluateMove(rack []Tile, move Move) float64 {
+	leave, err := Leave(rack, move.Tiles)
+	if err != nil {
+		return 0
+	}
+	return le.Evaluate(leave)
+}
+
+// Equity returns a move's score plus the value of its leave
+func (le *LeaveEvaluator) Equity(rack []Tile, move Move) float64 {
+	return float64(move.Score) + le.EvaluateMove(rack, move)
+}
+
+// RankedMove is a candidate move with its leave and equity
+type RankedMove struct {
+	Move       Move    `json:"move"`
+	Leave      []Tile  `json:"leave"`             // Tiles kept on the rack after the move
+	LeaveValue float64 `json:"leave_value"`       // Value of the leave
+	Equity     float64 `json:"equity"`            // Score plus leave value, less any defense penalty
+	Defense    float64 `json:"defense,omitempty"` // Penalty for the board the move opens; see DefenseEvaluator
+}
+
+// Rank orders moves by equity, highest first
+func (le *LeaveEvaluator) Rank(rack []Tile, moves []Move) []RankedMove {
+	ranked := make([]RankedMove, 0, len(moves))
+	for _, m := range moves {
+		leave, err := Leave(rack, m.Tiles)
+		if err != nil {
+			continue
+		}
+		value := le.Evaluate(leave)
+		ranked = append(ranked, RankedMove{
+			Move:       m,
+			Leave:      leave,
+			LeaveValue: value,
+			Equity:     float64(m.Score) + value,
+		})
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Equity > ranked[j].Equity
+	})
+	return ranked
+}
+
+// Leave returns the tiles remaining on the rack after the given tiles are played
+func Leave(rack []Tile, used []PlacedTile) ([]Tile, error) {
+	indices, err := rackIndices(rack, used)
+	if err != nil {
+		return nil, err
+	}
+	usedIdx := make(map[int]bool, len(indices))
+	for _, idx := range indices {
+		usedIdx[idx] = true
+	}
+
+	leave := make([]Tile, 0, len(rack)-len(indices))
+	for i, t := range rack {
+		if !usedIdx[i] {
+			leave = append(leave, t)
+		}
+	}
+	return leave, nil
+}
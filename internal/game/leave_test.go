@@ -0,0 +1,71 @@
+package game
+
+import (
+	"testing"
+)
+
+// TestLeaveEvaluate tests rack leave heuristics
+func TestLeaveEvaluate(t *testing.T) {
+	le := NewLeaveEvaluator()
+
+	testCases := []struct {
+		better, worse string
+	}{
+		{"S?", "QAA"},    // Blank and S beat Q and duplicate vowels
+		{"QU", "Q"},      // U rescues a Q
+		{"ERS", "IIU"},   // Synergy beats duplicates
+		{"AEST", "AEIO"}, // Balanced beats vowel heavy
+		{"RT", "VV"},     // Duplicate clunkers are worst
+		{"", "Q"},        // Playing everything beats keeping Q
+		{"ES", "EE"},     // Duplicates are penalized
+		{"AIRT", "BCDG"}, // Consonant heavy is penalized
+		{"INST", "IIST"}, // Synergy pairs count
+		{"ENRS", "UVWW"}, // Good letters beat bad ones
+	}
+
+	for _, tc := range testCases {
+		b, w := le.Evaluate(rackOf(tc.better)), le.Evaluate(rackOf(tc.worse))
+		if b <= w {
+			t.Errorf("Leave %q (%f) should beat %q (%f)", tc.better, b, tc.worse, w)
+		}
+	}
+}
+
+// TestLeave tests computing the tiles left after a move
+func TestLeave(t *testing.T) {
+	rack := rackOf("CAT?S")
+	leave, err := Leave(rack, []PlacedTile{{Tile: Tile{Letter: 'A'}}, {Tile: Tile{Letter: 'E', IsBlank: true}}})
+	if err != nil {
+		t.Fatalf("Leave failed: %v", err)
+	}
+	if gcgRack(leave) != "CTS" {
+		t.Errorf("Expected leave CTS, got %s", gcgRack(leave))
+	}
+
+	if _, err := Leave(rack, []PlacedTile{{Tile: Tile{Letter: 'Z'}}}); err != ErrTilesNotInRack {
+		t.Errorf("Should return ErrTilesNotInRack, got %v", err)
+	}
+}
+
+// TestLeaveRank tests ordering moves by score plus leave
+func TestLeaveRank(t *testing.T) {
+	le := NewLeaveEvaluator()
+	rack := rackOf("QSAT")
+	keepsS := Move{Score: 10, Tiles: []PlacedTile{{Tile: rack[0]}, {Tile: rack[2]}}}
+	keepsQ := Move{Score: 12, Tiles: []PlacedTile{{Tile: rack[1]}, {Tile: rack[2]}}}
+	bogus := Move{Score: 50, Tiles: []PlacedTile{{Tile: Tile{Letter: 'Z'}}}}
+
+	ranked := le.Rank(rack, []Move{keepsQ, keepsS, bogus})
+	if len(ranked) != 2 {
+		t.Fatalf("Moves not playable from the rack should be dropped, got %d", len(ranked))
+	}
+	if ranked[0].Move.Score != 10 {
+		t.Errorf("Keeping S should outrank keeping Q despite fewer points")
+	}
+	if ranked[0].Equity != float64(ranked[0].Move.Score)+ranked[0].LeaveValue {
+		t.Errorf("Equity should be score plus leave value")
+	}
+	if le.Equity(rack, keepsS) != ranked[0].Equity {
+		t.Errorf("Equity should match the ranked equity")
+	}
+}
@@ -0,0 +1,202 @@
+package game
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidLeaveTable is returned when a leave table file cannot be read
+var ErrInvalidLeaveTable = errors.New("invalid leave table")
+
+// LeaveTable holds learned values of leaves, keyed by LeaveKey
+// An evaluator with a table uses its value for any leave it holds in place of
+// the heuristic.
+type LeaveTable map[string]float64
+
+// LeaveKey returns the key of a leave in a LeaveTable: its letters in
+// alphabetical order, with '?' for each blank first
+func LeaveKey(leave []Tile) string {
+	letters := make([]rune, len(leave))
+	for i, t := range leave {
+		letters[i] = t.Letter
+		if t.IsBlank {
+			letters[i] = '?'
+		}
+	}
+	slices.Sort(letters)
+	return string(letters)
+}
+
+// ReadLeaveTable reads a leave table: one leave per line, its letters with
+// '?' for a blank, then its value
+//
+//	?S 31.2
+//	QU 2.5
+//
+// Letters may be in any order. Blank lines and lines starting with '#' are
+// ignored. Leaves hold from 1 to MaxRackSize-1 tiles.
+func ReadLeaveTable(r io.Reader) (LeaveTable, error) {
+	table := make(LeaveTable)
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%w: line %d: expected a leave and a value", ErrInvalidLeaveTable, n)
+		}
+		leave, err := ParseRack(fields[0], Classic)
+		if err != nil || len(leave) == 0 || len(leave) >= MaxRackSize {
+			return nil, fmt.Errorf("%w: line %d: bad leave %q", ErrInvalidLeaveTable, n, fields[0])
+		}
+		value, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: bad value %q", ErrInvalidLeaveTable, n, fields[1])
+		}
+		table[LeaveKey(leave)] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+// LoadLeaveTable reads a leave table from a file
+func LoadLeaveTable(path string) (LeaveTable, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadLeaveTable(f)
+}
+
+// Write writes the table in the format ReadLeaveTable reads, shortest leaves
+// first and then alphabetically
+func (t LeaveTable) Write(w io.Writer) error {
+	keys := make([]string, 0, len(t))
+	for k := range t {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) < len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+
+	bw := bufio.NewWriter(w)
+	for _, k := range keys {
+		fmt.Fprintf(bw, "%s %.2f\n", k, t[k])
+	}
+	return bw.Flush()
+}
+
+// LeaveLearner estimates leave values from finished games, such as
+// self-play games
+// Each time a player keeps a leave, the points they score on their next turn
+// are credited to the leave and to every smaller leave within it. A leave's
+// value is how far its average exceeds the average over all leaves, so rare
+// long leaves draw on the same games as the short leaves they contain.
+type LeaveLearner struct {
+	sums   map[string]float64
+	counts map[string]int
+	total  float64 // Next-turn points over all observed leaves
+	n      int     // Observed leaves
+}
+
+// NewLeaveLearner creates a learner with no observations
+func NewLeaveLearner() *LeaveLearner {
+	return &LeaveLearner{sums: make(map[string]float64), counts: make(map[string]int)}
+}
+
+// Observe learns from a game's history
+// Only actions recorded with the rack they were made from count, and only
+// when the player moved again.
+func (l *LeaveLearner) Observe(history []Move) {
+	for i, m := range history {
+		if m.Withdrawn || len(m.Rack) == 0 {
+			continue
+		}
+		used := m.Tiles
+		switch m.Type {
+		case PlaceTiles:
+		case Exchange:
+			used = tilesAsPlaced(m.Exchanged)
+		default:
+			continue
+		}
+		leave, err := Leave(m.Rack, used)
+		if err != nil || len(leave) == 0 || len(leave) >= MaxRackSize {
+			continue
+		}
+
+		next, ok := nextTurn(history[i+1:], m.PlayerID)
+		if !ok {
+			continue
+		}
+		points := float64(next.Score)
+		l.total += points
+		l.n++
+		for _, key := range subLeaves(LeaveKey(leave)) {
+			l.sums[key] += points
+			l.counts[key]++
+		}
+	}
+}
+
+// Table returns the learned values of the leaves observed at least minCount
+// times
+func (l *LeaveLearner) Table(minCount int) LeaveTable {
+	table := make(LeaveTable)
+	if l.n == 0 {
+		return table
+	}
+	mean := l.total / float64(l.n)
+	for key, n := range l.counts {
+		if n >= minCount {
+			table[key] = l.sums[key]/float64(n) - mean
+		}
+	}
+	return table
+}
+
+// nextTurn returns the player's next action, skipping withdrawn moves and
+// challenges
+func nextTurn(history []Move, playerID string) (Move, bool) {
+	for _, m := range history {
+		if m.PlayerID == playerID && !m.Withdrawn && m.Type != ChallengeTurn {
+			return m, true
+		}
+	}
+	return Move{}, false
+}
+
+// subLeaves returns the distinct non-empty leaves within a leave key
+func subLeaves(key string) []string {
+	letters := []rune(key)
+	seen := make(map[string]bool)
+	var keys []string
+	for mask := 1; mask < 1<<len(letters); mask++ {
+		var sb strings.Builder
+		for i, r := range letters {
+			if mask&(1<<i) != 0 {
+				sb.WriteRune(r)
+			}
+		}
+		if k := sb.String(); !seen[k] {
+			seen[k] = true
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
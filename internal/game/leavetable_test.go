@@ -0,0 +1,107 @@
+package game
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestLeaveKey tests that leave keys ignore tile order and list blanks first
+func TestLeaveKey(t *testing.T) {
+	if got := LeaveKey(rackOf("TS?E")); got != "?EST" {
+		t.Errorf("Expected ?EST, got %s", got)
+	}
+	if LeaveKey(rackOf("QU")) != LeaveKey(rackOf("UQ")) {
+		t.Errorf("Keys should not depend on tile order")
+	}
+}
+
+// TestReadLeaveTable tests reading, writing and rejecting leave tables
+func TestReadLeaveTable(t *testing.T) {
+	input := "# learned values\n\nS? 31.5\nuq 2.25\nV -4\n"
+	table, err := ReadLeaveTable(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadLeaveTable failed: %v", err)
+	}
+	if len(table) != 3 || table["?S"] != 31.5 || table["QU"] != 2.25 || table["V"] != -4 {
+		t.Errorf("Unexpected table %v", table)
+	}
+
+	var buf bytes.Buffer
+	if err := table.Write(&buf); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if buf.String() != "V -4.00\n?S 31.50\nQU 2.25\n" {
+		t.Errorf("Shorter leaves should be written first, got:\n%s", buf.String())
+	}
+	again, err := ReadLeaveTable(&buf)
+	if err != nil || len(again) != 3 || again["?S"] != 31.5 {
+		t.Errorf("A written table should read back, got %v, %v", again, err)
+	}
+
+	for _, bad := range []string{"S", "S x", "S 1 2", "S1 4", "- 0", "ABCDEFG 1"} {
+		if _, err := ReadLeaveTable(strings.NewReader(bad)); !errors.Is(err, ErrInvalidLeaveTable) {
+			t.Errorf("%q: expected ErrInvalidLeaveTable, got %v", bad, err)
+		}
+	}
+	if _, err := LoadLeaveTable(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Errorf("Loading a missing file should fail")
+	}
+}
+
+// TestLeaveEvaluatorTable tests that table values replace the heuristic
+func TestLeaveEvaluatorTable(t *testing.T) {
+	le := NewLeaveEvaluator()
+	heuristic := le.Evaluate(rackOf("QA"))
+	le.Table = LeaveTable{"AQ": 1.5}
+
+	if got := le.Evaluate(rackOf("QA")); got != 1.5 {
+		t.Errorf("Leaves in the table should take its value, got %.2f", got)
+	}
+	if got := le.Evaluate(rackOf("S")); got != NewLeaveEvaluator().Evaluate(rackOf("S")) {
+		t.Errorf("Other leaves should use the heuristic, got %.2f", got)
+	}
+	if heuristic == 1.5 {
+		t.Errorf("Test leave should differ from the table value")
+	}
+}
+
+// TestLeaveLearner tests learning leave values from a history
+func TestLeaveLearner(t *testing.T) {
+	history := []Move{
+		// p1 keeps S and scores 30 next turn; p2 keeps QV and scores 10
+		{Type: PlaceTiles, PlayerID: "p1", Rack: rackOf("CATSXYZ"), Tiles: []PlacedTile{
+			{Tile: Tile{Letter: 'C'}}, {Tile: Tile{Letter: 'A'}}, {Tile: Tile{Letter: 'T'}},
+			{Tile: Tile{Letter: 'X'}}, {Tile: Tile{Letter: 'Y'}}, {Tile: Tile{Letter: 'Z'}}}},
+		{Type: Exchange, PlayerID: "p2", Rack: rackOf("QVAEIOU"), Exchanged: rackOf("AEIOU")},
+		{Type: ChallengeTurn, PlayerID: "p1"},
+		{Type: PlaceTiles, PlayerID: "p1", Score: 30},
+		{Type: Pass, PlayerID: "p2", Score: 10, Rack: rackOf("QVABCDE")},
+		// Withdrawn moves and moves without a following turn are ignored
+		{Type: PlaceTiles, PlayerID: "p1", Withdrawn: true, Rack: rackOf("AB"), Tiles: []PlacedTile{{Tile: Tile{Letter: 'A'}}}},
+		{Type: PlaceTiles, PlayerID: "p1", Rack: rackOf("AB"), Tiles: []PlacedTile{{Tile: Tile{Letter: 'A'}}}},
+	}
+
+	l := NewLeaveLearner()
+	l.Observe(history)
+	table := l.Table(1)
+
+	want := LeaveTable{"S": 10, "QV": -10, "Q": -10, "V": -10}
+	if len(table) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, table)
+	}
+	for key, value := range want {
+		if math.Abs(table[key]-value) > 1e-9 {
+			t.Errorf("Leave %s should be worth %.1f, got %.1f", key, value, table[key])
+		}
+	}
+	if len(l.Table(2)) != 0 {
+		t.Errorf("Leaves seen fewer than minCount times should be left out")
+	}
+	if len(NewLeaveLearner().Table(1)) != 0 {
+		t.Errorf("A learner without observations should give an empty table")
+	}
+}
@@ -0,0 +1,77 @@
+package game
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Log attribute keys shared by every game log record, so records from many
+// games can be filtered and correlated
+const (
+	LogKeyGameID   = "game_id"
+	LogKeyPlayerID = "player_id"
+	LogKeyTurn     = "turn"
+)
+
+// SetLogger sends the game's log records to l; nil turns logging off
+// Every record carries the game ID, the number of turns in the history and the
+// acting player's ID where there is one. Recorded events are logged at info
+// level, except rack reordering, and rejected actions at debug level.
+func (g *Game) SetLogger(l *slog.Logger) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.logger = l
+}
+
+// log returns the game's logger with its correlation fields, or nil when
+// logging is off; callers must hold the lock
+func (g *Game) log() *slog.Logger {
+	if g.logger == nil {
+		return nil
+	}
+	return g.logger.With(slog.String(LogKeyGameID, g.ID), slog.Int(LogKeyTurn, len(g.History)))
+}
+
+// logEvent logs a newly recorded event; callers must hold the lock
+func (g *Game) logEvent(ev Event) {
+	l := g.log()
+	if l == nil {
+		return
+	}
+
+	level := slog.LevelInfo
+	if ev.Type == RackReordered {
+		level = slog.LevelDebug
+	}
+	attrs := []slog.Attr{slog.String("event", ev.Type.String()), slog.Int("seq", ev.Seq)}
+	if ev.PlayerID != "" {
+		attrs = append(attrs, slog.String(LogKeyPlayerID, ev.PlayerID))
+	}
+	switch ev.Type {
+	case MovePlayed:
+		if m := g.History[len(g.History)-1]; m.Type == PlaceTiles {
+			attrs = append(attrs, slog.String("word", m.Word), slog.Int("score", m.Score))
+		}
+	case TilesExchanged:
+		attrs = append(attrs, slog.Int("tiles", len(ev.Indices)))
+	case ChallengeResolved:
+		if ev.Challenge != nil {
+			attrs = append(attrs, slog.Bool("successful", ev.Challenge.Successful))
+		}
+	}
+	if g.State == Finished && ev.isTurn() {
+		attrs = append(attrs, slog.Bool("game_over", true))
+	}
+	l.LogAttrs(context.Background(), level, "game event", attrs...)
+}
+
+// logRejected logs an action refused by the rules; callers must hold the lock
+func (g *Game) logRejected(action, playerID string, err error) {
+	l := g.log()
+	if l == nil {
+		return
+	}
+	l.LogAttrs(context.Background(), slog.LevelDebug, "action rejected",
+		slog.String("action", action), slog.String(LogKeyPlayerID, playerID), slog.String("error", err.Error()))
+}
@@ -0,0 +1,96 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// logRecords decodes the JSON log records written to buf
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var rec map[string]any
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("Bad log record %q: %v", line, err)
+		}
+		records = append(records, rec)
+	}
+	return records
+}
+
+// TestGameLogging tests the correlation fields on event and rejection records
+func TestGameLogging(t *testing.T) {
+	var buf bytes.Buffer
+	g := newTestGame(t, 2)
+	g.SetLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	g.StartGame()
+	g.Players[0].Rack = rackOf("CATSXYZ")
+
+	if err := g.PassTurn("p2"); err == nil {
+		t.Fatalf("Passing out of turn should fail")
+	}
+	move, _ := g.Board.BuildMove("p1", "CAT", mustPos(t, "H8"), Horizontal, nil)
+	if _, err := g.PlayMove(move); err != nil {
+		t.Fatalf("PlayMove failed: %v", err)
+	}
+
+	records := logRecords(t, &buf)
+	if len(records) != 3 {
+		t.Fatalf("Should log the start, the rejection and the move, got %v", records)
+	}
+	for _, rec := range records {
+		if rec[LogKeyGameID] != "test-game" {
+			t.Errorf("Every record should carry the game ID, got %v", rec)
+		}
+	}
+
+	rejected := records[1]
+	if rejected["msg"] != "action rejected" || rejected["level"] != "DEBUG" || rejected["action"] != "pass" ||
+		rejected[LogKeyPlayerID] != "p2" || rejected[LogKeyTurn] != 0.0 {
+		t.Errorf("Rejected pass was not logged correctly: %v", rejected)
+	}
+	played := records[2]
+	if played["event"] != "MOVE_PLAYED" || played["level"] != "INFO" || played[LogKeyPlayerID] != "p1" ||
+		played["word"] != "CAT" || played["score"] != 10.0 || played[LogKeyTurn] != 1.0 {
+		t.Errorf("Move was not logged correctly: %v", played)
+	}
+
+	buf.Reset()
+	g.SetLogger(nil)
+	g.PassTurn("p2")
+	if buf.Len() != 0 {
+		t.Errorf("A nil logger should turn logging off, got %s", buf.String())
+	}
+}
+
+// TestGameManagerLogging tests that managed games log to the manager's logger
+func TestGameManagerLogging(t *testing.T) {
+	var buf bytes.Buffer
+	m := NewGameManager(1)
+	defer m.Close()
+	m.SetLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	g := newTestGame(t, 2)
+	m.Add(g)
+	g.StartGame()
+	m.Remove(g.ID)
+
+	var msgs []string
+	for _, rec := range logRecords(t, &buf) {
+		if rec[LogKeyGameID] != "test-game" {
+			t.Errorf("Every record should carry the game ID, got %v", rec)
+		}
+		msgs = append(msgs, rec["msg"].(string))
+	}
+	if strings.Join(msgs, ",") != "game added,game event,game removed" {
+		t.Errorf("Should log the game's life under the manager, got %v", msgs)
+	}
+}
@@ -0,0 +1,336 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"log/slog"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Default game manager settings
+const (
+	DefaultManagerShards = 32 // Lock shards the games are spread across
+)
+
+// Errors returned by the game manager
+var (
+	ErrGameExists    = errors.New("game already exists")
+	ErrGameNotFound  = errors.New("game not found")
+	ErrManagerClosed = errors.New("game manager is closed")
+)
+
+// GameManager owns many games played at once
+// Games are spread over shards so that looking up one game never waits on
+// another shard's lock, and each game has its own lock for operations that
+// span several calls. Bot moves are computed by a bounded pool of workers, so
+// a slow bot holds up only its own game. Games with a turn limit each have a
+// timer that passes a turn the moment it runs out, rather than waiting for a
+// periodic CheckTime.
+type GameManager struct {
+	shards  []managerShard
+	workers chan struct{} // Semaphore bounding concurrent bot computations
+	wg      sync.WaitGroup
+	watches sync.WaitGroup // Running turn limit watchers
+	closed  bool
+	logger  *slog.Logger // Logger given to added games (nil when logging is off)
+	mu      sync.RWMutex // Guards closed and logger
+}
+
+// managerShard holds the games whose IDs hash to it
+type managerShard struct {
+	games map[string]*managedGame
+	mu    sync.RWMutex
+}
+
+// managedGame pairs a game with the lock serializing operations on it
+type managedGame struct {
+	game *Game
+	stop chan struct{} // Closed to stop the turn limit watcher (nil without one)
+	mu   sync.Mutex
+}
+
+// BotResult reports the bot moves played in a game by the worker pool
+type BotResult struct {
+	GameID string
+	Moves  []Move
+	Err    error
+}
+
+// NewGameManager creates a manager computing at most workers bot turns at
+// once; zero or less uses one worker per CPU
+func NewGameManager(workers int) *GameManager {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	m := &GameManager{
+		shards:  make([]managerShard, DefaultManagerShards),
+		workers: make(chan struct{}, workers),
+	}
+	for i := range m.shards {
+		m.shards[i].games = make(map[string]*managedGame)
+	}
+	return m
+}
+
+// shard returns the shard holding a game ID
+func (m *GameManager) shard(id string) *managerShard {
+	h := fnv.New32a()
+	h.Write([]byte(id))
+	return &m.shards[h.Sum32()%uint32(len(m.shards))]
+}
+
+// lookup returns the managed entry for a game ID, or nil
+func (m *GameManager) lookup(id string) *managedGame {
+	s := m.shard(id)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.games[id]
+}
+
+// SetLogger sends the log records of games added from now on, and of the
+// manager itself, to l; nil turns logging off
+func (m *GameManager) SetLogger(l *slog.Logger) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.logger = l
+}
+
+// log logs a manager record about one game at the given level
+func (m *GameManager) log(level slog.Level, msg, id string, attrs ...slog.Attr) {
+	m.mu.RLock()
+	l := m.logger
+	m.mu.RUnlock()
+
+	if l != nil {
+		l.LogAttrs(context.Background(), level, msg, append([]slog.Attr{slog.String(LogKeyGameID, id)}, attrs...)...)
+	}
+}
+
+// Add puts a game under the manager's control
+// If the manager has a logger, the game logs to it. A game whose clock has a
+// turn limit is watched until it is removed or the manager is closed.
+func (m *GameManager) Add(g *Game) error {
+	s := m.shard(g.ID)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.games[g.ID]; ok {
+		return ErrGameExists
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.logger != nil {
+		g.SetLogger(m.logger)
+	}
+	mg := &managedGame{game: g}
+	if changed := g.watchChanges(); changed != nil && !m.closed {
+		mg.stop = make(chan struct{})
+		m.watches.Add(1)
+		go m.watchTurns(g, mg.stop, changed)
+	}
+	s.games[g.ID] = mg
+	m.log(slog.LevelDebug, "game added", g.ID)
+	return nil
+}
+
+// watchTurns passes a game's turns as they run over the turn limit, rearming
+// its timer whenever the game changes
+func (m *GameManager) watchTurns(g *Game, stop, changed <-chan struct{}) {
+	defer m.watches.Done()
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		var fire <-chan time.Time
+		if deadline, ok := g.TurnDeadline(); ok {
+			timer.Reset(time.Until(deadline))
+			fire = timer.C
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-changed:
+		case <-fire:
+			m.Do(g.ID, func(g *Game) error {
+				if flagged := g.CheckTime(); flagged != "" {
+					m.log(slog.LevelInfo, "player flagged", g.ID, slog.String(LogKeyPlayerID, flagged))
+				}
+				return nil
+			})
+		}
+	}
+}
+
+// Get returns a managed game
+// The game's own methods are safe to call concurrently; use Do to make
+// several calls without other operations on the game in between.
+func (m *GameManager) Get(id string) (*Game, bool) {
+	mg := m.lookup(id)
+	if mg == nil {
+		return nil, false
+	}
+	return mg.game, true
+}
+
+// Remove stops managing a game, returning false if it was not managed
+// Operations already running on the game are allowed to finish.
+func (m *GameManager) Remove(id string) bool {
+	s := m.shard(id)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.games[id]; !ok {
+		return false
+	}
+	if mg := s.games[id]; mg.stop != nil {
+		close(mg.stop)
+	}
+	delete(s.games, id)
+	m.log(slog.LevelDebug, "game removed", id)
+	return true
+}
+
+// Len returns the number of managed games
+func (m *GameManager) Len() int {
+	n := 0
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.mu.RLock()
+		n += len(s.games)
+		s.mu.RUnlock()
+	}
+	return n
+}
+
+// IDs returns the IDs of the managed games in alphabetical order
+func (m *GameManager) IDs() []string {
+	var ids []string
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.mu.RLock()
+		for id := range s.games {
+			ids = append(ids, id)
+		}
+		s.mu.RUnlock()
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Do runs fn with exclusive use of a game among the manager's operations
+// Only the game's own lock is held, so other games are unaffected.
+func (m *GameManager) Do(id string, fn func(*Game) error) error {
+	mg := m.lookup(id)
+	if mg == nil {
+		return ErrGameNotFound
+	}
+
+	mg.mu.Lock()
+	defer mg.mu.Unlock()
+
+	return fn(mg.game)
+}
+
+// CheckAbandonment applies each managed game's abandonment policy and returns
+// the IDs of the games that were forfeited, in alphabetical order
+// Servers should call it periodically; see Game.CheckAbandonment.
+func (m *GameManager) CheckAbandonment() []string {
+	var forfeited []string
+	for _, id := range m.IDs() {
+		m.Do(id, func(g *Game) error {
+			if g.CheckAbandonment() != "" {
+				forfeited = append(forfeited, id)
+			}
+			return nil
+		})
+	}
+	return forfeited
+}
+
+// CheckPauses resumes the managed games whose pause has run its course and
+// returns their IDs, in alphabetical order
+// Servers should call it periodically; see Game.CheckPause.
+func (m *GameManager) CheckPauses() []string {
+	var resumed []string
+	for _, id := range m.IDs() {
+		m.Do(id, func(g *Game) error {
+			if g.CheckPause() {
+				resumed = append(resumed, id)
+			}
+			return nil
+		})
+	}
+	return resumed
+}
+
+// PlayBotTurns plays a game's bot turns on the worker pool and reports the
+// moves on the returned channel, which receives exactly one result
+// Turns wait for a free worker, then run with exclusive use of the game as Do
+// gives.
+func (m *GameManager) PlayBotTurns(id string) <-chan BotResult {
+	result := make(chan BotResult, 1)
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.closed {
+		result <- BotResult{GameID: id, Err: ErrManagerClosed}
+		return result
+	}
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+
+		m.workers <- struct{}{}
+		defer func() { <-m.workers }()
+
+		res := BotResult{GameID: id}
+		res.Err = m.Do(id, func(g *Game) error {
+			var err error
+			res.Moves, err = g.PlayBotTurns()
+			return err
+		})
+		if res.Err != nil {
+			m.log(slog.LevelError, "bot turns failed", id, slog.String("error", res.Err.Error()))
+		}
+		result <- res
+	}()
+	return result
+}
+
+// Close stops accepting bot work, stops watching turn limits and waits for
+// queued and running bot turns to finish; the games stay available
+func (m *GameManager) Close() {
+	m.mu.Lock()
+	m.closed = true
+	m.mu.Unlock()
+
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.mu.Lock()
+		for _, mg := range s.games {
+			if mg.stop != nil {
+				close(mg.stop)
+				mg.stop = nil
+			}
+		}
+		s.mu.Unlock()
+	}
+	m.watches.Wait()
+	m.wg.Wait()
+}
@@ -0,0 +1,148 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newBotGame creates a started game between two bots playing from testWords
+func newBotGame(t *testing.T, id string) *Game {
+	t.Helper()
+
+	mg := NewMoveGenerator(testWords)
+	g, err := NewGame(id, []*Player{NewPlayer("b1", "Bot 1"), NewPlayer("b2", "Bot 2")})
+	if err != nil {
+		t.Fatalf("NewGame failed: %v", err)
+	}
+	g.AttachBot(NewBot("b1", Greedy, mg))
+	g.AttachBot(NewBot("b2", Greedy, mg))
+	g.StartGame()
+	return g
+}
+
+// TestGameManager tests adding, finding and removing games
+func TestGameManager(t *testing.T) {
+	m := NewGameManager(2)
+	defer m.Close()
+
+	for _, id := range []string{"test-game", "b", "a", "c"} {
+		g := newTestGame(t, 2)
+		g.ID = id
+		if err := m.Add(g); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+	if err := m.Add(newTestGame(t, 2)); !errors.Is(err, ErrGameExists) {
+		t.Errorf("Adding a duplicate ID should return ErrGameExists, got %v", err)
+	}
+
+	if ids := m.IDs(); !reflect.DeepEqual(ids, []string{"a", "b", "c", "test-game"}) {
+		t.Errorf("Unexpected IDs: %v", ids)
+	}
+	if g, ok := m.Get("a"); !ok || g.ID != "a" {
+		t.Errorf("Game a should be found")
+	}
+
+	if !m.Remove("a") || m.Remove("a") {
+		t.Errorf("Remove should succeed once")
+	}
+	if _, ok := m.Get("a"); ok || m.Len() != 3 {
+		t.Errorf("Removed game should be gone, %d left", m.Len())
+	}
+	if err := m.Do("a", func(*Game) error { return nil }); !errors.Is(err, ErrGameNotFound) {
+		t.Errorf("Do on a removed game should return ErrGameNotFound, got %v", err)
+	}
+}
+
+// TestGameManagerBotPool tests playing many bot games concurrently on the pool
+func TestGameManagerBotPool(t *testing.T) {
+	m := NewGameManager(4)
+
+	var results []<-chan BotResult
+	for i := 0; i < 20; i++ {
+		id := fmt.Sprintf("game-%d", i)
+		m.Add(newBotGame(t, id))
+		results = append(results, m.PlayBotTurns(id))
+	}
+
+	for _, ch := range results {
+		res := <-ch
+		if res.Err != nil {
+			t.Errorf("%s: bot turns failed: %v", res.GameID, res.Err)
+			continue
+		}
+		g, _ := m.Get(res.GameID)
+		if g.State != Finished || len(res.Moves) != len(g.History) {
+			t.Errorf("%s should be played to the end, got %s after %d moves", res.GameID, g.State, len(res.Moves))
+		}
+	}
+
+	m.Close()
+	if res := <-m.PlayBotTurns("game-0"); !errors.Is(res.Err, ErrManagerClosed) {
+		t.Errorf("Bot work after Close should return ErrManagerClosed, got %v", res.Err)
+	}
+	if res := <-NewGameManager(1).PlayBotTurns("missing"); !errors.Is(res.Err, ErrGameNotFound) {
+		t.Errorf("Bot work on a missing game should return ErrGameNotFound, got %v", res.Err)
+	}
+}
+
+// TestGameManagerIsolation tests that a game held busy does not hold up others
+func TestGameManagerIsolation(t *testing.T) {
+	m := NewGameManager(2)
+	defer m.Close()
+	m.Add(newBotGame(t, "slow"))
+	m.Add(newBotGame(t, "fast"))
+
+	busy, release := make(chan struct{}), make(chan struct{})
+	go m.Do("slow", func(*Game) error {
+		close(busy)
+		<-release
+		return nil
+	})
+	<-busy
+
+	slow := m.PlayBotTurns("slow")
+	select {
+	case res := <-m.PlayBotTurns("fast"):
+		if res.Err != nil {
+			t.Errorf("Fast game failed: %v", res.Err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("A busy game should not block another game's bots")
+	}
+
+	select {
+	case <-slow:
+		t.Errorf("The slow game's bots should wait for its lock")
+	default:
+	}
+	close(release)
+	if res := <-slow; res.Err != nil {
+		t.Errorf("Slow game failed once released: %v", res.Err)
+	}
+}
+
+// TestGameManagerTurnLimit tests that managed games pass turns as they run out
+func TestGameManagerTurnLimit(t *testing.T) {
+	m := NewGameManager(1)
+	g := newTestGame(t, 2)
+	g.SetTimeControl(TimeControl{Base: time.Minute, TurnLimit: 20 * time.Millisecond})
+	g.StartGame()
+	deadline, _ := g.TurnDeadline()
+	m.Add(g)
+
+	for end := time.Now().Add(5 * time.Second); len(g.GetHistory()) < 2; time.Sleep(time.Millisecond) {
+		if time.Now().After(end) {
+			t.Fatalf("Expected both turns passed, got %d", len(g.GetHistory()))
+		}
+	}
+	if first := g.GetHistory()[0]; first.PlayerID != "p1" || !first.Timestamp.Equal(deadline) {
+		t.Errorf("Expected p1 passed at %v, got %+v", deadline, first)
+	}
+
+	m.Remove(g.ID)
+	m.Close()
+}
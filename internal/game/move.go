@@ -0,0 +1,466 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Direction represents the axis along which a word is played
+type Direction int
+
+const (
+	Horizontal Direction = iota // Left to right along a row
+	Vertical                    // Top to bottom along a column
+)
+
+// String returns a string representation of the direction
+func (d Direction) String() string {
+	switch d {
+	case Horizontal:
+		return "HORIZONTAL"
+	case Vertical:
+		return "VERTICAL"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// step returns the row/col offset for one square in this direction
+func (d Direction) step() Position {
+	if d == Vertical {
+		return Position{Row: 1, Col: 0}
+	}
+	return Position{Row: 0, Col: 1}
+}
+
+// Perpendicular returns the direction at right angles to this one
+func (d Direction) Perpendicular() Direction {
+	if d == Vertical {
+		return Horizontal
+	}
+	return Vertical
+}
+
+// MoveType identifies the kind of turn a player took
+type MoveType int
+
+const (
+	PlaceTiles    MoveType = iota // Tiles placed on the board
+	Exchange                      // Tiles exchanged with the bag
+	Pass                          // Turn passed without playing
+	ChallengeTurn                 // Challenge of the previous move
+)
+
+// String returns a string representation of the move type
+func (mt MoveType) String() string {
+	switch mt {
+	case PlaceTiles:
+		return "PLACE_TILES"
+	case Exchange:
+		return "EXCHANGE"
+	case Pass:
+		return "PASS"
+	case ChallengeTurn:
+		return "CHALLENGE"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// PlacedTile is a tile placed from a rack onto a board position
+// For blank tiles, Tile.IsBlank is true and Tile.Letter holds the assigned letter
+type PlacedTile struct {
+	Tile     Tile     `json:"tile"`
+	Position Position `json:"position"`
+}
+
+// Move represents an action taken by a player, usually a word played on the board
+type Move struct {
+	Type      MoveType         `json:"type"`
+	PlayerID  string           `json:"player_id"`
+	Word      string           `json:"word"`                // Main word formed, including letters already on the board
+	Start     Position         `json:"start"`               // Position of the first letter of Word
+	Direction Direction        `json:"direction"`           // Axis along which Word is played
+	Tiles     []PlacedTile     `json:"tiles"`               // Tiles placed from the player's rack
+	Score     int              `json:"score"`               // Points scored, set when the move is played
+	Words     []string         `json:"words"`               // All words formed, set when the move is played
+	Timestamp time.Time        `json:"timestamp"`           // When the move was played
+	Exchanged []Tile           `json:"exchanged,omitempty"` // Tiles returned to the bag by an exchange
+	Drawn     []Tile           `json:"drawn,omitempty"`     // Tiles drawn from the bag after the move
+	Withdrawn bool             `json:"withdrawn,omitempty"` // True if the move was taken back after a challenge (Score and Total are kept)
+	Phonies   []string         `json:"phonies,omitempty"`   // Words formed that the dictionary did not hold, recorded when played under the double challenge rule
+	Rack      []Tile           `json:"rack,omitempty"`      // Player's rack before the action
+	Total     int              `json:"total"`               // Player's cumulative score after the action
+	Challenge *ChallengeResult `json:"challenge,omitempty"` // Outcome, for challenge entries
+	Breakdown *ScoreBreakdown  `json:"breakdown,omitempty"` // How Score was reached, for placements
+}
+
+// Positions returns the squares the move placed tiles on
+func (m Move) Positions() []Position {
+	positions := make([]Position, len(m.Tiles))
+	for i, pt := range m.Tiles {
+		positions[i] = pt.Position
+	}
+	return positions
+}
+
+// BlankAssignments returns the letter assigned to each blank tile in the move
+func (m Move) BlankAssignments() map[Position]rune {
+	blanks := make(map[Position]rune)
+	for _, pt := range m.Tiles {
+		if pt.Tile.IsBlank {
+			blanks[pt.Position] = pt.Tile.Letter
+		}
+	}
+	return blanks
+}
+
+// Errors returned by placement validation
+var (
+	ErrNoTilesPlaced      = errors.New("move must place at least one tile")
+	ErrTilesNotInLine     = errors.New("tiles must be placed in a single row or column")
+	ErrTilesNotContiguous = errors.New("tiles must form a contiguous line")
+	ErrFirstMoveNotCenter = errors.New("first move must cover the center square")
+	ErrFirstMoveTooShort  = errors.New("first move must form a word of at least two letters")
+	ErrNotConnected       = errors.New("move must connect to existing tiles")
+	ErrTilesNotInRack     = errors.New("player does not hold the tiles for this move")
+	ErrWordMismatch       = errors.New("placed tiles do not spell the declared word")
+	ErrDuplicatePosition  = errors.New("square is used more than once")
+	ErrUnassignedBlank    = errors.New("tile has no letter assigned")
+)
+
+// BuildMove derives the tiles a player must place to spell word from start in the given direction
+// Squares along the word that are already occupied must hold the matching letter.
+// blanks lists indices into word that are to be played with blank tiles.
+func (b *Board) BuildMove(playerID, word string, start Position, dir Direction, blanks []int) (Move, error) {
+	word = strings.ToUpper(strings.TrimSpace(word))
+	letters := []rune(word)
+	if len(letters) == 0 {
+		return Move{}, errors.New("word must not be empty")
+	}
+
+	isBlank := make(map[int]bool, len(blanks))
+	for _, idx := range blanks {
+		if idx < 0 || idx >= len(letters) {
+			return Move{}, fmt.Errorf("blank index %d out of range", idx)
+		}
+		isBlank[idx] = true
+	}
+
+	move := Move{PlayerID: playerID, Word: word, Start: start, Direction: dir}
+	step := dir.step()
+	pos := start
+	for i, letter := range letters {
+		if !unicode.IsLetter(letter) {
+			return Move{}, fmt.Errorf("invalid letter in word: %c", letter)
+		}
+		if !b.IsValidPosition(pos) {
+			return Move{}, fmt.Errorf("%w: word runs off the board at %s", ErrInvalidPosition, pos.String())
+		}
+
+		if existing := b.GetTile(pos); existing != nil {
+			if existing.Letter != letter {
+				return Move{}, fmt.Errorf("%w: %s holds %c, not %c", ErrPositionOccupied, pos.String(), existing.Letter, letter)
+			}
+			if isBlank[i] {
+				return Move{}, fmt.Errorf("blank index %d refers to a tile already on the board", i)
+			}
+		} else {
+			tile := Tile{Letter: letter, Points: b.tileValue(letter)}
+			if isBlank[i] {
+				tile = Tile{Letter: letter, Points: 0, IsBlank: true}
+			}
+			move.Tiles = append(move.Tiles, PlacedTile{Tile: tile, Position: pos})
+		}
+
+		pos = Position{Row: pos.Row + step.Row, Col: pos.Col + step.Col}
+	}
+
+	return move, nil
+}
+
+// ValidatePlacement checks that a move is a legal placement on the current board
+// It verifies that tiles lie in one contiguous line, connect to existing tiles
+// (or cover the center on the first move), spell the declared word, and are
+// all held in rack. Rejections are explained by a *MoveError.
+func (b *Board) ValidatePlacement(move Move, rack []Tile) error {
+	if len(move.Tiles) == 0 {
+		return moveError(RuleNoTiles, "")
+	}
+
+	for i, pt := range move.Tiles {
+		p := pt.Position
+		if !b.IsValidPosition(p) {
+			return moveError(RuleOffBoard, p.String(), p)
+		}
+		if b.HasTileAt(p) {
+			return moveError(RuleOccupied, p.String(), p)
+		}
+		if _, dup := placedAt(move.Tiles[:i], p); dup {
+			return moveError(RuleDuplicateSquare, p.String(), p)
+		}
+		if pt.Tile.Letter == 0 {
+			return moveError(RuleUnassignedBlank, p.String(), p)
+		}
+	}
+
+	if err := b.checkLine(move); err != nil {
+		return err
+	}
+
+	if b.IsFirstMove() {
+		if _, ok := placedAt(move.Tiles, b.Center); !ok {
+			return moveError(RuleNotOnCenter, "", b.Center)
+		}
+		if len(move.Tiles) < 2 {
+			return moveError(RuleFirstMoveTooShort, "", move.Positions()...)
+		}
+	} else if !b.touchesExisting(move.Tiles) {
+		return moveError(RuleNotConnected, "", move.Positions()...)
+	}
+
+	if move.Word != "" && !b.spellsMainWord(move) {
+		return moveError(RuleWordMismatch, "", move.Positions()...)
+	}
+
+	if !rackHolds(rack, move.Tiles) {
+		return moveError(RuleNotInRack, "", missingFromRack(rack, move.Tiles)...)
+	}
+
+	return nil
+}
+
+// checkLine verifies the placed tiles share a row or column and leave no gaps
+func (b *Board) checkLine(move Move) error {
+	first := move.Tiles[0].Position
+	minPos, maxPos := first, first
+	for _, pt := range move.Tiles[1:] {
+		p := pt.Position
+		switch move.Direction {
+		case Horizontal:
+			if p.Row != first.Row {
+				return moveError(RuleNotInLine, "", offLine(move)...)
+			}
+			if p.Col < minPos.Col {
+				minPos = p
+			}
+			if p.Col > maxPos.Col {
+				maxPos = p
+			}
+		case Vertical:
+			if p.Col != first.Col {
+				return moveError(RuleNotInLine, "", offLine(move)...)
+			}
+			if p.Row < minPos.Row {
+				minPos = p
+			}
+			if p.Row > maxPos.Row {
+				maxPos = p
+			}
+		default:
+			return fmt.Errorf("invalid direction: %d", move.Direction)
+		}
+	}
+
+	var gaps []Position
+	step := move.Direction.step()
+	for p := minPos; p != maxPos; {
+		p = Position{Row: p.Row + step.Row, Col: p.Col + step.Col}
+		if !b.occupiedWith(move.Tiles, p) {
+			gaps = append(gaps, p)
+		}
+	}
+	if len(gaps) > 0 {
+		return moveError(RuleNotContiguous, "", gaps...)
+	}
+
+	return nil
+}
+
+// offLine returns the placed squares outside the row or column of the first
+// tile
+func offLine(move Move) []Position {
+	first := move.Tiles[0].Position
+	var off []Position
+	for _, pt := range move.Tiles[1:] {
+		p := pt.Position
+		if move.Direction == Horizontal && p.Row != first.Row || move.Direction == Vertical && p.Col != first.Col {
+			off = append(off, p)
+		}
+	}
+	return off
+}
+
+// missingFromRack returns the squares of placed tiles that rack cannot supply
+func missingFromRack(rack []Tile, tiles []PlacedTile) []Position {
+	left := append([]Tile(nil), rack...)
+	var missing []Position
+	for _, pt := range tiles {
+		if i := findTile(left, pt.Tile); i >= 0 {
+			left = append(left[:i], left[i+1:]...)
+		} else {
+			missing = append(missing, pt.Position)
+		}
+	}
+	return missing
+}
+
+// spellsMainWord returns true if the main word formed by the move starts at
+// move.Start and matches move.Word, ignoring case
+func (b *Board) spellsMainWord(move Move) bool {
+	step := move.Direction.step()
+	start := move.Tiles[0].Position
+	for {
+		prev := Position{Row: start.Row - step.Row, Col: start.Col - step.Col}
+		if !prev.IsValid() || !b.occupiedWith(move.Tiles, prev) {
+			break
+		}
+		start = prev
+	}
+	if start != move.Start {
+		return false
+	}
+
+	word := move.Word
+	for p := start; p.IsValid() && b.occupiedWith(move.Tiles, p); p = (Position{Row: p.Row + step.Row, Col: p.Col + step.Col}) {
+		tile, isNew := placedAt(move.Tiles, p)
+		if !isNew {
+			tile = *b.Grid[p.Row][p.Col].Tile
+		}
+		r, size := utf8.DecodeRuneInString(word)
+		if size == 0 || unicode.ToUpper(r) != tile.Letter {
+			return false
+		}
+		word = word[size:]
+	}
+	return word == ""
+}
+
+// touchesExisting returns true if any placed tile is adjacent to a tile already on the board
+func (b *Board) touchesExisting(tiles []PlacedTile) bool {
+	for _, pt := range tiles {
+		if b.hasNeighbor(pt.Position) {
+			return true
+		}
+	}
+	return false
+}
+
+// rackHolds returns true if every placed tile can be drawn from rack
+// Blank tiles consume a blank from the rack regardless of their assigned letter.
+func rackHolds(rack []Tile, tiles []PlacedTile) bool {
+	// Counting by scanning keeps validation free of allocations
+	for i, pt := range tiles {
+		key := trackerKey(pt.Tile)
+		needed := 1
+		for _, other := range tiles[:i] {
+			if trackerKey(other.Tile) == key {
+				needed++
+			}
+		}
+		for _, t := range rack {
+			if trackerKey(t) == key {
+				needed--
+			}
+		}
+		if needed > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// withRackPoints returns a copy of the move with each placed tile worth what
+// the rack tile it uses is worth, so a submitted move cannot set its own tile
+// values; the rack must hold the tiles
+func withRackPoints(move Move, rack []Tile) Move {
+	left := append([]Tile(nil), rack...)
+	tiles := make([]PlacedTile, len(move.Tiles))
+	for i, pt := range move.Tiles {
+		if j := findTile(left, pt.Tile); j >= 0 {
+			pt.Tile.Points = left[j].Points
+			left = append(left[:j], left[j+1:]...)
+		}
+		tiles[i] = pt
+	}
+	move.Tiles = tiles
+	return move
+}
+
+// FormedWord is a word created on the board by a move
+type FormedWord struct {
+	Word      string     `json:"word"`
+	Start     Position   `json:"start"`
+	Direction Direction  `json:"direction"`
+	Positions []Position `json:"positions"` // Position of each letter in Word
+}
+
+// FormedWords returns every word of two or more letters created by the move:
+// the main word along the move's direction followed by any cross words
+func (b *Board) FormedWords(move Move) []FormedWord {
+	placed := make(map[Position]Tile, len(move.Tiles))
+	for _, pt := range move.Tiles {
+		placed[pt.Position] = pt.Tile
+	}
+
+	words := []FormedWord{}
+	if main := b.mainWord(move, placed); len(main.Positions) > 1 {
+		words = append(words, main)
+	}
+
+	cross := move.Direction.Perpendicular()
+	for _, pt := range move.Tiles {
+		if w := b.wordThrough(pt.Position, cross, placed); len(w.Positions) > 1 {
+			words = append(words, w)
+		}
+	}
+
+	return words
+}
+
+// mainWord returns the word along the move's direction through its placed tiles
+func (b *Board) mainWord(move Move, placed map[Position]Tile) FormedWord {
+	if len(move.Tiles) == 0 {
+		return FormedWord{}
+	}
+	return b.wordThrough(move.Tiles[0].Position, move.Direction, placed)
+}
+
+// wordThrough returns the maximal run of tiles through pos in the given direction,
+// treating the placed tiles as if they were already on the board
+func (b *Board) wordThrough(pos Position, dir Direction, placed map[Position]Tile) FormedWord {
+	step := dir.step()
+	occupied := func(p Position) bool {
+		if _, ok := placed[p]; ok {
+			return true
+		}
+		return b.HasTileAt(p)
+	}
+
+	// Walk back to the first letter of the word
+	start := pos
+	for {
+		prev := Position{Row: start.Row - step.Row, Col: start.Col - step.Col}
+		if !prev.IsValid() || !occupied(prev) {
+			break
+		}
+		start = prev
+	}
+
+	var sb strings.Builder
+	positions := []Position{}
+	for p := start; p.IsValid() && occupied(p); p = (Position{Row: p.Row + step.Row, Col: p.Col + step.Col}) {
+		if t, ok := placed[p]; ok {
+			sb.WriteRune(t.Letter)
+		} else {
+			sb.WriteRune(b.GetTile(p).Letter)
+		}
+		positions = append(positions, p)
+	}
+
+	return FormedWord{Word: sb.String(), Start: start, Direction: dir, Positions: positions}
+}
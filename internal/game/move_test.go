@@ -0,0 +1,302 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+// rackOf builds a rack from a string of letters, using '?' for blanks
+func rackOf(letters string) []Tile {
+	rack := make([]Tile, 0, len(letters))
+	for _, r := range letters {
+		if r == '?' {
+			rack = append(rack, Tile{IsBlank: true})
+		} else {
+			rack = append(rack, Tile{Letter: r, Points: GetTileValue(r)})
+		}
+	}
+	return rack
+}
+
+// mustPos converts string notation to a Position or fails the test
+func mustPos(t testing.TB, s string) Position {
+	t.Helper()
+	pos, err := NewPositionFromString(s)
+	if err != nil {
+		t.Fatalf("Invalid position %s: %v", s, err)
+	}
+	return pos
+}
+
+// placeWord places a word directly on the board without validation
+func placeWord(t testing.TB, b *Board, word string, start string, dir Direction) {
+	t.Helper()
+	move, err := b.BuildMove("setup", word, mustPos(t, start), dir, nil)
+	if err != nil {
+		t.Fatalf("Failed to build setup move %s: %v", word, err)
+	}
+	for _, pt := range move.Tiles {
+		if err := b.PlaceTile(pt.Tile, pt.Position); err != nil {
+			t.Fatalf("Failed to place setup tile: %v", err)
+		}
+	}
+}
+
+// TestBuildMove tests deriving placed tiles from a word
+func TestBuildMove(t *testing.T) {
+	board := NewBoard()
+	placeWord(t, board, "CAT", "G8", Horizontal)
+
+	// Play CART down through the A at H8
+	move, err := board.BuildMove("p1", "hart", mustPos(t, "H7"), Vertical, []int{3})
+	if err != nil {
+		t.Fatalf("BuildMove should succeed: %v", err)
+	}
+
+	if move.Word != "HART" {
+		t.Errorf("Word should be upper-cased, got %s", move.Word)
+	}
+
+	if len(move.Tiles) != 3 {
+		t.Fatalf("Move should place 3 new tiles, got %d", len(move.Tiles))
+	}
+
+	blanks := move.BlankAssignments()
+	if len(blanks) != 1 || blanks[mustPos(t, "H10")] != 'T' {
+		t.Errorf("Expected blank T at H10, got %v", blanks)
+	}
+
+	// Conflicting letter on the board
+	if _, err := board.BuildMove("p1", "HOT", mustPos(t, "H7"), Vertical, nil); err == nil {
+		t.Errorf("BuildMove should fail when the board holds a different letter")
+	}
+
+	// Running off the board
+	if _, err := board.BuildMove("p1", "QUIZ", mustPos(t, "M1"), Horizontal, nil); err == nil {
+		t.Errorf("BuildMove should fail when the word runs off the board")
+	}
+}
+
+// TestValidatePlacementFirstMove tests first-move rules
+func TestValidatePlacementFirstMove(t *testing.T) {
+	board := NewBoard()
+	rack := rackOf("CATSDOG")
+
+	move, _ := board.BuildMove("p1", "CAT", mustPos(t, "G8"), Horizontal, nil)
+	if err := board.ValidatePlacement(move, rack); err != nil {
+		t.Errorf("CAT through center should be valid: %v", err)
+	}
+
+	move, _ = board.BuildMove("p1", "CAT", mustPos(t, "A1"), Horizontal, nil)
+	if err := board.ValidatePlacement(move, rack); !errors.Is(err, ErrFirstMoveNotCenter) {
+		t.Errorf("First move off center should return ErrFirstMoveNotCenter, got %v", err)
+	}
+
+	move, _ = board.BuildMove("p1", "A", mustPos(t, "H8"), Horizontal, nil)
+	if err := board.ValidatePlacement(move, rack); !errors.Is(err, ErrFirstMoveTooShort) {
+		t.Errorf("Single tile first move should return ErrFirstMoveTooShort, got %v", err)
+	}
+}
+
+// TestValidatePlacementContiguity tests line and gap rules
+func TestValidatePlacementContiguity(t *testing.T) {
+	board := NewBoard()
+	rack := rackOf("CATSDOG")
+
+	notInLine := Move{Direction: Horizontal, Tiles: []PlacedTile{
+		{Tile: rack[0], Position: mustPos(t, "H8")},
+		{Tile: rack[1], Position: mustPos(t, "I9")},
+	}}
+	if err := board.ValidatePlacement(notInLine, rack); !errors.Is(err, ErrTilesNotInLine) {
+		t.Errorf("Diagonal placement should return ErrTilesNotInLine, got %v", err)
+	}
+
+	gap := Move{Direction: Horizontal, Tiles: []PlacedTile{
+		{Tile: rack[0], Position: mustPos(t, "H8")},
+		{Tile: rack[1], Position: mustPos(t, "J8")},
+	}}
+	if err := board.ValidatePlacement(gap, rack); !errors.Is(err, ErrTilesNotContiguous) {
+		t.Errorf("Gapped placement should return ErrTilesNotContiguous, got %v", err)
+	}
+
+	if err := board.ValidatePlacement(Move{}, rack); !errors.Is(err, ErrNoTilesPlaced) {
+		t.Errorf("Empty move should return ErrNoTilesPlaced, got %v", err)
+	}
+
+	// A gap filled by an existing tile is contiguous
+	placeWord(t, board, "CAT", "G8", Horizontal)
+	bridged := Move{Direction: Vertical, Tiles: []PlacedTile{
+		{Tile: Tile{Letter: 'O', Points: 1}, Position: mustPos(t, "G7")},
+		{Tile: Tile{Letter: 'S', Points: 1}, Position: mustPos(t, "G9")},
+	}}
+	if err := board.ValidatePlacement(bridged, rackOf("OS")); err != nil {
+		t.Errorf("Tiles bridged by an existing tile should be valid: %v", err)
+	}
+}
+
+// TestValidatePlacementConnection tests that later moves must touch existing tiles
+func TestValidatePlacementConnection(t *testing.T) {
+	board := NewBoard()
+	placeWord(t, board, "CAT", "G8", Horizontal)
+	rack := rackOf("DOGSXYZ")
+
+	move, _ := board.BuildMove("p1", "DOG", mustPos(t, "A1"), Horizontal, nil)
+	if err := board.ValidatePlacement(move, rack); !errors.Is(err, ErrNotConnected) {
+		t.Errorf("Disconnected move should return ErrNotConnected, got %v", err)
+	}
+
+	move, _ = board.BuildMove("p1", "CATS", mustPos(t, "G8"), Horizontal, nil)
+	if err := board.ValidatePlacement(move, rack); err != nil {
+		t.Errorf("Extending CAT to CATS should be valid: %v", err)
+	}
+
+	// Placing on an occupied square
+	occupied := Move{Direction: Horizontal, Tiles: []PlacedTile{
+		{Tile: rack[0], Position: mustPos(t, "H8")},
+	}}
+	if err := board.ValidatePlacement(occupied, rack); err == nil {
+		t.Errorf("Placing on an occupied square should fail")
+	}
+}
+
+// TestValidatePlacementRack tests that the player must hold the tiles
+func TestValidatePlacementRack(t *testing.T) {
+	board := NewBoard()
+
+	move, _ := board.BuildMove("p1", "ZOO", mustPos(t, "H8"), Horizontal, nil)
+	if err := board.ValidatePlacement(move, rackOf("ZOABCDE")); !errors.Is(err, ErrTilesNotInRack) {
+		t.Errorf("Move needing two Os from a rack with one should return ErrTilesNotInRack, got %v", err)
+	}
+
+	move, _ = board.BuildMove("p1", "ZOO", mustPos(t, "H8"), Horizontal, []int{2})
+	if err := board.ValidatePlacement(move, rackOf("ZO?BCDE")); err != nil {
+		t.Errorf("Blank should stand in for the second O: %v", err)
+	}
+
+	// A blank in the move requires a blank in the rack
+	if err := board.ValidatePlacement(move, rackOf("ZOOBCDE")); !errors.Is(err, ErrTilesNotInRack) {
+		t.Errorf("Blank in move without blank in rack should return ErrTilesNotInRack, got %v", err)
+	}
+}
+
+// TestValidatePlacementWordMismatch tests that the declared word must match the board
+func TestValidatePlacementWordMismatch(t *testing.T) {
+	board := NewBoard()
+	move, _ := board.BuildMove("p1", "CAT", mustPos(t, "G8"), Horizontal, nil)
+	move.Word = "COT"
+
+	if err := board.ValidatePlacement(move, rackOf("CAT")); !errors.Is(err, ErrWordMismatch) {
+		t.Errorf("Mismatched word should return ErrWordMismatch, got %v", err)
+	}
+
+	for _, word := range []string{"CA", "CATS"} {
+		move.Word = word
+		if err := board.ValidatePlacement(move, rackOf("CAT")); !errors.Is(err, ErrWordMismatch) {
+			t.Errorf("%s should return ErrWordMismatch, got %v", word, err)
+		}
+	}
+
+	move.Word = "cat"
+	if err := board.ValidatePlacement(move, rackOf("CAT")); err != nil {
+		t.Errorf("The declared word should be case-insensitive: %v", err)
+	}
+	move.Start = mustPos(t, "H8")
+	if err := board.ValidatePlacement(move, rackOf("CAT")); !errors.Is(err, ErrWordMismatch) {
+		t.Errorf("A wrong start should return ErrWordMismatch, got %v", err)
+	}
+}
+
+// TestFormedWords tests main and cross word detection
+func TestFormedWords(t *testing.T) {
+	board := NewBoard()
+	placeWord(t, board, "CAT", "G8", Horizontal)
+
+	// Play AT in row 9 under AT of CAT forming AA and TT cross words
+	move, _ := board.BuildMove("p1", "AT", mustPos(t, "H9"), Horizontal, nil)
+	words := board.FormedWords(move)
+
+	expected := []string{"AT", "AA", "TT"}
+	if len(words) != len(expected) {
+		t.Fatalf("Expected %d words, got %d: %v", len(expected), len(words), words)
+	}
+	for i, w := range words {
+		if w.Word != expected[i] {
+			t.Errorf("Word %d: expected %s, got %s", i, expected[i], w.Word)
+		}
+	}
+
+	// Single tile forming only a vertical word while declared horizontal
+	single := Move{Direction: Horizontal, Tiles: []PlacedTile{
+		{Tile: Tile{Letter: 'S', Points: 1}, Position: mustPos(t, "G9")},
+	}}
+	words = board.FormedWords(single)
+	if len(words) != 1 || words[0].Word != "CS" {
+		t.Errorf("Expected only cross word CS, got %v", words)
+	}
+}
+
+// TestDirectionString tests Direction string conversion
+func TestDirectionString(t *testing.T) {
+	if Horizontal.String() != "HORIZONTAL" || Vertical.String() != "VERTICAL" || Direction(9).String() != "UNKNOWN" {
+		t.Errorf("Unexpected direction strings")
+	}
+
+	if Horizontal.Perpendicular() != Vertical || Vertical.Perpendicular() != Horizontal {
+		t.Errorf("Perpendicular should swap directions")
+	}
+}
+
+// TestMoveTypeString tests MoveType string conversion
+func TestMoveTypeString(t *testing.T) {
+	testCases := map[MoveType]string{
+		PlaceTiles:    "PLACE_TILES",
+		Exchange:      "EXCHANGE",
+		Pass:          "PASS",
+		ChallengeTurn: "CHALLENGE",
+		MoveType(9):   "UNKNOWN",
+	}
+
+	for mt, expected := range testCases {
+		if mt.String() != expected {
+			t.Errorf("Expected %s, got %s", expected, mt.String())
+		}
+	}
+}
+
+// parallelPlay returns a board with CAT on it and a move under it forming cross words
+func parallelPlay(tb testing.TB) (*Board, Move) {
+	board := NewBoard()
+	placeWord(tb, board, "CAT", "G8", Horizontal)
+	move, err := board.BuildMove("p1", "AT", mustPos(tb, "H9"), Horizontal, nil)
+	if err != nil {
+		tb.Fatalf("BuildMove failed: %v", err)
+	}
+	return board, move
+}
+
+// TestValidatePlacementAllocations tests that a valid placement is checked without allocating
+func TestValidatePlacementAllocations(t *testing.T) {
+	board, move := parallelPlay(t)
+	rack := rackOf("ATXYZQV")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		if err := board.ValidatePlacement(move, rack); err != nil {
+			t.Fatalf("Placement should be valid: %v", err)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("ValidatePlacement should not allocate, got %.0f allocations", allocs)
+	}
+}
+
+// BenchmarkValidatePlacement measures checking a parallel play
+func BenchmarkValidatePlacement(b *testing.B) {
+	board, move := parallelPlay(b)
+	rack := rackOf("ATXYZQV")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		board.ValidatePlacement(move, rack)
+	}
+}
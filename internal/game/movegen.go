@@ -0,0 +1,542 @@
+package game
+
+import (
+	"bytes"
+	"fmt"
+	"math/bits"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"scrabbled/internal/dictionary"
+)
+
+// trieNode is a node in the prefix tree used for move generation
+// Nodes and edges refer to each other by index rather than pointer, so the
+// garbage collector need not scan the tree.
+type trieNode struct {
+	first    uint32 // Index of the node's first edge in MoveGenerator.edges
+	count    uint32 // Number of edges, which are in alphabetical order
+	terminal bool   // True if the path to this node spells a word
+}
+
+// trieEdge links a trie node to the child reached by a letter
+type trieEdge struct {
+	letter rune
+	node   uint32
+}
+
+// noNode stands for a missing child
+const noNode = ^uint32(0)
+
+// MoveGenerator finds every legal placement for a rack on a board
+type MoveGenerator struct {
+	nodes []trieNode // The root is node 0
+	edges []trieEdge
+}
+
+// NewMoveGenerator builds a move generator from a list of valid words
+func NewMoveGenerator(words []string) *MoveGenerator {
+	root := &buildNode{}
+	for _, word := range words {
+		root.add(strings.ToUpper(strings.TrimSpace(word)))
+	}
+	return root.flatten()
+}
+
+// NewMoveGeneratorForDictionary builds a move generator from the words a
+// dictionary accepts, so that bots keep to its tier and content filter
+// Returns an error if the dictionary cannot list its words.
+func NewMoveGeneratorForDictionary(dict dictionary.Dictionary) (*MoveGenerator, error) {
+	lister, ok := dictionary.AsLister(dict)
+	if !ok {
+		return nil, fmt.Errorf("dictionary %T cannot list its words", dict)
+	}
+	return NewMoveGenerator(lister.Words()), nil
+}
+
+// buildNode is a trie node under construction
+type buildNode struct {
+	children map[rune]*buildNode
+	terminal bool
+}
+
+// add inserts a word below the node
+func (n *buildNode) add(word string) {
+	if word == "" {
+		return
+	}
+	for _, r := range word {
+		child, ok := n.children[r]
+		if !ok {
+			if n.children == nil {
+				n.children = make(map[rune]*buildNode)
+			}
+			child = &buildNode{}
+			n.children[r] = child
+		}
+		n = child
+	}
+	n.terminal = true
+}
+
+// flatten lays the trie out breadth first, giving each node's edges a
+// contiguous run
+func (n *buildNode) flatten() *MoveGenerator {
+	mg := &MoveGenerator{}
+	queue := []*buildNode{n}
+	for i := 0; i < len(queue); i++ {
+		node := queue[i]
+		letters := make([]rune, 0, len(node.children))
+		for r := range node.children {
+			letters = append(letters, r)
+		}
+		sort.Slice(letters, func(a, b int) bool { return letters[a] < letters[b] })
+
+		mg.nodes = append(mg.nodes, trieNode{first: uint32(len(mg.edges)), count: uint32(len(letters)), terminal: node.terminal})
+		for _, r := range letters {
+			mg.edges = append(mg.edges, trieEdge{letter: r, node: uint32(len(queue))})
+			queue = append(queue, node.children[r])
+		}
+	}
+	return mg
+}
+
+// children returns the edges leaving a node
+func (mg *MoveGenerator) children(node uint32) []trieEdge {
+	n := mg.nodes[node]
+	return mg.edges[n.first : n.first+n.count]
+}
+
+// child returns the node reached from node by a letter, or noNode
+func (mg *MoveGenerator) child(node uint32, letter rune) uint32 {
+	for _, e := range mg.children(node) {
+		if e.letter == letter {
+			return e.node
+		}
+		if e.letter > letter {
+			break
+		}
+	}
+	return noNode
+}
+
+// IsWord returns true if the word is known to the generator
+func (mg *MoveGenerator) IsWord(word string) bool {
+	node := uint32(0)
+	for _, r := range word {
+		if node = mg.child(node, r); node == noNode {
+			return false
+		}
+	}
+	return mg.nodes[node].terminal
+}
+
+// GenerateMoves returns every legal move for the rack, highest scoring first
+// Moves with equal scores are returned in a fixed order.
+// Returned moves have Word, Start, Direction, Tiles and Score set; PlayerID is left empty.
+func (mg *MoveGenerator) GenerateMoves(b *Board, rack []Tile) []Move {
+	gen := &generation{mg: mg, board: b, rackSize: len(rack), seen: make(map[string]bool)}
+	gen.fillRack(rack)
+
+	// Plays down the board are found as plays across the transposed board
+	for _, dir := range []Direction{Horizontal, Vertical} {
+		gen.dir = dir
+		gen.snapshot()
+		for row := 0; row < 15; row++ {
+			gen.generateRow(row)
+		}
+		gen.board = b.Transpose()
+	}
+
+	// Ties are broken by placement so the order is reproducible
+	order := make([]int, len(gen.moves))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		a, b := order[i], order[j]
+		if gen.moves[a].Score != gen.moves[b].Score {
+			return gen.moves[a].Score > gen.moves[b].Score
+		}
+		return gen.keys[a] < gen.keys[b]
+	})
+
+	moves := make([]Move, len(order))
+	for i, idx := range order {
+		moves[i] = gen.moves[idx]
+	}
+	return moves
+}
+
+// letterSet is a set of letters, held as a bitmask for A to Z and a map for
+// any other letters a tile set uses
+// The zero value allows every letter.
+type letterSet struct {
+	restricted bool          // False if any letter is allowed
+	mask       uint32        // Allowed letters from A to Z, A being bit 0
+	extra      map[rune]bool // Allowed letters outside A to Z
+}
+
+// add allows a letter
+func (s *letterSet) add(r rune) {
+	if r >= 'A' && r <= 'Z' {
+		s.mask |= 1 << (r - 'A')
+		return
+	}
+	if s.extra == nil {
+		s.extra = make(map[rune]bool)
+	}
+	s.extra[r] = true
+}
+
+// has returns true if the letter is allowed
+func (s *letterSet) has(r rune) bool {
+	if !s.restricted {
+		return true
+	}
+	if r >= 'A' && r <= 'Z' {
+		return s.mask&(1<<(r-'A')) != 0
+	}
+	return s.extra[r]
+}
+
+// rackLetter counts the copies of one letter left on the rack
+type rackLetter struct {
+	letter rune
+	count  int
+	points int
+}
+
+// generation holds the working state of a single GenerateMoves call
+type generation struct {
+	mg       *MoveGenerator
+	board    *Board       // The board searched across: the original, then its transpose
+	rack     []rackLetter // Remaining rack letters other than blanks
+	blanks   int          // Remaining blanks
+	rackSize int
+	moves    []Move
+	keys     []string        // Placement key of each recorded move
+	tiles    []PlacedTile    // Unused space for the tiles of recorded moves
+	seen     map[string]bool // Keys of single-tile moves already recorded
+
+	// Board state, read once per direction
+	dir     Direction
+	mul     Multipliers
+	grid    [15][15]rune // Letter on each square, 0 if empty
+	points  [15][15]int  // Points of the tile on each square
+	anchors [15][15]bool // Empty squares a move may be built through
+
+	// Per-row state
+	squares   [15]Position // Squares of the row on the original board
+	line      [15]rune     // Letters on the row, 0 if empty
+	rowPoints [15]int      // Points of the tiles on the row
+	cross     [15]letterSet
+	crossSum  [15]int // Points of the tiles in the perpendicular word through each square
+	anchor    [15]bool
+	letterMul [15]int
+	wordMul   [15]int
+	placed    []PlacedTile
+	word      [15]rune // Letters of the word being built
+}
+
+// fillRack counts the rack's letters and blanks
+func (gen *generation) fillRack(rack []Tile) {
+	gen.placed = make([]PlacedTile, 0, len(rack))
+	for _, t := range rack {
+		if t.IsBlank {
+			gen.blanks++
+			continue
+		}
+		if i := gen.rackIndex(t.Letter); i >= 0 {
+			gen.rack[i].count++
+			continue
+		}
+		gen.rack = append(gen.rack, rackLetter{letter: t.Letter, count: 1, points: gen.board.tileValue(t.Letter)})
+	}
+}
+
+// rackIndex returns the index of a letter in the rack, or -1
+func (gen *generation) rackIndex(letter rune) int {
+	for i := range gen.rack {
+		if gen.rack[i].letter == letter {
+			return i
+		}
+	}
+	return -1
+}
+
+// snapshot reads the board's letters and finds the anchor squares
+func (gen *generation) snapshot() {
+	gen.grid, gen.points, gen.anchors = [15][15]rune{}, [15][15]int{}, [15][15]bool{}
+	gen.mul = gen.board.multipliers()
+	for _, pos := range gen.board.GetOccupiedPositions() {
+		if tile := gen.board.Grid[pos.Row][pos.Col].Tile; tile != nil {
+			gen.grid[pos.Row][pos.Col] = tile.Letter
+			gen.points[pos.Row][pos.Col] = tile.Points
+		}
+	}
+
+	if gen.board.IsFirstMove() {
+		center := gen.board.Center
+		gen.anchors[center.Row][center.Col] = true
+		return
+	}
+	for row := 0; row < 15; row++ {
+		for mask := gen.board.anchorMask(row); mask != 0; mask &= mask - 1 {
+			gen.anchors[row][bits.TrailingZeros16(mask)] = true
+		}
+	}
+}
+
+// generateRow finds all moves across one row of the board being searched
+func (gen *generation) generateRow(row int) {
+	hasAnchor := false
+
+	for i := 0; i < 15; i++ {
+		pos := Position{Row: row, Col: i}
+		gen.squares[i] = pos
+		if gen.dir == Vertical {
+			gen.squares[i] = FlipDiagonal.Apply(pos)
+		}
+		gen.line[i], gen.rowPoints[i] = gen.grid[row][i], gen.points[row][i]
+		gen.anchor[i] = gen.anchors[row][i]
+		gen.cross[i], gen.crossSum[i] = letterSet{}, 0
+		if gen.anchor[i] {
+			hasAnchor = true
+			gen.cross[i], gen.crossSum[i] = gen.crossCheck(pos)
+		}
+		premium := gen.board.Grid[row][i].Premium
+		gen.letterMul[i] = gen.mul.Letter(premium)
+		gen.wordMul[i] = gen.mul.Word(premium)
+	}
+	if !hasAnchor {
+		return
+	}
+
+	for start := 0; start < 15; start++ {
+		// Words must start at the line edge or after an empty square
+		if start > 0 && gen.line[start-1] != 0 {
+			continue
+		}
+		if !gen.anchorReachable(start) {
+			continue
+		}
+		gen.placed = gen.placed[:0]
+		gen.extend(start, start, 0, false, 0)
+	}
+}
+
+// anchorReachable returns true if an anchor can be covered starting at start
+// using no more tiles than the rack holds
+func (gen *generation) anchorReachable(start int) bool {
+	empties := 0
+	for i := start; i < 15; i++ {
+		if gen.line[i] == 0 {
+			if gen.anchor[i] {
+				return true
+			}
+			empties++
+			if empties >= gen.rackSize {
+				return false
+			}
+		}
+	}
+	return false
+}
+
+// crossCheck returns the letters that may be placed at pos without forming an
+// invalid word down its column, and the points of the tiles already in that
+// word
+func (gen *generation) crossCheck(pos Position) (letterSet, int) {
+	col := pos.Col
+
+	// Walk up to the first letter of the word down the column
+	first, sum := pos.Row, 0
+	for row := pos.Row - 1; row >= 0 && gen.grid[row][col] != 0; row-- {
+		first = row
+		sum += gen.points[row][col]
+	}
+	after := pos.Row + 1
+	for row := after; row < 15 && gen.grid[row][col] != 0; row++ {
+		sum += gen.points[row][col]
+	}
+	if first == pos.Row && (after >= 15 || gen.grid[after][col] == 0) {
+		return letterSet{}, 0
+	}
+
+	allowed := letterSet{restricted: true}
+	node := uint32(0)
+	for row := first; row < pos.Row; row++ {
+		if node = gen.mg.child(node, gen.grid[row][col]); node == noNode {
+			return allowed, sum
+		}
+	}
+	for _, e := range gen.mg.children(node) {
+		n := e.node
+		for row := after; n != noNode && row < 15 && gen.grid[row][col] != 0; row++ {
+			n = gen.mg.child(n, gen.grid[row][col])
+		}
+		if n != noNode && gen.mg.nodes[n].terminal {
+			allowed.add(e.letter)
+		}
+	}
+	return allowed, sum
+}
+
+// extend walks the trie along the row from index i, placing rack tiles on
+// empty squares and following existing tiles, recording every complete word
+// of the given length
+func (gen *generation) extend(start, i int, node uint32, hitAnchor bool, length int) {
+	if i < 15 && gen.line[i] != 0 {
+		letter := gen.line[i]
+		if child := gen.mg.child(node, letter); child != noNode {
+			gen.word[length] = letter
+			gen.extend(start, i+1, child, hitAnchor, length+1)
+		}
+		return
+	}
+
+	// Square i is empty or off the board, so the word may end here
+	if gen.mg.nodes[node].terminal && hitAnchor && len(gen.placed) > 0 && length > 1 {
+		gen.record(start, length)
+	}
+
+	if i >= 15 || len(gen.placed) == gen.rackSize {
+		return
+	}
+
+	cross := &gen.cross[i]
+	anchored := hitAnchor || gen.anchor[i]
+	pos := gen.squares[i]
+
+	for _, e := range gen.mg.children(node) {
+		if !cross.has(e.letter) {
+			continue
+		}
+		gen.word[length] = e.letter
+
+		if r := gen.rackIndex(e.letter); r >= 0 && gen.rack[r].count > 0 {
+			gen.rack[r].count--
+			gen.placed = append(gen.placed, PlacedTile{Tile: Tile{Letter: e.letter, Points: gen.rack[r].points}, Position: pos})
+			gen.extend(start, i+1, e.node, anchored, length+1)
+			gen.placed = gen.placed[:len(gen.placed)-1]
+			gen.rack[r].count++
+		}
+
+		if gen.blanks > 0 {
+			gen.blanks--
+			gen.placed = append(gen.placed, PlacedTile{Tile: Tile{Letter: e.letter, Points: 0, IsBlank: true}, Position: pos})
+			gen.extend(start, i+1, e.node, anchored, length+1)
+			gen.placed = gen.placed[:len(gen.placed)-1]
+			gen.blanks++
+		}
+	}
+}
+
+// record adds the current placement as a move unless an identical one exists
+// A tile placed alone is found once in each direction; longer placements lie
+// on one line and are found once.
+func (gen *generation) record(start, length int) {
+	// Tiles are cut from a shared block to save an allocation per move
+	n := len(gen.placed)
+	if len(gen.tiles) < n {
+		gen.tiles = make([]PlacedTile, 64*MaxRackSize)
+	}
+	tiles := gen.tiles[:n:n]
+	gen.tiles = gen.tiles[n:]
+	copy(tiles, gen.placed)
+
+	key := moveKey(tiles)
+	if len(tiles) == 1 {
+		if gen.seen[key] {
+			return
+		}
+		gen.seen[key] = true
+	}
+
+	move := Move{
+		Word:      string(gen.word[:length]),
+		Start:     gen.squares[start],
+		Direction: gen.dir,
+		Tiles:     tiles,
+	}
+	move.Score = gen.score(start, length)
+	gen.moves = append(gen.moves, move)
+	gen.keys = append(gen.keys, key)
+}
+
+// score returns the points for the current placement, scoring it as
+// Board.ScoreMove does from the line and cross-check state
+func (gen *generation) score(start, length int) int {
+	main, multiplier, cross := 0, 1, 0
+	next := 0
+	for i := start; i < start+length; i++ {
+		if gen.line[i] != 0 {
+			main += gen.rowPoints[i]
+			continue
+		}
+		value := gen.placed[next].Tile.Points * gen.letterMul[i]
+		next++
+		main += value
+		multiplier *= gen.wordMul[i]
+		if gen.cross[i].restricted {
+			cross += (gen.crossSum[i] + value) * gen.wordMul[i]
+		}
+	}
+
+	total := main*multiplier + cross
+	if len(gen.placed) == gen.board.RackSize {
+		total += gen.board.BingoBonus
+	}
+	return total
+}
+
+// moveKey returns a string identifying the tiles placed by a move: the
+// position and letter of each tile (lower case for a blank), sorted and joined
+// by commas
+func moveKey(tiles []PlacedTile) string {
+	var buf [MaxRackSize]keyPart
+	parts := buf[:0]
+	size := 0
+	for _, pt := range tiles {
+		var part keyPart
+		letter := pt.Tile.Letter
+		if pt.Tile.IsBlank {
+			letter = unicode.ToLower(letter)
+		}
+		part.n = copy(part.b[:], pt.Position.String())
+		part.n += utf8.EncodeRune(part.b[part.n:], letter)
+		size += part.n + 1
+
+		// Insertion sort keeps the parts in order without allocating
+		i := len(parts)
+		parts = append(parts, part)
+		for ; i > 0 && bytes.Compare(parts[i-1].bytes(), part.bytes()) > 0; i-- {
+			parts[i] = parts[i-1]
+		}
+		parts[i] = part
+	}
+
+	var sb strings.Builder
+	sb.Grow(size)
+	for i := range parts {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.Write(parts[i].bytes())
+	}
+	return sb.String()
+}
+
+// keyPart is one tile of a move key: a position such as H10 and a letter
+type keyPart struct {
+	b [len("INVALID") + utf8.UTFMax]byte
+	n int
+}
+
+// bytes returns the part's text
+func (p *keyPart) bytes() []byte {
+	return p.b[:p.n]
+}
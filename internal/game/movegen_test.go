@@ -0,0 +1,270 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+
+	"scrabbled/internal/dictionary"
+)
+
+// testWords is a small word list shared by move generator and bot tests
+var testWords = []string{"AA", "AT", "TA", "CAT", "CATS", "ACT", "SCAT", "TAS", "AS", "QI", "ZA", "DOG", "GOD", "DOGS", "GODS", "TO", "DO", "GO", "OD"}
+
+// TestMoveGeneratorFirstMove tests that first moves cover the center
+func TestMoveGeneratorFirstMove(t *testing.T) {
+	mg := NewMoveGenerator(testWords)
+	board := NewBoard()
+
+	moves := mg.GenerateMoves(board, rackOf("CATXYZV"))
+	if len(moves) == 0 {
+		t.Fatalf("Expected moves for CAT rack on an empty board")
+	}
+
+	for _, move := range moves {
+		if err := board.ValidatePlacement(move, rackOf("CATXYZV")); err != nil {
+			t.Errorf("Generated move %s at %s is invalid: %v", move.Word, move.Start, err)
+		}
+		if !mg.IsWord(move.Word) {
+			t.Errorf("Generated word %s is not in the word list", move.Word)
+		}
+	}
+
+	// Moves are sorted by score
+	for i := 1; i < len(moves); i++ {
+		if moves[i].Score > moves[i-1].Score {
+			t.Errorf("Moves should be sorted by score descending")
+			break
+		}
+	}
+}
+
+// TestMoveGeneratorCrossWords tests that generated moves form only valid words
+func TestMoveGeneratorCrossWords(t *testing.T) {
+	mg := NewMoveGenerator(testWords)
+	board := NewBoard()
+	placeWord(t, board, "CAT", "G8", Horizontal)
+	rack := rackOf("SDOGAQI")
+
+	moves := mg.GenerateMoves(board, rack)
+	if len(moves) == 0 {
+		t.Fatalf("Expected moves on a board with CAT")
+	}
+
+	foundCats := false
+	for _, move := range moves {
+		if err := board.ValidatePlacement(move, rack); err != nil {
+			t.Errorf("Generated move %s at %s is invalid: %v", move.Word, move.Start, err)
+		}
+		for _, w := range board.FormedWords(move) {
+			if !mg.IsWord(w.Word) {
+				t.Errorf("Move %s forms invalid word %s", move.Word, w.Word)
+			}
+		}
+		if move.Score != board.ScoreMove(move) {
+			t.Errorf("Move %s has score %d, expected %d", move.Word, move.Score, board.ScoreMove(move))
+		}
+		if move.Word == "CATS" {
+			foundCats = true
+		}
+	}
+
+	if !foundCats {
+		t.Errorf("Expected CATS among generated moves")
+	}
+}
+
+// TestMoveGeneratorBlanks tests that blanks can stand for any letter
+func TestMoveGeneratorBlanks(t *testing.T) {
+	mg := NewMoveGenerator(testWords)
+	board := NewBoard()
+
+	moves := mg.GenerateMoves(board, rackOf("Q?"))
+	found := false
+	for _, move := range moves {
+		if move.Word == "QI" {
+			found = true
+			if len(move.BlankAssignments()) != 1 {
+				t.Errorf("QI should be played with a blank I")
+			}
+		}
+	}
+	if !found {
+		t.Errorf("Expected QI using a blank")
+	}
+}
+
+// TestMoveGeneratorNoMoves tests racks with no playable word
+func TestMoveGeneratorNoMoves(t *testing.T) {
+	mg := NewMoveGenerator(testWords)
+
+	if moves := mg.GenerateMoves(NewBoard(), rackOf("XYZVVWW")); len(moves) != 0 {
+		t.Errorf("Expected no moves, got %d", len(moves))
+	}
+
+	if moves := mg.GenerateMoves(NewBoard(), nil); len(moves) != 0 {
+		t.Errorf("Expected no moves for an empty rack, got %d", len(moves))
+	}
+}
+
+// TestMoveGeneratorScores tests that generated moves score as Board.ScoreMove does
+func TestMoveGeneratorScores(t *testing.T) {
+	mg := NewMoveGenerator(benchmarkWords())
+	board, rack := denseBoard(t, mg)
+
+	for _, r := range [][]Tile{rack, rackOf("??AERST")} {
+		moves := mg.GenerateMoves(board, r)
+		if len(moves) == 0 {
+			t.Fatalf("Expected moves on the dense board")
+		}
+		for _, move := range moves {
+			if want := board.ScoreMove(move); move.Score != want {
+				t.Errorf("%s at %s should score %d, got %d", move.Word, move.Start, want, move.Score)
+			}
+		}
+	}
+}
+
+// TestMoveGeneratorTileSetLetters tests cross-checks on letters outside A to Z
+func TestMoveGeneratorTileSetLetters(t *testing.T) {
+	mg := NewMoveGenerator([]string{"ÖL", "LÖ", "ÖLE"})
+	board := NewBoard()
+	board.PlaceTile(Tile{Letter: 'L', Points: 2}, mustPos(t, "H8"))
+
+	found := map[string]bool{}
+	for _, move := range mg.GenerateMoves(board, []Tile{{Letter: 'Ö', Points: 8}, {Letter: 'E', Points: 1}}) {
+		found[move.Word] = true
+		for _, w := range board.FormedWords(move) {
+			if !mg.IsWord(w.Word) {
+				t.Errorf("%s at %s forms the invalid word %s", move.Word, move.Start, w.Word)
+			}
+		}
+		if want := board.ScoreMove(move); move.Score != want {
+			t.Errorf("%s should score %d, got %d", move.Word, want, move.Score)
+		}
+	}
+	for _, word := range []string{"ÖL", "LÖ", "ÖLE"} {
+		if !found[word] {
+			t.Errorf("Expected %s among generated moves, got %v", word, found)
+		}
+	}
+}
+
+// benchmarkWords returns a reproducible synthetic lexicon of about 60,000
+// words drawn from the English tile frequencies, standing in for a full word
+// list in benchmarks
+func benchmarkWords() []string {
+	var letters []rune
+	for _, letter := range sortedLetters(standardTileDistribution) {
+		for i := 0; i < standardTileDistribution[letter].quantity; i++ {
+			letters = append(letters, letter)
+		}
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	words := make([]string, 0, 60000)
+	for a := 'A'; a <= 'Z'; a++ {
+		for b := 'A'; b <= 'Z'; b++ {
+			if rng.Intn(4) == 0 {
+				words = append(words, string([]rune{a, b}))
+			}
+		}
+	}
+	for len(words) < cap(words) {
+		word := make([]rune, 3+rng.Intn(6))
+		for i := range word {
+			word[i] = letters[rng.Intn(len(letters))]
+		}
+		words = append(words, string(word))
+	}
+	return words
+}
+
+// denseBoard plays a seeded game between two greedy bots until the bag runs
+// out, returning the board and the rack of the player to move
+func denseBoard(tb testing.TB, mg *MoveGenerator) (*Board, []Tile) {
+	tb.Helper()
+
+	g, _ := NewGame("bench", []*Player{NewPlayer("p1", "Player 1"), NewPlayer("p2", "Player 2")})
+	g.TileBag = NewTileBagWithSeed(1)
+	if err := g.StartGame(); err != nil {
+		tb.Fatalf("StartGame failed: %v", err)
+	}
+	for g.State == InProgress && !g.TileBag.IsEmpty() {
+		player := g.CurrentPlayer()
+		move, found := NewBot(player.ID, Greedy, mg).ChooseMove(g.Board, player.Rack)
+		if !found {
+			break
+		}
+		if _, err := g.PlayMove(move); err != nil {
+			tb.Fatalf("Bot move failed: %v", err)
+		}
+	}
+	return g.Board, g.CurrentPlayer().Rack
+}
+
+// BenchmarkGenerateMovesEmptyBoard measures generation for an opening rack
+func BenchmarkGenerateMovesEmptyBoard(b *testing.B) {
+	mg := NewMoveGenerator(benchmarkWords())
+	board := NewBoard()
+	rack := rackOf("AEINRST")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mg.GenerateMoves(board, rack)
+	}
+}
+
+// BenchmarkGenerateMovesDenseBoard measures generation on a board near the end of a game
+func BenchmarkGenerateMovesDenseBoard(b *testing.B) {
+	mg := NewMoveGenerator(benchmarkWords())
+	board, rack := denseBoard(b, mg)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mg.GenerateMoves(board, rack)
+	}
+}
+
+// BenchmarkGenerateMovesBlanks measures generation with two blanks on a dense board
+func BenchmarkGenerateMovesBlanks(b *testing.B) {
+	mg := NewMoveGenerator(benchmarkWords())
+	board, _ := denseBoard(b, mg)
+	rack := rackOf("??AERST")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mg.GenerateMoves(board, rack)
+	}
+}
+
+// TestMoveGeneratorForDictionary tests generating only the words a dictionary accepts
+func TestMoveGeneratorForDictionary(t *testing.T) {
+	words := dictionary.NewWordList(dictionary.Custom)
+	for _, w := range testWords {
+		words.AddWord(w)
+	}
+	mg, err := NewMoveGeneratorForDictionary(dictionary.NewFiltered(words, func(word string) bool { return word != "CATS" }))
+	if err != nil {
+		t.Fatalf("NewMoveGeneratorForDictionary failed: %v", err)
+	}
+	moves := mg.GenerateMoves(NewBoard(), rackOf("CATSDOG"))
+	if len(moves) == 0 {
+		t.Fatalf("Expected moves from the allowed words")
+	}
+	for _, m := range moves {
+		if m.Word == "CATS" {
+			t.Errorf("Should not play a filtered word: %+v", m)
+		}
+	}
+
+	if _, err := NewMoveGeneratorForDictionary(newRecordedRuling(nil)); err == nil {
+		t.Errorf("Should refuse a dictionary that cannot list its words")
+	}
+	filtered := dictionary.NewFiltered(newRecordedRuling(nil), func(string) bool { return true })
+	if _, err := NewMoveGeneratorForDictionary(filtered); err == nil {
+		t.Errorf("Should refuse a filtered view of a dictionary that cannot list its words")
+	}
+}
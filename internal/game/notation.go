@@ -0,0 +1,312 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ErrInvalidNotation is returned when a position string cannot be parsed
+var ErrInvalidNotation = errors.New("invalid position notation")
+
+// GamePosition is a complete board position with every rack, score and the
+// player to move, independent of how the game reached it
+//
+// Its notation, like chess FEN, is a single line of space-separated fields:
+//
+//	board racks scores turn [variant]
+//
+// The board lists rows 1 to 15 separated by '/', each giving its tiles from
+// column A with blanks in lower case and runs of empty squares as a count.
+// Racks and scores are '/'-separated per player, with '?' for a blank and '-'
+// for an empty rack. Turn is the 0-based index of the player to move, and the
+// variant is omitted for classic games. For example:
+//
+//	15/15/15/15/15/15/15/7CaT5/15/15/15/15/15/15/15 AEINRST/DGO? 8/0 1
+type GamePosition struct {
+	Board  *Board
+	Racks  [][]Tile
+	Scores []int
+	Turn   int // Index of the player to move
+}
+
+// Position returns the game's current position
+func (g *Game) Position() *GamePosition {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	pos := &GamePosition{
+		Board:  g.Board.Clone(),
+		Racks:  make([][]Tile, len(g.Players)),
+		Scores: make([]int, len(g.Players)),
+		Turn:   g.CurrentTurn,
+	}
+	for i, p := range g.Players {
+		pos.Racks[i] = rackCopy(p.Rack)
+		pos.Scores[i] = p.Score
+	}
+	return pos
+}
+
+// Encode returns the position in single-line notation
+func (p *GamePosition) Encode() string {
+	rows := make([]string, 15)
+	for row := 0; row < 15; row++ {
+		var sb strings.Builder
+		empty := 0
+		for col := 0; col < 15; col++ {
+			tile := p.Board.GetTile(Position{Row: row, Col: col})
+			if tile == nil {
+				empty++
+				continue
+			}
+			if empty > 0 {
+				sb.WriteString(strconv.Itoa(empty))
+				empty = 0
+			}
+			if tile.IsBlank {
+				sb.WriteRune(unicode.ToLower(tile.Letter))
+			} else {
+				sb.WriteRune(tile.Letter)
+			}
+		}
+		if empty > 0 {
+			sb.WriteString(strconv.Itoa(empty))
+		}
+		rows[row] = sb.String()
+	}
+
+	racks := make([]string, len(p.Racks))
+	for i, rack := range p.Racks {
+		racks[i] = gcgRack(rack)
+		if racks[i] == "" {
+			racks[i] = "-"
+		}
+	}
+	scores := make([]string, len(p.Scores))
+	for i, score := range p.Scores {
+		scores[i] = strconv.Itoa(score)
+	}
+
+	fields := []string{
+		strings.Join(rows, "/"),
+		strings.Join(racks, "/"),
+		strings.Join(scores, "/"),
+		strconv.Itoa(p.Turn),
+	}
+	if p.Board.Variant != Classic {
+		fields = append(fields, p.Board.Variant.String())
+	}
+	return strings.Join(fields, " ")
+}
+
+// String returns the position in single-line notation
+func (p *GamePosition) String() string {
+	return p.Encode()
+}
+
+// ParsePosition reads a position from single-line notation
+// The tiles on the board and racks must all come from the variant's tile set.
+func ParsePosition(s string) (*GamePosition, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 4 && len(fields) != 5 {
+		return nil, fmt.Errorf("%w: expected 4 or 5 fields, got %d", ErrInvalidNotation, len(fields))
+	}
+
+	variant := Classic
+	if len(fields) == 5 {
+		v, err := parseVariant(fields[4])
+		if err != nil {
+			return nil, err
+		}
+		variant = v
+	}
+
+	pos := &GamePosition{Board: NewBoardForVariant(variant)}
+	if err := pos.parseBoard(fields[0]); err != nil {
+		return nil, err
+	}
+
+	for _, r := range strings.Split(fields[1], "/") {
+		rack, err := parseNotationRack(r, variant)
+		if err != nil {
+			return nil, err
+		}
+		pos.Racks = append(pos.Racks, rack)
+	}
+	if len(pos.Racks) < MinPlayers || len(pos.Racks) > MaxPlayers {
+		return nil, fmt.Errorf("%w: %d racks, expected %d to %d", ErrInvalidNotation, len(pos.Racks), MinPlayers, MaxPlayers)
+	}
+
+	for _, sc := range strings.Split(fields[2], "/") {
+		score, err := strconv.Atoi(sc)
+		if err != nil {
+			return nil, fmt.Errorf("%w: bad score %q", ErrInvalidNotation, sc)
+		}
+		pos.Scores = append(pos.Scores, score)
+	}
+	if len(pos.Scores) != len(pos.Racks) {
+		return nil, fmt.Errorf("%w: %d scores for %d racks", ErrInvalidNotation, len(pos.Scores), len(pos.Racks))
+	}
+
+	turn, err := strconv.Atoi(fields[3])
+	if err != nil || turn < 0 || turn >= len(pos.Racks) {
+		return nil, fmt.Errorf("%w: bad turn %q", ErrInvalidNotation, fields[3])
+	}
+	pos.Turn = turn
+
+	if err := pos.checkTileCounts(); err != nil {
+		return nil, err
+	}
+	return pos, nil
+}
+
+// parseBoard places the tiles described by the board field
+func (p *GamePosition) parseBoard(field string) error {
+	rows := strings.Split(field, "/")
+	if len(rows) != 15 {
+		return fmt.Errorf("%w: expected 15 rows, got %d", ErrInvalidNotation, len(rows))
+	}
+
+	for row, spec := range rows {
+		col := 0
+		runes := []rune(spec)
+		for i := 0; i < len(runes); i++ {
+			r := runes[i]
+			switch {
+			case unicode.IsDigit(r):
+				j := i
+				for j < len(runes) && unicode.IsDigit(runes[j]) {
+					j++
+				}
+				n, _ := strconv.Atoi(string(runes[i:j]))
+				if n == 0 {
+					return fmt.Errorf("%w: row %d has an empty run of 0", ErrInvalidNotation, row+1)
+				}
+				col += n
+				i = j - 1
+			case unicode.IsUpper(r):
+				if col < 15 {
+					p.Board.PlaceTile(Tile{Letter: r, Points: p.Board.Variant.TileValue(r)}, Position{Row: row, Col: col})
+				}
+				col++
+			case unicode.IsLower(r):
+				if col < 15 {
+					p.Board.PlaceTile(Tile{Letter: unicode.ToUpper(r), IsBlank: true}, Position{Row: row, Col: col})
+				}
+				col++
+			default:
+				return fmt.Errorf("%w: unexpected %q in row %d", ErrInvalidNotation, r, row+1)
+			}
+		}
+		if col != 15 {
+			return fmt.Errorf("%w: row %d covers %d squares", ErrInvalidNotation, row+1, col)
+		}
+	}
+	return nil
+}
+
+// ParseRack reads a rack in position notation, ignoring case, with tile
+// values from the variant
+func ParseRack(s string, variant Variant) ([]Tile, error) {
+	return parseNotationRack(strings.ToUpper(s), variant)
+}
+
+// parseNotationRack reads one rack, with '?' for blanks and '-' for an empty rack
+func parseNotationRack(s string, variant Variant) ([]Tile, error) {
+	rack := make([]Tile, 0, MaxRackSize)
+	if s == "-" {
+		return rack, nil
+	}
+	for _, r := range s {
+		switch {
+		case r == '?':
+			rack = append(rack, Tile{IsBlank: true})
+		case unicode.IsUpper(r):
+			rack = append(rack, Tile{Letter: r, Points: variant.TileValue(r)})
+		default:
+			return nil, fmt.Errorf("%w: unexpected %q in rack %q", ErrInvalidNotation, r, s)
+		}
+	}
+	if len(rack) == 0 || len(rack) > MaxRackSize {
+		return nil, fmt.Errorf("%w: rack %q must hold 1 to %d tiles or be '-'", ErrInvalidNotation, s, MaxRackSize)
+	}
+	return rack, nil
+}
+
+// checkTileCounts verifies that no tile appears more often than the tile set allows
+func (p *GamePosition) checkTileCounts() error {
+	tracker := p.Board.tileTracker()
+	used := make(map[rune]int)
+	for _, pos := range p.Board.GetOccupiedPositions() {
+		used[trackerKey(*p.Board.GetTile(pos))]++
+	}
+	for _, rack := range p.Racks {
+		for _, t := range rack {
+			used[trackerKey(t)]++
+		}
+	}
+
+	for letter, n := range used {
+		available, ok := tracker.distribution[letter]
+		if !ok {
+			return fmt.Errorf("%w: %q is not in the tile set", ErrInvalidNotation, letter)
+		}
+		if n > available {
+			name := string(letter)
+			if letter == 0 {
+				name = "?"
+			}
+			return fmt.Errorf("%w: %d %s tiles, only %d in the set", ErrInvalidNotation, n, name, available)
+		}
+	}
+	return nil
+}
+
+// parseVariant returns the variant with the given name
+func parseVariant(name string) (Variant, error) {
+	for _, v := range []Variant{Classic, WordsWithFriends} {
+		if strings.EqualFold(name, v.String()) {
+			return v, nil
+		}
+	}
+	return Classic, fmt.Errorf("%w: unknown variant %q", ErrInvalidNotation, name)
+}
+
+// NewGameFromPosition creates a game in progress at the given position
+// Players are seated as p1, p2 and so on, and the tiles not on the board or
+// any rack are shuffled into the bag. The game has no history or events.
+func NewGameFromPosition(id string, pos *GamePosition) (*Game, error) {
+	players := make([]*Player, len(pos.Racks))
+	for i := range players {
+		players[i] = NewPlayer(fmt.Sprintf("p%d", i+1), fmt.Sprintf("Player %d", i+1))
+	}
+	g, err := NewGameWithVariant(id, players, pos.Board.Variant)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.StartGame(); err != nil {
+		return nil, err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.Board = pos.Board.Clone()
+	var held []Tile
+	for i, p := range g.Players {
+		p.Rack = rackCopy(pos.Racks[i])
+		p.Score = pos.Scores[i]
+		held = append(held, p.Rack...)
+	}
+	g.CurrentTurn = pos.Turn
+	g.TileBag = NewTileBagFromTiles(g.Board.tileTracker().UnseenTiles(g.Board, held))
+	g.TileBag.shuffle()
+
+	// The position, not the deal that seated the players, is the source of the game
+	g.events = nil
+	g.undoLog = nil
+	return g, nil
+}
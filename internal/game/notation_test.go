@@ -0,0 +1,139 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+// examplePosition is CAT with a blank A at H8, p2 to move
+const examplePosition = "15/15/15/15/15/15/15/7CaT5/15/15/15/15/15/15/15 AEINRST/DGO? 8/0 1"
+
+// TestParsePosition tests reading a position from notation
+func TestParsePosition(t *testing.T) {
+	pos, err := ParsePosition(examplePosition)
+	if err != nil {
+		t.Fatalf("ParsePosition failed: %v", err)
+	}
+
+	if tile := pos.Board.GetTile(mustPos(t, "H8")); tile == nil || tile.Letter != 'C' || tile.Points != 3 {
+		t.Errorf("H8 should hold a C worth 3, got %+v", tile)
+	}
+	if tile := pos.Board.GetTile(mustPos(t, "I8")); tile == nil || !tile.IsBlank || tile.Letter != 'A' || tile.Points != 0 {
+		t.Errorf("I8 should hold a blank A, got %+v", tile)
+	}
+	if len(pos.Board.GetOccupiedPositions()) != 3 {
+		t.Errorf("Board should hold three tiles")
+	}
+	if len(pos.Racks) != 2 || len(pos.Racks[0]) != 7 || !pos.Racks[1][3].IsBlank {
+		t.Errorf("Racks were not parsed correctly: %v", pos.Racks)
+	}
+	if pos.Scores[0] != 8 || pos.Scores[1] != 0 || pos.Turn != 1 {
+		t.Errorf("Scores and turn were not parsed correctly: %v %d", pos.Scores, pos.Turn)
+	}
+
+	if got := pos.Encode(); got != examplePosition {
+		t.Errorf("Encode should round-trip:\n got %s\nwant %s", got, examplePosition)
+	}
+}
+
+// TestParsePositionVariant tests the optional variant field
+func TestParsePositionVariant(t *testing.T) {
+	s := "15/15/15/15/15/15/15/15/15/15/15/15/15/15/15 -/- 0/0 0 WORDS_WITH_FRIENDS"
+	pos, err := ParsePosition(s)
+	if err != nil {
+		t.Fatalf("ParsePosition failed: %v", err)
+	}
+	if pos.Board.Variant != WordsWithFriends || pos.Board.GetPremiumType(mustPos(t, "D1")) != TripleWordScore {
+		t.Errorf("Should use the Words With Friends board")
+	}
+	if len(pos.Racks[0]) != 0 {
+		t.Errorf("'-' should be an empty rack")
+	}
+	if pos.Encode() != s {
+		t.Errorf("Encode should keep the variant, got %s", pos.Encode())
+	}
+}
+
+// TestParsePositionErrors tests that malformed notation is rejected
+func TestParsePositionErrors(t *testing.T) {
+	empty := "15/15/15/15/15/15/15/15/15/15/15/15/15/15/15"
+	tests := []string{
+		"",
+		empty + " ABC 0 0",
+		empty + " A/B/C/D/E 0/0/0/0/0 0",
+		empty + " A/B 0 0",
+		empty + " A/B 0/x 0",
+		empty + " A/B 0/0 2",
+		empty + " A/B 0/0 0 CHESS",
+		empty + " ABCDEFGH/B 0/0 0",
+		empty + " a/B 0/0 0",
+		"15/15/15 A/B 0/0 0",
+		"14/15/15/15/15/15/15/15/15/15/15/15/15/15/15 A/B 0/0 0",
+		"15/15/15/15/15/15/15/15/15/15/15/15/15/15/16 A/B 0/0 0",
+		"15/15/15/15/15/15/15/0ABCDEFGHIJKLMNO/15/15/15/15/15/15/15 A/B 0/0 0",
+		"15/15/15/15/15/15/15/7C*6/15/15/15/15/15/15/15 A/B 0/0 0",
+		"15/15/15/15/15/15/15/7ZZ6/15/15/15/15/15/15/15 A/B 0/0 0",
+		empty + " ???/B 0/0 0",
+	}
+	for _, s := range tests {
+		if _, err := ParsePosition(s); !errors.Is(err, ErrInvalidNotation) {
+			t.Errorf("ParsePosition(%q) should fail with ErrInvalidNotation, got %v", s, err)
+		}
+	}
+}
+
+// TestGamePositionRoundTrip tests taking a position from a game and rebuilding a game from it
+func TestGamePositionRoundTrip(t *testing.T) {
+	g := newTestGame(t, 2)
+	// Deal the rack from the bag so the position's tile counts stay whole
+	if err := g.TileBag.stack(rackOf("CATSDOG")); err != nil {
+		t.Fatalf("Stacking the bag failed: %v", err)
+	}
+	g.StartGame()
+	move, _ := g.Board.BuildMove("p1", "CAT", mustPos(t, "H8"), Horizontal, nil)
+	if _, err := g.PlayMove(move); err != nil {
+		t.Fatalf("PlayMove failed: %v", err)
+	}
+
+	notation := g.Position().Encode()
+	pos, err := ParsePosition(notation)
+	if err != nil {
+		t.Fatalf("ParsePosition failed on %q: %v", notation, err)
+	}
+
+	rebuilt, err := NewGameFromPosition("copy", pos)
+	if err != nil {
+		t.Fatalf("NewGameFromPosition failed: %v", err)
+	}
+	if rebuilt.State != InProgress || rebuilt.CurrentTurn != 1 {
+		t.Errorf("Rebuilt game should be in progress with p2 to move")
+	}
+	if rebuilt.Players[0].Score != g.Players[0].Score {
+		t.Errorf("Scores should carry over, got %d", rebuilt.Players[0].Score)
+	}
+	if rebuilt.TileBag.RemainingCount() != g.TileBag.RemainingCount() {
+		t.Errorf("Bag should hold the unseen tiles: %d, want %d", rebuilt.TileBag.RemainingCount(), g.TileBag.RemainingCount())
+	}
+	if len(rebuilt.History) != 0 || rebuilt.EventCount() != 0 || rebuilt.CanUndo() {
+		t.Errorf("Rebuilt game should have no history, events or undo")
+	}
+	if got := rebuilt.Position().Encode(); got != notation {
+		t.Errorf("Rebuilt game should be at the same position:\n got %s\nwant %s", got, notation)
+	}
+}
+
+// TestParseRack tests reading a rack in either case with variant tile values
+func TestParseRack(t *testing.T) {
+	rack, err := ParseRack("qa?", WordsWithFriends)
+	if err != nil {
+		t.Fatalf("ParseRack failed: %v", err)
+	}
+	if len(rack) != 3 || rack[0].Letter != 'Q' || rack[0].Points != WordsWithFriends.TileValue('Q') || !rack[2].IsBlank {
+		t.Errorf("Should read Q, A and a blank, got %v", rack)
+	}
+	for _, s := range []string{"", "A1", "ABCDEFGH"} {
+		if _, err := ParseRack(s, Classic); !errors.Is(err, ErrInvalidNotation) {
+			t.Errorf("ParseRack(%q) should fail with ErrInvalidNotation, got %v", s, err)
+		}
+	}
+}
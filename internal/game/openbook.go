@@ -0,0 +1,118 @@
+package game
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultBookDefense is the equity an opening book gives up per point of
+// premium access a first play opens to the opponent
+const DefaultBookDefense = 1.0
+
+// OpeningBook caches the best first play for each rack
+// Plays are ranked by equity less Defense for each point of premium access
+// they open (see premiumAccess), so among similar plays the book prefers the
+// placement that keeps the opponent off the premium squares. Racks are looked
+// up by LeaveKey, and plays are worked out the first time a rack is seen or
+// ahead of time with Precompute. A book serves one board layout; use a book
+// per variant. It is safe for concurrent use.
+type OpeningBook struct {
+	Defense   float64 // Equity given up per point of premium access
+	generator *MoveGenerator
+	leave     *LeaveEvaluator
+	plays     map[string]bookPlay
+	mu        sync.RWMutex
+}
+
+// bookPlay is a book entry; found is false for racks with no play
+type bookPlay struct {
+	move  Move
+	found bool
+}
+
+// NewOpeningBook creates an empty book finding plays with the generator and
+// valuing leaves with leave, or the default leave values when leave is nil
+func NewOpeningBook(generator *MoveGenerator, leave *LeaveEvaluator) *OpeningBook {
+	if leave == nil {
+		leave = NewLeaveEvaluator()
+	}
+	return &OpeningBook{
+		Defense:   DefaultBookDefense,
+		generator: generator,
+		leave:     leave,
+		plays:     make(map[string]bookPlay),
+	}
+}
+
+// Lookup returns the book play for a rack on an empty board, working it out
+// if the rack has not been seen before
+// Returns false if the board is not empty or the rack has no play.
+func (ob *OpeningBook) Lookup(board *Board, rack []Tile) (Move, bool) {
+	if !board.IsFirstMove() {
+		return Move{}, false
+	}
+	key := LeaveKey(rack)
+
+	ob.mu.RLock()
+	play, ok := ob.plays[key]
+	ob.mu.RUnlock()
+	if !ok {
+		play = ob.best(board, rack)
+		ob.mu.Lock()
+		ob.plays[key] = play
+		ob.mu.Unlock()
+	}
+	return play.move, play.found
+}
+
+// Precompute works out the book plays for racks not yet in the book, on the
+// empty board given; it stops early if ctx is cancelled
+func (ob *OpeningBook) Precompute(ctx context.Context, board *Board, racks [][]Tile) error {
+	for _, rack := range racks {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		ob.Lookup(board, rack)
+	}
+	return nil
+}
+
+// Len returns the number of racks in the book
+func (ob *OpeningBook) Len() int {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+
+	return len(ob.plays)
+}
+
+// best finds the play for a rack with the highest equity after the defense
+// penalty
+func (ob *OpeningBook) best(board *Board, rack []Tile) bookPlay {
+	var play bookPlay
+	var bestValue float64
+	for _, r := range ob.leave.Rank(rack, ob.generator.GenerateMoves(board, rack)) {
+		value := r.Equity - ob.Defense*float64(premiumAccess(board, r.Move))
+		if !play.found || value > bestValue {
+			play, bestValue = bookPlay{move: r.Move, found: true}, value
+		}
+	}
+	return play
+}
+
+// premiumAccess measures the premium squares a move opens to the opponent:
+// each empty premium square next to a tile it places, weighted by premiumWeight
+func premiumAccess(board *Board, move Move) int {
+	placed := placedPositions(move)
+	seen := make(map[Position]bool)
+	access := 0
+	for _, t := range move.Tiles {
+		for _, pos := range board.GetAdjacentPositions(t.Position) {
+			if placed[pos] || seen[pos] || !board.IsEmpty(pos) {
+				continue
+			}
+			seen[pos] = true
+			access += premiumWeight(board.GetPremiumType(pos))
+		}
+	}
+	return access
+}
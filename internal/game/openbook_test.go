@@ -0,0 +1,95 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// TestPremiumAccess tests weighing the premium squares a move opens
+func TestPremiumAccess(t *testing.T) {
+	board := NewBoard()
+	move := Move{Tiles: []PlacedTile{
+		{Tile: Tile{Letter: 'C'}, Position: Position{Row: 7, Col: 6}},
+		{Tile: Tile{Letter: 'A'}, Position: Position{Row: 7, Col: 7}},
+		{Tile: Tile{Letter: 'T'}, Position: Position{Row: 7, Col: 8}},
+	}}
+	// G7, I7, G9 and I9 are double letters
+	if got := premiumAccess(board, move); got != 4 {
+		t.Errorf("Expected access 4, got %d", got)
+	}
+
+	board.PlaceTile(Tile{Letter: 'S'}, Position{Row: 6, Col: 6})
+	if got := premiumAccess(board, move); got != 3 {
+		t.Errorf("Occupied squares open nothing, expected 3, got %d", got)
+	}
+}
+
+// TestOpeningBook tests looking up and caching first plays
+func TestOpeningBook(t *testing.T) {
+	mg := NewMoveGenerator(testWords)
+	book := NewOpeningBook(mg, nil)
+	board := NewBoard()
+
+	move, ok := book.Lookup(board, rackOf("CATSDOG"))
+	if !ok || len(move.Tiles) == 0 {
+		t.Fatalf("Should find a first play")
+	}
+	if again, _ := book.Lookup(board, rackOf("DOGSCAT")); book.Len() != 1 || again.Word != move.Word || again.Start != move.Start {
+		t.Errorf("The same tiles in another order should share the entry, got %d entries", book.Len())
+	}
+	if _, ok := book.Lookup(board, rackOf("XYZ")); ok || book.Len() != 2 {
+		t.Errorf("A rack with no play should be remembered as such")
+	}
+
+	played := board.Clone()
+	played.PlaceTile(Tile{Letter: 'A', Points: 1}, Position{Row: 7, Col: 7})
+	if _, ok := book.Lookup(played, rackOf("CATSDOG")); ok {
+		t.Errorf("The book only covers first plays")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := book.Precompute(ctx, board, [][]Tile{rackOf("GOD")}); !errors.Is(err, context.Canceled) || book.Len() != 2 {
+		t.Errorf("A cancelled precompute should stop, got %v", err)
+	}
+	if err := book.Precompute(context.Background(), board, [][]Tile{rackOf("GOD"), rackOf("TA")}); err != nil || book.Len() != 4 {
+		t.Errorf("Precompute should add both racks, got %v with %d entries", err, book.Len())
+	}
+}
+
+// TestOpeningBookDefense tests preferring plays that open fewer premiums
+func TestOpeningBookDefense(t *testing.T) {
+	mg := NewMoveGenerator(testWords)
+	board := NewBoard()
+	rack := rackOf("CATSDOG")
+	ranked := NewLeaveEvaluator().Rank(rack, mg.GenerateMoves(board, rack))
+
+	book := NewOpeningBook(mg, nil)
+	book.Defense = 0
+	if move, _ := book.Lookup(board, rack); NewLeaveEvaluator().Equity(rack, move) != ranked[0].Equity {
+		t.Errorf("Without defense the book should play for equity alone")
+	}
+
+	book = NewOpeningBook(mg, nil)
+	book.Defense = 1000
+	least := premiumAccess(board, ranked[0].Move)
+	for _, r := range ranked {
+		least = min(least, premiumAccess(board, r.Move))
+	}
+	if move, _ := book.Lookup(board, rack); premiumAccess(board, move) != least {
+		t.Errorf("A defensive book should open the fewest premiums (%d), got %d", least, premiumAccess(board, move))
+	}
+}
+
+// TestBotOpeningBook tests an equity bot consulting its book
+func TestBotOpeningBook(t *testing.T) {
+	mg := NewMoveGenerator(testWords)
+	bot := NewBot("p1", Equity, mg)
+	bot.Book = NewOpeningBook(mg, nil)
+
+	move, ok := bot.ChooseMove(NewBoard(), rackOf("CATSDOG"))
+	if !ok || move.PlayerID != "p1" || bot.Book.Len() != 1 {
+		t.Errorf("The bot should play from the book as p1, got %+v with %d entries", move, bot.Book.Len())
+	}
+}
@@ -0,0 +1,312 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Errors returned when pausing and resuming games
+var (
+	ErrGamePaused       = errors.New("game is paused")
+	ErrNotPaused        = errors.New("game is not paused")
+	ErrPauseNotAllowed  = errors.New("pause not allowed")
+	ErrNoPauseRequested = errors.New("no pause has been requested")
+)
+
+// PauseRules lets long-running games be paused, freezing the clocks,
+// abandonment and expiry deadlines
+// Players may pause together by agreement, and each player may also go on
+// vacation alone, drawing on an allowance for the whole game.
+type PauseRules struct {
+	Vacation time.Duration `json:"vacation"`  // Vacation allowance per player (0 for none)
+	MaxPause time.Duration `json:"max_pause"` // Longest single pause of either kind
+}
+
+// Pause describes a pause in progress
+type Pause struct {
+	PlayerID string    `json:"player_id,omitempty"` // Player on vacation ("" when agreed by everyone)
+	Start    time.Time `json:"start"`
+	Until    time.Time `json:"until"` // When play resumes if no one resumes it sooner
+}
+
+// PauseRequest is a proposed pause awaiting the agreement of every active player
+type PauseRequest struct {
+	PlayerID string        `json:"player_id"` // Player who proposed the pause
+	Duration time.Duration `json:"duration"`
+	Agreed   []string      `json:"agreed"` // Players who have agreed so far, the proposer first
+}
+
+// copyPause returns a copy of a pause, or nil
+func copyPause(p *Pause) *Pause {
+	if p == nil {
+		return nil
+	}
+	cp := *p
+	return &cp
+}
+
+// copyPauseRequest returns a deep copy of a pause request, or nil
+func copyPauseRequest(r *PauseRequest) *PauseRequest {
+	if r == nil {
+		return nil
+	}
+	cp := *r
+	cp.Agreed = append([]string(nil), r.Agreed...)
+	return &cp
+}
+
+// copyVacations returns a copy of the vacation time each player has used
+func copyVacations(used map[string]time.Duration) map[string]time.Duration {
+	if used == nil {
+		return nil
+	}
+	cp := make(map[string]time.Duration, len(used))
+	for id, d := range used {
+		cp[id] = d
+	}
+	return cp
+}
+
+// TakeVacation pauses the game for up to d on behalf of one player, who need
+// not be the one to move
+// The time actually spent paused is charged to the player's allowance when
+// play resumes.
+func (g *Game) TakeVacation(playerID string, d time.Duration) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := g.checkPause(playerID, d); err != nil {
+		return err
+	}
+	if left := g.vacationLeft(playerID); d > left {
+		return fmt.Errorf("%w: %v of vacation requested, %v left", ErrPauseNotAllowed, d, left)
+	}
+	now := time.Now()
+	g.pause(&Pause{PlayerID: playerID, Start: now}, d)
+	g.record(Event{Type: VacationTaken, Timestamp: now, PlayerID: playerID, Duration: d})
+	g.touchPaused()
+	return nil
+}
+
+// RequestPause proposes pausing the game for d; the pause begins once every
+// other active player has agreed with AgreePause
+// Computer opponents never agree, so games against them can only be paused
+// for vacations.
+func (g *Game) RequestPause(playerID string, d time.Duration) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := g.checkPause(playerID, d); err != nil {
+		return err
+	}
+	if g.PauseRequest != nil {
+		return fmt.Errorf("%w: %s has already proposed a pause", ErrPauseNotAllowed, g.PauseRequest.PlayerID)
+	}
+	g.PauseRequest = &PauseRequest{PlayerID: playerID, Duration: d, Agreed: []string{playerID}}
+	g.record(Event{Type: PauseRequested, PlayerID: playerID, Duration: d})
+	g.touch()
+	return nil
+}
+
+// AgreePause accepts the proposed pause, starting it if the player was the
+// last to agree
+func (g *Game) AgreePause(playerID string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := g.checkPauseRequest(playerID); err != nil {
+		return err
+	}
+	req := g.PauseRequest
+	if !slices.Contains(req.Agreed, playerID) {
+		req.Agreed = append(req.Agreed, playerID)
+	}
+	now := time.Now()
+	g.record(Event{Type: PauseAgreed, Timestamp: now, PlayerID: playerID})
+
+	for _, p := range g.Players {
+		if p.IsActive && !slices.Contains(req.Agreed, p.ID) {
+			g.touch()
+			return nil
+		}
+	}
+	g.PauseRequest = nil
+	g.pause(&Pause{Start: now}, req.Duration)
+	g.touchPaused()
+	return nil
+}
+
+// DeclinePause rejects the proposed pause; the proposer may decline it to
+// withdraw it
+func (g *Game) DeclinePause(playerID string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := g.checkPauseRequest(playerID); err != nil {
+		return err
+	}
+	g.PauseRequest = nil
+	g.record(Event{Type: PauseDeclined, PlayerID: playerID})
+	g.touch()
+	return nil
+}
+
+// Resume ends the pause early
+// Any player may end a pause agreed by everyone, but only the player on
+// vacation may end their vacation.
+func (g *Game) Resume(playerID string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.findPlayer(playerID) == nil {
+		return ErrPlayerNotFound
+	}
+	if g.Paused == nil {
+		return ErrNotPaused
+	}
+	if g.Paused.PlayerID != "" && g.Paused.PlayerID != playerID {
+		return fmt.Errorf("%w: only %s may end their vacation", ErrPauseNotAllowed, g.Paused.PlayerID)
+	}
+	g.resume(playerID, time.Now())
+	return nil
+}
+
+// CheckPause resumes play once the pause has run its course; it returns true
+// if the game was resumed
+// Like CheckTime, servers should call it periodically.
+func (g *Game) CheckPause() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := time.Now()
+	if g.Paused == nil || now.Before(g.Paused.Until) {
+		return false
+	}
+	g.resume("", now)
+	return true
+}
+
+// unpause ends the pause at a recorded time, as when replaying a resumption
+func (g *Game) unpause(playerID string, at time.Time) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.Paused == nil {
+		return ErrNotPaused
+	}
+	g.resume(playerID, at)
+	return nil
+}
+
+// IsPaused returns true while the game is paused
+func (g *Game) IsPaused() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.Paused != nil
+}
+
+// VacationLeft returns how much of a player's vacation allowance remains
+func (g *Game) VacationLeft(playerID string) (time.Duration, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if g.findPlayer(playerID) == nil {
+		return 0, ErrPlayerNotFound
+	}
+	return g.vacationLeft(playerID), nil
+}
+
+// vacationLeft returns the unused part of a player's allowance; callers must hold the lock
+func (g *Game) vacationLeft(playerID string) time.Duration {
+	if g.Pauses == nil {
+		return 0
+	}
+	if left := g.Pauses.Vacation - g.VacationUsed[playerID]; left > 0 {
+		return left
+	}
+	return 0
+}
+
+// checkPause returns an error unless playerID may start a pause of d; callers
+// must hold the lock
+func (g *Game) checkPause(playerID string, d time.Duration) error {
+	if g.State != InProgress {
+		return ErrGameNotInProgress
+	}
+	if g.findPlayer(playerID) == nil {
+		return ErrPlayerNotFound
+	}
+	if g.Pauses == nil {
+		return fmt.Errorf("%w: the rules allow no pauses", ErrPauseNotAllowed)
+	}
+	if g.Paused != nil {
+		return ErrGamePaused
+	}
+	if d <= 0 || d > g.Pauses.MaxPause {
+		return fmt.Errorf("%w: pauses last up to %v, got %v", ErrPauseNotAllowed, g.Pauses.MaxPause, d)
+	}
+	return nil
+}
+
+// checkPauseRequest returns an error unless playerID may answer the pause
+// request; callers must hold the lock
+func (g *Game) checkPauseRequest(playerID string) error {
+	if g.State != InProgress {
+		return ErrGameNotInProgress
+	}
+	if g.findPlayer(playerID) == nil {
+		return ErrPlayerNotFound
+	}
+	if g.PauseRequest == nil {
+		return ErrNoPauseRequested
+	}
+	return nil
+}
+
+// pause freezes the game for up to d from p.Start, stopping the running clock
+// without the increment; callers must hold the lock
+func (g *Game) pause(p *Pause, d time.Duration) {
+	p.Until = p.Start.Add(d)
+	g.Paused = p
+	if g.Clock != nil {
+		g.Clock.stop(false)
+	}
+}
+
+// resume ends the pause at the given time, charging a vacation to the player
+// who took it and restarting the clock of the player to move; callers must
+// hold the lock
+func (g *Game) resume(playerID string, at time.Time) {
+	p := g.Paused
+	if p.PlayerID != "" {
+		used := at.Sub(p.Start)
+		if at.After(p.Until) {
+			used = p.Until.Sub(p.Start)
+		}
+		if g.VacationUsed == nil {
+			g.VacationUsed = make(map[string]time.Duration)
+		}
+		g.VacationUsed[p.PlayerID] += used
+	}
+	g.Paused = nil
+	g.turnStarted = at
+	g.switchClock(false)
+	g.record(Event{Type: GameResumed, Timestamp: at, PlayerID: playerID})
+	g.touch()
+}
+
+// touchPaused records a change that started a pause, pushing the expiry back
+// past the end of the pause; callers must hold the lock
+func (g *Game) touchPaused() {
+	g.touch()
+	g.ExpiresAt = g.Paused.Until.Add(DefaultGameExpiration)
+}
+
+// endPause drops any pause or pause request when the game ends; callers must hold the lock
+func (g *Game) endPause() {
+	g.Paused = nil
+	g.PauseRequest = nil
+}
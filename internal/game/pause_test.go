@@ -0,0 +1,239 @@
+package game
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// newPauseGame creates a started, timed game between n players who may each
+// take two days of vacation in pauses of up to a day
+func newPauseGame(t *testing.T, n int) *Game {
+	t.Helper()
+	rules := DefaultRules()
+	rules.Pauses = &PauseRules{Vacation: 48 * time.Hour, MaxPause: 24 * time.Hour}
+	rules.AbandonAfter = time.Hour
+	tc := TournamentTimeControl()
+	rules.TimeControl = &tc
+
+	var players []*Player
+	for i := 1; i <= n; i++ {
+		id := string(rune('0' + i))
+		players = append(players, NewPlayer("p"+id, "Player "+id))
+	}
+	g, err := NewGameWithRules("pause", players, rules)
+	if err != nil {
+		t.Fatalf("NewGameWithRules failed: %v", err)
+	}
+	if err := g.StartGame(); err != nil {
+		t.Fatalf("StartGame failed: %v", err)
+	}
+	return g
+}
+
+// TestPauseRulesValidate tests rejecting unusable pause rules
+func TestPauseRulesValidate(t *testing.T) {
+	rules := DefaultRules()
+	rules.Pauses = &PauseRules{Vacation: time.Hour}
+	if err := rules.Validate(); !errors.Is(err, ErrInvalidRules) {
+		t.Errorf("Pauses need a maximum length, got %v", err)
+	}
+	rules.Pauses = &PauseRules{Vacation: -time.Hour, MaxPause: time.Hour}
+	if err := rules.Validate(); !errors.Is(err, ErrInvalidRules) {
+		t.Errorf("A negative allowance should be rejected, got %v", err)
+	}
+
+	rules = DefaultRules()
+	rules.Solo = &SoloRules{Duration: time.Minute}
+	rules.Pauses = &PauseRules{MaxPause: time.Hour}
+	if err := rules.Validate(); !errors.Is(err, ErrInvalidRules) {
+		t.Errorf("Solo games should not be pausable, got %v", err)
+	}
+
+	g, _ := NewGame("plain", []*Player{NewPlayer("p1", "Ann"), NewPlayer("p2", "Bob")})
+	g.StartGame()
+	if err := g.TakeVacation("p1", time.Hour); !errors.Is(err, ErrPauseNotAllowed) {
+		t.Errorf("Games without pause rules cannot be paused, got %v", err)
+	}
+}
+
+// TestTakeVacation tests that a vacation freezes the game and uses up the allowance
+func TestTakeVacation(t *testing.T) {
+	g := newPauseGame(t, 2)
+
+	if err := g.TakeVacation("p2", 25*time.Hour); !errors.Is(err, ErrPauseNotAllowed) {
+		t.Errorf("Should refuse a pause longer than the maximum, got %v", err)
+	}
+	if err := g.TakeVacation("p9", time.Hour); !errors.Is(err, ErrPlayerNotFound) {
+		t.Errorf("Expected ErrPlayerNotFound, got %v", err)
+	}
+	// p1 is to move, but anyone may go on vacation
+	if err := g.TakeVacation("p2", 12*time.Hour); err != nil {
+		t.Fatalf("TakeVacation failed: %v", err)
+	}
+	if !g.IsPaused() || g.Paused.PlayerID != "p2" || g.Paused.Until.Sub(g.Paused.Start) != 12*time.Hour {
+		t.Fatalf("Should pause the game for p2, got %+v", g.Paused)
+	}
+	if g.Clock.Running != "" {
+		t.Errorf("Should stop the clocks, %s is running", g.Clock.Running)
+	}
+	if !g.ExpiresAt.After(g.Paused.Until) {
+		t.Errorf("Should not expire before the pause ends, expires %v", g.ExpiresAt)
+	}
+	if err := g.PassTurn("p1"); !errors.Is(err, ErrGamePaused) {
+		t.Errorf("Should refuse turns while paused, got %v", err)
+	}
+	if err := g.TakeVacation("p1", time.Hour); !errors.Is(err, ErrGamePaused) {
+		t.Errorf("Should refuse a second pause, got %v", err)
+	}
+	g.turnStarted = time.Now().Add(-2 * time.Hour)
+	if absent := g.CheckAbandonment(); absent != "" {
+		t.Errorf("A paused game should not be abandoned, got %q", absent)
+	}
+
+	if err := g.Resume("p1"); !errors.Is(err, ErrPauseNotAllowed) {
+		t.Errorf("Only p2 should end their vacation, got %v", err)
+	}
+	if err := g.Resume("p2"); err != nil {
+		t.Fatalf("Resume failed: %v", err)
+	}
+	if g.IsPaused() || g.Clock.Running != "p1" {
+		t.Errorf("Should restart p1's clock, running %q", g.Clock.Running)
+	}
+	if left, _ := g.VacationLeft("p2"); left <= 47*time.Hour || left > 48*time.Hour {
+		t.Errorf("Should charge only the time paused, %v left", left)
+	}
+	if err := g.Resume("p2"); !errors.Is(err, ErrNotPaused) {
+		t.Errorf("Expected ErrNotPaused, got %v", err)
+	}
+
+	// A vacation that runs its course is charged in full
+	g.TakeVacation("p2", 24*time.Hour)
+	if g.CheckPause() {
+		t.Errorf("Should not resume before the pause ends")
+	}
+	g.Paused.Start = g.Paused.Start.Add(-25 * time.Hour)
+	g.Paused.Until = g.Paused.Until.Add(-25 * time.Hour)
+	if !g.CheckPause() || g.IsPaused() {
+		t.Fatalf("Should resume once the pause ends")
+	}
+	if left, _ := g.VacationLeft("p2"); left <= 23*time.Hour || left > 24*time.Hour {
+		t.Errorf("Should charge the whole day, %v left", left)
+	}
+	if err := g.TakeVacation("p2", 24*time.Hour); !errors.Is(err, ErrPauseNotAllowed) {
+		t.Errorf("Should refuse more vacation than is left, got %v", err)
+	}
+	if err := g.PassTurn("p1"); err != nil {
+		t.Errorf("Play should go on after the pause, got %v", err)
+	}
+}
+
+// TestRequestPause tests pausing by agreement of every player
+func TestRequestPause(t *testing.T) {
+	g := newPauseGame(t, 3)
+
+	if err := g.AgreePause("p2"); !errors.Is(err, ErrNoPauseRequested) {
+		t.Errorf("Expected ErrNoPauseRequested, got %v", err)
+	}
+	if err := g.RequestPause("p1", 6*time.Hour); err != nil {
+		t.Fatalf("RequestPause failed: %v", err)
+	}
+	if err := g.RequestPause("p3", time.Hour); !errors.Is(err, ErrPauseNotAllowed) {
+		t.Errorf("Should refuse a second request, got %v", err)
+	}
+	g.AgreePause("p2")
+	if g.IsPaused() {
+		t.Fatalf("Should wait for p3 to agree")
+	}
+	if err := g.AgreePause("p3"); err != nil {
+		t.Fatalf("AgreePause failed: %v", err)
+	}
+	if !g.IsPaused() || g.Paused.PlayerID != "" || g.PauseRequest != nil {
+		t.Fatalf("Should pause the game for everyone, got %+v", g.Paused)
+	}
+	if err := g.Resume("p3"); err != nil {
+		t.Errorf("Anyone may end an agreed pause, got %v", err)
+	}
+	for _, id := range []string{"p1", "p2", "p3"} {
+		if left, _ := g.VacationLeft(id); left != 48*time.Hour {
+			t.Errorf("An agreed pause should not use %s's vacation, %v left", id, left)
+		}
+	}
+
+	g.RequestPause("p2", time.Hour)
+	if err := g.DeclinePause("p3"); err != nil {
+		t.Fatalf("DeclinePause failed: %v", err)
+	}
+	if g.PauseRequest != nil || g.IsPaused() {
+		t.Errorf("Declining should drop the request")
+	}
+
+	g.TakeVacation("p1", time.Hour)
+	if err := g.Resign("p2"); err != nil {
+		t.Fatalf("Resign failed: %v", err)
+	}
+	if g.IsPaused() {
+		t.Errorf("A finished game should not stay paused")
+	}
+}
+
+// TestPauseSaveAndReplay tests that pauses survive saving and replay
+func TestPauseSaveAndReplay(t *testing.T) {
+	g := newPauseGame(t, 2)
+	g.TakeVacation("p1", time.Hour)
+	g.Resume("p1")
+	g.PassTurn("p1")
+	g.RequestPause("p2", 3*time.Hour)
+	g.AgreePause("p1")
+
+	data, err := g.Serialize()
+	if err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	loaded, err := LoadGame(data)
+	if err != nil {
+		t.Fatalf("LoadGame failed: %v", err)
+	}
+	if loaded.Paused == nil || !loaded.Paused.Until.Equal(g.Paused.Until) || loaded.VacationUsed["p1"] != g.VacationUsed["p1"] {
+		t.Errorf("Should load the pause and vacation used, got %+v %v", loaded.Paused, loaded.VacationUsed)
+	}
+	if loaded.Rules().Pauses == nil || *loaded.Rules().Pauses != *g.Pauses {
+		t.Errorf("Rules should report the pause rules, got %+v", loaded.Rules().Pauses)
+	}
+
+	replayed, err := Replay(g.Events(), -1)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if replayed.Paused == nil || *replayed.Paused != *g.Paused {
+		t.Errorf("Replay should reproduce the pause %+v, got %+v", g.Paused, replayed.Paused)
+	}
+	if replayed.VacationUsed["p1"] != g.VacationUsed["p1"] {
+		t.Errorf("Replay should charge %v of vacation, got %v", g.VacationUsed["p1"], replayed.VacationUsed["p1"])
+	}
+	if !replayed.ExpiresAt.Equal(g.ExpiresAt) {
+		t.Errorf("Replay should expire at %v, got %v", g.ExpiresAt, replayed.ExpiresAt)
+	}
+}
+
+// TestGameManagerCheckPauses tests resuming managed games whose pause has ended
+func TestGameManagerCheckPauses(t *testing.T) {
+	m := NewGameManager(1)
+	defer m.Close()
+
+	for _, id := range []string{"over", "paused", "playing"} {
+		g := newPauseGame(t, 2)
+		g.ID = id
+		if id != "playing" {
+			g.TakeVacation("p1", time.Hour)
+		}
+		if id == "over" {
+			g.Paused.Until = time.Now().Add(-time.Minute)
+		}
+		m.Add(g)
+	}
+
+	if got := m.CheckPauses(); len(got) != 1 || got[0] != "over" {
+		t.Errorf("Only the game whose pause is over should resume, got %v", got)
+	}
+}
@@ -0,0 +1,163 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SnapshotVersion is the current version of the saved game format
+// Version 2 records the bingo bonus, rack size and exchange minimum.
+const SnapshotVersion = 2
+
+// gameSnapshot is the complete persisted state of a game
+type gameSnapshot struct {
+	Version       int                      `json:"version"`
+	ID            string                   `json:"id"`
+	Board         *Board                   `json:"board"`
+	Players       []*Player                `json:"players"`
+	Bag           []Tile                   `json:"bag"`
+	CurrentTurn   int                      `json:"current_turn"`
+	State         GameState                `json:"state"`
+	History       History                  `json:"history"`
+	Scoreless     int                      `json:"scoreless_turns"`
+	ChallengeRule ChallengeRule            `json:"challenge_rule"`
+	ExchangeMin   int                      `json:"exchange_minimum"` // Tiles that must remain in the bag to exchange
+	Challengeable bool                     `json:"challengeable"`
+	LosesTurn     []string                 `json:"loses_turn,omitempty"`
+	CreatedAt     time.Time                `json:"created_at"`
+	LastActivity  time.Time                `json:"last_activity"`
+	TurnStarted   time.Time                `json:"turn_started,omitempty"` // When the player to move began their turn
+	ExpiresAt     time.Time                `json:"expires_at"`
+	Clock         *Clock                   `json:"clock,omitempty"`
+	Revision      int64                    `json:"revision"`
+	EventCount    int                      `json:"event_count"`               // Length of the event stream when saved
+	Feedback      []MoveFeedback           `json:"feedback,omitempty"`        // Coaching feedback given so far
+	Annotations   []Annotation             `json:"annotations,omitempty"`     // Notes on moves in the history
+	Dictionary    string                   `json:"dictionary_name,omitempty"` // Registry name of the attached dictionary
+	Teams         *TeamRules               `json:"teams,omitempty"`           // Team play settings
+	Solo          *SoloRules               `json:"solo,omitempty"`            // Solo practice settings
+	AbandonAfter  time.Duration            `json:"abandon_after,omitempty"`   // Time on one turn after which the player to move forfeits
+	Forfeit       *Forfeit                 `json:"forfeit,omitempty"`         // Who lost the game by resigning or abandoning it
+	Pauses        *PauseRules              `json:"pauses,omitempty"`          // Pauses allowed
+	Paused        *Pause                   `json:"paused,omitempty"`          // Pause in progress
+	PauseRequest  *PauseRequest            `json:"pause_request,omitempty"`   // Pause awaiting agreement
+	VacationUsed  map[string]time.Duration `json:"vacation_used,omitempty"`   // Vacation time taken by each player
+}
+
+// Serialize produces a complete JSON snapshot of the game
+// The snapshot includes the board, racks, bag contents (in draw order), scores,
+// history, coaching feedback, annotations and whose turn it is. Attached dictionaries, bots
+// and coaching settings are not saved, though the registry name of the
+// dictionary is.
+func (g *Game) Serialize() ([]byte, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return json.Marshal(g.captureState())
+}
+
+// LoadGame reconstructs a game from a snapshot produced by Serialize
+// Snapshots from earlier versions are migrated first, and the restored game is
+// validated before it is returned.
+func LoadGame(data []byte) (*Game, error) {
+	data, err := MigrateSnapshot(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var snap gameSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("failed to parse game snapshot: %w", err)
+	}
+	if snap.Board == nil {
+		return nil, errors.New("snapshot has no board")
+	}
+
+	g := &Game{}
+	g.restoreState(snap)
+	g.Clock = copyClock(snap.Clock)
+	g.Paused = snap.Paused
+	g.PauseRequest = snap.PauseRequest
+	g.VacationUsed = snap.VacationUsed
+	g.DictionaryName = snap.Dictionary
+	g.Revision = snap.Revision
+	g.savedRevision = snap.Revision
+	g.eventBase = snap.EventCount
+	g.turnStarted = snap.TurnStarted
+	if g.turnStarted.IsZero() {
+		g.turnStarted = snap.LastActivity
+	}
+	if g.Players == nil {
+		g.Players = []*Player{}
+	}
+
+	if err := g.validateState(); err != nil {
+		return nil, fmt.Errorf("invalid game snapshot: %w", err)
+	}
+
+	return g, nil
+}
+
+// SavedRevision returns the revision the game had when it was last loaded or
+// saved; stores compare it with their copy to detect concurrent updates
+func (g *Game) SavedRevision() int64 {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.savedRevision
+}
+
+// MarkSaved records that the given revision has been written to storage
+func (g *Game) MarkSaved(revision int64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.savedRevision = revision
+}
+
+// validateState checks the internal consistency of a restored game
+func (g *Game) validateState() error {
+	if err := g.Board.ValidateBoard(); err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool, len(g.Players))
+	for _, p := range g.Players {
+		if p == nil {
+			return errors.New("nil player")
+		}
+		if err := p.Validate(); err != nil {
+			return err
+		}
+		if seen[p.ID] {
+			return fmt.Errorf("duplicate player ID: %s", p.ID)
+		}
+		seen[p.ID] = true
+	}
+	if len(g.Players) > MaxPlayers {
+		return fmt.Errorf("too many players: %d", len(g.Players))
+	}
+
+	switch g.State {
+	case WaitingForPlayers, Finished:
+	case InProgress:
+		if g.CurrentTurn < 0 || g.CurrentTurn >= len(g.Players) {
+			return fmt.Errorf("current turn %d out of range", g.CurrentTurn)
+		}
+	default:
+		return fmt.Errorf("unknown game state: %d", g.State)
+	}
+
+	for row := 0; row < 15; row++ {
+		for col := 0; col < 15; col++ {
+			sq := g.Board.Grid[row][col]
+			if sq.Occupied != (sq.Tile != nil) {
+				return fmt.Errorf("square %s has inconsistent occupancy", Position{Row: row, Col: col}.String())
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package game
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// TestSerializeRoundTrip tests that a saved game restores to identical state
+func TestSerializeRoundTrip(t *testing.T) {
+	g := newTestGame(t, 2)
+	g.SetChallengeRule(DoubleChallenge)
+	g.StartGame()
+	g.Players[0].Rack = rackOf("CAT?XYZ")
+	move, _ := g.Board.BuildMove("p1", "CATS", mustPos(t, "G8"), Horizontal, []int{3})
+	if _, err := g.PlayMove(move); err != nil {
+		t.Fatalf("Setup move failed: %v", err)
+	}
+
+	data, err := g.Serialize()
+	if err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+
+	restored, err := LoadGame(data)
+	if err != nil {
+		t.Fatalf("LoadGame failed: %v", err)
+	}
+
+	if restored.ID != g.ID || restored.State != g.State || restored.CurrentTurn != g.CurrentTurn {
+		t.Errorf("Game metadata should match after restore")
+	}
+
+	if !reflect.DeepEqual(restored.Board.Grid, g.Board.Grid) {
+		t.Errorf("Board should match after restore")
+	}
+
+	if !reflect.DeepEqual(restored.TileBag.Tiles(), g.TileBag.Tiles()) {
+		t.Errorf("Bag contents and order should match after restore")
+	}
+
+	for i, p := range g.Players {
+		r := restored.Players[i]
+		if r.ID != p.ID || r.Score != p.Score || !reflect.DeepEqual(r.Rack, p.Rack) {
+			t.Errorf("Player %s should match after restore", p.ID)
+		}
+	}
+
+	if len(restored.History) != 1 || restored.History[0].Word != "CATS" || !restored.History[0].Timestamp.Equal(g.History[0].Timestamp) {
+		t.Errorf("History should match after restore")
+	}
+
+	if blank := restored.Board.GetTile(mustPos(t, "J8")); blank == nil || !blank.IsBlank || blank.Letter != 'S' {
+		t.Errorf("Blank assignment should survive restore, got %v", blank)
+	}
+
+	if restored.ChallengeRule != DoubleChallenge || !restored.CanChallenge() {
+		t.Errorf("Challenge state should survive restore")
+	}
+
+	// The restored game continues identically
+	expected := g.TileBag.Tiles()
+	restored.PassTurn("p2")
+	if restored.CurrentPlayer().ID != "p1" {
+		t.Errorf("Restored game should continue with the correct turn order")
+	}
+	drawn := restored.TileBag.DrawTiles(1)
+	if drawn[0] != expected[len(expected)-1] {
+		t.Errorf("Restored bag should draw the same next tile")
+	}
+}
+
+// TestSerializeAllStates tests saving games in each lifecycle state
+func TestSerializeAllStates(t *testing.T) {
+	waiting := newTestGame(t, 1)
+
+	started := newTestGame(t, 3)
+	started.StartGame()
+
+	finished := newTestGame(t, 2)
+	finished.StartGame()
+	finished.EndGame()
+
+	for _, g := range []*Game{waiting, started, finished} {
+		data, err := g.Serialize()
+		if err != nil {
+			t.Fatalf("Serialize failed for %s game: %v", g.State, err)
+		}
+		restored, err := LoadGame(data)
+		if err != nil {
+			t.Fatalf("LoadGame failed for %s game: %v", g.State, err)
+		}
+		if restored.State != g.State || len(restored.Players) != len(g.Players) {
+			t.Errorf("%s game should restore with the same state and players", g.State)
+		}
+		if restored.TileBag.RemainingCount() != g.TileBag.RemainingCount() {
+			t.Errorf("%s game should restore with the same bag size", g.State)
+		}
+	}
+}
+
+// TestLoadGameCorruption tests rejection of malformed and inconsistent snapshots
+func TestLoadGameCorruption(t *testing.T) {
+	g := newTestGame(t, 2)
+	g.StartGame()
+	data, _ := g.Serialize()
+
+	mutate := func(change func(snap map[string]interface{})) []byte {
+		var snap map[string]interface{}
+		json.Unmarshal(data, &snap)
+		change(snap)
+		out, _ := json.Marshal(snap)
+		return out
+	}
+
+	testCases := []struct {
+		name string
+		data []byte
+	}{
+		{"invalid json", []byte("{not json")},
+		{"future version", mutate(func(s map[string]interface{}) { s["version"] = SnapshotVersion + 1 })},
+		{"missing version", mutate(func(s map[string]interface{}) { delete(s, "version") })},
+		{"missing board", mutate(func(s map[string]interface{}) { delete(s, "board") })},
+		{"turn out of range", mutate(func(s map[string]interface{}) { s["current_turn"] = 5 })},
+		{"unknown state", mutate(func(s map[string]interface{}) { s["state"] = 42 })},
+		{"moved center", mutate(func(s map[string]interface{}) {
+			s["board"].(map[string]interface{})["center"] = map[string]interface{}{"row": 0, "col": 0}
+		})},
+		{"duplicate players", mutate(func(s map[string]interface{}) {
+			players := s["players"].([]interface{})
+			s["players"] = append(players, players[0])
+		})},
+	}
+
+	for _, tc := range testCases {
+		if _, err := LoadGame(tc.data); err == nil {
+			t.Errorf("%s: LoadGame should fail", tc.name)
+		}
+	}
+}
+
+// TestRevisionTracking tests that changes bump the revision and loading records it as saved
+func TestRevisionTracking(t *testing.T) {
+	g := newTestGame(t, 2)
+	before := g.Revision
+	g.StartGame()
+	g.PassTurn("p1")
+	if g.Revision != before+2 {
+		t.Errorf("Should bump the revision on each change, got %d from %d", g.Revision, before)
+	}
+	if g.SavedRevision() != 0 {
+		t.Errorf("New game should not have a saved revision, got %d", g.SavedRevision())
+	}
+
+	data, _ := g.Serialize()
+	restored, err := LoadGame(data)
+	if err != nil {
+		t.Fatalf("LoadGame failed: %v", err)
+	}
+	if restored.Revision != g.Revision || restored.SavedRevision() != g.Revision {
+		t.Errorf("Should restore revision %d as saved, got %d/%d", g.Revision, restored.Revision, restored.SavedRevision())
+	}
+
+	restored.MarkSaved(42)
+	if restored.SavedRevision() != 42 {
+		t.Errorf("MarkSaved should record the revision")
+	}
+}
@@ -0,0 +1,83 @@
+package game
+
+import (
+	"sort"
+
+	"scrabbled/internal/dictionary"
+)
+
+// Affixes a plausible phony adds to a real word
+var (
+	phonySuffixes = []string{"S", "ED", "ER", "ING", "Y"}
+	phonyPrefixes = []string{"RE", "UN"}
+)
+
+// phonies returns the formed words the dictionary does not hold, for a play
+// that stands until challenged; nil under the void rule, where such plays are
+// rejected, or without a dictionary. Callers must hold the lock.
+func (g *Game) phonies(words []FormedWord) []string {
+	if g.Dictionary == nil || g.ChallengeRule == VoidChallenge {
+		return nil
+	}
+	var invalid []string
+	for _, w := range words {
+		if !g.Dictionary.IsValid(w.Word) {
+			invalid = append(invalid, w.Word)
+		}
+	}
+	return invalid
+}
+
+// InventPhonies returns plausible phonies made from real words by adding a
+// common prefix or suffix, such as CATED or RECAT, in alphabetical order
+// Words already in the list are left out.
+func InventPhonies(words []string) []string {
+	known := make(map[string]bool, len(words))
+	for _, w := range words {
+		known[dictionary.Normalize(w)] = true
+	}
+
+	invented := make(map[string]bool)
+	for w := range known {
+		if len(w) < 2 {
+			continue
+		}
+		for _, suffix := range phonySuffixes {
+			if !known[w+suffix] {
+				invented[w+suffix] = true
+			}
+		}
+		for _, prefix := range phonyPrefixes {
+			if !known[prefix+w] {
+				invented[prefix+w] = true
+			}
+		}
+	}
+
+	phonies := make([]string, 0, len(invented))
+	for w := range invented {
+		phonies = append(phonies, w)
+	}
+	sort.Strings(phonies)
+	return phonies
+}
+
+// NewPhonyGenerator builds a move generator from real words plus the phonies
+// InventPhonies makes from them, for a bluffing bot
+func NewPhonyGenerator(words []string) *MoveGenerator {
+	return NewMoveGenerator(append(append([]string{}, words...), InventPhonies(words)...))
+}
+
+// bluff picks the highest scoring play forming a word the bot's generator
+// does not know, from the bot's phony generator
+// Returns false if the rack offers no phony.
+func (b *Bot) bluff(board *Board, rack []Tile) (Move, bool) {
+	for _, m := range b.Phonies.GenerateMoves(board, rack) {
+		for _, w := range board.FormedWords(m) {
+			if !b.generator.IsWord(w.Word) {
+				return m, true
+			}
+		}
+	}
+	return Move{}, false
+}
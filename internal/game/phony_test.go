@@ -0,0 +1,76 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestNewPhonyGenerator tests inventing plausible phonies from real words
+func TestNewPhonyGenerator(t *testing.T) {
+	phonies := NewPhonyGenerator(testWords)
+	for _, w := range []string{"CAT", "CATS", "CATED", "RECAT", "UNDOG", "DOGY"} {
+		if !phonies.IsWord(w) {
+			t.Errorf("Expected %s among the words and phonies", w)
+		}
+	}
+	if phonies.IsWord("DOGGY") || phonies.IsWord("CATSX") {
+		t.Errorf("Only words with a common affix should be invented")
+	}
+}
+
+// TestBluffingBot tests playing phonies at the configured rate
+func TestBluffingBot(t *testing.T) {
+	mg := NewMoveGenerator(testWords)
+	rack := rackOf("CATEDXV")
+
+	honest := NewBotWithSeed("p1", Equity, mg, 1)
+	honest.Phonies = NewPhonyGenerator(testWords)
+	for i := 0; i < 10; i++ {
+		move, _ := honest.ChooseMove(NewBoard(), rack)
+		for _, w := range NewBoard().FormedWords(move) {
+			if !mg.IsWord(w.Word) {
+				t.Fatalf("A bot with no bluff rate should not play %s", w.Word)
+			}
+		}
+	}
+
+	bluffer := NewBotWithSeed("p1", Equity, mg, 1)
+	bluffer.Phonies = honest.Phonies
+	bluffer.Bluff = 1
+	move, ok := bluffer.ChooseMove(NewBoard(), rack)
+	if !ok || move.PlayerID != "p1" {
+		t.Fatalf("Expected a move for p1, got %+v", move)
+	}
+	phony := false
+	for _, w := range NewBoard().FormedWords(move) {
+		phony = phony || !mg.IsWord(w.Word)
+	}
+	if !phony {
+		t.Errorf("A bot bluffing every turn should play a phony, got %s", move.Word)
+	}
+}
+
+// TestBluffingStrategy tests a bluffing strategy's phonies being recorded in a game
+func TestBluffingStrategy(t *testing.T) {
+	g := newChallengeGame(t)
+	mg := NewMoveGenerator(testWords)
+	g.AttachStrategy("p1", NewBluffingStrategy(mg, NewPhonyGenerator(testWords), 1))
+	g.Players[0].Rack = rackOf("CATEDXV")
+
+	moves, err := g.PlayBotTurns()
+	if err != nil || len(moves) != 1 {
+		t.Fatalf("Expected one bot move, got %d: %v", len(moves), err)
+	}
+	if last, _ := g.History.Last(); len(last.Phonies) == 0 {
+		t.Errorf("Expected the bluff recorded as a phony, got %+v", last)
+	}
+}
+
+// TestInventPhonies tests listing the invented phonies alone
+func TestInventPhonies(t *testing.T) {
+	phonies := InventPhonies([]string{"cat", "cats"})
+	expected := []string{"CATED", "CATER", "CATING", "CATSED", "CATSER", "CATSING", "CATSS", "CATSY", "CATY", "RECAT", "RECATS", "UNCAT", "UNCATS"}
+	if !reflect.DeepEqual(phonies, expected) {
+		t.Errorf("Expected %v, got %v", expected, phonies)
+	}
+}
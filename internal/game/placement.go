@@ -0,0 +1,74 @@
+package game
+
+import "sort"
+
+// HasPlacement returns true if the square holds a tile whose move is known
+func (s *Square) HasPlacement() bool {
+	return !s.IsEmpty() && s.PlayerID != ""
+}
+
+// markPlaced records the turn and player that placed tiles on the given squares
+func (b *Board) markPlaced(positions []Position, turn int, playerID string) {
+	for _, pos := range positions {
+		if sq := b.GetSquare(pos); sq != nil && sq.Occupied {
+			sq.Turn, sq.PlayerID = turn, playerID
+		}
+	}
+}
+
+// PlacedOn returns the squares holding tiles placed on the given turn, in
+// board order
+func (b *Board) PlacedOn(turn int) []Position {
+	var positions []Position
+	for _, pos := range b.GetOccupiedPositions() {
+		if sq := b.GetSquare(pos); sq.HasPlacement() && sq.Turn == turn {
+			positions = append(positions, pos)
+		}
+	}
+	return positions
+}
+
+// LastPlayed returns the squares of the most recent play still on the board,
+// for renderers to highlight, or nil if no tile's move is known
+func (b *Board) LastPlayed() []Position {
+	last := -1
+	for _, pos := range b.GetOccupiedPositions() {
+		if sq := b.GetSquare(pos); sq.HasPlacement() && sq.Turn > last {
+			last = sq.Turn
+		}
+	}
+	if last < 0 {
+		return nil
+	}
+	return b.PlacedOn(last)
+}
+
+// PlacedMoves reconstructs the plays whose tiles are on the board, in turn
+// order, from the placements recorded on its squares
+// Each move has its Type, PlayerID and Tiles set; scores, words and racks are
+// not recorded on the board. Tiles whose move is unknown are left out, as are
+// plays that were withdrawn, so the result is a partial history.
+func (b *Board) PlacedMoves() []Move {
+	byTurn := make(map[int]*Move)
+	var turns []int
+	for _, pos := range b.GetOccupiedPositions() {
+		sq := b.GetSquare(pos)
+		if !sq.HasPlacement() {
+			continue
+		}
+		m, ok := byTurn[sq.Turn]
+		if !ok {
+			m = &Move{Type: PlaceTiles, PlayerID: sq.PlayerID}
+			byTurn[sq.Turn] = m
+			turns = append(turns, sq.Turn)
+		}
+		m.Tiles = append(m.Tiles, PlacedTile{Tile: *sq.Tile, Position: pos})
+	}
+
+	sort.Ints(turns)
+	moves := make([]Move, len(turns))
+	for i, turn := range turns {
+		moves[i] = *byTurn[turn]
+	}
+	return moves
+}
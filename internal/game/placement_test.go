@@ -0,0 +1,85 @@
+package game
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// TestPlacements tests recording the turn and player of each placed tile
+func TestPlacements(t *testing.T) {
+	g := newTestGame(t, 2)
+	g.StartGame()
+	g.Players[0].Rack = rackOf("CATXYZQ")
+	move, _ := g.Board.BuildMove("p1", "CAT", mustPos(t, "G8"), Horizontal, nil)
+	if _, err := g.PlayMove(move); err != nil {
+		t.Fatalf("CAT should be playable: %v", err)
+	}
+	g.PassTurn("p2")
+	g.Players[0].Rack = rackOf("SXYZQAB")
+	move, _ = g.Board.BuildMove("p1", "CATS", mustPos(t, "G8"), Horizontal, nil)
+	if _, err := g.PlayMove(move); err != nil {
+		t.Fatalf("CATS should be playable: %v", err)
+	}
+
+	if sq := g.Board.GetSquare(mustPos(t, "H8")); !sq.HasPlacement() || sq.Turn != 0 || sq.PlayerID != "p1" {
+		t.Errorf("H8 should be placed by p1 on turn 0, got %+v", sq)
+	}
+	if got := g.Board.LastPlayed(); !reflect.DeepEqual(got, []Position{mustPos(t, "J8")}) {
+		t.Errorf("Expected the last play on J8, got %v", got)
+	}
+	if got := g.Board.PlacedOn(0); len(got) != 3 || got[0] != mustPos(t, "G8") {
+		t.Errorf("Expected CAT placed on turn 0, got %v", got)
+	}
+	if got := g.Board.PlacedOn(1); got != nil {
+		t.Errorf("A pass should place nothing, got %v", got)
+	}
+
+	moves := g.Board.PlacedMoves()
+	if len(moves) != 2 || len(moves[0].Tiles) != 3 || len(moves[1].Tiles) != 1 || moves[1].Tiles[0].Tile.Letter != 'S' {
+		t.Fatalf("Expected CAT then S, got %+v", moves)
+	}
+	if moves[0].Type != PlaceTiles || moves[0].PlayerID != "p1" {
+		t.Errorf("Unexpected reconstructed move %+v", moves[0])
+	}
+
+	// Placements survive cloning and JSON
+	var restored Board
+	data, _ := json.Marshal(g.Board.Clone())
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !reflect.DeepEqual(restored.PlacedMoves(), moves) {
+		t.Errorf("Placements should round-trip, got %+v", restored.PlacedMoves())
+	}
+}
+
+// TestPlacementsWithdrawn tests that withdrawn and hand-placed tiles carry no placement
+func TestPlacementsWithdrawn(t *testing.T) {
+	g := newChallengeGame(t)
+	g.Players[0].Rack = rackOf("CATXYZQ")
+	move, _ := g.Board.BuildMove("p1", "CAT", mustPos(t, "G8"), Horizontal, nil)
+	g.PlayMove(move)
+	g.Players[1].Rack = rackOf("XSQZVWK")
+	phony, _ := g.Board.BuildMove("p2", "CATX", mustPos(t, "G8"), Horizontal, nil)
+	g.PlayMove(phony)
+	if got := g.Board.LastPlayed(); len(got) != 1 || g.Board.GetSquare(got[0]).PlayerID != "p2" {
+		t.Fatalf("Expected p2's X as the last play, got %v", got)
+	}
+
+	if result, err := g.Challenge("p1"); err != nil || !result.Successful {
+		t.Fatalf("Challenge on CATX should succeed: %+v, %v", result, err)
+	}
+	if got := g.Board.LastPlayed(); len(got) != 3 {
+		t.Errorf("Withdrawing should leave CAT as the last play, got %v", got)
+	}
+	if sq := g.Board.GetSquare(mustPos(t, "J8")); sq.Turn != 0 || sq.PlayerID != "" {
+		t.Errorf("The withdrawn square should be cleared, got %+v", sq)
+	}
+
+	b := NewBoard()
+	b.PlaceTile(Tile{Letter: 'A', Points: 1}, mustPos(t, "H8"))
+	if b.GetSquare(mustPos(t, "H8")).HasPlacement() || b.LastPlayed() != nil || len(b.PlacedMoves()) != 0 {
+		t.Errorf("Tiles placed outside a game should have no placement")
+	}
+}
@@ -0,0 +1,119 @@
+package game
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+)
+
+// MaxRackSize is the maximum number of tiles a player may hold
+const MaxRackSize = 7
+
+// Errors returned by rack operations
+var (
+	ErrRackFull         = errors.New("rack is full")
+	ErrInvalidRackIndex = errors.New("invalid rack index")
+)
+
+// Player represents a participant in a game
+type Player struct {
+	ID       string `json:"id"`        // Unique player identifier
+	Name     string `json:"name"`      // Display name
+	Rack     []Tile `json:"rack"`      // Tiles currently held by the player
+	Score    int    `json:"score"`     // Current score
+	IsActive bool   `json:"is_active"` // True while the player is participating in the game
+}
+
+// NewPlayer creates a new player with an empty rack
+func NewPlayer(id, name string) *Player {
+	return &Player{
+		ID:       id,
+		Name:     name,
+		Rack:     make([]Tile, 0, MaxRackSize),
+		Score:    0,
+		IsActive: true,
+	}
+}
+
+// NewPlayerID returns a random version 4 UUID for identifying a player
+// Games call it for players added without an ID.
+func NewPlayerID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40 // Version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// AddTilesToRack adds tiles to the player's rack
+// Returns an error (and adds nothing) if the rack would exceed MaxRackSize
+func (p *Player) AddTilesToRack(tiles []Tile) error {
+	if len(p.Rack)+len(tiles) > MaxRackSize {
+		return fmt.Errorf("%w: %d tiles in rack, cannot add %d", ErrRackFull, len(p.Rack), len(tiles))
+	}
+
+	p.Rack = append(p.Rack, tiles...)
+	return nil
+}
+
+// RemoveTilesFromRack removes the tiles at the given rack indices and returns them
+// Returns an error (and removes nothing) if any index is invalid or repeated
+func (p *Player) RemoveTilesFromRack(indices []int) ([]Tile, error) {
+	if err := p.checkRackIndices(indices); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[int]bool, len(indices))
+	for _, idx := range indices {
+		seen[idx] = true
+	}
+
+	removed := make([]Tile, 0, len(indices))
+	for _, idx := range indices {
+		removed = append(removed, p.Rack[idx])
+	}
+
+	remaining := make([]Tile, 0, MaxRackSize)
+	for i, tile := range p.Rack {
+		if !seen[i] {
+			remaining = append(remaining, tile)
+		}
+	}
+	p.Rack = remaining
+
+	return removed, nil
+}
+
+// checkRackIndices returns an error if any index is out of range or repeated
+func (p *Player) checkRackIndices(indices []int) error {
+	seen := make(map[int]bool, len(indices))
+	for _, idx := range indices {
+		if idx < 0 || idx >= len(p.Rack) {
+			return fmt.Errorf("%w: %d", ErrInvalidRackIndex, idx)
+		}
+		if seen[idx] {
+			return fmt.Errorf("%w: %d is repeated", ErrInvalidRackIndex, idx)
+		}
+		seen[idx] = true
+	}
+	return nil
+}
+
+// GetRackSize returns the number of tiles in the player's rack
+func (p *Player) GetRackSize() int {
+	return len(p.Rack)
+}
+
+// Validate checks that the player is in a consistent state
+func (p *Player) Validate() error {
+	if p.ID == "" {
+		return errors.New("player ID must not be empty")
+	}
+	if p.Name == "" {
+		return errors.New("player name must not be empty")
+	}
+	if len(p.Rack) > MaxRackSize {
+		return fmt.Errorf("rack has %d tiles, maximum is %d", len(p.Rack), MaxRackSize)
+	}
+	return nil
+}
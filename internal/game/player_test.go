@@ -0,0 +1,149 @@
+package game
+
+import (
+	"regexp"
+	"testing"
+)
+
+// TestPlayerCreation tests player creation and initialization
+func TestPlayerCreation(t *testing.T) {
+	player := NewPlayer("p1", "Alice")
+
+	if player.ID != "p1" || player.Name != "Alice" {
+		t.Errorf("Player should have ID 'p1' and name 'Alice', got '%s' and '%s'", player.ID, player.Name)
+	}
+
+	if player.GetRackSize() != 0 {
+		t.Errorf("New player should have an empty rack, got %d tiles", player.GetRackSize())
+	}
+
+	if player.Score != 0 {
+		t.Errorf("New player should have a score of 0, got %d", player.Score)
+	}
+
+	if !player.IsActive {
+		t.Errorf("New player should be active")
+	}
+
+	if err := player.Validate(); err != nil {
+		t.Errorf("New player should be valid: %v", err)
+	}
+}
+
+// TestAddTilesToRack tests adding tiles including rack limit and overflow handling
+func TestAddTilesToRack(t *testing.T) {
+	player := NewPlayer("p1", "Alice")
+	tiles := []Tile{
+		{Letter: 'A', Points: 1},
+		{Letter: 'B', Points: 3},
+		{Letter: 'C', Points: 3},
+	}
+
+	if err := player.AddTilesToRack(tiles); err != nil {
+		t.Errorf("Should be able to add 3 tiles: %v", err)
+	}
+
+	if player.GetRackSize() != 3 {
+		t.Errorf("Rack should have 3 tiles, got %d", player.GetRackSize())
+	}
+
+	// Fill the rack to capacity
+	if err := player.AddTilesToRack(tiles[:3]); err != nil {
+		t.Errorf("Should be able to add 3 more tiles: %v", err)
+	}
+	if err := player.AddTilesToRack(tiles[:1]); err != nil {
+		t.Errorf("Should be able to fill rack to %d tiles: %v", MaxRackSize, err)
+	}
+
+	// Overflow should fail and leave the rack unchanged
+	if err := player.AddTilesToRack(tiles[:1]); err == nil {
+		t.Errorf("Adding beyond %d tiles should fail", MaxRackSize)
+	}
+
+	if player.GetRackSize() != MaxRackSize {
+		t.Errorf("Rack should still have %d tiles after overflow, got %d", MaxRackSize, player.GetRackSize())
+	}
+}
+
+// TestRemoveTilesFromRack tests tile removal including invalid indices and empty rack
+func TestRemoveTilesFromRack(t *testing.T) {
+	player := NewPlayer("p1", "Alice")
+	player.AddTilesToRack([]Tile{
+		{Letter: 'A', Points: 1},
+		{Letter: 'B', Points: 3},
+		{Letter: 'C', Points: 3},
+		{Letter: 'D', Points: 2},
+	})
+
+	removed, err := player.RemoveTilesFromRack([]int{1, 3})
+	if err != nil {
+		t.Fatalf("Should be able to remove tiles: %v", err)
+	}
+
+	if len(removed) != 2 || removed[0].Letter != 'B' || removed[1].Letter != 'D' {
+		t.Errorf("Should have removed B and D, got %v", removed)
+	}
+
+	if player.GetRackSize() != 2 || player.Rack[0].Letter != 'A' || player.Rack[1].Letter != 'C' {
+		t.Errorf("Rack should contain A and C, got %v", player.Rack)
+	}
+
+	// Invalid indices should fail and leave the rack unchanged
+	invalidCases := [][]int{{-1}, {2}, {0, 0}}
+	for _, indices := range invalidCases {
+		if _, err := player.RemoveTilesFromRack(indices); err == nil {
+			t.Errorf("Removing indices %v should fail", indices)
+		}
+		if player.GetRackSize() != 2 {
+			t.Errorf("Rack should be unchanged after failed removal of %v", indices)
+		}
+	}
+
+	// Removing from an empty rack should fail
+	empty := NewPlayer("p2", "Bob")
+	if _, err := empty.RemoveTilesFromRack([]int{0}); err == nil {
+		t.Errorf("Removing from an empty rack should fail")
+	}
+}
+
+// TestPlayerValidation tests player state validation
+func TestPlayerValidation(t *testing.T) {
+	testCases := []struct {
+		name   string
+		player *Player
+		valid  bool
+	}{
+		{"valid player", NewPlayer("p1", "Alice"), true},
+		{"missing ID", NewPlayer("", "Alice"), false},
+		{"missing name", NewPlayer("p1", ""), false},
+		{"oversized rack", &Player{ID: "p1", Name: "Alice", Rack: make([]Tile, MaxRackSize+1)}, false},
+	}
+
+	for _, tc := range testCases {
+		err := tc.player.Validate()
+		if tc.valid && err != nil {
+			t.Errorf("%s: expected valid, got %v", tc.name, err)
+		}
+		if !tc.valid && err == nil {
+			t.Errorf("%s: expected validation error", tc.name)
+		}
+	}
+}
+
+// uuidPattern matches a version 4 UUID
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+// TestNewPlayerID tests generating distinct version 4 UUIDs
+func TestNewPlayerID(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 1000; i++ {
+		id := NewPlayerID()
+		if !uuidPattern.MatchString(id) {
+			t.Fatalf("Should generate a version 4 UUID, got %q", id)
+		}
+		if seen[id] {
+			t.Fatalf("Should not repeat IDs, got %q twice", id)
+		}
+		seen[id] = true
+	}
+}
@@ -0,0 +1,164 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// RackOrder selects how SortRack arranges a rack
+type RackOrder int
+
+const (
+	ByLetter RackOrder = iota // Alphabetical, blanks last
+	ByPoints                  // Highest value first, then alphabetical
+)
+
+// String returns a string representation of the rack order
+func (o RackOrder) String() string {
+	switch o {
+	case ByLetter:
+		return "BY_LETTER"
+	case ByPoints:
+		return "BY_POINTS"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// ReplenishRack draws tiles from the bag until the player's rack is full or
+// the bag is empty, returning the tiles drawn
+// Moves replenish the mover's rack themselves; this is for racks left short
+// in any other way. The draw is recorded so the game replays the same racks.
+// If the tiles do not fit on the rack they go back to the bag, so none are
+// lost.
+func (g *Game) ReplenishRack(playerID string) ([]Tile, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.State != InProgress {
+		return nil, ErrGameNotInProgress
+	}
+	player := g.findPlayer(playerID)
+	if player == nil {
+		return nil, ErrPlayerNotFound
+	}
+
+	drawn, err := g.replenish(player)
+	if err != nil {
+		return nil, err
+	}
+	if len(drawn) > 0 {
+		g.record(Event{Type: TilesDrawn, PlayerID: playerID, Drawn: drawn})
+		g.touch()
+	}
+	return append([]Tile(nil), drawn...), nil
+}
+
+// replenish draws tiles to fill a player's rack, putting them back in the bag
+// if the rack cannot take them; callers must hold the lock
+func (g *Game) replenish(p *Player) ([]Tile, error) {
+	drawn := g.TileBag.DrawTiles(g.Board.RackSize - p.GetRackSize())
+	if err := p.AddTilesToRack(drawn); err != nil {
+		g.TileBag.putBack(drawn)
+		return nil, err
+	}
+	return drawn, nil
+}
+
+// ReorderRack rearranges the rack so that the tile at index perm[i] moves to
+// index i
+// perm must name every rack index exactly once; otherwise the rack is left
+// unchanged and an error is returned.
+func (p *Player) ReorderRack(perm []int) error {
+	if len(perm) != len(p.Rack) {
+		return fmt.Errorf("%w: %d indices for a rack of %d", ErrInvalidRackIndex, len(perm), len(p.Rack))
+	}
+	if err := p.checkRackIndices(perm); err != nil {
+		return err
+	}
+
+	reordered := make([]Tile, len(p.Rack), MaxRackSize)
+	for i, idx := range perm {
+		reordered[i] = p.Rack[idx]
+	}
+	p.Rack = reordered
+	return nil
+}
+
+// ShuffleRack puts the rack in a random order
+func (p *Player) ShuffleRack() {
+	p.ReorderRack(rand.Perm(len(p.Rack)))
+}
+
+// SortRack puts the rack in the given order
+func (p *Player) SortRack(order RackOrder) {
+	p.ReorderRack(p.sortedOrder(order))
+}
+
+// sortedOrder returns the permutation that sorts the rack
+func (p *Player) sortedOrder(order RackOrder) []int {
+	perm := make([]int, len(p.Rack))
+	for i := range perm {
+		perm[i] = i
+	}
+
+	byLetter := func(a, b Tile) bool {
+		if a.IsBlank != b.IsBlank {
+			return b.IsBlank
+		}
+		return a.Letter < b.Letter
+	}
+	sort.SliceStable(perm, func(i, j int) bool {
+		a, b := p.Rack[perm[i]], p.Rack[perm[j]]
+		if order == ByPoints && a.Points != b.Points {
+			return a.Points > b.Points
+		}
+		return byLetter(a, b)
+	})
+	return perm
+}
+
+// ReorderRack rearranges a player's rack as Player.ReorderRack does
+// Players may arrange their racks at any time. The new order is recorded so
+// that rack indices given later, such as those of an exchange, refer to the
+// same tiles when the game is replayed.
+func (g *Game) ReorderRack(playerID string, perm []int) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.reorderRack(playerID, func(*Player) []int { return perm })
+}
+
+// ShuffleRack puts a player's rack in a random order
+func (g *Game) ShuffleRack(playerID string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.reorderRack(playerID, func(p *Player) []int { return rand.Perm(len(p.Rack)) })
+}
+
+// SortRack puts a player's rack in the given order
+func (g *Game) SortRack(playerID string, order RackOrder) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.reorderRack(playerID, func(p *Player) []int { return p.sortedOrder(order) })
+}
+
+// reorderRack applies the permutation chosen for a player's rack and records
+// it; callers must hold the lock
+func (g *Game) reorderRack(playerID string, choose func(*Player) []int) error {
+	player := g.findPlayer(playerID)
+	if player == nil {
+		return ErrPlayerNotFound
+	}
+
+	perm := choose(player)
+	if err := player.ReorderRack(perm); err != nil {
+		return err
+	}
+	g.record(Event{Type: RackReordered, PlayerID: playerID, Indices: append([]int(nil), perm...)})
+	g.touch()
+	return nil
+}
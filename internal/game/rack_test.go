@@ -0,0 +1,174 @@
+package game
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// rackLetters returns the letters of a rack, with '?' for blanks
+func rackLetters(rack []Tile) string {
+	letters := make([]rune, len(rack))
+	for i, tile := range rack {
+		letters[i] = tile.Letter
+		if tile.IsBlank {
+			letters[i] = '?'
+		}
+	}
+	return string(letters)
+}
+
+// TestReorderRack tests rearranging a rack by permutation
+func TestReorderRack(t *testing.T) {
+	player := NewPlayer("p1", "Player 1")
+	player.Rack = rackOf("CAT")
+
+	if err := player.ReorderRack([]int{2, 0, 1}); err != nil {
+		t.Fatalf("ReorderRack failed: %v", err)
+	}
+	if got := rackLetters(player.Rack); got != "TCA" {
+		t.Errorf("Rack should be TCA, got %s", got)
+	}
+
+	for _, perm := range [][]int{{0, 1}, {0, 1, 1}, {0, 1, 3}, {0, 1, 2, 3}} {
+		if err := player.ReorderRack(perm); !errors.Is(err, ErrInvalidRackIndex) {
+			t.Errorf("Should reject permutation %v, got %v", perm, err)
+		}
+	}
+	if got := rackLetters(player.Rack); got != "TCA" {
+		t.Errorf("Rejected permutations should leave the rack unchanged, got %s", got)
+	}
+}
+
+// TestSortRack tests sorting a rack by letter and by points
+func TestSortRack(t *testing.T) {
+	player := NewPlayer("p1", "Player 1")
+	player.Rack = rackOf("QE?ZAXE")
+
+	player.SortRack(ByLetter)
+	if got := rackLetters(player.Rack); got != "AEEQXZ?" {
+		t.Errorf("Sorting by letter should give AEEQXZ?, got %s", got)
+	}
+
+	player.SortRack(ByPoints)
+	if got := rackLetters(player.Rack); got != "QZXAEE?" {
+		t.Errorf("Sorting by points should give QZXAEE?, got %s", got)
+	}
+
+	if ByPoints.String() != "BY_POINTS" || RackOrder(9).String() != "UNKNOWN" {
+		t.Errorf("Should name rack orders, got %s and %s", ByPoints, RackOrder(9))
+	}
+}
+
+// TestShuffleRack tests that shuffling keeps the same tiles
+func TestShuffleRack(t *testing.T) {
+	player := NewPlayer("p1", "Player 1")
+	player.Rack = rackOf("ABCDEFG")
+
+	changed := false
+	for i := 0; i < 20 && !changed; i++ {
+		player.ShuffleRack()
+		changed = rackLetters(player.Rack) != "ABCDEFG"
+	}
+	if !changed {
+		t.Errorf("Shuffling should change the order of the rack")
+	}
+
+	player.SortRack(ByLetter)
+	if got := rackLetters(player.Rack); got != "ABCDEFG" {
+		t.Errorf("Shuffling should keep the same tiles, got %s", got)
+	}
+}
+
+// TestGameRackOrderReplay tests that rack rearrangements are recorded so an
+// exchange by rack index replays with the same tiles
+func TestGameRackOrderReplay(t *testing.T) {
+	g := newTestGame(t, 2)
+	g.StartGame()
+
+	if err := g.SortRack("p9", ByLetter); !errors.Is(err, ErrPlayerNotFound) {
+		t.Errorf("Should reject an unknown player, got %v", err)
+	}
+	if err := g.ShuffleRack("p2"); err != nil {
+		t.Fatalf("ShuffleRack failed: %v", err)
+	}
+	if err := g.SortRack("p1", ByPoints); err != nil {
+		t.Fatalf("SortRack failed: %v", err)
+	}
+	if err := g.ReorderRack("p1", []int{6, 5, 4, 3, 2, 1, 0}); err != nil {
+		t.Fatalf("ReorderRack failed: %v", err)
+	}
+	if err := g.ExchangeTiles("p1", []int{0, 1}); err != nil {
+		t.Fatalf("ExchangeTiles failed: %v", err)
+	}
+
+	replayer, err := NewReplayer(g.Events())
+	if err != nil {
+		t.Fatalf("NewReplayer failed: %v", err)
+	}
+	replayed, err := replayer.Seek(replayer.Len())
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	for i, p := range g.Players {
+		if !reflect.DeepEqual(replayed.Players[i].Rack, p.Rack) {
+			t.Errorf("Replayed rack of %s should be %s, got %s", p.ID, rackLetters(p.Rack), rackLetters(replayed.Players[i].Rack))
+		}
+	}
+	if got, want := replayed.History[0].Exchanged, g.History[0].Exchanged; !reflect.DeepEqual(got, want) {
+		t.Errorf("Replay should exchange %s, got %s", rackLetters(want), rackLetters(got))
+	}
+}
+
+// TestReplenishRack tests refilling a short rack from the bag
+func TestReplenishRack(t *testing.T) {
+	g := newTestGame(t, 2)
+	if _, err := g.ReplenishRack("p1"); !errors.Is(err, ErrGameNotInProgress) {
+		t.Errorf("Should not draw before the game starts, got %v", err)
+	}
+	g.StartGame()
+	if _, err := g.ReplenishRack("p9"); !errors.Is(err, ErrPlayerNotFound) {
+		t.Errorf("Should reject an unknown player, got %v", err)
+	}
+
+	events := g.EventCount()
+	if drawn, err := g.ReplenishRack("p1"); err != nil || len(drawn) != 0 || g.EventCount() != events {
+		t.Errorf("A full rack should draw nothing and record nothing, got %v, %v", drawn, err)
+	}
+
+	g.Players[0].Rack = g.Players[0].Rack[:4]
+	remaining := g.TileBag.RemainingCount()
+	drawn, err := g.ReplenishRack("p1")
+	if err != nil {
+		t.Fatalf("ReplenishRack failed: %v", err)
+	}
+	if len(drawn) != 3 || len(g.Players[0].Rack) != MaxRackSize || g.TileBag.RemainingCount() != remaining-3 {
+		t.Errorf("Should draw three tiles to fill the rack, got %s", rackLetters(drawn))
+	}
+	last := g.Events()[g.EventCount()-1]
+	if last.Type != TilesDrawn || last.PlayerID != "p1" || !reflect.DeepEqual(last.Drawn, drawn) {
+		t.Errorf("Should record the draw, got %+v", last)
+	}
+
+	// Near the end of the bag the rack is filled as far as the bag allows
+	g.TileBag.DrawTiles(g.TileBag.RemainingCount() - 2)
+	g.Players[1].Rack = g.Players[1].Rack[:2]
+	if drawn, err := g.ReplenishRack("p2"); err != nil || len(drawn) != 2 || !g.TileBag.IsEmpty() {
+		t.Errorf("Should draw the last two tiles, got %v, %v", drawn, err)
+	}
+}
+
+// TestReplenishRackKeepsTiles tests that tiles which do not fit go back to the bag
+func TestReplenishRackKeepsTiles(t *testing.T) {
+	g := newTestGame(t, 2)
+	g.StartGame()
+	g.Board.RackSize = MaxRackSize + 1
+	before := g.TileBag.Tiles()
+
+	if _, err := g.ReplenishRack("p1"); !errors.Is(err, ErrRackFull) {
+		t.Errorf("Should fail when the rack cannot take the tiles, got %v", err)
+	}
+	if !reflect.DeepEqual(g.TileBag.Tiles(), before) || len(g.Players[0].Rack) != MaxRackSize {
+		t.Errorf("A failed draw should leave the bag and rack as they were")
+	}
+}
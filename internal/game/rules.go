@@ -0,0 +1,212 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"scrabbled/internal/dictionary"
+)
+
+// ErrInvalidRules is returned when a game is created with inconsistent rules
+var ErrInvalidRules = errors.New("invalid game rules")
+
+// Rules configures how a game is played, from the variant's board and tiles
+// to house rules such as a smaller rack, a different bingo bonus or weaker
+// premium squares
+// Start from DefaultRules or RulesForVariant and change the fields that differ.
+type Rules struct {
+	Variant         Variant               `json:"variant"`
+	Dictionary      dictionary.Dictionary `json:"-"` // Word list used to validate moves (nil accepts any word)
+	ChallengeRule   ChallengeRule         `json:"challenge_rule"`
+	BingoBonus      int                   `json:"bingo_bonus"`             // Points for playing a full rack
+	Multipliers     *Multipliers          `json:"multipliers,omitempty"`   // Premium square multipliers (nil for the standard ones)
+	RackSize        int                   `json:"rack_size"`               // Tiles in a full rack, at most MaxRackSize
+	ExchangeMinimum int                   `json:"exchange_minimum"`        // Tiles that must remain in the bag to exchange
+	TimeControl     *TimeControl          `json:"time_control,omitempty"`  // Clocks and overtime policy (nil when untimed)
+	Teams           *TeamRules            `json:"teams,omitempty"`         // Team play for four players (nil when players play alone)
+	Solo            *SoloRules            `json:"solo,omitempty"`          // Practice for one player against the clock
+	AbandonAfter    time.Duration         `json:"abandon_after,omitempty"` // Time on one turn after which the player to move forfeits (0 never)
+	Pauses          *PauseRules           `json:"pauses,omitempty"`        // Pauses and vacations allowed (nil when the game cannot be paused)
+}
+
+// DefaultRules returns the rules of classic Scrabble
+func DefaultRules() Rules {
+	return RulesForVariant(Classic)
+}
+
+// RulesForVariant returns the standard rules of a variant: a seven tile
+// rack, the variant's bingo bonus, the void challenge rule and no clocks
+func RulesForVariant(v Variant) Rules {
+	return Rules{
+		Variant:         v,
+		ChallengeRule:   VoidChallenge,
+		BingoBonus:      v.BingoBonus(),
+		RackSize:        MaxRackSize,
+		ExchangeMinimum: MinTilesForExchange,
+	}
+}
+
+// BlitzRules returns the speed rules for classic Scrabble: BlitzTimeControl
+// clocks, with turns passed when their time runs out, and the void challenge
+// rule, so that with a Dictionary set plays are validated as they are made
+// and there is nothing to challenge
+func BlitzRules() Rules {
+	rules := DefaultRules()
+	tc := BlitzTimeControl()
+	rules.TimeControl = &tc
+	return rules
+}
+
+// Validate checks that the rules describe a playable game
+func (r Rules) Validate() error {
+	if r.Variant.String() == "UNKNOWN" {
+		return fmt.Errorf("%w: unknown variant %d", ErrInvalidRules, r.Variant)
+	}
+	if r.ChallengeRule.String() == "UNKNOWN" {
+		return fmt.Errorf("%w: unknown challenge rule %d", ErrInvalidRules, r.ChallengeRule)
+	}
+	if r.BingoBonus < 0 {
+		return fmt.Errorf("%w: bingo bonus must not be negative, got %d", ErrInvalidRules, r.BingoBonus)
+	}
+	if m := r.Multipliers; m != nil && (m.DoubleLetter < 1 || m.TripleLetter < 1 || m.DoubleWord < 1 || m.TripleWord < 1) {
+		return fmt.Errorf("%w: multipliers must be positive, got %+v", ErrInvalidRules, *m)
+	}
+	if r.RackSize < 1 || r.RackSize > MaxRackSize {
+		return fmt.Errorf("%w: rack size must be 1 to %d, got %d", ErrInvalidRules, MaxRackSize, r.RackSize)
+	}
+	if r.ExchangeMinimum < 1 {
+		return fmt.Errorf("%w: exchange minimum must be positive, got %d", ErrInvalidRules, r.ExchangeMinimum)
+	}
+	if tc := r.TimeControl; tc != nil && (tc.Base <= 0 || tc.Increment < 0 || tc.OvertimePenalty < 0 || tc.MaxOvertime < 0 || tc.TurnLimit < 0) {
+		return fmt.Errorf("%w: time control %+v", ErrInvalidRules, *tc)
+	}
+	if tc := r.TimeControl; tc != nil && tc.TurnLimit > 0 && r.ChallengeRule != VoidChallenge {
+		return fmt.Errorf("%w: turn limits leave no time to challenge", ErrInvalidRules)
+	}
+	if r.AbandonAfter < 0 {
+		return fmt.Errorf("%w: abandonment timeout must not be negative, got %v", ErrInvalidRules, r.AbandonAfter)
+	}
+	if p := r.Pauses; p != nil && (p.Vacation < 0 || p.MaxPause <= 0) {
+		return fmt.Errorf("%w: pause rules %+v", ErrInvalidRules, *p)
+	}
+	if r.Teams != nil && r.Teams.Consultation < 0 {
+		return fmt.Errorf("%w: consultation window must not be negative, got %v", ErrInvalidRules, r.Teams.Consultation)
+	}
+	if r.Solo != nil {
+		switch {
+		case r.Solo.Duration <= 0:
+			return fmt.Errorf("%w: solo duration must be positive, got %v", ErrInvalidRules, r.Solo.Duration)
+		case r.Teams != nil:
+			return fmt.Errorf("%w: solo games cannot be played in teams", ErrInvalidRules)
+		case r.TimeControl != nil:
+			return fmt.Errorf("%w: solo games are timed by their duration", ErrInvalidRules)
+		case r.ChallengeRule != VoidChallenge:
+			return fmt.Errorf("%w: solo games have no one to challenge", ErrInvalidRules)
+		case r.Pauses != nil:
+			return fmt.Errorf("%w: solo games cannot be paused", ErrInvalidRules)
+		}
+	}
+	return nil
+}
+
+// NewGameWithRules creates a new game waiting for players under the given rules
+// Players are seated in the order given; up to MaxPlayers may be supplied.
+func NewGameWithRules(id string, players []*Player, rules Rules) (*Game, error) {
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+	if len(players) > MaxPlayers {
+		return nil, fmt.Errorf("%w: %d players (maximum %d)", ErrGameFull, len(players), MaxPlayers)
+	}
+
+	now := time.Now()
+	g := &Game{
+		ID:              id,
+		Board:           NewBoardForVariant(rules.Variant),
+		Players:         make([]*Player, 0, MaxPlayers),
+		TileBag:         NewTileBagForVariant(rules.Variant),
+		Dictionary:      rules.Dictionary,
+		CurrentTurn:     0,
+		State:           WaitingForPlayers,
+		ChallengeRule:   rules.ChallengeRule,
+		ExchangeMinimum: rules.ExchangeMinimum,
+		AbandonAfter:    rules.AbandonAfter,
+		CreatedAt:       now,
+		LastActivity:    now,
+		ExpiresAt:       now.Add(DefaultGameExpiration),
+		bots:            make(map[string]Strategy),
+		losesTurn:       make(map[string]bool),
+	}
+	g.Board.BingoBonus = rules.BingoBonus
+	g.Board.RackSize = rules.RackSize
+	if rules.Multipliers != nil {
+		m := *rules.Multipliers
+		g.Board.Multipliers = &m
+	}
+
+	for _, p := range players {
+		if err := g.addPlayer(p); err != nil {
+			return nil, err
+		}
+	}
+	if rules.TimeControl != nil {
+		g.Clock = newClock(*rules.TimeControl, g.Players)
+	}
+	if rules.Teams != nil {
+		teams := *rules.Teams
+		g.Teams = &teams
+	}
+	if rules.Solo != nil {
+		solo := *rules.Solo
+		g.Solo = &solo
+		g.Clock = newClock(soloClock(solo), g.Players)
+	}
+	if rules.Pauses != nil {
+		pauses := *rules.Pauses
+		g.Pauses = &pauses
+	}
+
+	// Dictionaries are not part of the event stream
+	recorded := rules
+	recorded.Dictionary = nil
+	g.record(Event{Type: GameCreated, GameID: id, Players: seatCopies(g.Players), Variant: rules.Variant, Rules: &recorded})
+	return g, nil
+}
+
+// Rules returns the rules the game is played under
+func (g *Game) Rules() Rules {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	rules := Rules{
+		Variant:         g.Board.Variant,
+		Dictionary:      g.Dictionary,
+		ChallengeRule:   g.ChallengeRule,
+		BingoBonus:      g.Board.BingoBonus,
+		RackSize:        g.Board.RackSize,
+		ExchangeMinimum: g.ExchangeMinimum,
+		AbandonAfter:    g.AbandonAfter,
+	}
+	if g.Board.Multipliers != nil {
+		m := *g.Board.Multipliers
+		rules.Multipliers = &m
+	}
+	if g.Clock != nil && g.Solo == nil {
+		tc := g.Clock.Control
+		rules.TimeControl = &tc
+	}
+	if g.Teams != nil {
+		teams := *g.Teams
+		rules.Teams = &teams
+	}
+	if g.Solo != nil {
+		solo := *g.Solo
+		rules.Solo = &solo
+	}
+	if g.Pauses != nil {
+		pauses := *g.Pauses
+		rules.Pauses = &pauses
+	}
+	return rules
+}
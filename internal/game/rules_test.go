@@ -0,0 +1,224 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+// newHouseRulesGame creates a started two player game with a five tile rack,
+// a 20 point bingo bonus, a one tile exchange minimum and clocks
+func newHouseRulesGame(t *testing.T) *Game {
+	t.Helper()
+
+	rules := DefaultRules()
+	rules.RackSize = 5
+	rules.BingoBonus = 20
+	rules.ExchangeMinimum = 1
+	tc := TournamentTimeControl()
+	rules.TimeControl = &tc
+
+	g, err := NewGameWithRules("house", []*Player{NewPlayer("p1", "Player 1")}, rules)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	if err := g.AddPlayer(NewPlayer("p2", "Player 2")); err != nil {
+		t.Fatalf("Failed to add player: %v", err)
+	}
+	if err := g.StartGame(); err != nil {
+		t.Fatalf("Failed to start game: %v", err)
+	}
+	return g
+}
+
+// TestRulesForVariant tests the standard rules of each variant
+func TestRulesForVariant(t *testing.T) {
+	classic := DefaultRules()
+	if classic.Variant != Classic || classic.BingoBonus != BingoBonus || classic.RackSize != MaxRackSize ||
+		classic.ExchangeMinimum != MinTilesForExchange || classic.ChallengeRule != VoidChallenge || classic.TimeControl != nil {
+		t.Errorf("Should use the classic rules by default, got %+v", classic)
+	}
+	if wwf := RulesForVariant(WordsWithFriends); wwf.BingoBonus != WWFBingoBonus {
+		t.Errorf("Should use the Words With Friends bingo bonus, got %d", wwf.BingoBonus)
+	}
+
+	g := newTestGame(t, 2)
+	if rules := g.Rules(); rules.RackSize != MaxRackSize || rules.BingoBonus != BingoBonus || rules.ExchangeMinimum != MinTilesForExchange {
+		t.Errorf("NewGame should play under the default rules, got %+v", rules)
+	}
+}
+
+// TestRulesValidate tests rejecting unplayable rules
+func TestRulesValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(*Rules)
+	}{
+		{"unknown variant", func(r *Rules) { r.Variant = Variant(9) }},
+		{"unknown challenge rule", func(r *Rules) { r.ChallengeRule = ChallengeRule(9) }},
+		{"negative bingo bonus", func(r *Rules) { r.BingoBonus = -1 }},
+		{"empty rack", func(r *Rules) { r.RackSize = 0 }},
+		{"oversized rack", func(r *Rules) { r.RackSize = MaxRackSize + 1 }},
+		{"no exchange minimum", func(r *Rules) { r.ExchangeMinimum = 0 }},
+		{"clock without time", func(r *Rules) { r.TimeControl = &TimeControl{} }},
+		{"zero multiplier", func(r *Rules) { r.Multipliers = &Multipliers{DoubleLetter: 2, TripleLetter: 3, TripleWord: 3} }},
+		{"negative abandonment timeout", func(r *Rules) { r.AbandonAfter = -time.Minute }},
+		{"negative turn limit", func(r *Rules) { r.TimeControl = &TimeControl{Base: time.Minute, TurnLimit: -time.Second} }},
+		{"turn limit with challenges", func(r *Rules) {
+			tc := BlitzTimeControl()
+			r.TimeControl, r.ChallengeRule = &tc, DoubleChallenge
+		}},
+	}
+
+	for _, tt := range tests {
+		rules := DefaultRules()
+		tt.change(&rules)
+		if _, err := NewGameWithRules("bad", nil, rules); !errors.Is(err, ErrInvalidRules) {
+			t.Errorf("Should reject %s, got %v", tt.name, err)
+		}
+	}
+
+	rules := DefaultRules()
+	rules.BingoBonus = 0
+	if err := rules.Validate(); err != nil {
+		t.Errorf("Should allow playing without a bingo bonus: %v", err)
+	}
+	if err := BlitzRules().Validate(); err != nil {
+		t.Errorf("Blitz rules should be valid: %v", err)
+	}
+}
+
+// TestNewGameWithRules tests that house rules govern dealing, scoring,
+// exchanges and clocks
+func TestNewGameWithRules(t *testing.T) {
+	g := newHouseRulesGame(t)
+
+	for _, p := range g.Players {
+		if p.GetRackSize() != 5 {
+			t.Errorf("Player %s should be dealt 5 tiles, got %d", p.ID, p.GetRackSize())
+		}
+	}
+	if g.Clock == nil {
+		t.Fatal("Should start the clocks")
+	}
+	if left, err := g.TimeRemaining("p2"); err != nil || left != 25*time.Minute {
+		t.Errorf("Player seated later should get the base time, got %v, %v", left, err)
+	}
+
+	move, err := g.Board.BuildMove("p1", "HOUSE", mustPos(t, "H8"), Horizontal, nil)
+	if err != nil {
+		t.Fatalf("BuildMove failed: %v", err)
+	}
+	// H(4) O(1) U(1) S(1) E(1) with H on H8 (DWS) and E on L8 (DLS), plus the house bingo bonus
+	if got, want := g.Board.ScoreMove(move), (4+1+1+1+2)*2+20; got != want {
+		t.Errorf("Five tile play should score %d with the house bingo bonus, got %d", want, got)
+	}
+
+	g.TileBag.DrawTiles(g.TileBag.RemainingCount() - 1)
+	if err := g.ExchangeTiles("p1", []int{0}); err != nil {
+		t.Errorf("Should allow exchanging one tile with one left in the bag: %v", err)
+	}
+	if err := g.ExchangeTiles("p2", []int{0, 1}); !errors.Is(err, ErrExchangeNotAllowed) {
+		t.Errorf("Should not exchange more tiles than the bag holds, got %v", err)
+	}
+}
+
+// TestRulesMultipliers tests scoring, move generation and saving with
+// custom premium multipliers
+func TestRulesMultipliers(t *testing.T) {
+	rules := DefaultRules()
+	rules.BingoBonus = WWFBingoBonus
+	rules.Multipliers = &Multipliers{DoubleLetter: 2, TripleLetter: 3, DoubleWord: 1, TripleWord: 1}
+	g, err := NewGameWithRules("classroom", []*Player{NewPlayer("p1", "Player 1"), NewPlayer("p2", "Player 2")}, rules)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	rules.Multipliers.DoubleLetter = 5
+	if got := g.Rules().Multipliers; got == nil || *got != (Multipliers{DoubleLetter: 2, TripleLetter: 3, DoubleWord: 1, TripleWord: 1}) {
+		t.Errorf("Game should keep its own copy of the multipliers, got %+v", got)
+	}
+
+	move, err := g.Board.BuildMove("p1", "HOUSE", mustPos(t, "H8"), Horizontal, nil)
+	if err != nil {
+		t.Fatalf("BuildMove failed: %v", err)
+	}
+	// The center square no longer doubles the word; E on L8 still doubles
+	if got, want := g.Board.ScoreMove(move), 4+1+1+1+2; got != want {
+		t.Errorf("HOUSE should score %d without word premiums, got %d", want, got)
+	}
+	for _, m := range NewMoveGenerator(testWords).GenerateMoves(g.Board, rackOf("CATSDOG")) {
+		if got := g.Board.ScoreMove(m); got != m.Score {
+			t.Errorf("Generated %s at %s should score %d as ScoreMove does, got %d", m.Word, m.Start, got, m.Score)
+		}
+	}
+
+	g.StartGame()
+	data, err := g.Serialize()
+	if err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	loaded, err := LoadGame(data)
+	if err != nil {
+		t.Fatalf("LoadGame failed: %v", err)
+	}
+	if got := loaded.Rules(); got.Multipliers == nil || got.Multipliers.DoubleWord != 1 || got.BingoBonus != WWFBingoBonus {
+		t.Errorf("Loaded game should keep the multipliers, got %+v", got)
+	}
+	replayer, err := NewReplayer(g.Events())
+	if err != nil {
+		t.Fatalf("NewReplayer failed: %v", err)
+	}
+	replayed, err := replayer.Seek(replayer.Len())
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if got := replayed.Rules(); got.Multipliers == nil || got.Multipliers.TripleWord != 1 {
+		t.Errorf("Replayed game should keep the multipliers, got %+v", got)
+	}
+	if DefaultRules().Multipliers != nil || newTestGame(t, 2).Rules().Multipliers != nil {
+		t.Errorf("Standard rules should leave the multipliers unset")
+	}
+}
+
+// TestRulesPersist tests that rules survive saving, loading and replay
+func TestRulesPersist(t *testing.T) {
+	g := newHouseRulesGame(t)
+	want := g.Rules()
+
+	data, err := g.Serialize()
+	if err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	loaded, err := LoadGame(data)
+	if err != nil {
+		t.Fatalf("LoadGame failed: %v", err)
+	}
+	if got := loaded.Rules(); got.RackSize != 5 || got.BingoBonus != 20 || got.ExchangeMinimum != 1 || got.TimeControl == nil {
+		t.Errorf("Loaded game should keep the rules %+v, got %+v", want, got)
+	}
+
+	replayer, err := NewReplayer(g.Events())
+	if err != nil {
+		t.Fatalf("NewReplayer failed: %v", err)
+	}
+	replayed, err := replayer.Seek(replayer.Len())
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if got := replayed.Rules(); got.RackSize != 5 || got.BingoBonus != 20 || got.ExchangeMinimum != 1 {
+		t.Errorf("Replayed game should keep the rules %+v, got %+v", want, got)
+	}
+}
+
+// TestRulesOldBoard tests that boards saved before rules were recorded load
+// with the variant's standard rules
+func TestRulesOldBoard(t *testing.T) {
+	var board Board
+	if err := json.Unmarshal([]byte(`{"variant":1}`), &board); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if board.BingoBonus != WWFBingoBonus || board.RackSize != MaxRackSize {
+		t.Errorf("Should default to the variant's rules, got bonus %d and rack %d", board.BingoBonus, board.RackSize)
+	}
+}
@@ -0,0 +1,119 @@
+package game
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ExportScoresheet writes the game as a plain-text scoresheet, laid out the
+// way club players record games
+// Each turn is numbered and gives the player, the play with its coordinates
+// (8D across, D8 down), the score and the player's running total. Letters
+// already on the board are shown in parentheses and blanks in lower case.
+// Withdrawn plays and challenges are noted under the turn they concern, and a
+// finished game ends with the rack adjustments and final scores.
+func (g *Game) ExportScoresheet(w io.Writer) error {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	bw := bufio.NewWriter(w)
+	names := make([]string, len(g.Players))
+	width := len("Player")
+	for i, p := range g.Players {
+		names[i] = p.Name
+		width = max(width, utf8.RuneCountInString(p.Name))
+	}
+	if g.ID != "" {
+		fmt.Fprintf(bw, "Game %s\n", g.ID)
+	}
+	fmt.Fprintf(bw, "%s\n\n", strings.Join(names, " vs "))
+
+	line := func(turn, playerID, play string, score, total int) {
+		name := playerID
+		if p := g.findPlayer(playerID); p != nil {
+			name = p.Name
+		}
+		fmt.Fprintf(bw, "%4s  %-*s  %-20s %+5d %6d\n", turn, width, name, play, score, total)
+	}
+	fmt.Fprintf(bw, "%4s  %-*s  %-20s %5s %6s\n", "Turn", width, "Player", "Play", "Score", "Total")
+
+	turn := 0
+	for _, m := range g.History {
+		switch m.Type {
+		case PlaceTiles:
+			turn++
+			line(fmt.Sprint(turn), m.PlayerID, m.Coordinates()+" "+scoresheetPlay(m), m.Score, m.Total)
+			if m.Withdrawn {
+				line("", m.PlayerID, "Withdrawn", -m.Score, m.Total-m.Score)
+			}
+		case Exchange:
+			turn++
+			line(fmt.Sprint(turn), m.PlayerID, "Exchange "+gcgRack(m.Exchanged), 0, m.Total)
+		case Pass:
+			turn++
+			line(fmt.Sprint(turn), m.PlayerID, "Pass", 0, m.Total)
+		case ChallengeTurn:
+			note := "Challenge failed"
+			if m.Challenge != nil && m.Challenge.Successful {
+				note = "Challenged " + strings.Join(m.Challenge.InvalidWords, ", ")
+			}
+			line("", m.PlayerID, note, 0, m.Total)
+		}
+	}
+
+	if g.State == Finished {
+		for _, adj := range g.endRackAdjustments() {
+			play := "Rack " + gcgRack(adj.tiles)
+			if adj.wentOut {
+				play = "Out (" + gcgRack(adj.tiles) + ")"
+			}
+			line("", adj.player.ID, play, adj.points, adj.player.Score)
+		}
+		fmt.Fprintln(bw)
+		scores := make([]string, len(g.Players))
+		for i, p := range g.Players {
+			scores[i] = fmt.Sprintf("%s %d", p.Name, p.Score)
+		}
+		fmt.Fprintf(bw, "Final: %s\n", strings.Join(scores, ", "))
+	}
+
+	return bw.Flush()
+}
+
+// scoresheetPlay writes the word a play forms with the letters already on the
+// board in parentheses and blanks in lower case, as in (CAT)S
+func scoresheetPlay(m Move) string {
+	placed := make(map[Position]Tile, len(m.Tiles))
+	for _, pt := range m.Tiles {
+		placed[pt.Position] = pt.Tile
+	}
+
+	var sb strings.Builder
+	step := m.Direction.step()
+	pos := m.Start
+	existing := false
+	for _, r := range m.Word {
+		t, isNew := placed[pos]
+		if !isNew != existing {
+			if existing {
+				sb.WriteRune(')')
+			} else {
+				sb.WriteRune('(')
+			}
+			existing = !isNew
+		}
+		if isNew && t.IsBlank {
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+		pos = Position{Row: pos.Row + step.Row, Col: pos.Col + step.Col}
+	}
+	if existing {
+		sb.WriteRune(')')
+	}
+	return sb.String()
+}
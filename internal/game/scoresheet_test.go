@@ -0,0 +1,89 @@
+package game
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestExportScoresheet tests the scoresheet lines for each kind of turn
+func TestExportScoresheet(t *testing.T) {
+	f, _ := os.Open("testdata/sample.gcg")
+	defer f.Close()
+	g, err := ImportGCG(f)
+	if err != nil {
+		t.Fatalf("ImportGCG failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := g.ExportScoresheet(&buf); err != nil {
+		t.Fatalf("ExportScoresheet failed: %v", err)
+	}
+	out := buf.String()
+
+	for _, line := range []string{
+		"Game sample-1\nAlice Smith vs Bob Jones\n",
+		"Turn  Player       Play                 Score  Total\n",
+		"   1  Alice Smith  8G CAT                 +10     10\n",
+		"   2  Bob Jones    8G (CAT)S               +6      6\n",
+		"   3  Alice Smith  Exchange XYZ            +0     10\n",
+		"   4  Bob Jones    Pass                    +0      6\n",
+		"   5  Alice Smith  H7 Q(A)I               +12     22\n",
+		"      Alice Smith  Withdrawn              -12     10\n",
+		"   6  Bob Jones    9F DO                   +9     20\n",
+	} {
+		if !strings.Contains(out, line) {
+			t.Errorf("Scoresheet should contain %q, got:\n%s", line, out)
+		}
+	}
+	if strings.Contains(out, "Final:") {
+		t.Errorf("Unfinished game should have no final scores, got:\n%s", out)
+	}
+}
+
+// TestScoresheetEndOfGame tests blanks and the closing adjustments
+func TestScoresheetEndOfGame(t *testing.T) {
+	g := newTestGame(t, 2)
+	g.StartGame()
+	g.TileBag.DrawTiles(g.TileBag.RemainingCount() - 2)
+	g.Players[0].Rack = rackOf("CAT?")
+	g.Players[1].Rack = rackOf("QZ")
+
+	move, _ := g.Board.BuildMove("p1", "CATS", mustPos(t, "H7"), Vertical, []int{3})
+	if _, err := g.PlayMove(move); err != nil {
+		t.Fatalf("PlayMove failed: %v", err)
+	}
+	g.PassTurn("p2")
+	g.Players[0].Rack = rackOf("AT")
+	finisher, _ := g.Board.BuildMove("p1", "AT", mustPos(t, "I8"), Vertical, nil)
+	if _, err := g.PlayMove(finisher); err != nil {
+		t.Fatalf("Final move failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := g.ExportScoresheet(&buf); err != nil {
+		t.Fatalf("ExportScoresheet failed: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"H7 CATs",
+		"Player 1  Out (QZ)               +20     38",
+		"Player 2  Rack QZ                -20    -20",
+		"Final: Player 1 ",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Scoresheet should contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+// TestScoresheetPlay tests parentheses around letters already on the board
+func TestScoresheetPlay(t *testing.T) {
+	board := NewBoard()
+	placeWord(t, board, "CAT", "G8", Horizontal)
+	move, _ := board.BuildMove("p1", "SCATTER", mustPos(t, "F8"), Horizontal, []int{6})
+	if got := scoresheetPlay(move); got != "S(CAT)TEr" {
+		t.Errorf("Should bracket the existing letters, got %s", got)
+	}
+}
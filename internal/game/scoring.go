@@ -0,0 +1,148 @@
+package game
+
+// BingoBonus is awarded for playing all seven tiles from the rack in one move
+// in classic Scrabble; see Variant.BingoBonus for other variants and
+// Board.BingoBonus for the bonus in effect
+const BingoBonus = 50
+
+// Multipliers sets what each kind of premium square multiplies by, for
+// house rules such as classroom scoring
+type Multipliers struct {
+	DoubleLetter int `json:"double_letter"`
+	TripleLetter int `json:"triple_letter"`
+	DoubleWord   int `json:"double_word"`
+	TripleWord   int `json:"triple_word"`
+}
+
+// StandardMultipliers returns the multipliers premium squares are named for
+func StandardMultipliers() Multipliers {
+	return Multipliers{DoubleLetter: 2, TripleLetter: 3, DoubleWord: 2, TripleWord: 3}
+}
+
+// Letter returns the letter multiplier for a premium square
+func (m Multipliers) Letter(pt PremiumType) int {
+	switch pt {
+	case DoubleLetterScore:
+		return m.DoubleLetter
+	case TripleLetterScore:
+		return m.TripleLetter
+	default:
+		return 1
+	}
+}
+
+// Word returns the word multiplier for a premium square
+func (m Multipliers) Word(pt PremiumType) int {
+	switch pt {
+	case DoubleWordScore:
+		return m.DoubleWord
+	case TripleWordScore:
+		return m.TripleWord
+	default:
+		return 1
+	}
+}
+
+// multipliers returns the multipliers in effect on the board
+func (b *Board) multipliers() Multipliers {
+	if b.Multipliers != nil {
+		return *b.Multipliers
+	}
+	return StandardMultipliers()
+}
+
+// ScoreWord calculates the score of a single formed word
+// Premium squares only count for tiles placed by the move (those in placed).
+func (b *Board) ScoreWord(word FormedWord, placed map[Position]Tile) int {
+	sum := 0
+	multiplier := 1
+	mul := b.multipliers()
+
+	for _, pos := range word.Positions {
+		if tile, isNew := placed[pos]; isNew {
+			premium := b.GetPremiumType(pos)
+			sum += tile.Points * mul.Letter(premium)
+			multiplier *= mul.Word(premium)
+		} else if tile := b.GetTile(pos); tile != nil {
+			sum += tile.Points
+		}
+	}
+
+	return sum * multiplier
+}
+
+// ScoreMove calculates the total score of a move on the current board,
+// including all cross words and the bingo bonus
+// The move is assumed to have passed ValidatePlacement. It scores the same
+// words as FormedWords without building them, so it does not allocate.
+func (b *Board) ScoreMove(move Move) int {
+	if len(move.Tiles) == 0 {
+		return 0
+	}
+
+	total := 0
+	if score, length := b.scoreRun(move.Tiles, move.Tiles[0].Position, move.Direction); length > 1 {
+		total += score
+	}
+	cross := move.Direction.Perpendicular()
+	for _, pt := range move.Tiles {
+		if score, length := b.scoreRun(move.Tiles, pt.Position, cross); length > 1 {
+			total += score
+		}
+	}
+
+	if len(move.Tiles) == b.RackSize {
+		total += b.BingoBonus
+	}
+
+	return total
+}
+
+// scoreRun scores the maximal run of tiles through pos in the given
+// direction, treating the placed tiles as if they were on the board, and
+// returns the run's length
+func (b *Board) scoreRun(tiles []PlacedTile, pos Position, dir Direction) (int, int) {
+	step := dir.step()
+
+	start := pos
+	for {
+		prev := Position{Row: start.Row - step.Row, Col: start.Col - step.Col}
+		if !prev.IsValid() || !b.occupiedWith(tiles, prev) {
+			break
+		}
+		start = prev
+	}
+
+	sum, multiplier, length := 0, 1, 0
+	mul := b.multipliers()
+	for p := start; p.IsValid() && b.occupiedWith(tiles, p); p = (Position{Row: p.Row + step.Row, Col: p.Col + step.Col}) {
+		if tile, isNew := placedAt(tiles, p); isNew {
+			premium := b.Grid[p.Row][p.Col].Premium
+			sum += tile.Points * mul.Letter(premium)
+			multiplier *= mul.Word(premium)
+		} else if tile := b.Grid[p.Row][p.Col].Tile; tile != nil {
+			sum += tile.Points
+		}
+		length++
+	}
+	return sum * multiplier, length
+}
+
+// occupiedWith returns true if pos holds a tile on the board or one of the placed tiles
+func (b *Board) occupiedWith(tiles []PlacedTile, pos Position) bool {
+	if _, ok := placedAt(tiles, pos); ok {
+		return true
+	}
+	return b.HasTileAt(pos)
+}
+
+// placedAt returns the tile placed at pos; as with a map of the tiles, the
+// last one wins if a position is used twice
+func placedAt(tiles []PlacedTile, pos Position) (Tile, bool) {
+	for i := len(tiles) - 1; i >= 0; i-- {
+		if tiles[i].Position == pos {
+			return tiles[i].Tile, true
+		}
+	}
+	return Tile{}, false
+}
@@ -0,0 +1,130 @@
+package game
+
+import (
+	"testing"
+)
+
+// TestScoreBasicWord tests basic letter scoring
+func TestScoreBasicWord(t *testing.T) {
+	board := NewBoard()
+	placeWord(t, board, "CAT", "G8", Horizontal)
+
+	// Extend CAT to CATS with S on J8 (normal): C(3) A(1) T(1) S(1)
+	move, _ := board.BuildMove("p1", "CATS", mustPos(t, "G8"), Horizontal, nil)
+	if score := board.ScoreMove(move); score != 6 {
+		t.Errorf("CATS should score 6, got %d", score)
+	}
+}
+
+// TestScorePremiumSquares tests DLS, TLS, DWS and TWS multipliers
+func TestScorePremiumSquares(t *testing.T) {
+	testCases := []struct {
+		name     string
+		word     string
+		start    string
+		dir      Direction
+		expected int
+	}{
+		// C(3) A(1) T(1) with A on H8 (DWS): (3+1+1)*2
+		{"double word center", "CAT", "G8", Horizontal, 10},
+		// Z on D8 (DLS) and O on H8 (DWS), plus the bingo bonus
+		{"double letter and double word", "ZOOLOGY", "D8", Horizontal, (20+1+1+1+1+2+4)*2 + BingoBonus},
+		// QI with Q on G8, I on H8: (10+1)*2
+		{"simple two letter", "QI", "G8", Horizontal, 22},
+	}
+
+	for _, tc := range testCases {
+		board := NewBoard()
+		move, err := board.BuildMove("p1", tc.word, mustPos(t, tc.start), tc.dir, nil)
+		if err != nil {
+			t.Fatalf("%s: BuildMove failed: %v", tc.name, err)
+		}
+		if score := board.ScoreMove(move); score != tc.expected {
+			t.Errorf("%s: expected %d, got %d", tc.name, tc.expected, score)
+		}
+	}
+
+	// Triple word and triple letter squares
+	board := NewBoard()
+	placeWord(t, board, "JAB", "H6", Vertical)
+	// HAJ along row 6 through the existing J, with H on F6 (TLS)
+	move, err := board.BuildMove("p1", "HAJ", mustPos(t, "F6"), Horizontal, nil)
+	if err != nil {
+		t.Fatalf("BuildMove failed: %v", err)
+	}
+	// H(4*3) A(1) J(8, existing)
+	if score := board.ScoreMove(move); score != 21 {
+		t.Errorf("HAJ with H on TLS should score 21, got %d", score)
+	}
+
+	board = NewBoard()
+	placeWord(t, board, "ABCDEFG", "A8", Horizontal)
+	board.RemoveTile(mustPos(t, "A8"))
+	single := Move{Direction: Horizontal, Tiles: []PlacedTile{{Tile: Tile{Letter: 'A', Points: 1}, Position: mustPos(t, "A8")}}}
+	// A(1) B(3) C(3) D(2) E(1) F(4) G(2) = 16, A8 is TWS
+	if score := board.ScoreMove(single); score != 48 {
+		t.Errorf("Word through TWS should score 48, got %d", score)
+	}
+}
+
+// TestScoreMultipleWords tests scoring cross words formed in a single move
+func TestScoreMultipleWords(t *testing.T) {
+	board := NewBoard()
+	placeWord(t, board, "CAT", "G8", Horizontal)
+
+	// AT in row 9 under A and T with T on I9 (DLS): AT (1+2) + AA (1+1) + TT (1+2)
+	move, _ := board.BuildMove("p1", "AT", mustPos(t, "H9"), Horizontal, nil)
+	if score := board.ScoreMove(move); score != 8 {
+		t.Errorf("AT forming AA and TT should score 8, got %d", score)
+	}
+}
+
+// TestScoreBlankTiles tests that blanks score zero
+func TestScoreBlankTiles(t *testing.T) {
+	board := NewBoard()
+	move, _ := board.BuildMove("p1", "QI", mustPos(t, "G8"), Horizontal, []int{0})
+	// Blank Q(0) + I(1) doubled
+	if score := board.ScoreMove(move); score != 2 {
+		t.Errorf("Blank Q in QI should score 2, got %d", score)
+	}
+}
+
+// TestBingoBonus tests the 50 point bonus for playing all seven tiles
+func TestBingoBonus(t *testing.T) {
+	board := NewBoard()
+	move, _ := board.BuildMove("p1", "RETAINS", mustPos(t, "H8"), Horizontal, nil)
+	// All 1 point letters; H8 DWS, K8 normal, L8 DLS (I): (7+1)*2 + 50
+	if score := board.ScoreMove(move); score != 66 {
+		t.Errorf("RETAINS bingo should score 66, got %d", score)
+	}
+
+	move, _ = board.BuildMove("p1", "RETAIN", mustPos(t, "H8"), Horizontal, nil)
+	if score := board.ScoreMove(move); score != 14 {
+		t.Errorf("RETAIN should score 14 with no bingo, got %d", score)
+	}
+}
+
+// TestScoreMoveAllocations tests that scoring does not allocate
+func TestScoreMoveAllocations(t *testing.T) {
+	board, move := parallelPlay(t)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		if score := board.ScoreMove(move); score != 8 {
+			t.Fatalf("AT forming AA and TT should score 8, got %d", score)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("ScoreMove should not allocate, got %.0f allocations", allocs)
+	}
+}
+
+// BenchmarkScoreMove measures scoring a move that forms cross words
+func BenchmarkScoreMove(b *testing.B) {
+	board, move := parallelPlay(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		board.ScoreMove(move)
+	}
+}
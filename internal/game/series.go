@@ -0,0 +1,192 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrSeriesOver is returned when a game is requested from a decided series
+var ErrSeriesOver = errors.New("series is over")
+
+// Series is a run of consecutive games between the same players under the
+// same rules, such as a best-of-N match or a string of rematches
+// Each game rotates the seating by one, so the first move passes to the next
+// player (partnerships are kept in team play). A best-of-N match ends once a
+// player has won more than half of its games, or after N games.
+type Series struct {
+	ID      string
+	BestOf  int       // Games in the match, or 0 for an open-ended series
+	players []*Player // Seating of the first game
+	rules   Rules
+	games   []*Game
+	mu      sync.RWMutex
+}
+
+// SeriesStanding is a player's record over the finished games of a series
+type SeriesStanding struct {
+	PlayerID string `json:"player_id"`
+	Wins     int    `json:"wins"`
+	Spread   int    `json:"spread"` // Cumulative margin; see Game.Spread
+}
+
+// NewSeries creates a series for the given players, seated as listed for the
+// first game; bestOf is the length of the match, or 0 for an open-ended series
+func NewSeries(id string, players []*Player, rules Rules, bestOf int) (*Series, error) {
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+	if bestOf < 0 {
+		return nil, fmt.Errorf("%w: best of %d games", ErrInvalidRules, bestOf)
+	}
+	if len(players) > MaxPlayers {
+		return nil, fmt.Errorf("%w: %d players (maximum %d)", ErrGameFull, len(players), MaxPlayers)
+	}
+	return &Series{ID: id, BestOf: bestOf, players: seatCopies(players), rules: rules}, nil
+}
+
+// NextGame creates the series' next game, waiting to be started
+// Games are named after the series and numbered from 1. Returns
+// ErrGameNotFinished while the previous game is unfinished and ErrSeriesOver
+// once the match is decided.
+func (s *Series) NextGame() (*Game, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if n := len(s.games); n > 0 && !gameOver(s.games[n-1]) {
+		return nil, ErrGameNotFinished
+	}
+	if s.over() {
+		return nil, ErrSeriesOver
+	}
+
+	g, err := NewGameWithRules(fmt.Sprintf("%s-%d", s.ID, len(s.games)+1), rotateSeats(s.players, len(s.games)), s.rules)
+	if err != nil {
+		return nil, err
+	}
+	s.games = append(s.games, g)
+	return g, nil
+}
+
+// Games returns the series' games in the order they were created
+func (s *Series) Games() []*Game {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]*Game(nil), s.games...)
+}
+
+// Standings returns each player's record in the seating of the first game
+func (s *Series) Standings() []SeriesStanding {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.standings()
+}
+
+// standings totals the finished games; callers must hold the lock
+func (s *Series) standings() []SeriesStanding {
+	standings := make([]SeriesStanding, len(s.players))
+	for i, p := range s.players {
+		standings[i].PlayerID = p.ID
+	}
+	for _, g := range s.games {
+		g.mu.RLock()
+		if g.State == Finished {
+			for i := range standings {
+				id := standings[i].PlayerID
+				if g.isOutrightWinner(id) {
+					standings[i].Wins++
+				}
+				standings[i].Spread += g.spread(id)
+			}
+		}
+		g.mu.RUnlock()
+	}
+	return standings
+}
+
+// Winner returns the player who has clinched a best-of-N match, or "" while
+// it is undecided; in team play it is the partner seated first
+func (s *Series) Winner() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.winner()
+}
+
+// winner returns the player with more than half the match's wins; callers
+// must hold the lock
+func (s *Series) winner() string {
+	if s.BestOf == 0 {
+		return ""
+	}
+	for _, st := range s.standings() {
+		if 2*st.Wins > s.BestOf {
+			return st.PlayerID
+		}
+	}
+	return ""
+}
+
+// Over returns true once a best-of-N match is decided or all its games have
+// finished; open-ended series are never over
+func (s *Series) Over() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.over()
+}
+
+// over reports whether the match is complete; callers must hold the lock
+func (s *Series) over() bool {
+	if s.BestOf == 0 {
+		return false
+	}
+	if s.winner() != "" {
+		return true
+	}
+	finished := 0
+	for _, g := range s.games {
+		if gameOver(g) {
+			finished++
+		}
+	}
+	return finished >= s.BestOf
+}
+
+// Rematch creates a new game between the same players under the same rules,
+// with the seating rotated so the next player moves first
+// Returns ErrGameNotFinished until the game has ended.
+func (g *Game) Rematch(id string) (*Game, error) {
+	rules := g.Rules()
+
+	g.mu.RLock()
+	if g.State != Finished {
+		g.mu.RUnlock()
+		return nil, ErrGameNotFinished
+	}
+	players := rotateSeats(g.Players, 1)
+	g.mu.RUnlock()
+
+	return NewGameWithRules(id, players, rules)
+}
+
+// gameOver returns true if the game has finished
+func gameOver(g *Game) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.State == Finished
+}
+
+// rotateSeats returns fresh copies of the players with the seating rotated
+// left by n places
+func rotateSeats(players []*Player, n int) []*Player {
+	seats := seatCopies(players)
+	if len(seats) == 0 {
+		return seats
+	}
+	n %= len(seats)
+	return append(seats[n:], seats[:n]...)
+}
@@ -0,0 +1,152 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+// finishWith starts a game, sets the scores in seating order and ends it
+func finishWith(t *testing.T, g *Game, scores ...int) {
+	t.Helper()
+
+	if err := g.StartGame(); err != nil {
+		t.Fatalf("StartGame failed: %v", err)
+	}
+	for i, s := range scores {
+		g.Players[i].Score = s
+	}
+	if err := g.EndGame(); err != nil {
+		t.Fatalf("EndGame failed: %v", err)
+	}
+}
+
+// TestSeriesBestOf tests a best-of-three match decided in two games
+func TestSeriesBestOf(t *testing.T) {
+	s, err := NewSeries("match", []*Player{NewPlayer("p1", "Ann"), NewPlayer("p2", "Bob")}, DefaultRules(), 3)
+	if err != nil {
+		t.Fatalf("NewSeries failed: %v", err)
+	}
+
+	g1, err := s.NextGame()
+	if err != nil {
+		t.Fatalf("NextGame failed: %v", err)
+	}
+	if g1.ID != "match-1" || g1.Players[0].ID != "p1" {
+		t.Errorf("p1 should move first in match-1, got %s %s", g1.ID, g1.Players[0].ID)
+	}
+	if _, err := s.NextGame(); !errors.Is(err, ErrGameNotFinished) {
+		t.Errorf("Should not start a game before the last one finishes, got %v", err)
+	}
+	finishWith(t, g1, 400, 350)
+
+	g2, err := s.NextGame()
+	if err != nil {
+		t.Fatalf("NextGame failed: %v", err)
+	}
+	if g2.Players[0].ID != "p2" {
+		t.Errorf("p2 should move first in the second game, got %s", g2.Players[0].ID)
+	}
+	if s.Winner() != "" || s.Over() {
+		t.Errorf("One win should not decide a best of three")
+	}
+	// Seated p2, p1
+	finishWith(t, g2, 380, 420)
+
+	if s.Winner() != "p1" || !s.Over() {
+		t.Errorf("p1 should clinch the match, got winner %q", s.Winner())
+	}
+	if _, err := s.NextGame(); !errors.Is(err, ErrSeriesOver) {
+		t.Errorf("Expected ErrSeriesOver, got %v", err)
+	}
+	standings := s.Standings()
+	if standings[0] != (SeriesStanding{PlayerID: "p1", Wins: 2, Spread: 90}) ||
+		standings[1] != (SeriesStanding{PlayerID: "p2", Wins: 0, Spread: -90}) {
+		t.Errorf("Unexpected standings %+v", standings)
+	}
+	if len(s.Games()) != 2 {
+		t.Errorf("Expected 2 games, got %d", len(s.Games()))
+	}
+}
+
+// TestSeriesTies tests that a tied match ends after its last game
+func TestSeriesTies(t *testing.T) {
+	s, _ := NewSeries("tied", []*Player{NewPlayer("p1", "Ann"), NewPlayer("p2", "Bob")}, DefaultRules(), 1)
+
+	g, _ := s.NextGame()
+	finishWith(t, g, 300, 300)
+	if !s.Over() || s.Winner() != "" {
+		t.Errorf("A drawn single game should end the match undecided")
+	}
+}
+
+// TestSeriesOpenEnded tests that rematches rotate through every player
+func TestSeriesOpenEnded(t *testing.T) {
+	players := []*Player{NewPlayer("p1", "Ann"), NewPlayer("p2", "Bob"), NewPlayer("p3", "Cy")}
+	s, _ := NewSeries("open", players, DefaultRules(), 0)
+
+	for i, first := range []string{"p1", "p2", "p3", "p1"} {
+		g, err := s.NextGame()
+		if err != nil {
+			t.Fatalf("NextGame failed: %v", err)
+		}
+		if g.Players[0].ID != first {
+			t.Errorf("Game %d: %s should move first, got %s", i+1, first, g.Players[0].ID)
+		}
+		finishWith(t, g, 10, 20, 30)
+	}
+	if s.Over() {
+		t.Errorf("An open-ended series is never over")
+	}
+	if players[0].Score != 0 {
+		t.Errorf("Series games should not share players with the caller")
+	}
+
+	if _, err := NewSeries("bad", players, DefaultRules(), -1); !errors.Is(err, ErrInvalidRules) {
+		t.Errorf("Expected ErrInvalidRules for a negative match length, got %v", err)
+	}
+}
+
+// TestRematch tests that a rematch keeps the rules and rotates the seating
+func TestRematch(t *testing.T) {
+	rules := DefaultRules()
+	rules.BingoBonus = 35
+	g, _ := NewGameWithRules("first", []*Player{NewPlayer("p1", "Ann"), NewPlayer("p2", "Bob")}, rules)
+
+	if _, err := g.Rematch("second"); !errors.Is(err, ErrGameNotFinished) {
+		t.Errorf("Expected ErrGameNotFinished, got %v", err)
+	}
+	finishWith(t, g, 200, 100)
+
+	r, err := g.Rematch("second")
+	if err != nil {
+		t.Fatalf("Rematch failed: %v", err)
+	}
+	if r.State != WaitingForPlayers || r.Players[0].ID != "p2" || r.Players[1].Score != 0 {
+		t.Errorf("The rematch should seat p2 first with fresh scores, got %+v", r.Players)
+	}
+	if r.Board.BingoBonus != 35 {
+		t.Errorf("The rematch should keep the rules, got bingo bonus %d", r.Board.BingoBonus)
+	}
+}
+
+// TestSpread tests margins against the best opponent and between teams
+func TestSpread(t *testing.T) {
+	g := newTestGame(t, 3)
+	finishWith(t, g, 300, 320, 280)
+	for id, want := range map[string]int{"p1": -20, "p2": 20, "p3": -40} {
+		if got, _ := g.Spread(id); got != want {
+			t.Errorf("%s: expected spread %d, got %d", id, want, got)
+		}
+	}
+	if _, err := g.Spread("p9"); !errors.Is(err, ErrPlayerNotFound) {
+		t.Errorf("Expected ErrPlayerNotFound, got %v", err)
+	}
+
+	teams := newTeamGame(t, 0)
+	for i, s := range []int{100, 50, 30, 60} {
+		teams.Players[i].Score = s
+	}
+	if got, _ := teams.Spread("p3"); got != 20 {
+		t.Errorf("p3's team should lead by 20, got %d", got)
+	}
+}
@@ -0,0 +1,172 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Simul is a simultaneous exhibition, in which one host plays a separate
+// two player game against each of many opponents at once
+// The host is seated first and so moves first in every game. A bot host is
+// attached to all the games; add them to a GameManager to play its turns
+// concurrently on the worker pool.
+type Simul struct {
+	ID     string
+	HostID string
+	games  []*Game // One per opponent, in the order the opponents were given
+}
+
+// SimulBoard is one game of a simul as shown on the host's dashboard
+type SimulBoard struct {
+	GameID        string    `json:"game_id"`
+	OpponentID    string    `json:"opponent_id"`
+	Turn          int       `json:"turn"` // Turns taken so far
+	HostScore     int       `json:"host_score"`
+	OpponentScore int       `json:"opponent_score"`
+	WaitingSince  time.Time `json:"waiting_since"` // When the host's turn began
+	View          GameView  `json:"view"`          // The game as the host sees it
+}
+
+// SimulResult tallies the host's finished games
+type SimulResult struct {
+	Wins    int `json:"wins"`
+	Losses  int `json:"losses"`
+	Draws   int `json:"draws"`
+	Playing int `json:"playing"` // Games not yet finished
+	Spread  int `json:"spread"`  // Host's cumulative margin over the finished games
+}
+
+// NewSimul creates a simul between the host and each opponent, with every
+// game waiting to be started
+// Games are named after the simul and numbered from 1 in the order of the
+// opponents. Team and solo rules are refused, since each game is a duel.
+func NewSimul(id string, host *Player, opponents []*Player, rules Rules) (*Simul, error) {
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+	if rules.Teams != nil || rules.Solo != nil {
+		return nil, fmt.Errorf("%w: simul games are played one against one", ErrInvalidRules)
+	}
+	if len(opponents) == 0 {
+		return nil, fmt.Errorf("%w: a simul needs at least one opponent", ErrNotEnoughPlayers)
+	}
+
+	seen := map[string]bool{host.ID: true}
+	s := &Simul{ID: id, HostID: host.ID, games: make([]*Game, 0, len(opponents))}
+	for i, opp := range opponents {
+		if seen[opp.ID] {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicatePlayer, opp.ID)
+		}
+		seen[opp.ID] = true
+
+		g, err := NewGameWithRules(fmt.Sprintf("%s-%d", id, i+1), seatCopies([]*Player{host, opp}), rules)
+		if err != nil {
+			return nil, err
+		}
+		s.games = append(s.games, g)
+	}
+	return s, nil
+}
+
+// Games returns the simul's games in the order of the opponents
+func (s *Simul) Games() []*Game {
+	return append([]*Game(nil), s.games...)
+}
+
+// Game returns the game against an opponent, or nil if they are not playing
+func (s *Simul) Game(opponentID string) *Game {
+	for _, g := range s.games {
+		if g.GetPlayer(opponentID) != nil {
+			return g
+		}
+	}
+	return nil
+}
+
+// Start deals the opening racks in every game still waiting to start
+func (s *Simul) Start() error {
+	for _, g := range s.games {
+		if err := g.StartGame(); err != nil && !errors.Is(err, ErrGameNotWaiting) {
+			return fmt.Errorf("game %s: %w", g.ID, err)
+		}
+	}
+	return nil
+}
+
+// AttachBot hands the host's seat in every game to a bot playing for the host
+// The bot is shared between the games, so its strategy must be safe for
+// concurrent use when the games are played concurrently.
+func (s *Simul) AttachBot(bot *Bot) error {
+	if bot.PlayerID != s.HostID {
+		return fmt.Errorf("%w: the bot plays for %s, not the host %s", ErrPlayerNotFound, bot.PlayerID, s.HostID)
+	}
+	for _, g := range s.games {
+		if err := g.AttachBot(bot); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Awaiting returns the boards on which it is the host's turn, the longest
+// waiting first
+// Paused games are left out, since the host cannot move in them.
+func (s *Simul) Awaiting() []SimulBoard {
+	var boards []SimulBoard
+	for _, g := range s.games {
+		if b, ok := s.board(g); ok {
+			boards = append(boards, b)
+		}
+	}
+	sort.SliceStable(boards, func(i, j int) bool {
+		return boards[i].WaitingSince.Before(boards[j].WaitingSince)
+	})
+	return boards
+}
+
+// board describes a game for the dashboard, reporting false unless it awaits
+// the host's move
+func (s *Simul) board(g *Game) (SimulBoard, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	host := g.currentPlayer()
+	if host == nil || host.ID != s.HostID || g.Paused != nil {
+		return SimulBoard{}, false
+	}
+	opp := g.Players[1]
+	return SimulBoard{
+		GameID:        g.ID,
+		OpponentID:    opp.ID,
+		Turn:          len(g.History),
+		HostScore:     host.Score,
+		OpponentScore: opp.Score,
+		WaitingSince:  g.turnStarted,
+		View:          g.view(ViewOptions{PlayerID: s.HostID}),
+	}, true
+}
+
+// Result tallies the host's wins, losses and draws so far
+func (s *Simul) Result() SimulResult {
+	var r SimulResult
+	for _, g := range s.games {
+		g.mu.RLock()
+		switch {
+		case g.State != Finished:
+			r.Playing++
+		case g.isOutrightWinner(s.HostID):
+			r.Wins++
+		case g.Forfeit == nil && g.spread(s.HostID) == 0:
+			r.Draws++
+		default:
+			r.Losses++
+		}
+		if g.State == Finished {
+			r.Spread += g.spread(s.HostID)
+		}
+		g.mu.RUnlock()
+	}
+	return r
+}
@@ -0,0 +1,134 @@
+package game
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// newTestSimul creates a simul hosted by h against n opponents
+func newTestSimul(t *testing.T, n int) *Simul {
+	t.Helper()
+	var opponents []*Player
+	for i := 1; i <= n; i++ {
+		id := string(rune('0' + i))
+		opponents = append(opponents, NewPlayer("o"+id, "Opponent "+id))
+	}
+	s, err := NewSimul("simul", NewPlayer("h", "Host"), opponents, DefaultRules())
+	if err != nil {
+		t.Fatalf("NewSimul failed: %v", err)
+	}
+	return s
+}
+
+// TestNewSimul tests seating the host against each opponent
+func TestNewSimul(t *testing.T) {
+	s := newTestSimul(t, 3)
+	games := s.Games()
+	if len(games) != 3 {
+		t.Fatalf("Expected 3 games, got %d", len(games))
+	}
+	for i, g := range games {
+		if g.ID != "simul-"+string(rune('1'+i)) || g.Players[0].ID != "h" || len(g.Players) != 2 {
+			t.Errorf("Game %d should seat the host first, got %s %+v", i, g.ID, g.Players[0])
+		}
+	}
+	if g := s.Game("o2"); g != games[1] {
+		t.Errorf("Should find o2's game")
+	}
+	if s.Game("o9") != nil {
+		t.Errorf("Should not find a game for a stranger")
+	}
+
+	if _, err := NewSimul("bad", NewPlayer("h", "Host"), nil, DefaultRules()); !errors.Is(err, ErrNotEnoughPlayers) {
+		t.Errorf("Expected ErrNotEnoughPlayers, got %v", err)
+	}
+	if _, err := NewSimul("bad", NewPlayer("h", "Host"), []*Player{NewPlayer("h", "Again")}, DefaultRules()); !errors.Is(err, ErrDuplicatePlayer) {
+		t.Errorf("The host should not play themself, got %v", err)
+	}
+	rules := DefaultRules()
+	rules.Teams = &TeamRules{}
+	if _, err := NewSimul("bad", NewPlayer("h", "Host"), []*Player{NewPlayer("o1", "One")}, rules); !errors.Is(err, ErrInvalidRules) {
+		t.Errorf("Team rules should be refused, got %v", err)
+	}
+}
+
+// TestSimulAwaiting tests listing the boards waiting for the host
+func TestSimulAwaiting(t *testing.T) {
+	s := newTestSimul(t, 3)
+	if got := s.Awaiting(); len(got) != 0 {
+		t.Errorf("Games that have not started await nobody, got %d", len(got))
+	}
+	if err := s.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if err := s.Start(); err != nil {
+		t.Errorf("Starting again should leave the games alone, got %v", err)
+	}
+
+	// The host passes in the first game, so it waits for o1
+	games := s.Games()
+	games[0].PassTurn("h")
+	games[2].turnStarted = time.Now().Add(-time.Hour)
+	got := s.Awaiting()
+	if len(got) != 2 || got[0].GameID != "simul-3" || got[1].GameID != "simul-2" {
+		t.Fatalf("Expected simul-3 then simul-2, got %+v", got)
+	}
+	b := got[1]
+	if b.OpponentID != "o2" || b.Turn != 0 || b.View.CurrentPlayerID != "h" || len(b.View.Players[0].Rack) != 7 || len(b.View.Players[1].Rack) != 0 {
+		t.Errorf("Board should show the host's rack only, got %+v", b)
+	}
+
+	games[0].PassTurn("o1")
+	if got := s.Awaiting(); len(got) != 3 {
+		t.Errorf("The host should be to move everywhere, got %d boards", len(got))
+	}
+}
+
+// TestSimulBotHost tests a bot playing every game for the host
+func TestSimulBotHost(t *testing.T) {
+	s := newTestSimul(t, 3)
+	if err := s.AttachBot(NewBot("o1", Greedy, NewMoveGenerator(testWords))); !errors.Is(err, ErrPlayerNotFound) {
+		t.Errorf("A bot for someone else should be refused, got %v", err)
+	}
+	if err := s.AttachBot(NewBot("h", Greedy, NewMoveGenerator(testWords))); err != nil {
+		t.Fatalf("AttachBot failed: %v", err)
+	}
+	s.Start()
+
+	m := NewGameManager(3)
+	defer m.Close()
+	var results []<-chan BotResult
+	for _, g := range s.Games() {
+		m.Add(g)
+		results = append(results, m.PlayBotTurns(g.ID))
+	}
+	for _, r := range results {
+		if res := <-r; res.Err != nil {
+			t.Fatalf("PlayBotTurns failed: %v", res.Err)
+		}
+	}
+	if got := s.Awaiting(); len(got) != 0 {
+		t.Errorf("The bot should have moved in every game, %d boards wait", len(got))
+	}
+}
+
+// TestSimulResult tests tallying the host's results
+func TestSimulResult(t *testing.T) {
+	s := newTestSimul(t, 4)
+	games := s.Games()
+	finishWith(t, games[0], 300, 250)
+	finishWith(t, games[1], 200, 260)
+	finishWith(t, games[2], 280, 280)
+	games[3].StartGame()
+
+	want := SimulResult{Wins: 1, Losses: 1, Draws: 1, Playing: 1, Spread: -10}
+	if got := s.Result(); got != want {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+
+	games[3].Resign("h")
+	if got := s.Result(); got.Losses != 2 || got.Playing != 0 {
+		t.Errorf("A resignation should count as a loss, got %+v", got)
+	}
+}
@@ -0,0 +1,193 @@
+package game
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// Default simulation settings
+const (
+	DefaultSimIterations = 100
+	DefaultSimPlies      = 2
+)
+
+// Simulator ranks candidate moves by playing out random continuations
+// Each iteration deals the opponent a random rack from the unseen tiles, draws
+// replacements for the player from the rest, and has both sides play their
+// best-equity moves for a fixed number of plies. Given a RackModel, the
+// opponent's rack starts from tiles the model believes they kept.
+type Simulator struct {
+	Iterations int             // Continuations played per candidate
+	Plies      int             // Moves played after the candidate, alternating from the opponent
+	Leave      *LeaveEvaluator // Used to choose continuation moves and value the final racks
+	Inference  *RackInference  // Narrows the opponent's rack in Game.Simulate (nil deals it at random)
+	generator  *MoveGenerator
+	rng        *rand.Rand
+}
+
+// NewSimulator creates a simulator whose random choices are determined by seed
+func NewSimulator(generator *MoveGenerator, seed int64) *Simulator {
+	return &Simulator{
+		Iterations: DefaultSimIterations,
+		Plies:      DefaultSimPlies,
+		Leave:      NewLeaveEvaluator(),
+		generator:  generator,
+		rng:        rand.New(rand.NewSource(seed)),
+	}
+}
+
+// SimResult summarizes the simulated outcomes of one candidate move
+type SimResult struct {
+	Move           Move    `json:"move"`
+	Iterations     int     `json:"iterations"`
+	AvgSpread      float64 `json:"avg_spread"`      // Mean final spread from the player's point of view
+	WinProbability float64 `json:"win_probability"` // Fraction of continuations won, counting ties as half
+}
+
+// Simulate plays out each candidate and returns results ordered by win
+// probability, then average spread
+// spread is the player's current lead over the opponent and unseen holds the
+// opponent's rack plus the bag, as reported by a TileTracker.
+func (s *Simulator) Simulate(b *Board, rack, unseen []Tile, candidates []Move, spread int) []SimResult {
+	return s.SimulateWithModel(b, rack, unseen, nil, candidates, spread)
+}
+
+// SimulateWithModel is Simulate with the opponent's rack weighted by model
+// Each continuation deals the opponent one of the model's leaves, then fills
+// the rack from the rest of the unseen tiles; a nil model deals at random.
+func (s *Simulator) SimulateWithModel(b *Board, rack, unseen []Tile, model *RackModel, candidates []Move, spread int) []SimResult {
+	results := make([]SimResult, 0, len(candidates))
+	scratch := &Board{}
+	for _, candidate := range candidates {
+		leave, err := Leave(rack, candidate.Tiles)
+		if err != nil {
+			continue
+		}
+
+		result := SimResult{Move: candidate, Iterations: s.Iterations}
+		wins := 0.0
+		for i := 0; i < s.Iterations; i++ {
+			final := s.playOut(b, scratch, candidate, leave, unseen, model, spread)
+			result.AvgSpread += final
+			switch {
+			case final > 0:
+				wins++
+			case final == 0:
+				wins += 0.5
+			}
+		}
+		if s.Iterations > 0 {
+			result.AvgSpread /= float64(s.Iterations)
+			result.WinProbability = wins / float64(s.Iterations)
+		}
+		results = append(results, result)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].WinProbability != results[j].WinProbability {
+			return results[i].WinProbability > results[j].WinProbability
+		}
+		return results[i].AvgSpread > results[j].AvgSpread
+	})
+	return results
+}
+
+// playOut runs one random continuation of a candidate on a copy of b made in
+// board, and returns the final spread
+func (s *Simulator) playOut(b, board *Board, candidate Move, leave, unseen []Tile, model *RackModel, spread int) float64 {
+	b.CopyTo(board)
+	for _, pt := range candidate.Tiles {
+		board.PlaceTile(pt.Tile, pt.Position)
+	}
+	final := float64(spread + candidate.Score)
+
+	var kept []Tile
+	bag := unseen
+	if model != nil && len(model.Leaves) > 0 {
+		bag, kept = takeTiles(unseen, model.sample(s.rng))
+	} else {
+		bag = append([]Tile{}, unseen...)
+	}
+	s.rng.Shuffle(len(bag), func(i, j int) { bag[i], bag[j] = bag[j], bag[i] })
+
+	draw := func(n int) []Tile {
+		if n > len(bag) {
+			n = len(bag)
+		}
+		drawn := bag[len(bag)-n:]
+		bag = bag[:len(bag)-n]
+		return drawn
+	}
+
+	opponent := append(kept, draw(board.RackSize-len(kept))...)
+	mine := append(append([]Tile{}, leave...), draw(board.RackSize-len(leave))...)
+
+	for ply := 0; ply < s.Plies; ply++ {
+		rack, sign := &opponent, -1.0
+		if ply%2 == 1 {
+			rack, sign = &mine, 1.0
+		}
+
+		ranked := s.Leave.Rank(*rack, s.generator.GenerateMoves(board, *rack))
+		if len(ranked) == 0 {
+			continue // No play available; the side passes
+		}
+		move := ranked[0]
+		for _, pt := range move.Move.Tiles {
+			board.PlaceTile(pt.Tile, pt.Position)
+		}
+		final += sign * float64(move.Move.Score)
+		*rack = append(move.Leave, draw(board.RackSize-len(move.Leave))...)
+		if len(*rack) == 0 {
+			break // The side went out
+		}
+	}
+
+	// Value what each side is left holding
+	return final + s.Leave.Evaluate(mine) - s.Leave.Evaluate(opponent)
+}
+
+// Simulate ranks the player's top candidate moves by simulation
+// Candidates are the player's n best moves by equity; n <= 0 uses DefaultHintCount.
+// With sim.Inference set, the opponent's rack is narrowed from their last turn.
+func (g *Game) Simulate(playerID string, sim *Simulator, n int) ([]SimResult, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if g.State != InProgress {
+		return nil, ErrGameNotInProgress
+	}
+	player := g.findPlayer(playerID)
+	if player == nil {
+		return nil, ErrPlayerNotFound
+	}
+
+	// Spread is measured against the strongest opponent
+	best := 0
+	first := true
+	for _, p := range g.Players {
+		if p.ID != playerID && (first || p.Score > best) {
+			best, first = p.Score, false
+		}
+	}
+
+	ranked := sim.Leave.Rank(player.Rack, sim.generator.GenerateMoves(g.Board, player.Rack))
+	if n <= 0 {
+		n = DefaultHintCount
+	}
+	if n < len(ranked) {
+		ranked = ranked[:n]
+	}
+	candidates := make([]Move, len(ranked))
+	for i, r := range ranked {
+		candidates[i] = r.Move
+		candidates[i].PlayerID = playerID
+	}
+
+	var model *RackModel
+	if sim.Inference != nil {
+		model = g.inferRack(player, sim.Inference)
+	}
+	unseen := g.Board.tileTracker().UnseenTiles(g.Board, player.Rack)
+	return sim.SimulateWithModel(g.Board, player.Rack, unseen, model, candidates, player.Score-best), nil
+}
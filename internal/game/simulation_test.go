@@ -0,0 +1,104 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestSimulate tests ranking candidates by simulated continuations
+func TestSimulate(t *testing.T) {
+	mg := NewMoveGenerator(testWords)
+	b := NewBoard()
+	rack := rackOf("CATSDOG")
+	unseen := NewTileTracker().UnseenTiles(b, rack)
+
+	moves := mg.GenerateMoves(b, rack)
+	if len(moves) < 2 {
+		t.Fatalf("Expected several candidate moves, got %d", len(moves))
+	}
+	best, worst := moves[0], moves[len(moves)-1]
+
+	sim := NewSimulator(mg, 1)
+	sim.Iterations = 20
+	results := sim.Simulate(b, rack, unseen, []Move{worst, best}, 0)
+	if len(results) != 2 {
+		t.Fatalf("Expected a result per candidate, got %d", len(results))
+	}
+
+	for i, r := range results {
+		if r.Iterations != 20 {
+			t.Errorf("Expected 20 iterations, got %d", r.Iterations)
+		}
+		if r.WinProbability < 0 || r.WinProbability > 1 {
+			t.Errorf("Win probability out of range: %f", r.WinProbability)
+		}
+		if i > 0 && r.WinProbability > results[i-1].WinProbability {
+			t.Errorf("Results should be ordered by win probability")
+		}
+	}
+
+	// A big lead should be reflected in the spread
+	ahead := NewSimulator(mg, 1)
+	ahead.Iterations = 20
+	lead := ahead.Simulate(b, rack, unseen, []Move{best}, 200)
+	if lead[0].WinProbability != 1 || lead[0].AvgSpread < 100 {
+		t.Errorf("A 200 point lead should always win, got %+v", lead[0])
+	}
+
+	// Candidates not playable from the rack are skipped
+	bogus := Move{Tiles: []PlacedTile{{Tile: Tile{Letter: 'Z'}, Position: b.Center}}}
+	if r := sim.Simulate(b, rack, unseen, []Move{bogus}, 0); len(r) != 0 {
+		t.Errorf("Unplayable candidate should be skipped")
+	}
+}
+
+// TestSimulateDeterministic tests that equal seeds give equal results
+func TestSimulateDeterministic(t *testing.T) {
+	mg := NewMoveGenerator(testWords)
+	b := NewBoard()
+	rack := rackOf("CATSDOG")
+	unseen := NewTileTracker().UnseenTiles(b, rack)
+	candidates := mg.GenerateMoves(b, rack)[:3]
+
+	run := func() []SimResult {
+		sim := NewSimulator(mg, 7)
+		sim.Iterations = 10
+		return sim.Simulate(b, rack, unseen, candidates, 0)
+	}
+	if !reflect.DeepEqual(run(), run()) {
+		t.Errorf("Simulations with the same seed should match")
+	}
+
+	if !b.IsFirstMove() {
+		t.Errorf("Simulation should not modify the board")
+	}
+}
+
+// TestGameSimulate tests simulating from a player's position in a game
+func TestGameSimulate(t *testing.T) {
+	mg := NewMoveGenerator(testWords)
+	sim := NewSimulator(mg, 3)
+	sim.Iterations = 5
+	g := newTestGame(t, 2)
+
+	if _, err := g.Simulate("p1", sim, 2); err != ErrGameNotInProgress {
+		t.Errorf("Should require a game in progress, got %v", err)
+	}
+
+	g.StartGame()
+	g.Players[0].Rack = rackOf("CATSDOG")
+	results, err := g.Simulate("p1", sim, 2)
+	if err != nil {
+		t.Fatalf("Simulate failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	if results[0].Move.PlayerID != "p1" {
+		t.Errorf("Candidates should be attributed to the player")
+	}
+
+	if _, err := g.Simulate("nobody", sim, 2); err != ErrPlayerNotFound {
+		t.Errorf("Should return ErrPlayerNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package game
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrNotSoloGame is returned for solo operations on games with opponents
+var ErrNotSoloGame = errors.New("game is not a solo game")
+
+// SoloRules configures practice against the clock
+// A single player draws and plays every turn, scoring as many points as they
+// can before Duration runs out. Unplayed tiles are not deducted at the end.
+type SoloRules struct {
+	Duration time.Duration `json:"duration"` // Time allowed for the whole game
+}
+
+// SoloStatus reports the progress of a solo game
+type SoloStatus struct {
+	Score     int           `json:"score"`
+	Plays     int           `json:"plays"`     // Tile placements made
+	Elapsed   time.Duration `json:"elapsed"`   // Time used so far
+	Remaining time.Duration `json:"remaining"` // Time left, never negative
+}
+
+// SoloStatus returns the player's score and time in a solo game
+func (g *Game) SoloStatus() (SoloStatus, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if g.Solo == nil {
+		return SoloStatus{}, ErrNotSoloGame
+	}
+	if len(g.Players) == 0 {
+		return SoloStatus{}, ErrPlayerNotFound
+	}
+
+	player := g.Players[0]
+	left := g.Clock.timeLeft(player.ID)
+	if left < 0 {
+		left = 0
+	}
+	status := SoloStatus{
+		Score:     player.Score,
+		Elapsed:   g.Solo.Duration - left,
+		Remaining: left,
+	}
+	for _, m := range g.History {
+		if m.Type == PlaceTiles && !m.Withdrawn {
+			status.Plays++
+		}
+	}
+	return status, nil
+}
+
+// soloClock returns the countdown timing a solo game
+func soloClock(solo SoloRules) TimeControl {
+	return TimeControl{Base: solo.Duration}
+}
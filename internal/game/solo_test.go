@@ -0,0 +1,127 @@
+package game
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// newSoloGame creates a started solo game for p1, holding CATSDOG, timed by
+// the returned clock
+func newSoloGame(t *testing.T, duration time.Duration) (*Game, *fakeNow) {
+	t.Helper()
+
+	rules := DefaultRules()
+	rules.Solo = &SoloRules{Duration: duration}
+	g, err := NewGameWithRules("solo", []*Player{NewPlayer("p1", "Player 1")}, rules)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	clock := &fakeNow{t: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	g.Clock.now = clock.now
+	if err := g.TileBag.stack(rackOf("CATSDOG")); err != nil {
+		t.Fatalf("Failed to stack bag: %v", err)
+	}
+	if err := g.StartGame(); err != nil {
+		t.Fatalf("Failed to start game: %v", err)
+	}
+	return g, clock
+}
+
+// TestSoloTurns tests that the single player keeps the turn and the status
+// tracks score and time
+func TestSoloTurns(t *testing.T) {
+	g, clock := newSoloGame(t, 5*time.Minute)
+
+	clock.advance(time.Minute)
+	move, _ := g.Board.BuildMove("p1", "CAT", mustPos(t, "H8"), Horizontal, nil)
+	if _, err := g.PlayMove(move); err != nil {
+		t.Fatalf("PlayMove failed: %v", err)
+	}
+	if g.CurrentPlayer().ID != "p1" {
+		t.Errorf("Solo player should keep the turn, got %s", g.CurrentPlayer().ID)
+	}
+
+	clock.advance(30 * time.Second)
+	status, err := g.SoloStatus()
+	if err != nil {
+		t.Fatalf("SoloStatus failed: %v", err)
+	}
+	if status.Score != g.Players[0].Score || status.Plays != 1 {
+		t.Errorf("Status should report the score and one play, got %+v", status)
+	}
+	if status.Elapsed != 90*time.Second || status.Remaining != 3*time.Minute+30*time.Second {
+		t.Errorf("Status should report 1m30s used of 5m, got %+v", status)
+	}
+
+	rules := DefaultRules()
+	rules.Solo = &SoloRules{Duration: time.Minute}
+	waiting, _ := NewGameWithRules("waiting", []*Player{NewPlayer("p1", "Player 1")}, rules)
+	if err := waiting.AddPlayer(NewPlayer("p2", "Player 2")); !errors.Is(err, ErrGameFull) {
+		t.Errorf("Should not seat an opponent, got %v", err)
+	}
+	if _, err := newTestGame(t, 2).SoloStatus(); !errors.Is(err, ErrNotSoloGame) {
+		t.Errorf("Should report that a game is not solo, got %v", err)
+	}
+}
+
+// TestSoloTimeUp tests that the countdown ends the game without deducting the
+// rack
+func TestSoloTimeUp(t *testing.T) {
+	g, clock := newSoloGame(t, time.Minute)
+
+	clock.advance(time.Minute + time.Second)
+	if err := g.PassTurn("p1"); !errors.Is(err, ErrOutOfTime) {
+		t.Errorf("Should refuse moves after time is up, got %v", err)
+	}
+	if g.State != Finished {
+		t.Errorf("Should finish the game, got %v", g.State)
+	}
+	if g.Players[0].Score != 0 {
+		t.Errorf("Should not deduct unplayed tiles, got %d", g.Players[0].Score)
+	}
+	if status, _ := g.SoloStatus(); status.Remaining != 0 || status.Elapsed != time.Minute {
+		t.Errorf("Status should report the whole duration used, got %+v", status)
+	}
+}
+
+// TestSoloRules tests validation and persistence of solo settings
+func TestSoloRules(t *testing.T) {
+	for name, rules := range map[string]Rules{
+		"no duration": {Solo: &SoloRules{}},
+		"teams":       {Solo: &SoloRules{Duration: time.Minute}, Teams: &TeamRules{}},
+		"clock":       {Solo: &SoloRules{Duration: time.Minute}, TimeControl: &TimeControl{Base: time.Minute}},
+		"challenge":   {Solo: &SoloRules{Duration: time.Minute}, ChallengeRule: DoubleChallenge},
+	} {
+		base := DefaultRules()
+		base.Solo, base.Teams, base.TimeControl, base.ChallengeRule = rules.Solo, rules.Teams, rules.TimeControl, rules.ChallengeRule
+		if err := base.Validate(); !errors.Is(err, ErrInvalidRules) {
+			t.Errorf("Should reject solo rules with %s, got %v", name, err)
+		}
+	}
+
+	g, _ := newSoloGame(t, 5*time.Minute)
+	if rules := g.Rules(); rules.Solo == nil || rules.Solo.Duration != 5*time.Minute || rules.TimeControl != nil {
+		t.Errorf("Rules should report the solo duration only, got %+v", rules)
+	}
+
+	data, err := g.Serialize()
+	if err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	loaded, err := LoadGame(data)
+	if err != nil {
+		t.Fatalf("LoadGame failed: %v", err)
+	}
+	if loaded.Solo == nil || loaded.Solo.Duration != 5*time.Minute {
+		t.Errorf("Loaded game should stay solo, got %+v", loaded.Solo)
+	}
+
+	replayed, err := Replay(g.Events(), -1)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if replayed.Solo == nil || replayed.State != InProgress {
+		t.Errorf("Replayed game should be a solo game in progress, got %+v %v", replayed.Solo, replayed.State)
+	}
+}
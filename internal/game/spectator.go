@@ -0,0 +1,139 @@
+package game
+
+// ViewOptions controls what a GameView reveals
+type ViewOptions struct {
+	ShowRacks bool   // Reveal every rack, as for commentators or a finished game
+	PlayerID  string // Reveal only this player's rack and own history details
+}
+
+// PlayerView is a player's public state
+type PlayerView struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Score    int    `json:"score"`
+	RackSize int    `json:"rack_size"`
+	Rack     []Tile `json:"rack,omitempty"` // Only when the options reveal it
+	IsActive bool   `json:"is_active"`
+}
+
+// GameView is a read-only snapshot of a game for spectators
+// Hidden racks are omitted, as are the rack, drawn and exchanged tiles in
+// history entries of players whose racks are hidden.
+type GameView struct {
+	ID              string       `json:"id"`
+	State           GameState    `json:"state"`
+	Board           *Board       `json:"board"`
+	Players         []PlayerView `json:"players"`
+	CurrentPlayerID string       `json:"current_player_id,omitempty"`
+	BagCount        int          `json:"bag_count"`
+	History         History      `json:"history"`
+	Revision        int64        `json:"revision"`
+}
+
+// View returns a snapshot of the game with racks redacted as the options require
+func (g *Game) View(opts ViewOptions) GameView {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.view(opts)
+}
+
+// view builds a GameView; callers must hold the lock
+func (g *Game) view(opts ViewOptions) GameView {
+	v := GameView{
+		ID:       g.ID,
+		State:    g.State,
+		Board:    g.Board.Clone(),
+		Players:  make([]PlayerView, len(g.Players)),
+		BagCount: g.TileBag.RemainingCount(),
+		History:  copyHistory(g.History),
+		Revision: g.Revision,
+	}
+
+	for i, p := range g.Players {
+		v.Players[i] = PlayerView{
+			ID:       p.ID,
+			Name:     p.Name,
+			Score:    p.Score,
+			RackSize: len(p.Rack),
+			IsActive: p.IsActive,
+		}
+		if opts.reveals(p.ID) {
+			v.Players[i].Rack = rackCopy(p.Rack)
+		}
+	}
+	if p := g.currentPlayer(); p != nil {
+		v.CurrentPlayerID = p.ID
+	}
+
+	for i := range v.History {
+		if m := &v.History[i]; !opts.reveals(m.PlayerID) {
+			m.Rack = nil
+			m.Drawn = nil
+			m.Exchanged = nil
+		}
+	}
+	return v
+}
+
+// reveals returns true if the player's rack may be shown
+func (o ViewOptions) reveals(playerID string) bool {
+	return o.ShowRacks || (o.PlayerID != "" && o.PlayerID == playerID)
+}
+
+// Subscription delivers a fresh GameView after every change to a game
+// Updates holds at most one pending view; a slow reader skips to the latest.
+type Subscription struct {
+	Updates <-chan GameView
+	updates chan GameView
+	opts    ViewOptions
+	game    *Game
+}
+
+// Subscribe registers a spectator; the current view is delivered immediately
+func (g *Game) Subscribe(opts ViewOptions) *Subscription {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ch := make(chan GameView, 1)
+	sub := &Subscription{Updates: ch, updates: ch, opts: opts, game: g}
+	ch <- g.view(opts)
+
+	if g.subscribers == nil {
+		g.subscribers = make(map[*Subscription]bool)
+	}
+	g.subscribers[sub] = true
+	return sub
+}
+
+// Close stops updates and closes the Updates channel
+func (s *Subscription) Close() {
+	g := s.game
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.subscribers[s] {
+		delete(g.subscribers, s)
+		close(s.updates)
+	}
+}
+
+// Spectators returns the number of open subscriptions
+func (g *Game) Spectators() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return len(g.subscribers)
+}
+
+// notify sends the latest view to every subscriber, replacing any view they
+// have not yet read; callers must hold the lock
+func (g *Game) notify() {
+	for sub := range g.subscribers {
+		select {
+		case <-sub.updates:
+		default:
+		}
+		sub.updates <- g.view(sub.opts)
+	}
+}
@@ -0,0 +1,92 @@
+package game
+
+import (
+	"testing"
+)
+
+// TestViewRedactsRacks tests that spectator views hide racks unless asked to show them
+func TestViewRedactsRacks(t *testing.T) {
+	g := newTestGame(t, 2)
+	g.StartGame()
+	g.ExchangeTiles("p1", []int{0})
+
+	v := g.View(ViewOptions{})
+	if v.BagCount != g.TileBag.RemainingCount() || v.CurrentPlayerID != "p2" || v.State != InProgress {
+		t.Errorf("View should show bag count, turn and state")
+	}
+	for _, p := range v.Players {
+		if p.Rack != nil || p.RackSize != MaxRackSize {
+			t.Errorf("Spectators should see only the rack size of %s", p.ID)
+		}
+	}
+	if m := v.History[0]; m.Rack != nil || m.Drawn != nil || m.Exchanged != nil {
+		t.Errorf("History should not reveal exchanged or drawn tiles")
+	}
+	if g.History[0].Exchanged == nil {
+		t.Errorf("Redacting the view should not modify the game")
+	}
+
+	all := g.View(ViewOptions{ShowRacks: true})
+	if len(all.Players[0].Rack) != MaxRackSize || all.History[0].Exchanged == nil {
+		t.Errorf("ShowRacks should reveal racks and history details")
+	}
+
+	own := g.View(ViewOptions{PlayerID: "p2"})
+	if own.Players[0].Rack != nil || len(own.Players[1].Rack) != MaxRackSize {
+		t.Errorf("A player's view should reveal only their own rack")
+	}
+}
+
+// TestViewIsSnapshot tests that later changes do not alter a view
+func TestViewIsSnapshot(t *testing.T) {
+	g := newTestGame(t, 2)
+	g.StartGame()
+	v := g.View(ViewOptions{})
+
+	g.Board.PlaceTile(Tile{Letter: 'A', Points: 1}, mustPos(t, "H8"))
+	if v.Board.HasTileAt(mustPos(t, "H8")) {
+		t.Errorf("View should hold a copy of the board")
+	}
+}
+
+// TestSubscribe tests that spectators receive a view after each change
+func TestSubscribe(t *testing.T) {
+	g := newTestGame(t, 2)
+	sub := g.Subscribe(ViewOptions{})
+
+	if v := <-sub.Updates; v.State != WaitingForPlayers {
+		t.Errorf("Should deliver the current view on subscribe, got %v", v.State)
+	}
+
+	g.StartGame()
+	g.PassTurn("p1")
+
+	// Only the latest view is kept for a slow reader
+	v := <-sub.Updates
+	if len(v.History) != 1 || v.CurrentPlayerID != "p2" {
+		t.Errorf("Should deliver the latest view, got %d moves", len(v.History))
+	}
+	if v.Players[0].Rack != nil {
+		t.Errorf("Subscription views should apply the options")
+	}
+	select {
+	case <-sub.Updates:
+		t.Errorf("Stale views should be dropped")
+	default:
+	}
+
+	if g.Spectators() != 1 {
+		t.Errorf("Should count the spectator, got %d", g.Spectators())
+	}
+	sub.Close()
+	sub.Close()
+	if _, ok := <-sub.Updates; ok {
+		t.Errorf("Close should close the updates channel")
+	}
+	if g.Spectators() != 0 {
+		t.Errorf("Close should remove the spectator")
+	}
+
+	// Changes after closing must not panic on the closed channel
+	g.PassTurn("p2")
+}
@@ -0,0 +1,245 @@
+package game
+
+// PlayerStats accumulates a player's performance over one or more games
+type PlayerStats struct {
+	PlayerID         string `json:"player_id"`
+	Games            int    `json:"games"`             // Games the player took part in
+	Finished         int    `json:"finished"`          // Games that reached the end
+	Wins             int    `json:"wins"`              // Finished games with the outright highest score
+	Turns            int    `json:"turns"`             // Plays, exchanges and passes
+	Plays            int    `json:"plays"`             // Tile placements that stood
+	Exchanges        int    `json:"exchanges"`         // Tile exchanges
+	Passes           int    `json:"passes"`            // Passed turns
+	PlayPoints       int    `json:"play_points"`       // Points scored by plays that stood
+	Bingos           int    `json:"bingos"`            // Plays using all seven tiles
+	TilesPlayed      int    `json:"tiles_played"`      // Tiles placed by plays that stood
+	HighestWord      string `json:"highest_word"`      // Main word of the highest scoring play
+	HighestScore     int    `json:"highest_score"`     // Score of the highest scoring play
+	ChallengesWon    int    `json:"challenges_won"`    // Challenges made that removed a phony
+	ChallengesLost   int    `json:"challenges_lost"`   // Challenges made against valid plays
+	PhoniesWithdrawn int    `json:"phonies_withdrawn"` // Own plays withdrawn after a challenge
+	PlaysUpheld      int    `json:"plays_upheld"`      // Own plays that survived a challenge
+	PhoniesPlayed    int    `json:"phonies_played"`    // Own plays forming a word the dictionary did not hold
+	PhoniesStood     int    `json:"phonies_stood"`     // Own phonies that went unchallenged
+	PhoniesMissed    int    `json:"phonies_missed"`    // Opponents' phonies the player let stand
+}
+
+// AverageScore returns the average number of points scored per turn
+func (s PlayerStats) AverageScore() float64 {
+	if s.Turns == 0 {
+		return 0
+	}
+	return float64(s.PlayPoints) / float64(s.Turns)
+}
+
+// PhonyRate returns the fraction of the player's plays that were phonies
+func (s PlayerStats) PhonyRate() float64 {
+	if s.Plays+s.PhoniesWithdrawn == 0 {
+		return 0
+	}
+	return float64(s.PhoniesPlayed) / float64(s.Plays+s.PhoniesWithdrawn)
+}
+
+// DetectionRate returns the fraction of opponents' phonies the player
+// challenged off the board
+func (s PlayerStats) DetectionRate() float64 {
+	if s.ChallengesWon+s.PhoniesMissed == 0 {
+		return 0
+	}
+	return float64(s.ChallengesWon) / float64(s.ChallengesWon+s.PhoniesMissed)
+}
+
+// Add merges another set of stats for the same player into s
+func (s *PlayerStats) Add(other PlayerStats) {
+	s.Games += other.Games
+	s.Finished += other.Finished
+	s.Wins += other.Wins
+	s.Turns += other.Turns
+	s.Plays += other.Plays
+	s.Exchanges += other.Exchanges
+	s.Passes += other.Passes
+	s.PlayPoints += other.PlayPoints
+	s.Bingos += other.Bingos
+	s.TilesPlayed += other.TilesPlayed
+	s.ChallengesWon += other.ChallengesWon
+	s.ChallengesLost += other.ChallengesLost
+	s.PhoniesWithdrawn += other.PhoniesWithdrawn
+	s.PlaysUpheld += other.PlaysUpheld
+	s.PhoniesPlayed += other.PhoniesPlayed
+	s.PhoniesStood += other.PhoniesStood
+	s.PhoniesMissed += other.PhoniesMissed
+	if other.HighestScore > s.HighestScore {
+		s.HighestScore = other.HighestScore
+		s.HighestWord = other.HighestWord
+	}
+}
+
+// PlayerStats returns the statistics for one player in this game
+func (g *Game) PlayerStats(playerID string) (PlayerStats, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if g.findPlayer(playerID) == nil {
+		return PlayerStats{}, ErrPlayerNotFound
+	}
+	return g.playerStats(playerID), nil
+}
+
+// playerStats computes a player's statistics from the history; callers must hold the lock
+func (g *Game) playerStats(playerID string) PlayerStats {
+	stats := PlayerStats{PlayerID: playerID, Games: 1}
+
+	for i, m := range g.History {
+		// A phony stands once it can no longer be challenged
+		stood := len(m.Phonies) > 0 && !m.Withdrawn && (i < len(g.History)-1 || !g.challengeable)
+		if stood && m.PlayerID != playerID && !g.isPartner(playerID, m.PlayerID) {
+			stats.PhoniesMissed++
+		}
+
+		if m.Type == ChallengeTurn && m.Challenge != nil {
+			switch {
+			case m.Challenge.ChallengerID == playerID && m.Challenge.Successful:
+				stats.ChallengesWon++
+			case m.Challenge.ChallengerID == playerID:
+				stats.ChallengesLost++
+			case m.Challenge.ChallengedID == playerID && m.Challenge.Successful:
+				stats.PhoniesWithdrawn++
+			case m.Challenge.ChallengedID == playerID:
+				stats.PlaysUpheld++
+			}
+			continue
+		}
+		if m.PlayerID != playerID {
+			continue
+		}
+
+		stats.Turns++
+		switch m.Type {
+		case Exchange:
+			stats.Exchanges++
+		case Pass:
+			stats.Passes++
+		case PlaceTiles:
+			if len(m.Phonies) > 0 {
+				stats.PhoniesPlayed++
+			}
+			if stood {
+				stats.PhoniesStood++
+			}
+			if m.Withdrawn {
+				continue
+			}
+			stats.Plays++
+			stats.PlayPoints += m.Score
+			stats.TilesPlayed += len(m.Tiles)
+			if len(m.Tiles) == g.Board.RackSize {
+				stats.Bingos++
+			}
+			if m.Score > stats.HighestScore {
+				stats.HighestScore = m.Score
+				stats.HighestWord = m.Word
+			}
+		}
+	}
+
+	if g.State == Finished {
+		stats.Finished = 1
+		if g.isOutrightWinner(playerID) {
+			stats.Wins = 1
+		}
+	}
+	return stats
+}
+
+// isOutrightWinner returns true if the player's score beats every other
+// player's, or in team play if their team's beats the other team's; callers
+// must hold the lock
+// A player who forfeited never wins, and their score is not counted against
+// the others.
+func (g *Game) isOutrightWinner(playerID string) bool {
+	if g.forfeited(playerID) {
+		return false
+	}
+	if g.Teams != nil {
+		if g.Forfeit != nil {
+			return true
+		}
+		scores := g.teamScores()
+		team := g.seatOf(playerID) % 2
+		return scores[team].Score > scores[1-team].Score
+	}
+
+	player := g.findPlayer(playerID)
+	for _, p := range g.Players {
+		if p.ID != playerID && !g.forfeited(p.ID) && p.Score >= player.Score {
+			return false
+		}
+	}
+	return true
+}
+
+// Spread returns a player's winning margin in the game: their score less the
+// best opposing score, or in team play their team's score less the other
+// team's
+func (g *Game) Spread(playerID string) (int, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if g.findPlayer(playerID) == nil {
+		return 0, ErrPlayerNotFound
+	}
+	return g.spread(playerID), nil
+}
+
+// spread computes a player's margin; callers must hold the lock
+func (g *Game) spread(playerID string) int {
+	if g.Teams != nil {
+		scores := g.teamScores()
+		team := g.seatOf(playerID) % 2
+		return scores[team].Score - scores[1-team].Score
+	}
+
+	player := g.findPlayer(playerID)
+	best, found := 0, false
+	for _, p := range g.Players {
+		if p.ID != playerID && (!found || p.Score > best) {
+			best, found = p.Score, true
+		}
+	}
+	return player.Score - best
+}
+
+// Standing is a player's final score in a finished game
+type Standing struct {
+	PlayerID  string `json:"player_id"`
+	Score     int    `json:"score"`
+	Forfeited bool   `json:"forfeited,omitempty"` // Lost by resigning or abandoning, whatever the score
+}
+
+// FinalStandings returns every player's final score in seating order
+// Returns ErrGameNotFinished until the game has ended.
+func (g *Game) FinalStandings() ([]Standing, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if g.State != Finished {
+		return nil, ErrGameNotFinished
+	}
+	standings := make([]Standing, len(g.Players))
+	for i, p := range g.Players {
+		standings[i] = Standing{PlayerID: p.ID, Score: p.Score, Forfeited: g.forfeited(p.ID)}
+	}
+	return standings, nil
+}
+
+// AggregateStats combines a player's statistics across a series of games
+// Games the player did not take part in are ignored.
+func AggregateStats(playerID string, games ...*Game) PlayerStats {
+	total := PlayerStats{PlayerID: playerID}
+	for _, g := range games {
+		if stats, err := g.PlayerStats(playerID); err == nil {
+			total.Add(stats)
+		}
+	}
+	return total
+}
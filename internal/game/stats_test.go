@@ -0,0 +1,164 @@
+package game
+
+import (
+	"testing"
+)
+
+// TestPlayerStats tests per-game statistics including the challenge record
+func TestPlayerStats(t *testing.T) {
+	g := newChallengeGame(t)
+
+	g.Players[0].Rack = rackOf("CATXYZV")
+	cat, _ := g.Board.BuildMove("p1", "CAT", mustPos(t, "G8"), Horizontal, nil)
+	if _, err := g.PlayMove(cat); err != nil {
+		t.Fatalf("CAT failed: %v", err)
+	}
+
+	// p2 makes CATS and survives p1's challenge, so p1 loses a turn
+	g.Players[1].Rack = rackOf("SXEEIOU")
+	cats, _ := g.Board.BuildMove("p2", "CATS", mustPos(t, "G8"), Horizontal, nil)
+	if _, err := g.PlayMove(cats); err != nil {
+		t.Fatalf("CATS failed: %v", err)
+	}
+	if _, err := g.Challenge("p1"); err != nil {
+		t.Fatalf("Challenge failed: %v", err)
+	}
+
+	// p2 plays a phony and p1 challenges it off
+	g.Players[1].Rack = rackOf("XEEIOUA")
+	phony, _ := g.Board.BuildMove("p2", "CATSX", mustPos(t, "G8"), Horizontal, nil)
+	if _, err := g.PlayMove(phony); err != nil {
+		t.Fatalf("Phony failed: %v", err)
+	}
+	if _, err := g.Challenge("p1"); err != nil {
+		t.Fatalf("Challenge failed: %v", err)
+	}
+
+	if err := g.ExchangeTiles("p1", []int{0}); err != nil {
+		t.Fatalf("Exchange failed: %v", err)
+	}
+	if err := g.PassTurn("p2"); err != nil {
+		t.Fatalf("Pass failed: %v", err)
+	}
+
+	p1, err := g.PlayerStats("p1")
+	if err != nil {
+		t.Fatalf("PlayerStats failed: %v", err)
+	}
+	expected1 := PlayerStats{PlayerID: "p1", Games: 1, Turns: 2, Plays: 1, Exchanges: 1, PlayPoints: 10,
+		TilesPlayed: 3, HighestWord: "CAT", HighestScore: 10, ChallengesWon: 1, ChallengesLost: 1}
+	if p1 != expected1 {
+		t.Errorf("p1 stats:\nexpected %+v\ngot      %+v", expected1, p1)
+	}
+	if p1.AverageScore() != 5 {
+		t.Errorf("Expected average 5 per turn, got %f", p1.AverageScore())
+	}
+
+	p2, _ := g.PlayerStats("p2")
+	expected2 := PlayerStats{PlayerID: "p2", Games: 1, Turns: 3, Plays: 1, Passes: 1, PlayPoints: 6,
+		TilesPlayed: 1, HighestWord: "CATS", HighestScore: 6, PhoniesWithdrawn: 1, PlaysUpheld: 1, PhoniesPlayed: 1}
+	if p2 != expected2 {
+		t.Errorf("p2 stats:\nexpected %+v\ngot      %+v", expected2, p2)
+	}
+
+	if _, err := g.PlayerStats("nobody"); err != ErrPlayerNotFound {
+		t.Errorf("Should return ErrPlayerNotFound, got %v", err)
+	}
+}
+
+// TestPhonyStats tests counting phonies that stood and the opponent who missed them
+func TestPhonyStats(t *testing.T) {
+	g := newChallengeGame(t)
+
+	g.Players[0].Rack = rackOf("CATXYZV")
+	cat, _ := g.Board.BuildMove("p1", "CAT", mustPos(t, "G8"), Horizontal, nil)
+	g.PlayMove(cat)
+
+	g.Players[1].Rack = rackOf("XEEIOUA")
+	phony, _ := g.Board.BuildMove("p2", "CATX", mustPos(t, "G8"), Horizontal, nil)
+	if _, err := g.PlayMove(phony); err != nil {
+		t.Fatalf("Phony failed: %v", err)
+	}
+	if last, _ := g.History.Last(); len(last.Phonies) != 1 || last.Phonies[0] != "CATX" {
+		t.Errorf("Should record the phony when played, got %v", last.Phonies)
+	}
+
+	// Nothing has stood while the phony can still be challenged
+	if p2, _ := g.PlayerStats("p2"); p2.PhoniesPlayed != 1 || p2.PhoniesStood != 0 {
+		t.Errorf("A phony open to challenge has not stood, got %+v", p2)
+	}
+
+	if err := g.PassTurn("p1"); err != nil {
+		t.Fatalf("Pass failed: %v", err)
+	}
+	p1, _ := g.PlayerStats("p1")
+	p2, _ := g.PlayerStats("p2")
+	if p2.PhoniesStood != 1 || p2.PhonyRate() != 1 || p1.PhoniesMissed != 1 || p1.DetectionRate() != 0 {
+		t.Errorf("Expected p2's phony to stand and p1 to miss it, got %+v and %+v", p2, p1)
+	}
+	if p1.PhoniesPlayed != 0 || p2.PhoniesMissed != 0 {
+		t.Errorf("Valid plays are not phonies, got %+v and %+v", p1, p2)
+	}
+}
+
+// TestAggregateStats tests combining statistics across games
+func TestAggregateStats(t *testing.T) {
+	first := newTestGame(t, 2)
+	first.StartGame()
+	first.Players[0].Rack = rackOf("CATSDOG")
+	bingo, _ := first.Board.BuildMove("p1", "CATSDOG", mustPos(t, "H8"), Horizontal, nil)
+	bingoScore, err := first.PlayMove(bingo)
+	if err != nil {
+		t.Fatalf("Bingo failed: %v", err)
+	}
+	first.EndGame()
+
+	second := newTestGame(t, 2)
+	second.StartGame()
+	second.Players[0].Rack = rackOf("CAT")
+	cat, _ := second.Board.BuildMove("p1", "CAT", mustPos(t, "H8"), Horizontal, nil)
+	second.PlayMove(cat)
+
+	other, _ := NewGame("other", []*Player{NewPlayer("x", "X"), NewPlayer("y", "Y")})
+
+	total := AggregateStats("p1", first, second, other)
+	if total.Games != 2 || total.Finished != 1 || total.Wins != 1 {
+		t.Errorf("Expected 2 games, 1 finished and 1 win, got %+v", total)
+	}
+	if total.Bingos != 1 || total.TilesPlayed != 10 || total.Turns != 2 {
+		t.Errorf("Unexpected totals: %+v", total)
+	}
+	if total.HighestWord != "CATSDOG" || total.HighestScore != bingoScore {
+		t.Errorf("Highest play should be the bingo, got %s for %d", total.HighestWord, total.HighestScore)
+	}
+	if total.PlayPoints != bingoScore+10 {
+		t.Errorf("Expected %d points, got %d", bingoScore+10, total.PlayPoints)
+	}
+
+	// Ties are not wins
+	tied := newTestGame(t, 2)
+	tied.StartGame()
+	tied.EndGame()
+	if s, _ := tied.PlayerStats("p1"); s.Wins != 0 || s.Finished != 1 {
+		t.Errorf("A tied game should be finished but not won, got %+v", s)
+	}
+}
+
+// TestFinalStandings tests reporting final scores once a game ends
+func TestFinalStandings(t *testing.T) {
+	g := newTestGame(t, 3)
+	g.StartGame()
+	if _, err := g.FinalStandings(); err != ErrGameNotFinished {
+		t.Errorf("Should return ErrGameNotFinished, got %v", err)
+	}
+
+	g.Players[1].Score = 42
+	g.EndGame()
+	standings, err := g.FinalStandings()
+	if err != nil {
+		t.Fatalf("FinalStandings failed: %v", err)
+	}
+	if len(standings) != 3 || standings[1] != (Standing{PlayerID: "p2", Score: 42}) {
+		t.Errorf("Unexpected standings: %+v", standings)
+	}
+}
@@ -0,0 +1,102 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrNoMove is returned by a Strategy that finds no move worth playing; the
+// player then passes
+var ErrNoMove = errors.New("no move found")
+
+// Strategy chooses moves for a computer-controlled player
+// It sees the game as the player would, through a view revealing only the
+// player to move's rack, so engines outside this package, such as neural
+// networks or external programs, can play without access to the game itself.
+// The move is validated like any other; an illegal move stops the bot turns
+// with the validation error.
+type Strategy interface {
+	ChooseMove(ctx context.Context, view GameView) (Move, error)
+}
+
+// NewGreedyStrategy returns the reference Strategy that plays the highest
+// scoring move
+func NewGreedyStrategy(generator *MoveGenerator) Strategy {
+	return botStrategy{NewBot("", Greedy, generator)}
+}
+
+// NewEquityStrategy returns the reference Strategy that plays the move with
+// the best score plus leave value, using the default leave values when leave
+// is nil
+func NewEquityStrategy(generator *MoveGenerator, leave *LeaveEvaluator) Strategy {
+	bot := NewBot("", Equity, generator)
+	if leave != nil {
+		bot.Leave = leave
+	}
+	return botStrategy{bot}
+}
+
+// NewAdaptiveStrategy returns a Strategy that keeps the game close, steering
+// its spread over the leading opponent toward target
+func NewAdaptiveStrategy(generator *MoveGenerator, target int) Strategy {
+	bot := NewBot("", Adaptive, generator)
+	bot.Target = target
+	return botStrategy{bot}
+}
+
+// NewBluffingStrategy returns an equity Strategy that plays a plausible phony
+// from phonies on the given fraction of its turns, for challenge training in
+// games under the double challenge rule
+func NewBluffingStrategy(generator, phonies *MoveGenerator, rate float64) Strategy {
+	bot := NewBot("", Equity, generator)
+	bot.Phonies = phonies
+	bot.Bluff = rate
+	return botStrategy{bot}
+}
+
+// botStrategy adapts a Bot to the Strategy interface
+type botStrategy struct {
+	bot *Bot
+}
+
+// ChooseMove picks the bot's move for the rack of the player to move
+func (s botStrategy) ChooseMove(ctx context.Context, view GameView) (Move, error) {
+	if err := ctx.Err(); err != nil {
+		return Move{}, err
+	}
+	rack := view.CurrentRack()
+	if rack == nil {
+		return Move{}, fmt.Errorf("view does not reveal the rack of %q", view.CurrentPlayerID)
+	}
+	move, found := s.bot.choose(view.Board, rack, paceOf(view, view.CurrentPlayerID))
+	if !found {
+		return Move{}, ErrNoMove
+	}
+	move.PlayerID = view.CurrentPlayerID
+	return move, nil
+}
+
+// String returns the name of the bot's strategy
+func (s botStrategy) String() string {
+	return s.bot.Strategy.String()
+}
+
+// CurrentRack returns the rack of the player to move, or nil if the view
+// does not reveal it
+func (v GameView) CurrentRack() []Tile {
+	for _, p := range v.Players {
+		if p.ID == v.CurrentPlayerID {
+			return p.Rack
+		}
+	}
+	return nil
+}
+
+// strategyName returns a name for a strategy in traces
+func strategyName(s Strategy) string {
+	if named, ok := s.(fmt.Stringer); ok {
+		return named.String()
+	}
+	return fmt.Sprintf("%T", s)
+}
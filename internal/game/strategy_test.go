@@ -0,0 +1,112 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// strategyFunc adapts a function to the Strategy interface
+type strategyFunc func(ctx context.Context, view GameView) (Move, error)
+
+func (f strategyFunc) ChooseMove(ctx context.Context, view GameView) (Move, error) {
+	return f(ctx, view)
+}
+
+// TestAttachStrategy tests a custom strategy playing through the game loop
+func TestAttachStrategy(t *testing.T) {
+	g := newTestGame(t, 2)
+	g.StartGame()
+	g.Players[0].Rack = rackOf("CATXYZV")
+
+	var seen GameView
+	custom := strategyFunc(func(ctx context.Context, view GameView) (Move, error) {
+		seen = view
+		return view.Board.BuildMove("", "CAT", Position{Row: 7, Col: 7}, Horizontal, nil)
+	})
+	if err := g.AttachStrategy("nobody", custom); !errors.Is(err, ErrPlayerNotFound) {
+		t.Errorf("Attaching to an unknown player should fail, got %v", err)
+	}
+	if err := g.AttachStrategy("p1", custom); err != nil {
+		t.Fatalf("AttachStrategy failed: %v", err)
+	}
+	if !g.IsBot("p1") {
+		t.Errorf("A player with a strategy should count as a bot")
+	}
+
+	played, err := g.PlayBotTurns()
+	if err != nil {
+		t.Fatalf("PlayBotTurns failed: %v", err)
+	}
+	if len(played) != 1 || played[0].PlayerID != "p1" || played[0].Word != "CAT" {
+		t.Errorf("Strategy's move should be played for p1, got %v", played)
+	}
+	if rackLetters(seen.CurrentRack()) != "CATXYZV" || seen.Players[1].Rack != nil {
+		t.Errorf("Strategy should see only its own rack, got %v", seen.Players)
+	}
+}
+
+// TestStrategyOutcomes tests passing, errors and illegal moves from strategies
+func TestStrategyOutcomes(t *testing.T) {
+	g := newTestGame(t, 2)
+	g.StartGame()
+	g.AttachStrategy("p1", strategyFunc(func(context.Context, GameView) (Move, error) {
+		return Move{}, ErrNoMove
+	}))
+	if played, err := g.PlayBotTurns(); err != nil || len(played) != 1 || played[0].Type != Pass {
+		t.Errorf("A strategy with no move should pass, got %v, %v", played, err)
+	}
+
+	engineDown := errors.New("engine crashed")
+	g.AttachStrategy("p2", strategyFunc(func(context.Context, GameView) (Move, error) {
+		return Move{}, engineDown
+	}))
+	if _, err := g.PlayBotTurns(); !errors.Is(err, engineDown) {
+		t.Errorf("Strategy errors should stop the bot turns, got %v", err)
+	}
+
+	g.AttachStrategy("p2", strategyFunc(func(context.Context, GameView) (Move, error) {
+		return Move{Tiles: []PlacedTile{{Tile: Tile{Letter: 'Q', Points: 10}, Position: Position{Row: 0, Col: 0}}}}, nil
+	}))
+	if _, err := g.PlayBotTurns(); err == nil || g.CurrentPlayer().ID != "p2" {
+		t.Errorf("An illegal move should be rejected without ending the turn, got %v", err)
+	}
+}
+
+// TestReferenceStrategies tests the greedy and equity reference strategies
+func TestReferenceStrategies(t *testing.T) {
+	mg := NewMoveGenerator(testWords)
+	g := newTestGame(t, 2)
+	g.StartGame()
+	g.Players[0].Rack = rackOf("SDOGVVW")
+	view := g.View(ViewOptions{PlayerID: "p1"})
+
+	for name, s := range map[string]Strategy{
+		"GREEDY": NewGreedyStrategy(mg),
+		"EQUITY": NewEquityStrategy(mg, nil),
+	} {
+		move, err := s.ChooseMove(context.Background(), view)
+		if err != nil {
+			t.Fatalf("%s: ChooseMove failed: %v", name, err)
+		}
+		if move.PlayerID != "p1" || move.Word == "" {
+			t.Errorf("%s: should choose a move for p1, got %+v", name, move)
+		}
+		if strategyName(s) != name {
+			t.Errorf("Strategy should be named %s, got %s", name, strategyName(s))
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := NewGreedyStrategy(mg).ChooseMove(ctx, view); !errors.Is(err, context.Canceled) {
+		t.Errorf("A cancelled search should fail, got %v", err)
+	}
+	if _, err := NewGreedyStrategy(mg).ChooseMove(context.Background(), g.View(ViewOptions{})); err == nil {
+		t.Errorf("A view hiding the rack should fail")
+	}
+	g.Players[0].Rack = rackOf("VVVWWXX")
+	if _, err := NewGreedyStrategy(mg).ChooseMove(context.Background(), g.View(ViewOptions{PlayerID: "p1"})); !errors.Is(err, ErrNoMove) {
+		t.Errorf("A rack with no play should give ErrNoMove, got %v", err)
+	}
+}
@@ -0,0 +1,200 @@
+package game
+
+// Symmetry is one of the eight ways of rotating or reflecting the board onto
+// itself
+type Symmetry int
+
+const (
+	Identity         Symmetry = iota
+	Rotate90                  // Quarter turn clockwise
+	Rotate180                 // Half turn
+	Rotate270                 // Quarter turn anticlockwise
+	FlipRows                  // Mirror top to bottom
+	FlipColumns               // Mirror left to right
+	FlipDiagonal              // Mirror in the diagonal from A1 to O15, swapping rows and columns
+	FlipAntiDiagonal          // Mirror in the diagonal from O1 to A15
+)
+
+// AllSymmetries lists every symmetry, Identity first
+var AllSymmetries = []Symmetry{Identity, Rotate90, Rotate180, Rotate270, FlipRows, FlipColumns, FlipDiagonal, FlipAntiDiagonal}
+
+// String returns a string representation of the symmetry
+func (s Symmetry) String() string {
+	switch s {
+	case Identity:
+		return "IDENTITY"
+	case Rotate90:
+		return "ROTATE_90"
+	case Rotate180:
+		return "ROTATE_180"
+	case Rotate270:
+		return "ROTATE_270"
+	case FlipRows:
+		return "FLIP_ROWS"
+	case FlipColumns:
+		return "FLIP_COLUMNS"
+	case FlipDiagonal:
+		return "FLIP_DIAGONAL"
+	case FlipAntiDiagonal:
+		return "FLIP_ANTI_DIAGONAL"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// Apply returns the square that pos moves to under the symmetry
+func (s Symmetry) Apply(pos Position) Position {
+	r, c := pos.Row, pos.Col
+	switch s {
+	case Rotate90:
+		return Position{Row: c, Col: 14 - r}
+	case Rotate180:
+		return Position{Row: 14 - r, Col: 14 - c}
+	case Rotate270:
+		return Position{Row: 14 - c, Col: r}
+	case FlipRows:
+		return Position{Row: 14 - r, Col: c}
+	case FlipColumns:
+		return Position{Row: r, Col: 14 - c}
+	case FlipDiagonal:
+		return Position{Row: c, Col: r}
+	case FlipAntiDiagonal:
+		return Position{Row: 14 - c, Col: 14 - r}
+	default:
+		return pos
+	}
+}
+
+// Inverse returns the symmetry that undoes this one
+func (s Symmetry) Inverse() Symmetry {
+	switch s {
+	case Rotate90:
+		return Rotate270
+	case Rotate270:
+		return Rotate90
+	default:
+		return s
+	}
+}
+
+// Transform returns the layout rotated or reflected by the symmetry
+func (l Layout) Transform(s Symmetry) Layout {
+	var out Layout
+	for row := 0; row < 15; row++ {
+		for col := 0; col < 15; col++ {
+			to := s.Apply(Position{Row: row, Col: col})
+			out[to.Row][to.Col] = l[row][col]
+		}
+	}
+	return out
+}
+
+// Transform returns a copy of the board rotated or reflected by the
+// symmetry, tiles, premium squares and center alike
+// A board whose premium layout changes is given it as its custom layout, so
+// ValidateBoard still accepts it.
+func (b *Board) Transform(s Symmetry) *Board {
+	t := &Board{}
+	*t = *b
+	t.occupied = [15]uint16{}
+
+	n := 0
+	for row := 0; row < 15; row++ {
+		for col := 0; col < 15; col++ {
+			if b.Grid[row][col].Tile != nil {
+				n++
+			}
+		}
+	}
+	t.tiles = make([]Tile, 0, n)
+
+	for row := 0; row < 15; row++ {
+		for col := 0; col < 15; col++ {
+			sq := b.Grid[row][col]
+			if sq.Tile != nil {
+				t.tiles = append(t.tiles, *sq.Tile)
+				sq.Tile = &t.tiles[len(t.tiles)-1]
+			}
+			to := s.Apply(Position{Row: row, Col: col})
+			t.Grid[to.Row][to.Col] = sq
+			if sq.Occupied {
+				t.occupied[to.Row] |= 1 << to.Col
+			}
+		}
+	}
+	t.Center = s.Apply(b.Center)
+	t.rehash()
+
+	if layout := b.PremiumLayout(); layout.Transform(s) != layout {
+		transformed := layout.Transform(s)
+		t.CustomLayout = &transformed
+	}
+	return t
+}
+
+// Transpose returns a copy of the board with rows and columns swapped, so
+// that plays down the original board read across the new one
+func (b *Board) Transpose() *Board {
+	return b.Transform(FlipDiagonal)
+}
+
+// Symmetries returns the symmetries that leave the board's premium squares
+// and center where they are, Identity first
+// Boards related by one of these are the same position for play.
+func (b *Board) Symmetries() []Symmetry {
+	layout := b.PremiumLayout()
+	var syms []Symmetry
+	for _, s := range AllSymmetries {
+		if s.Apply(b.Center) == b.Center && layout.Transform(s) == layout {
+			syms = append(syms, s)
+		}
+	}
+	return syms
+}
+
+// Canonical returns the board's canonical form and the symmetry taking the
+// board to it
+// Boards that differ only by one of their Symmetries share a canonical form,
+// the transformed board that sorts first read row by row, so it may be used to
+// recognize the same position reached in another orientation.
+func (b *Board) Canonical() (*Board, Symmetry) {
+	best := Identity
+	for _, s := range b.Symmetries()[1:] {
+		if b.compareTransformed(s, best) < 0 {
+			best = s
+		}
+	}
+	return b.Transform(best), best
+}
+
+// compareTransformed compares the tiles of the board transformed by s and by
+// t, square by square in row order
+func (b *Board) compareTransformed(s, t Symmetry) int {
+	sInv, tInv := s.Inverse(), t.Inverse()
+	for row := 0; row < 15; row++ {
+		for col := 0; col < 15; col++ {
+			pos := Position{Row: row, Col: col}
+			p, q := sInv.Apply(pos), tInv.Apply(pos)
+			if a, c := squareOrder(&b.Grid[p.Row][p.Col]), squareOrder(&b.Grid[q.Row][q.Col]); a != c {
+				if a < c {
+					return -1
+				}
+				return 1
+			}
+		}
+	}
+	return 0
+}
+
+// squareOrder ranks a square's contents for Canonical: empty squares first,
+// then tiles by letter, a blank after the tile it stands for
+func squareOrder(sq *Square) int64 {
+	if sq.IsEmpty() {
+		return 0
+	}
+	order := int64(sq.Tile.Letter)*2 + 1
+	if sq.Tile.IsBlank {
+		order++
+	}
+	return order
+}
@@ -0,0 +1,156 @@
+package game
+
+import (
+	"testing"
+)
+
+// TestSymmetryApply tests that every symmetry maps the board onto itself and is undone by its inverse
+func TestSymmetryApply(t *testing.T) {
+	for _, s := range AllSymmetries {
+		seen := make(map[Position]bool)
+		for row := 0; row < 15; row++ {
+			for col := 0; col < 15; col++ {
+				pos := Position{Row: row, Col: col}
+				to := s.Apply(pos)
+				if !to.IsValid() || seen[to] {
+					t.Fatalf("%s should map squares one to one, got %s for %s", s, to, pos)
+				}
+				seen[to] = true
+				if back := s.Inverse().Apply(to); back != pos {
+					t.Errorf("%s inverse should restore %s, got %s", s, pos, back)
+				}
+			}
+		}
+		if s.Apply(Position{Row: 7, Col: 7}) != (Position{Row: 7, Col: 7}) {
+			t.Errorf("%s should keep the center in place", s)
+		}
+	}
+
+	if got := Rotate90.Apply(mustPos(t, "A1")); got != mustPos(t, "O1") {
+		t.Errorf("A quarter turn should take A1 to O1, got %s", got)
+	}
+	if got := FlipDiagonal.Apply(mustPos(t, "B1")); got != mustPos(t, "A2") {
+		t.Errorf("Transposing should take B1 to A2, got %s", got)
+	}
+	if Symmetry(99).String() != "UNKNOWN" || FlipAntiDiagonal.String() != "FLIP_ANTI_DIAGONAL" {
+		t.Errorf("Unexpected symmetry names")
+	}
+}
+
+// TestBoardTranspose tests transposing boards with standard and custom layouts
+func TestBoardTranspose(t *testing.T) {
+	board := NewBoard()
+	placeWord(t, board, "CAT", "G8", Horizontal)
+
+	tr := board.Transpose()
+	for i, pos := range []string{"H7", "H8", "H9"} {
+		tile := tr.GetTile(mustPos(t, pos))
+		if tile == nil || tile.Letter != rune("CAT"[i]) {
+			t.Errorf("Transposed board should have %c at %s, got %v", "CAT"[i], pos, tile)
+		}
+	}
+	if !tr.hasNeighbor(mustPos(t, "H10")) || tr.hasNeighbor(mustPos(t, "J8")) {
+		t.Errorf("Transposed board should rebuild its occupancy masks")
+	}
+	if err := tr.ValidateBoard(); err != nil || tr.CustomLayout != nil {
+		t.Errorf("Transposing a symmetric layout should keep it, got %v", err)
+	}
+	if board.GetTile(mustPos(t, "H7")) != nil {
+		t.Errorf("Transposing should leave the original board alone")
+	}
+	if back := tr.Transpose(); back.String() != board.String() {
+		t.Errorf("Transposing twice should restore the board")
+	}
+
+	layout := board.PremiumLayout()
+	layout[0][3] = TripleWordScore
+	custom, err := NewBoardFromLayout(layoutRows(layout))
+	if err != nil {
+		t.Fatalf("NewBoardFromLayout failed: %v", err)
+	}
+	tr = custom.Transpose()
+	if tr.GetPremiumType(mustPos(t, "A4")) != TripleWordScore || tr.CustomLayout == nil {
+		t.Errorf("Transposing should move the premium squares")
+	}
+	if err := tr.ValidateBoard(); err != nil {
+		t.Errorf("Transposed custom board should validate, got %v", err)
+	}
+}
+
+// TestBoardCanonical tests that positions differing by a symmetry share a canonical form
+func TestBoardCanonical(t *testing.T) {
+	across := NewBoard()
+	placeWord(t, across, "CAT", "G8", Horizontal)
+	down := NewBoard()
+	placeWord(t, down, "CAT", "H7", Vertical)
+	mirrored := NewBoard()
+	placeWord(t, mirrored, "TAC", "G8", Horizontal)
+
+	if len(across.Symmetries()) != 8 {
+		t.Errorf("The standard board should have 8 symmetries, got %v", across.Symmetries())
+	}
+	want, _ := across.Canonical()
+	for name, b := range map[string]*Board{"down": down, "mirrored": mirrored} {
+		got, s := b.Canonical()
+		if got.String() != want.String() {
+			t.Errorf("%s: canonical form should match, got\n%s", name, got)
+		}
+		if b.Transform(s).String() != got.String() {
+			t.Errorf("%s: returned symmetry %s should give the canonical form", name, s)
+		}
+	}
+
+	other := NewBoard()
+	placeWord(t, other, "ACT", "G8", Horizontal)
+	if got, _ := other.Canonical(); got.String() == want.String() {
+		t.Errorf("Different positions should have different canonical forms")
+	}
+
+	layout := across.PremiumLayout()
+	layout[0][3] = TripleWordScore
+	custom, err := NewBoardFromLayout(layoutRows(layout))
+	if err != nil {
+		t.Fatalf("NewBoardFromLayout failed: %v", err)
+	}
+	if syms := custom.Symmetries(); len(syms) != 1 || syms[0] != Identity {
+		t.Errorf("Only symmetries keeping the premiums should count, got %v", syms)
+	}
+}
+
+// TestGenerateMovesTransposed tests that transposing the board transposes the generated moves
+func TestGenerateMovesTransposed(t *testing.T) {
+	mg := NewMoveGenerator(testWords)
+	board := NewBoard()
+	placeWord(t, board, "CAT", "G8", Horizontal)
+	placeWord(t, board, "DOG", "J6", Vertical)
+	rack := rackOf("SDOGAT?")
+
+	moves := mg.GenerateMoves(board, rack)
+	transposed := mg.GenerateMoves(board.Transpose(), rack)
+	if len(moves) == 0 || len(moves) != len(transposed) {
+		t.Fatalf("Should find as many moves on the transposed board, got %d and %d", len(moves), len(transposed))
+	}
+
+	want := make(map[string]int)
+	for _, m := range moves {
+		want[moveKey(m.Tiles)] = m.Score
+	}
+	for _, m := range transposed {
+		tiles := make([]PlacedTile, len(m.Tiles))
+		for i, pt := range m.Tiles {
+			tiles[i] = PlacedTile{Tile: pt.Tile, Position: FlipDiagonal.Apply(pt.Position)}
+		}
+		if score, ok := want[moveKey(tiles)]; !ok || score != m.Score {
+			t.Errorf("Move %s at %s should match a move on the original board", m.Word, m.Start)
+		}
+	}
+}
+
+// layoutRows converts a layout to the rows NewBoardFromLayout takes
+func layoutRows(layout Layout) [][]PremiumType {
+	rows := make([][]PremiumType, 15)
+	for i := range rows {
+		rows[i] = layout[i][:]
+	}
+	return rows
+}
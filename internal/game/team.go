@@ -0,0 +1,178 @@
+package game
+
+import (
+	"errors"
+	"time"
+)
+
+// TeamSize is the number of partners on each side of a team game
+const TeamSize = 2
+
+// Errors returned by team play
+var (
+	ErrNotTeamGame        = errors.New("game is not played in teams")
+	ErrNotPartner         = errors.New("only the partner of the player to move may consult")
+	ErrConsultationClosed = errors.New("consultation window has closed")
+)
+
+// TeamRules configures team play
+// Two teams of two sit alternately, seats 0 and 2 against seats 1 and 3, so
+// turns alternate between the teams and between partners. Partners share a
+// score, and the game is won by the team with the higher total.
+type TeamRules struct {
+	Consultation time.Duration `json:"consultation,omitempty"` // How long into a turn the partner may see the rack and suggest a move (0 disables)
+}
+
+// TeamScore is a team's combined score
+type TeamScore struct {
+	Team      int      `json:"team"`       // 0 for seats 0 and 2, 1 for seats 1 and 3
+	PlayerIDs []string `json:"player_ids"` // Partners in seating order
+	Score     int      `json:"score"`
+}
+
+// Team returns the team a player belongs to
+func (g *Game) Team(playerID string) (int, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if g.Teams == nil {
+		return 0, ErrNotTeamGame
+	}
+	seat := g.seatOf(playerID)
+	if seat < 0 {
+		return 0, ErrPlayerNotFound
+	}
+	return seat % 2, nil
+}
+
+// Partner returns the ID of a player's partner
+func (g *Game) Partner(playerID string) (string, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if g.Teams == nil {
+		return "", ErrNotTeamGame
+	}
+	partner := g.partner(playerID)
+	if partner == nil {
+		return "", ErrPlayerNotFound
+	}
+	return partner.ID, nil
+}
+
+// TeamScores returns each team's combined score
+func (g *Game) TeamScores() ([]TeamScore, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if g.Teams == nil {
+		return nil, ErrNotTeamGame
+	}
+	return g.teamScores(), nil
+}
+
+// PartnerRack returns the rack of the player to move to their partner while
+// the consultation window is open
+func (g *Game) PartnerRack(partnerID string) ([]Tile, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	player, err := g.checkConsultation(partnerID)
+	if err != nil {
+		return nil, err
+	}
+	return rackCopy(player.Rack), nil
+}
+
+// SuggestMove lets the partner of the player to move propose a move while the
+// consultation window is open
+// The suggestion must be a legal placement from the mover's rack and replaces
+// any earlier one; it is discarded when the turn ends.
+func (g *Game) SuggestMove(partnerID string, move Move) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	player, err := g.checkConsultation(partnerID)
+	if err != nil {
+		return err
+	}
+	if err := g.Board.ValidatePlacement(move, player.Rack); err != nil {
+		return err
+	}
+
+	move = withRackPoints(move, player.Rack)
+	move.PlayerID = player.ID
+	g.suggestion = &move
+	g.notify()
+	return nil
+}
+
+// Suggestion returns the move the partner suggested for this turn, if any
+func (g *Game) Suggestion(playerID string) (Move, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if g.suggestion == nil || g.suggestion.PlayerID != playerID {
+		return Move{}, false
+	}
+	return *g.suggestion, true
+}
+
+// seatOf returns a player's seat index, or -1; callers must hold the lock
+func (g *Game) seatOf(playerID string) int {
+	for i, p := range g.Players {
+		if p.ID == playerID {
+			return i
+		}
+	}
+	return -1
+}
+
+// partner returns a player's partner, or nil; callers must hold the lock
+func (g *Game) partner(playerID string) *Player {
+	seat := g.seatOf(playerID)
+	if seat < 0 || len(g.Players) != 2*TeamSize {
+		return nil
+	}
+	return g.Players[(seat+TeamSize)%len(g.Players)]
+}
+
+// isPartner returns true if two players are partners in team play; callers
+// must hold the lock
+func (g *Game) isPartner(a, b string) bool {
+	p := g.partner(a)
+	return g.Teams != nil && p != nil && p.ID == b
+}
+
+// teamScores sums the partners' scores; callers must hold the lock
+func (g *Game) teamScores() []TeamScore {
+	scores := make([]TeamScore, 2)
+	for i := range scores {
+		scores[i].Team = i
+	}
+	for seat, p := range g.Players {
+		ts := &scores[seat%2]
+		ts.PlayerIDs = append(ts.PlayerIDs, p.ID)
+		ts.Score += p.Score
+	}
+	return scores
+}
+
+// checkConsultation returns the player to move if partnerID may consult with
+// them now; callers must hold the lock
+func (g *Game) checkConsultation(partnerID string) (*Player, error) {
+	if g.Teams == nil {
+		return nil, ErrNotTeamGame
+	}
+	if g.State != InProgress {
+		return nil, ErrGameNotInProgress
+	}
+	player := g.Players[g.CurrentTurn]
+	if partner := g.partner(player.ID); partner == nil || partner.ID != partnerID {
+		return nil, ErrNotPartner
+	}
+	if time.Since(g.turnStarted) > g.Teams.Consultation {
+		return nil, ErrConsultationClosed
+	}
+	return player, nil
+}
@@ -0,0 +1,176 @@
+package game
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// newTeamGame creates a started team game of p1 and p3 against p2 and p4,
+// with p1 holding CATSDOG
+func newTeamGame(t *testing.T, consultation time.Duration) *Game {
+	t.Helper()
+
+	rules := DefaultRules()
+	rules.Teams = &TeamRules{Consultation: consultation}
+	players := make([]*Player, 2*TeamSize)
+	for i := range players {
+		id := string(rune('1' + i))
+		players[i] = NewPlayer("p"+id, "Player "+id)
+	}
+
+	g, err := NewGameWithRules("team", players, rules)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	if err := g.TileBag.stack(rackOf("CATSDOG")); err != nil {
+		t.Fatalf("Failed to stack bag: %v", err)
+	}
+	if err := g.StartGame(); err != nil {
+		t.Fatalf("Failed to start game: %v", err)
+	}
+	return g
+}
+
+// TestTeamSeating tests that partners sit opposite each other
+func TestTeamSeating(t *testing.T) {
+	g := newTeamGame(t, 0)
+
+	for id, want := range map[string]int{"p1": 0, "p2": 1, "p3": 0, "p4": 1} {
+		if team, err := g.Team(id); err != nil || team != want {
+			t.Errorf("Player %s should be on team %d, got %d, %v", id, want, team, err)
+		}
+	}
+	for id, want := range map[string]string{"p1": "p3", "p2": "p4", "p3": "p1", "p4": "p2"} {
+		if partner, err := g.Partner(id); err != nil || partner != want {
+			t.Errorf("Partner of %s should be %s, got %s, %v", id, want, partner, err)
+		}
+	}
+	if _, err := g.Partner("p9"); !errors.Is(err, ErrPlayerNotFound) {
+		t.Errorf("Should not find an unknown player's partner, got %v", err)
+	}
+
+	// Turns alternate between teams and between partners
+	order := []string{g.CurrentPlayer().ID}
+	for i := 0; i < 3; i++ {
+		g.PassTurn(g.CurrentPlayer().ID)
+		order = append(order, g.CurrentPlayer().ID)
+	}
+	if got := order[0] + order[1] + order[2] + order[3]; got != "p1p2p3p4" {
+		t.Errorf("Turns should rotate p1p2p3p4, got %s", got)
+	}
+
+	if _, err := newTestGame(t, 2).Team("p1"); !errors.Is(err, ErrNotTeamGame) {
+		t.Errorf("Should report that a game is not played in teams, got %v", err)
+	}
+
+	rules := DefaultRules()
+	rules.Teams = &TeamRules{}
+	short, _ := NewGameWithRules("short", []*Player{NewPlayer("a", "A"), NewPlayer("b", "B"), NewPlayer("c", "C")}, rules)
+	if err := short.StartGame(); !errors.Is(err, ErrNotEnoughPlayers) {
+		t.Errorf("Team games should need four players, got %v", err)
+	}
+
+	rules.Teams.Consultation = -time.Second
+	if err := rules.Validate(); !errors.Is(err, ErrInvalidRules) {
+		t.Errorf("Should reject a negative consultation window, got %v", err)
+	}
+}
+
+// TestTeamScoring tests combined scores, going out and the winning team
+func TestTeamScoring(t *testing.T) {
+	g := newTeamGame(t, 0)
+	g.Players[0].Score, g.Players[1].Score, g.Players[2].Score, g.Players[3].Score = 100, 120, 90, 60
+	g.Players[0].Rack = nil
+	g.Players[1].Rack = rackOf("Q")  // 10
+	g.Players[2].Rack = rackOf("ZX") // 18
+	g.Players[3].Rack = rackOf("AE") // 2
+
+	g.mu.Lock()
+	g.finish(g.Players[0])
+	g.mu.Unlock()
+
+	// p1 collects only the opponents' 12 points; p3 still loses their 18
+	want := []int{112, 110, 72, 58}
+	for i, p := range g.Players {
+		if p.Score != want[i] {
+			t.Errorf("Player %s should finish with %d, got %d", p.ID, want[i], p.Score)
+		}
+	}
+
+	scores, err := g.TeamScores()
+	if err != nil {
+		t.Fatalf("TeamScores failed: %v", err)
+	}
+	if scores[0].Score != 184 || scores[1].Score != 168 || len(scores[0].PlayerIDs) != TeamSize {
+		t.Errorf("Team scores should be 184 and 168, got %+v", scores)
+	}
+
+	for id, wins := range map[string]int{"p1": 1, "p3": 1, "p2": 0, "p4": 0} {
+		if stats, _ := g.PlayerStats(id); stats.Wins != wins {
+			t.Errorf("Player %s should have %d wins, got %d", id, wins, stats.Wins)
+		}
+	}
+}
+
+// TestConsultation tests the partner seeing the rack and suggesting a move
+func TestConsultation(t *testing.T) {
+	g := newTeamGame(t, time.Minute)
+
+	rack, err := g.PartnerRack("p3")
+	if err != nil || len(rack) != MaxRackSize {
+		t.Fatalf("Partner should see the rack, got %v, %v", rack, err)
+	}
+	if _, err := g.PartnerRack("p2"); !errors.Is(err, ErrNotPartner) {
+		t.Errorf("Opponents should not see the rack, got %v", err)
+	}
+
+	move, _ := g.Board.BuildMove("p3", "CAT", mustPos(t, "H8"), Horizontal, nil)
+	if err := g.SuggestMove("p3", move); err != nil {
+		t.Fatalf("SuggestMove failed: %v", err)
+	}
+	if err := g.SuggestMove("p3", Move{}); !errors.Is(err, ErrNoTilesPlaced) {
+		t.Errorf("Should reject an illegal suggestion, got %v", err)
+	}
+	suggested, ok := g.Suggestion("p1")
+	if !ok || suggested.PlayerID != "p1" || suggested.Word != "CAT" {
+		t.Errorf("Player to move should receive the suggestion for themselves, got %+v", suggested)
+	}
+	if _, err := g.PlayMove(suggested); err != nil {
+		t.Errorf("Suggested move should be playable: %v", err)
+	}
+	if _, ok := g.Suggestion("p1"); ok {
+		t.Errorf("Suggestion should be discarded when the turn ends")
+	}
+
+	g.mu.Lock()
+	g.turnStarted = time.Now().Add(-2 * time.Minute)
+	g.mu.Unlock()
+	if _, err := g.PartnerRack("p4"); !errors.Is(err, ErrConsultationClosed) {
+		t.Errorf("Consultation should close after the window, got %v", err)
+	}
+
+	if _, err := newTeamGame(t, 0).PartnerRack("p3"); !errors.Is(err, ErrConsultationClosed) {
+		t.Errorf("Consultation should be closed without a window, got %v", err)
+	}
+}
+
+// TestTeamRulesPersist tests that team play survives saving and loading
+func TestTeamRulesPersist(t *testing.T) {
+	g := newTeamGame(t, time.Minute)
+
+	data, err := g.Serialize()
+	if err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	loaded, err := LoadGame(data)
+	if err != nil {
+		t.Fatalf("LoadGame failed: %v", err)
+	}
+	if rules := loaded.Rules(); rules.Teams == nil || rules.Teams.Consultation != time.Minute {
+		t.Errorf("Loaded game should keep team play, got %+v", rules.Teams)
+	}
+	if partner, err := loaded.Partner("p2"); err != nil || partner != "p4" {
+		t.Errorf("Loaded game should keep partnerships, got %s, %v", partner, err)
+	}
+}
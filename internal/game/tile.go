@@ -2,7 +2,9 @@ package game
 
 import (
 	"errors"
+	"fmt"
 	"math/rand"
+	"sort"
 	"sync"
 )
 
@@ -24,14 +26,18 @@ func (t Tile) String() string {
 // TileBag manages the collection of tiles that can be drawn from
 type TileBag struct {
 	tiles []Tile
+	rng   *rand.Rand // Source of shuffles; nil uses the global generator
 	mu    sync.Mutex
 }
 
-// Standard Scrabble tile distribution (100 tiles total)
-var standardTileDistribution = map[rune]struct {
+// letterSpec gives the number of copies and point value of a letter
+type letterSpec struct {
 	quantity int
 	points   int
-}{
+}
+
+// Standard Scrabble tile distribution (100 tiles total)
+var standardTileDistribution = map[rune]letterSpec{
 	'A': {9, 1}, 'B': {2, 3}, 'C': {2, 3}, 'D': {4, 2}, 'E': {12, 1},
 	'F': {2, 4}, 'G': {3, 2}, 'H': {2, 4}, 'I': {9, 1}, 'J': {1, 8},
 	'K': {1, 5}, 'L': {4, 1}, 'M': {2, 3}, 'N': {6, 1}, 'O': {8, 1},
@@ -45,12 +51,31 @@ const blankTileCount = 2
 
 // NewTileBag creates a new tile bag with the standard Scrabble distribution
 func NewTileBag() *TileBag {
+	return newShuffledTileBag(standardTileDistribution, blankTileCount, nil)
+}
+
+// NewTileBagWithSeed creates a standard tile bag whose shuffles are determined by seed
+// Bags created with the same seed yield identical tile sequences.
+func NewTileBagWithSeed(seed int64) *TileBag {
+	return NewTileBagWithSource(rand.NewSource(seed))
+}
+
+// NewTileBagWithSource creates a standard tile bag that shuffles using src
+func NewTileBagWithSource(src rand.Source) *TileBag {
+	return newShuffledTileBag(standardTileDistribution, blankTileCount, rand.New(src))
+}
+
+// newShuffledTileBag fills a bag with the given distribution and shuffles it with rng
+func newShuffledTileBag(distribution map[rune]letterSpec, blanks int, rng *rand.Rand) *TileBag {
 	bag := &TileBag{
 		tiles: make([]Tile, 0, 100), // Pre-allocate for 100 tiles
+		rng:   rng,
 	}
 
-	// Add letter tiles according to standard distribution
-	for letter, dist := range standardTileDistribution {
+	// Add letter tiles according to the distribution, in alphabetical
+	// order so that seeded shuffles are reproducible
+	for _, letter := range sortedLetters(distribution) {
+		dist := distribution[letter]
 		for i := 0; i < dist.quantity; i++ {
 			bag.tiles = append(bag.tiles, Tile{
 				Letter:  letter,
@@ -61,7 +86,7 @@ func NewTileBag() *TileBag {
 	}
 
 	// Add blank tiles
-	for i := 0; i < blankTileCount; i++ {
+	for i := 0; i < blanks; i++ {
 		bag.tiles = append(bag.tiles, Tile{
 			Letter:  0, // 0 represents blank
 			Points:  0,
@@ -75,11 +100,45 @@ func NewTileBag() *TileBag {
 	return bag
 }
 
+// sortedLetters returns the letters of a distribution in ascending order
+func sortedLetters(distribution map[rune]letterSpec) []rune {
+	letters := make([]rune, 0, len(distribution))
+	for letter := range distribution {
+		letters = append(letters, letter)
+	}
+	sort.Slice(letters, func(i, j int) bool { return letters[i] < letters[j] })
+	return letters
+}
+
+// NewTileBagFromTiles creates a tile bag holding exactly the given tiles in order
+// This is used to restore a saved game; the tiles are not shuffled.
+func NewTileBagFromTiles(tiles []Tile) *TileBag {
+	bag := &TileBag{
+		tiles: make([]Tile, len(tiles)),
+	}
+	copy(bag.tiles, tiles)
+	return bag
+}
+
+// Tiles returns a copy of the tiles remaining in the bag, in draw order from the end
+func (tb *TileBag) Tiles() []Tile {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	tiles := make([]Tile, len(tb.tiles))
+	copy(tiles, tb.tiles)
+	return tiles
+}
+
 // shuffle randomizes the order of tiles in the bag
 func (tb *TileBag) shuffle() {
 	// In Go 1.20+, the global rand functions are automatically seeded
+	intn := rand.Intn
+	if tb.rng != nil {
+		intn = tb.rng.Intn
+	}
 	for i := len(tb.tiles) - 1; i > 0; i-- {
-		j := rand.Intn(i + 1)
+		j := intn(i + 1)
 		tb.tiles[i], tb.tiles[j] = tb.tiles[j], tb.tiles[i]
 	}
 }
@@ -123,6 +182,57 @@ func (tb *TileBag) ReturnTiles(tiles []Tile) {
 	tb.shuffle()
 }
 
+// Exchange draws drawCount tiles and then returns the given tiles, under one
+// lock so no other draw can come in between
+// The returned tiles are shuffled in only after the draw, so a player can never
+// draw back the tiles they are exchanging. It fails with ErrExchangeNotAllowed,
+// changing nothing, if the bag holds fewer than drawCount tiles.
+func (tb *TileBag) Exchange(returned []Tile, drawCount int) ([]Tile, error) {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	available := len(tb.tiles)
+	if drawCount < 0 || drawCount > available {
+		return nil, fmt.Errorf("%w: cannot draw %d of %d tiles", ErrExchangeNotAllowed, drawCount, available)
+	}
+
+	drawn := make([]Tile, drawCount)
+	copy(drawn, tb.tiles[available-drawCount:])
+	tb.tiles = append(tb.tiles[:available-drawCount], returned...)
+	tb.shuffle()
+	return drawn, nil
+}
+
+// putBack returns tiles just drawn to the top of the bag, unshuffled, so the
+// bag is as it was before the draw
+func (tb *TileBag) putBack(drawn []Tile) {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	tb.tiles = append(tb.tiles, drawn...)
+}
+
+// stack moves the given tiles to the top of the bag so they are drawn next,
+// the last tile being drawn first
+func (tb *TileBag) stack(tiles []Tile) error {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	rest := make([]Tile, len(tb.tiles))
+	copy(rest, tb.tiles)
+	top := make([]Tile, 0, len(tiles))
+	for _, want := range tiles {
+		i := findTile(rest, want)
+		if i < 0 {
+			return fmt.Errorf("tile %s is not in the bag", want)
+		}
+		top = append(top, rest[i])
+		rest = append(rest[:i], rest[i+1:]...)
+	}
+	tb.tiles = append(rest, top...)
+	return nil
+}
+
 // RemainingCount returns the number of tiles left in the bag
 func (tb *TileBag) RemainingCount() int {
 	tb.mu.Lock()
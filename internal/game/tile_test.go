@@ -1,6 +1,9 @@
 package game
 
 import (
+	"errors"
+	"math/rand"
+	"reflect"
 	"sync"
 	"testing"
 )
@@ -270,6 +273,46 @@ func TestReturnTiles(t *testing.T) {
 	})
 }
 
+// TestTileBagExchange tests drawing before the returned tiles are mixed in
+func TestTileBagExchange(t *testing.T) {
+	t.Run("Draw comes from the tiles already in the bag", func(t *testing.T) {
+		bag := NewTileBagFromTiles([]Tile{{Letter: 'A', Points: 1}, {Letter: 'B', Points: 3}})
+		returned := []Tile{{Letter: 'Q', Points: 10}, {Letter: 'Z', Points: 10}}
+
+		drawn, err := bag.Exchange(returned, 2)
+		if err != nil {
+			t.Fatalf("Exchange failed: %v", err)
+		}
+		if rackLetters(drawn) != "AB" {
+			t.Errorf("Should draw the bag's own tiles, got %s", rackLetters(drawn))
+		}
+		if got := rackLetters(bag.Tiles()); got != "QZ" && got != "ZQ" {
+			t.Errorf("Bag should hold the returned tiles, got %s", got)
+		}
+	})
+
+	t.Run("Too few tiles to draw", func(t *testing.T) {
+		bag := NewTileBagFromTiles([]Tile{{Letter: 'A', Points: 1}})
+		before := bag.Tiles()
+
+		if _, err := bag.Exchange([]Tile{{Letter: 'Q', Points: 10}, {Letter: 'Z', Points: 10}}, 2); !errors.Is(err, ErrExchangeNotAllowed) {
+			t.Errorf("Should refuse to draw more tiles than the bag holds, got %v", err)
+		}
+		if !reflect.DeepEqual(bag.Tiles(), before) {
+			t.Errorf("A refused exchange should leave the bag unchanged")
+		}
+	})
+
+	t.Run("Count is kept", func(t *testing.T) {
+		bag := NewTileBag()
+		initialCount := bag.RemainingCount()
+		drawn, err := bag.Exchange(bag.DrawTiles(7), 7)
+		if err != nil || len(drawn) != 7 || bag.RemainingCount() != initialCount-7 {
+			t.Errorf("Exchange should swap like for like, got %d drawn, %d left, %v", len(drawn), bag.RemainingCount(), err)
+		}
+	})
+}
+
 // TestRemainingCount tests the RemainingCount method accuracy
 func TestRemainingCount(t *testing.T) {
 	bag := NewTileBag()
@@ -498,3 +541,65 @@ func TestConcurrentMixedOperations(t *testing.T) {
 		t.Errorf("Invalid remaining count after concurrent operations: %d", remaining)
 	}
 }
+
+// TestTileBagFromTiles tests restoring a bag with exact contents and order
+func TestTileBagFromTiles(t *testing.T) {
+	tiles := []Tile{
+		{Letter: 'A', Points: 1},
+		{Letter: 'B', Points: 3},
+		{Letter: 0, Points: 0, IsBlank: true},
+	}
+
+	bag := NewTileBagFromTiles(tiles)
+	if bag.RemainingCount() != 3 {
+		t.Fatalf("Bag should have 3 tiles, got %d", bag.RemainingCount())
+	}
+
+	contents := bag.Tiles()
+	for i := range tiles {
+		if contents[i] != tiles[i] {
+			t.Errorf("Tile %d should be %v, got %v", i, tiles[i], contents[i])
+		}
+	}
+
+	// Modifying the returned slice must not affect the bag
+	contents[0].Letter = 'Z'
+	if bag.Tiles()[0].Letter != 'A' {
+		t.Errorf("Tiles should return a copy of the bag contents")
+	}
+
+	// Tiles are drawn from the end
+	drawn := bag.DrawTiles(1)
+	if !drawn[0].IsBlank {
+		t.Errorf("Expected to draw the blank first, got %v", drawn[0])
+	}
+}
+
+// TestSeededTileBag tests that bags with the same seed produce identical sequences
+func TestSeededTileBag(t *testing.T) {
+	bag1 := NewTileBagWithSeed(42)
+	bag2 := NewTileBagWithSeed(42)
+
+	if bag1.RemainingCount() != 100 {
+		t.Fatalf("Seeded bag should hold 100 tiles, got %d", bag1.RemainingCount())
+	}
+	if !reflect.DeepEqual(bag1.Tiles(), bag2.Tiles()) {
+		t.Errorf("Bags with the same seed should have the same order")
+	}
+
+	// Returned tiles are reshuffled with the same source
+	bag1.ReturnTiles(bag1.DrawTiles(7))
+	bag2.ReturnTiles(bag2.DrawTiles(7))
+	if !reflect.DeepEqual(bag1.DrawTiles(20), bag2.DrawTiles(20)) {
+		t.Errorf("Seeded bags should stay in step after returning tiles")
+	}
+
+	if reflect.DeepEqual(NewTileBagWithSeed(1).Tiles(), NewTileBagWithSeed(2).Tiles()) {
+		t.Errorf("Different seeds should produce different orders")
+	}
+
+	custom := NewTileBagWithSource(rand.NewSource(42))
+	if !reflect.DeepEqual(custom.Tiles(), NewTileBagWithSeed(42).Tiles()) {
+		t.Errorf("A source with the same seed should match NewTileBagWithSeed")
+	}
+}
@@ -0,0 +1,261 @@
+package game
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+//go:embed tilesets/*.json
+var embeddedTileSets embed.FS
+
+// firstDigraphRune is the rune assigned to the first multi-character tile of a
+// tile set; later ones follow it in the Unicode private use area
+const firstDigraphRune = '\uE000'
+
+// ErrUnknownTileSet is returned when no embedded tile set has the requested name
+var ErrUnknownTileSet = errors.New("unknown tile set")
+
+// TileSpec describes one kind of tile in a tile set
+type TileSpec struct {
+	Letter   string `json:"letter"`   // Face of the tile; may be several characters, such as Spanish "CH"
+	Quantity int    `json:"quantity"` // Number of copies in the bag
+	Points   int    `json:"points"`   // Point value
+}
+
+// TileSet is the tile distribution and point values for a language edition
+// Single-letter tiles are stored as their own rune; multi-character tiles
+// such as CH, LL and RR are each assigned a private rune so the rest of the
+// engine can treat every tile as one letter. Use Encode and Decode to convert
+// words to and from this form.
+type TileSet struct {
+	Language string              `json:"language"`
+	Blanks   int                 `json:"blanks"`
+	Tiles    []TileSpec          `json:"tiles"`
+	runes    map[string]rune     // Tile face to engine rune
+	faces    map[rune]string     // Engine rune to tile face
+	letters  map[rune]letterSpec // Quantity and points by engine rune
+	maxFace  int                 // Length in runes of the longest face
+}
+
+// NewTileSet creates a tile set after validating the distribution
+func NewTileSet(language string, blanks int, tiles []TileSpec) (*TileSet, error) {
+	ts := &TileSet{Language: language, Blanks: blanks, Tiles: tiles}
+	if err := ts.index(); err != nil {
+		return nil, err
+	}
+	return ts, nil
+}
+
+// LoadTileSet returns one of the embedded tile sets by name (for example "spanish")
+func LoadTileSet(name string) (*TileSet, error) {
+	data, err := embeddedTileSets.ReadFile("tilesets/" + strings.ToLower(name) + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTileSet, name)
+	}
+	return ParseTileSet(strings.NewReader(string(data)))
+}
+
+// TileSetNames returns the names of the embedded tile sets in alphabetical order
+func TileSetNames() []string {
+	entries, _ := embeddedTileSets.ReadDir("tilesets")
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, strings.TrimSuffix(e.Name(), ".json"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadTileSetFile reads a tile set from a JSON file
+func LoadTileSetFile(filename string) (*TileSet, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open tile set: %w", err)
+	}
+	defer f.Close()
+
+	ts, err := ParseTileSet(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", filename, err)
+	}
+	return ts, nil
+}
+
+// ParseTileSet reads a tile set from JSON
+func ParseTileSet(r io.Reader) (*TileSet, error) {
+	var ts TileSet
+	if err := json.NewDecoder(r).Decode(&ts); err != nil {
+		return nil, err
+	}
+	return &ts, nil
+}
+
+// UnmarshalJSON decodes and validates a tile set
+func (ts *TileSet) UnmarshalJSON(data []byte) error {
+	type plain TileSet
+	if err := json.Unmarshal(data, (*plain)(ts)); err != nil {
+		return err
+	}
+	return ts.index()
+}
+
+// index validates the tile specs and builds the lookup tables
+func (ts *TileSet) index() error {
+	if len(ts.Tiles) == 0 {
+		return errors.New("tile set has no tiles")
+	}
+	if ts.Blanks < 0 {
+		return fmt.Errorf("invalid blank count %d", ts.Blanks)
+	}
+
+	ts.runes = make(map[string]rune, len(ts.Tiles))
+	ts.faces = make(map[rune]string, len(ts.Tiles))
+	ts.letters = make(map[rune]letterSpec, len(ts.Tiles))
+	ts.maxFace = 0
+	next := firstDigraphRune
+
+	for _, spec := range ts.Tiles {
+		face := strings.ToUpper(spec.Letter)
+		if face == "" {
+			return errors.New("tile with an empty face")
+		}
+		if _, dup := ts.runes[face]; dup {
+			return fmt.Errorf("duplicate tile %q", face)
+		}
+		if spec.Quantity <= 0 {
+			return fmt.Errorf("tile %q: quantity must be positive, got %d", face, spec.Quantity)
+		}
+		if spec.Points < 0 {
+			return fmt.Errorf("tile %q: points must not be negative, got %d", face, spec.Points)
+		}
+
+		r, size := utf8.DecodeRuneInString(face)
+		if size != len(face) {
+			r = next
+			next++
+		}
+		ts.runes[face] = r
+		ts.faces[r] = face
+		ts.letters[r] = letterSpec{quantity: spec.Quantity, points: spec.Points}
+		if n := utf8.RuneCountInString(face); n > ts.maxFace {
+			ts.maxFace = n
+		}
+	}
+
+	if n := ts.TileCount(); n < MinTileBagSize {
+		return fmt.Errorf("tile set has %d tiles, minimum is %d", n, MinTileBagSize)
+	}
+	return nil
+}
+
+// TileCount returns the total number of tiles, including blanks
+func (ts *TileSet) TileCount() int {
+	total := ts.Blanks
+	for _, spec := range ts.Tiles {
+		total += spec.Quantity
+	}
+	return total
+}
+
+// Value returns the point value of a tile by its engine rune
+// Returns 0 for blank tiles or letters not in the set
+func (ts *TileSet) Value(r rune) int {
+	return ts.letters[r].points
+}
+
+// Rune returns the engine rune for a tile face such as "A" or "CH"
+func (ts *TileSet) Rune(face string) (rune, bool) {
+	r, ok := ts.runes[strings.ToUpper(face)]
+	return r, ok
+}
+
+// Face returns the printed face of a tile given its engine rune
+func (ts *TileSet) Face(r rune) string {
+	if face, ok := ts.faces[r]; ok {
+		return face
+	}
+	return string(r)
+}
+
+// Encode converts a word to engine runes, matching the longest tile face at
+// each point so that, for example, Spanish "CHICO" becomes CH-I-C-O
+// Returns an error if part of the word matches no tile.
+func (ts *TileSet) Encode(word string) (string, error) {
+	letters := []rune(strings.ToUpper(word))
+	var sb strings.Builder
+
+	for i := 0; i < len(letters); {
+		matched := false
+		for n := ts.maxFace; n > 0; n-- {
+			if i+n > len(letters) {
+				continue
+			}
+			if r, ok := ts.runes[string(letters[i:i+n])]; ok {
+				sb.WriteRune(r)
+				i += n
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return "", fmt.Errorf("%q has no %s tile for %q", word, ts.Language, string(letters[i]))
+		}
+	}
+	return sb.String(), nil
+}
+
+// Decode converts engine runes back to printed tile faces
+func (ts *TileSet) Decode(encoded string) string {
+	var sb strings.Builder
+	for _, r := range encoded {
+		sb.WriteString(ts.Face(r))
+	}
+	return sb.String()
+}
+
+// NewTileBag creates a shuffled tile bag holding the tile set
+func (ts *TileSet) NewTileBag() *TileBag {
+	return newShuffledTileBag(ts.letters, ts.Blanks, nil)
+}
+
+// NewTileBagWithSeed creates a tile bag holding the tile set whose shuffles are determined by seed
+func (ts *TileSet) NewTileBagWithSeed(seed int64) *TileBag {
+	return newShuffledTileBag(ts.letters, ts.Blanks, rand.New(rand.NewSource(seed)))
+}
+
+// distribution returns the letter quantities and values by engine rune
+func (ts *TileSet) distribution() map[rune]letterSpec {
+	return ts.letters
+}
+
+// tileValue returns the point value of a letter on this board, using the
+// tile set if one is configured and the variant otherwise
+func (b *Board) tileValue(letter rune) int {
+	if b.TileSet != nil {
+		return b.TileSet.Value(letter)
+	}
+	return b.Variant.TileValue(letter)
+}
+
+// SetTileSet plays the game with a language tile set instead of the variant's tiles
+// The tile set may only be changed before the game starts.
+func (g *Game) SetTileSet(ts *TileSet) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.State != WaitingForPlayers {
+		return ErrGameNotWaiting
+	}
+	g.Board.TileSet = ts
+	g.TileBag = ts.NewTileBag()
+	g.record(Event{Type: TileSetChanged, TileSet: ts})
+	return nil
+}
@@ -0,0 +1,171 @@
+package game
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// TestEmbeddedTileSets tests that every embedded tile set loads with its expected size
+func TestEmbeddedTileSets(t *testing.T) {
+	expected := map[string]int{"english": 100, "french": 102, "german": 102, "italian": 120, "spanish": 100}
+
+	names := TileSetNames()
+	if len(names) != len(expected) {
+		t.Errorf("Expected %d embedded tile sets, got %v", len(expected), names)
+	}
+	for _, name := range names {
+		ts, err := LoadTileSet(name)
+		if err != nil {
+			t.Errorf("Failed to load %s: %v", name, err)
+			continue
+		}
+		if n := ts.TileCount(); n != expected[name] {
+			t.Errorf("%s should have %d tiles, got %d", name, expected[name], n)
+		}
+	}
+
+	// The English set matches the built-in distribution
+	english, _ := LoadTileSet("English")
+	for letter := 'A'; letter <= 'Z'; letter++ {
+		if english.Value(letter) != GetTileValue(letter) {
+			t.Errorf("English %c should be worth %d, got %d", letter, GetTileValue(letter), english.Value(letter))
+		}
+	}
+
+	if _, err := LoadTileSet("klingon"); !errors.Is(err, ErrUnknownTileSet) {
+		t.Errorf("Should return ErrUnknownTileSet, got %v", err)
+	}
+}
+
+// TestTileSetDigraphs tests multi-character tiles in the Spanish set
+func TestTileSetDigraphs(t *testing.T) {
+	spanish, err := LoadTileSet("spanish")
+	if err != nil {
+		t.Fatalf("Failed to load Spanish tiles: %v", err)
+	}
+
+	ch, ok := spanish.Rune("ch")
+	if !ok || spanish.Face(ch) != "CH" || spanish.Value(ch) != 5 {
+		t.Errorf("CH should be a single tile worth 5")
+	}
+	if r, _ := spanish.Rune("Ñ"); spanish.Value(r) != 8 {
+		t.Errorf("Ñ should be worth 8")
+	}
+
+	encoded, err := spanish.Encode("churro")
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	if n := len([]rune(encoded)); n != 4 {
+		t.Errorf("CHURRO should be 4 tiles (CH-U-RR-O), got %d", n)
+	}
+	if spanish.Decode(encoded) != "CHURRO" {
+		t.Errorf("Decode should restore CHURRO, got %s", spanish.Decode(encoded))
+	}
+
+	if _, err := spanish.Encode("KIWI"); err == nil {
+		t.Errorf("Spanish has no K tile; Encode should fail")
+	}
+
+	bag := spanish.NewTileBagWithSeed(1)
+	digraphs := 0
+	for _, tile := range bag.Tiles() {
+		if !tile.IsBlank && utf8.RuneCountInString(spanish.Face(tile.Letter)) > 1 {
+			digraphs++
+		}
+	}
+	if bag.RemainingCount() != 100 || digraphs != 3 {
+		t.Errorf("Spanish bag should hold 100 tiles including CH, LL and RR, got %d and %d", bag.RemainingCount(), digraphs)
+	}
+}
+
+// TestTileSetValidation tests rejection of invalid tile sets
+func TestTileSetValidation(t *testing.T) {
+	testCases := map[string]struct {
+		blanks int
+		tiles  []TileSpec
+	}{
+		"no tiles":       {2, nil},
+		"negative blank": {-1, []TileSpec{{"A", 1, 1}}},
+		"empty face":     {0, []TileSpec{{"", 1, 1}}},
+		"duplicate":      {0, []TileSpec{{"A", 1, 1}, {"a", 2, 1}}},
+		"zero quantity":  {0, []TileSpec{{"A", 0, 1}}},
+		"negative value": {0, []TileSpec{{"A", 1, -1}}},
+	}
+	for name, tc := range testCases {
+		if _, err := NewTileSet("Test", tc.blanks, tc.tiles); err == nil {
+			t.Errorf("%s: NewTileSet should fail", name)
+		}
+	}
+
+	if _, err := ParseTileSet(strings.NewReader(`{"language": "X", "tiles": [{"letter": "A", "quantity": -2}]}`)); err == nil {
+		t.Errorf("ParseTileSet should validate the tiles")
+	}
+}
+
+// TestLoadTileSetFile tests loading a custom tile set from JSON
+func TestLoadTileSetFile(t *testing.T) {
+	ts, err := LoadTileSetFile("testdata/custom_tileset.json")
+	if err != nil {
+		t.Fatalf("LoadTileSetFile failed: %v", err)
+	}
+	if ts.Language != "Teaching" || ts.TileCount() != 22 {
+		t.Errorf("Unexpected tile set: %s with %d tiles", ts.Language, ts.TileCount())
+	}
+	if encoded, err := ts.Encode("QUAT"); err != nil || len([]rune(encoded)) != 3 {
+		t.Errorf("QUAT should encode to 3 tiles, got %q, %v", encoded, err)
+	}
+
+	if _, err := LoadTileSetFile("testdata/missing.json"); err == nil {
+		t.Errorf("Loading a missing file should fail")
+	}
+}
+
+// TestGameWithTileSet tests playing with a language tile set
+func TestGameWithTileSet(t *testing.T) {
+	german, _ := LoadTileSet("german")
+	g := newTestGame(t, 2)
+	if err := g.SetTileSet(german); err != nil {
+		t.Fatalf("SetTileSet failed: %v", err)
+	}
+	if g.TileBag.RemainingCount() != 102 {
+		t.Errorf("German bag should hold 102 tiles, got %d", g.TileBag.RemainingCount())
+	}
+	// Deal ÖL to the first player from the bag so the tile counts stay whole
+	if err := g.TileBag.stack([]Tile{{Letter: 'Ö', Points: 8}, {Letter: 'L', Points: 2}}); err != nil {
+		t.Fatalf("Stacking the bag failed: %v", err)
+	}
+	g.StartGame()
+
+	if err := g.SetTileSet(german); err != ErrGameNotWaiting {
+		t.Errorf("Tile set should not change after the start, got %v", err)
+	}
+
+	// ÖL on the center double word: (8 + 2) * 2
+	move, err := g.Board.BuildMove("p1", "ÖL", g.Board.Center, Horizontal, nil)
+	if err != nil {
+		t.Fatalf("BuildMove failed: %v", err)
+	}
+	if score, err := g.PlayMove(move); err != nil || score != 20 {
+		t.Errorf("Expected score 20, got %d (%v)", score, err)
+	}
+
+	unseen, _ := g.UnseenTiles("p1")
+	if len(unseen) != 102-2-g.Players[0].GetRackSize() {
+		t.Errorf("Unseen tiles should count the German set, got %d", len(unseen))
+	}
+
+	data, err := g.Serialize()
+	if err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	loaded, err := LoadGame(data)
+	if err != nil {
+		t.Fatalf("LoadGame failed: %v", err)
+	}
+	if loaded.Board.TileSet == nil || loaded.Board.TileSet.Language != "German" || loaded.Board.tileValue('Ö') != 8 {
+		t.Errorf("Loaded game should keep its tile set")
+	}
+}
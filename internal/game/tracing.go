@@ -0,0 +1,69 @@
+package game
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Span names for the stages of move processing
+const (
+	SpanPlayMove  = "game.play_move"  // Whole move, parent of the stages below
+	SpanValidate  = "game.validate"   // Clock, turn, placement and dictionary checks
+	SpanScore     = "game.score"      // Formed words and their score
+	SpanApply     = "game.apply"      // Board, rack, bag and history updates
+	SpanNotify    = "game.notify"     // Spectator views
+	SpanBotSearch = "game.bot_search" // Bot move generation and choice
+)
+
+// Tracer starts spans timing the stages of move processing
+// It mirrors the start-and-end shape of an OpenTelemetry tracer, so an adapter
+// over one needs only to convert the attributes and record the error.
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is one timed stage started by a Tracer
+type Span interface {
+	SetAttributes(attrs ...slog.Attr)
+	End(err error) // err is nil when the stage succeeded
+}
+
+// noopSpan is the span used when tracing is off
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(...slog.Attr) {}
+func (noopSpan) End(error)                  {}
+
+// SetTracer traces the game's move processing and bot searches with t; nil
+// turns tracing off
+// The root span of each move carries the game ID, turn and player ID, using
+// the same keys as the game's log records.
+func (g *Game) SetTracer(t Tracer) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.tracer = t
+}
+
+// startSpan starts a span under ctx with the given attributes, or a span that
+// does nothing when tracing is off; callers must hold the lock
+func (g *Game) startSpan(ctx context.Context, name string, attrs ...slog.Attr) (context.Context, Span) {
+	if g.tracer == nil {
+		return ctx, noopSpan{}
+	}
+	ctx, span := g.tracer.Start(ctx, name)
+	if len(attrs) > 0 {
+		span.SetAttributes(attrs...)
+	}
+	return ctx, span
+}
+
+// traceAttrs returns the attributes identifying a player's turn in this game;
+// callers must hold the lock
+func (g *Game) traceAttrs(playerID string) []slog.Attr {
+	return []slog.Attr{
+		slog.String(LogKeyGameID, g.ID),
+		slog.Int(LogKeyTurn, len(g.History)),
+		slog.String(LogKeyPlayerID, playerID),
+	}
+}
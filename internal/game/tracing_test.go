@@ -0,0 +1,130 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+)
+
+// recordedSpan is a span captured by recordingTracer
+type recordedSpan struct {
+	name   string
+	parent string
+	attrs  map[string]slog.Value
+	err    error
+	ended  bool
+}
+
+func (s *recordedSpan) SetAttributes(attrs ...slog.Attr) {
+	for _, a := range attrs {
+		s.attrs[a.Key] = a.Value
+	}
+}
+
+func (s *recordedSpan) End(err error) {
+	s.err = err
+	s.ended = true
+}
+
+// spanKey is the context key of the span a recordingTracer started
+type spanKey struct{}
+
+// recordingTracer records every span it starts
+type recordingTracer struct {
+	spans []*recordedSpan
+}
+
+func (rt *recordingTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	s := &recordedSpan{name: name, attrs: make(map[string]slog.Value)}
+	if parent, ok := ctx.Value(spanKey{}).(*recordedSpan); ok {
+		s.parent = parent.name
+	}
+	rt.spans = append(rt.spans, s)
+	return context.WithValue(ctx, spanKey{}, s), s
+}
+
+// TestPlayMoveTracing tests the spans around each stage of a move
+func TestPlayMoveTracing(t *testing.T) {
+	g := newTestGame(t, 2)
+	g.StartGame()
+	tracer := &recordingTracer{}
+	g.SetTracer(tracer)
+	g.Players[0].Rack = rackOf("CATSXYZ")
+
+	move, _ := g.Board.BuildMove("p1", "CAT", mustPos(t, "H8"), Horizontal, nil)
+	if _, err := g.PlayMove(move); err != nil {
+		t.Fatalf("PlayMove failed: %v", err)
+	}
+
+	want := []struct{ name, parent string }{
+		{SpanPlayMove, ""},
+		{SpanValidate, SpanPlayMove},
+		{SpanScore, SpanPlayMove},
+		{SpanApply, SpanPlayMove},
+		{SpanNotify, SpanPlayMove},
+	}
+	if len(tracer.spans) != len(want) {
+		t.Fatalf("Should start %d spans, got %d", len(want), len(tracer.spans))
+	}
+	for i, w := range want {
+		s := tracer.spans[i]
+		if s.name != w.name || s.parent != w.parent || !s.ended || s.err != nil {
+			t.Errorf("Span %d should be %s under %q and ended cleanly, got %+v", i, w.name, w.parent, s)
+		}
+	}
+	root := tracer.spans[0]
+	if root.attrs[LogKeyGameID].String() != "test-game" || root.attrs[LogKeyPlayerID].String() != "p1" || root.attrs[LogKeyTurn].Int64() != 0 {
+		t.Errorf("Root span should identify the turn, got %v", root.attrs)
+	}
+	if tracer.spans[2].attrs["score"].Int64() != 10 {
+		t.Errorf("Score span should carry the score, got %v", tracer.spans[2].attrs)
+	}
+
+	tracer.spans = nil
+	if _, err := g.PlayMove(move); err == nil {
+		t.Fatalf("Playing out of turn should fail")
+	}
+	if len(tracer.spans) != 2 || !errors.Is(tracer.spans[0].err, ErrNotPlayersTurn) || !errors.Is(tracer.spans[1].err, ErrNotPlayersTurn) {
+		t.Errorf("A rejected move should end its root and validation spans with the error, got %+v", tracer.spans)
+	}
+}
+
+// TestBotSearchTracing tests the span around each bot move search
+func TestBotSearchTracing(t *testing.T) {
+	g := newBotGame(t, "bots")
+	tracer := &recordingTracer{}
+	g.SetTracer(tracer)
+
+	played, err := g.PlayBotTurns()
+	if err != nil {
+		t.Fatalf("PlayBotTurns failed: %v", err)
+	}
+
+	searches := 0
+	for _, s := range tracer.spans {
+		if s.name != SpanBotSearch {
+			continue
+		}
+		searches++
+		if s.parent != "" || !s.ended || s.attrs["strategy"].String() != "GREEDY" {
+			t.Errorf("Bot search span was not recorded correctly: %+v", s)
+		}
+	}
+	if searches != len(played) {
+		t.Errorf("Should trace one search per bot turn, got %d for %d turns", searches, len(played))
+	}
+}
+
+// TestNoTracer tests that moves play normally without a tracer
+func TestNoTracer(t *testing.T) {
+	g := newTestGame(t, 2)
+	g.StartGame()
+	g.SetTracer(nil)
+	g.Players[0].Rack = rackOf("CATSXYZ")
+
+	move, _ := g.Board.BuildMove("p1", "CAT", mustPos(t, "H8"), Horizontal, nil)
+	if score, err := g.PlayMove(move); err != nil || score != 10 {
+		t.Errorf("Should play untraced, got %d, %v", score, err)
+	}
+}
@@ -0,0 +1,110 @@
+package game
+
+// TileTracker computes the tiles a player has not yet seen: those on
+// opponents' racks or still in the bag
+type TileTracker struct {
+	letters      map[rune]letterSpec // Letter quantities and values of the tile set
+	distribution map[rune]int        // Full tile set by letter; blanks are keyed by 0
+}
+
+// NewTileTracker creates a tracker for the standard Scrabble distribution
+func NewTileTracker() *TileTracker {
+	return NewTileTrackerForVariant(Classic)
+}
+
+// NewTileTrackerForVariant creates a tracker for the variant's tile distribution
+func NewTileTrackerForVariant(v Variant) *TileTracker {
+	return newTileTracker(v.distribution(), blankTileCount)
+}
+
+// NewTileTrackerForTileSet creates a tracker for a language tile set
+func NewTileTrackerForTileSet(ts *TileSet) *TileTracker {
+	return newTileTracker(ts.distribution(), ts.Blanks)
+}
+
+// newTileTracker creates a tracker for the given letters and number of blanks
+func newTileTracker(letters map[rune]letterSpec, blanks int) *TileTracker {
+	distribution := make(map[rune]int, len(letters)+1)
+	for letter, dist := range letters {
+		distribution[letter] = dist.quantity
+	}
+	distribution[0] = blanks
+	return &TileTracker{letters: letters, distribution: distribution}
+}
+
+// Unseen returns the count of each unseen tile given the board and the
+// player's own rack; blanks are keyed by 0 and letters with none left are omitted
+func (tt *TileTracker) Unseen(b *Board, rack []Tile) map[rune]int {
+	unseen := make(map[rune]int, len(tt.distribution))
+	for letter, n := range tt.distribution {
+		unseen[letter] = n
+	}
+
+	for _, pos := range b.GetOccupiedPositions() {
+		unseen[trackerKey(*b.GetTile(pos))]--
+	}
+	for _, t := range rack {
+		unseen[trackerKey(t)]--
+	}
+
+	for letter, n := range unseen {
+		if n <= 0 {
+			delete(unseen, letter)
+		}
+	}
+	return unseen
+}
+
+// UnseenTiles returns the unseen tiles in alphabetical order with blanks last
+func (tt *TileTracker) UnseenTiles(b *Board, rack []Tile) []Tile {
+	unseen := tt.Unseen(b, rack)
+
+	tiles := make([]Tile, 0, 100)
+	for _, letter := range sortedLetters(tt.letters) {
+		for i := 0; i < unseen[letter]; i++ {
+			tiles = append(tiles, Tile{Letter: letter, Points: tt.letters[letter].points})
+		}
+	}
+	for i := 0; i < unseen[0]; i++ {
+		tiles = append(tiles, Tile{IsBlank: true})
+	}
+	return tiles
+}
+
+// UnseenCount returns the number of unseen tiles
+func (tt *TileTracker) UnseenCount(b *Board, rack []Tile) int {
+	total := 0
+	for _, n := range tt.Unseen(b, rack) {
+		total += n
+	}
+	return total
+}
+
+// trackerKey returns the tracking key for a tile; blanks count as blanks
+// whatever letter they were assigned
+func trackerKey(t Tile) rune {
+	if t.IsBlank {
+		return 0
+	}
+	return t.Letter
+}
+
+// tileTracker returns a tracker for the tiles the board is played with
+func (b *Board) tileTracker() *TileTracker {
+	if b.TileSet != nil {
+		return NewTileTrackerForTileSet(b.TileSet)
+	}
+	return NewTileTrackerForVariant(b.Variant)
+}
+
+// UnseenTiles returns the tiles the player has not seen: every opponent rack plus the bag
+func (g *Game) UnseenTiles(playerID string) ([]Tile, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	player := g.findPlayer(playerID)
+	if player == nil {
+		return nil, ErrPlayerNotFound
+	}
+	return g.Board.tileTracker().UnseenTiles(g.Board, player.Rack), nil
+}
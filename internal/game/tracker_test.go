@@ -0,0 +1,68 @@
+package game
+
+import (
+	"testing"
+)
+
+// TestTileTrackerFreshGame tests that everything but the rack is unseen at the start
+func TestTileTrackerFreshGame(t *testing.T) {
+	tracker := NewTileTracker()
+	b := NewBoard()
+	rack := rackOf("AEEQRS?")
+
+	unseen := tracker.Unseen(b, rack)
+	if unseen['E'] != 10 || unseen['A'] != 8 || unseen[0] != 1 {
+		t.Errorf("Unexpected unseen counts: E=%d A=%d blank=%d", unseen['E'], unseen['A'], unseen[0])
+	}
+	if _, ok := unseen['Q']; ok {
+		t.Errorf("Letters with none left should be omitted")
+	}
+	if n := tracker.UnseenCount(b, rack); n != 93 {
+		t.Errorf("Expected 93 unseen tiles, got %d", n)
+	}
+}
+
+// TestTileTrackerBoard tests that board tiles, including blanks, are seen
+func TestTileTrackerBoard(t *testing.T) {
+	tracker := NewTileTracker()
+	b := NewBoard()
+	placeWord(t, b, "ZAX", "H8", Horizontal)
+	b.PlaceTile(Tile{Letter: 'E', IsBlank: true}, mustPos(t, "H9"))
+
+	unseen := tracker.Unseen(b, rackOf("?"))
+	if _, ok := unseen['Z']; ok {
+		t.Errorf("Z on the board should be seen")
+	}
+	if unseen['E'] != 12 {
+		t.Errorf("A blank played as E should not count against E, got %d", unseen['E'])
+	}
+	if _, ok := unseen[0]; ok {
+		t.Errorf("Both blanks should be seen")
+	}
+
+	tiles := tracker.UnseenTiles(b, rackOf("?"))
+	if len(tiles) != 95 {
+		t.Fatalf("Expected 95 unseen tiles, got %d", len(tiles))
+	}
+	if tiles[0].Letter != 'A' || tiles[len(tiles)-1].Letter != 'Y' {
+		t.Errorf("Unseen tiles should be in alphabetical order, got %v..%v", tiles[0], tiles[len(tiles)-1])
+	}
+}
+
+// TestGameUnseenTiles tests that a player's unseen tiles are the opponents' racks plus the bag
+func TestGameUnseenTiles(t *testing.T) {
+	g := newTestGame(t, 2)
+	g.StartGame()
+
+	unseen, err := g.UnseenTiles("p1")
+	if err != nil {
+		t.Fatalf("UnseenTiles failed: %v", err)
+	}
+	if expected := g.TileBag.RemainingCount() + g.Players[1].GetRackSize(); len(unseen) != expected {
+		t.Errorf("Expected %d unseen tiles, got %d", expected, len(unseen))
+	}
+
+	if _, err := g.UnseenTiles("nobody"); err != ErrPlayerNotFound {
+		t.Errorf("Should return ErrPlayerNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,190 @@
+package game
+
+import (
+	"errors"
+	"time"
+)
+
+// Errors returned by undo and redo
+var (
+	ErrNothingToUndo = errors.New("nothing to undo")
+	ErrNothingToRedo = errors.New("nothing to redo")
+)
+
+// Undo reverts the most recent turn action (play, exchange, pass or challenge),
+// restoring the board, racks, bag, scores and turn pointer
+func (g *Game) Undo() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if len(g.undoLog) == 0 {
+		return ErrNothingToUndo
+	}
+	if g.Paused != nil {
+		return ErrGamePaused
+	}
+
+	prev := g.undoLog[len(g.undoLog)-1]
+	g.undoLog = g.undoLog[:len(g.undoLog)-1]
+	g.redoLog = append(g.redoLog, g.captureState())
+	g.restoreTurnState(prev)
+	g.resumeClock()
+	g.record(Event{Type: ActionUndone})
+	g.touch()
+
+	return nil
+}
+
+// Redo reapplies the most recently undone action
+func (g *Game) Redo() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if len(g.redoLog) == 0 {
+		return ErrNothingToRedo
+	}
+	if g.Paused != nil {
+		return ErrGamePaused
+	}
+
+	next := g.redoLog[len(g.redoLog)-1]
+	g.redoLog = g.redoLog[:len(g.redoLog)-1]
+	g.undoLog = append(g.undoLog, g.captureState())
+	g.restoreTurnState(next)
+	g.resumeClock()
+	g.record(Event{Type: ActionRedone})
+	g.touch()
+
+	return nil
+}
+
+// CanUndo returns true if there is an action to undo
+func (g *Game) CanUndo() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return len(g.undoLog) > 0
+}
+
+// CanRedo returns true if there is an undone action to redo
+func (g *Game) CanRedo() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return len(g.redoLog) > 0
+}
+
+// restoreTurnState restores a snapshot for undo or redo, keeping annotations
+// made since it was taken on moves that remain in the history; callers must
+// hold the lock
+func (g *Game) restoreTurnState(snap gameSnapshot) {
+	current, turns := g.annotations, len(g.History)
+	g.restoreState(snap)
+
+	var kept []Annotation
+	for _, a := range current {
+		if a.Turn < turns && a.Turn < len(g.History) {
+			kept = append(kept, a)
+		}
+	}
+	for _, a := range g.annotations {
+		if a.Turn >= turns {
+			kept = append(kept, a)
+		}
+	}
+	g.annotations = kept
+}
+
+// recordAction saves the current state before a turn action mutates it and
+// discards any redo history; callers must hold the lock
+func (g *Game) recordAction() {
+	g.undoLog = append(g.undoLog, g.captureState())
+	g.redoLog = nil
+}
+
+// captureState takes a deep copy of the game state; callers must hold the lock
+func (g *Game) captureState() gameSnapshot {
+	snap := gameSnapshot{
+		Version:       SnapshotVersion,
+		ID:            g.ID,
+		Board:         g.Board.Clone(),
+		Players:       make([]*Player, len(g.Players)),
+		Bag:           g.TileBag.Tiles(),
+		CurrentTurn:   g.CurrentTurn,
+		State:         g.State,
+		History:       copyHistory(g.History),
+		Scoreless:     g.Scoreless,
+		ChallengeRule: g.ChallengeRule,
+		ExchangeMin:   g.ExchangeMinimum,
+		Teams:         g.Teams,
+		Solo:          g.Solo,
+		AbandonAfter:  g.AbandonAfter,
+		Forfeit:       copyForfeit(g.Forfeit),
+		Pauses:        g.Pauses,
+		Paused:        copyPause(g.Paused),
+		PauseRequest:  copyPauseRequest(g.PauseRequest),
+		VacationUsed:  copyVacations(g.VacationUsed),
+		Challengeable: g.challengeable,
+		CreatedAt:     g.CreatedAt,
+		LastActivity:  g.LastActivity,
+		TurnStarted:   g.turnStarted,
+		ExpiresAt:     g.ExpiresAt,
+		Clock:         copyClock(g.Clock),
+		Revision:      g.Revision,
+		EventCount:    g.eventBase + len(g.events),
+		Feedback:      append([]MoveFeedback(nil), g.feedback...),
+		Annotations:   append([]Annotation(nil), g.annotations...),
+		Dictionary:    g.DictionaryName,
+	}
+
+	for i, p := range g.Players {
+		snap.Players[i] = p.Clone()
+	}
+	for id := range g.losesTurn {
+		snap.LosesTurn = append(snap.LosesTurn, id)
+	}
+
+	return snap
+}
+
+// restoreState replaces the game state with a deep copy of snap; callers must hold the lock
+// Attached bots, coaching settings, the dictionary, the clocks, pauses and the undo/redo logs are
+// left untouched, so undoing a move does not give back the time spent on it.
+func (g *Game) restoreState(snap gameSnapshot) {
+	g.ID = snap.ID
+	g.Board = snap.Board.Clone()
+	g.TileBag = NewTileBagFromTiles(snap.Bag)
+	g.CurrentTurn = snap.CurrentTurn
+	g.State = snap.State
+	g.Scoreless = snap.Scoreless
+	g.ChallengeRule = snap.ChallengeRule
+	g.ExchangeMinimum = snap.ExchangeMin
+	g.Teams = snap.Teams
+	g.Solo = snap.Solo
+	g.AbandonAfter = snap.AbandonAfter
+	g.Forfeit = copyForfeit(snap.Forfeit)
+	g.Pauses = snap.Pauses
+	g.turnStarted = time.Now()
+	g.suggestion = nil
+	g.challengeable = snap.Challengeable
+	g.CreatedAt = snap.CreatedAt
+	g.LastActivity = snap.LastActivity
+	g.ExpiresAt = snap.ExpiresAt
+
+	g.Players = make([]*Player, len(snap.Players))
+	for i, p := range snap.Players {
+		g.Players[i] = p.Clone()
+	}
+
+	g.History = copyHistory(snap.History)
+	g.feedback = append([]MoveFeedback(nil), snap.Feedback...)
+	g.annotations = append([]Annotation(nil), snap.Annotations...)
+
+	g.losesTurn = make(map[string]bool, len(snap.LosesTurn))
+	for _, id := range snap.LosesTurn {
+		g.losesTurn[id] = true
+	}
+	if g.bots == nil {
+		g.bots = make(map[string]Strategy)
+	}
+}
@@ -0,0 +1,125 @@
+package game
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestUndoRedoMove tests reverting and reapplying a tile placement
+func TestUndoRedoMove(t *testing.T) {
+	g := newTestGame(t, 2)
+	g.StartGame()
+
+	if err := g.Undo(); !errors.Is(err, ErrNothingToUndo) {
+		t.Errorf("Undo with no actions should return ErrNothingToUndo, got %v", err)
+	}
+
+	g.Players[0].Rack = rackOf("CATXYZQ")
+	rackBefore := append([]Tile{}, g.Players[0].Rack...)
+	bagBefore := g.TileBag.Tiles()
+
+	move, _ := g.Board.BuildMove("p1", "CAT", mustPos(t, "G8"), Horizontal, nil)
+	g.PlayMove(move)
+	after, _ := g.Serialize()
+
+	if err := g.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+
+	if !g.Board.IsFirstMove() {
+		t.Errorf("Undo should clear the board")
+	}
+	if !reflect.DeepEqual(g.Players[0].Rack, rackBefore) {
+		t.Errorf("Undo should restore the rack, got %v", g.Players[0].Rack)
+	}
+	if !reflect.DeepEqual(g.TileBag.Tiles(), bagBefore) {
+		t.Errorf("Undo should restore the bag")
+	}
+	if g.Players[0].Score != 0 || len(g.History) != 0 || g.CurrentPlayer().ID != "p1" {
+		t.Errorf("Undo should restore score, history and turn")
+	}
+
+	if !g.CanRedo() {
+		t.Fatalf("Redo should be available after undo")
+	}
+	if err := g.Redo(); err != nil {
+		t.Fatalf("Redo failed: %v", err)
+	}
+
+	redone, _ := g.Serialize()
+	a, _ := LoadGame(after)
+	b, _ := LoadGame(redone)
+	if !reflect.DeepEqual(a.Board.Grid, b.Board.Grid) || a.Players[0].Score != b.Players[0].Score || a.CurrentTurn != b.CurrentTurn {
+		t.Errorf("Redo should restore the state after the move")
+	}
+
+	if err := g.Redo(); !errors.Is(err, ErrNothingToRedo) {
+		t.Errorf("Second redo should return ErrNothingToRedo, got %v", err)
+	}
+}
+
+// TestUndoExchangeAndPass tests undoing non-placement turns
+func TestUndoExchangeAndPass(t *testing.T) {
+	g := newTestGame(t, 2)
+	g.StartGame()
+	rackBefore := append([]Tile{}, g.Players[0].Rack...)
+
+	g.ExchangeTiles("p1", []int{0, 1})
+	g.PassTurn("p2")
+
+	g.Undo()
+	if g.CurrentPlayer().ID != "p2" || g.Scoreless != 1 {
+		t.Errorf("Undoing the pass should return the turn to p2")
+	}
+
+	g.Undo()
+	if !reflect.DeepEqual(g.Players[0].Rack, rackBefore) || g.Scoreless != 0 {
+		t.Errorf("Undoing the exchange should restore the original rack")
+	}
+	if g.CanUndo() {
+		t.Errorf("No further undo should be available")
+	}
+}
+
+// TestRedoClearedByNewAction tests that a new action discards the redo history
+func TestRedoClearedByNewAction(t *testing.T) {
+	g := newTestGame(t, 2)
+	g.StartGame()
+
+	g.PassTurn("p1")
+	g.Undo()
+	g.PassTurn("p1")
+
+	if g.CanRedo() {
+		t.Errorf("A new action should clear the redo history")
+	}
+
+	// Failed actions are not recorded
+	g.PassTurn("p1")
+	if err := g.ExchangeTiles("p2", []int{42}); err == nil {
+		t.Fatalf("Invalid exchange should fail")
+	}
+	g.Undo()
+	g.Undo()
+	if g.CanUndo() {
+		t.Errorf("Failed actions should not be recorded for undo")
+	}
+}
+
+// TestUndoChallenge tests undoing a challenge restores the withdrawn move
+func TestUndoChallenge(t *testing.T) {
+	g := newChallengeGame(t)
+	g.Players[0].Rack = rackOf("CATXYZQ")
+
+	phony, _ := g.Board.BuildMove("p1", "TAC", mustPos(t, "G8"), Horizontal, nil)
+	g.PlayMove(phony)
+	g.Challenge("p2")
+
+	if err := g.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if g.Board.IsFirstMove() || !g.CanChallenge() {
+		t.Errorf("Undoing a challenge should restore the challenged move")
+	}
+}
@@ -0,0 +1,132 @@
+package game
+
+import (
+	"math/rand"
+)
+
+// Variant selects the board layout, tile distribution and bingo bonus of a game
+type Variant int
+
+const (
+	Classic          Variant = iota // Standard Scrabble
+	WordsWithFriends                // Words With Friends layout, 104 tiles and a 35 point bingo
+)
+
+// String returns a string representation of the variant
+func (v Variant) String() string {
+	switch v {
+	case Classic:
+		return "CLASSIC"
+	case WordsWithFriends:
+		return "WORDS_WITH_FRIENDS"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// WWFBingoBonus is the Words With Friends bonus for playing all seven tiles
+const WWFBingoBonus = 35
+
+// Words With Friends tile distribution (102 letters plus 2 blanks)
+var wwfTileDistribution = map[rune]letterSpec{
+	'A': {9, 1}, 'B': {2, 4}, 'C': {2, 4}, 'D': {5, 2}, 'E': {13, 1},
+	'F': {2, 4}, 'G': {3, 3}, 'H': {4, 3}, 'I': {8, 1}, 'J': {1, 10},
+	'K': {1, 5}, 'L': {4, 2}, 'M': {2, 4}, 'N': {5, 2}, 'O': {8, 1},
+	'P': {2, 4}, 'Q': {1, 10}, 'R': {6, 1}, 'S': {5, 1}, 'T': {7, 1},
+	'U': {4, 2}, 'V': {2, 5}, 'W': {2, 4}, 'X': {1, 8}, 'Y': {2, 3},
+	'Z': {1, 10},
+}
+
+// wwfLayout is the Words With Friends premium layout, one string per row:
+// T triple word, D double word, t triple letter, d double letter
+// The center square carries no premium.
+var wwfLayout = [15]string{
+	"...T..t.t..T...",
+	"..d..D...D..d..",
+	".d..d.....d..d.",
+	"T..t...D...t..T",
+	"..d...d.d...d..",
+	".D...t...t...D.",
+	"t...d.....d...t",
+	"...D.......D...",
+	"t...d.....d...t",
+	".D...t...t...D.",
+	"..d...d.d...d..",
+	"T..t...D...t..T",
+	".d..d.....d..d.",
+	"..d..D...D..d..",
+	"...T..t.t..T...",
+}
+
+// BingoBonus returns the bonus for playing all seven tiles in the variant
+func (v Variant) BingoBonus() int {
+	if v == WordsWithFriends {
+		return WWFBingoBonus
+	}
+	return BingoBonus
+}
+
+// TileValue returns the point value of a letter in the variant
+// Returns 0 for blank tiles or invalid letters
+func (v Variant) TileValue(letter rune) int {
+	return v.distribution()[letter].points
+}
+
+// TileCount returns the total number of tiles, including blanks, in the variant
+func (v Variant) TileCount() int {
+	total := blankTileCount
+	for _, spec := range v.distribution() {
+		total += spec.quantity
+	}
+	return total
+}
+
+// distribution returns the letter distribution of the variant
+func (v Variant) distribution() map[rune]letterSpec {
+	if v == WordsWithFriends {
+		return wwfTileDistribution
+	}
+	return standardTileDistribution
+}
+
+// NewBoardForVariant creates an empty board with the variant's premium layout
+func NewBoardForVariant(v Variant) *Board {
+	board := NewBoard()
+	board.Variant = v
+	board.BingoBonus = v.BingoBonus()
+	if v != WordsWithFriends {
+		return board
+	}
+
+	premiums := map[byte]PremiumType{
+		'T': TripleWordScore,
+		'D': DoubleWordScore,
+		't': TripleLetterScore,
+		'd': DoubleLetterScore,
+		'.': Normal,
+	}
+	for row, line := range wwfLayout {
+		for col := 0; col < 15; col++ {
+			board.Grid[row][col].Premium = premiums[line[col]]
+		}
+	}
+	return board
+}
+
+// NewTileBagForVariant creates a shuffled tile bag with the variant's distribution
+func NewTileBagForVariant(v Variant) *TileBag {
+	return newShuffledTileBag(v.distribution(), blankTileCount, nil)
+}
+
+// NewTileBagForVariantWithSeed creates a variant tile bag whose shuffles are determined by seed
+func NewTileBagForVariantWithSeed(v Variant, seed int64) *TileBag {
+	return newShuffledTileBag(v.distribution(), blankTileCount, rand.New(rand.NewSource(seed)))
+}
+
+// Variant returns the rule set the game is played under
+func (g *Game) Variant() Variant {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.Board.Variant
+}
@@ -0,0 +1,120 @@
+package game
+
+import (
+	"testing"
+)
+
+// TestWWFBoard tests the Words With Friends premium layout
+func TestWWFBoard(t *testing.T) {
+	board := NewBoardForVariant(WordsWithFriends)
+
+	counts := board.CountPremiumSquares()
+	expected := map[PremiumType]int{
+		Normal:            165,
+		DoubleLetterScore: 24,
+		TripleLetterScore: 16,
+		DoubleWordScore:   12,
+		TripleWordScore:   8,
+	}
+	for pt, n := range expected {
+		if counts[pt] != n {
+			t.Errorf("Expected %d %s squares, got %d", n, pt, counts[pt])
+		}
+	}
+
+	for pos, pt := range map[string]PremiumType{"D1": TripleWordScore, "G1": TripleLetterScore, "F2": DoubleWordScore, "C2": DoubleLetterScore, "H8": Normal, "L15": TripleWordScore} {
+		if got := board.GetPremiumType(mustPos(t, pos)); got != pt {
+			t.Errorf("%s should be %s, got %s", pos, pt, got)
+		}
+	}
+
+	if err := board.ValidateBoard(); err != nil {
+		t.Errorf("WWF board should be valid: %v", err)
+	}
+
+	// A classic layout does not validate as WWF
+	classic := NewBoard()
+	classic.Variant = WordsWithFriends
+	if err := classic.ValidateBoard(); err == nil {
+		t.Errorf("Classic layout should not validate as a WWF board")
+	}
+}
+
+// TestVariantTiles tests variant tile distributions and values
+func TestVariantTiles(t *testing.T) {
+	if n := WordsWithFriends.TileCount(); n != 104 {
+		t.Errorf("WWF should have 104 tiles, got %d", n)
+	}
+	if n := Classic.TileCount(); n != 100 {
+		t.Errorf("Classic should have 100 tiles, got %d", n)
+	}
+
+	bag := NewTileBagForVariant(WordsWithFriends)
+	if bag.RemainingCount() != 104 {
+		t.Errorf("WWF bag should hold 104 tiles, got %d", bag.RemainingCount())
+	}
+	for _, tile := range bag.Tiles() {
+		if !tile.IsBlank && tile.Points != WordsWithFriends.TileValue(tile.Letter) {
+			t.Errorf("Tile %c should be worth %d, got %d", tile.Letter, WordsWithFriends.TileValue(tile.Letter), tile.Points)
+		}
+	}
+
+	if WordsWithFriends.TileValue('B') != 4 || Classic.TileValue('B') != 3 {
+		t.Errorf("B should be worth 4 in WWF and 3 in classic")
+	}
+	if WordsWithFriends.BingoBonus() != 35 || Classic.BingoBonus() != BingoBonus {
+		t.Errorf("Unexpected bingo bonuses")
+	}
+
+	seeded1 := NewTileBagForVariantWithSeed(WordsWithFriends, 9)
+	seeded2 := NewTileBagForVariantWithSeed(WordsWithFriends, 9)
+	if gcgRack(seeded1.DrawTiles(20)) != gcgRack(seeded2.DrawTiles(20)) {
+		t.Errorf("Seeded variant bags should match")
+	}
+}
+
+// TestWWFGame tests playing a game under the Words With Friends variant
+func TestWWFGame(t *testing.T) {
+	g, err := NewGameWithVariant("wwf", []*Player{NewPlayer("p1", "Alice"), NewPlayer("p2", "Bob")}, WordsWithFriends)
+	if err != nil {
+		t.Fatalf("NewGameWithVariant failed: %v", err)
+	}
+	if g.Variant() != WordsWithFriends {
+		t.Errorf("Game should report its variant")
+	}
+	g.StartGame()
+
+	// CAT through the unmarked center: C(4) + A(1) + T(1)
+	g.Players[0].Rack = []Tile{{Letter: 'C', Points: 4}, {Letter: 'A', Points: 1}, {Letter: 'T', Points: 1}}
+	move, err := g.Board.BuildMove("p1", "CAT", mustPos(t, "H8"), Horizontal, nil)
+	if err != nil {
+		t.Fatalf("BuildMove failed: %v", err)
+	}
+	score, err := g.PlayMove(move)
+	if err != nil {
+		t.Fatalf("PlayMove failed: %v", err)
+	}
+	if score != 6 {
+		t.Errorf("Expected WWF score 6, got %d", score)
+	}
+
+	// Bingos earn the WWF bonus; D8 is a double word
+	b := NewBoardForVariant(WordsWithFriends)
+	bingo, _ := b.BuildMove("p1", "ZOOLOGY", mustPos(t, "B8"), Horizontal, nil)
+	if got, want := b.ScoreMove(bingo), (10+1+1+2+1+3+3)*2+WWFBingoBonus; got != want {
+		t.Errorf("Expected bingo score %d, got %d", want, got)
+	}
+
+	// The variant survives a save and load
+	data, err := g.Serialize()
+	if err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	loaded, err := LoadGame(data)
+	if err != nil {
+		t.Fatalf("LoadGame failed: %v", err)
+	}
+	if loaded.Variant() != WordsWithFriends {
+		t.Errorf("Loaded game should keep its variant")
+	}
+}
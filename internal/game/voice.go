@@ -0,0 +1,307 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// ErrUnrecognizedPlay is returned when a spoken play cannot be understood
+var ErrUnrecognizedPlay = errors.New("unrecognized play")
+
+// spokenNumbers are the row numbers as words
+var spokenNumbers = map[string]string{
+	"ONE": "1", "TWO": "2", "THREE": "3", "FOUR": "4", "FIVE": "5",
+	"SIX": "6", "SEVEN": "7", "EIGHT": "8", "NINE": "9", "TEN": "10",
+	"ELEVEN": "11", "TWELVE": "12", "THIRTEEN": "13", "FOURTEEN": "14", "FIFTEEN": "15",
+}
+
+// spokenLetters are the letters in the NATO spelling alphabet
+var spokenLetters = map[string]string{
+	"ALPHA": "A", "ALFA": "A", "BRAVO": "B", "CHARLIE": "C", "DELTA": "D",
+	"ECHO": "E", "FOXTROT": "F", "GOLF": "G", "HOTEL": "H", "INDIA": "I",
+	"JULIET": "J", "JULIETT": "J", "KILO": "K", "LIMA": "L", "MIKE": "M",
+	"NOVEMBER": "N", "OSCAR": "O", "PAPA": "P", "QUEBEC": "Q", "ROMEO": "R",
+	"SIERRA": "S", "TANGO": "T", "UNIFORM": "U", "VICTOR": "V", "WHISKEY": "W",
+	"XRAY": "X", "X-RAY": "X", "YANKEE": "Y", "ZULU": "Z",
+}
+
+// spokenDirections are the words that give a play's direction
+var spokenDirections = map[string]Direction{
+	"ACROSS": Horizontal, "HORIZONTAL": Horizontal, "HORIZONTALLY": Horizontal,
+	"DOWN": Vertical, "VERTICAL": Vertical, "VERTICALLY": Vertical,
+}
+
+// spokenFillers are words that join the parts of a play and carry no meaning
+var spokenFillers = map[string]bool{
+	"AT": true, "ON": true, "FROM": true, "START": true, "STARTING": true, "GOING": true,
+}
+
+// gridReference matches coordinates in either order once spoken words are replaced
+var gridReference = regexp.MustCompile(`^([A-Z][0-9]{1,2}|[0-9]{1,2}[A-Z])$`)
+
+// ParseSpokenPlay converts a play as a voice assistant would hear it, such as
+// "CAT at H8 across" or "8D QI down, blank as U", to a move by the player on
+// the current board
+// See Board.ParseSpokenPlay for the forms understood. The player's rack helps
+// settle ambiguous coordinates, but the move is not checked against it.
+func (g *Game) ParseSpokenPlay(playerID, s string) (Move, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	var rack []Tile
+	if p := g.findPlayer(playerID); p != nil {
+		rack = p.Rack
+	}
+	return g.Board.ParseSpokenPlay(playerID, s, rack)
+}
+
+// ParseSpokenPlay converts a spoken play to a move on the board
+// The word and its coordinates may come in either order, joined by words such
+// as "at" or "from", and case is ignored. Rows may be said as numbers or
+// words and columns as letters or in the NATO alphabet ("hotel eight"), or
+// the square named as "row 8 column H". Blanks are given after the play, as
+// in "blank as U" or "blanks as U and E", and stand for the first placed tile
+// with that letter.
+//
+// Coordinates are ambiguous when spoken: callers rarely keep to "8H" for
+// across and "H8" for down. An "across" or "down" is always followed; without
+// one the standard order is preferred, but if the play only fits the board,
+// and rack when one is given, in the other direction, that direction is taken.
+func (b *Board) ParseSpokenPlay(playerID, s string, rack []Tile) (Move, error) {
+	tokens := strings.FieldsFunc(strings.ToUpper(s), func(r rune) bool {
+		return unicode.IsSpace(r) || r == ',' || r == ';'
+	})
+	if len(tokens) > 0 && (tokens[0] == "PLAY" || tokens[0] == "PLAYING") {
+		tokens = tokens[1:]
+	}
+
+	tokens, blanks, err := spokenBlanks(tokens)
+	if err != nil {
+		return Move{}, err
+	}
+
+	// The play starts with its word or coordinates, so a first word such as
+	// DOWN is the word played
+	var dirs []Direction
+	var rest []string
+	for i, tok := range tokens {
+		if d, ok := spokenDirections[tok]; ok && i > 0 {
+			dirs = append(dirs, d)
+		} else {
+			rest = append(rest, tok)
+		}
+	}
+	if len(dirs) > 1 {
+		return Move{}, fmt.Errorf("%w: %q gives more than one direction", ErrUnrecognizedPlay, s)
+	}
+
+	word, pos, dir, ordered, ok := splitSpokenPlay(rest)
+	if !ok {
+		return Move{}, fmt.Errorf("%w: %q", ErrUnrecognizedPlay, s)
+	}
+
+	var candidates []Direction
+	switch {
+	case len(dirs) == 1:
+		candidates = dirs
+	case ordered:
+		candidates = []Direction{dir, dir.Perpendicular()}
+	default:
+		candidates = []Direction{Horizontal, Vertical}
+	}
+
+	var first Move
+	var firstErr error
+	for i, d := range candidates {
+		move, err := b.spokenMove(playerID, pos, d, word, blanks)
+		if err == nil && b.fits(move, rack) {
+			return move, nil
+		}
+		if i == 0 {
+			first, firstErr = move, err
+		}
+	}
+	return first, firstErr
+}
+
+// spokenBlanks removes the blank assignments from a spoken play, returning
+// the remaining words and the letters the blanks stand for in order
+func spokenBlanks(tokens []string) ([]string, []rune, error) {
+	var rest []string
+	var blanks []rune
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		if tok != "BLANK" && tok != "BLANKS" {
+			rest = append(rest, tok)
+			continue
+		}
+		// "with a blank" need not be said, but is often heard
+		if n := len(rest); n > 0 && (rest[n-1] == "A" || rest[n-1] == "THE") {
+			rest = rest[:n-1]
+		}
+		if n := len(rest); n > 0 && rest[n-1] == "WITH" {
+			rest = rest[:n-1]
+		}
+
+		i++
+		if i < len(tokens) && (tokens[i] == "AS" || tokens[i] == "IS" || tokens[i] == "FOR" || tokens[i] == "=") {
+			i++
+		}
+		start := len(blanks)
+		for ; i < len(tokens); i++ {
+			if tokens[i] == "AND" {
+				continue
+			}
+			letter, ok := spokenLetter(tokens[i])
+			if !ok {
+				break
+			}
+			blanks = append(blanks, letter)
+		}
+		i--
+		if len(blanks) == start {
+			return nil, nil, fmt.Errorf("%w: a blank needs a letter", ErrUnrecognizedPlay)
+		}
+	}
+	return rest, blanks, nil
+}
+
+// spokenLetter reads a single letter, said plainly or in the NATO alphabet
+func spokenLetter(tok string) (rune, bool) {
+	if l, ok := spokenLetters[tok]; ok {
+		tok = l
+	}
+	r := []rune(tok)
+	if len(r) != 1 || !unicode.IsLetter(r[0]) {
+		return 0, false
+	}
+	return r[0], true
+}
+
+// splitSpokenPlay finds the word and the coordinates, whichever comes first,
+// reporting whether the coordinates were said in an order that gives the
+// direction
+func splitSpokenPlay(tokens []string) (word string, pos Position, dir Direction, ordered, ok bool) {
+	if len(tokens) < 2 {
+		return "", Position{}, Horizontal, false, false
+	}
+	// Joining words are dropped from the coordinates only, so AT and ON can
+	// still be played
+	coordinates := func(tokens []string) (Position, Direction, bool, bool) {
+		var words []string
+		for _, tok := range tokens {
+			if !spokenFillers[tok] {
+				words = append(words, tok)
+			}
+		}
+		return spokenCoordinates(words)
+	}
+
+	isWord := func(tok string) bool {
+		for _, r := range tok {
+			if !unicode.IsLetter(r) {
+				return false
+			}
+		}
+		return true
+	}
+	// Coordinates first, as in "8D QI"
+	if last := tokens[len(tokens)-1]; isWord(last) {
+		if pos, dir, ordered, ok := coordinates(tokens[:len(tokens)-1]); ok {
+			return last, pos, dir, ordered, true
+		}
+	}
+	// The word first, as in "CAT at H8"
+	if isWord(tokens[0]) {
+		if pos, dir, ordered, ok := coordinates(tokens[1:]); ok {
+			return tokens[0], pos, dir, ordered, true
+		}
+	}
+	return "", Position{}, Horizontal, false, false
+}
+
+// spokenCoordinates reads a square from its spoken words, such as "H8",
+// "8 H", "hotel eight" or "row 8 column H"
+func spokenCoordinates(tokens []string) (Position, Direction, bool, bool) {
+	var sb strings.Builder
+	var row, col string
+	for i := 0; i < len(tokens); i++ {
+		switch tok := tokens[i]; {
+		case tok == "ROW" && i+1 < len(tokens):
+			i++
+			row = spokenGrid(tokens[i])
+		case tok == "COLUMN" && i+1 < len(tokens):
+			i++
+			col = spokenGrid(tokens[i])
+		default:
+			sb.WriteString(spokenGrid(tok))
+		}
+	}
+
+	if row != "" || col != "" {
+		if sb.Len() > 0 || row == "" || len(col) != 1 || !unicode.IsLetter(rune(col[0])) {
+			return Position{}, Horizontal, false, false
+		}
+		pos, _, err := ParseCoordinates(col + row)
+		return pos, Horizontal, false, err == nil
+	}
+	if !gridReference.MatchString(sb.String()) {
+		return Position{}, Horizontal, false, false
+	}
+	pos, dir, err := ParseCoordinates(sb.String())
+	return pos, dir, true, err == nil
+}
+
+// spokenGrid replaces a row number or column letter said as a word
+func spokenGrid(tok string) string {
+	if n, ok := spokenNumbers[tok]; ok {
+		return n
+	}
+	if l, ok := spokenLetters[tok]; ok {
+		return l
+	}
+	return tok
+}
+
+// spokenMove builds the play of word from pos in dir, making blanks of the
+// first placed tiles with the blanks' letters
+func (b *Board) spokenMove(playerID string, pos Position, dir Direction, word string, blanks []rune) (Move, error) {
+	coords := pos.String() + " down"
+	if dir == Horizontal {
+		coords = pos.String() + " across"
+	}
+	move, err := b.ParsePlay(playerID, coords, word)
+	if err != nil {
+		return Move{}, err
+	}
+
+	for _, letter := range blanks {
+		found := false
+		for i := range move.Tiles {
+			t := &move.Tiles[i].Tile
+			if t.Letter == letter && !t.IsBlank {
+				t.IsBlank, t.Points, found = true, 0, true
+				break
+			}
+		}
+		if !found {
+			return Move{}, fmt.Errorf("%w: no %c is placed for the blank", ErrUnrecognizedPlay, letter)
+		}
+	}
+	return move, nil
+}
+
+// fits reports whether a move is a legal placement from the rack, or from any
+// rack when none is given
+func (b *Board) fits(move Move, rack []Tile) bool {
+	if rack == nil {
+		rack = make([]Tile, len(move.Tiles))
+		for i, pt := range move.Tiles {
+			rack[i] = pt.Tile
+		}
+	}
+	return b.ValidatePlacement(move, rack) == nil
+}
@@ -0,0 +1,110 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestParseSpokenPlay tests converting spoken plays to moves
+func TestParseSpokenPlay(t *testing.T) {
+	empty := NewBoard()
+	board := NewBoard()
+	placeWord(t, board, "CAT", "H8", Horizontal)
+
+	tests := []struct {
+		name   string
+		board  *Board
+		spoken string
+		start  string
+		dir    Direction
+		word   string
+		tiles  int
+	}{
+		{"word first", empty, "CAT at H8 across", "H8", Horizontal, "CAT", 3},
+		{"coordinates first", empty, "8G QI", "G8", Horizontal, "QI", 2},
+		{"numbers and letters as words", empty, "play cat at hotel eight across", "H8", Horizontal, "CAT", 3},
+		{"separated coordinates", empty, "ZA from 8, h", "H8", Horizontal, "ZA", 2},
+		{"row and column", empty, "QI at row eight column G", "G8", Horizontal, "QI", 2},
+		{"word that is a filler", empty, "ON at 8H", "H8", Horizontal, "ON", 2},
+		{"word that is a direction", empty, "DOWN at H8 across", "H8", Horizontal, "DOWN", 4},
+		{"letters on the board", board, "ATE at juliet seven", "J7", Vertical, "ATE", 2},
+		{"only the other direction fits", board, "CAT at 8H", "H8", Vertical, "CAT", 2},
+		{"direction said outright", board, "TA at 9I down", "I9", Vertical, "TA", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			move, err := tt.board.ParseSpokenPlay("p1", tt.spoken, nil)
+			if err != nil {
+				t.Fatalf("ParseSpokenPlay(%q) failed: %v", tt.spoken, err)
+			}
+			if move.Start != mustPos(t, tt.start) || move.Direction != tt.dir || move.Word != tt.word || len(move.Tiles) != tt.tiles {
+				t.Errorf("ParseSpokenPlay(%q) = %s %s %v with %d tiles, want %s %s %v with %d",
+					tt.spoken, move.Word, move.Start, move.Direction, len(move.Tiles), tt.word, tt.start, tt.dir, tt.tiles)
+			}
+			if move.PlayerID != "p1" {
+				t.Errorf("Expected the move to be by p1, got %q", move.PlayerID)
+			}
+		})
+	}
+}
+
+// TestParseSpokenPlayBlanks tests assigning blanks to placed tiles
+func TestParseSpokenPlayBlanks(t *testing.T) {
+	board := NewBoard()
+	move, err := board.ParseSpokenPlay("p1", "8G QUA across, blank as U", nil)
+	if err != nil {
+		t.Fatalf("ParseSpokenPlay failed: %v", err)
+	}
+	if blanks := move.BlankAssignments(); len(blanks) != 1 || blanks[mustPos(t, "H8")] != 'U' || move.Tiles[1].Tile.Points != 0 {
+		t.Errorf("Expected a blank U at H8, got %v", blanks)
+	}
+
+	move, err = board.ParseSpokenPlay("p1", "TATS at H8 across with blanks as T and tango", nil)
+	if err != nil {
+		t.Fatalf("ParseSpokenPlay failed: %v", err)
+	}
+	if blanks := move.BlankAssignments(); len(blanks) != 2 || blanks[mustPos(t, "H8")] != 'T' || blanks[mustPos(t, "J8")] != 'T' {
+		t.Errorf("Expected both Ts to be blanks, got %v", blanks)
+	}
+}
+
+// TestParseSpokenPlayRack tests settling ambiguous coordinates by the rack
+func TestParseSpokenPlayRack(t *testing.T) {
+	g := newTestGame(t, 2)
+	placeWord(t, g.Board, "CAT", "H8", Horizontal)
+
+	// Across from J7 needs two Ts, down from J7 uses the T on the board
+	move, err := g.Board.ParseSpokenPlay("p1", "ATT at 7J", nil)
+	if err != nil || move.Direction != Horizontal {
+		t.Errorf("Without a rack the standard order should win, got %v, %v", move.Direction, err)
+	}
+	g.Players[0].Rack = rackOf("AEIXYZT")
+	move, err = g.ParseSpokenPlay("p1", "ATT at 7J")
+	if err != nil || move.Direction != Vertical || len(move.Tiles) != 2 {
+		t.Errorf("The rack should settle the play down, got %v with %d tiles, %v", move.Direction, len(move.Tiles), err)
+	}
+}
+
+// TestParseSpokenPlayErrors tests rejecting plays that cannot be understood
+func TestParseSpokenPlayErrors(t *testing.T) {
+	board := NewBoard()
+	for _, spoken := range []string{
+		"",
+		"CAT",
+		"CAT at",
+		"CAT at Z9",
+		"CAT at row eight",
+		"CAT at row H column eight",
+		"CAT at H8 across down",
+		"CAT at H8 blank as",
+		"CAT at H8 blank as E",
+	} {
+		if _, err := board.ParseSpokenPlay("p1", spoken, nil); !errors.Is(err, ErrUnrecognizedPlay) {
+			t.Errorf("ParseSpokenPlay(%q) should fail as unrecognized, got %v", spoken, err)
+		}
+	}
+	if _, err := board.ParseSpokenPlay("p1", "CATS at 8M across", nil); err == nil {
+		t.Errorf("A play running off the board should fail")
+	}
+}
@@ -0,0 +1,104 @@
+package game
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// The saved game format is versioned so that stored games survive changes to
+// the engine. Field names are part of the format and never change within a
+// version. Readers ignore fields they do not know, so a field may be added
+// without a new version when leaving it out means what older versions meant.
+// Renaming or removing a field, or changing what a value means, requires a
+// new SnapshotVersion and a migration that upgrades snapshots from the
+// previous version. Snapshots from a newer version than this package knows
+// are rejected rather than loaded with their new fields dropped.
+
+// ErrUnsupportedSnapshot is returned for snapshots with a missing or unknown version
+var ErrUnsupportedSnapshot = errors.New("unsupported snapshot version")
+
+//go:embed schema/snapshot.schema.json
+var snapshotSchema []byte
+
+// SnapshotSchema returns the JSON Schema describing snapshots of the current version
+func SnapshotSchema() []byte {
+	return append([]byte(nil), snapshotSchema...)
+}
+
+// snapshotMigrations upgrade a decoded snapshot by one version; entry i takes
+// version i+1 to version i+2
+var snapshotMigrations = []func(snap map[string]json.RawMessage) error{
+	migrateSnapshotV1,
+}
+
+// MigrateSnapshot upgrades a snapshot produced by Serialize at any supported
+// version to the current SnapshotVersion
+// Current snapshots are returned unchanged; stores may save the result so
+// later loads skip the migration.
+func MigrateSnapshot(data []byte) ([]byte, error) {
+	var snap map[string]json.RawMessage
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("failed to parse game snapshot: %w", err)
+	}
+
+	var version int
+	if raw, ok := snap["version"]; ok {
+		if err := json.Unmarshal(raw, &version); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedSnapshot, raw)
+		}
+	}
+	if version < 1 || version > SnapshotVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedSnapshot, version)
+	}
+	if version == SnapshotVersion {
+		return data, nil
+	}
+
+	for ; version < SnapshotVersion; version++ {
+		if err := snapshotMigrations[version-1](snap); err != nil {
+			return nil, fmt.Errorf("failed to migrate snapshot from version %d: %w", version, err)
+		}
+	}
+	snap["version"] = json.RawMessage(strconv.Itoa(version))
+	return json.Marshal(snap)
+}
+
+// migrateSnapshotV1 records the standard rules, which version 1 left implicit:
+// the variant's bingo bonus, a full rack and the usual exchange minimum
+func migrateSnapshotV1(snap map[string]json.RawMessage) error {
+	setDefault(snap, "exchange_minimum", MinTilesForExchange)
+
+	raw, ok := snap["board"]
+	if !ok || string(raw) == "null" {
+		return nil
+	}
+	var board map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &board); err != nil {
+		return fmt.Errorf("board: %w", err)
+	}
+	var variant Variant
+	if v, ok := board["variant"]; ok {
+		if err := json.Unmarshal(v, &variant); err != nil {
+			return fmt.Errorf("board variant: %w", err)
+		}
+	}
+	setDefault(board, "bingo_bonus", variant.BingoBonus())
+	setDefault(board, "rack_size", MaxRackSize)
+
+	data, err := json.Marshal(board)
+	if err != nil {
+		return err
+	}
+	snap["board"] = data
+	return nil
+}
+
+// setDefault sets a numeric field of a decoded object unless it is present
+func setDefault(obj map[string]json.RawMessage, key string, value int) {
+	if _, ok := obj[key]; !ok {
+		obj[key] = json.RawMessage(strconv.Itoa(value))
+	}
+}
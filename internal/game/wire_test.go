@@ -0,0 +1,178 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// jsonFields returns the JSON names of a struct's encoded fields and of
+// those that are always present
+func jsonFields(typ reflect.Type) (names, required []string) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := field.Tag.Get("json")
+		if !field.IsExported() || tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		names = append(names, name)
+		if opts != "omitempty" {
+			required = append(required, name)
+		}
+	}
+	sort.Strings(names)
+	sort.Strings(required)
+	return names, required
+}
+
+// schemaObject is the part of a JSON Schema object definition compared with the Go types
+type schemaObject struct {
+	Required   []string                   `json:"required"`
+	Properties map[string]json.RawMessage `json:"properties"`
+	Defs       map[string]schemaObject    `json:"$defs"`
+}
+
+// TestSnapshotSchemaFields tests that the schema lists exactly the fields the
+// snapshot types encode, so renaming a field fails until the schema and
+// version are updated
+func TestSnapshotSchemaFields(t *testing.T) {
+	var schema schemaObject
+	if err := json.Unmarshal(SnapshotSchema(), &schema); err != nil {
+		t.Fatalf("Schema should be valid JSON: %v", err)
+	}
+
+	var version struct {
+		Const int `json:"const"`
+	}
+	json.Unmarshal(schema.Properties["version"], &version)
+	if version.Const != SnapshotVersion {
+		t.Errorf("Schema should describe version %d, got %d", SnapshotVersion, version.Const)
+	}
+
+	types := map[string]reflect.Type{
+		"":                 reflect.TypeOf(gameSnapshot{}),
+		"position":         reflect.TypeOf(Position{}),
+		"tile":             reflect.TypeOf(Tile{}),
+		"square":           reflect.TypeOf(Square{}),
+		"board":            reflect.TypeOf(Board{}),
+		"multipliers":      reflect.TypeOf(Multipliers{}),
+		"tile_set":         reflect.TypeOf(TileSet{}),
+		"tile_spec":        reflect.TypeOf(TileSpec{}),
+		"player":           reflect.TypeOf(Player{}),
+		"placed_tile":      reflect.TypeOf(PlacedTile{}),
+		"move":             reflect.TypeOf(Move{}),
+		"score_breakdown":  reflect.TypeOf(ScoreBreakdown{}),
+		"word_score":       reflect.TypeOf(WordScore{}),
+		"tile_score":       reflect.TypeOf(TileScore{}),
+		"challenge_result": reflect.TypeOf(ChallengeResult{}),
+		"time_control":     reflect.TypeOf(TimeControl{}),
+		"team_rules":       reflect.TypeOf(TeamRules{}),
+		"solo_rules":       reflect.TypeOf(SoloRules{}),
+		"forfeit":          reflect.TypeOf(Forfeit{}),
+		"pause_rules":      reflect.TypeOf(PauseRules{}),
+		"pause":            reflect.TypeOf(Pause{}),
+		"pause_request":    reflect.TypeOf(PauseRequest{}),
+		"annotation":       reflect.TypeOf(Annotation{}),
+		"clock":            reflect.TypeOf(Clock{}),
+		"ranked_move":      reflect.TypeOf(RankedMove{}),
+		"feedback":         reflect.TypeOf(MoveFeedback{}),
+	}
+
+	for def, typ := range types {
+		obj := schema
+		if def != "" {
+			var ok bool
+			if obj, ok = schema.Defs[def]; !ok {
+				t.Errorf("Schema should define %s", def)
+				continue
+			}
+		}
+
+		names, required := jsonFields(typ)
+		var props []string
+		for name := range obj.Properties {
+			props = append(props, name)
+		}
+		sort.Strings(props)
+		sort.Strings(obj.Required)
+
+		if !reflect.DeepEqual(props, names) {
+			t.Errorf("Schema for %s should list fields %v, got %v", typ.Name(), names, props)
+		}
+		if !reflect.DeepEqual(obj.Required, required) {
+			t.Errorf("Schema for %s should require %v, got %v", typ.Name(), required, obj.Required)
+		}
+	}
+}
+
+// TestMigrateSnapshot tests upgrading a version 1 snapshot
+func TestMigrateSnapshot(t *testing.T) {
+	g, err := NewGameWithVariant("old", []*Player{NewPlayer("p1", "Player 1"), NewPlayer("p2", "Player 2")}, WordsWithFriends)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	g.StartGame()
+	data, err := g.Serialize()
+	if err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+
+	if migrated, err := MigrateSnapshot(data); err != nil || !bytes.Equal(migrated, data) {
+		t.Errorf("Should leave a current snapshot unchanged, got error %v", err)
+	}
+
+	// Rewrite the snapshot as version 1 wrote it
+	var snap map[string]any
+	json.Unmarshal(data, &snap)
+	snap["version"] = 1
+	delete(snap, "exchange_minimum")
+	board := snap["board"].(map[string]any)
+	delete(board, "bingo_bonus")
+	delete(board, "rack_size")
+	old, _ := json.Marshal(snap)
+
+	migrated, err := MigrateSnapshot(old)
+	if err != nil {
+		t.Fatalf("MigrateSnapshot failed: %v", err)
+	}
+	var upgraded struct {
+		Version int `json:"version"`
+	}
+	json.Unmarshal(migrated, &upgraded)
+	if upgraded.Version != SnapshotVersion {
+		t.Errorf("Should upgrade to version %d, got %d", SnapshotVersion, upgraded.Version)
+	}
+
+	loaded, err := LoadGame(old)
+	if err != nil {
+		t.Fatalf("LoadGame failed: %v", err)
+	}
+	rules := loaded.Rules()
+	if rules.BingoBonus != WWFBingoBonus || rules.RackSize != MaxRackSize || rules.ExchangeMinimum != MinTilesForExchange {
+		t.Errorf("Should load version 1 games under the standard rules, got %+v", rules)
+	}
+	if !reflect.DeepEqual(loaded.Players[0].Rack, g.Players[0].Rack) {
+		t.Errorf("Migration should keep the rest of the game")
+	}
+}
+
+// TestMigrateSnapshotVersions tests rejecting snapshots without a known version
+func TestMigrateSnapshotVersions(t *testing.T) {
+	tests := []string{
+		`{}`,
+		`{"version": 0}`,
+		`{"version": "2"}`,
+		`{"version": 99}`,
+	}
+
+	for _, data := range tests {
+		if _, err := MigrateSnapshot([]byte(data)); !errors.Is(err, ErrUnsupportedSnapshot) {
+			t.Errorf("Should reject %s, got %v", data, err)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package game
+
+// Zobrist hashing gives each tile in each place a pseudo-random 64-bit key and
+// hashes a position as the exclusive or of the keys of its tiles, so placing
+// or removing a tile updates the hash with a single exclusive or.
+
+// Key slots: one per board square, then the rack places of each player, then
+// the player to move
+const (
+	rackSlots     = 64                 // Rack places per player, enough for any rack
+	firstRackSlot = 256                // Slot of the first place on the first player's rack
+	turnSlot      = firstRackSlot << 8 // Slot of the player to move
+)
+
+// zobristKey returns the key for a letter, blank or not, in a slot
+// Keys are derived by the SplitMix64 finalizer rather than read from a table,
+// so letters of every tile set have one; the finalizer is a bijection, so
+// distinct slots and tiles never share a key.
+func zobristKey(slot int, letter rune, blank bool) uint64 {
+	z := uint64(slot)<<32 | uint64(uint32(letter))<<1
+	if blank {
+		z |= 1
+	}
+	z += 0x9e3779b97f4a7c15
+	z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+	z = (z ^ z>>27) * 0x94d049bb133111eb
+	return z ^ z>>31
+}
+
+// squareKey returns the key for a tile on a square
+func squareKey(pos Position, tile Tile) uint64 {
+	return zobristKey(pos.Row*15+pos.Col, tile.Letter, tile.IsBlank)
+}
+
+// Hash returns the Zobrist hash of the tiles on the board
+// Boards holding the same letters and blanks on the same squares hash alike,
+// whatever order the tiles were placed in. The hash is kept up to date as
+// tiles are placed and removed, so reading it costs nothing.
+func (b *Board) Hash() uint64 {
+	return b.hash
+}
+
+// HashAfter returns the hash the board would have after placing the tiles,
+// without placing them
+func (b *Board) HashAfter(tiles []PlacedTile) uint64 {
+	h := b.hash
+	for _, pt := range tiles {
+		h ^= squareKey(pt.Position, pt.Tile)
+	}
+	return h
+}
+
+// rehash recomputes the hash from the tiles on the board
+func (b *Board) rehash() {
+	b.hash = 0
+	for row := 0; row < 15; row++ {
+		for col := 0; col < 15; col++ {
+			if sq := &b.Grid[row][col]; !sq.IsEmpty() {
+				b.hash ^= squareKey(Position{Row: row, Col: col}, *sq.Tile)
+			}
+		}
+	}
+}
+
+// Hash returns the Zobrist hash of the position: the board's tiles, each
+// player's rack and the player to move
+// Racks hash alike whatever order their tiles are in. Scores are left out, so
+// an endgame solver searching on spread can share results between lines of
+// play that reach the same tiles by different routes.
+func (p *GamePosition) Hash() uint64 {
+	var h uint64
+	if p.Board != nil {
+		h = p.Board.Hash()
+	}
+	for i, rack := range p.Racks {
+		for j, t := range rack {
+			// The k-th copy of a tile gets its own key, so repeated tiles
+			// do not cancel out
+			k := 0
+			for _, prev := range rack[:j] {
+				if prev.Letter == t.Letter && prev.IsBlank == t.IsBlank {
+					k++
+				}
+			}
+			h ^= zobristKey(firstRackSlot+i*rackSlots+k%rackSlots, t.Letter, t.IsBlank)
+		}
+	}
+	return h ^ zobristKey(turnSlot+p.Turn, 0, false)
+}
@@ -0,0 +1,124 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestBoardHash tests that the board hash follows the tiles on the board
+func TestBoardHash(t *testing.T) {
+	empty := NewBoard()
+	if empty.Hash() != 0 {
+		t.Errorf("An empty board should hash to 0, got %x", empty.Hash())
+	}
+
+	across := NewBoard()
+	placeWord(t, across, "CAT", "G8", Horizontal)
+	placeWord(t, across, "DOG", "J6", Vertical)
+	down := NewBoard()
+	placeWord(t, down, "DOG", "J6", Vertical)
+	placeWord(t, down, "CAT", "G8", Horizontal)
+	if across.Hash() == 0 || across.Hash() != down.Hash() {
+		t.Errorf("Boards with the same tiles should hash alike, got %x and %x", across.Hash(), down.Hash())
+	}
+
+	move, err := across.BuildMove("p1", "AT", mustPos(t, "A1"), Horizontal, []int{0})
+	if err != nil {
+		t.Fatalf("BuildMove failed: %v", err)
+	}
+	after := across.HashAfter(move.Tiles)
+	for _, pt := range move.Tiles {
+		across.PlaceTile(pt.Tile, pt.Position)
+	}
+	if across.Hash() != after {
+		t.Errorf("HashAfter should predict the hash, got %x want %x", after, across.Hash())
+	}
+	for _, pt := range move.Tiles {
+		if _, err := across.RemoveTile(pt.Position); err != nil {
+			t.Fatalf("RemoveTile failed: %v", err)
+		}
+	}
+	if across.Hash() != down.Hash() {
+		t.Errorf("Removing the tiles should restore the hash")
+	}
+
+	blank := NewBoard()
+	blank.PlaceTile(Tile{Letter: 'C', IsBlank: true}, mustPos(t, "H8"))
+	plain := NewBoard()
+	plain.PlaceTile(Tile{Letter: 'C', Points: 3}, mustPos(t, "H8"))
+	if blank.Hash() == plain.Hash() {
+		t.Errorf("A blank should hash differently from the tile it stands for")
+	}
+}
+
+// TestBoardHashCopies tests that copied, decoded and transformed boards carry a correct hash
+func TestBoardHashCopies(t *testing.T) {
+	board := NewBoard()
+	placeWord(t, board, "CAT", "G8", Horizontal)
+
+	if board.Clone().Hash() != board.Hash() {
+		t.Errorf("A clone should keep the hash")
+	}
+
+	data, err := json.Marshal(board)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var decoded Board
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if decoded.Hash() != board.Hash() {
+		t.Errorf("A JSON round trip should rebuild the hash")
+	}
+
+	bin, err := board.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+	reused := NewBoard()
+	placeWord(t, reused, "DOG", "H8", Vertical)
+	if err := reused.UnmarshalBinary(bin); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	if reused.Hash() != board.Hash() {
+		t.Errorf("Decoding over a board should replace its hash")
+	}
+
+	down := NewBoard()
+	placeWord(t, down, "CAT", "H7", Vertical)
+	if board.Transpose().Hash() != down.Hash() {
+		t.Errorf("A transposed board should hash as the board it matches")
+	}
+}
+
+// TestGamePositionHash tests hashing racks and the player to move
+func TestGamePositionHash(t *testing.T) {
+	parse := func(s string) *GamePosition {
+		t.Helper()
+		pos, err := ParsePosition(s)
+		if err != nil {
+			t.Fatalf("ParsePosition(%q) failed: %v", s, err)
+		}
+		return pos
+	}
+	base := parse("15/15/15/15/15/15/15/7CaT5/15/15/15/15/15/15/15 AEINRST/DGO? 8/0 1")
+
+	for s, same := range map[string]bool{
+		"15/15/15/15/15/15/15/7CaT5/15/15/15/15/15/15/15 TSRNIEA/?OGD 8/0 1":  true,
+		"15/15/15/15/15/15/15/7CaT5/15/15/15/15/15/15/15 AEINRST/DGO? 20/5 1": true,
+		"15/15/15/15/15/15/15/7CaT5/15/15/15/15/15/15/15 AEINRST/DGO? 8/0 0":  false,
+		"15/15/15/15/15/15/15/7CaT5/15/15/15/15/15/15/15 DGO?/AEINRST 8/0 1":  false,
+		"15/15/15/15/15/15/15/7CAT5/15/15/15/15/15/15/15 AEINRST/DGO? 8/0 1":  false,
+	} {
+		if got := parse(s).Hash() == base.Hash(); got != same {
+			t.Errorf("%q: hash should match the base position: %v", s, same)
+		}
+	}
+
+	doubled := parse("15/15/15/15/15/15/15/7CaT5/15/15/15/15/15/15/15 AA/- 8/0 0")
+	empty := parse("15/15/15/15/15/15/15/7CaT5/15/15/15/15/15/15/15 -/- 8/0 0")
+	if doubled.Hash() == empty.Hash() {
+		t.Errorf("Repeated rack tiles should not cancel out")
+	}
+}
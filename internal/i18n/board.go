@@ -0,0 +1,73 @@
+package i18n
+
+import (
+	"strings"
+
+	"scrabbled/internal/game"
+)
+
+// premiumKeys names the premium squares
+var premiumKeys = map[game.PremiumType]Key{
+	game.DoubleLetterScore: KeyDoubleLetter,
+	game.TripleLetterScore: KeyTripleLetter,
+	game.DoubleWordScore:   KeyDoubleWord,
+	game.TripleWordScore:   KeyTripleWord,
+}
+
+// Board describes the board in sentences for screen readers and voice
+// assistants: one line for each row holding tiles, such as
+// "Row 8: H8 C, I8 A, J8 T", then the hotspots a play can reach
+func (l *Localizer) Board(b *game.Board) []string {
+	if b.IsFirstMove() {
+		return []string{l.Text(KeyBoardEmpty, b.Center)}
+	}
+
+	var lines []string
+	for row := 0; row < 15; row++ {
+		var tiles []string
+		for col := 0; col < 15; col++ {
+			pos := game.Position{Row: row, Col: col}
+			if t := b.GetTile(pos); t != nil {
+				tiles = append(tiles, l.tile(KeyBoardTile, KeyBoardBlank, pos, *t))
+			}
+		}
+		if len(tiles) > 0 {
+			lines = append(lines, l.Text(KeyBoardRow, row+1, l.list(tiles)))
+		}
+	}
+
+	spots := b.Hotspots()
+	if len(spots) == 0 {
+		return append(lines, l.Text(KeyBoardNoSpots))
+	}
+	named := make([]string, len(spots))
+	for i, pos := range spots {
+		named[i] = l.Text(KeyBoardHotspot, pos, l.Text(premiumKeys[b.GetPremiumType(pos)]))
+	}
+	return append(lines, l.Text(KeyBoardHotspots, l.list(named)))
+}
+
+// Square describes one square, such as "H8: C" or "D8: empty, double
+// letter", for reading out a cursor's position
+func (l *Localizer) Square(b *game.Board, pos game.Position) string {
+	if t := b.GetTile(pos); t != nil {
+		return l.tile(KeySquareTile, KeySquareBlank, pos, *t)
+	}
+	if key, ok := premiumKeys[b.GetPremiumType(pos)]; ok {
+		return l.Text(KeySquarePremium, pos, l.Text(key))
+	}
+	return l.Text(KeySquareEmpty, pos)
+}
+
+// tile renders a tile on a square with the message for a letter or a blank
+func (l *Localizer) tile(letter, blank Key, pos game.Position, t game.Tile) string {
+	if t.IsBlank {
+		return l.Text(blank, pos, t.Letter)
+	}
+	return l.Text(letter, pos, t.Letter)
+}
+
+// list joins items with the language's separator
+func (l *Localizer) list(items []string) string {
+	return strings.Join(items, l.Text(KeyListSeparator))
+}
@@ -0,0 +1,70 @@
+package i18n
+
+import (
+	"reflect"
+	"testing"
+
+	"scrabbled/internal/game"
+)
+
+// testBoard holds CAT across row 6 from E6, with a blank A
+func testBoard(t *testing.T) *game.Board {
+	t.Helper()
+	b := game.NewBoard()
+	for i, tile := range []game.Tile{{Letter: 'C', Points: 3}, {Letter: 'A', IsBlank: true}, {Letter: 'T', Points: 1}} {
+		if err := b.PlaceTile(tile, game.Position{Row: 5, Col: 4 + i}); err != nil {
+			t.Fatalf("PlaceTile failed: %v", err)
+		}
+	}
+	return b
+}
+
+// TestLocalizerBoard tests describing the tiles and hotspots of a board
+func TestLocalizerBoard(t *testing.T) {
+	l := NewLocalizer()
+	if got := l.Board(game.NewBoard()); !reflect.DeepEqual(got, []string{"The board is empty; the first play must cover H8."}) {
+		t.Errorf("Unexpected empty board description: %q", got)
+	}
+
+	expected := []string{
+		"Row 6: E6 C, F6 blank A, G6 T",
+		"Hotspots open at E5 double word, G7 double letter.",
+	}
+	if got := l.Board(testBoard(t)); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+
+	spanish := &Catalog{Language: "es", Messages: map[Key]string{
+		KeyBoardRow:      "Fila %d: %s",
+		KeyBoardBlank:    "%s comodín %c",
+		KeyBoardHotspots: "Casillas libres: %s.",
+		KeyDoubleWord:    "palabra doble",
+		KeyDoubleLetter:  "letra doble",
+	}}
+	expected = []string{
+		"Fila 6: E6 C, F6 comodín A, G6 T",
+		"Casillas libres: E5 palabra doble, G7 letra doble.",
+	}
+	if got := NewLocalizer(spanish).Board(testBoard(t)); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+// TestLocalizerSquare tests describing single squares
+func TestLocalizerSquare(t *testing.T) {
+	l := NewLocalizer()
+	b := testBoard(t)
+	cases := map[game.Position]string{
+		{Row: 5, Col: 4}:  "E6: C",
+		{Row: 5, Col: 5}:  "F6: blank A",
+		{Row: 4, Col: 4}:  "E5: empty, double word",
+		{Row: 0, Col: 0}:  "A1: empty, triple word",
+		{Row: 0, Col: 1}:  "B1: empty",
+		{Row: 13, Col: 9}: "J14: empty, triple letter",
+	}
+	for pos, expected := range cases {
+		if got := l.Square(b, pos); got != expected {
+			t.Errorf("Square(%v) = %q, want %q", pos, got, expected)
+		}
+	}
+}
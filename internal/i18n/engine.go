@@ -0,0 +1,72 @@
+package i18n
+
+import (
+	"errors"
+	"strings"
+
+	"scrabbled/internal/game"
+)
+
+// Error renders an engine error in the localizer's language
+// The error is matched to the first engine error it wraps; detail the engine
+// added after that error's message, such as the words a play formed, is kept
+// as written. Errors from outside the engine are returned unchanged.
+func (l *Localizer) Error(err error) string {
+	if err == nil {
+		return ""
+	}
+	for _, ek := range errorKeys {
+		if !errors.Is(err, ek.err) {
+			continue
+		}
+		msg := l.Text(ek.key)
+		if detail, ok := strings.CutPrefix(err.Error(), ek.err.Error()+": "); ok {
+			return l.Text(KeyErrorDetail, msg, detail)
+		}
+		return msg
+	}
+	return err.Error()
+}
+
+// Move describes a history entry, naming the player who made it
+func (l *Localizer) Move(m game.Move, name string) string {
+	switch m.Type {
+	case game.PlaceTiles:
+		if m.Withdrawn {
+			return l.Text(KeyWithdrawn, name, m.Word)
+		}
+		return l.Text(KeyPlayed, name, m.Word, m.Coordinates(), m.Score)
+	case game.Exchange:
+		return l.Text(KeyExchanged, name, len(m.Exchanged))
+	case game.ChallengeTurn:
+		if m.Challenge != nil && m.Challenge.Successful {
+			return l.Text(KeyChallengeWon, name, strings.Join(m.Challenge.InvalidWords, ", "))
+		}
+		return l.Text(KeyChallengeLost, name)
+	default:
+		return l.Text(KeyPassed, name)
+	}
+}
+
+// Result summarizes a finished game from its players' final scores
+func (l *Localizer) Result(players []*game.Player) string {
+	if len(players) == 0 {
+		return l.Text(KeyResultNoPlayers)
+	}
+	first, second := players[0], (*game.Player)(nil)
+	for _, p := range players[1:] {
+		switch {
+		case p.Score > first.Score:
+			first, second = p, first
+		case second == nil || p.Score > second.Score:
+			second = p
+		}
+	}
+	if second == nil {
+		return l.Text(KeyResultSolo, first.Name, first.Score)
+	}
+	if second.Score == first.Score {
+		return l.Text(KeyResultTie, first.Score)
+	}
+	return l.Text(KeyResultWin, first.Name, first.Score, second.Score)
+}
@@ -0,0 +1,100 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"scrabbled/internal/game"
+)
+
+// german translates a few engine errors
+var german = &Catalog{Language: "de", Messages: map[Key]string{
+	"error.invalid_word":     "Wort nicht im Wörterbuch",
+	"error.not_players_turn": "Dieser Spieler ist nicht am Zug",
+	"error.no_tiles_placed":  "Es muss mindestens ein Stein gelegt werden",
+	KeyErrorDetail:           "%s (%s)",
+	KeyPlayed:                "%s legte %s auf %s für %d.",
+	KeyResultWin:             "Spielende. %s gewinnt %d zu %d.",
+	KeyResultTie:             "Spielende. Unentschieden bei %d.",
+}}
+
+// TestLocalizerError tests rendering engine errors with their detail
+func TestLocalizerError(t *testing.T) {
+	l := NewLocalizer(german)
+
+	detailed := fmt.Errorf("%w: %s", game.ErrInvalidWord, "CATX, QZ")
+	if got := l.Error(detailed); got != "Wort nicht im Wörterbuch (CATX, QZ)" {
+		t.Errorf("Expected the detail after the translation, got %q", got)
+	}
+	if got := l.Error(fmt.Errorf("play failed: %w", game.ErrNotPlayersTurn)); got != "Dieser Spieler ist nicht am Zug" {
+		t.Errorf("Expected the wrapped error translated, got %q", got)
+	}
+
+	// Rejected placements wrap the sentinel of the rule they broke
+	err := game.NewBoard().ValidatePlacement(game.Move{}, nil)
+	if got := l.Error(err); got != "Es muss mindestens ein Stein gelegt werden" {
+		t.Errorf("Expected the placement error translated, got %q", got)
+	}
+
+	if got := l.Error(game.ErrGameFull); got != game.ErrGameFull.Error() {
+		t.Errorf("Expected English for an untranslated error, got %q", got)
+	}
+	if got := l.Error(errors.New("disk full")); got != "disk full" {
+		t.Errorf("Expected other errors unchanged, got %q", got)
+	}
+	if l.Error(nil) != "" {
+		t.Errorf("Expected nothing for a nil error")
+	}
+}
+
+// TestLocalizerMove tests describing each kind of turn
+func TestLocalizerMove(t *testing.T) {
+	l := NewLocalizer()
+	cases := []struct {
+		move     game.Move
+		expected string
+	}{
+		{game.Move{Type: game.PlaceTiles, Word: "CAT", Start: game.Position{Row: 7, Col: 7}, Score: 10}, "Ann played CAT at 8H for 10."},
+		{game.Move{Type: game.PlaceTiles, Word: "CAT", Start: game.Position{Row: 3, Col: 1}, Direction: game.Vertical}, "Ann played CAT at B4 for 0."},
+		{game.Move{Type: game.PlaceTiles, Word: "CATX", Withdrawn: true}, "Ann's play of CATX was withdrawn."},
+		{game.Move{Type: game.Exchange, Exchanged: make([]game.Tile, 3)}, "Ann exchanged 3 tiles."},
+		{game.Move{Type: game.Pass}, "Ann passed."},
+		{game.Move{Type: game.ChallengeTurn, Challenge: &game.ChallengeResult{Successful: true, InvalidWords: []string{"CATX", "QZ"}}}, "Ann challenged CATX, QZ off the board."},
+		{game.Move{Type: game.ChallengeTurn, Challenge: &game.ChallengeResult{}}, "Ann challenged and lost."},
+	}
+	for _, tc := range cases {
+		if got := l.Move(tc.move, "Ann"); got != tc.expected {
+			t.Errorf("Expected %q, got %q", tc.expected, got)
+		}
+	}
+
+	if got := NewLocalizer(german).Move(cases[0].move, "Ann"); got != "Ann legte CAT auf 8H für 10." {
+		t.Errorf("Expected the German description, got %q", got)
+	}
+}
+
+// TestLocalizerResult tests summarizing wins, ties and solo games
+func TestLocalizerResult(t *testing.T) {
+	player := func(name string, score int) *game.Player {
+		p := game.NewPlayer(name, name)
+		p.Score = score
+		return p
+	}
+	l := NewLocalizer(german)
+
+	cases := []struct {
+		players  []*game.Player
+		expected string
+	}{
+		{[]*game.Player{player("Ann", 300), player("Ben", 350), player("Cy", 320)}, "Spielende. Ben gewinnt 350 zu 320."},
+		{[]*game.Player{player("Ann", 350), player("Ben", 300), player("Cy", 350)}, "Spielende. Unentschieden bei 350."},
+		{[]*game.Player{player("Ann", 280)}, "Game over. Ann scored 280."},
+		{nil, "Game over."},
+	}
+	for _, tc := range cases {
+		if got := l.Result(tc.players); got != tc.expected {
+			t.Errorf("Expected %q, got %q", tc.expected, got)
+		}
+	}
+}
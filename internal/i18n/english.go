@@ -0,0 +1,154 @@
+package i18n
+
+import (
+	"scrabbled/internal/dictionary"
+	"scrabbled/internal/game"
+)
+
+// Keys of the engine's messages; errors have keys of their own, see errorKeys
+const (
+	KeyErrorDetail     Key = "error.detail"        // An error message and its detail
+	KeyPlayed          Key = "move.played"         // Player, word, coordinates and score of a play
+	KeyWithdrawn       Key = "move.withdrawn"      // Player and word of a play withdrawn after a challenge
+	KeyExchanged       Key = "move.exchanged"      // Player and number of tiles exchanged
+	KeyPassed          Key = "move.passed"         // Player who passed
+	KeyChallengeWon    Key = "move.challenge_won"  // Challenger and the invalid words
+	KeyChallengeLost   Key = "move.challenge_lost" // Challenger whose challenge failed
+	KeyResultWin       Key = "result.win"          // Winner, their score and the runner-up's
+	KeyResultTie       Key = "result.tie"          // Tied score
+	KeyResultSolo      Key = "result.solo"         // Player and score of a one-player game
+	KeyResultNoPlayers Key = "result.no_players"   // A game with no one in it
+
+	KeyBoardEmpty    Key = "board.empty"       // The center square
+	KeyBoardRow      Key = "board.row"         // Row number and its tiles
+	KeyBoardTile     Key = "board.tile"        // Square and letter of a tile
+	KeyBoardBlank    Key = "board.blank"       // Square and letter of a blank
+	KeyBoardHotspots Key = "board.hotspots"    // The open premium squares
+	KeyBoardHotspot  Key = "board.hotspot"     // Square and premium name
+	KeyBoardNoSpots  Key = "board.no_hotspots" // No premium square can be reached
+	KeyListSeparator Key = "list.separator"    // Between the items of a list
+	KeySquareTile    Key = "square.tile"       // Square and letter of a tile
+	KeySquareBlank   Key = "square.blank"      // Square and letter of a blank
+	KeySquareEmpty   Key = "square.empty"      // An empty square
+	KeySquarePremium Key = "square.premium"    // An empty square and its premium name
+	KeyDoubleLetter  Key = "premium.double_letter"
+	KeyTripleLetter  Key = "premium.triple_letter"
+	KeyDoubleWord    Key = "premium.double_word"
+	KeyTripleWord    Key = "premium.triple_word"
+)
+
+// errorKeys gives the message key of each engine error, in the order they
+// are matched; the English text is the error's own
+var errorKeys = []struct {
+	err error
+	key Key
+}{
+	{game.ErrNoTilesPlaced, "error.no_tiles_placed"},
+	{game.ErrTilesNotInLine, "error.tiles_not_in_line"},
+	{game.ErrTilesNotContiguous, "error.tiles_not_contiguous"},
+	{game.ErrFirstMoveNotCenter, "error.first_move_not_center"},
+	{game.ErrFirstMoveTooShort, "error.first_move_too_short"},
+	{game.ErrNotConnected, "error.not_connected"},
+	{game.ErrTilesNotInRack, "error.tiles_not_in_rack"},
+	{game.ErrWordMismatch, "error.word_mismatch"},
+	{game.ErrDuplicatePosition, "error.duplicate_position"},
+	{game.ErrUnassignedBlank, "error.unassigned_blank"},
+	{game.ErrInvalidPosition, "error.invalid_position"},
+	{game.ErrPositionOccupied, "error.position_occupied"},
+	{game.ErrNoTileAtPosition, "error.no_tile_at_position"},
+	{game.ErrInvalidWord, "error.invalid_word"},
+	{game.ErrExchangeNotAllowed, "error.exchange_not_allowed"},
+	{game.ErrNoTilesExchanged, "error.no_tiles_exchanged"},
+	{game.ErrRackFull, "error.rack_full"},
+	{game.ErrInvalidRackIndex, "error.invalid_rack_index"},
+
+	{game.ErrNotPlayersTurn, "error.not_players_turn"},
+
+	{game.ErrGameNotWaiting, "error.game_not_waiting"},
+	{game.ErrGameNotInProgress, "error.game_not_in_progress"},
+	{game.ErrGameNotFinished, "error.game_not_finished"},
+	{game.ErrGameFull, "error.game_full"},
+	{game.ErrNotEnoughPlayers, "error.not_enough_players"},
+	{game.ErrDuplicatePlayer, "error.duplicate_player"},
+	{game.ErrDuplicateName, "error.duplicate_name"},
+	{game.ErrNotTeamGame, "error.not_team_game"},
+	{game.ErrNotPartner, "error.not_partner"},
+	{game.ErrConsultationClosed, "error.consultation_closed"},
+	{game.ErrNotSoloGame, "error.not_solo_game"},
+	{game.ErrNoSuchTurn, "error.no_such_turn"},
+	{game.ErrInvalidAnnotation, "error.invalid_annotation"},
+	{game.ErrOutOfTime, "error.out_of_time"},
+	{game.ErrChallengeNotAllowed, "error.challenge_not_allowed"},
+	{game.ErrNothingToChallenge, "error.nothing_to_challenge"},
+	{game.ErrOwnMove, "error.own_move"},
+	{game.ErrNothingToUndo, "error.nothing_to_undo"},
+	{game.ErrNothingToRedo, "error.nothing_to_redo"},
+	{game.ErrGameExists, "error.game_exists"},
+	{game.ErrManagerClosed, "error.manager_closed"},
+	{game.ErrSeriesOver, "error.series_over"},
+	{game.ErrStaleDiff, "error.stale_diff"},
+	{game.ErrGamePaused, "error.game_paused"},
+	{game.ErrNotPaused, "error.not_paused"},
+	{game.ErrPauseNotAllowed, "error.pause_not_allowed"},
+	{game.ErrNoPauseRequested, "error.no_pause_requested"},
+
+	{game.ErrPlayerNotFound, "error.player_not_found"},
+	{game.ErrGameNotFound, "error.game_not_found"},
+	{game.ErrUnknownTileSet, "error.unknown_tile_set"},
+
+	{game.ErrInvalidNotation, "error.invalid_notation"},
+	{game.ErrUnrecognizedPlay, "error.unrecognized_play"},
+	{game.ErrInvalidBinary, "error.invalid_binary"},
+	{game.ErrInvalidLeaveTable, "error.invalid_leave_table"},
+	{game.ErrInvalidRules, "error.invalid_rules"},
+	{game.ErrUnsupportedSnapshot, "error.unsupported_snapshot"},
+
+	{game.ErrNoDictionary, "error.no_dictionary"},
+	{game.ErrNoMoveGenerator, "error.no_move_generator"},
+	{game.ErrNoClock, "error.no_clock"},
+
+	{dictionary.ErrDictionaryNotFound, "error.dictionary_not_found"},
+	{dictionary.ErrNoDefault, "error.no_default_dictionary"},
+}
+
+// English is the built-in catalog and the fallback for every other
+var English = newEnglish()
+
+// newEnglish builds the English catalog, taking error messages from the
+// errors themselves so the two cannot drift apart
+func newEnglish() *Catalog {
+	c := &Catalog{Language: "en", Messages: map[Key]string{
+		KeyErrorDetail:     "%s: %s",
+		KeyPlayed:          "%s played %s at %s for %d.",
+		KeyWithdrawn:       "%s's play of %s was withdrawn.",
+		KeyExchanged:       "%s exchanged %d tiles.",
+		KeyPassed:          "%s passed.",
+		KeyChallengeWon:    "%s challenged %s off the board.",
+		KeyChallengeLost:   "%s challenged and lost.",
+		KeyResultWin:       "Game over. %s wins, %d to %d.",
+		KeyResultTie:       "Game over. The game is tied at %d.",
+		KeyResultSolo:      "Game over. %s scored %d.",
+		KeyResultNoPlayers: "Game over.",
+
+		KeyBoardEmpty:    "The board is empty; the first play must cover %s.",
+		KeyBoardRow:      "Row %d: %s",
+		KeyBoardTile:     "%s %c",
+		KeyBoardBlank:    "%s blank %c",
+		KeyBoardHotspots: "Hotspots open at %s.",
+		KeyBoardHotspot:  "%s %s",
+		KeyBoardNoSpots:  "No premium squares are open.",
+		KeyListSeparator: ", ",
+		KeySquareTile:    "%s: %c",
+		KeySquareBlank:   "%s: blank %c",
+		KeySquareEmpty:   "%s: empty",
+		KeySquarePremium: "%s: empty, %s",
+		KeyDoubleLetter:  "double letter",
+		KeyTripleLetter:  "triple letter",
+		KeyDoubleWord:    "double word",
+		KeyTripleWord:    "triple word",
+	}}
+	for _, ek := range errorKeys {
+		c.Messages[ek.key] = ek.err.Error()
+	}
+	return c
+}
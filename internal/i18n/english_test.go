@@ -0,0 +1,27 @@
+package i18n
+
+import (
+	"testing"
+
+	"scrabbled/internal/game"
+)
+
+// TestEnglish tests that every engine error has its own key and English text
+func TestEnglish(t *testing.T) {
+	keys := make(map[Key]bool)
+	for _, ek := range errorKeys {
+		if keys[ek.key] {
+			t.Errorf("Key %s is used twice", ek.key)
+		}
+		keys[ek.key] = true
+		if English.Messages[ek.key] != ek.err.Error() {
+			t.Errorf("Expected %s to read %q, got %q", ek.key, ek.err.Error(), English.Messages[ek.key])
+		}
+		if game.Code(ek.err) == game.CodeUnknown {
+			t.Errorf("Expected %s to be an engine error", ek.key)
+		}
+	}
+	if err := English.Validate(); err != nil {
+		t.Errorf("Expected English to validate, got %v", err)
+	}
+}
@@ -0,0 +1,169 @@
+// Package i18n localizes the human-facing text of the engine and its clients:
+// error messages, move descriptions, game-end summaries and the spoken
+// descriptions of the board used by screen readers
+//
+// Messages are looked up by key in catalogs of fmt templates. English is
+// built in and is the fallback for any message a catalog lacks, so a partial
+// translation never shows a bare key. Deployments plug in other languages by
+// loading JSON catalogs with ParseCatalog and registering them.
+package i18n
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrInvalidCatalog is returned when a message catalog cannot be used
+var ErrInvalidCatalog = errors.New("invalid message catalog")
+
+// Key identifies a message, such as "move.passed"
+type Key string
+
+// Catalog holds one language's message templates in fmt syntax
+// Translations may reorder arguments with explicit indexes, as in "%[2]s".
+type Catalog struct {
+	Language string         `json:"language"` // BCP 47 tag, such as "fr" or "pt-BR"
+	Messages map[Key]string `json:"messages"`
+}
+
+// ParseCatalog reads a catalog from JSON, such as
+// {"language": "fr", "messages": {"move.passed": "%s a passé"}}
+func ParseCatalog(r io.Reader) (*Catalog, error) {
+	var c Catalog
+	if err := json.NewDecoder(r).Decode(&c); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCatalog, err)
+	}
+	if c.Language == "" {
+		return nil, fmt.Errorf("%w: missing language", ErrInvalidCatalog)
+	}
+	return &c, nil
+}
+
+// verbPattern matches a formatting directive with its flags and index
+var verbPattern = regexp.MustCompile(`%(\[\d+\])?[-+# 0]*\d*(\.\d*)?[a-zA-Z%]`)
+
+// verbs returns the sorted verb letters of a template, ignoring %%
+func verbs(template string) string {
+	var found []string
+	for _, v := range verbPattern.FindAllString(template, -1) {
+		if verb := v[len(v)-1:]; verb != "%" {
+			found = append(found, verb)
+		}
+	}
+	sort.Strings(found)
+	return strings.Join(found, "")
+}
+
+// Validate checks that every message with a default takes the same arguments
+// as the default, so a translation cannot garble its output
+// Defaults are searched in order, and English last.
+func (c *Catalog) Validate(defaults ...*Catalog) error {
+	defaults = append(defaults, English)
+	for key, template := range c.Messages {
+		for _, d := range defaults {
+			def, ok := d.Messages[key]
+			if !ok {
+				continue
+			}
+			if verbs(template) != verbs(def) {
+				return fmt.Errorf("%w: %s %q does not take the arguments of %q", ErrInvalidCatalog, key, template, def)
+			}
+			break
+		}
+	}
+	return nil
+}
+
+// Localizer renders messages from a chain of catalogs
+// A message is taken from the first catalog that has it, then from English.
+type Localizer struct {
+	catalogs []*Catalog
+}
+
+// NewLocalizer creates a localizer reading catalogs in order, falling back
+// to English; with no catalogs it renders English
+// Clients with messages of their own pass their translation first and their
+// English defaults after it.
+func NewLocalizer(catalogs ...*Catalog) *Localizer {
+	var chain []*Catalog
+	for _, c := range catalogs {
+		if c != nil {
+			chain = append(chain, c)
+		}
+	}
+	return &Localizer{catalogs: append(chain, English)}
+}
+
+// Language returns the language of the first catalog
+func (l *Localizer) Language() string {
+	return l.catalogs[0].Language
+}
+
+// Text renders the message for key with args, or the key itself if no
+// catalog has the message
+func (l *Localizer) Text(key Key, args ...any) string {
+	for _, c := range l.catalogs {
+		if template, ok := c.Messages[key]; ok {
+			if len(args) == 0 {
+				return template
+			}
+			return fmt.Sprintf(template, args...)
+		}
+	}
+	return string(key)
+}
+
+// Registry holds the catalogs a deployment can serve, by language
+type Registry struct {
+	catalogs map[string]*Catalog
+	mu       sync.RWMutex
+}
+
+// NewRegistry creates a registry holding English
+func NewRegistry() *Registry {
+	return &Registry{catalogs: map[string]*Catalog{English.Language: English}}
+}
+
+// Register adds or replaces the catalog for its language
+func (r *Registry) Register(c *Catalog) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.catalogs[strings.ToLower(c.Language)] = c
+}
+
+// Languages returns the registered languages in alphabetical order
+func (r *Registry) Languages() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	langs := make([]string, 0, len(r.catalogs))
+	for lang := range r.catalogs {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// Localizer returns a localizer for the language, trying the base language
+// of a regional tag (so "fr-CA" can use "fr") and falling back to English
+func (r *Registry) Localizer(lang string) *Localizer {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	lang = strings.ToLower(strings.ReplaceAll(lang, "_", "-"))
+	if c, ok := r.catalogs[lang]; ok {
+		return NewLocalizer(c)
+	}
+	if base, _, ok := strings.Cut(lang, "-"); ok {
+		if c, ok := r.catalogs[base]; ok {
+			return NewLocalizer(c)
+		}
+	}
+	return NewLocalizer()
+}
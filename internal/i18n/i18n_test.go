@@ -0,0 +1,97 @@
+package i18n
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// french is a partial French catalog
+var french = &Catalog{Language: "fr", Messages: map[Key]string{
+	KeyPassed:    "%s a passé.",
+	KeyResultWin: "Partie terminée. %[1]s gagne, %[2]d à %[3]d.",
+}}
+
+// TestParseCatalog tests reading catalogs from JSON
+func TestParseCatalog(t *testing.T) {
+	c, err := ParseCatalog(strings.NewReader(`{"language": "fr", "messages": {"move.passed": "%s a passé."}}`))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if c.Language != "fr" || c.Messages[KeyPassed] != "%s a passé." {
+		t.Errorf("Unexpected catalog: %+v", c)
+	}
+
+	for _, bad := range []string{`{"messages": {}}`, `not json`} {
+		if _, err := ParseCatalog(strings.NewReader(bad)); !errors.Is(err, ErrInvalidCatalog) {
+			t.Errorf("Expected ErrInvalidCatalog for %s, got %v", bad, err)
+		}
+	}
+}
+
+// TestValidate tests that translations must take their default's arguments
+func TestValidate(t *testing.T) {
+	if err := french.Validate(); err != nil {
+		t.Errorf("Expected the French catalog to be valid, got %v", err)
+	}
+
+	bad := &Catalog{Language: "fr", Messages: map[Key]string{KeyPlayed: "%s a joué %s pour %d."}}
+	if err := bad.Validate(); !errors.Is(err, ErrInvalidCatalog) {
+		t.Errorf("Expected a missing argument to be rejected, got %v", err)
+	}
+
+	defaults := &Catalog{Language: "en", Messages: map[Key]string{"app.hello": "Hello %s, 100%% ready"}}
+	ok := &Catalog{Language: "fr", Messages: map[Key]string{"app.hello": "Bonjour %s", "app.other": "%d"}}
+	if err := ok.Validate(defaults); err != nil {
+		t.Errorf("Expected client messages to validate against their defaults, got %v", err)
+	}
+	ok.Messages["app.hello"] = "Bonjour %d"
+	if err := ok.Validate(defaults); err == nil {
+		t.Errorf("Expected a changed verb to be rejected")
+	}
+}
+
+// TestLocalizerText tests looking messages up through the catalog chain
+func TestLocalizerText(t *testing.T) {
+	l := NewLocalizer(french)
+	if l.Language() != "fr" {
+		t.Errorf("Expected French, got %s", l.Language())
+	}
+	if got := l.Text(KeyPassed, "Alice"); got != "Alice a passé." {
+		t.Errorf("Expected the French message, got %q", got)
+	}
+	if got := l.Text(KeyExchanged, "Alice", 3); got != "Alice exchanged 3 tiles." {
+		t.Errorf("Expected English for a missing translation, got %q", got)
+	}
+	if got := l.Text("app.unknown"); got != "app.unknown" {
+		t.Errorf("Expected the key for an unknown message, got %q", got)
+	}
+
+	client := &Catalog{Language: "en", Messages: map[Key]string{"app.hello": "Hello %s", KeyPassed: "%s sat out."}}
+	if got := NewLocalizer(nil, client).Text("app.hello", "Bob"); got != "Hello Bob" {
+		t.Errorf("Expected the client's default, got %q", got)
+	}
+	if got := NewLocalizer(french, client).Text(KeyPassed, "Bob"); got != "Bob a passé." {
+		t.Errorf("Expected the translation to come before the client's default, got %q", got)
+	}
+	if NewLocalizer().Language() != "en" {
+		t.Errorf("Expected English by default")
+	}
+}
+
+// TestRegistry tests choosing a catalog by language tag
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+	r.Register(french)
+	if got := r.Languages(); !reflect.DeepEqual(got, []string{"en", "fr"}) {
+		t.Errorf("Expected en and fr, got %v", got)
+	}
+
+	cases := map[string]string{"fr": "fr", "FR": "fr", "fr-CA": "fr", "fr_BE": "fr", "de": "en", "": "en"}
+	for lang, expected := range cases {
+		if got := r.Localizer(lang).Language(); got != expected {
+			t.Errorf("Localizer(%q): expected %s, got %s", lang, expected, got)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"strings"
+)
+
+// Email sends alerts by SMTP to the addresses players have registered
+type Email struct {
+	Addr    string                               // SMTP server as host:port
+	Auth    smtp.Auth                            // Credentials; nil sends without authenticating
+	From    string                               // Sender address
+	Address func(playerID string) (string, bool) // Looks up a player's address; players without one are skipped
+
+	send func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewEmail creates an email notifier sending through the SMTP server at addr
+func NewEmail(addr string, auth smtp.Auth, from string, address func(playerID string) (string, bool)) *Email {
+	return &Email{Addr: addr, Auth: auth, From: from, Address: address, send: smtp.SendMail}
+}
+
+// OnYourTurn emails a turn alert
+func (e *Email) OnYourTurn(ctx context.Context, a Alert) error {
+	return e.mail(ctx, a, fmt.Sprintf("Your move in game %s", a.GameID),
+		fmt.Sprintf("It is your turn in game %s, after %d turns.", a.GameID, a.Turn))
+}
+
+// OnGameEnd emails the final scores
+func (e *Email) OnGameEnd(ctx context.Context, a Alert) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Game %s is over.\n\nFinal scores:\n", a.GameID)
+	for _, s := range a.Standings {
+		fmt.Fprintf(&b, "  %s %d", s.PlayerID, s.Score)
+		if s.Forfeited {
+			b.WriteString(" (forfeited)")
+		}
+		b.WriteString("\n")
+	}
+	return e.mail(ctx, a, fmt.Sprintf("Game %s is over", a.GameID), b.String())
+}
+
+// OnChallenge emails the outcome of a challenge
+func (e *Email) OnChallenge(ctx context.Context, a Alert) error {
+	body := fmt.Sprintf("%s challenged your play in game %s. ", a.Challenge.ChallengerID, a.GameID)
+	if a.Challenge.Successful {
+		body += fmt.Sprintf("The play was withdrawn: %s not in the dictionary.", strings.Join(a.Challenge.InvalidWords, ", "))
+	} else {
+		body += "The play stands."
+	}
+	return e.mail(ctx, a, fmt.Sprintf("Your play in game %s was challenged", a.GameID), body)
+}
+
+// mail sends one message to the alert's recipient
+func (e *Email) mail(ctx context.Context, a Alert, subject, body string) error {
+	to, ok := e.Address(a.PlayerID)
+	if !ok {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s\r\n",
+		headerValue(e.From), headerValue(to), headerValue(subject), strings.ReplaceAll(body, "\n", "\r\n"))
+	send := e.send
+	if send == nil {
+		send = smtp.SendMail
+	}
+	if err := send(e.Addr, e.Auth, e.From, []string{to}, []byte(msg)); err != nil {
+		return fmt.Errorf("%w: %v", ErrDeliveryFailed, err)
+	}
+	return nil
+}
+
+// headerValue strips line breaks, which would let a value add headers of its own
+func headerValue(s string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(s)
+}
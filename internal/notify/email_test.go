@@ -0,0 +1,77 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"net/smtp"
+	"strings"
+	"testing"
+
+	"scrabbled/internal/game"
+)
+
+// sentMail is one message handed to the SMTP sender
+type sentMail struct {
+	to  []string
+	msg string
+}
+
+// newTestEmail creates an email notifier that records messages instead of sending them
+func newTestEmail(sent *[]sentMail, err error) *Email {
+	e := NewEmail("smtp.example.com:25", nil, "games@example.com", func(id string) (string, bool) {
+		if id == "p1" {
+			return "one@example.com", true
+		}
+		return "", false
+	})
+	e.send = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		*sent = append(*sent, sentMail{to: to, msg: string(msg)})
+		return err
+	}
+	return e
+}
+
+// TestEmail tests the messages sent for each alert
+func TestEmail(t *testing.T) {
+	var sent []sentMail
+	e := newTestEmail(&sent, nil)
+	ctx := context.Background()
+
+	e.OnYourTurn(ctx, Alert{Kind: YourTurn, GameID: "g1", PlayerID: "p1", Turn: 4})
+	e.OnGameEnd(ctx, Alert{Kind: GameEnd, GameID: "g1", PlayerID: "p1", Standings: []game.Standing{{PlayerID: "p1", Score: 300}, {PlayerID: "p2", Score: 250, Forfeited: true}}})
+	e.OnChallenge(ctx, Alert{Kind: Challenge, GameID: "g1", PlayerID: "p1", Challenge: &game.ChallengeResult{ChallengerID: "p2", Successful: true, InvalidWords: []string{"CTA"}}})
+	if len(sent) != 3 {
+		t.Fatalf("Expected 3 messages, got %d", len(sent))
+	}
+	if sent[0].to[0] != "one@example.com" || !strings.Contains(sent[0].msg, "Subject: Your move in game g1\r\n") {
+		t.Errorf("Unexpected turn message %+v", sent[0])
+	}
+	if !strings.Contains(sent[1].msg, "p1 300\r\n") || !strings.Contains(sent[1].msg, "p2 250 (forfeited)") {
+		t.Errorf("Final scores missing from %q", sent[1].msg)
+	}
+	if !strings.Contains(sent[2].msg, "withdrawn: CTA not in the dictionary") {
+		t.Errorf("Challenge outcome missing from %q", sent[2].msg)
+	}
+
+	// Players without an address are skipped; game IDs cannot add headers
+	e.OnYourTurn(ctx, Alert{GameID: "g1", PlayerID: "p2"})
+	e.OnYourTurn(ctx, Alert{GameID: "g1\r\nBcc: x@example.com", PlayerID: "p1"})
+	if len(sent) != 4 || strings.Contains(strings.SplitN(sent[3].msg, "\r\n\r\n", 2)[0], "\r\nBcc:") {
+		t.Errorf("Expected one more message without injected headers, got %+v", sent[3:])
+	}
+}
+
+// TestEmailFailure tests reporting failed sends
+func TestEmailFailure(t *testing.T) {
+	var sent []sentMail
+	e := newTestEmail(&sent, errors.New("connection refused"))
+	if err := e.OnYourTurn(context.Background(), Alert{PlayerID: "p1"}); !errors.Is(err, ErrDeliveryFailed) {
+		t.Errorf("Expected ErrDeliveryFailed, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := e.OnYourTurn(ctx, Alert{PlayerID: "p1"}); !errors.Is(err, context.Canceled) || len(sent) != 1 {
+		t.Errorf("A cancelled context should stop the send, got %v", err)
+	}
+}
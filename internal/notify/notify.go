@@ -0,0 +1,160 @@
+// Package notify alerts players of correspondence games when it is their move,
+// when their play is challenged and when a game ends
+//
+// A Dispatcher reads the events each game records and calls a Notifier, such
+// as a Webhook or an Email sender, for the players who should hear about them.
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"scrabbled/internal/game"
+)
+
+// ErrDeliveryFailed is wrapped by the errors notifiers return when an alert
+// could not be delivered
+var ErrDeliveryFailed = errors.New("alert could not be delivered")
+
+// Kind identifies what an alert is about
+type Kind int
+
+const (
+	YourTurn  Kind = iota // The player is to move
+	GameEnd               // The game is over
+	Challenge             // The player's last play was challenged
+)
+
+// String returns a string representation of the alert kind
+func (k Kind) String() string {
+	switch k {
+	case YourTurn:
+		return "YOUR_TURN"
+	case GameEnd:
+		return "GAME_END"
+	case Challenge:
+		return "CHALLENGE"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// Alert is one notification for one player
+type Alert struct {
+	Kind      Kind                  `json:"kind"`
+	GameID    string                `json:"game_id"`
+	PlayerID  string                `json:"player_id"` // Recipient
+	Turn      int                   `json:"turn"`      // Turns in the game's history when the alert was raised
+	Standings []game.Standing       `json:"standings,omitempty"`
+	Challenge *game.ChallengeResult `json:"challenge,omitempty"`
+	Time      time.Time             `json:"time"`
+}
+
+// Notifier delivers alerts to players
+// Methods are called from the goroutine that calls Dispatcher.Notify and
+// should return once the alert is handed off or has failed.
+type Notifier interface {
+	OnYourTurn(ctx context.Context, a Alert) error
+	OnGameEnd(ctx context.Context, a Alert) error
+	OnChallenge(ctx context.Context, a Alert) error
+}
+
+// Dispatcher turns the events games record into alerts
+// It remembers how far it has read each game's event stream, so calling
+// Notify after every action alerts each player once.
+type Dispatcher struct {
+	notifier Notifier
+
+	mu    sync.Mutex
+	seen  map[string]int  // Next event to read, by game ID
+	ended map[string]bool // Games whose end has been announced
+	now   func() time.Time
+}
+
+// NewDispatcher creates a dispatcher sending alerts through n
+func NewDispatcher(n Notifier) *Dispatcher {
+	return &Dispatcher{notifier: n, seen: make(map[string]int), ended: make(map[string]bool), now: time.Now}
+}
+
+// Notify sends the alerts raised by the events g has recorded since the last
+// call for the same game
+// Challenged players hear of each challenge, the player to move hears once
+// however many turns went by, and every player hears when the game ends. Bots
+// are never alerted. A failed delivery does not stop the others; all failures
+// are returned together.
+func (d *Dispatcher) Notify(ctx context.Context, g *game.Game) error {
+	d.mu.Lock()
+	events := g.EventsSince(d.seen[g.ID])
+	if len(events) > 0 {
+		d.seen[g.ID] = events[len(events)-1].Seq + 1
+	}
+	announceEnd := g.State == game.Finished && !d.ended[g.ID]
+	if announceEnd {
+		d.ended[g.ID] = true
+	}
+	now := d.now()
+	d.mu.Unlock()
+
+	base := Alert{GameID: g.ID, Turn: len(g.History), Time: now}
+	var errs []error
+	send := func(player string, notify func(context.Context, Alert) error, a Alert) {
+		if player == "" || g.IsBot(player) {
+			return
+		}
+		a.PlayerID = player
+		if err := notify(ctx, a); err != nil {
+			errs = append(errs, fmt.Errorf("%s alert for %s: %w", a.Kind, player, err))
+		}
+	}
+
+	moved := false
+	for _, ev := range events {
+		if ev.Type == game.ChallengeResolved && ev.Challenge != nil {
+			a := base
+			a.Kind, a.Challenge = Challenge, ev.Challenge
+			send(ev.Challenge.ChallengedID, d.notifier.OnChallenge, a)
+		}
+		moved = moved || changesTurn(ev.Type)
+	}
+
+	switch {
+	case announceEnd:
+		a := base
+		a.Kind = GameEnd
+		a.Standings, _ = g.FinalStandings()
+		for _, p := range g.Players {
+			send(p.ID, d.notifier.OnGameEnd, a)
+		}
+	case moved && g.State == game.InProgress:
+		a := base
+		a.Kind = YourTurn
+		if p := g.CurrentPlayer(); p != nil {
+			send(p.ID, d.notifier.OnYourTurn, a)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Forget drops what the dispatcher remembers about a game, such as one that
+// has been deleted
+func (d *Dispatcher) Forget(gameID string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.seen, gameID)
+	delete(d.ended, gameID)
+}
+
+// changesTurn returns true for events after which another player may be to move
+func changesTurn(t game.EventType) bool {
+	switch t {
+	case game.GameStarted, game.MovePlayed, game.TilesExchanged, game.TurnPassed, game.TurnSkipped,
+		game.ChallengeResolved, game.TimeExpired, game.PlayerResigned, game.GameAbandoned,
+		game.ActionUndone, game.ActionRedone, game.GameResumed:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,158 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"scrabbled/internal/dictionary"
+	"scrabbled/internal/game"
+)
+
+// recorder is a Notifier remembering the alerts it was given
+type recorder struct {
+	alerts []Alert
+	fail   bool
+}
+
+func (r *recorder) OnYourTurn(ctx context.Context, a Alert) error  { return r.add(a) }
+func (r *recorder) OnGameEnd(ctx context.Context, a Alert) error   { return r.add(a) }
+func (r *recorder) OnChallenge(ctx context.Context, a Alert) error { return r.add(a) }
+
+// add records an alert, failing if the recorder is set to
+func (r *recorder) add(a Alert) error {
+	r.alerts = append(r.alerts, a)
+	if r.fail {
+		return ErrDeliveryFailed
+	}
+	return nil
+}
+
+// take returns the alerts recorded since the last call as kind:player strings
+func (r *recorder) take() []string {
+	var got []string
+	for _, a := range r.alerts {
+		got = append(got, a.Kind.String()+":"+a.PlayerID)
+	}
+	r.alerts = nil
+	return got
+}
+
+// newNotifyGame creates a two player double challenge game that knows the word CAT
+func newNotifyGame(t *testing.T) *game.Game {
+	t.Helper()
+	g, err := game.NewGame("g1", []*game.Player{game.NewPlayer("p1", "One"), game.NewPlayer("p2", "Two")})
+	if err != nil {
+		t.Fatalf("NewGame failed: %v", err)
+	}
+	dict := dictionary.NewWordList(dictionary.Custom)
+	dict.AddWord("CAT")
+	g.SetDictionary(dict)
+	g.SetChallengeRule(game.DoubleChallenge)
+	return g
+}
+
+// tiles returns rack tiles for letters
+func tiles(letters string) []game.Tile {
+	var rack []game.Tile
+	for _, r := range letters {
+		rack = append(rack, game.Tile{Letter: r, Points: 1})
+	}
+	return rack
+}
+
+// TestDispatcher tests which players are alerted as a game goes on
+func TestDispatcher(t *testing.T) {
+	ctx := context.Background()
+	rec := &recorder{}
+	d := NewDispatcher(rec)
+	g := newNotifyGame(t)
+
+	d.Notify(ctx, g)
+	if got := rec.take(); got != nil {
+		t.Errorf("Nobody should be alerted before the game starts, got %v", got)
+	}
+
+	g.StartGame()
+	d.Notify(ctx, g)
+	if got := rec.take(); len(got) != 1 || got[0] != "YOUR_TURN:p1" {
+		t.Errorf("Expected p1 alerted to move first, got %v", got)
+	}
+	d.Notify(ctx, g)
+	if got := rec.take(); got != nil {
+		t.Errorf("Nothing new should alert nobody, got %v", got)
+	}
+
+	// Several turns in one batch alert only the player now to move
+	g.PassTurn("p1")
+	g.PassTurn("p2")
+	d.Notify(ctx, g)
+	if got := rec.take(); len(got) != 1 || got[0] != "YOUR_TURN:p1" {
+		t.Errorf("Expected only p1 alerted, got %v", got)
+	}
+
+	// A successful challenge alerts the challenged player, and the challenger moves next
+	g.Players[0].Rack = tiles("CTAXYZQ")
+	move, _ := g.Board.BuildMove("p1", "CTA", game.Position{Row: 7, Col: 6}, game.Horizontal, nil)
+	if _, err := g.PlayMove(move); err != nil {
+		t.Fatalf("PlayMove failed: %v", err)
+	}
+	if _, err := g.Challenge("p2"); err != nil {
+		t.Fatalf("Challenge failed: %v", err)
+	}
+	d.Notify(ctx, g)
+	got := rec.take()
+	if len(got) != 2 || got[0] != "CHALLENGE:p1" || got[1] != "YOUR_TURN:p2" {
+		t.Errorf("Expected the challenge for p1 and the turn for p2, got %v", got)
+	}
+
+	// The end is announced to everyone, once
+	g.EndGame()
+	d.Notify(ctx, g)
+	got = rec.take()
+	if len(got) != 2 || got[0] != "GAME_END:p1" || got[1] != "GAME_END:p2" {
+		t.Errorf("Expected both players told of the end, got %v", got)
+	}
+	d.Notify(ctx, g)
+	if got := rec.take(); got != nil {
+		t.Errorf("The end should be announced once, got %v", got)
+	}
+}
+
+// TestDispatcherSkipsBots tests that bots are not alerted and failures are reported
+func TestDispatcherSkipsBots(t *testing.T) {
+	rec := &recorder{fail: true}
+	d := NewDispatcher(rec)
+	g := newNotifyGame(t)
+	g.AttachBot(game.NewBot("p2", game.Greedy, game.NewMoveGenerator([]string{"CAT"})))
+	g.StartGame()
+
+	err := d.Notify(context.Background(), g)
+	if !errors.Is(err, ErrDeliveryFailed) {
+		t.Errorf("Should report the failed delivery, got %v", err)
+	}
+	rec.take()
+	g.PassTurn("p1")
+	d.Notify(context.Background(), g)
+	if got := rec.take(); got != nil {
+		t.Errorf("The bot should not be alerted, got %v", got)
+	}
+	g.PassTurn("p2")
+	d.Notify(context.Background(), g)
+	if got := rec.take(); len(got) != 1 || got[0] != "YOUR_TURN:p1" {
+		t.Errorf("Expected the human alerted, got %v", got)
+	}
+
+	d.Forget("g1")
+	d.Notify(context.Background(), g)
+	if got := rec.take(); len(got) != 1 {
+		t.Errorf("A forgotten game should be read from the start, got %v", got)
+	}
+}
+
+// TestKindString tests alert kind names
+func TestKindString(t *testing.T) {
+	if YourTurn.String() != "YOUR_TURN" || GameEnd.String() != "GAME_END" || Challenge.String() != "CHALLENGE" || Kind(9).String() != "UNKNOWN" {
+		t.Errorf("Unexpected kind names")
+	}
+}
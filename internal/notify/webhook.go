@@ -0,0 +1,84 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// SignatureHeader carries the HMAC-SHA256 of a webhook body, as
+// "sha256=<hex>", when the webhook has a secret
+const SignatureHeader = "X-Scrabbled-Signature"
+
+// Webhook posts alerts as JSON to a URL
+// Receivers sharing the secret can check SignatureHeader to be sure the alert
+// came from this server.
+type Webhook struct {
+	URL    string
+	Secret string       // Key for signing bodies; empty sends them unsigned
+	Client *http.Client // Client to post with; nil uses http.DefaultClient
+}
+
+// NewWebhook creates a webhook posting to url, signing with secret if it is not empty
+func NewWebhook(url, secret string) *Webhook {
+	return &Webhook{URL: url, Secret: secret}
+}
+
+// OnYourTurn posts a turn alert
+func (w *Webhook) OnYourTurn(ctx context.Context, a Alert) error {
+	return w.post(ctx, a)
+}
+
+// OnGameEnd posts a game end alert
+func (w *Webhook) OnGameEnd(ctx context.Context, a Alert) error {
+	return w.post(ctx, a)
+}
+
+// OnChallenge posts a challenge alert
+func (w *Webhook) OnChallenge(ctx context.Context, a Alert) error {
+	return w.post(ctx, a)
+}
+
+// post sends an alert, failing unless the receiver answers with a 2xx status
+func (w *Webhook) post(ctx context.Context, a Alert) error {
+	body, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if w.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(w.Secret, body))
+	}
+
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDeliveryFailed, err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%w: webhook answered %s", ErrDeliveryFailed, resp.Status)
+	}
+	return nil
+}
+
+// Sign returns the SignatureHeader value for a body
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,57 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestWebhook tests posting signed alerts
+func TestWebhook(t *testing.T) {
+	var got Alert
+	var signature string
+	var valid bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		signature = r.Header.Get(SignatureHeader)
+		valid = signature == Sign("secret", body)
+		json.Unmarshal(body, &got)
+	}))
+	defer srv.Close()
+
+	w := NewWebhook(srv.URL, "secret")
+	if err := w.OnYourTurn(context.Background(), Alert{Kind: YourTurn, GameID: "g1", PlayerID: "p1", Turn: 3}); err != nil {
+		t.Fatalf("OnYourTurn failed: %v", err)
+	}
+	if got.Kind != YourTurn || got.GameID != "g1" || got.PlayerID != "p1" || got.Turn != 3 {
+		t.Errorf("Unexpected alert received %+v", got)
+	}
+	if !valid {
+		t.Errorf("Signature %q should match the body", signature)
+	}
+
+	w.Secret = ""
+	if err := w.OnGameEnd(context.Background(), Alert{Kind: GameEnd}); err != nil || signature != "" {
+		t.Errorf("Unsigned webhooks should omit the signature, got %q, %v", signature, err)
+	}
+}
+
+// TestWebhookFailure tests reporting receivers that refuse alerts
+func TestWebhookFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "gone", http.StatusGone)
+	}))
+	w := NewWebhook(srv.URL, "")
+	if err := w.OnChallenge(context.Background(), Alert{Kind: Challenge}); !errors.Is(err, ErrDeliveryFailed) {
+		t.Errorf("Expected ErrDeliveryFailed for a 410, got %v", err)
+	}
+
+	srv.Close()
+	if err := w.OnYourTurn(context.Background(), Alert{}); !errors.Is(err, ErrDeliveryFailed) {
+		t.Errorf("Expected ErrDeliveryFailed for an unreachable receiver, got %v", err)
+	}
+}
@@ -0,0 +1,221 @@
+// Package puzzle mines games for positions where a bingo or a standout play
+// exists and turns them into training puzzles, and sets board vision
+// exercises from mid-game positions
+package puzzle
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+
+	"scrabbled/internal/game"
+)
+
+// Default mining thresholds
+const (
+	DefaultMinScore  = 40 // Lowest top score worth a best-play puzzle
+	DefaultMinMargin = 10 // Points the best play must lead the next best by
+)
+
+// Kind identifies what a puzzle asks the solver to find
+type Kind int
+
+const (
+	FindTheBingo Kind = iota // Find a move using all seven tiles
+	BestPlay                 // Find the highest scoring move
+)
+
+// String returns a string representation of the puzzle kind
+func (k Kind) String() string {
+	switch k {
+	case FindTheBingo:
+		return "FIND_THE_BINGO"
+	case BestPlay:
+		return "BEST_PLAY"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// Puzzle is a position with a rack and the score the solver should reach
+type Puzzle struct {
+	ID          string      `json:"id"`
+	Kind        Kind        `json:"kind"`
+	Board       *game.Board `json:"board"`
+	Rack        []game.Tile `json:"rack"`
+	TargetScore int         `json:"target_score"` // Score of the best solution
+	Solutions   []game.Move `json:"solutions"`    // Moves that solve the puzzle, highest scoring first
+	Source      string      `json:"source"`       // ID of the game the position came from
+	Turn        int         `json:"turn"`         // Moves made in the source game before the position
+}
+
+// Miner finds puzzles in positions from random or recorded games
+type Miner struct {
+	MinScore  int // Lowest top score for a best-play puzzle
+	MinMargin int // Lead the best play needs over the next best
+	generator *game.MoveGenerator
+	rng       *rand.Rand
+}
+
+// NewMiner creates a miner whose random games are determined by seed
+func NewMiner(generator *game.MoveGenerator, seed int64) *Miner {
+	return &Miner{
+		MinScore:  DefaultMinScore,
+		MinMargin: DefaultMinMargin,
+		generator: generator,
+		rng:       rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Position checks a single position for a puzzle
+// A bingo makes a find-the-bingo puzzle; otherwise a best play that scores at
+// least MinScore and beats every other move by MinMargin makes a best-play
+// puzzle. Returns false if the position has neither.
+func (m *Miner) Position(b *game.Board, rack []game.Tile) (Puzzle, bool) {
+	moves := m.generator.GenerateMoves(b, rack)
+	if len(moves) == 0 {
+		return Puzzle{}, false
+	}
+
+	p := Puzzle{Board: b, Rack: append([]game.Tile(nil), rack...)}
+
+	// Moves are generated highest scoring first, so bingos come out in order
+	for _, move := range moves {
+		if len(move.Tiles) == b.RackSize {
+			p.Solutions = append(p.Solutions, move)
+		}
+	}
+	if len(p.Solutions) > 0 {
+		p.Kind = FindTheBingo
+		p.TargetScore = p.Solutions[0].Score
+		return p, true
+	}
+
+	best := moves[0].Score
+	if best < m.MinScore {
+		return Puzzle{}, false
+	}
+	for _, move := range moves {
+		if move.Score == best {
+			p.Solutions = append(p.Solutions, move)
+		} else if best-move.Score < m.MinMargin {
+			return Puzzle{}, false
+		} else {
+			break
+		}
+	}
+	p.Kind = BestPlay
+	p.TargetScore = best
+	return p, true
+}
+
+// FromGame checks the position facing the player to move in a game in progress
+func (m *Miner) FromGame(g *game.Game) (Puzzle, bool) {
+	view := g.View(game.ViewOptions{ShowRacks: true})
+	if view.State != game.InProgress {
+		return Puzzle{}, false
+	}
+
+	for _, pv := range view.Players {
+		if pv.ID != view.CurrentPlayerID {
+			continue
+		}
+		p, ok := m.Position(view.Board, pv.Rack)
+		if !ok {
+			return Puzzle{}, false
+		}
+		p.Source = view.ID
+		p.Turn = len(view.History)
+		p.ID = fmt.Sprintf("%s-%d", p.Source, p.Turn)
+		return p, true
+	}
+	return Puzzle{}, false
+}
+
+// FromEvents replays a recorded game and mines the position before each turn
+func (m *Miner) FromEvents(events []game.Event) ([]Puzzle, error) {
+	r, err := game.NewReplayer(events)
+	if err != nil {
+		return nil, err
+	}
+
+	puzzles := []Puzzle{}
+	for i := 1; i < r.Len(); i++ {
+		switch events[i].Type {
+		case game.MovePlayed, game.TilesExchanged, game.TurnPassed:
+		default:
+			continue
+		}
+		g, err := r.Seek(i)
+		if err != nil {
+			return nil, err
+		}
+		if p, ok := m.FromGame(g); ok {
+			puzzles = append(puzzles, p)
+		}
+	}
+	return puzzles, nil
+}
+
+// FromRandomGames plays n games between two equity bots and mines the
+// position before every turn
+func (m *Miner) FromRandomGames(n int) ([]Puzzle, error) {
+	puzzles := []Puzzle{}
+	for i := 0; i < n; i++ {
+		found, err := m.randomGame(m.rng.Int63())
+		if err != nil {
+			return nil, err
+		}
+		puzzles = append(puzzles, found...)
+	}
+	return puzzles, nil
+}
+
+// randomGame plays out one self-play game from a seeded bag
+func (m *Miner) randomGame(seed int64) ([]Puzzle, error) {
+	puzzles := []Puzzle{}
+	err := selfPlay(m.generator, seed, func(g *game.Game) {
+		if p, ok := m.FromGame(g); ok {
+			puzzles = append(puzzles, p)
+		}
+	})
+	return puzzles, err
+}
+
+// selfPlay plays a game between two equity bots from a seeded bag, calling
+// visit with the game before every turn
+func selfPlay(generator *game.MoveGenerator, seed int64, visit func(g *game.Game)) error {
+	players := []*game.Player{game.NewPlayer("bot1", "Bot 1"), game.NewPlayer("bot2", "Bot 2")}
+	g, err := game.NewGame(fmt.Sprintf("random-%d", seed), players)
+	if err != nil {
+		return err
+	}
+	g.TileBag = game.NewTileBagWithSeed(seed)
+	if err := g.StartGame(); err != nil {
+		return err
+	}
+
+	for g.State == game.InProgress {
+		visit(g)
+
+		player := g.CurrentPlayer()
+		bot := game.NewBot(player.ID, game.Equity, generator)
+		if move, found := bot.ChooseMove(g.Board, player.Rack); found {
+			_, err = g.PlayMove(move)
+		} else {
+			err = g.PassTurn(player.ID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteJSON writes puzzles to w as an indented JSON array
+func WriteJSON(w io.Writer, puzzles []Puzzle) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(puzzles)
+}
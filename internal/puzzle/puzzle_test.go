@@ -0,0 +1,176 @@
+package puzzle
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"scrabbled/internal/game"
+)
+
+// testWords is a small lexicon with a few seven-letter words
+var testWords = []string{
+	"RETAINS", "RETINAS", "STAINER", "ANESTRI",
+	"AT", "TA", "AS", "IS", "IT", "TI", "NA", "AN", "IN", "RE", "ER", "ES",
+	"EAT", "TEA", "ATE", "SAT", "SIT", "TIN", "TAN", "RAT", "TAR", "ART", "STAR", "RATS",
+	"ZA", "QI", "ZAS", "QIS", "ZIT", "ZITS",
+}
+
+// rackOf builds a rack from letters, with ? for a blank
+func rackOf(letters string) []game.Tile {
+	rack := make([]game.Tile, 0, len(letters))
+	for _, r := range letters {
+		if r == '?' {
+			rack = append(rack, game.Tile{IsBlank: true})
+		} else {
+			rack = append(rack, game.Tile{Letter: r, Points: game.GetTileValue(r)})
+		}
+	}
+	return rack
+}
+
+// TestPositionBingo tests that a rack holding a bingo becomes a find-the-bingo puzzle
+func TestPositionBingo(t *testing.T) {
+	m := NewMiner(game.NewMoveGenerator(testWords), 1)
+
+	p, ok := m.Position(game.NewBoard(), rackOf("RETAINS"))
+	if !ok {
+		t.Fatalf("Should find a bingo puzzle")
+	}
+	if p.Kind != FindTheBingo {
+		t.Errorf("Expected FIND_THE_BINGO, got %s", p.Kind)
+	}
+	words := map[string]bool{}
+	for i, s := range p.Solutions {
+		if len(s.Tiles) != game.MaxRackSize {
+			t.Errorf("Every solution should use all seven tiles, got %s", s.Word)
+		}
+		if i == 0 && s.Score != p.TargetScore {
+			t.Errorf("Target should be the best bingo score %d, got %d", s.Score, p.TargetScore)
+		}
+		words[s.Word] = true
+	}
+	if len(words) != 4 {
+		t.Errorf("Should offer all four anagrams, got %v", words)
+	}
+}
+
+// TestPositionBestPlay tests the score and margin thresholds for best-play puzzles
+func TestPositionBestPlay(t *testing.T) {
+	m := NewMiner(game.NewMoveGenerator(testWords), 1)
+	m.MinScore = 20
+	m.MinMargin = 1
+
+	p, ok := m.Position(game.NewBoard(), rackOf("ZITSEEE"))
+	if !ok {
+		t.Fatalf("Should find a best-play puzzle")
+	}
+	if p.Kind != BestPlay || p.TargetScore < m.MinScore {
+		t.Errorf("Expected a best play of at least %d, got %s worth %d", m.MinScore, p.Kind, p.TargetScore)
+	}
+	for _, s := range p.Solutions {
+		if s.Score != p.TargetScore || s.Word != "ZITS" {
+			t.Errorf("Solutions should all be the top-scoring ZITS, got %s for %d", s.Word, s.Score)
+		}
+	}
+
+	m.MinScore = 100
+	if _, ok := m.Position(game.NewBoard(), rackOf("ZITSEEE")); ok {
+		t.Errorf("Should reject positions whose best play is below MinScore")
+	}
+
+	m.MinScore = 1
+	m.MinMargin = 100
+	if _, ok := m.Position(game.NewBoard(), rackOf("ZITSEEE")); ok {
+		t.Errorf("Should reject positions without a clear best play")
+	}
+
+	if _, ok := m.Position(game.NewBoard(), rackOf("UUUUVVV")); ok {
+		t.Errorf("Should reject positions with no moves")
+	}
+}
+
+// TestFromRandomGames tests that self-play mining is reproducible and yields valid puzzles
+func TestFromRandomGames(t *testing.T) {
+	mine := func() []Puzzle {
+		m := NewMiner(game.NewMoveGenerator(testWords), 42)
+		m.MinScore = 10
+		m.MinMargin = 1
+		puzzles, err := m.FromRandomGames(3)
+		if err != nil {
+			t.Fatalf("FromRandomGames failed: %v", err)
+		}
+		return puzzles
+	}
+
+	puzzles := mine()
+	if len(puzzles) == 0 {
+		t.Fatalf("Should mine some puzzles")
+	}
+	for _, p := range puzzles {
+		if p.ID == "" || p.Source == "" || len(p.Rack) == 0 || len(p.Solutions) == 0 {
+			t.Errorf("Puzzle should be complete, got %+v", p)
+		}
+		if p.Solutions[0].Score != p.TargetScore {
+			t.Errorf("Best solution should reach the target")
+		}
+	}
+
+	var a, b bytes.Buffer
+	if err := WriteJSON(&a, puzzles); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+	WriteJSON(&b, mine())
+	if a.String() != b.String() {
+		t.Errorf("The same seed should mine the same puzzles")
+	}
+
+	var decoded []Puzzle
+	if err := json.Unmarshal(a.Bytes(), &decoded); err != nil || len(decoded) != len(puzzles) {
+		t.Errorf("Puzzles should round-trip through JSON: %v", err)
+	}
+}
+
+// TestFromEvents tests mining a recorded game
+func TestFromEvents(t *testing.T) {
+	gen := game.NewMoveGenerator(testWords)
+	g, err := game.NewGame("recorded", []*game.Player{game.NewPlayer("p1", "Alice"), game.NewPlayer("p2", "Bob")})
+	if err != nil {
+		t.Fatalf("NewGame failed: %v", err)
+	}
+	g.TileBag = game.NewTileBagWithSeed(1)
+	g.StartGame()
+	g.AttachBot(game.NewBot("p1", game.Greedy, gen))
+	g.AttachBot(game.NewBot("p2", game.Greedy, gen))
+	if _, err := g.PlayBotTurns(); err != nil {
+		t.Fatalf("PlayBotTurns failed: %v", err)
+	}
+
+	m := NewMiner(gen, 1)
+	m.MinScore = 10
+	m.MinMargin = 1
+	puzzles, err := m.FromEvents(g.Events())
+	if err != nil {
+		t.Fatalf("FromEvents failed: %v", err)
+	}
+	if len(puzzles) == 0 {
+		t.Fatalf("Should mine some puzzles from the recorded game")
+	}
+	history := g.GetHistory()
+	for _, p := range puzzles {
+		if p.Source != "recorded" || p.ID != fmt.Sprintf("recorded-%d", p.Turn) {
+			t.Errorf("Puzzles should name their source game and turn, got %q", p.ID)
+		}
+		if p.Turn >= len(history) || history[p.Turn].Type != game.PlaceTiles {
+			continue
+		}
+		if played := history[p.Turn].Score; played != p.TargetScore {
+			t.Errorf("The greedy bot should have found the %d point play at turn %d, scored %d", p.TargetScore, p.Turn, played)
+		}
+	}
+
+	if _, err := NewMiner(gen, 1).FromEvents(nil); err == nil {
+		t.Errorf("Should reject an empty event stream")
+	}
+}
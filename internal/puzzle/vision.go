@@ -0,0 +1,174 @@
+package puzzle
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"scrabbled/internal/game"
+)
+
+// Default board vision settings
+const (
+	DefaultVisionTarget    = 30          // Score a play must reach
+	DefaultVisionTimeLimit = time.Minute // Time allowed to find one
+	DefaultVisionMinTurn   = 6           // Moves made before a position counts as mid-game
+)
+
+// Vision is a board vision exercise: a mid-game position in which the solver
+// must find any play reaching the target score within the time limit
+// Unlike a best-play puzzle any qualifying play will do, which trains
+// spotting scoring lanes on a crowded board rather than anagramming.
+type Vision struct {
+	ID        string        `json:"id"`
+	Board     *game.Board   `json:"board"`
+	Rack      []game.Tile   `json:"rack"`
+	Target    int           `json:"target"`     // Score a play must reach
+	TimeLimit time.Duration `json:"time_limit"` // Time allowed to answer
+	Plays     int           `json:"plays"`      // Number of plays reaching the target
+	Best      game.Move     `json:"best"`       // Highest scoring play
+	Source    string        `json:"source"`     // ID of the game the position came from
+	Turn      int           `json:"turn"`       // Moves made in the source game before the position
+}
+
+// VisionResult is the verdict on an answer to a board vision exercise
+type VisionResult struct {
+	Move   game.Move `json:"move"`    // The answer as scored by the move generator
+	Legal  bool      `json:"legal"`   // True if the answer is a legal play from the rack
+	Score  int       `json:"score"`   // Score of the answer, 0 if illegal
+	InTime bool      `json:"in_time"` // True if answered within the time limit
+	Solved bool      `json:"solved"`  // True if a legal answer reached the target in time
+	Rank   int       `json:"rank"`    // Plays scoring more than the answer, plus one; 0 if illegal
+}
+
+// VisionTrainer sets and marks board vision exercises
+type VisionTrainer struct {
+	Target    int           // Score a play must reach
+	TimeLimit time.Duration // Time allowed to answer
+	MinTurn   int           // Moves made before a position is used
+	generator *game.MoveGenerator
+	rng       *rand.Rand
+}
+
+// NewVisionTrainer creates a trainer whose random games are determined by seed
+func NewVisionTrainer(generator *game.MoveGenerator, seed int64) *VisionTrainer {
+	return &VisionTrainer{
+		Target:    DefaultVisionTarget,
+		TimeLimit: DefaultVisionTimeLimit,
+		MinTurn:   DefaultVisionMinTurn,
+		generator: generator,
+		rng:       rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Position sets an exercise for a single position
+// Returns false if no play reaches the target.
+func (t *VisionTrainer) Position(b *game.Board, rack []game.Tile) (Vision, bool) {
+	moves := t.generator.GenerateMoves(b, rack)
+	if len(moves) == 0 || moves[0].Score < t.Target {
+		return Vision{}, false
+	}
+
+	v := Vision{
+		Board:     b,
+		Rack:      append([]game.Tile(nil), rack...),
+		Target:    t.Target,
+		TimeLimit: t.TimeLimit,
+		Best:      moves[0],
+	}
+	for _, move := range moves {
+		if move.Score < t.Target {
+			break
+		}
+		v.Plays++
+	}
+	return v, true
+}
+
+// FromGame sets an exercise from the position facing the player to move in a
+// game in progress, once at least MinTurn moves have been made
+func (t *VisionTrainer) FromGame(g *game.Game) (Vision, bool) {
+	view := g.View(game.ViewOptions{ShowRacks: true})
+	if view.State != game.InProgress || len(view.History) < t.MinTurn {
+		return Vision{}, false
+	}
+
+	for _, pv := range view.Players {
+		if pv.ID != view.CurrentPlayerID {
+			continue
+		}
+		v, ok := t.Position(view.Board, pv.Rack)
+		if !ok {
+			return Vision{}, false
+		}
+		v.Source = view.ID
+		v.Turn = len(view.History)
+		v.ID = fmt.Sprintf("%s-%d", v.Source, v.Turn)
+		return v, true
+	}
+	return Vision{}, false
+}
+
+// FromRandomGames plays n games between two equity bots and sets an exercise
+// from every mid-game position
+func (t *VisionTrainer) FromRandomGames(n int) ([]Vision, error) {
+	exercises := []Vision{}
+	for i := 0; i < n; i++ {
+		err := selfPlay(t.generator, t.rng.Int63(), func(g *game.Game) {
+			if v, ok := t.FromGame(g); ok {
+				exercises = append(exercises, v)
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return exercises, nil
+}
+
+// Check marks an answer given after elapsed
+// The answer is legal if the move generator finds the same placement from the
+// exercise's rack, and is scored as the generator scores it.
+func (t *VisionTrainer) Check(v Vision, answer game.Move, elapsed time.Duration) VisionResult {
+	result := VisionResult{Move: answer, InTime: elapsed <= v.TimeLimit}
+	moves := t.generator.GenerateMoves(v.Board, v.Rack)
+	for _, move := range moves {
+		if samePlacement(move, answer) {
+			result.Move = move
+			result.Legal = true
+			result.Score = move.Score
+			break
+		}
+	}
+	if !result.Legal {
+		return result
+	}
+
+	result.Rank = 1
+	for _, move := range moves {
+		if move.Score > result.Score {
+			result.Rank++
+		}
+	}
+	result.Solved = result.InTime && result.Score >= v.Target
+	return result
+}
+
+// samePlacement returns true if two moves place the same tiles on the same
+// squares, blanks included
+func samePlacement(a, b game.Move) bool {
+	if len(a.Tiles) != len(b.Tiles) {
+		return false
+	}
+	placed := make(map[game.Position]game.Tile, len(a.Tiles))
+	for _, pt := range a.Tiles {
+		placed[pt.Position] = pt.Tile
+	}
+	for _, pt := range b.Tiles {
+		tile, ok := placed[pt.Position]
+		if !ok || tile.Letter != pt.Tile.Letter || tile.IsBlank != pt.Tile.IsBlank {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,96 @@
+package puzzle
+
+import (
+	"testing"
+	"time"
+
+	"scrabbled/internal/game"
+)
+
+// TestVisionPosition tests setting an exercise only when a play reaches the target
+func TestVisionPosition(t *testing.T) {
+	vt := NewVisionTrainer(game.NewMoveGenerator(testWords), 1)
+	vt.Target = 20
+
+	v, ok := vt.Position(game.NewBoard(), rackOf("ZITSEEE"))
+	if !ok {
+		t.Fatalf("Should set an exercise")
+	}
+	if v.Target != 20 || v.TimeLimit != DefaultVisionTimeLimit || v.Plays == 0 || v.Best.Score < v.Target {
+		t.Errorf("Unexpected exercise: %+v", v)
+	}
+
+	vt.Target = 100
+	if _, ok := vt.Position(game.NewBoard(), rackOf("ZITSEEE")); ok {
+		t.Errorf("Should reject positions where no play reaches the target")
+	}
+	if _, ok := vt.Position(game.NewBoard(), rackOf("UUUUVVV")); ok {
+		t.Errorf("Should reject positions with no moves")
+	}
+}
+
+// TestVisionCheck tests marking answers for legality, score and time
+func TestVisionCheck(t *testing.T) {
+	vt := NewVisionTrainer(game.NewMoveGenerator(testWords), 1)
+	vt.Target = 20
+	v, _ := vt.Position(game.NewBoard(), rackOf("ZITSEEE"))
+
+	answer := func(coords, play string) game.Move {
+		t.Helper()
+		move, err := v.Board.ParsePlay("p1", coords, play)
+		if err != nil {
+			t.Fatalf("Failed to parse %s %s: %v", coords, play, err)
+		}
+		return move
+	}
+
+	r := vt.Check(v, answer("8H", "ZITS"), 10*time.Second)
+	if !r.Legal || !r.InTime || !r.Solved || r.Score != 26 || r.Move.Score != 26 {
+		t.Errorf("Expected ZITS at 8H to score 26 and solve the exercise, got %+v", r)
+	}
+	if r.Rank < 1 || (r.Rank == 1) != (r.Score == v.Best.Score) {
+		t.Errorf("Expected a rank consistent with the best play, got %d", r.Rank)
+	}
+
+	if r := vt.Check(v, answer("8H", "ZITS"), 2*time.Minute); !r.Legal || r.InTime || r.Solved {
+		t.Errorf("Expected a late answer to be legal but unsolved, got %+v", r)
+	}
+	if r := vt.Check(v, answer("8H", "SIT"), time.Second); !r.Legal || r.Solved || r.Score >= v.Target {
+		t.Errorf("Expected SIT to fall short of the target, got %+v", r)
+	}
+	if r := vt.Check(v, answer("A1", "ZITS"), time.Second); r.Legal || r.Solved || r.Rank != 0 {
+		t.Errorf("Expected a play off the centre to be illegal, got %+v", r)
+	}
+	if r := vt.Check(v, answer("8H", "zITS"), time.Second); r.Legal {
+		t.Errorf("Expected a blank the rack does not hold to be illegal, got %+v", r)
+	}
+}
+
+// TestVisionFromRandomGames tests that exercises come from reproducible mid-game positions
+func TestVisionFromRandomGames(t *testing.T) {
+	set := func() []Vision {
+		vt := NewVisionTrainer(game.NewMoveGenerator(testWords), 42)
+		vt.Target = 10
+		vt.MinTurn = 2
+		exercises, err := vt.FromRandomGames(3)
+		if err != nil {
+			t.Fatalf("FromRandomGames failed: %v", err)
+		}
+		return exercises
+	}
+
+	exercises := set()
+	if len(exercises) == 0 {
+		t.Fatalf("Should set some exercises")
+	}
+	for _, v := range exercises {
+		if v.ID == "" || v.Turn < 2 || v.Plays == 0 || v.Best.Score < v.Target {
+			t.Errorf("Exercise should be complete and mid-game, got %+v", v)
+		}
+	}
+
+	again := set()
+	if len(again) != len(exercises) || again[0].ID != exercises[0].ID || again[0].Best.Score != exercises[0].Best.Score {
+		t.Errorf("The same seed should set the same exercises")
+	}
+}
@@ -0,0 +1,52 @@
+package rating
+
+import (
+	"math"
+	"time"
+)
+
+// Default Elo settings
+const (
+	DefaultEloRating = 1500
+	DefaultEloK      = 32
+)
+
+// Elo is the classic Elo rating system
+// When SpreadScale is positive, wins by larger margins move ratings further:
+// the K factor grows linearly with the spread, up to double at SpreadScale points.
+type Elo struct {
+	K           float64 // Maximum rating change per game at the base rate
+	SpreadScale float64 // Spread at which the margin multiplier reaches 2 (0 ignores spread)
+}
+
+// NewElo creates an Elo system with the default K factor, ignoring spread
+func NewElo() *Elo {
+	return &Elo{K: DefaultEloK}
+}
+
+// NewRating returns the starting Elo rating
+func (e *Elo) NewRating(playerID string) Rating {
+	return Rating{PlayerID: playerID, Value: DefaultEloRating}
+}
+
+// Update applies the results of one game to a rating
+func (e *Elo) Update(r Rating, results []Result) Rating {
+	change := 0.0
+	for _, res := range results {
+		expected := 1 / (1 + math.Pow(10, (res.Opponent.Value-r.Value)/400))
+		change += e.K * e.marginMultiplier(res.Spread) * (res.Score - expected)
+	}
+
+	r.Value += change
+	r.Games++
+	r.UpdatedAt = time.Now()
+	return r
+}
+
+// marginMultiplier scales the K factor by the margin of victory
+func (e *Elo) marginMultiplier(spread int) float64 {
+	if e.SpreadScale <= 0 {
+		return 1
+	}
+	return 1 + math.Min(math.Abs(float64(spread))/e.SpreadScale, 1)
+}
@@ -0,0 +1,46 @@
+package rating
+
+import (
+	"math"
+	"testing"
+)
+
+// TestEloUpdate tests Elo rating changes
+func TestEloUpdate(t *testing.T) {
+	elo := NewElo()
+	a, b := elo.NewRating("a"), elo.NewRating("b")
+	if a.Value != DefaultEloRating {
+		t.Errorf("New rating should be %d, got %f", DefaultEloRating, a.Value)
+	}
+
+	// Evenly matched players exchange K/2 points
+	won := elo.Update(a, []Result{{Opponent: b, Score: 1, Spread: 100}})
+	if math.Abs(won.Value-1516) > 1e-9 || won.Games != 1 {
+		t.Errorf("Expected 1516 after one game, got %f (%d games)", won.Value, won.Games)
+	}
+	lost := elo.Update(b, []Result{{Opponent: a, Score: 0, Spread: -100}})
+	if math.Abs(lost.Value-1484) > 1e-9 {
+		t.Errorf("Expected 1484, got %f", lost.Value)
+	}
+
+	// Beating a much weaker player gains little
+	b.Value = 1100
+	if gain := elo.Update(a, []Result{{Opponent: b, Score: 1}}).Value - a.Value; gain > 3 {
+		t.Errorf("Beating a 400 point weaker player should gain about 3 points, got %f", gain)
+	}
+}
+
+// TestEloSpread tests scaling Elo changes by the margin of victory
+func TestEloSpread(t *testing.T) {
+	elo := &Elo{K: DefaultEloK, SpreadScale: 200}
+	a, b := elo.NewRating("a"), elo.NewRating("b")
+
+	narrow := elo.Update(a, []Result{{Opponent: b, Score: 1, Spread: 10}}).Value - a.Value
+	blowout := elo.Update(a, []Result{{Opponent: b, Score: 1, Spread: 300}}).Value - a.Value
+	if blowout <= narrow {
+		t.Errorf("A blowout (%f) should gain more than a narrow win (%f)", blowout, narrow)
+	}
+	if math.Abs(blowout-32) > 1e-9 {
+		t.Errorf("Spread beyond the scale should double the change to 32, got %f", blowout)
+	}
+}
@@ -0,0 +1,119 @@
+package rating
+
+import (
+	"math"
+	"time"
+)
+
+// Default Glicko-2 settings, on the Glicko scale
+const (
+	DefaultGlickoRating     = 1500
+	DefaultGlickoDeviation  = 350
+	DefaultGlickoVolatility = 0.06
+	DefaultGlickoTau        = 0.5
+)
+
+// glickoScale converts between the Glicko and Glicko-2 scales
+const glickoScale = 173.7178
+
+// convergence is the tolerance of the volatility iteration
+const convergence = 0.000001
+
+// Glicko2 is Mark Glickman's Glicko-2 rating system, treating each game as a
+// rating period
+type Glicko2 struct {
+	Tau float64 // Constrains the change in volatility over time (0.3 to 1.2)
+}
+
+// NewGlicko2 creates a Glicko-2 system with the default tau
+func NewGlicko2() *Glicko2 {
+	return &Glicko2{Tau: DefaultGlickoTau}
+}
+
+// NewRating returns the starting Glicko-2 rating
+func (gl *Glicko2) NewRating(playerID string) Rating {
+	return Rating{
+		PlayerID:   playerID,
+		Value:      DefaultGlickoRating,
+		Deviation:  DefaultGlickoDeviation,
+		Volatility: DefaultGlickoVolatility,
+	}
+}
+
+// Update applies the results of one rating period to a rating
+// Spread does not affect Glicko-2 ratings.
+func (gl *Glicko2) Update(r Rating, results []Result) Rating {
+	mu := (r.Value - DefaultGlickoRating) / glickoScale
+	phi := r.Deviation / glickoScale
+	sigma := r.Volatility
+
+	r.UpdatedAt = time.Now()
+	if len(results) == 0 {
+		r.Deviation = math.Sqrt(phi*phi+sigma*sigma) * glickoScale
+		return r
+	}
+
+	// Estimated variance and improvement from the results
+	invV, sum := 0.0, 0.0
+	for _, res := range results {
+		muJ := (res.Opponent.Value - DefaultGlickoRating) / glickoScale
+		g := glickoG(res.Opponent.Deviation / glickoScale)
+		e := 1 / (1 + math.Exp(-g*(mu-muJ)))
+		invV += g * g * e * (1 - e)
+		sum += g * (res.Score - e)
+	}
+	v := 1 / invV
+	delta := v * sum
+
+	sigma = gl.volatility(phi, sigma, v, delta)
+	phiStar := math.Sqrt(phi*phi + sigma*sigma)
+	phi = 1 / math.Sqrt(1/(phiStar*phiStar)+1/v)
+	mu += phi * phi * sum
+
+	r.Value = mu*glickoScale + DefaultGlickoRating
+	r.Deviation = phi * glickoScale
+	r.Volatility = sigma
+	r.Games++
+	return r
+}
+
+// volatility computes the new volatility using the Illinois algorithm
+func (gl *Glicko2) volatility(phi, sigma, v, delta float64) float64 {
+	tau := gl.Tau
+	a := math.Log(sigma * sigma)
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		d := phi*phi + v + ex
+		return ex*(delta*delta-phi*phi-v-ex)/(2*d*d) - (x-a)/(tau*tau)
+	}
+
+	A := a
+	var B float64
+	if delta*delta > phi*phi+v {
+		B = math.Log(delta*delta - phi*phi - v)
+	} else {
+		k := 1.0
+		for f(a-k*tau) < 0 {
+			k++
+		}
+		B = a - k*tau
+	}
+
+	fA, fB := f(A), f(B)
+	for math.Abs(B-A) > convergence {
+		C := A + (A-B)*fA/(fB-fA)
+		fC := f(C)
+		if fC*fB <= 0 {
+			A, fA = B, fB
+		} else {
+			fA /= 2
+		}
+		B, fB = C, fC
+	}
+	return math.Exp(A / 2)
+}
+
+// glickoG reduces the impact of a result according to the opponent's deviation
+func glickoG(phi float64) float64 {
+	return 1 / math.Sqrt(1+3*phi*phi/(math.Pi*math.Pi))
+}
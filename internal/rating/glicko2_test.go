@@ -0,0 +1,42 @@
+package rating
+
+import (
+	"math"
+	"testing"
+)
+
+// TestGlicko2Example tests the worked example from Glickman's Glicko-2 paper
+func TestGlicko2Example(t *testing.T) {
+	gl := NewGlicko2()
+	player := Rating{PlayerID: "p", Value: 1500, Deviation: 200, Volatility: 0.06}
+	results := []Result{
+		{Opponent: Rating{Value: 1400, Deviation: 30}, Score: 1},
+		{Opponent: Rating{Value: 1550, Deviation: 100}, Score: 0},
+		{Opponent: Rating{Value: 1700, Deviation: 300}, Score: 0},
+	}
+
+	updated := gl.Update(player, results)
+	if math.Abs(updated.Value-1464.06) > 0.01 {
+		t.Errorf("Expected rating 1464.06, got %.2f", updated.Value)
+	}
+	if math.Abs(updated.Deviation-151.52) > 0.01 {
+		t.Errorf("Expected deviation 151.52, got %.2f", updated.Deviation)
+	}
+	if math.Abs(updated.Volatility-0.05999) > 0.00001 {
+		t.Errorf("Expected volatility 0.05999, got %.5f", updated.Volatility)
+	}
+	if updated.Games != 1 {
+		t.Errorf("Expected 1 game, got %d", updated.Games)
+	}
+}
+
+// TestGlicko2Inactive tests that a period without games only widens the deviation
+func TestGlicko2Inactive(t *testing.T) {
+	gl := NewGlicko2()
+	player := Rating{PlayerID: "p", Value: 1700, Deviation: 50, Volatility: 0.06}
+
+	updated := gl.Update(player, nil)
+	if updated.Value != 1700 || updated.Deviation <= 50 || updated.Games != 0 {
+		t.Errorf("Inactivity should only increase the deviation, got %+v", updated)
+	}
+}
@@ -0,0 +1,155 @@
+package rating
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"scrabbled/internal/game"
+)
+
+// ErrAlreadyRecorded is returned when a game is submitted to a ladder twice
+var ErrAlreadyRecorded = errors.New("game has already been recorded")
+
+// Store persists ratings; a server implements it to keep a ladder across restarts
+type Store interface {
+	// Get returns a player's rating, or false if the player is unrated
+	Get(playerID string) (Rating, bool, error)
+	// Put saves a player's rating
+	Put(r Rating) error
+	// All returns every stored rating
+	All() ([]Rating, error)
+}
+
+// MemoryStore is an in-memory Store
+type MemoryStore struct {
+	ratings map[string]Rating
+	mu      sync.RWMutex
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{ratings: make(map[string]Rating)}
+}
+
+// Get returns a player's rating, or false if the player is unrated
+func (s *MemoryStore) Get(playerID string) (Rating, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	r, ok := s.ratings[playerID]
+	return r, ok, nil
+}
+
+// Put saves a player's rating
+func (s *MemoryStore) Put(r Rating) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ratings[r.PlayerID] = r
+	return nil
+}
+
+// All returns every stored rating
+func (s *MemoryStore) All() ([]Rating, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	all := make([]Rating, 0, len(s.ratings))
+	for _, r := range s.ratings {
+		all = append(all, r)
+	}
+	return all, nil
+}
+
+// Ladder applies a rating system to completed games and keeps the results in a Store
+type Ladder struct {
+	system   System
+	store    Store
+	recorded map[string]bool // IDs of games already applied
+	mu       sync.Mutex
+}
+
+// NewLadder creates a ladder using the given system and store
+func NewLadder(system System, store Store) *Ladder {
+	return &Ladder{
+		system:   system,
+		store:    store,
+		recorded: make(map[string]bool),
+	}
+}
+
+// RecordGame updates the ratings of everyone in a finished game and returns
+// their new ratings in seating order
+// Every player is rated against the others' ratings from before the game.
+func (l *Ladder) RecordGame(g *game.Game) ([]Rating, error) {
+	standings, err := g.FinalStandings()
+	if err != nil {
+		return nil, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.recorded[g.ID] {
+		return nil, ErrAlreadyRecorded
+	}
+
+	before := make(map[string]Rating, len(standings))
+	for _, s := range standings {
+		r, err := l.rating(s.PlayerID)
+		if err != nil {
+			return nil, err
+		}
+		before[s.PlayerID] = r
+	}
+
+	outcomes := Outcomes(standings, before)
+	updated := make([]Rating, len(standings))
+	for i, s := range standings {
+		updated[i] = l.system.Update(before[s.PlayerID], outcomes[s.PlayerID])
+	}
+	for _, r := range updated {
+		if err := l.store.Put(r); err != nil {
+			return nil, err
+		}
+	}
+
+	l.recorded[g.ID] = true
+	return updated, nil
+}
+
+// Rating returns a player's rating, or the starting rating if they are unrated
+func (l *Ladder) Rating(playerID string) (Rating, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.rating(playerID)
+}
+
+// rating loads a rating from the store; callers must hold the lock
+func (l *Ladder) rating(playerID string) (Rating, error) {
+	r, ok, err := l.store.Get(playerID)
+	if err != nil {
+		return Rating{}, err
+	}
+	if !ok {
+		return l.system.NewRating(playerID), nil
+	}
+	return r, nil
+}
+
+// Standings returns every rated player, highest rating first
+func (l *Ladder) Standings() ([]Rating, error) {
+	all, err := l.store.All()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Value != all[j].Value {
+			return all[i].Value > all[j].Value
+		}
+		return all[i].PlayerID < all[j].PlayerID
+	})
+	return all, nil
+}
@@ -0,0 +1,70 @@
+package rating
+
+import (
+	"errors"
+	"testing"
+
+	"scrabbled/internal/game"
+)
+
+// TestLadderRecordGame tests updating and storing ratings after games
+func TestLadderRecordGame(t *testing.T) {
+	store := NewMemoryStore()
+	ladder := NewLadder(NewElo(), store)
+
+	updated, err := ladder.RecordGame(finishedGame(t, "g1", 420, 380))
+	if err != nil {
+		t.Fatalf("RecordGame failed: %v", err)
+	}
+	if len(updated) != 2 || updated[0].Value != 1516 || updated[1].Value != 1484 {
+		t.Errorf("Unexpected ratings: %+v", updated)
+	}
+
+	if stored, ok, _ := store.Get("p1"); !ok || stored.Value != 1516 {
+		t.Errorf("Ratings should be saved to the store, got %+v", stored)
+	}
+
+	if _, err := ladder.RecordGame(finishedGame(t, "g1", 420, 380)); !errors.Is(err, ErrAlreadyRecorded) {
+		t.Errorf("Recording the same game twice should fail, got %v", err)
+	}
+
+	// The loser wins the rematch and recovers most of the points
+	ladder.RecordGame(finishedGame(t, "g2", 300, 450))
+	standings, _ := ladder.Standings()
+	if len(standings) != 2 || standings[0].PlayerID != "p2" || standings[0].Games != 2 {
+		t.Errorf("p2 should top the ladder after two games, got %+v", standings)
+	}
+
+	if r, _ := ladder.Rating("newcomer"); r.Value != DefaultEloRating || r.Games != 0 {
+		t.Errorf("Unrated players should get the starting rating, got %+v", r)
+	}
+}
+
+// TestLadderUnfinishedGame tests that games in progress cannot be rated
+func TestLadderUnfinishedGame(t *testing.T) {
+	ladder := NewLadder(NewGlicko2(), NewMemoryStore())
+
+	g, _ := game.NewGame("live", []*game.Player{game.NewPlayer("a", "A"), game.NewPlayer("b", "B")})
+	g.StartGame()
+	if _, err := ladder.RecordGame(g); !errors.Is(err, game.ErrGameNotFinished) {
+		t.Errorf("Should return ErrGameNotFinished, got %v", err)
+	}
+}
+
+// TestLadderGlicko2 tests a multiplayer game under Glicko-2
+func TestLadderGlicko2(t *testing.T) {
+	ladder := NewLadder(NewGlicko2(), NewMemoryStore())
+
+	updated, err := ladder.RecordGame(finishedGame(t, "four", 500, 400, 300, 200))
+	if err != nil {
+		t.Fatalf("RecordGame failed: %v", err)
+	}
+	for i := 1; i < len(updated); i++ {
+		if updated[i].Value >= updated[i-1].Value {
+			t.Errorf("Higher finishers should be rated higher: %+v", updated)
+		}
+	}
+	if updated[0].Deviation >= DefaultGlickoDeviation {
+		t.Errorf("Playing should reduce the deviation, got %f", updated[0].Deviation)
+	}
+}
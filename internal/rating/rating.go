@@ -0,0 +1,68 @@
+// Package rating maintains player ratings from completed games using the Elo
+// or Glicko-2 systems
+package rating
+
+import (
+	"time"
+
+	"scrabbled/internal/game"
+)
+
+// Rating is a player's current strength estimate
+type Rating struct {
+	PlayerID   string    `json:"player_id"`
+	Value      float64   `json:"rating"`
+	Deviation  float64   `json:"deviation"`  // Rating deviation (Glicko-2 only)
+	Volatility float64   `json:"volatility"` // Expected fluctuation (Glicko-2 only)
+	Games      int       `json:"games"`      // Rated games played
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Result is one pairwise outcome against an opponent
+type Result struct {
+	Opponent Rating  // Opponent's rating before the game
+	Score    float64 // 1 for a win, 0.5 for a tie, 0 for a loss
+	Spread   int     // Points scored minus the opponent's points
+}
+
+// System is a rating algorithm
+type System interface {
+	// NewRating returns the starting rating for an unrated player
+	NewRating(playerID string) Rating
+	// Update returns the rating after the given results, all from one game
+	Update(r Rating, results []Result) Rating
+}
+
+// Outcomes converts a game's final standings into pairwise results for each
+// player, using the given ratings for opponents
+// Games with more than two players are scored as a win, tie or loss against
+// every other player. A player who forfeited loses to everyone who did not,
+// whatever the scores, though the spread is kept.
+func Outcomes(standings []game.Standing, ratings map[string]Rating) map[string][]Result {
+	outcomes := make(map[string][]Result, len(standings))
+	for _, me := range standings {
+		for _, them := range standings {
+			if me.PlayerID == them.PlayerID {
+				continue
+			}
+			score := 0.5
+			switch {
+			case me.Forfeited != them.Forfeited:
+				score = 0
+				if them.Forfeited {
+					score = 1
+				}
+			case me.Score > them.Score:
+				score = 1
+			case me.Score < them.Score:
+				score = 0
+			}
+			outcomes[me.PlayerID] = append(outcomes[me.PlayerID], Result{
+				Opponent: ratings[them.PlayerID],
+				Score:    score,
+				Spread:   me.Score - them.Score,
+			})
+		}
+	}
+	return outcomes
+}
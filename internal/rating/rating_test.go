@@ -0,0 +1,64 @@
+package rating
+
+import (
+	"testing"
+
+	"scrabbled/internal/game"
+)
+
+// finishedGame creates a finished game with the given final scores for players p1..pN
+func finishedGame(t *testing.T, id string, scores ...int) *game.Game {
+	t.Helper()
+
+	players := make([]*game.Player, len(scores))
+	for i := range scores {
+		id := string(rune('1' + i))
+		players[i] = game.NewPlayer("p"+id, "Player "+id)
+	}
+	g, err := game.NewGame(id, players)
+	if err != nil {
+		t.Fatalf("NewGame failed: %v", err)
+	}
+	if err := g.StartGame(); err != nil {
+		t.Fatalf("StartGame failed: %v", err)
+	}
+	for i, s := range scores {
+		g.Players[i].Score = s
+	}
+	g.EndGame()
+	return g
+}
+
+// TestOutcomes tests converting standings into pairwise results
+func TestOutcomes(t *testing.T) {
+	standings := []game.Standing{{PlayerID: "a", Score: 400}, {PlayerID: "b", Score: 350}, {PlayerID: "c", Score: 400}}
+	ratings := map[string]Rating{"a": {PlayerID: "a", Value: 1600}, "b": {PlayerID: "b", Value: 1400}, "c": {PlayerID: "c", Value: 1500}}
+
+	outcomes := Outcomes(standings, ratings)
+	a := outcomes["a"]
+	if len(a) != 2 {
+		t.Fatalf("Each player should have a result against each opponent, got %d", len(a))
+	}
+	if a[0].Opponent.PlayerID != "b" || a[0].Score != 1 || a[0].Spread != 50 {
+		t.Errorf("a should beat b by 50, got %+v", a[0])
+	}
+	if a[1].Score != 0.5 || a[1].Spread != 0 {
+		t.Errorf("a should tie c, got %+v", a[1])
+	}
+	if b := outcomes["b"]; b[0].Score != 0 || b[0].Spread != -50 {
+		t.Errorf("b should lose to a by 50, got %+v", b[0])
+	}
+}
+
+// TestOutcomesForfeit tests that a forfeit loses whatever the scores
+func TestOutcomesForfeit(t *testing.T) {
+	standings := []game.Standing{{PlayerID: "a", Score: 400, Forfeited: true}, {PlayerID: "b", Score: 350}}
+
+	outcomes := Outcomes(standings, map[string]Rating{})
+	if a := outcomes["a"][0]; a.Score != 0 || a.Spread != 50 {
+		t.Errorf("a should lose by forfeit keeping the spread, got %+v", a)
+	}
+	if b := outcomes["b"][0]; b.Score != 1 {
+		t.Errorf("b should win by forfeit, got %+v", b)
+	}
+}
@@ -0,0 +1,137 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+
+	"scrabbled/internal/game"
+)
+
+// Fonts used for PNG text, parsed once
+var (
+	boldFont    = mustParseFont(gobold.TTF)
+	regularFont = mustParseFont(goregular.TTF)
+)
+
+// mustParseFont parses an embedded font, panicking if it is corrupt
+func mustParseFont(ttf []byte) *opentype.Font {
+	f, err := opentype.Parse(ttf)
+	if err != nil {
+		panic(fmt.Sprintf("render: parsing embedded font: %v", err))
+	}
+	return f
+}
+
+// PNG writes the board to w as a PNG image
+func PNG(w io.Writer, b *game.Board, opts Options) error {
+	img, err := Image(b, opts)
+	if err != nil {
+		return err
+	}
+	return png.Encode(w, img)
+}
+
+// Image draws the board to a new in-memory image
+func Image(b *game.Board, opts Options) (*image.RGBA, error) {
+	l, err := newLayout(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	faces, err := newFaces(l.size)
+	if err != nil {
+		return nil, err
+	}
+	defer faces.close()
+
+	img := image.NewRGBA(image.Rect(0, 0, l.width, l.width))
+	draw.Draw(img, img.Bounds(), image.NewUniform(backgroundColor), image.Point{}, draw.Src)
+
+	// Coordinates along all four edges
+	for i := 0; i < boardSize; i++ {
+		center := l.margin + i*l.size + l.size/2
+		for _, y := range []int{l.margin / 2, l.width - l.margin/2} {
+			drawText(img, faces.label, center, y, labelColor, columnLabel(i))
+		}
+		for _, x := range []int{l.margin / 2, l.width - l.margin/2} {
+			drawText(img, faces.label, x, center, labelColor, rowLabel(i))
+		}
+	}
+
+	for _, sq := range l.squares(b, opts.Highlight) {
+		// Squares are inset by a pixel so the background shows through as grid lines
+		cell := image.Rect(sq.x+1, sq.y+1, sq.x+l.size, sq.y+l.size)
+		draw.Draw(img, cell, image.NewUniform(sq.fill), image.Point{}, draw.Src)
+
+		cx, cy := sq.x+l.size/2, sq.y+l.size/2
+		switch {
+		case sq.letter != "":
+			drawText(img, faces.letter, cx, cy, textColor, sq.letter)
+			if sq.points != "" {
+				drawText(img, faces.points, sq.x+l.size*4/5, sq.y+l.size*4/5, textColor, sq.points)
+			}
+		case sq.label != "":
+			fg := textColor
+			if sq.lightOnDark {
+				fg = backgroundColor
+			}
+			drawText(img, faces.label, cx, cy, fg, sq.label)
+		}
+	}
+
+	return img, nil
+}
+
+// faces are the font faces for one square size
+type faces struct {
+	letter, points, label font.Face
+}
+
+// newFaces sizes the fonts to fit squares of the given width
+func newFaces(size int) (*faces, error) {
+	newFace := func(f *opentype.Font, px int) (font.Face, error) {
+		return opentype.NewFace(f, &opentype.FaceOptions{Size: float64(px), DPI: 72, Hinting: font.HintingFull})
+	}
+
+	var fs faces
+	var err error
+	if fs.letter, err = newFace(boldFont, size*3/5); err != nil {
+		return nil, err
+	}
+	if fs.points, err = newFace(regularFont, size*3/10); err != nil {
+		return nil, err
+	}
+	if fs.label, err = newFace(regularFont, size*2/5); err != nil {
+		return nil, err
+	}
+	return &fs, nil
+}
+
+// close releases the font faces
+func (fs *faces) close() {
+	fs.letter.Close()
+	fs.points.Close()
+	fs.label.Close()
+}
+
+// drawText draws text centered on (x, y)
+func drawText(dst draw.Image, face font.Face, x, y int, c color.Color, text string) {
+	d := font.Drawer{Dst: dst, Src: image.NewUniform(c), Face: face}
+	metrics := face.Metrics()
+	width := d.MeasureString(text)
+	d.Dot = fixed.Point26_6{
+		X: fixed.I(x) - width/2,
+		Y: fixed.I(y) + (metrics.Ascent-metrics.Descent)/2,
+	}
+	d.DrawString(text)
+}
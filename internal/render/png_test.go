@@ -0,0 +1,72 @@
+package render
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+
+	"scrabbled/internal/game"
+)
+
+// TestPNG tests that the PNG output decodes to a correctly colored board
+func TestPNG(t *testing.T) {
+	var buf bytes.Buffer
+	opts := Options{SquareSize: 20, Highlight: []game.Position{{Row: 7, Col: 9}}}
+	if err := PNG(&buf, testBoard(t), opts); err != nil {
+		t.Fatalf("PNG failed: %v", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Should produce a valid PNG: %v", err)
+	}
+
+	l, _ := newLayout(opts)
+	if b := img.Bounds(); b.Dx() != l.width || b.Dy() != l.width {
+		t.Fatalf("Should be %dx%d, got %v", l.width, l.width, b)
+	}
+
+	// Sample just inside each square's top-left corner, clear of any text
+	pixel := func(row, col int) [3]uint32 {
+		r, g, b, _ := img.At(l.margin+col*l.size+2, l.margin+row*l.size+2).RGBA()
+		return [3]uint32{r >> 8, g >> 8, b >> 8}
+	}
+	rgb := func(c interface{ RGBA() (r, g, b, a uint32) }) [3]uint32 {
+		r, g, b, _ := c.RGBA()
+		return [3]uint32{r >> 8, g >> 8, b >> 8}
+	}
+
+	if got := pixel(0, 0); got != rgb(premiumColors[game.TripleWordScore]) {
+		t.Errorf("A1 should be red, got %v", got)
+	}
+	if got := pixel(1, 5); got != rgb(premiumColors[game.TripleLetterScore]) {
+		t.Errorf("F2 should be dark blue, got %v", got)
+	}
+	if got := pixel(7, 7); got != rgb(tileColor) {
+		t.Errorf("H8 should be a tile, got %v", got)
+	}
+	if got := pixel(7, 9); got != rgb(highlightColor) {
+		t.Errorf("J8 should be highlighted, got %v", got)
+	}
+}
+
+// TestImageDrawsText tests that letters are drawn onto tiles
+func TestImageDrawsText(t *testing.T) {
+	img, err := Image(testBoard(t), Options{})
+	if err != nil {
+		t.Fatalf("Image failed: %v", err)
+	}
+	l, _ := newLayout(Options{})
+	x0, y0 := l.margin+7*l.size, l.margin+7*l.size
+
+	dark := 0
+	for y := y0; y < y0+l.size; y++ {
+		for x := x0; x < x0+l.size; x++ {
+			if r, _, _, _ := img.At(x, y).RGBA(); r>>8 < 0x80 {
+				dark++
+			}
+		}
+	}
+	if dark == 0 {
+		t.Errorf("Should draw a letter on the H8 tile")
+	}
+}
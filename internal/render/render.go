@@ -0,0 +1,127 @@
+// Package render draws boards as SVG or PNG images for sharing positions
+// outside the game
+package render
+
+import (
+	"fmt"
+	"image/color"
+	"unicode"
+
+	"scrabbled/internal/game"
+)
+
+// DefaultSquareSize is the width of a board square in pixels when none is given
+const DefaultSquareSize = 40
+
+// boardSize is the number of squares along each edge of the board
+const boardSize = 15
+
+// Options controls how a board is drawn
+type Options struct {
+	SquareSize int             // Width of a square in pixels; DefaultSquareSize if zero
+	Highlight  []game.Position // Squares to mark, typically the last move from Board.LastPlayed
+}
+
+// Colors used for the board, premium squares and tiles
+var (
+	backgroundColor = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	gridColor       = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	squareColor     = color.RGBA{0xd8, 0xd3, 0xbb, 0xff}
+	tileColor       = color.RGBA{0xf3, 0xdc, 0xa8, 0xff}
+	highlightColor  = color.RGBA{0xff, 0xc1, 0x07, 0xff}
+	textColor       = color.RGBA{0x21, 0x21, 0x21, 0xff}
+	labelColor      = color.RGBA{0x75, 0x75, 0x75, 0xff}
+
+	premiumColors = map[game.PremiumType]color.RGBA{
+		game.TripleWordScore:   {0xd3, 0x2f, 0x2f, 0xff},
+		game.DoubleWordScore:   {0xf4, 0x8f, 0xb1, 0xff},
+		game.TripleLetterScore: {0x19, 0x76, 0xd2, 0xff},
+		game.DoubleLetterScore: {0x90, 0xca, 0xf9, 0xff},
+	}
+)
+
+// premiumLabels are printed on empty premium squares
+var premiumLabels = map[game.PremiumType]string{
+	game.TripleWordScore:   "TW",
+	game.DoubleWordScore:   "DW",
+	game.TripleLetterScore: "TL",
+	game.DoubleLetterScore: "DL",
+}
+
+// square is everything needed to draw one board square
+type square struct {
+	x, y        int // Top-left corner in pixels
+	fill        color.RGBA
+	letter      string // Tile letter; blanks are lower case
+	points      string // Tile value; empty for blanks
+	label       string // Premium label for empty squares
+	lightOnDark bool   // Label needs light text on a dark premium color
+}
+
+// layout holds the geometry of a drawing
+type layout struct {
+	size   int // Square width
+	margin int // Space left for coordinates on each edge
+	width  int // Width and height of the whole image
+}
+
+// newLayout works out the geometry for the given options
+func newLayout(opts Options) (layout, error) {
+	size := opts.SquareSize
+	if size == 0 {
+		size = DefaultSquareSize
+	}
+	if size < 10 {
+		return layout{}, fmt.Errorf("square size %d is too small", size)
+	}
+	margin := size * 3 / 4
+	return layout{size: size, margin: margin, width: 2*margin + boardSize*size}, nil
+}
+
+// squares lists every square of the board ready for drawing
+func (l layout) squares(b *game.Board, highlight []game.Position) []square {
+	lit := make(map[game.Position]bool, len(highlight))
+	for _, pos := range highlight {
+		lit[pos] = true
+	}
+
+	squares := make([]square, 0, boardSize*boardSize)
+	for row := 0; row < boardSize; row++ {
+		for col := 0; col < boardSize; col++ {
+			pos := game.Position{Row: row, Col: col}
+			sq := square{
+				x:    l.margin + col*l.size,
+				y:    l.margin + row*l.size,
+				fill: squareColor,
+			}
+			if tile := b.GetTile(pos); tile != nil {
+				sq.fill = tileColor
+				if lit[pos] {
+					sq.fill = highlightColor
+				}
+				sq.letter = string(tile.Letter)
+				if tile.IsBlank {
+					sq.letter = string(unicode.ToLower(tile.Letter))
+				} else {
+					sq.points = fmt.Sprint(tile.Points)
+				}
+			} else if premium := b.GetPremiumType(pos); premium != game.Normal {
+				sq.fill = premiumColors[premium]
+				sq.label = premiumLabels[premium]
+				sq.lightOnDark = premium == game.TripleWordScore || premium == game.TripleLetterScore
+			}
+			squares = append(squares, sq)
+		}
+	}
+	return squares
+}
+
+// columnLabel returns the letter naming a board column
+func columnLabel(col int) string {
+	return string(rune('A' + col))
+}
+
+// rowLabel returns the number naming a board row
+func rowLabel(row int) string {
+	return fmt.Sprint(row + 1)
+}
@@ -0,0 +1,60 @@
+package render
+
+import (
+	"testing"
+
+	"scrabbled/internal/game"
+)
+
+// testBoard returns a board holding CAT across H8, with a blank A
+func testBoard(t *testing.T) *game.Board {
+	t.Helper()
+	b := game.NewBoard()
+	tiles := []game.Tile{{Letter: 'C', Points: 3}, {Letter: 'A', IsBlank: true}, {Letter: 'T', Points: 1}}
+	for i, tile := range tiles {
+		if err := b.PlaceTile(tile, game.Position{Row: 7, Col: 7 + i}); err != nil {
+			t.Fatalf("PlaceTile: %v", err)
+		}
+	}
+	return b
+}
+
+// TestNewLayout tests image geometry and size validation
+func TestNewLayout(t *testing.T) {
+	l, err := newLayout(Options{})
+	if err != nil {
+		t.Fatalf("Should accept the default size: %v", err)
+	}
+	if l.size != DefaultSquareSize || l.width != 2*l.margin+15*DefaultSquareSize {
+		t.Errorf("Should use the default square size, got %+v", l)
+	}
+	if _, err := newLayout(Options{SquareSize: 4}); err == nil {
+		t.Errorf("Should reject squares too small to draw")
+	}
+}
+
+// TestSquares tests how tiles, blanks, premiums and highlights are drawn
+func TestSquares(t *testing.T) {
+	l, _ := newLayout(Options{})
+	squares := l.squares(testBoard(t), []game.Position{{Row: 7, Col: 9}})
+	if len(squares) != 225 {
+		t.Fatalf("Should list 225 squares, got %d", len(squares))
+	}
+	at := func(row, col int) square { return squares[row*15+col] }
+
+	if sq := at(0, 0); sq.label != "TW" || sq.fill != premiumColors[game.TripleWordScore] || !sq.lightOnDark {
+		t.Errorf("A1 should be a red triple word square, got %+v", sq)
+	}
+	if sq := at(7, 7); sq.letter != "C" || sq.points != "3" || sq.fill != tileColor || sq.label != "" {
+		t.Errorf("H8 should hold an unhighlighted C worth 3, got %+v", sq)
+	}
+	if sq := at(7, 8); sq.letter != "a" || sq.points != "" {
+		t.Errorf("Blanks should be lower case without points, got %+v", sq)
+	}
+	if sq := at(7, 9); sq.fill != highlightColor {
+		t.Errorf("Highlighted tiles should stand out, got %+v", sq)
+	}
+	if sq := at(0, 1); sq.fill != squareColor || sq.label != "" {
+		t.Errorf("B1 should be a plain square, got %+v", sq)
+	}
+}
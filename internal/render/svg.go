@@ -0,0 +1,74 @@
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"image/color"
+	"io"
+
+	"scrabbled/internal/game"
+)
+
+// SVG writes the board to w as an SVG document
+func SVG(w io.Writer, b *game.Board, opts Options) error {
+	l, err := newLayout(opts)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif">`+"\n",
+		l.width, l.width, l.width, l.width)
+	fmt.Fprintf(bw, `<rect width="%d" height="%d" fill="%s"/>`+"\n", l.width, l.width, hex(backgroundColor))
+
+	// Coordinates along all four edges
+	labelSize := l.size * 2 / 5
+	for i := 0; i < boardSize; i++ {
+		center := l.margin + i*l.size + l.size/2
+		for _, y := range []int{l.margin / 2, l.width - l.margin/2} {
+			svgText(bw, center, y, labelSize, labelColor, false, columnLabel(i))
+		}
+		for _, x := range []int{l.margin / 2, l.width - l.margin/2} {
+			svgText(bw, x, center, labelSize, labelColor, false, rowLabel(i))
+		}
+	}
+
+	for _, sq := range l.squares(b, opts.Highlight) {
+		fmt.Fprintf(bw, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s" stroke="%s"/>`+"\n",
+			sq.x, sq.y, l.size, l.size, hex(sq.fill), hex(gridColor))
+
+		cx, cy := sq.x+l.size/2, sq.y+l.size/2
+		switch {
+		case sq.letter != "":
+			svgText(bw, cx, cy, l.size*3/5, textColor, true, sq.letter)
+			if sq.points != "" {
+				svgText(bw, sq.x+l.size*4/5, sq.y+l.size*4/5, l.size*3/10, textColor, false, sq.points)
+			}
+		case sq.label != "":
+			fg := textColor
+			if sq.lightOnDark {
+				fg = backgroundColor
+			}
+			svgText(bw, cx, cy, labelSize, fg, false, sq.label)
+		}
+	}
+
+	fmt.Fprintln(bw, `</svg>`)
+	return bw.Flush()
+}
+
+// svgText writes a text element centered on (x, y)
+func svgText(w io.Writer, x, y, size int, fill color.RGBA, bold bool, text string) {
+	weight := "normal"
+	if bold {
+		weight = "bold"
+	}
+	fmt.Fprintf(w, `<text x="%d" y="%d" font-size="%d" font-weight="%s" fill="%s" text-anchor="middle" dominant-baseline="central">%s</text>`+"\n",
+		x, y, size, weight, hex(fill), html.EscapeString(text))
+}
+
+// hex formats a color as an SVG hex triplet
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
@@ -0,0 +1,45 @@
+package render
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"scrabbled/internal/game"
+)
+
+// TestSVG tests that the SVG output contains the board and its tiles
+func TestSVG(t *testing.T) {
+	var buf bytes.Buffer
+	err := SVG(&buf, testBoard(t), Options{SquareSize: 30, Highlight: []game.Position{{Row: 7, Col: 7}}})
+	if err != nil {
+		t.Fatalf("SVG failed: %v", err)
+	}
+	out := buf.String()
+
+	if !strings.HasPrefix(out, `<svg xmlns="http://www.w3.org/2000/svg" width="494" height="494"`) {
+		t.Errorf("Should start with a sized svg element, got %q", out[:80])
+	}
+	if !strings.HasSuffix(out, "</svg>\n") {
+		t.Errorf("Should close the svg element")
+	}
+	if n := strings.Count(out, "<rect "); n != 226 {
+		t.Errorf("Should draw a background and 225 squares, got %d rects", n)
+	}
+	for _, want := range []string{">C</text>", ">a</text>", ">T</text>", ">TW</text>", ">H</text>", ">15</text>", hex(highlightColor)} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Should contain %q", want)
+		}
+	}
+}
+
+// TestSVGInvalidOptions tests that bad options are reported
+func TestSVGInvalidOptions(t *testing.T) {
+	var buf bytes.Buffer
+	if err := SVG(&buf, game.NewBoard(), Options{SquareSize: 2}); err == nil {
+		t.Errorf("Should reject a tiny square size")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Should write nothing on error")
+	}
+}
@@ -0,0 +1,134 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+
+	_ "github.com/mattn/go-sqlite3" // SQLite driver
+)
+
+// migrations are applied in order; each entry moves the schema up one version
+var migrations = []string{
+	`CREATE TABLE games (
+		id            TEXT PRIMARY KEY,
+		state         INTEGER NOT NULL,
+		current_turn  INTEGER NOT NULL,
+		snapshot      BLOB NOT NULL,
+		created_at    INTEGER NOT NULL,
+		last_activity INTEGER NOT NULL,
+		expires_at    INTEGER NOT NULL
+	);
+	CREATE INDEX games_state ON games (state, expires_at);
+
+	CREATE TABLE players (
+		game_id   TEXT NOT NULL REFERENCES games (id) ON DELETE CASCADE,
+		seat      INTEGER NOT NULL,
+		player_id TEXT NOT NULL,
+		name      TEXT NOT NULL,
+		score     INTEGER NOT NULL,
+		PRIMARY KEY (game_id, seat)
+	);
+	CREATE INDEX players_player_id ON players (player_id);
+
+	CREATE TABLE moves (
+		game_id   TEXT NOT NULL REFERENCES games (id) ON DELETE CASCADE,
+		seq       INTEGER NOT NULL,
+		player_id TEXT NOT NULL,
+		type      TEXT NOT NULL,
+		word      TEXT NOT NULL,
+		score     INTEGER NOT NULL,
+		total     INTEGER NOT NULL,
+		withdrawn INTEGER NOT NULL,
+		played_at INTEGER NOT NULL,
+		PRIMARY KEY (game_id, seq)
+	);`,
+	`ALTER TABLE games ADD COLUMN revision INTEGER NOT NULL DEFAULT 0;`,
+	`CREATE TABLE events (
+		game_id     TEXT NOT NULL,
+		seq         INTEGER NOT NULL,
+		type        TEXT NOT NULL,
+		recorded_at INTEGER NOT NULL,
+		data        BLOB NOT NULL,
+		PRIMARY KEY (game_id, seq)
+	);`,
+	`CREATE TABLE profiles (
+		player_id    TEXT PRIMARY KEY,
+		avatar_url   TEXT NOT NULL,
+		country      TEXT NOT NULL,
+		dictionaries TEXT NOT NULL,
+		bio          TEXT NOT NULL,
+		updated_at   INTEGER NOT NULL
+	);`,
+	`CREATE TABLE cards (
+		player_id   TEXT NOT NULL,
+		alphagram   TEXT NOT NULL,
+		words       TEXT NOT NULL,
+		box         INTEGER NOT NULL,
+		due_at      INTEGER NOT NULL,
+		correct     INTEGER NOT NULL,
+		incorrect   INTEGER NOT NULL,
+		reviewed_at INTEGER NOT NULL,
+		PRIMARY KEY (player_id, alphagram)
+	);`,
+	`ALTER TABLE moves ADD COLUMN coordinates TEXT NOT NULL DEFAULT '';`,
+}
+
+// OpenSQLite opens the SQLite database at path, creating it if necessary, and
+// brings its schema up to date
+// Use ":memory:" for a private in-memory database.
+func OpenSQLite(path string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite3", path+"?_foreign_keys=on&_busy_timeout=5000")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// SQLite allows a single writer; an in-memory database is also private to
+	// its connection
+	db.SetMaxOpenConns(1)
+
+	if err := migrate(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// SchemaVersion returns the number of migrations applied to the database
+func SchemaVersion(db *sql.DB) (int, error) {
+	var version int
+	if err := db.QueryRow(`PRAGMA user_version`).Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return version, nil
+}
+
+// migrate applies any migrations newer than the database's schema version
+func migrate(db *sql.DB) error {
+	version, err := SchemaVersion(db)
+	if err != nil {
+		return err
+	}
+	if version > len(migrations) {
+		return fmt.Errorf("database schema version %d is newer than supported version %d", version, len(migrations))
+	}
+
+	for i := version; i < len(migrations); i++ {
+		tx, err := db.Begin()
+		if err != nil {
+			return fmt.Errorf("failed to begin migration %d: %w", i+1, err)
+		}
+		if _, err := tx.Exec(migrations[i]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d failed: %w", i+1, err)
+		}
+		// PRAGMA does not accept bound parameters
+		if _, err := tx.Exec(fmt.Sprintf(`PRAGMA user_version = %d`, i+1)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to record migration %d: %w", i+1, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit migration %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
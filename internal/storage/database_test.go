@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// TestOpenSQLiteMigrates tests that opening a database applies the schema once
+func TestOpenSQLiteMigrates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "games.db")
+
+	db, err := OpenSQLite(path)
+	if err != nil {
+		t.Fatalf("OpenSQLite failed: %v", err)
+	}
+	if version, err := SchemaVersion(db); err != nil || version != len(migrations) {
+		t.Errorf("Should apply all migrations, got version %d (%v)", version, err)
+	}
+	if _, err := db.Exec(`INSERT INTO games (id, state, current_turn, snapshot, created_at, last_activity, expires_at)
+		VALUES ('g1', 0, 0, '{}', 0, 0, 0)`); err != nil {
+		t.Errorf("Should create the games table: %v", err)
+	}
+	db.Close()
+
+	// Reopening must not reapply migrations or lose data
+	db, err = OpenSQLite(path)
+	if err != nil {
+		t.Fatalf("Reopening should succeed: %v", err)
+	}
+	defer db.Close()
+
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM games`).Scan(&count); err != nil || count != 1 {
+		t.Errorf("Should keep existing rows on reopen, got %d (%v)", count, err)
+	}
+}
+
+// TestOpenSQLiteNewerSchema tests that unknown future schemas are rejected
+func TestOpenSQLiteNewerSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "games.db")
+	db, err := OpenSQLite(path)
+	if err != nil {
+		t.Fatalf("OpenSQLite failed: %v", err)
+	}
+	db.Exec(`PRAGMA user_version = 99`)
+	db.Close()
+
+	if _, err := OpenSQLite(path); err == nil {
+		t.Errorf("Should reject a database from a newer version")
+	}
+}
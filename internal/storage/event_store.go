@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"scrabbled/internal/game"
+)
+
+// EventStore persists games as append-only event streams
+// AppendEvents fails with ErrVersionConflict unless the first event continues
+// the stored stream exactly, so two servers cannot both extend the same game.
+type EventStore interface {
+	AppendEvents(gameID string, events []game.Event) error
+	LoadEvents(gameID string) ([]game.Event, error)
+}
+
+// ReplayGame rebuilds a game from its stored events, stopping after the first
+// n events (n < 0 replays them all)
+func ReplayGame(store EventStore, gameID string, n int) (*game.Game, error) {
+	events, err := store.LoadEvents(gameID)
+	if err != nil {
+		return nil, err
+	}
+	return game.Replay(events, n)
+}
+
+// AppendEvents adds events to the end of a game's stream in one transaction
+func (s *SQLiteGameStore) AppendEvents(gameID string, events []game.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var next int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM events WHERE game_id = ?`, gameID).Scan(&next); err != nil {
+		return fmt.Errorf("failed to read event stream %s: %w", gameID, err)
+	}
+
+	for i, ev := range events {
+		if ev.Seq != next+i {
+			return ErrVersionConflict
+		}
+		data, err := json.Marshal(ev)
+		if err != nil {
+			return fmt.Errorf("failed to encode event %d: %w", ev.Seq, err)
+		}
+		_, err = tx.Exec(`INSERT INTO events (game_id, seq, type, recorded_at, data) VALUES (?, ?, ?, ?, ?)`,
+			gameID, ev.Seq, ev.Type.String(), ev.Timestamp.UnixNano(), data)
+		if err != nil {
+			return fmt.Errorf("failed to append event %d: %w", ev.Seq, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit events for %s: %w", gameID, err)
+	}
+	return nil
+}
+
+// LoadEvents returns a game's full event stream in order
+func (s *SQLiteGameStore) LoadEvents(gameID string) ([]game.Event, error) {
+	rows, err := s.db.Query(`SELECT data FROM events WHERE game_id = ? ORDER BY seq`, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load events for %s: %w", gameID, err)
+	}
+	defer rows.Close()
+
+	events := []game.Event{}
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to read event: %w", err)
+		}
+		var ev game.Event
+		if err := json.Unmarshal(data, &ev); err != nil {
+			return nil, fmt.Errorf("failed to decode event %d: %w", len(events), err)
+		}
+		events = append(events, ev)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, ErrGameNotFound
+	}
+	return events, nil
+}
@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Compile-time check that SQLiteGameStore implements EventStore
+var _ EventStore = (*SQLiteGameStore)(nil)
+
+// TestAppendAndReplayEvents tests storing an event stream and replaying it
+func TestAppendAndReplayEvents(t *testing.T) {
+	store := newTestStore(t)
+	g := newStartedGame(t, "g1")
+
+	if err := store.AppendEvents("g1", g.Events()); err != nil {
+		t.Fatalf("AppendEvents failed: %v", err)
+	}
+
+	// Later events are appended incrementally
+	stored := g.EventCount()
+	g.PassTurn("bob")
+	if err := store.AppendEvents("g1", g.EventsSince(stored)); err != nil {
+		t.Fatalf("Incremental AppendEvents failed: %v", err)
+	}
+
+	events, err := store.LoadEvents("g1")
+	if err != nil {
+		t.Fatalf("LoadEvents failed: %v", err)
+	}
+	if len(events) != g.EventCount() {
+		t.Fatalf("Should load %d events, got %d", g.EventCount(), len(events))
+	}
+
+	replayed, err := ReplayGame(store, "g1", -1)
+	if err != nil {
+		t.Fatalf("ReplayGame failed: %v", err)
+	}
+	if replayed.CurrentTurn != g.CurrentTurn || len(replayed.History) != 2 {
+		t.Errorf("Replayed game should match, got turn %d and %d moves", replayed.CurrentTurn, len(replayed.History))
+	}
+	for i, p := range g.Players {
+		if !reflect.DeepEqual(replayed.Players[i].Rack, p.Rack) {
+			t.Errorf("Replayed rack for %s should match", p.ID)
+		}
+	}
+
+	// Stop partway through to inspect an earlier position
+	earlier, err := ReplayGame(store, "g1", len(events)-1)
+	if err != nil {
+		t.Fatalf("Partial ReplayGame failed: %v", err)
+	}
+	if len(earlier.History) != 1 {
+		t.Errorf("Partial replay should stop before the last pass, got %d moves", len(earlier.History))
+	}
+}
+
+// TestAppendEventsConflict tests that streams cannot be forked or gapped
+func TestAppendEventsConflict(t *testing.T) {
+	store := newTestStore(t)
+	g := newStartedGame(t, "g1")
+	events := g.Events()
+
+	if err := store.AppendEvents("g1", events[:1]); err != nil {
+		t.Fatalf("AppendEvents failed: %v", err)
+	}
+	if err := store.AppendEvents("g1", events); err != ErrVersionConflict {
+		t.Errorf("Should reject events already stored, got %v", err)
+	}
+	if err := store.AppendEvents("g1", events[2:]); err != ErrVersionConflict {
+		t.Errorf("Should reject a gap in the stream, got %v", err)
+	}
+	if err := store.AppendEvents("g1", events[1:]); err != nil {
+		t.Errorf("Should accept the continuation: %v", err)
+	}
+
+	if _, err := store.LoadEvents("missing"); err != ErrGameNotFound {
+		t.Errorf("Should return ErrGameNotFound for an unknown stream, got %v", err)
+	}
+}
+
+// TestDeleteGameRemovesEvents tests that deleting a game drops its stream
+func TestDeleteGameRemovesEvents(t *testing.T) {
+	store := newTestStore(t)
+	g := newStartedGame(t, "g1")
+	store.SaveGame(g)
+	store.AppendEvents("g1", g.Events())
+
+	if err := store.DeleteGame("g1"); err != nil {
+		t.Fatalf("DeleteGame failed: %v", err)
+	}
+	if _, err := store.LoadEvents("g1"); err != ErrGameNotFound {
+		t.Errorf("Events should be deleted with the game, got %v", err)
+	}
+}
@@ -0,0 +1,425 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"scrabbled/internal/game"
+	"scrabbled/internal/study"
+)
+
+// Errors returned by game stores
+var (
+	ErrGameNotFound    = errors.New("game not found")
+	ErrVersionConflict = errors.New("game was modified by another server")
+)
+
+// GameStore persists games so they survive server restarts
+// SaveGame uses optimistic locking: it fails with ErrVersionConflict if the
+// stored game has changed since this copy was loaded or last saved, and the
+// caller should reload the game and retry.
+type GameStore interface {
+	SaveGame(g *game.Game) error
+	LoadGame(gameID string) (*game.Game, error)
+	DeleteGame(gameID string) error
+	ListActiveGames() ([]GameSummary, error)
+	GetExpiredGames() ([]string, error)
+	UpdateLastActivity(gameID string) error
+	SaveProfile(p Profile) error
+	LoadProfile(playerID string) (Profile, error)
+	SaveCards(playerID string, cards []study.Card) error
+	LoadCards(playerID string) ([]study.Card, error)
+}
+
+// GameSummary describes a stored game without loading it
+type GameSummary struct {
+	ID           string         `json:"id"`
+	State        game.GameState `json:"state"`
+	PlayerIDs    []string       `json:"player_ids"` // In seating order
+	CurrentTurn  int            `json:"current_turn"`
+	LastActivity time.Time      `json:"last_activity"`
+	Profiles     []Profile      `json:"profiles"` // Of the players who have one, in seating order
+}
+
+// savedGame holds the fields of a game snapshot that are indexed in tables
+type savedGame struct {
+	ID           string         `json:"id"`
+	Revision     int64          `json:"revision"`
+	State        game.GameState `json:"state"`
+	CurrentTurn  int            `json:"current_turn"`
+	Players      []*game.Player `json:"players"`
+	History      game.History   `json:"history"`
+	CreatedAt    time.Time      `json:"created_at"`
+	LastActivity time.Time      `json:"last_activity"`
+	ExpiresAt    time.Time      `json:"expires_at"`
+}
+
+// SQLiteGameStore stores games in an SQLite database
+// The full game snapshot is kept alongside player and move tables that can be
+// queried directly.
+type SQLiteGameStore struct {
+	db  *sql.DB
+	now func() time.Time
+}
+
+// NewSQLiteGameStore opens (or creates) the game database at path
+func NewSQLiteGameStore(path string) (*SQLiteGameStore, error) {
+	db, err := OpenSQLite(path)
+	if err != nil {
+		return nil, err
+	}
+	return &SQLiteGameStore{db: db, now: time.Now}, nil
+}
+
+// Close closes the underlying database
+func (s *SQLiteGameStore) Close() error {
+	return s.db.Close()
+}
+
+// Ping checks that the database is reachable
+func (s *SQLiteGameStore) Ping() error {
+	return s.db.Ping()
+}
+
+// SaveGame inserts or replaces a game, its players and its moves in one transaction
+func (s *SQLiteGameStore) SaveGame(g *game.Game) error {
+	base := g.SavedRevision()
+	data, saved, err := snapshotGame(g)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var stored int64
+	err = tx.QueryRow(`SELECT revision FROM games WHERE id = ?`, saved.ID).Scan(&stored)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return fmt.Errorf("failed to read game %s: %w", saved.ID, err)
+	case stored != base:
+		return ErrVersionConflict
+	}
+
+	_, err = tx.Exec(`INSERT INTO games (id, revision, state, current_turn, snapshot, created_at, last_activity, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			revision = excluded.revision,
+			state = excluded.state,
+			current_turn = excluded.current_turn,
+			snapshot = excluded.snapshot,
+			last_activity = excluded.last_activity,
+			expires_at = excluded.expires_at`,
+		saved.ID, saved.Revision, int(saved.State), saved.CurrentTurn, data,
+		saved.CreatedAt.UnixNano(), saved.LastActivity.UnixNano(), saved.ExpiresAt.UnixNano())
+	if err != nil {
+		return fmt.Errorf("failed to save game %s: %w", saved.ID, err)
+	}
+
+	if _, err := tx.Exec(`DELETE FROM players WHERE game_id = ?`, saved.ID); err != nil {
+		return fmt.Errorf("failed to clear players: %w", err)
+	}
+	for seat, p := range saved.Players {
+		_, err := tx.Exec(`INSERT INTO players (game_id, seat, player_id, name, score) VALUES (?, ?, ?, ?, ?)`,
+			saved.ID, seat, p.ID, p.Name, p.Score)
+		if err != nil {
+			return fmt.Errorf("failed to save player %s: %w", p.ID, err)
+		}
+	}
+
+	if _, err := tx.Exec(`DELETE FROM moves WHERE game_id = ?`, saved.ID); err != nil {
+		return fmt.Errorf("failed to clear moves: %w", err)
+	}
+	for seq, m := range saved.History {
+		_, err := tx.Exec(`INSERT INTO moves (game_id, seq, player_id, type, coordinates, word, score, total, withdrawn, played_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			saved.ID, seq, m.PlayerID, m.Type.String(), m.Coordinates(), m.Word, m.Score, m.Total, m.Withdrawn, m.Timestamp.UnixNano())
+		if err != nil {
+			return fmt.Errorf("failed to save move %d: %w", seq, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit game %s: %w", saved.ID, err)
+	}
+	g.MarkSaved(saved.Revision)
+	return nil
+}
+
+// snapshotGame serializes a game and decodes the fields stores index
+func snapshotGame(g *game.Game) ([]byte, savedGame, error) {
+	var saved savedGame
+	data, err := g.Serialize()
+	if err != nil {
+		return nil, saved, fmt.Errorf("failed to serialize game: %w", err)
+	}
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, saved, fmt.Errorf("failed to read game snapshot: %w", err)
+	}
+	return data, saved, nil
+}
+
+// LoadGame restores a stored game
+// The restored game has no dictionary or bots attached.
+func (s *SQLiteGameStore) LoadGame(gameID string) (*game.Game, error) {
+	var data []byte
+	var lastActivity, expiresAt int64
+	err := s.db.QueryRow(`SELECT snapshot, last_activity, expires_at FROM games WHERE id = ?`, gameID).
+		Scan(&data, &lastActivity, &expiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrGameNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load game %s: %w", gameID, err)
+	}
+
+	g, err := game.LoadGame(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Activity may have been recorded since the snapshot was taken
+	g.LastActivity = time.Unix(0, lastActivity)
+	g.ExpiresAt = time.Unix(0, expiresAt)
+	return g, nil
+}
+
+// DeleteGame removes a game with its players, moves and events
+func (s *SQLiteGameStore) DeleteGame(gameID string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM events WHERE game_id = ?`, gameID); err != nil {
+		return fmt.Errorf("failed to delete events for %s: %w", gameID, err)
+	}
+	res, err := tx.Exec(`DELETE FROM games WHERE id = ?`, gameID)
+	if err != nil {
+		return fmt.Errorf("failed to delete game %s: %w", gameID, err)
+	}
+	if err := requireRow(res); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ListActiveGames returns unfinished, unexpired games, most recently active first
+func (s *SQLiteGameStore) ListActiveGames() ([]GameSummary, error) {
+	rows, err := s.db.Query(`SELECT g.id, g.state, g.current_turn, g.last_activity, p.player_id,
+			pr.avatar_url, pr.country, pr.dictionaries, pr.bio, pr.updated_at
+		FROM games g
+		LEFT JOIN players p ON p.game_id = g.id
+		LEFT JOIN profiles pr ON pr.player_id = p.player_id
+		WHERE g.state != ? AND g.expires_at > ?
+		ORDER BY g.last_activity DESC, g.id, p.seat`,
+		int(game.Finished), s.now().UnixNano())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list games: %w", err)
+	}
+	defer rows.Close()
+
+	summaries := []GameSummary{}
+	for rows.Next() {
+		var sum GameSummary
+		var state int
+		var lastActivity int64
+		var playerID, avatarURL, country, dictionaries, bio sql.NullString
+		var updatedAt sql.NullInt64
+		if err := rows.Scan(&sum.ID, &state, &sum.CurrentTurn, &lastActivity, &playerID,
+			&avatarURL, &country, &dictionaries, &bio, &updatedAt); err != nil {
+			return nil, fmt.Errorf("failed to read game: %w", err)
+		}
+
+		if n := len(summaries); n == 0 || summaries[n-1].ID != sum.ID {
+			sum.State = game.GameState(state)
+			sum.LastActivity = time.Unix(0, lastActivity)
+			sum.PlayerIDs = []string{}
+			sum.Profiles = []Profile{}
+			summaries = append(summaries, sum)
+		}
+		if !playerID.Valid {
+			continue
+		}
+		last := &summaries[len(summaries)-1]
+		last.PlayerIDs = append(last.PlayerIDs, playerID.String)
+		if updatedAt.Valid {
+			p, err := scanProfile(playerID.String, avatarURL.String, country.String, dictionaries.String, bio.String, updatedAt.Int64)
+			if err != nil {
+				return nil, err
+			}
+			last.Profiles = append(last.Profiles, p)
+		}
+	}
+	return summaries, rows.Err()
+}
+
+// GetExpiredGames returns the IDs of games whose expiry time has passed
+func (s *SQLiteGameStore) GetExpiredGames() ([]string, error) {
+	rows, err := s.db.Query(`SELECT id FROM games WHERE expires_at <= ? ORDER BY id`, s.now().UnixNano())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find expired games: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to read game ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// UpdateLastActivity records activity on a stored game and extends its expiry
+func (s *SQLiteGameStore) UpdateLastActivity(gameID string) error {
+	now := s.now()
+	res, err := s.db.Exec(`UPDATE games SET last_activity = ?, expires_at = ? WHERE id = ?`,
+		now.UnixNano(), now.Add(game.DefaultGameExpiration).UnixNano(), gameID)
+	if err != nil {
+		return fmt.Errorf("failed to update game %s: %w", gameID, err)
+	}
+	return requireRow(res)
+}
+
+// SaveProfile validates and inserts or replaces a player's profile
+func (s *SQLiteGameStore) SaveProfile(p Profile) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	dictionaries, err := json.Marshal(p.Dictionaries)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`INSERT INTO profiles (player_id, avatar_url, country, dictionaries, bio, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (player_id) DO UPDATE SET
+			avatar_url = excluded.avatar_url,
+			country = excluded.country,
+			dictionaries = excluded.dictionaries,
+			bio = excluded.bio,
+			updated_at = excluded.updated_at`,
+		p.PlayerID, p.AvatarURL, p.Country, dictionaries, p.Bio, s.now().UnixNano())
+	if err != nil {
+		return fmt.Errorf("failed to save profile %s: %w", p.PlayerID, err)
+	}
+	return nil
+}
+
+// LoadProfile returns a player's profile
+func (s *SQLiteGameStore) LoadProfile(playerID string) (Profile, error) {
+	var avatarURL, country, dictionaries, bio string
+	var updatedAt int64
+	err := s.db.QueryRow(`SELECT avatar_url, country, dictionaries, bio, updated_at FROM profiles WHERE player_id = ?`, playerID).
+		Scan(&avatarURL, &country, &dictionaries, &bio, &updatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Profile{}, ErrProfileNotFound
+	}
+	if err != nil {
+		return Profile{}, fmt.Errorf("failed to load profile %s: %w", playerID, err)
+	}
+	return scanProfile(playerID, avatarURL, country, dictionaries, bio, updatedAt)
+}
+
+// SaveCards inserts or replaces cards in a player's cardbox, leaving their
+// other cards alone
+func (s *SQLiteGameStore) SaveCards(playerID string, cards []study.Card) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to save cards of %s: %w", playerID, err)
+	}
+	defer tx.Rollback()
+
+	for _, c := range cards {
+		_, err := tx.Exec(`INSERT INTO cards (player_id, alphagram, words, box, due_at, correct, incorrect, reviewed_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (player_id, alphagram) DO UPDATE SET
+				words = excluded.words,
+				box = excluded.box,
+				due_at = excluded.due_at,
+				correct = excluded.correct,
+				incorrect = excluded.incorrect,
+				reviewed_at = excluded.reviewed_at`,
+			playerID, c.Alphagram, strings.Join(c.Words, " "), c.Box, unixNano(c.Due), c.Correct, c.Incorrect, unixNano(c.ReviewedAt))
+		if err != nil {
+			return fmt.Errorf("failed to save card %s of %s: %w", c.Alphagram, playerID, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to save cards of %s: %w", playerID, err)
+	}
+	return nil
+}
+
+// LoadCards returns every card in a player's cardbox, in alphagram order; a
+// player who has not studied has none
+func (s *SQLiteGameStore) LoadCards(playerID string) ([]study.Card, error) {
+	rows, err := s.db.Query(`SELECT alphagram, words, box, due_at, correct, incorrect, reviewed_at
+		FROM cards WHERE player_id = ? ORDER BY alphagram`, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cards of %s: %w", playerID, err)
+	}
+	defer rows.Close()
+
+	var cards []study.Card
+	for rows.Next() {
+		var c study.Card
+		var words string
+		var due, reviewed int64
+		if err := rows.Scan(&c.Alphagram, &words, &c.Box, &due, &c.Correct, &c.Incorrect, &reviewed); err != nil {
+			return nil, fmt.Errorf("failed to load cards of %s: %w", playerID, err)
+		}
+		c.Words = strings.Fields(words)
+		c.Due, c.ReviewedAt = fromUnixNano(due), fromUnixNano(reviewed)
+		cards = append(cards, c)
+	}
+	return cards, rows.Err()
+}
+
+// unixNano returns t as nanoseconds since the epoch, or 0 for the zero time
+func unixNano(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+// fromUnixNano reverses unixNano
+func fromUnixNano(n int64) time.Time {
+	if n == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, n)
+}
+
+// scanProfile assembles a profile from its columns
+func scanProfile(playerID, avatarURL, country, dictionaries, bio string, updatedAt int64) (Profile, error) {
+	p := Profile{PlayerID: playerID, AvatarURL: avatarURL, Country: country, Bio: bio, UpdatedAt: time.Unix(0, updatedAt)}
+	if err := json.Unmarshal([]byte(dictionaries), &p.Dictionaries); err != nil {
+		return Profile{}, fmt.Errorf("failed to read dictionaries of %s: %w", playerID, err)
+	}
+	return p, nil
+}
+
+// requireRow returns ErrGameNotFound if a statement affected no rows
+func requireRow(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrGameNotFound
+	}
+	return nil
+}
@@ -0,0 +1,303 @@
+package storage
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"scrabbled/internal/game"
+	"scrabbled/internal/study"
+)
+
+// Compile-time check that SQLiteGameStore implements GameStore
+var _ GameStore = (*SQLiteGameStore)(nil)
+
+// newTestStore opens a store in a temporary directory
+func newTestStore(t *testing.T) *SQLiteGameStore {
+	t.Helper()
+	store, err := NewSQLiteGameStore(filepath.Join(t.TempDir(), "games.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteGameStore failed: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+// newStartedGame creates a started two-player game with one pass played
+func newStartedGame(t *testing.T, id string) *game.Game {
+	t.Helper()
+	g, err := game.NewGame(id, []*game.Player{
+		game.NewPlayer("alice", "Alice"),
+		game.NewPlayer("bob", "Bob"),
+	})
+	if err != nil {
+		t.Fatalf("NewGame failed: %v", err)
+	}
+	if err := g.StartGame(); err != nil {
+		t.Fatalf("StartGame failed: %v", err)
+	}
+	if err := g.PassTurn("alice"); err != nil {
+		t.Fatalf("PassTurn failed: %v", err)
+	}
+	return g
+}
+
+// TestSaveAndLoadGame tests that a saved game can be resumed after reopening
+func TestSaveAndLoadGame(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "games.db")
+	store, err := NewSQLiteGameStore(path)
+	if err != nil {
+		t.Fatalf("NewSQLiteGameStore failed: %v", err)
+	}
+	g := newStartedGame(t, "g1")
+	if err := store.SaveGame(g); err != nil {
+		t.Fatalf("SaveGame failed: %v", err)
+	}
+	store.Close()
+
+	// Simulate a server restart
+	store, err = NewSQLiteGameStore(path)
+	if err != nil {
+		t.Fatalf("Reopening failed: %v", err)
+	}
+	defer store.Close()
+
+	loaded, err := store.LoadGame("g1")
+	if err != nil {
+		t.Fatalf("LoadGame failed: %v", err)
+	}
+	if loaded.State != game.InProgress || loaded.CurrentTurn != 1 || len(loaded.History) != 1 {
+		t.Errorf("Should restore game state, got %v turn %d history %d", loaded.State, loaded.CurrentTurn, len(loaded.History))
+	}
+	if !reflect.DeepEqual(loaded.Players[0].Rack, g.Players[0].Rack) {
+		t.Errorf("Should restore racks")
+	}
+	if !reflect.DeepEqual(loaded.TileBag.Tiles(), g.TileBag.Tiles()) {
+		t.Errorf("Should restore the bag")
+	}
+
+	// The resumed game is playable
+	if err := loaded.PassTurn("bob"); err != nil {
+		t.Errorf("Should be able to continue the loaded game: %v", err)
+	}
+}
+
+// TestSaveGameTables tests that players and moves are written to their tables
+func TestSaveGameTables(t *testing.T) {
+	store := newTestStore(t)
+	g := newStartedGame(t, "g1")
+	store.SaveGame(g)
+
+	// Saving again replaces rather than duplicates rows
+	rack, _ := game.ParseRack("CATXYZQ", game.Classic)
+	g.Players[1].Rack = rack
+	move, err := g.ParsePlay("bob", "H7", "CAT")
+	if err != nil {
+		t.Fatalf("ParsePlay failed: %v", err)
+	}
+	if _, err := g.PlayMove(move); err != nil {
+		t.Fatalf("PlayMove failed: %v", err)
+	}
+	if err := store.SaveGame(g); err != nil {
+		t.Fatalf("Second SaveGame failed: %v", err)
+	}
+
+	var players, moves int
+	store.db.QueryRow(`SELECT COUNT(*) FROM players WHERE game_id = 'g1'`).Scan(&players)
+	store.db.QueryRow(`SELECT COUNT(*) FROM moves WHERE game_id = 'g1'`).Scan(&moves)
+	if players != 2 || moves != 2 {
+		t.Errorf("Should store 2 players and 2 moves, got %d and %d", players, moves)
+	}
+
+	var moveType, playerID, coords string
+	store.db.QueryRow(`SELECT type, player_id, coordinates FROM moves WHERE game_id = 'g1' AND seq = 1`).Scan(&moveType, &playerID, &coords)
+	if moveType != "PLACE_TILES" || playerID != "bob" || coords != "H7" {
+		t.Errorf("Should record move details, got %s at %q by %s", moveType, coords, playerID)
+	}
+	store.db.QueryRow(`SELECT coordinates FROM moves WHERE game_id = 'g1' AND seq = 0`).Scan(&coords)
+	if coords != "" {
+		t.Errorf("Should record no coordinates for a pass, got %q", coords)
+	}
+}
+
+// TestLoadGameNotFound tests loading and deleting games that do not exist
+func TestLoadGameNotFound(t *testing.T) {
+	store := newTestStore(t)
+
+	if _, err := store.LoadGame("missing"); err != ErrGameNotFound {
+		t.Errorf("Should return ErrGameNotFound, got %v", err)
+	}
+	if err := store.DeleteGame("missing"); err != ErrGameNotFound {
+		t.Errorf("Should return ErrGameNotFound on delete, got %v", err)
+	}
+	if err := store.UpdateLastActivity("missing"); err != ErrGameNotFound {
+		t.Errorf("Should return ErrGameNotFound on activity update, got %v", err)
+	}
+}
+
+// TestLoadCorruptedGame tests that an unreadable snapshot is reported
+func TestLoadCorruptedGame(t *testing.T) {
+	store := newTestStore(t)
+	store.SaveGame(newStartedGame(t, "g1"))
+	store.db.Exec(`UPDATE games SET snapshot = 'not json' WHERE id = 'g1'`)
+
+	if _, err := store.LoadGame("g1"); err == nil {
+		t.Errorf("Should fail to load a corrupted snapshot")
+	}
+}
+
+// TestDeleteGame tests that deleting a game removes its rows
+func TestDeleteGame(t *testing.T) {
+	store := newTestStore(t)
+	store.SaveGame(newStartedGame(t, "g1"))
+
+	if err := store.DeleteGame("g1"); err != nil {
+		t.Fatalf("DeleteGame failed: %v", err)
+	}
+	if _, err := store.LoadGame("g1"); err != ErrGameNotFound {
+		t.Errorf("Deleted game should not load, got %v", err)
+	}
+
+	var rows int
+	store.db.QueryRow(`SELECT (SELECT COUNT(*) FROM players) + (SELECT COUNT(*) FROM moves)`).Scan(&rows)
+	if rows != 0 {
+		t.Errorf("Should cascade to players and moves, %d rows left", rows)
+	}
+}
+
+// TestListActiveGames tests that finished and expired games are excluded
+func TestListActiveGames(t *testing.T) {
+	store := newTestStore(t)
+
+	store.SaveGame(newStartedGame(t, "active"))
+	finished := newStartedGame(t, "finished")
+	finished.EndGame()
+	store.SaveGame(finished)
+	expired := newStartedGame(t, "expired")
+	expired.ExpiresAt = time.Now().Add(-time.Hour)
+	store.SaveGame(expired)
+
+	games, err := store.ListActiveGames()
+	if err != nil {
+		t.Fatalf("ListActiveGames failed: %v", err)
+	}
+	if len(games) != 1 || games[0].ID != "active" {
+		t.Fatalf("Should list only the active game, got %+v", games)
+	}
+	if !reflect.DeepEqual(games[0].PlayerIDs, []string{"alice", "bob"}) || games[0].CurrentTurn != 1 {
+		t.Errorf("Should summarize players and turn, got %+v", games[0])
+	}
+}
+
+// TestExpiredGamesAndActivity tests expiry detection and activity updates
+func TestExpiredGamesAndActivity(t *testing.T) {
+	store := newTestStore(t)
+	store.SaveGame(newStartedGame(t, "g1"))
+	store.SaveGame(newStartedGame(t, "g2"))
+
+	now := time.Now().Add(game.DefaultGameExpiration + time.Hour)
+	store.now = func() time.Time { return now }
+
+	ids, err := store.GetExpiredGames()
+	if err != nil {
+		t.Fatalf("GetExpiredGames failed: %v", err)
+	}
+	if !reflect.DeepEqual(ids, []string{"g1", "g2"}) {
+		t.Errorf("Should report both games expired, got %v", ids)
+	}
+
+	if err := store.UpdateLastActivity("g1"); err != nil {
+		t.Fatalf("UpdateLastActivity failed: %v", err)
+	}
+	if ids, _ := store.GetExpiredGames(); !reflect.DeepEqual(ids, []string{"g2"}) {
+		t.Errorf("Activity should extend expiry, got %v", ids)
+	}
+
+	g, _ := store.LoadGame("g1")
+	if !g.LastActivity.Equal(now) {
+		t.Errorf("Loaded game should carry the recorded activity, got %v", g.LastActivity)
+	}
+}
+
+// TestSaveGameVersionConflict tests that a save from a stale copy is rejected
+func TestSaveGameVersionConflict(t *testing.T) {
+	store := newTestStore(t)
+	if err := store.SaveGame(newStartedGame(t, "g1")); err != nil {
+		t.Fatalf("SaveGame failed: %v", err)
+	}
+
+	// Two servers load the same game and both move
+	first, _ := store.LoadGame("g1")
+	second, _ := store.LoadGame("g1")
+	first.PassTurn("bob")
+	second.PassTurn("bob")
+
+	if err := store.SaveGame(first); err != nil {
+		t.Fatalf("First save should succeed: %v", err)
+	}
+	if err := store.SaveGame(second); err != ErrVersionConflict {
+		t.Errorf("Stale save should conflict, got %v", err)
+	}
+
+	// Reloading picks up the winning move and saves cleanly
+	reloaded, _ := store.LoadGame("g1")
+	if len(reloaded.History) != 2 {
+		t.Errorf("Stored game should keep the first save, got %d moves", len(reloaded.History))
+	}
+	reloaded.PassTurn("alice")
+	if err := store.SaveGame(reloaded); err != nil {
+		t.Errorf("Save after reload should succeed: %v", err)
+	}
+}
+
+// TestProfiles tests storing player profiles in SQLite
+func TestProfiles(t *testing.T) {
+	testStoreProfiles(t, newTestStore(t))
+}
+
+// TestCards tests storing cardbox progress in SQLite
+func TestCards(t *testing.T) {
+	testStoreCards(t, newTestStore(t))
+}
+
+// testStoreCards tests saving, updating and loading a player's cards in a store
+func testStoreCards(t *testing.T, store GameStore) {
+	t.Helper()
+	if cards, err := store.LoadCards("alice"); err != nil || len(cards) != 0 {
+		t.Errorf("Should have no cards before studying, got %v (%v)", cards, err)
+	}
+
+	now := time.Unix(1000, 0)
+	cb := study.NewCardbox("alice")
+	if err := store.SaveCards("alice", cb.Add(&study.List{Items: []study.Item{
+		{Alphagram: "AERT", Words: []string{"RATE", "TEAR"}},
+		{Alphagram: "ACT", Words: []string{"CAT"}},
+	}}, now)); err != nil {
+		t.Fatalf("SaveCards failed: %v", err)
+	}
+	reviewed, _ := cb.Review("AERT", true, now)
+	if err := store.SaveCards("alice", []study.Card{reviewed}); err != nil {
+		t.Fatalf("SaveCards failed: %v", err)
+	}
+	store.SaveCards("bob", []study.Card{{Alphagram: "DGO", Words: []string{"DOG", "GOD"}, Due: now}})
+
+	loaded, err := store.LoadCards("alice")
+	if err != nil {
+		t.Fatalf("LoadCards failed: %v", err)
+	}
+	expected := cb.Cards()
+	if len(loaded) != len(expected) {
+		t.Fatalf("Expected %d cards, got %+v", len(expected), loaded)
+	}
+	for i, c := range loaded {
+		e := expected[i]
+		if c.Alphagram != e.Alphagram || !reflect.DeepEqual(c.Words, e.Words) || c.Box != e.Box || c.Correct != e.Correct ||
+			c.Incorrect != e.Incorrect || !c.Due.Equal(e.Due) || !c.ReviewedAt.Equal(e.ReviewedAt) {
+			t.Errorf("Expected %+v, got %+v", e, c)
+		}
+	}
+	if !loaded[0].ReviewedAt.IsZero() {
+		t.Errorf("An unreviewed card should load with a zero review time, got %v", loaded[0].ReviewedAt)
+	}
+}
@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+	"unicode/utf8"
+
+	"scrabbled/internal/dictionary"
+)
+
+// Errors returned for player profiles
+var (
+	ErrProfileNotFound = errors.New("profile not found")
+	ErrInvalidProfile  = errors.New("invalid profile")
+)
+
+// Limits on profile fields
+const (
+	MaxBioLength       = 500  // Characters in a bio
+	MaxAvatarURLLength = 2048 // Bytes in an avatar URL
+)
+
+// Profile is what a player shows others: their avatar, country, favored word
+// lists and a short bio
+// Profiles belong to players rather than games, so they are stored once and
+// attached to every game summary the player appears in.
+type Profile struct {
+	PlayerID     string               `json:"player_id"`
+	AvatarURL    string               `json:"avatar_url,omitempty"`   // http or https image URL
+	Country      string               `json:"country,omitempty"`      // ISO 3166-1 alpha-2 code, such as "GB"
+	Dictionaries []dictionary.Lexicon `json:"dictionaries,omitempty"` // Preferred lexicons, most preferred first
+	Bio          string               `json:"bio,omitempty"`
+	UpdatedAt    time.Time            `json:"updated_at"` // Set by the store when the profile is saved
+}
+
+// Validate checks that every field is well formed, wrapping ErrInvalidProfile
+func (p Profile) Validate() error {
+	if p.PlayerID == "" {
+		return fmt.Errorf("%w: missing player ID", ErrInvalidProfile)
+	}
+	if p.AvatarURL != "" {
+		u, err := url.Parse(p.AvatarURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || len(p.AvatarURL) > MaxAvatarURLLength {
+			return fmt.Errorf("%w: avatar URL %q", ErrInvalidProfile, p.AvatarURL)
+		}
+	}
+	if p.Country != "" && !isCountryCode(p.Country) {
+		return fmt.Errorf("%w: country %q is not an ISO 3166 code", ErrInvalidProfile, p.Country)
+	}
+	for _, lex := range p.Dictionaries {
+		if dictionary.ParseLexicon(string(lex)) != lex {
+			return fmt.Errorf("%w: unknown lexicon %q", ErrInvalidProfile, lex)
+		}
+	}
+	if n := utf8.RuneCountInString(p.Bio); n > MaxBioLength {
+		return fmt.Errorf("%w: bio has %d characters, limit %d", ErrInvalidProfile, n, MaxBioLength)
+	}
+	return nil
+}
+
+// isCountryCode returns true for two upper-case ASCII letters
+func isCountryCode(s string) bool {
+	return len(s) == 2 && s[0] >= 'A' && s[0] <= 'Z' && s[1] >= 'A' && s[1] <= 'Z'
+}
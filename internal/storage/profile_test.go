@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"scrabbled/internal/dictionary"
+)
+
+// TestProfileValidate tests rejecting malformed profile fields
+func TestProfileValidate(t *testing.T) {
+	valid := Profile{
+		PlayerID:     "alice",
+		AvatarURL:    "https://example.com/alice.png",
+		Country:      "GB",
+		Dictionaries: []dictionary.Lexicon{dictionary.Collins, dictionary.NWL},
+		Bio:          "Plays for fun",
+	}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Profile should be valid: %v", err)
+	}
+	if err := (Profile{PlayerID: "bob"}).Validate(); err != nil {
+		t.Errorf("Only the player ID should be required: %v", err)
+	}
+
+	testCases := map[string]func(p *Profile){
+		"no player":       func(p *Profile) { p.PlayerID = "" },
+		"relative avatar": func(p *Profile) { p.AvatarURL = "/alice.png" },
+		"avatar scheme":   func(p *Profile) { p.AvatarURL = "javascript:alert(1)" },
+		"long avatar":     func(p *Profile) { p.AvatarURL = "https://example.com/" + strings.Repeat("a", MaxAvatarURLLength) },
+		"lower country":   func(p *Profile) { p.Country = "gb" },
+		"long country":    func(p *Profile) { p.Country = "GBR" },
+		"dated lexicon":   func(p *Profile) { p.Dictionaries = []dictionary.Lexicon{"CSW21"} },
+		"long bio":        func(p *Profile) { p.Bio = strings.Repeat("é", MaxBioLength+1) },
+	}
+	for name, change := range testCases {
+		p := valid
+		change(&p)
+		if err := p.Validate(); !errors.Is(err, ErrInvalidProfile) {
+			t.Errorf("%s: Expected ErrInvalidProfile, got %v", name, err)
+		}
+	}
+}
+
+// testStoreProfiles tests saving, loading and listing profiles in a store
+func testStoreProfiles(t *testing.T, store GameStore) {
+	t.Helper()
+	if _, err := store.LoadProfile("alice"); !errors.Is(err, ErrProfileNotFound) {
+		t.Errorf("Should return ErrProfileNotFound, got %v", err)
+	}
+	if err := store.SaveProfile(Profile{PlayerID: "alice", Country: "gb"}); !errors.Is(err, ErrInvalidProfile) {
+		t.Errorf("Should reject an invalid profile, got %v", err)
+	}
+
+	alice := Profile{PlayerID: "alice", Country: "GB", Dictionaries: []dictionary.Lexicon{dictionary.Collins}, Bio: "First"}
+	if err := store.SaveProfile(alice); err != nil {
+		t.Fatalf("SaveProfile failed: %v", err)
+	}
+	alice.Bio = "Updated"
+	if err := store.SaveProfile(alice); err != nil {
+		t.Fatalf("SaveProfile failed: %v", err)
+	}
+	loaded, err := store.LoadProfile("alice")
+	if err != nil {
+		t.Fatalf("LoadProfile failed: %v", err)
+	}
+	if loaded.UpdatedAt.IsZero() {
+		t.Errorf("The store should set the update time")
+	}
+	loaded.UpdatedAt = alice.UpdatedAt
+	if !reflect.DeepEqual(loaded, alice) {
+		t.Errorf("Expected %+v, got %+v", alice, loaded)
+	}
+
+	// Listings carry the profiles of the players who have one
+	if err := store.SaveGame(newStartedGame(t, "g1")); err != nil {
+		t.Fatalf("SaveGame failed: %v", err)
+	}
+	games, err := store.ListActiveGames()
+	if err != nil {
+		t.Fatalf("ListActiveGames failed: %v", err)
+	}
+	if len(games) != 1 || len(games[0].Profiles) != 1 || games[0].Profiles[0].Bio != "Updated" {
+		t.Errorf("Expected alice's profile in the listing, got %+v", games)
+	}
+}
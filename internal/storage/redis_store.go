@@ -0,0 +1,392 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"scrabbled/internal/game"
+	"scrabbled/internal/study"
+)
+
+// DefaultRedisPrefix namespaces the keys written by RedisGameStore
+const DefaultRedisPrefix = "scrabbled"
+
+// RedisGameStore stores games in Redis so several server instances can share
+// one pool of games
+// Each game is a hash holding its snapshot, revision and index fields. Saves
+// are WATCH/MULTI transactions, so a write based on a stale copy fails with
+// ErrVersionConflict instead of overwriting another server's move.
+type RedisGameStore struct {
+	client redis.UniversalClient
+	prefix string
+	now    func() time.Time
+}
+
+// NewRedisGameStore creates a store using client; an empty prefix uses DefaultRedisPrefix
+func NewRedisGameStore(client redis.UniversalClient, prefix string) *RedisGameStore {
+	if prefix == "" {
+		prefix = DefaultRedisPrefix
+	}
+	return &RedisGameStore{client: client, prefix: prefix, now: time.Now}
+}
+
+// gameKey returns the key of the hash holding a game
+func (s *RedisGameStore) gameKey(gameID string) string {
+	return s.prefix + ":game:" + gameID
+}
+
+// activeKey returns the key of the set of unfinished game IDs
+func (s *RedisGameStore) activeKey() string {
+	return s.prefix + ":games:active"
+}
+
+// expiryKey returns the key of the sorted set of game IDs scored by expiry time
+func (s *RedisGameStore) expiryKey() string {
+	return s.prefix + ":games:expiry"
+}
+
+// profileKey returns the key of the JSON-encoded profile of a player
+func (s *RedisGameStore) profileKey(playerID string) string {
+	return s.prefix + ":profile:" + playerID
+}
+
+// cardsKey returns the key of the hash of a player's JSON-encoded cards by alphagram
+func (s *RedisGameStore) cardsKey(playerID string) string {
+	return s.prefix + ":cards:" + playerID
+}
+
+// Ping checks that Redis is reachable
+func (s *RedisGameStore) Ping() error {
+	return s.client.Ping(context.Background()).Err()
+}
+
+// SaveGame writes a game if the stored copy has not changed since it was loaded
+func (s *RedisGameStore) SaveGame(g *game.Game) error {
+	ctx := context.Background()
+	base := g.SavedRevision()
+	data, saved, err := snapshotGame(g)
+	if err != nil {
+		return err
+	}
+
+	playerIDs := make([]string, len(saved.Players))
+	for i, p := range saved.Players {
+		playerIDs[i] = p.ID
+	}
+	players, err := json.Marshal(playerIDs)
+	if err != nil {
+		return err
+	}
+
+	key := s.gameKey(saved.ID)
+	err = s.client.Watch(ctx, func(tx *redis.Tx) error {
+		stored, err := tx.HGet(ctx, key, "revision").Int64()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return err
+		}
+		if err == nil && stored != base {
+			return ErrVersionConflict
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.HSet(ctx, key,
+				"snapshot", data,
+				"revision", saved.Revision,
+				"state", int(saved.State),
+				"current_turn", saved.CurrentTurn,
+				"players", players,
+				"last_activity", saved.LastActivity.UnixNano(),
+				"expires_at", saved.ExpiresAt.UnixNano(),
+			)
+			pipe.ZAdd(ctx, s.expiryKey(), redis.Z{Score: float64(saved.ExpiresAt.UnixMilli()), Member: saved.ID})
+			if saved.State == game.Finished {
+				pipe.SRem(ctx, s.activeKey(), saved.ID)
+			} else {
+				pipe.SAdd(ctx, s.activeKey(), saved.ID)
+			}
+			return nil
+		})
+		return err
+	}, key)
+
+	if errors.Is(err, redis.TxFailedErr) {
+		return ErrVersionConflict
+	}
+	if err != nil {
+		if errors.Is(err, ErrVersionConflict) {
+			return err
+		}
+		return fmt.Errorf("failed to save game %s: %w", saved.ID, err)
+	}
+	g.MarkSaved(saved.Revision)
+	return nil
+}
+
+// LoadGame restores a stored game
+// The restored game has no dictionary or bots attached.
+func (s *RedisGameStore) LoadGame(gameID string) (*game.Game, error) {
+	vals, err := s.client.HMGet(context.Background(), s.gameKey(gameID), "snapshot", "last_activity", "expires_at").Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load game %s: %w", gameID, err)
+	}
+	data, ok := vals[0].(string)
+	if !ok {
+		return nil, ErrGameNotFound
+	}
+
+	g, err := game.LoadGame([]byte(data))
+	if err != nil {
+		return nil, err
+	}
+
+	// Activity may have been recorded since the snapshot was taken
+	g.LastActivity = parseUnixNano(vals[1])
+	g.ExpiresAt = parseUnixNano(vals[2])
+	return g, nil
+}
+
+// DeleteGame removes a game and its index entries
+func (s *RedisGameStore) DeleteGame(gameID string) error {
+	ctx := context.Background()
+	var deleted *redis.IntCmd
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		deleted = pipe.Del(ctx, s.gameKey(gameID))
+		pipe.SRem(ctx, s.activeKey(), gameID)
+		pipe.ZRem(ctx, s.expiryKey(), gameID)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete game %s: %w", gameID, err)
+	}
+	if deleted.Val() == 0 {
+		return ErrGameNotFound
+	}
+	return nil
+}
+
+// ListActiveGames returns unfinished, unexpired games, most recently active first
+func (s *RedisGameStore) ListActiveGames() ([]GameSummary, error) {
+	ctx := context.Background()
+	ids, err := s.client.SMembers(ctx, s.activeKey()).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list games: %w", err)
+	}
+
+	cmds := make([]*redis.SliceCmd, len(ids))
+	_, err = s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, id := range ids {
+			cmds[i] = pipe.HMGet(ctx, s.gameKey(id), "state", "current_turn", "players", "last_activity", "expires_at")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read games: %w", err)
+	}
+
+	now := s.now()
+	summaries := []GameSummary{}
+	for i, cmd := range cmds {
+		vals := cmd.Val()
+		if vals[0] == nil || !parseUnixNano(vals[4]).After(now) {
+			continue // Deleted since listing, or expired
+		}
+		sum := GameSummary{
+			ID:           ids[i],
+			State:        game.GameState(parseInt(vals[0])),
+			CurrentTurn:  parseInt(vals[1]),
+			PlayerIDs:    []string{},
+			LastActivity: parseUnixNano(vals[3]),
+			Profiles:     []Profile{},
+		}
+		if players, ok := vals[2].(string); ok {
+			if err := json.Unmarshal([]byte(players), &sum.PlayerIDs); err != nil {
+				return nil, fmt.Errorf("failed to read players of game %s: %w", ids[i], err)
+			}
+		}
+		summaries = append(summaries, sum)
+	}
+
+	if err := s.attachProfiles(ctx, summaries); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		if !summaries[i].LastActivity.Equal(summaries[j].LastActivity) {
+			return summaries[i].LastActivity.After(summaries[j].LastActivity)
+		}
+		return summaries[i].ID < summaries[j].ID
+	})
+	return summaries, nil
+}
+
+// GetExpiredGames returns the IDs of games whose expiry time has passed
+func (s *RedisGameStore) GetExpiredGames() ([]string, error) {
+	ids, err := s.client.ZRangeByScore(context.Background(), s.expiryKey(), &redis.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatInt(s.now().UnixMilli(), 10),
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find expired games: %w", err)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// UpdateLastActivity records activity on a stored game and extends its expiry
+// The revision is unchanged, so this never conflicts with a concurrent save.
+func (s *RedisGameStore) UpdateLastActivity(gameID string) error {
+	ctx := context.Background()
+	key := s.gameKey(gameID)
+	now := s.now()
+	expires := now.Add(game.DefaultGameExpiration)
+
+	err := s.client.Watch(ctx, func(tx *redis.Tx) error {
+		n, err := tx.Exists(ctx, key).Result()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return ErrGameNotFound
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.HSet(ctx, key, "last_activity", now.UnixNano(), "expires_at", expires.UnixNano())
+			pipe.ZAdd(ctx, s.expiryKey(), redis.Z{Score: float64(expires.UnixMilli()), Member: gameID})
+			return nil
+		})
+		return err
+	}, key)
+
+	if errors.Is(err, ErrGameNotFound) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update game %s: %w", gameID, err)
+	}
+	return nil
+}
+
+// SaveProfile validates and writes a player's profile
+func (s *RedisGameStore) SaveProfile(p Profile) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	p.UpdatedAt = s.now()
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	if err := s.client.Set(context.Background(), s.profileKey(p.PlayerID), data, 0).Err(); err != nil {
+		return fmt.Errorf("failed to save profile %s: %w", p.PlayerID, err)
+	}
+	return nil
+}
+
+// LoadProfile returns a player's profile
+func (s *RedisGameStore) LoadProfile(playerID string) (Profile, error) {
+	data, err := s.client.Get(context.Background(), s.profileKey(playerID)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return Profile{}, ErrProfileNotFound
+	}
+	if err != nil {
+		return Profile{}, fmt.Errorf("failed to load profile %s: %w", playerID, err)
+	}
+	var p Profile
+	if err := json.Unmarshal(data, &p); err != nil {
+		return Profile{}, fmt.Errorf("failed to read profile %s: %w", playerID, err)
+	}
+	return p, nil
+}
+
+// SaveCards inserts or replaces cards in a player's cardbox, leaving their
+// other cards alone
+func (s *RedisGameStore) SaveCards(playerID string, cards []study.Card) error {
+	if len(cards) == 0 {
+		return nil
+	}
+	fields := make(map[string]interface{}, len(cards))
+	for _, c := range cards {
+		data, err := json.Marshal(c)
+		if err != nil {
+			return err
+		}
+		fields[c.Alphagram] = data
+	}
+	if err := s.client.HSet(context.Background(), s.cardsKey(playerID), fields).Err(); err != nil {
+		return fmt.Errorf("failed to save cards of %s: %w", playerID, err)
+	}
+	return nil
+}
+
+// LoadCards returns every card in a player's cardbox, in alphagram order; a
+// player who has not studied has none
+func (s *RedisGameStore) LoadCards(playerID string) ([]study.Card, error) {
+	vals, err := s.client.HGetAll(context.Background(), s.cardsKey(playerID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cards of %s: %w", playerID, err)
+	}
+	var cards []study.Card
+	for alphagram, data := range vals {
+		var c study.Card
+		if err := json.Unmarshal([]byte(data), &c); err != nil {
+			return nil, fmt.Errorf("failed to read card %s of %s: %w", alphagram, playerID, err)
+		}
+		cards = append(cards, c)
+	}
+	sort.Slice(cards, func(i, j int) bool { return cards[i].Alphagram < cards[j].Alphagram })
+	return cards, nil
+}
+
+// attachProfiles fills in the profiles of the players in each summary with
+// one read
+func (s *RedisGameStore) attachProfiles(ctx context.Context, summaries []GameSummary) error {
+	var keys []string
+	for _, sum := range summaries {
+		for _, id := range sum.PlayerIDs {
+			keys = append(keys, s.profileKey(id))
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	vals, err := s.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return fmt.Errorf("failed to read profiles: %w", err)
+	}
+
+	for i := range summaries {
+		for _, id := range summaries[i].PlayerIDs {
+			data, ok := vals[0].(string)
+			vals = vals[1:]
+			if !ok {
+				continue
+			}
+			var p Profile
+			if err := json.Unmarshal([]byte(data), &p); err != nil {
+				return fmt.Errorf("failed to read profile %s: %w", id, err)
+			}
+			summaries[i].Profiles = append(summaries[i].Profiles, p)
+		}
+	}
+	return nil
+}
+
+// parseInt reads an integer hash field, returning 0 if it is missing
+func parseInt(v interface{}) int {
+	s, _ := v.(string)
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// parseUnixNano reads a hash field holding nanoseconds since the epoch
+func parseUnixNano(v interface{}) time.Time {
+	s, _ := v.(string)
+	n, _ := strconv.ParseInt(s, 10, 64)
+	return time.Unix(0, n)
+}
@@ -0,0 +1,150 @@
+package storage
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+
+	"scrabbled/internal/game"
+)
+
+// Compile-time check that RedisGameStore implements GameStore
+var _ GameStore = (*RedisGameStore)(nil)
+
+// newTestRedisStore creates a store backed by an in-process Redis server
+func newTestRedisStore(t *testing.T) *RedisGameStore {
+	t.Helper()
+	srv := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewRedisGameStore(client, "")
+}
+
+// TestRedisSaveAndLoadGame tests that games round trip through Redis
+func TestRedisSaveAndLoadGame(t *testing.T) {
+	store := newTestRedisStore(t)
+	if err := store.Ping(); err != nil {
+		t.Fatalf("Ping failed: %v", err)
+	}
+
+	g := newStartedGame(t, "g1")
+	if err := store.SaveGame(g); err != nil {
+		t.Fatalf("SaveGame failed: %v", err)
+	}
+
+	loaded, err := store.LoadGame("g1")
+	if err != nil {
+		t.Fatalf("LoadGame failed: %v", err)
+	}
+	if loaded.CurrentTurn != 1 || len(loaded.History) != 1 || !reflect.DeepEqual(loaded.Players[1].Rack, g.Players[1].Rack) {
+		t.Errorf("Should restore the game state")
+	}
+	if loaded.Revision != g.Revision || loaded.SavedRevision() != g.Revision {
+		t.Errorf("Should restore revision %d, got %d", g.Revision, loaded.Revision)
+	}
+
+	if _, err := store.LoadGame("missing"); err != ErrGameNotFound {
+		t.Errorf("Should return ErrGameNotFound, got %v", err)
+	}
+}
+
+// TestRedisVersionConflict tests optimistic locking between two servers
+func TestRedisVersionConflict(t *testing.T) {
+	store := newTestRedisStore(t)
+	store.SaveGame(newStartedGame(t, "g1"))
+
+	first, _ := store.LoadGame("g1")
+	second, _ := store.LoadGame("g1")
+	first.PassTurn("bob")
+	second.PassTurn("bob")
+
+	if err := store.SaveGame(first); err != nil {
+		t.Fatalf("First save should succeed: %v", err)
+	}
+	if err := store.SaveGame(second); err != ErrVersionConflict {
+		t.Errorf("Stale save should conflict, got %v", err)
+	}
+
+	// The winner can keep saving its own changes
+	first.PassTurn("alice")
+	if err := store.SaveGame(first); err != nil {
+		t.Errorf("Repeated saves from the same copy should succeed: %v", err)
+	}
+}
+
+// TestRedisListActiveGames tests listing unfinished, unexpired games
+func TestRedisListActiveGames(t *testing.T) {
+	store := newTestRedisStore(t)
+
+	store.SaveGame(newStartedGame(t, "active"))
+	finished := newStartedGame(t, "finished")
+	finished.EndGame()
+	store.SaveGame(finished)
+	expired := newStartedGame(t, "expired")
+	expired.ExpiresAt = time.Now().Add(-time.Hour)
+	store.SaveGame(expired)
+
+	games, err := store.ListActiveGames()
+	if err != nil {
+		t.Fatalf("ListActiveGames failed: %v", err)
+	}
+	if len(games) != 1 || games[0].ID != "active" {
+		t.Fatalf("Should list only the active game, got %+v", games)
+	}
+	if !reflect.DeepEqual(games[0].PlayerIDs, []string{"alice", "bob"}) || games[0].State != game.InProgress {
+		t.Errorf("Should summarize the game, got %+v", games[0])
+	}
+
+	if ids, _ := store.GetExpiredGames(); !reflect.DeepEqual(ids, []string{"expired"}) {
+		t.Errorf("Should report the expired game, got %v", ids)
+	}
+}
+
+// TestRedisActivityAndDelete tests activity updates and deletion
+func TestRedisActivityAndDelete(t *testing.T) {
+	store := newTestRedisStore(t)
+	store.SaveGame(newStartedGame(t, "g1"))
+	store.SaveGame(newStartedGame(t, "g2"))
+
+	now := time.Now().Add(game.DefaultGameExpiration + time.Hour)
+	store.now = func() time.Time { return now }
+
+	if ids, _ := store.GetExpiredGames(); !reflect.DeepEqual(ids, []string{"g1", "g2"}) {
+		t.Errorf("Should report both games expired, got %v", ids)
+	}
+	if err := store.UpdateLastActivity("g1"); err != nil {
+		t.Fatalf("UpdateLastActivity failed: %v", err)
+	}
+	if ids, _ := store.GetExpiredGames(); !reflect.DeepEqual(ids, []string{"g2"}) {
+		t.Errorf("Activity should extend expiry, got %v", ids)
+	}
+	if g, _ := store.LoadGame("g1"); !g.LastActivity.Equal(now) {
+		t.Errorf("Loaded game should carry the recorded activity, got %v", g.LastActivity)
+	}
+
+	if err := store.DeleteGame("g2"); err != nil {
+		t.Fatalf("DeleteGame failed: %v", err)
+	}
+	if err := store.DeleteGame("g2"); err != ErrGameNotFound {
+		t.Errorf("Deleting twice should return ErrGameNotFound, got %v", err)
+	}
+	if ids, _ := store.GetExpiredGames(); len(ids) != 0 {
+		t.Errorf("Deleted game should leave the expiry index, got %v", ids)
+	}
+	if err := store.UpdateLastActivity("g2"); err != ErrGameNotFound {
+		t.Errorf("Should not update a deleted game, got %v", err)
+	}
+}
+
+// TestRedisProfiles tests storing player profiles in Redis
+func TestRedisProfiles(t *testing.T) {
+	testStoreProfiles(t, newTestRedisStore(t))
+}
+
+// TestRedisCards tests storing cardbox progress in Redis
+func TestRedisCards(t *testing.T) {
+	testStoreCards(t, newTestRedisStore(t))
+}
@@ -0,0 +1,164 @@
+package study
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"scrabbled/internal/dictionary"
+)
+
+// ErrUnknownCard is returned when reviewing an alphagram that is not in the cardbox
+var ErrUnknownCard = errors.New("alphagram is not in the cardbox")
+
+// BoxIntervals gives how long a card waits after a review before it is due
+// again, by the box the review leaves it in
+// Cards start in box 0, move up a box when answered correctly and go back to
+// box 0 when missed; box 0 cards are due at once, so a missed card comes back
+// in the same session.
+var BoxIntervals = []time.Duration{
+	0,
+	24 * time.Hour,
+	3 * 24 * time.Hour,
+	7 * 24 * time.Hour,
+	14 * 24 * time.Hour,
+	30 * 24 * time.Hour,
+	90 * 24 * time.Hour,
+}
+
+// Card is a player's progress on one alphagram
+type Card struct {
+	Alphagram  string    `json:"alphagram"`
+	Words      []string  `json:"words"` // The answers, alphabetical
+	Box        int       `json:"box"`
+	Due        time.Time `json:"due"`
+	Correct    int       `json:"correct"`     // Reviews answered correctly
+	Incorrect  int       `json:"incorrect"`   // Reviews missed
+	ReviewedAt time.Time `json:"reviewed_at"` // Zero until first reviewed
+}
+
+// Check returns true if the guesses are exactly the card's words, in any
+// order and case
+func (c Card) Check(guesses []string) bool {
+	found := make(map[string]bool, len(guesses))
+	for _, g := range guesses {
+		found[dictionary.Normalize(g)] = true
+	}
+	if len(found) != len(c.Words) {
+		return false
+	}
+	for _, w := range c.Words {
+		if !found[w] {
+			return false
+		}
+	}
+	return true
+}
+
+// Cardbox is a player's Leitner cardbox of alphagrams
+// A cardbox is not safe for concurrent use.
+type Cardbox struct {
+	PlayerID string
+	cards    map[string]*Card
+}
+
+// NewCardbox creates a player's cardbox holding cards, such as those loaded
+// from a store
+// Cards beyond the last box, saved under a longer schedule, go in the last box.
+func NewCardbox(playerID string, cards ...Card) *Cardbox {
+	cb := &Cardbox{PlayerID: playerID, cards: make(map[string]*Card, len(cards))}
+	for _, c := range cards {
+		c.Box = max(0, min(c.Box, len(BoxIntervals)-1))
+		cb.cards[c.Alphagram] = &c
+	}
+	return cb
+}
+
+// Add puts the list's alphagrams that are not already in the cardbox into
+// box 0, due now, and returns the cards added
+func (cb *Cardbox) Add(list *List, now time.Time) []Card {
+	var added []Card
+	for _, item := range list.Items {
+		if _, ok := cb.cards[item.Alphagram]; ok {
+			continue
+		}
+		c := &Card{Alphagram: item.Alphagram, Words: append([]string(nil), item.Words...), Due: now}
+		cb.cards[c.Alphagram] = c
+		added = append(added, *c)
+	}
+	return added
+}
+
+// Card returns the card for an alphagram
+func (cb *Cardbox) Card(alphagram string) (Card, bool) {
+	c, ok := cb.cards[dictionary.Alphagram(alphagram)]
+	if !ok {
+		return Card{}, false
+	}
+	return *c, true
+}
+
+// Cards returns every card in alphagram order
+func (cb *Cardbox) Cards() []Card {
+	cards := make([]Card, 0, len(cb.cards))
+	for _, c := range cb.cards {
+		cards = append(cards, *c)
+	}
+	sort.Slice(cards, func(i, j int) bool { return cards[i].Alphagram < cards[j].Alphagram })
+	return cards
+}
+
+// Due returns up to limit cards due for review at now, most overdue first,
+// with ties going to the lower box; a limit of 0 returns every due card
+func (cb *Cardbox) Due(now time.Time, limit int) []Card {
+	var due []Card
+	for _, c := range cb.cards {
+		if !c.Due.After(now) {
+			due = append(due, *c)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		switch {
+		case !due[i].Due.Equal(due[j].Due):
+			return due[i].Due.Before(due[j].Due)
+		case due[i].Box != due[j].Box:
+			return due[i].Box < due[j].Box
+		default:
+			return due[i].Alphagram < due[j].Alphagram
+		}
+	})
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+	return due
+}
+
+// Review records an answer to a card, moving it up a box if correct or back
+// to box 0 if not, and schedules its next review by BoxIntervals
+func (cb *Cardbox) Review(alphagram string, correct bool, now time.Time) (Card, error) {
+	c, ok := cb.cards[dictionary.Alphagram(alphagram)]
+	if !ok {
+		return Card{}, ErrUnknownCard
+	}
+	if correct {
+		c.Correct++
+		if c.Box < len(BoxIntervals)-1 {
+			c.Box++
+		}
+	} else {
+		c.Incorrect++
+		c.Box = 0
+	}
+	c.ReviewedAt = now
+	c.Due = now.Add(BoxIntervals[c.Box])
+	return *c, nil
+}
+
+// Boxes returns the number of cards in each box
+func (cb *Cardbox) Boxes() []int {
+	counts := make([]int, len(BoxIntervals))
+	for _, c := range cb.cards {
+		counts[c.Box]++
+	}
+	return counts
+}
@@ -0,0 +1,122 @@
+package study
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// day is the interval of box 1
+const day = 24 * time.Hour
+
+// TestCardCheck tests marking a guess at a card's words
+func TestCardCheck(t *testing.T) {
+	c := Card{Alphagram: "AERT", Words: []string{"RATE", "TEAR"}}
+	cases := []struct {
+		guesses  []string
+		expected bool
+	}{
+		{[]string{"tear", "RATE"}, true},
+		{[]string{"RATE", "TEAR", "RATE"}, true},
+		{[]string{"RATE"}, false},
+		{[]string{"RATE", "TARE"}, false},
+		{[]string{"RATE", "TEAR", "TARE"}, false},
+		{nil, false},
+	}
+	for _, tc := range cases {
+		if got := c.Check(tc.guesses); got != tc.expected {
+			t.Errorf("Check(%v): expected %v, got %v", tc.guesses, tc.expected, got)
+		}
+	}
+}
+
+// TestCardboxAdd tests adding a study list without resetting cards already held
+func TestCardboxAdd(t *testing.T) {
+	now := time.Unix(1000, 0)
+	cb := NewCardbox("p1", Card{Alphagram: "AERT", Words: []string{"RATE", "TEAR"}, Box: 3, Due: now.Add(day)})
+
+	added := cb.Add(testList, now)
+	if len(added) != 1 || added[0].Alphagram != "ACT" || added[0].Box != 0 || !added[0].Due.Equal(now) {
+		t.Errorf("Expected only ACT to be added, due now, got %+v", added)
+	}
+	if c, _ := cb.Card("TEAR"); c.Box != 3 {
+		t.Errorf("Expected the card already held to keep its box, got %+v", c)
+	}
+	if got := cb.Boxes(); got[0] != 1 || got[3] != 1 {
+		t.Errorf("Expected a card in boxes 0 and 3, got %v", got)
+	}
+	if cards := cb.Cards(); len(cards) != 2 || cards[0].Alphagram != "ACT" {
+		t.Errorf("Expected both cards in alphagram order, got %+v", cards)
+	}
+	if _, ok := cb.Card("DOG"); ok {
+		t.Errorf("Expected no card for DOG")
+	}
+
+	far := NewCardbox("p1", Card{Alphagram: "ACT", Box: 99})
+	if c, _ := far.Card("ACT"); c.Box != len(BoxIntervals)-1 {
+		t.Errorf("Expected a card beyond the last box to go in it, got box %d", c.Box)
+	}
+}
+
+// TestCardboxReview tests moving cards between boxes and scheduling reviews
+func TestCardboxReview(t *testing.T) {
+	now := time.Unix(1000, 0)
+	cb := NewCardbox("p1")
+	cb.Add(testList, now)
+
+	c, err := cb.Review("tear", true, now)
+	if err != nil {
+		t.Fatalf("Failed to review: %v", err)
+	}
+	expected := Card{Alphagram: "AERT", Words: []string{"RATE", "TEAR"}, Box: 1, Due: now.Add(day), Correct: 1, ReviewedAt: now}
+	if !reflect.DeepEqual(c, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, c)
+	}
+
+	later := now.Add(day)
+	c, _ = cb.Review("AERT", true, later)
+	if c.Box != 2 || !c.Due.Equal(later.Add(BoxIntervals[2])) {
+		t.Errorf("Expected box 2 due in three days, got %+v", c)
+	}
+	c, _ = cb.Review("AERT", false, later)
+	if c.Box != 0 || c.Incorrect != 1 || !c.Due.Equal(later) {
+		t.Errorf("Expected a miss to go back to box 0, due at once, got %+v", c)
+	}
+
+	for range len(BoxIntervals) + 1 {
+		c, _ = cb.Review("ACT", true, now)
+	}
+	if c.Box != len(BoxIntervals)-1 {
+		t.Errorf("Expected cards to stop in the last box, got %d", c.Box)
+	}
+
+	if _, err := cb.Review("DOG", true, now); !errors.Is(err, ErrUnknownCard) {
+		t.Errorf("Expected ErrUnknownCard, got %v", err)
+	}
+}
+
+// TestCardboxDue tests listing due cards, most overdue first
+func TestCardboxDue(t *testing.T) {
+	now := time.Unix(1000, 0)
+	cb := NewCardbox("p1",
+		Card{Alphagram: "ACT", Box: 2, Due: now.Add(-day)},
+		Card{Alphagram: "AERT", Box: 1, Due: now.Add(-day)},
+		Card{Alphagram: "DGO", Box: 0, Due: now},
+		Card{Alphagram: "EINST", Box: 0, Due: now.Add(time.Second)},
+	)
+
+	var alphagrams []string
+	for _, c := range cb.Due(now, 0) {
+		alphagrams = append(alphagrams, c.Alphagram)
+	}
+	if expected := []string{"AERT", "ACT", "DGO"}; !reflect.DeepEqual(alphagrams, expected) {
+		t.Errorf("Expected %v, got %v", expected, alphagrams)
+	}
+	if due := cb.Due(now, 1); len(due) != 1 || due[0].Alphagram != "AERT" {
+		t.Errorf("Expected the limit to keep the most overdue card, got %+v", due)
+	}
+	if due := cb.Due(now.Add(-2*day), 0); len(due) != 0 {
+		t.Errorf("Expected nothing due earlier, got %+v", due)
+	}
+}
@@ -0,0 +1,77 @@
+package study
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Format is a study list export format
+type Format int
+
+const (
+	CSV  Format = iota // One row per alphagram, for flashcard apps such as Anki
+	JSON               // The list as indented JSON
+)
+
+// String returns a string representation of the format
+func (f Format) String() string {
+	switch f {
+	case CSV:
+		return "CSV"
+	case JSON:
+		return "JSON"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// ParseFormat returns the format with the given name, ignoring case
+func ParseFormat(name string) (Format, error) {
+	for f := CSV; f <= JSON; f++ {
+		if strings.EqualFold(strings.TrimSpace(name), f.String()) {
+			return f, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown study list format %q", name)
+}
+
+// Write exports the list to w in the given format
+func (l *List) Write(w io.Writer, f Format) error {
+	switch f {
+	case CSV:
+		return l.WriteCSV(w)
+	case JSON:
+		return l.WriteJSON(w)
+	default:
+		return fmt.Errorf("unknown study list format %d", f)
+	}
+}
+
+// WriteCSV writes the list with a header row and one row per alphagram: the
+// alphagram as the card's front, its words space-separated as the back, then
+// its combinations and rank
+func (l *List) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"alphagram", "words", "combinations", "rank"})
+	for _, item := range l.Items {
+		cw.Write([]string{
+			item.Alphagram,
+			strings.Join(item.Words, " "),
+			strconv.FormatInt(item.Combinations, 10),
+			strconv.Itoa(item.Rank),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON writes the list to w as indented JSON
+func (l *List) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(l)
+}
@@ -0,0 +1,59 @@
+package study
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// testList is a two-alphagram study list
+var testList = &List{Name: "test", Items: []Item{
+	{Alphagram: "AERT", Words: []string{"RATE", "TEAR"}, Combinations: 3888, Rank: 1},
+	{Alphagram: "ACT", Words: []string{"CAT"}, Combinations: 108, Rank: 2},
+}}
+
+// TestWriteCSV tests exporting a list as flashcard rows
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testList.Write(&buf, CSV); err != nil {
+		t.Fatalf("Failed to write CSV: %v", err)
+	}
+	expected := "alphagram,words,combinations,rank\nAERT,RATE TEAR,3888,1\nACT,CAT,108,2\n"
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+// TestWriteJSON tests that an exported list decodes to the same list
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testList.Write(&buf, JSON); err != nil {
+		t.Fatalf("Failed to write JSON: %v", err)
+	}
+	var decoded List
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Failed to decode: %v", err)
+	}
+	if !reflect.DeepEqual(&decoded, testList) {
+		t.Errorf("Expected %+v, got %+v", testList, decoded)
+	}
+}
+
+// TestParseFormat tests looking formats up by name
+func TestParseFormat(t *testing.T) {
+	for _, f := range []Format{CSV, JSON} {
+		if got, err := ParseFormat(" " + f.String() + " "); err != nil || got != f {
+			t.Errorf("ParseFormat(%s): got %v, %v", f, got, err)
+		}
+	}
+	if f, err := ParseFormat("csv"); err != nil || f != CSV {
+		t.Errorf("Expected lower case names to parse, got %v, %v", f, err)
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Errorf("Expected an unknown format to be rejected")
+	}
+	if Format(9).String() != "UNKNOWN" {
+		t.Errorf("Expected UNKNOWN for an invalid format")
+	}
+}
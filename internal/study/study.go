@@ -0,0 +1,121 @@
+// Package study builds word study lists grouped by alphagram, such as the most
+// probable seven-letter bingos or every four-letter word with a J, Q, X or Z,
+// for export to flashcard apps
+package study
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"scrabbled/internal/dictionary"
+	"scrabbled/internal/game"
+)
+
+// ErrNoWords is returned when no words in the lexicon match the criteria
+var ErrNoWords = errors.New("no words match the study criteria")
+
+// Criteria selects the alphagrams in a study list
+type Criteria struct {
+	Length     int    // Letters in each word
+	MaxRank    int    // Most probable alphagrams to keep, by probability rank (0 keeps all)
+	Containing string // Keep alphagrams holding at least one of these letters (empty keeps all)
+}
+
+// Item is one alphagram of a study list with the words it spells
+type Item struct {
+	Alphagram    string   `json:"alphagram"`
+	Words        []string `json:"words"`        // Alphabetical
+	Combinations int64    `json:"combinations"` // Ways to draw the letters from a standard bag, without blanks
+	Rank         int      `json:"rank"`         // Probability rank among alphagrams of its length, from 1
+}
+
+// List is a study list, most probable alphagram first
+type List struct {
+	Name  string `json:"name"`
+	Items []Item `json:"items"`
+}
+
+// Words returns the number of words in the list
+func (l *List) Words() int {
+	n := 0
+	for _, item := range l.Items {
+		n += len(item.Words)
+	}
+	return n
+}
+
+// Generate builds a study list of the lexicon's words matching the criteria
+// Alphagrams are ranked by probability among every alphagram of the same
+// length in the lexicon before Containing is applied, so the top 2000 sevens
+// are the same alphagrams however the list is narrowed. Ties are ranked
+// alphabetically.
+func Generate(name string, lexicon dictionary.Lister, c Criteria) (*List, error) {
+	if c.Length < 2 || c.MaxRank < 0 {
+		return nil, fmt.Errorf("invalid study criteria %+v", c)
+	}
+	containing := dictionary.Normalize(c.Containing)
+
+	groups := make(map[string][]string)
+	for _, w := range lexicon.Words() {
+		if len([]rune(w)) == c.Length {
+			key := dictionary.Alphagram(w)
+			groups[key] = append(groups[key], w)
+		}
+	}
+
+	items := make([]Item, 0, len(groups))
+	for key, words := range groups {
+		sort.Strings(words)
+		items = append(items, Item{Alphagram: key, Words: words, Combinations: Combinations(key)})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Combinations != items[j].Combinations {
+			return items[i].Combinations > items[j].Combinations
+		}
+		return items[i].Alphagram < items[j].Alphagram
+	})
+
+	list := &List{Name: name}
+	for i, item := range items {
+		item.Rank = i + 1
+		if c.MaxRank > 0 && item.Rank > c.MaxRank {
+			break
+		}
+		if containing == "" || strings.ContainsAny(item.Alphagram, containing) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	if len(list.Items) == 0 {
+		return nil, ErrNoWords
+	}
+	return list, nil
+}
+
+// Combinations returns the number of ways to draw the letters from a
+// standard tile bag without using blanks, the usual measure of how likely a
+// rack is; 0 if the bag cannot hold them
+func Combinations(letters string) int64 {
+	counts := make(map[rune]int)
+	for _, r := range dictionary.Normalize(letters) {
+		counts[r]++
+	}
+	ways := int64(1)
+	for letter, k := range counts {
+		ways *= choose(game.GetTileQuantity(letter), k)
+	}
+	return ways
+}
+
+// choose returns the binomial coefficient n choose k
+func choose(n, k int) int64 {
+	if k < 0 || k > n {
+		return 0
+	}
+	c := int64(1)
+	for i := 1; i <= k; i++ {
+		c = c * int64(n-k+i) / int64(i)
+	}
+	return c
+}
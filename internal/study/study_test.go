@@ -0,0 +1,89 @@
+package study
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"scrabbled/internal/dictionary"
+)
+
+// testLexicon builds a word list from words
+func testLexicon(words ...string) *dictionary.WordList {
+	wl := dictionary.NewWordList(dictionary.TWL06)
+	for _, w := range words {
+		wl.AddWord(w)
+	}
+	return wl
+}
+
+// lexicon holds a few sevens and fours, including JQXZ words
+var lexicon = testLexicon(
+	"RETAINS", "RETINAS", "STAINER", "SATIRE", "QUIXOTE",
+	"JAZZ", "QUIZ", "AXES", "RATE", "TEAR", "CAT",
+)
+
+// TestCombinations tests counting the ways to draw letters from a standard bag
+func TestCombinations(t *testing.T) {
+	cases := map[string]int64{
+		"AEINRST": 9 * 12 * 9 * 6 * 6 * 4 * 6,
+		"EE":      66, // 12 choose 2
+		"AJZZ":    0,  // There is one Z
+		"qi":      9,
+	}
+	for letters, expected := range cases {
+		if got := Combinations(letters); got != expected {
+			t.Errorf("Combinations(%s): expected %d, got %d", letters, expected, got)
+		}
+	}
+}
+
+// TestGenerate tests grouping words by alphagram in probability order
+func TestGenerate(t *testing.T) {
+	list, err := Generate("sevens", lexicon, Criteria{Length: 7})
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+	expected := []Item{
+		{Alphagram: "AEINRST", Words: []string{"RETAINS", "RETINAS", "STAINER"}, Combinations: Combinations("AEINRST"), Rank: 1},
+		{Alphagram: "EIOQTUX", Words: []string{"QUIXOTE"}, Combinations: Combinations("EIOQTUX"), Rank: 2},
+	}
+	if !reflect.DeepEqual(list.Items, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, list.Items)
+	}
+	if list.Name != "sevens" || list.Words() != 4 {
+		t.Errorf("Expected the sevens list to hold 4 words, got %q with %d", list.Name, list.Words())
+	}
+
+	top, _ := Generate("top", lexicon, Criteria{Length: 7, MaxRank: 1})
+	if len(top.Items) != 1 || top.Items[0].Alphagram != "AEINRST" {
+		t.Errorf("Expected only the most probable seven, got %+v", top.Items)
+	}
+}
+
+// TestGenerateContaining tests narrowing a list to alphagrams with given letters
+func TestGenerateContaining(t *testing.T) {
+	list, err := Generate("jqxz", lexicon, Criteria{Length: 4, Containing: "jqxz"})
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+	var alphagrams []string
+	for _, item := range list.Items {
+		alphagrams = append(alphagrams, item.Alphagram)
+	}
+	// AERT ranks first among the fours but holds none of the letters
+	expected := []string{"AESX", "IQUZ", "AJZZ"}
+	if !reflect.DeepEqual(alphagrams, expected) {
+		t.Errorf("Expected %v, got %v", expected, alphagrams)
+	}
+	if list.Items[0].Rank != 2 {
+		t.Errorf("Expected ranks to count every four, got %d", list.Items[0].Rank)
+	}
+
+	if _, err := Generate("none", lexicon, Criteria{Length: 4, MaxRank: 1, Containing: "JQXZ"}); !errors.Is(err, ErrNoWords) {
+		t.Errorf("Expected ErrNoWords, got %v", err)
+	}
+	if _, err := Generate("bad", lexicon, Criteria{Length: 1}); err == nil {
+		t.Errorf("Expected a length under 2 to be rejected")
+	}
+}
@@ -0,0 +1,181 @@
+package tournament
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"unicode/utf8"
+)
+
+// Result is the outcome of a finished pairing, from the first player's side
+type Result struct {
+	Round      int      `json:"round"`
+	PlayerID   string   `json:"player_id"`
+	OpponentID string   `json:"opponent_id,omitempty"` // Empty for a bye
+	Wins       int      `json:"wins"`                  // Games the player won
+	Losses     int      `json:"losses"`                // Games the opponent won
+	Spread     int      `json:"spread"`                // Player's cumulative margin over the match
+	Winner     string   `json:"winner,omitempty"`      // Empty for a drawn match
+	Games      []string `json:"games,omitempty"`       // IDs of the match's games
+}
+
+// Standing is a player's record in the tournament
+type Standing struct {
+	Rank     int     `json:"rank"` // Shared by players level on wins and spread
+	PlayerID string  `json:"player_id"`
+	Name     string  `json:"name"`
+	Wins     float64 `json:"wins"` // Matches won, with a draw counting half and a bye as a win
+	Losses   float64 `json:"losses"`
+	Spread   int     `json:"spread"`
+	Byes     int     `json:"byes,omitempty"`
+}
+
+// Report is a tournament's results as written by WriteJSON
+type Report struct {
+	ID        string     `json:"id"`
+	Format    Format     `json:"format"`
+	Rounds    int        `json:"rounds"` // Rounds paired so far
+	Results   []Result   `json:"results"`
+	Standings []Standing `json:"standings"`
+}
+
+// Results returns the outcome of every finished pairing in round order
+func (t *Tournament) Results() []Result {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.results()
+}
+
+// results collects the finished pairings' outcomes; callers must hold the lock
+func (t *Tournament) results() []Result {
+	var results []Result
+	for _, r := range t.rounds {
+		for _, p := range r.Pairings {
+			if res, ok := t.result(p); ok {
+				results = append(results, res)
+			}
+		}
+	}
+	return results
+}
+
+// result scores a pairing, returning false until its match is over
+// A match goes to the player who won more of its games.
+func (t *Tournament) result(p *Pairing) (Result, bool) {
+	res := Result{Round: p.Round, PlayerID: p.PlayerID, OpponentID: p.OpponentID}
+	if p.IsBye() {
+		res.Winner, res.Spread = p.PlayerID, t.config.ByeSpread
+		return res, true
+	}
+	if !p.Series.Over() {
+		return Result{}, false
+	}
+
+	// The first game seats the pairing's players in order
+	standings := p.Series.Standings()
+	res.Wins, res.Losses, res.Spread = standings[0].Wins, standings[1].Wins, standings[0].Spread
+	switch {
+	case res.Wins > res.Losses:
+		res.Winner = p.PlayerID
+	case res.Losses > res.Wins:
+		res.Winner = p.OpponentID
+	}
+	for _, g := range p.Series.Games() {
+		res.Games = append(res.Games, g.ID)
+	}
+	return res, true
+}
+
+// Standings ranks the players by wins, then spread, then seeding
+func (t *Tournament) Standings() []Standing {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.standings()
+}
+
+// standings tallies the finished pairings; callers must hold the lock
+func (t *Tournament) standings() []Standing {
+	standings := make([]Standing, len(t.players))
+	index := make(map[string]*Standing, len(t.players))
+	for i, p := range t.players {
+		standings[i] = Standing{PlayerID: p.ID, Name: p.Name}
+		index[p.ID] = &standings[i]
+	}
+
+	for _, res := range t.results() {
+		player := index[res.PlayerID]
+		player.Spread += res.Spread
+		if res.OpponentID == "" {
+			player.Wins++
+			player.Byes++
+			continue
+		}
+		opponent := index[res.OpponentID]
+		opponent.Spread -= res.Spread
+		switch res.Winner {
+		case res.PlayerID:
+			player.Wins++
+			opponent.Losses++
+		case res.OpponentID:
+			player.Losses++
+			opponent.Wins++
+		default:
+			player.Wins, player.Losses = player.Wins+0.5, player.Losses+0.5
+			opponent.Wins, opponent.Losses = opponent.Wins+0.5, opponent.Losses+0.5
+		}
+	}
+
+	// Seeding order breaks ties, so the sort must be stable
+	sort.SliceStable(standings, func(i, j int) bool {
+		if standings[i].Wins != standings[j].Wins {
+			return standings[i].Wins > standings[j].Wins
+		}
+		return standings[i].Spread > standings[j].Spread
+	})
+	for i := range standings {
+		standings[i].Rank = i + 1
+		if i > 0 && standings[i].Wins == standings[i-1].Wins && standings[i].Spread == standings[i-1].Spread {
+			standings[i].Rank = standings[i-1].Rank
+		}
+	}
+	return standings
+}
+
+// WriteJSON writes the tournament's results and standings to w as indented JSON
+func (t *Tournament) WriteJSON(w io.Writer) error {
+	t.mu.RLock()
+	report := Report{
+		ID:        t.ID,
+		Format:    t.config.Format,
+		Rounds:    len(t.rounds),
+		Results:   t.results(),
+		Standings: t.standings(),
+	}
+	t.mu.RUnlock()
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// WriteStandings writes the standings as a plain-text table, one player per
+// line with their wins, losses and spread
+func (t *Tournament) WriteStandings(w io.Writer) error {
+	standings := t.Standings()
+
+	width := len("Player")
+	for _, s := range standings {
+		width = max(width, utf8.RuneCountInString(s.Name))
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%4s  %-*s %5s %5s %6s\n", "Rank", width, "Player", "W", "L", "Spread")
+	for _, s := range standings {
+		fmt.Fprintf(bw, "%4d  %-*s %5.1f %5.1f %+6d\n", s.Rank, width, s.Name, s.Wins, s.Losses, s.Spread)
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,77 @@
+package tournament
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestStandings tests ranking by wins then spread, with draws and byes
+func TestStandings(t *testing.T) {
+	tour, _ := New("club", entrants(3), DefaultConfig())
+
+	// Round 1: p1 has the bye and p2 draws p3
+	r, err := tour.NextRound()
+	if err != nil {
+		t.Fatalf("NextRound failed: %v", err)
+	}
+	playRound(t, r, map[string]int{"p2": 300, "p3": 300})
+
+	standings := tour.Standings()
+	if standings[0].PlayerID != "p1" || standings[0].Wins != 1 || standings[0].Spread != DefaultByeSpread || standings[0].Byes != 1 {
+		t.Errorf("The bye should count as a win with the bye spread, got %+v", standings[0])
+	}
+	if standings[1].Wins != 0.5 || standings[1].Losses != 0.5 || standings[1].Rank != 2 || standings[2].Rank != 2 {
+		t.Errorf("A draw should count half and share the rank, got %+v", standings[1:])
+	}
+
+	// Round 2: p2 beats p1 by 150 and p3 has the bye, so spread splits p2 and p3
+	r, _ = tour.NextRound()
+	playRound(t, r, map[string]int{"p1": 250, "p2": 400})
+	standings = tour.Standings()
+	if standings[0].PlayerID != "p2" || standings[0].Wins != 1.5 || standings[0].Spread != 150 {
+		t.Errorf("p2 should lead, got %+v", standings[0])
+	}
+	if standings[1].PlayerID != "p3" || standings[1].Wins != 1.5 || standings[1].Rank != 2 {
+		t.Errorf("p3 should be second on spread, got %+v", standings[1])
+	}
+	if standings[2].PlayerID != "p1" || standings[2].Spread != -100 {
+		t.Errorf("p1 should be last on -100, got %+v", standings[2])
+	}
+
+	var buf bytes.Buffer
+	if err := tour.WriteStandings(&buf); err != nil {
+		t.Fatalf("WriteStandings failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 || !strings.Contains(lines[1], "Player 2") || !strings.Contains(lines[1], "+150") {
+		t.Errorf("Unexpected standings table:\n%s", buf.String())
+	}
+}
+
+// TestWriteJSON tests exporting the results and standings
+func TestWriteJSON(t *testing.T) {
+	tour, _ := New("export", entrants(2), DefaultConfig())
+	r, _ := tour.NextRound()
+	playRound(t, r, map[string]int{"p1": 350, "p2": 400})
+
+	var buf bytes.Buffer
+	if err := tour.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+	var report Report
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("Report should be valid JSON: %v", err)
+	}
+	if report.ID != "export" || report.Rounds != 1 || len(report.Results) != 1 || len(report.Standings) != 2 {
+		t.Fatalf("Unexpected report %+v", report)
+	}
+	res := report.Results[0]
+	if res.Winner != "p2" || res.Spread != -50 || len(res.Games) != 1 || res.Games[0] != "export-r1-1-1" {
+		t.Errorf("Unexpected result %+v", res)
+	}
+	if report.Standings[0].PlayerID != "p2" {
+		t.Errorf("p2 should top the standings, got %+v", report.Standings)
+	}
+}
@@ -0,0 +1,94 @@
+package tournament
+
+// maxPairingSteps bounds the search for a round without rematches, which
+// grows quickly in large fields once most pairings have been played
+const maxPairingSteps = 100000
+
+// swissPairs pairs the next Swiss round; callers must hold the lock
+// Players are ranked by their standings so far. With an odd field the
+// lowest-ranked player with the fewest byes sits out. The rest are paired from
+// the top, each with the highest-ranked player they have not yet met; if no
+// such pairing is found, neighbours in the ranking meet. Whoever has moved
+// first in fewer matches moves first.
+func (t *Tournament) swissPairs() [][2]string {
+	met := make(map[[2]string]bool)
+	byes := make(map[string]int)
+	firsts := make(map[string]int)
+	for _, r := range t.rounds {
+		for _, p := range r.Pairings {
+			if p.IsBye() {
+				byes[p.PlayerID]++
+				continue
+			}
+			met[meeting(p.PlayerID, p.OpponentID)] = true
+			firsts[p.PlayerID]++
+		}
+	}
+
+	var ranked []string
+	for _, s := range t.standings() {
+		ranked = append(ranked, s.PlayerID)
+	}
+
+	bye := ""
+	if len(ranked)%2 == 1 {
+		out := len(ranked) - 1
+		for i := out - 1; i >= 0; i-- {
+			if byes[ranked[i]] < byes[ranked[out]] {
+				out = i
+			}
+		}
+		bye = ranked[out]
+		ranked = append(ranked[:out:out], ranked[out+1:]...)
+	}
+
+	steps := 0
+	pairs, ok := pairAvoiding(ranked, met, &steps)
+	if !ok {
+		pairs = nil
+		for i := 0; i+1 < len(ranked); i += 2 {
+			pairs = append(pairs, [2]string{ranked[i], ranked[i+1]})
+		}
+	}
+	for i, pair := range pairs {
+		if firsts[pair[1]] < firsts[pair[0]] {
+			pairs[i] = [2]string{pair[1], pair[0]}
+		}
+	}
+	if bye != "" {
+		pairs = append(pairs, [2]string{bye, ""})
+	}
+	return pairs
+}
+
+// pairAvoiding pairs the ranked players without repeating a meeting, trying
+// the highest-ranked opponents first; it gives up after maxPairingSteps
+func pairAvoiding(ranked []string, met map[[2]string]bool, steps *int) ([][2]string, bool) {
+	if len(ranked) == 0 {
+		return nil, true
+	}
+	first := ranked[0]
+	for i := 1; i < len(ranked); i++ {
+		if *steps++; *steps > maxPairingSteps {
+			return nil, false
+		}
+		if met[meeting(first, ranked[i])] {
+			continue
+		}
+		rest := make([]string, 0, len(ranked)-2)
+		rest = append(rest, ranked[1:i]...)
+		rest = append(rest, ranked[i+1:]...)
+		if pairs, ok := pairAvoiding(rest, met, steps); ok {
+			return append([][2]string{{first, ranked[i]}}, pairs...), true
+		}
+	}
+	return nil, false
+}
+
+// meeting returns a key identifying a pairing of two players in either order
+func meeting(a, b string) [2]string {
+	if b < a {
+		a, b = b, a
+	}
+	return [2]string{a, b}
+}
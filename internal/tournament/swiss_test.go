@@ -0,0 +1,89 @@
+package tournament
+
+import (
+	"testing"
+)
+
+// TestSwissAvoidsRematches tests pairing by record without repeats
+func TestSwissAvoidsRematches(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Format, cfg.Rounds = Swiss, 4
+	tour, err := New("swiss", entrants(5), cfg)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	strength := map[string]int{"p1": 500, "p2": 450, "p3": 400, "p4": 350, "p5": 300}
+
+	met := make(map[[2]string]bool)
+	byes := make(map[string]bool)
+	for i := 0; i < cfg.Rounds; i++ {
+		r, err := tour.NextRound()
+		if err != nil {
+			t.Fatalf("NextRound failed: %v", err)
+		}
+		for _, p := range r.Pairings {
+			if p.IsBye() {
+				if byes[p.PlayerID] {
+					t.Errorf("Round %d: %s should not get a second bye", r.Number, p.PlayerID)
+				}
+				byes[p.PlayerID] = true
+				continue
+			}
+			key := meeting(p.PlayerID, p.OpponentID)
+			if met[key] {
+				t.Errorf("Round %d: %v should not meet again", r.Number, key)
+			}
+			met[key] = true
+		}
+		if last := r.Pairings[len(r.Pairings)-1]; !last.IsBye() {
+			t.Errorf("Round %d: the bye should be listed last", r.Number)
+		}
+		playRound(t, r, strength)
+	}
+
+	// After the first round the two winners meet
+	r2 := tour.Rounds()[1]
+	if top := meeting(r2.Pairings[0].PlayerID, r2.Pairings[0].OpponentID); top != meeting("p1", "p3") && top != meeting("p1", "p2") {
+		t.Errorf("The leaders should meet in round 2, got %v", top)
+	}
+	if !tour.Over() {
+		t.Errorf("The tournament should be over after its rounds")
+	}
+}
+
+// TestSwissRematch tests that a field too small to avoid rematches still pairs
+func TestSwissRematch(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Format, cfg.Rounds = Swiss, 2
+	tour, _ := New("small", entrants(2), cfg)
+
+	first := ""
+	for i := 0; i < 2; i++ {
+		r, err := tour.NextRound()
+		if err != nil {
+			t.Fatalf("NextRound failed: %v", err)
+		}
+		if len(r.Pairings) != 1 {
+			t.Fatalf("Expected one pairing, got %d", len(r.Pairings))
+		}
+		if r.Pairings[0].PlayerID == first {
+			t.Errorf("The first move should alternate between rounds")
+		}
+		first = r.Pairings[0].PlayerID
+		playRound(t, r, nil)
+	}
+}
+
+// TestPairAvoidingGivesUp tests that the search stops once it runs out of steps
+func TestPairAvoidingGivesUp(t *testing.T) {
+	met := map[[2]string]bool{meeting("a", "b"): true}
+	steps := maxPairingSteps
+	if _, ok := pairAvoiding([]string{"a", "c", "b", "d"}, met, &steps); ok {
+		t.Errorf("Should give up without steps left")
+	}
+	steps = 0
+	pairs, ok := pairAvoiding([]string{"a", "b", "c", "d"}, met, &steps)
+	if !ok || pairs[0] != [2]string{"a", "c"} || pairs[1] != [2]string{"b", "d"} {
+		t.Errorf("Should pair a with c to avoid a rematch, got %v", pairs)
+	}
+}
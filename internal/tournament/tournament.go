@@ -0,0 +1,256 @@
+// Package tournament runs round-robin and Swiss tournaments, pairing players
+// into game series each round and ranking them by wins then spread
+package tournament
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"scrabbled/internal/game"
+)
+
+// DefaultByeSpread is the spread credited with a bye under the usual club rules
+const DefaultByeSpread = 50
+
+// Errors returned by tournaments
+var (
+	ErrInvalidConfig   = errors.New("invalid tournament configuration")
+	ErrRoundInProgress = errors.New("round is still in progress")
+	ErrTournamentOver  = errors.New("tournament is over")
+)
+
+// Format selects how players are paired each round
+type Format int
+
+const (
+	RoundRobin Format = iota // Everyone plays everyone once
+	Swiss                    // Players with similar records meet, avoiding rematches
+)
+
+// String returns a string representation of the format
+func (f Format) String() string {
+	switch f {
+	case RoundRobin:
+		return "ROUND_ROBIN"
+	case Swiss:
+		return "SWISS"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// Config describes how a tournament is run
+type Config struct {
+	Format    Format
+	Rounds    int        // Rounds of a Swiss tournament; a round robin has as many as it needs
+	BestOf    int        // Games in each pairing's match (0 plays one)
+	Rules     game.Rules // Rules of every game; team and solo play are not supported
+	ByeSpread int        // Spread credited with a bye
+}
+
+// DefaultConfig returns a single-game round robin under the classic rules
+func DefaultConfig() Config {
+	return Config{Format: RoundRobin, BestOf: 1, Rules: game.DefaultRules(), ByeSpread: DefaultByeSpread}
+}
+
+// Tournament pairs a fixed field of players round by round
+// Each pairing is a game.Series between two players; with an odd number of
+// players one sits out each round with a bye, which counts as a win.
+type Tournament struct {
+	ID      string
+	config  Config
+	players []*game.Player // Entrants in seeding order
+	rounds  []*Round
+	mu      sync.RWMutex
+}
+
+// Round is one round of pairings
+type Round struct {
+	Number   int        // From 1
+	Pairings []*Pairing // Matches, then any bye
+}
+
+// Pairing is a match between two players, or a bye
+type Pairing struct {
+	Round      int
+	PlayerID   string       // Moves first in the first game
+	OpponentID string       // "" for a bye
+	Series     *game.Series // Games of the match (nil for a bye)
+}
+
+// New creates a tournament for the given players, listed in seeding order
+func New(id string, players []*game.Player, cfg Config) (*Tournament, error) {
+	if cfg.BestOf == 0 {
+		cfg.BestOf = 1
+	}
+	switch {
+	case cfg.Format.String() == "UNKNOWN":
+		return nil, fmt.Errorf("%w: unknown format %d", ErrInvalidConfig, cfg.Format)
+	case cfg.Format == Swiss && cfg.Rounds < 1:
+		return nil, fmt.Errorf("%w: a Swiss tournament needs at least one round, got %d", ErrInvalidConfig, cfg.Rounds)
+	case cfg.BestOf < 0:
+		return nil, fmt.Errorf("%w: best of %d games", ErrInvalidConfig, cfg.BestOf)
+	case cfg.Rules.Teams != nil || cfg.Rules.Solo != nil:
+		return nil, fmt.Errorf("%w: pairings are between two players", ErrInvalidConfig)
+	case len(players) < 2:
+		return nil, fmt.Errorf("%w: %d players (minimum 2)", ErrInvalidConfig, len(players))
+	}
+	if err := cfg.Rules.Validate(); err != nil {
+		return nil, err
+	}
+
+	t := &Tournament{ID: id, config: cfg}
+	seen := make(map[string]bool, len(players))
+	for _, p := range players {
+		// An empty ID marks a bye, so every entrant needs one
+		if p == nil || p.ID == "" {
+			return nil, fmt.Errorf("%w: every player needs an ID", ErrInvalidConfig)
+		}
+		if seen[p.ID] {
+			return nil, fmt.Errorf("%w: player %s entered twice", ErrInvalidConfig, p.ID)
+		}
+		seen[p.ID] = true
+		t.players = append(t.players, game.NewPlayer(p.ID, p.Name))
+	}
+	return t, nil
+}
+
+// TotalRounds returns the number of rounds the tournament will play
+func (t *Tournament) TotalRounds() int {
+	if t.config.Format == Swiss {
+		return t.config.Rounds
+	}
+	n := len(t.players)
+	if n%2 == 1 {
+		return n
+	}
+	return n - 1
+}
+
+// NextRound pairs the next round and creates its series, whose games the
+// caller then plays
+// Returns ErrRoundInProgress until every match of the current round is over
+// and ErrTournamentOver after the last round.
+func (t *Tournament) NextRound() (*Round, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if n := len(t.rounds); n > 0 && !t.rounds[n-1].Done() {
+		return nil, ErrRoundInProgress
+	}
+	if len(t.rounds) >= t.TotalRounds() {
+		return nil, ErrTournamentOver
+	}
+
+	number := len(t.rounds) + 1
+	var pairs [][2]string
+	if t.config.Format == Swiss {
+		pairs = t.swissPairs()
+	} else {
+		pairs = roundRobinPairs(t.playerIDs(), number-1)
+	}
+
+	round := &Round{Number: number}
+	for i, pair := range pairs {
+		p := &Pairing{Round: number, PlayerID: pair[0], OpponentID: pair[1]}
+		if !p.IsBye() {
+			series, err := game.NewSeries(fmt.Sprintf("%s-r%d-%d", t.ID, number, i+1),
+				[]*game.Player{t.player(pair[0]), t.player(pair[1])}, t.config.Rules, t.config.BestOf)
+			if err != nil {
+				return nil, err
+			}
+			p.Series = series
+		}
+		round.Pairings = append(round.Pairings, p)
+	}
+	// Byes go last
+	sort.SliceStable(round.Pairings, func(i, j int) bool {
+		return !round.Pairings[i].IsBye() && round.Pairings[j].IsBye()
+	})
+	t.rounds = append(t.rounds, round)
+	return round, nil
+}
+
+// Rounds returns the rounds paired so far
+func (t *Tournament) Rounds() []*Round {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return append([]*Round(nil), t.rounds...)
+}
+
+// Over returns true once the last round has been played
+func (t *Tournament) Over() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	n := len(t.rounds)
+	return n == t.TotalRounds() && t.rounds[n-1].Done()
+}
+
+// playerIDs returns the entrants' IDs in seeding order
+func (t *Tournament) playerIDs() []string {
+	ids := make([]string, len(t.players))
+	for i, p := range t.players {
+		ids[i] = p.ID
+	}
+	return ids
+}
+
+// player returns the entrant with the given ID
+func (t *Tournament) player(id string) *game.Player {
+	for _, p := range t.players {
+		if p.ID == id {
+			return p
+		}
+	}
+	return nil
+}
+
+// Done returns true once every match in the round is over
+func (r *Round) Done() bool {
+	for _, p := range r.Pairings {
+		if !p.Done() {
+			return false
+		}
+	}
+	return true
+}
+
+// IsBye returns true if the pairing is a bye
+func (p *Pairing) IsBye() bool {
+	return p.OpponentID == ""
+}
+
+// Done returns true once the match is over; byes are over at once
+func (p *Pairing) Done() bool {
+	return p.IsBye() || p.Series.Over()
+}
+
+// roundRobinPairs returns round r (from 0) of a round robin by the circle
+// method: the first player stays put while the rest rotate, and a missing
+// opponent ("") in an odd field is a bye
+func roundRobinPairs(ids []string, r int) [][2]string {
+	if len(ids)%2 == 1 {
+		ids = append(ids, "")
+	}
+	n := len(ids)
+	circle := make([]string, n)
+	circle[0] = ids[0]
+	for i := 1; i < n; i++ {
+		circle[i] = ids[1+(i-1+r)%(n-1)]
+	}
+
+	pairs := make([][2]string, 0, n/2)
+	for i := 0; i < n/2; i++ {
+		a, b := circle[i], circle[n-1-i]
+		// Alternate who moves first, and keep the bye in second place
+		if (r+i)%2 == 1 && a != "" && b != "" || a == "" {
+			a, b = b, a
+		}
+		pairs = append(pairs, [2]string{a, b})
+	}
+	return pairs
+}
@@ -0,0 +1,148 @@
+package tournament
+
+import (
+	"errors"
+	"testing"
+
+	"scrabbled/internal/game"
+)
+
+// entrants creates players p1, p2, ... named after their IDs
+func entrants(n int) []*game.Player {
+	players := make([]*game.Player, n)
+	for i := range players {
+		id := string(rune('1' + i))
+		players[i] = game.NewPlayer("p"+id, "Player "+id)
+	}
+	return players
+}
+
+// playRound plays every match of a round, each game scored by the players'
+// strengths
+func playRound(t *testing.T, r *Round, strength map[string]int) {
+	t.Helper()
+
+	for _, p := range r.Pairings {
+		if p.IsBye() {
+			continue
+		}
+		for !p.Series.Over() {
+			g, err := p.Series.NextGame()
+			if err != nil {
+				t.Fatalf("NextGame failed: %v", err)
+			}
+			if err := g.StartGame(); err != nil {
+				t.Fatalf("StartGame failed: %v", err)
+			}
+			for _, player := range g.Players {
+				player.Score = strength[player.ID]
+			}
+			if err := g.EndGame(); err != nil {
+				t.Fatalf("EndGame failed: %v", err)
+			}
+		}
+	}
+}
+
+// TestNewValidation tests rejecting unplayable configurations
+func TestNewValidation(t *testing.T) {
+	teams := DefaultConfig()
+	teams.Rules.Teams = &game.TeamRules{}
+	swiss := DefaultConfig()
+	swiss.Format = Swiss
+	unknown := DefaultConfig()
+	unknown.Format = Format(9)
+
+	tests := []struct {
+		name    string
+		players []*game.Player
+		cfg     Config
+	}{
+		{"one player", entrants(1), DefaultConfig()},
+		{"duplicate player", append(entrants(2), game.NewPlayer("p1", "Again")), DefaultConfig()},
+		{"player without ID", append(entrants(2), game.NewPlayer("", "Anonymous")), DefaultConfig()},
+		{"nil player", append(entrants(2), nil), DefaultConfig()},
+		{"team rules", entrants(4), teams},
+		{"Swiss without rounds", entrants(4), swiss},
+		{"unknown format", entrants(4), unknown},
+	}
+	for _, tt := range tests {
+		if _, err := New("t", tt.players, tt.cfg); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("%s: expected ErrInvalidConfig, got %v", tt.name, err)
+		}
+	}
+}
+
+// TestRoundRobin tests that everyone meets everyone once
+func TestRoundRobin(t *testing.T) {
+	for _, n := range []int{4, 5} {
+		tour, err := New("rr", entrants(n), DefaultConfig())
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		want := n - 1
+		if n%2 == 1 {
+			want = n
+		}
+		if tour.TotalRounds() != want {
+			t.Errorf("%d players: expected %d rounds, got %d", n, want, tour.TotalRounds())
+		}
+
+		met := make(map[[2]string]int)
+		byes := make(map[string]int)
+		for !tour.Over() {
+			r, err := tour.NextRound()
+			if err != nil {
+				t.Fatalf("NextRound failed: %v", err)
+			}
+			if _, err := tour.NextRound(); !errors.Is(err, ErrRoundInProgress) {
+				t.Errorf("Expected ErrRoundInProgress, got %v", err)
+			}
+			for _, p := range r.Pairings {
+				if p.IsBye() {
+					byes[p.PlayerID]++
+				} else {
+					met[meeting(p.PlayerID, p.OpponentID)]++
+				}
+			}
+			playRound(t, r, nil)
+		}
+
+		if len(met) != n*(n-1)/2 {
+			t.Errorf("%d players: expected %d pairings, got %d", n, n*(n-1)/2, len(met))
+		}
+		for pair, count := range met {
+			if count != 1 {
+				t.Errorf("%v should meet once, met %d times", pair, count)
+			}
+		}
+		if n%2 == 1 && len(byes) != n {
+			t.Errorf("Each player should have one bye, got %v", byes)
+		}
+		if _, err := tour.NextRound(); !errors.Is(err, ErrTournamentOver) {
+			t.Errorf("Expected ErrTournamentOver, got %v", err)
+		}
+	}
+}
+
+// TestRoundRobinBestOf tests that each pairing plays a match
+func TestRoundRobinBestOf(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.BestOf = 3
+	tour, _ := New("bo3", entrants(2), cfg)
+
+	r, err := tour.NextRound()
+	if err != nil {
+		t.Fatalf("NextRound failed: %v", err)
+	}
+	playRound(t, r, map[string]int{"p1": 400, "p2": 300})
+
+	games := r.Pairings[0].Series.Games()
+	if len(games) != 2 || games[0].ID != "bo3-r1-1-1" {
+		t.Fatalf("The match should end after p1 wins two games, got %d games", len(games))
+	}
+	results := tour.Results()
+	if len(results) != 1 || results[0].Winner != "p1" || results[0].Wins != 2 || results[0].Spread != 200 {
+		t.Errorf("Unexpected result %+v", results)
+	}
+}